@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"job-scheduler/internal/models"
+)
+
+func newApplyCommand(opts *options) *cobra.Command {
+	var (
+		file   string
+		dryRun bool
+		diff   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "apply -f <file>",
+		Short: "Create and update jobs from a YAML or JSON job manifest",
+		Long: "Create the jobs of a manifest that don't exist and make those that do match it, matching jobs\n" +
+			"by team and name. Jobs missing from the manifest are left alone. Use - to read the manifest from stdin.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifest, err := readManifest(cmd.InOrStdin(), file)
+			if err != nil {
+				return err
+			}
+
+			result, err := opts.client().ImportJobs(cmd.Context(), manifest, dryRun, diff)
+			if result != nil {
+				if opts.asJSON() {
+					if writeErr := writeJSON(cmd.OutOrStdout(), result); writeErr != nil {
+						return writeErr
+					}
+				} else if writeErr := writeImportResult(cmd.OutOrStdout(), result); writeErr != nil {
+					return writeErr
+				}
+			}
+			return err
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Job manifest to apply, or - for stdin")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would change without changing anything")
+	cmd.Flags().BoolVar(&diff, "diff", false, "List the settings each update changes")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func newExportCommand(opts *options) *cobra.Command {
+	var (
+		format string
+		team   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write job definitions as a YAML or JSON job manifest",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !models.IsValidJobManifestFormat(format) {
+				return fmt.Errorf("--format must be yaml or json")
+			}
+			var teamFilter *string
+			if cmd.Flags().Changed("team") {
+				teamFilter = &team
+			}
+
+			manifest, err := opts.client().ExportJobs(cmd.Context(), format, teamFilter)
+			if err != nil {
+				return err
+			}
+			_, err = cmd.OutOrStdout().Write(manifest)
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", models.JobManifestFormatYAML, "Manifest format: yaml or json")
+	cmd.Flags().StringVar(&team, "team", "", "Only export this team's jobs")
+	return cmd
+}
+
+// readManifest reads a job manifest from a file, or from stdin when the file is -
+func readManifest(stdin io.Reader, file string) ([]byte, error) {
+	if file == "-" {
+		return io.ReadAll(stdin)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job manifest: %w", err)
+	}
+	return data, nil
+}
+
+// writeImportResult writes what an import did, or would do, to each job
+func writeImportResult(w io.Writer, result *models.JobImportResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TEAM\tNAME\tACTION\tDETAILS")
+	for _, item := range result.Jobs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", orDash(item.Team), item.Name, item.Action, item.Error)
+		for _, change := range item.Changes {
+			fmt.Fprintf(tw, "\t\t\t%s: %v -> %v\n", change.Field, change.From, change.To)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	prefix := ""
+	if result.DryRun {
+		prefix = "Dry run: "
+	}
+	_, err := fmt.Fprintf(w, "\n%s%d created, %d updated, %d unchanged, %d pending approval, %d failed\n",
+		prefix, result.Created, result.Updated, result.Unchanged, result.PendingApproval, result.Failed)
+	return err
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+)
+
+func newJobsCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jobs",
+		Short: "List, create, run, pause and resume jobs",
+	}
+	cmd.AddCommand(
+		newJobsListCommand(opts),
+		newJobsGetCommand(opts),
+		newJobsCreateCommand(opts),
+		newJobsTriggerCommand(opts),
+		newJobsPauseCommand(opts),
+		newJobsResumeCommand(opts),
+	)
+	return cmd
+}
+
+func newJobsListCommand(opts *options) *cobra.Command {
+	var page, limit int
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List jobs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list, err := opts.client().ListJobs(cmd.Context(), page, limit)
+			if err != nil {
+				return err
+			}
+			if opts.asJSON() {
+				return writeJSON(cmd.OutOrStdout(), list)
+			}
+			if err := writeJobTable(cmd.OutOrStdout(), list.Jobs); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "\nPage %d of %d (%d jobs)\n", list.Page, list.TotalPages, list.TotalCount)
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&page, "page", 1, "Page to list, from 1")
+	cmd.Flags().IntVar(&limit, "limit", 20, "Jobs per page")
+	return cmd
+}
+
+func newJobsGetCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <job-id>",
+		Short: "Show a job",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			job, err := opts.client().GetJob(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+			if opts.asJSON() {
+				return writeJSON(cmd.OutOrStdout(), job)
+			}
+			return writeJobTable(cmd.OutOrStdout(), []dto.JobResponse{*job})
+		},
+	}
+}
+
+func newJobsCreateCommand(opts *options) *cobra.Command {
+	var (
+		req      models.CreateJobRequest
+		jobType  string
+		config   string
+		inactive bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a job",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req.JobType = models.JobType(jobType)
+			if config != "" {
+				if err := json.Unmarshal([]byte(config), &req.Config); err != nil {
+					return fmt.Errorf("--config must be a JSON object: %w", err)
+				}
+			}
+			if inactive {
+				active := false
+				req.IsActive = &active
+			}
+
+			job, err := opts.client().CreateJob(cmd.Context(), &req)
+			if err != nil {
+				return err
+			}
+			if opts.asJSON() {
+				return writeJSON(cmd.OutOrStdout(), job)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Created job %s (%s)\n", job.Name, job.ID)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&req.Name, "name", "", "Job name")
+	cmd.Flags().StringVar(&req.Description, "description", "", "Job description")
+	cmd.Flags().StringVar(&req.Schedule, "schedule", "", "Cron expression, or interval for interval jobs")
+	cmd.Flags().StringVar(&jobType, "type", "", "Job type, e.g. health_check or data_processing")
+	cmd.Flags().StringVar(&config, "config", "", "Job config, as a JSON object")
+	cmd.Flags().StringVar(&req.Team, "team", "", "Team owning the job")
+	cmd.Flags().StringVar(&req.Owner, "owner", "", "Owner of the job, the requesting user by default")
+	cmd.Flags().BoolVar(&inactive, "inactive", false, "Create the job without scheduling it")
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("type")
+	return cmd
+}
+
+func newJobsTriggerCommand(opts *options) *cobra.Command {
+	var params string
+
+	cmd := &cobra.Command{
+		Use:   "trigger <job-id>",
+		Short: "Run a job now, outside its schedule",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			var runParams models.JobConfig
+			if params != "" {
+				if err := json.Unmarshal([]byte(params), &runParams); err != nil {
+					return fmt.Errorf("--params must be a JSON object: %w", err)
+				}
+			}
+
+			if err := opts.client().TriggerJob(cmd.Context(), id, runParams); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Triggered job %s\n", id)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&params, "params", "", "Parameters passed to the run, as a JSON object")
+	return cmd
+}
+
+func newJobsPauseCommand(opts *options) *cobra.Command {
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:   "pause <job-id>",
+		Short: "Pause a job",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			job, err := opts.client().PauseJob(cmd.Context(), id, reason)
+			if err != nil {
+				return err
+			}
+			if opts.asJSON() {
+				return writeJSON(cmd.OutOrStdout(), job)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Paused job %s (%s)\n", job.Name, job.ID)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&reason, "reason", "", "Why the job is paused")
+	_ = cmd.MarkFlagRequired("reason")
+	return cmd
+}
+
+func newJobsResumeCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume <job-id>",
+		Short: "Resume a paused job",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			job, err := opts.client().ResumeJob(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+			if opts.asJSON() {
+				return writeJSON(cmd.OutOrStdout(), job)
+			}
+			if job.PausedAt != nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "Resuming job %s (%s) is waiting for a second approver\n", job.Name, job.ID)
+				return nil
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Resumed job %s (%s)\n", job.Name, job.ID)
+			return nil
+		},
+	}
+}
+
+// parseID parses a job or run ID argument
+func parseID(arg string) (uuid.UUID, error) {
+	id, err := uuid.Parse(arg)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid ID %q", arg)
+	}
+	return id, nil
+}
+
+// writeJobTable writes jobs as a table
+func writeJobTable(w io.Writer, jobs []dto.JobResponse) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tTEAM\tTYPE\tSCHEDULE\tSTATE\tNEXT RUN")
+	for _, job := range jobs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			job.ID, job.Name, orDash(job.Team), job.JobType, orDash(job.Schedule), job.State, formatTime(job.NextRunAt))
+	}
+	return tw.Flush()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format(time.RFC3339)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
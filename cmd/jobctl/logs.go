@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"job-scheduler/internal/dto"
+)
+
+// logPollInterval is how often followed runs are polled for new lines
+const logPollInterval = 2 * time.Second
+
+func newLogsCommand(opts *options) *cobra.Command {
+	var follow bool
+
+	cmd := &cobra.Command{
+		Use:   "logs <execution-id>",
+		Short: "Print the lines a run logged",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			return opts.client().FollowExecutionLogs(cmd.Context(), id, follow, logPollInterval, func(line dto.ExecutionLogLineResponse) {
+				if opts.asJSON() {
+					_ = writeJSON(out, line)
+					return
+				}
+				fmt.Fprintln(out, formatLogLine(line))
+			})
+		},
+	}
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep printing new lines until the run finishes")
+	return cmd
+}
+
+// formatLogLine writes a log line as its time, level, message and fields, ordered by name
+func formatLogLine(line dto.ExecutionLogLineResponse) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %-5s %s", line.LoggedAt.Local().Format(time.RFC3339), strings.ToUpper(line.Level), line.Message)
+
+	keys := make([]string, 0, len(line.Fields))
+	for key := range line.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&b, " %s=%v", key, line.Fields[key])
+	}
+	return b.String()
+}
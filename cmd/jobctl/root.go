@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"job-scheduler/internal/apiclient"
+)
+
+// defaultServer is the scheduler jobctl talks to when neither --server nor JOBCTL_SERVER is set
+const defaultServer = "http://localhost:8080"
+
+// options are the flags shared by every command
+type options struct {
+	server string
+	user   string
+	token  string
+	output string
+}
+
+// client returns a client of the configured server
+func (o *options) client() *apiclient.Client {
+	client := apiclient.New(o.server, o.user)
+	client.SetToken(o.token)
+	return client
+}
+
+// asJSON reports whether output should be written as JSON rather than a table
+func (o *options) asJSON() bool {
+	return o.output == "json"
+}
+
+func newRootCommand() *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:           "jobctl",
+		Short:         "Manage the job scheduler through its REST API",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.output != "table" && opts.output != "json" {
+				return fmt.Errorf("--output must be table or json")
+			}
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&opts.server, "server", envOr("JOBCTL_SERVER", defaultServer), "Scheduler URL (env JOBCTL_SERVER)")
+	cmd.PersistentFlags().StringVar(&opts.user, "user", os.Getenv("JOBCTL_USER"), "User to act as, sent in the X-User header (env JOBCTL_USER)")
+	cmd.PersistentFlags().StringVar(&opts.token, "token", os.Getenv("JOBCTL_TOKEN"), "Bearer token authenticating the user when access control is on (env JOBCTL_TOKEN)")
+	cmd.PersistentFlags().StringVarP(&opts.output, "output", "o", "table", "Output format: table or json")
+
+	cmd.AddCommand(
+		newJobsCommand(opts),
+		newLogsCommand(opts),
+		newStatsCommand(opts),
+		newApplyCommand(opts),
+		newExportCommand(opts),
+		newTokenCommand(),
+	)
+	return cmd
+}
+
+// envOr returns an environment variable, or fallback when it is unset
+func envOr(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
+
+// writeJSON writes a value as indented JSON
+func writeJSON(w io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
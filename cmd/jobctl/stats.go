@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+func newStatsCommand(opts *options) *cobra.Command {
+	var jobID string
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Summarize the runs of all jobs, or of one job",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			if jobID == "" {
+				stats, err := opts.client().OverallStats(cmd.Context())
+				if err != nil {
+					return err
+				}
+				if opts.asJSON() {
+					return writeJSON(out, stats)
+				}
+				return writeStats(out, [][2]string{
+					{"Jobs", fmt.Sprint(stats.TotalJobs)},
+					{"Runs", fmt.Sprint(stats.TotalExecutions)},
+					{"Succeeded", fmt.Sprint(stats.SuccessfulExecutions)},
+					{"Failed", fmt.Sprint(stats.FailedExecutions)},
+					{"Failed in last 24h", fmt.Sprint(stats.FailuresLast24h)},
+					{"Success rate", fmt.Sprintf("%.1f%%", stats.SuccessRate)},
+					{"Average duration", formatMillis(stats.AverageExecutionTime)},
+				})
+			}
+
+			id, err := parseID(jobID)
+			if err != nil {
+				return err
+			}
+			stats, err := opts.client().JobStats(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+			if opts.asJSON() {
+				return writeJSON(out, stats)
+			}
+			return writeStats(out, [][2]string{
+				{"Runs", fmt.Sprint(stats.TotalExecutions)},
+				{"Succeeded", fmt.Sprint(stats.SuccessfulExecutions)},
+				{"Failed", fmt.Sprint(stats.FailedExecutions)},
+				{"Success rate", fmt.Sprintf("%.1f%%", stats.SuccessRate)},
+				{"Average duration", formatMillis(stats.AverageExecutionTime)},
+			})
+		},
+	}
+	cmd.Flags().StringVar(&jobID, "job", "", "Only summarize this job's runs")
+	return cmd
+}
+
+// writeStats writes named values as two aligned columns
+func writeStats(w io.Writer, rows [][2]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintf(tw, "%s:\t%s\n", row[0], row[1])
+	}
+	return tw.Flush()
+}
+
+func formatMillis(ms *int64) string {
+	if ms == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%dms", *ms)
+}
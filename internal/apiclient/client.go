@@ -0,0 +1,309 @@
+// Package apiclient is a client of the scheduler's REST API, used by the jobctl command
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+)
+
+// actorHeader identifies the user making a request, as handlers.ActorHeader does
+const actorHeader = "X-User"
+
+// defaultTimeout bounds each request
+const defaultTimeout = 30 * time.Second
+
+// Error is an error response of the API
+type Error struct {
+	StatusCode int
+	Message    string `json:"error"`
+	Details    string `json:"details"`
+	// Import is the result of an import rejected because the manifest has invalid jobs
+	Import *models.JobImportResult `json:"import"`
+}
+
+func (e *Error) Error() string {
+	if e.Details != "" {
+		return fmt.Sprintf("%s (%d): %s", e.Message, e.StatusCode, e.Details)
+	}
+	return fmt.Sprintf("%s (%d)", e.Message, e.StatusCode)
+}
+
+// Client calls the scheduler's v1 API as a user
+type Client struct {
+	baseURL    string
+	user       string
+	token      string
+	httpClient *http.Client
+}
+
+// New creates a client of the API served at baseURL, e.g. http://localhost:8080
+// Requests are attributed to user; with access control on, set a token to prove who is asking
+func New(baseURL, user string) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/") + "/api/v1",
+		user:       user,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+// SetToken sets the bearer token authenticating the client's requests
+func (c *Client) SetToken(token string) {
+	c.token = token
+}
+
+// ListJobs returns a page of jobs
+func (c *Client) ListJobs(ctx context.Context, page, limit int) (*dto.JobListResponse, error) {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+
+	var resp dto.JobListResponse
+	if err := c.do(ctx, http.MethodGet, "/jobs?"+query.Encode(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetJob returns a job
+func (c *Client) GetJob(ctx context.Context, id uuid.UUID) (*dto.JobResponse, error) {
+	var resp struct {
+		Job dto.JobResponse `json:"job"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/jobs/"+id.String(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Job, nil
+}
+
+// CreateJob creates a job
+func (c *Client) CreateJob(ctx context.Context, req *models.CreateJobRequest) (*dto.JobResponse, error) {
+	var resp struct {
+		Job dto.JobResponse `json:"job"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/jobs", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Job, nil
+}
+
+// PauseJob pauses a job, recording why
+func (c *Client) PauseJob(ctx context.Context, id uuid.UUID, reason string) (*dto.JobResponse, error) {
+	var resp struct {
+		Job dto.JobResponse `json:"job"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/jobs/"+id.String()+"/pause", &models.PauseJobRequest{Reason: reason}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Job, nil
+}
+
+// ResumeJob resumes a paused job
+// Resuming a protected job waits for a second approver, in which case the job is returned unchanged
+func (c *Client) ResumeJob(ctx context.Context, id uuid.UUID) (*dto.JobResponse, error) {
+	var resp struct {
+		Job dto.JobResponse `json:"job"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/jobs/"+id.String()+"/resume", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Job, nil
+}
+
+// TriggerJob starts a run of a job now, passing it params
+func (c *Client) TriggerJob(ctx context.Context, id uuid.UUID, params models.JobConfig) error {
+	return c.do(ctx, http.MethodPost, "/jobs/"+id.String()+"/trigger", &models.TriggerJobRequest{Params: params}, nil)
+}
+
+// ExecutionLogPage is a page of the lines a run logged
+type ExecutionLogPage struct {
+	ExecutionID uuid.UUID                      `json:"execution_id"`
+	Lines       []dto.ExecutionLogLineResponse `json:"lines"`
+	NextAfter   int                            `json:"next_after"`
+	Finished    bool                           `json:"finished"`
+}
+
+// ExecutionLogs returns up to limit lines a run logged after the line numbered after
+func (c *Client) ExecutionLogs(ctx context.Context, executionID uuid.UUID, after, limit int) (*ExecutionLogPage, error) {
+	query := url.Values{}
+	query.Set("after", strconv.Itoa(after))
+	query.Set("limit", strconv.Itoa(limit))
+
+	var page ExecutionLogPage
+	if err := c.do(ctx, http.MethodGet, "/executions/"+executionID.String()+"/logs?"+query.Encode(), nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// FollowExecutionLogs passes every line a run logged to fn, in order, polling every interval for new
+// lines until the run finishes or ctx is done. Without follow it stops at the last line logged so far
+func (c *Client) FollowExecutionLogs(ctx context.Context, executionID uuid.UUID, follow bool, interval time.Duration, fn func(dto.ExecutionLogLineResponse)) error {
+	const limit = 1000
+
+	after := 0
+	for {
+		page, err := c.ExecutionLogs(ctx, executionID, after, limit)
+		if err != nil {
+			return err
+		}
+		for _, line := range page.Lines {
+			fn(line)
+		}
+		after = page.NextAfter
+
+		// A full page may have more lines behind it
+		if len(page.Lines) == limit {
+			continue
+		}
+		if page.Finished || !follow {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// OverallStats summarizes the runs of all jobs
+func (c *Client) OverallStats(ctx context.Context) (*models.OverallExecutionStats, error) {
+	var resp struct {
+		Stats models.OverallExecutionStats `json:"stats"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/stats", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Stats, nil
+}
+
+// JobStats summarizes a job's runs
+func (c *Client) JobStats(ctx context.Context, id uuid.UUID) (*models.JobExecutionStats, error) {
+	var resp struct {
+		Stats models.JobExecutionStats `json:"stats"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/jobs/"+id.String()+"/stats", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Stats, nil
+}
+
+// ExportJobs returns the job manifest of every job, or of one team's jobs when team is given,
+// in the given format, yaml or json
+func (c *Client) ExportJobs(ctx context.Context, format string, team *string) ([]byte, error) {
+	query := url.Values{}
+	query.Set("format", format)
+	if team != nil {
+		query.Set("team", *team)
+	}
+
+	resp, err := c.send(ctx, http.MethodGet, "/jobs/export?"+query.Encode(), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// ImportJobs creates and updates jobs from a YAML or JSON job manifest
+// With dryRun nothing is changed; with diff updates list the settings they change. A manifest with
+// invalid jobs is rejected with an *Error, and the result saying which jobs are invalid is returned with it
+func (c *Client) ImportJobs(ctx context.Context, manifest []byte, dryRun, diff bool) (*models.JobImportResult, error) {
+	query := url.Values{}
+	query.Set("dry_run", strconv.FormatBool(dryRun))
+	query.Set("diff", strconv.FormatBool(diff))
+
+	resp, err := c.send(ctx, http.MethodPost, "/jobs/import?"+query.Encode(), "application/yaml", bytes.NewReader(manifest))
+	if err != nil {
+		if apiErr, ok := err.(*Error); ok && apiErr.Import != nil {
+			return apiErr.Import, err
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Import models.JobImportResult `json:"import"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &body.Import, nil
+}
+
+// do sends a request with an optional JSON body, decoding a JSON response into out if it isn't nil
+func (c *Client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	contentType := ""
+	if in != nil {
+		encoded, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+		contentType = "application/json"
+	}
+
+	resp, err := c.send(ctx, method, path, contentType, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// send sends a request, returning an error for responses that aren't successful
+// The caller must close the body of the response
+func (c *Client) send(ctx context.Context, method, path, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.user != "" {
+		req.Header.Set(actorHeader, c.user)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s %s: %w", method, path, err)
+	}
+	if resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	apiErr := &Error{}
+	if err := json.Unmarshal(data, apiErr); err != nil || apiErr.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+	apiErr.StatusCode = resp.StatusCode
+	return nil, apiErr
+}
@@ -0,0 +1,173 @@
+package apidocs
+
+import "job-scheduler/internal/models"
+
+// str, num and boolean describe a scalar config setting
+func str(description string) *Schema {
+	return &Schema{Type: "string", Description: description}
+}
+
+func num(description string) *Schema {
+	return &Schema{Type: "number", Description: description}
+}
+
+func boolean(description string) *Schema {
+	return &Schema{Type: "boolean", Description: description}
+}
+
+// object describes a config object, which may hold settings besides those listed
+func object(description string, properties map[string]*Schema, required ...string) *Schema {
+	return &Schema{Type: "object", Description: description, Properties: properties, Required: required, AdditionalProperties: true}
+}
+
+// oneOfStrings describes a string setting taking one of values
+func oneOfStrings(description string, values ...string) *Schema {
+	return &Schema{Type: "string", Description: description, Enum: values}
+}
+
+// list describes a list setting
+func list(items *Schema, description string) *Schema {
+	return &Schema{Type: "array", Items: items, Description: description}
+}
+
+// jobConfigComponents returns the schemas of each built-in job type's config, named after the
+// job type, and JobConfig, the settings every job type shares
+func jobConfigComponents() map[string]*Schema {
+	healthProbe := map[string]*Schema{
+		"name":                 str("Name of the probe in the run's output, its target by default"),
+		"type":                 oneOfStrings("Kind of probe, http by default", "http", "tcp", "dns"),
+		"url":                  str("URL an http probe requests"),
+		"address":              str("host:port a tcp probe connects to"),
+		"host":                 str("Host a dns probe resolves"),
+		"method":               str("HTTP method, GET by default"),
+		"headers":              {Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+		"body":                 str("Request body"),
+		"expected_status":      {Type: "integer", Description: "Status code to expect, 200 by default"},
+		"body_regex":           str("Regular expression the response body must match"),
+		"insecure_skip_verify": boolean("Skip TLS certificate verification"),
+		"tls_server_name":      str("Name to verify the certificate against"),
+		"expected_address":     str("Address a dns probe's host must resolve to"),
+		"max_latency_ms":       num("Fail the probe when it takes longer than this"),
+		"timeout_seconds":      num("Time limit of the probe"),
+	}
+	healthCheck := make(map[string]*Schema, len(healthProbe)+2)
+	for key, value := range healthProbe {
+		healthCheck[key] = value
+	}
+	healthCheck["urls"] = list(&Schema{Type: "string"}, "URLs to request, each an http probe")
+	healthCheck["probes"] = list(object("", healthProbe), "Probes to run; top-level settings apply to every probe that doesn't set its own")
+
+	maintenanceOperation := object("", map[string]*Schema{
+		"type":            oneOfStrings("Operation to run", "vacuum", "analyze", "rotate_partitions", "sql"),
+		"table":           str("Table to work on"),
+		"tables":          list(&Schema{Type: "string"}, "Tables to vacuum or analyze, all of them when neither table nor tables is set"),
+		"full":            boolean("Run VACUUM FULL"),
+		"analyze":         boolean("Also analyze vacuumed tables, true by default"),
+		"interval":        oneOfStrings("Partition interval", "day", "week", "month"),
+		"premake":         {Type: "integer", Description: "Partitions to create ahead, 2 by default"},
+		"retain":          {Type: "integer", Description: "Past partitions to keep; older ones are dropped"},
+		"statement":       str("Name of an allowed statement from DB_MAINTENANCE_STATEMENTS"),
+		"timeout_seconds": num("Time limit of the operation"),
+	}, "type")
+
+	pipelineFormat := oneOfStrings("File format, from the path's extension by default", "csv", "json")
+	pipelineSource := object("Where a pipeline reads its rows", map[string]*Schema{
+		"type":   oneOfStrings("Kind of source", "sql", "http", "file"),
+		"query":  str("SQL query of an sql source, run in a read-only transaction"),
+		"url":    str("URL of an http source, returning a JSON array of objects"),
+		"field":  str("Field of an http source's response holding the rows"),
+		"path":   str("Path of a file source within DATA_PROCESSING_DIR"),
+		"format": pipelineFormat,
+	}, "type")
+	pipelineStep := object("", map[string]*Schema{
+		"type":        oneOfStrings("Kind of step", "map", "filter", "select"),
+		"fields":      {Description: "Fields a map step sets to templates rendered with each row, or the fields a select step keeps"},
+		"condition":   str("Template of a filter step; rows where it renders as true are kept"),
+		"expression":  str("CEL expression of a filter step over row, instead of a condition; rows where it is true are kept"),
+		"expressions": object("CEL expressions over row a map step sets fields to, by field", nil),
+	}, "type")
+	pipelineSink := object("Where a pipeline writes its rows", map[string]*Schema{
+		"type":    oneOfStrings("Kind of sink", "table", "file", "artifact"),
+		"table":   str("Table a table sink inserts into"),
+		"replace": boolean("Delete the table's rows before inserting"),
+		"path":    str("Path of a file sink within DATA_PROCESSING_DIR"),
+		"name":    str("Name of an artifact sink's file, the job's name by default"),
+		"format":  pipelineFormat,
+	}, "type")
+
+	return map[string]*Schema{
+		"JobConfig": {
+			Type:        "object",
+			Description: "Job type specific settings, described by the job type's config schema, and these settings shared by every job type",
+			AnyOf: []*Schema{
+				ref(configComponent(models.JobTypeEmailNotification)),
+				ref(configComponent(models.JobTypeDataProcessing)),
+				ref(configComponent(models.JobTypeReportGeneration)),
+				ref(configComponent(models.JobTypeHealthCheck)),
+				ref(configComponent(models.JobTypeDBMaintenance)),
+			},
+			Properties: map[string]*Schema{
+				"params":                           object("Parameters of a triggered run", nil),
+				"call_budget":                      object("Limits the outbound calls a run makes, e.g. {\"max_calls\": 1000, \"window\": \"1m\", \"on_exceeded\": \"pause\"}", nil),
+				"history_retention_days":           num("Days to keep run history, 0 keeping it forever"),
+				"artifact_retention_days":          num("Days to keep run artifacts, 0 keeping them forever"),
+				"alert_after_failures":             num("Consecutive failures before alerting"),
+				"alert_duration_threshold_seconds": num("Alert when a run takes longer than this"),
+				"success_criteria":                 str("CEL expression over output, duration_ms and attempt a run returning without error must meet to succeed"),
+				"notification_filter":              str("CEL expression over event, job, execution and fields deciding whether a notification is delivered"),
+			},
+			AdditionalProperties: true,
+		},
+		configComponent(models.JobTypeEmailNotification): object("Config of email_notification jobs", map[string]*Schema{
+			"recipient":            str("Address to send to"),
+			"subject":              str("Subject line, a template rendered with each recipient's fields in batch emails"),
+			"body":                 str("Message body, a template rendered with each recipient's fields in batch emails"),
+			"recipients":           list(&Schema{AnyOf: []*Schema{{Type: "string"}, object("", nil)}}, "Recipients of a batch email, each an address or an object of the address and its fields"),
+			"recipients_query":     str("SQL query whose rows are the recipients of a batch email, run on the registered database named by connection"),
+			"connection":           str("Registered database connection recipients_query runs on"),
+			"recipients_artifact":  str("ID of a CSV artifact, with a header row, whose rows are the recipients of a batch email"),
+			"recipient_field":      str("Field holding each batch recipient's address, email by default"),
+			"send_concurrency":     num("Emails of a batch sent at once, 1 by default and at most 20"),
+			"send_rate_per_second": num("Most emails of a batch sent per second, unlimited by default"),
+		}),
+		configComponent(models.JobTypeDataProcessing): object("Config of data_processing jobs; jobs without a source simulate processing", map[string]*Schema{
+			"source":                  pipelineSource,
+			"steps":                   list(pipelineStep, "Steps transforming the source's rows, in order"),
+			"sink":                    pipelineSink,
+			"connection":              str("Registered database connection sql sources and table sinks use, the service's own database by default"),
+			"processing_time_seconds": num("How long simulated processing takes"),
+			"data_size":               str("Amount of data to simulate processing, e.g. 1MB"),
+			"operation":               str("Simulated operation, transform by default"),
+		}),
+		configComponent(models.JobTypeReportGeneration): object("Config of report_generation jobs", map[string]*Schema{
+			"report_type":     str("Name of the report"),
+			"report_template": str("Name of a stored report template to build the report from"),
+			"format":          oneOfStrings("Report file format", models.ReportFormatText, models.ReportFormatCSV, models.ReportFormatJSON, models.ReportFormatXLSX, models.ReportFormatPDF),
+			"include_charts":  boolean("Include charts in the report"),
+			"query":           str("SQL query whose rows fill the report, run on the registered database named by connection"),
+			"connection":      str("Registered database connection the query, or the report template's queries, run on"),
+			"source_url":      str("URL returning the report's rows as JSON"),
+			"source_field":    str("Field of the source_url response holding the rows"),
+			"columns":         list(&Schema{Type: "string"}, "Columns of the report, in order"),
+		}),
+		configComponent(models.JobTypeHealthCheck): object("Config of health_check jobs", healthCheck),
+		configComponent(models.JobTypeDBMaintenance): object("Config of db_maintenance jobs", map[string]*Schema{
+			"connection": str("Registered database connection to work on, the service's own database by default"),
+			"operations": list(maintenanceOperation, "Operations to run in order"),
+		}, "operations"),
+	}
+}
+
+// jobConfigNames names the config schema of each built-in job type
+var jobConfigNames = map[models.JobType]string{
+	models.JobTypeEmailNotification: "EmailNotificationConfig",
+	models.JobTypeDataProcessing:    "DataProcessingConfig",
+	models.JobTypeReportGeneration:  "ReportGenerationConfig",
+	models.JobTypeHealthCheck:       "HealthCheckConfig",
+	models.JobTypeDBMaintenance:     "DBMaintenanceConfig",
+}
+
+// configComponent returns the name of a job type's config schema
+func configComponent(jobType models.JobType) string {
+	return jobConfigNames[jobType]
+}
@@ -0,0 +1,259 @@
+// Package apidocs generates the OpenAPI description of the scheduler's API
+// Every registered route is described from the router, so the document can't miss a route. The
+// request and response bodies of the main routes, and the config of each job type, are described
+// from the types the handlers use
+package apidocs
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"job-scheduler/internal/dto"
+)
+
+// Document is an OpenAPI 3.0 document
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+	Security   []map[string][]string            `json:"security,omitempty"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Operation describes a route
+type Operation struct {
+	Tags        []string             `json:"tags,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	OperationID string               `json:"operationId"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+	// Security is empty for routes reached without a user, and unset for routes taking the default
+	Security *[]map[string][]string `json:"security,omitempty"`
+	// RequiredRole is the least role allowed to call the route when access control is enabled
+	RequiredRole string `json:"x-required-role,omitempty"`
+}
+
+// Parameter describes a path or query parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes the body a route takes
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes a response of a route
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a body
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the schemas and security schemes operations refer to
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme describes how requests identify their user
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Scheme      string `json:"scheme,omitempty"`
+	In          string `json:"in,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Options describe the API being documented
+type Options struct {
+	Title   string
+	Version string
+	// BearerAuth documents that requests authenticate their user with a bearer token
+	BearerAuth bool
+	// Access returns the role a route requires, or public for routes reached without a user
+	Access func(method, path string) (role string, public bool)
+}
+
+// userScheme names the security scheme of requests authenticating their user
+const userScheme = "user"
+
+// Generate describes routes, as returned by gin.Engine.Routes
+func Generate(routes gin.RoutesInfo, opts Options) *Document {
+	schemas := newSchemaBuilder()
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:   opts.Title,
+			Version: opts.Version,
+			Description: "Errors under /api/v1 are reported as {\"error\", \"details\"}, and under /api/v2 in the " +
+				"ErrorResponse envelope. Middleware such as access control and request timeouts use the envelope on every version.",
+		},
+		Paths: make(map[string]map[string]*Operation),
+	}
+	if opts.BearerAuth {
+		doc.Security = []map[string][]string{{userScheme: {}}}
+		doc.Components.SecuritySchemes = map[string]*SecurityScheme{
+			userScheme: {Type: "http", Scheme: "bearer", Description: "A token naming the user making the request, whose role decides what they may do"},
+		}
+	}
+
+	sorted := append(gin.RoutesInfo(nil), routes...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+
+	for _, route := range sorted {
+		path := openAPIPath(route.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*Operation)
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = describe(schemas, route, opts)
+	}
+
+	doc.Components.Schemas = schemas.components
+	for name, schema := range jobConfigComponents() {
+		doc.Components.Schemas[name] = schema
+	}
+	doc.Components.Schemas["LegacyError"] = &Schema{
+		Type:        "object",
+		Description: "Error reported by /api/v1 handlers",
+		Properties: map[string]*Schema{
+			"error":   {Type: "string"},
+			"details": {Type: "string"},
+		},
+		Required: []string{"error"},
+	}
+	schemas.schemaFor(dto.ErrorResponse{})
+	return doc
+}
+
+// describe returns the operation of a route
+func describe(schemas *schemaBuilder, route gin.RouteInfo, opts Options) *Operation {
+	meta := operations[route.Method+" "+route.Path]
+
+	op := &Operation{
+		Tags:        []string{tag(route.Path)},
+		Summary:     meta.summary,
+		OperationID: operationID(route.Method, route.Path),
+		Parameters:  append(pathParams(route.Path), meta.query...),
+		Responses:   make(map[string]*Response),
+	}
+
+	if meta.request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: schemas.schemaFor(meta.request)}},
+		}
+	}
+
+	status := meta.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := &Response{Description: http.StatusText(status)}
+	if meta.response != nil {
+		success.Content = map[string]MediaType{"application/json": {Schema: schemas.schemaFor(meta.response)}}
+	}
+	op.Responses[strconv.Itoa(status)] = success
+
+	errorSchema := "ErrorResponse"
+	if strings.HasPrefix(route.Path, "/api/v1/") {
+		errorSchema = "LegacyError"
+	}
+	op.Responses["default"] = &Response{
+		Description: "Error",
+		Content:     map[string]MediaType{"application/json": {Schema: ref(errorSchema)}},
+	}
+
+	if opts.Access != nil {
+		role, public := opts.Access(route.Method, route.Path)
+		if public {
+			op.Security = &[]map[string][]string{}
+		} else {
+			op.RequiredRole = role
+		}
+	}
+	return op
+}
+
+// openAPIPath converts a gin route path to an OpenAPI path, e.g. /jobs/:id to /jobs/{id}
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// pathParams describes the parameters in a gin route path
+// IDs are UUIDs; other parameters are strings
+func pathParams(path string) []Parameter {
+	var params []Parameter
+	for _, segment := range strings.Split(path, "/") {
+		if !strings.HasPrefix(segment, ":") && !strings.HasPrefix(segment, "*") {
+			continue
+		}
+		name := segment[1:]
+		schema := &Schema{Type: "string"}
+		if name == "id" || strings.HasSuffix(name, "_id") {
+			schema.Format = "uuid"
+		}
+		params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	return params
+}
+
+// tag groups a route by the resource it works on: the first segment after the API version
+func tag(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 2 && segments[0] == "api" {
+		segments = segments[2:]
+	}
+	return segments[0]
+}
+
+// operationID names a route's operation after its method and path, e.g. getApiV1JobsById
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" {
+			continue
+		}
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			b.WriteString("By")
+			segment = segment[1:]
+		}
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool { return r == '-' || r == '_' }) {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
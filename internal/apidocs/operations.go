@@ -0,0 +1,260 @@
+package apidocs
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+)
+
+// operation describes what a route takes and returns beyond what its path says
+type operation struct {
+	summary string
+	query   []Parameter
+	// request is a value of the JSON body the route takes, if any
+	request interface{}
+	// status is the status of a successful response, 200 by default
+	status int
+	// response is a value of the JSON body of a successful response, if any
+	response interface{}
+}
+
+// queryParam describes an optional query parameter
+func queryParam(name, schemaType, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: schemaType}}
+}
+
+// Query parameters shared by several routes
+var (
+	pageParams = []Parameter{
+		queryParam("page", "integer", "Page to return, from 1"),
+		queryParam("limit", "integer", "Items per page"),
+	}
+	teamParam = queryParam("team", "string", "Team owning the job, none by default")
+	// jobFilterParams filter both versions of the job list
+	jobFilterParams = []Parameter{
+		queryParam("state", "string", "Comma-separated job states to list, e.g. active,errored"),
+		queryParam("name", "string", "Text the job names contain, ignoring case"),
+		queryParam("job_type", "string", "Job type"),
+		queryParam("is_active", "boolean", "true for active jobs only, false for jobs in other states"),
+		queryParam("tag", "string", "Tag the jobs have"),
+		queryParam("created_after", "string", "RFC 3339 time or date the jobs were created at or after"),
+		queryParam("created_before", "string", "RFC 3339 time or date the jobs were created before"),
+	}
+)
+
+// v1 response bodies, which wrap the resource in a named field
+type (
+	jobBody struct {
+		Job dto.JobResponse `json:"job"`
+	}
+	jobsBody struct {
+		Jobs       []dto.JobResponse `json:"jobs"`
+		TotalCount int               `json:"total_count"`
+	}
+	jobMessageBody struct {
+		Message string          `json:"message"`
+		Job     dto.JobResponse `json:"job"`
+	}
+	messageBody struct {
+		Message string `json:"message"`
+	}
+	executionBody struct {
+		Execution dto.ExecutionResponse `json:"execution"`
+	}
+	executionsBody struct {
+		Executions []dto.ExecutionResponse `json:"executions"`
+	}
+	executionOutputBody struct {
+		ExecutionID uuid.UUID              `json:"execution_id"`
+		Status      models.ExecutionStatus `json:"status"`
+		Output      map[string]interface{} `json:"output"`
+	}
+	jobStatsBody struct {
+		JobID uuid.UUID                `json:"job_id"`
+		Stats models.JobExecutionStats `json:"stats"`
+	}
+	overallStatsBody struct {
+		Stats models.OverallExecutionStats `json:"stats"`
+	}
+	jobTypeStatsBody struct {
+		Window string                         `json:"window"`
+		Stats  []models.JobTypeExecutionStats `json:"stats"`
+	}
+	jobTriggerBody struct {
+		Message string    `json:"message"`
+		JobID   uuid.UUID `json:"job_id"`
+	}
+	jobImportBody struct {
+		Message string                 `json:"message"`
+		Import  models.JobImportResult `json:"import"`
+	}
+	throughputBody struct {
+		Interval string                    `json:"interval"`
+		From     time.Time                 `json:"from"`
+		To       time.Time                 `json:"to"`
+		Buckets  []models.ThroughputBucket `json:"buckets"`
+	}
+)
+
+// operations describes the routes clients use most, keyed by method and gin route path
+// Routes not listed here are still documented, with their path parameters and error responses
+var operations = map[string]operation{
+	"POST /api/v1/jobs": {
+		summary:  "Create a job",
+		request:  models.CreateJobRequest{},
+		status:   http.StatusCreated,
+		response: jobMessageBody{},
+	},
+	"GET /api/v1/jobs": {
+		summary: "List jobs",
+		query: append(append(pageParams,
+			queryParam("sort", "string", "created_at for the newest jobs first (default), name, next_run for the jobs due soonest first, "+
+				"health for the least healthy jobs first, -health for the healthiest")),
+			jobFilterParams...),
+		response: dto.JobListResponse{},
+	},
+	"GET /api/v1/jobs/errored": {
+		summary:  "List errored jobs, whose stored schedules can't be used",
+		response: jobsBody{},
+	},
+	"POST /api/v1/jobs/preview": {
+		summary:  "Preview when a schedule fires and check a job's config, without saving it",
+		request:  models.PreviewJobRequest{},
+		response: models.JobPreview{},
+	},
+	"GET /api/v1/jobs/:id": {
+		summary:  "Get a job",
+		response: jobBody{},
+	},
+	"PUT /api/v1/jobs/:id": {
+		summary:  "Update a job",
+		request:  models.UpdateJobRequest{},
+		response: jobMessageBody{},
+	},
+	"DELETE /api/v1/jobs/:id": {
+		summary:  "Delete a job",
+		response: messageBody{},
+	},
+	"POST /api/v1/jobs/:id/pause": {
+		summary:  "Pause a job",
+		request:  models.PauseJobRequest{},
+		response: jobMessageBody{},
+	},
+	"POST /api/v1/jobs/:id/resume": {
+		summary:  "Resume a paused job",
+		response: jobMessageBody{},
+	},
+	"POST /api/v1/jobs/:id/trigger": {
+		summary:  "Run a job now, outside its schedule",
+		request:  models.TriggerJobRequest{},
+		status:   http.StatusAccepted,
+		response: jobTriggerBody{},
+	},
+	"GET /api/v1/jobs/by-name/:name": {
+		summary:  "Get a team's job by name",
+		query:    []Parameter{teamParam},
+		response: jobBody{},
+	},
+	"PUT /api/v1/jobs/by-name/:name": {
+		summary:  "Create or replace a job by name",
+		request:  models.CreateJobRequest{},
+		response: jobMessageBody{},
+	},
+	"DELETE /api/v1/jobs/by-name/:name": {
+		summary:  "Delete a team's job by name",
+		query:    []Parameter{teamParam},
+		response: messageBody{},
+	},
+	"GET /api/v1/jobs/export": {
+		summary: "Export job definitions as a YAML or JSON manifest",
+		query: []Parameter{
+			queryParam("format", "string", "yaml (default) or json"),
+			queryParam("team", "string", "Only export this team's jobs"),
+		},
+		response: models.JobManifest{},
+	},
+	"POST /api/v1/jobs/import": {
+		summary: "Create and update jobs from a YAML or JSON manifest",
+		query: []Parameter{
+			queryParam("dry_run", "boolean", "true to report what the import would do without doing it"),
+			queryParam("diff", "boolean", "true to list the settings each update changes"),
+		},
+		request:  models.JobManifest{},
+		response: jobImportBody{},
+	},
+	"GET /api/v1/jobs/:id/executions": {
+		summary:  "List a job's runs",
+		query:    append(pageParams, queryParam("exact", "boolean", "false to estimate total_count for jobs with many runs")),
+		response: dto.ExecutionListResponse{},
+	},
+	"HEAD /api/v1/jobs/:id/executions": {
+		summary: "Count a job's runs exactly, in the X-Total-Count header",
+	},
+	"GET /api/v1/executions/recent": {
+		summary:  "List the most recent runs",
+		query:    []Parameter{queryParam("limit", "integer", "Runs to return")},
+		response: executionsBody{},
+	},
+	"GET /api/v1/executions/:id": {
+		summary:  "Get a run",
+		response: executionBody{},
+	},
+	"GET /api/v1/executions/:id/output": {
+		summary:  "Get a finished run's output",
+		response: executionOutputBody{},
+	},
+	"GET /api/v1/stats": {
+		summary:  "Summarize the runs of all jobs",
+		response: overallStatsBody{},
+	},
+	"GET /api/v1/stats/by-type": {
+		summary:  "Summarize runs by job type",
+		query:    []Parameter{queryParam("window", "string", "How far back to look, e.g. 24h or 7d")},
+		response: jobTypeStatsBody{},
+	},
+	"GET /api/v1/stats/throughput": {
+		summary: "Count runs started, completed and failed per interval",
+		query: []Parameter{
+			queryParam("interval", "string", "Length of each bucket, e.g. 15m, 1h or 1d"),
+			queryParam("from", "string", "Start of the range, an RFC 3339 time; a day before to by default"),
+			queryParam("to", "string", "End of the range, an RFC 3339 time; now by default"),
+		},
+		response: throughputBody{},
+	},
+	"GET /api/v1/jobs/:id/stats": {
+		summary:  "Summarize a job's runs",
+		response: jobStatsBody{},
+	},
+
+	"GET /api/v2/jobs": {
+		summary: "List jobs",
+		query: append([]Parameter{
+			queryParam("cursor", "string", "next_cursor of the previous page"),
+			queryParam("limit", "integer", "Jobs per page"),
+		}, jobFilterParams...),
+		response: dto.JobPageResponse{},
+	},
+	"GET /api/v2/jobs/:id": {
+		summary:  "Get a job",
+		response: dto.JobResponse{},
+	},
+	"POST /api/v2/jobs": {
+		summary:  "Create a job",
+		request:  models.CreateJobRequest{},
+		status:   http.StatusCreated,
+		response: dto.JobResponse{},
+	},
+	"PATCH /api/v2/jobs/:id": {
+		summary:  "Update a job",
+		request:  models.UpdateJobRequest{},
+		response: dto.JobResponse{},
+	},
+	"DELETE /api/v2/jobs/:id": {
+		summary: "Delete a job",
+		status:  http.StatusNoContent,
+	},
+}
@@ -0,0 +1,237 @@
+package apidocs
+
+import (
+	"encoding/json"
+	"go/ast"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// Schema is an OpenAPI 3.0 schema object
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Example              interface{}        `json:"example,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+}
+
+// ref returns a schema referring to a component
+func ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// enums lists the values of the string types the API accepts only some values of
+var enums = map[reflect.Type][]string{
+	reflect.TypeOf(models.JobType("")): {
+		string(models.JobTypeEmailNotification), string(models.JobTypeDataProcessing),
+		string(models.JobTypeReportGeneration), string(models.JobTypeHealthCheck), string(models.JobTypeDBMaintenance),
+	},
+	reflect.TypeOf(models.ScheduleType("")): {string(models.ScheduleTypeCron), string(models.ScheduleTypeOnce)},
+	reflect.TypeOf(models.JobSeverity("")): {
+		string(models.JobSeverityLow), string(models.JobSeverityMedium),
+		string(models.JobSeverityHigh), string(models.JobSeverityCritical),
+	},
+	reflect.TypeOf(models.BackoffStrategy("")): {
+		string(models.BackoffFixed), string(models.BackoffLinear), string(models.BackoffExponential),
+	},
+	reflect.TypeOf(models.MisfirePolicy("")): {
+		string(models.MisfireIgnore), string(models.MisfireRunOnce), string(models.MisfireRunAll),
+	},
+	reflect.TypeOf(models.ConcurrencyPolicy("")): {
+		string(models.ConcurrencyAllow), string(models.ConcurrencyForbid), string(models.ConcurrencyReplace),
+	},
+	reflect.TypeOf(models.ExecutionStatus("")): {
+		string(models.ExecutionStatusPending), string(models.ExecutionStatusRunning),
+		string(models.ExecutionStatusCompleted), string(models.ExecutionStatusFailed),
+		string(models.ExecutionStatusCancelled), string(models.ExecutionStatusQueued),
+		string(models.ExecutionStatusAwaitingApproval), string(models.ExecutionStatusExpired),
+		string(models.ExecutionStatusStalled), string(models.ExecutionStatusDependencyUnavailable),
+		string(models.ExecutionStatusSkipped), string(models.ExecutionStatusInterrupted),
+	},
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	uuidType       = reflect.TypeOf(uuid.UUID{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	jobConfigType  = reflect.TypeOf(models.JobConfig{})
+)
+
+// schemaBuilder derives schemas from Go types, collecting named structs as components
+type schemaBuilder struct {
+	components map[string]*Schema
+	types      map[string]reflect.Type
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{
+		components: make(map[string]*Schema),
+		types:      make(map[string]reflect.Type),
+	}
+}
+
+// schemaFor returns the schema of the JSON a value marshals to
+func (b *schemaBuilder) schemaFor(value interface{}) *Schema {
+	return b.schemaOf(reflect.TypeOf(value))
+}
+
+// schemaOf returns the schema of the JSON values of type t marshal to, referring to exported structs
+// by component
+func (b *schemaBuilder) schemaOf(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case rawMessageType:
+		return &Schema{}
+	case jobConfigType:
+		return ref("JobConfig")
+	}
+	if values, ok := enums[t]; ok {
+		return &Schema{Type: "string", Enum: values}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := b.schemaOf(t.Elem())
+		if schema.Ref != "" {
+			return schema
+		}
+		nullable := *schema
+		nullable.Nullable = true
+		return &nullable
+	case reflect.Struct:
+		// Unnamed and unexported structs, such as the response wrappers, are described in place
+		if !ast.IsExported(t.Name()) {
+			return b.structSchema(t)
+		}
+		return ref(b.component(t))
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: b.schemaOf(t.Elem())}
+	case reflect.Map:
+		if t.Elem().Kind() == reflect.Interface {
+			return &Schema{Type: "object", AdditionalProperties: true}
+		}
+		return &Schema{Type: "object", AdditionalProperties: b.schemaOf(t.Elem())}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	default:
+		return &Schema{}
+	}
+}
+
+// component adds a named struct's schema to the components, returning its name
+// Structs sharing a name across packages are told apart by their package's name
+func (b *schemaBuilder) component(t reflect.Type) string {
+	name := t.Name()
+	if existing, ok := b.types[name]; ok && existing != t {
+		pkg := t.PkgPath()
+		pkg = pkg[strings.LastIndex(pkg, "/")+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	if _, ok := b.types[name]; ok {
+		return name
+	}
+
+	// Register the name before building the schema so self-referencing structs terminate
+	b.types[name] = t
+	b.components[name] = &Schema{}
+	*b.components[name] = *b.structSchema(t)
+	return name
+}
+
+// structSchema returns the object schema of a struct's JSON fields
+// Fields tagged required by validation are required; embedded structs contribute their fields
+func (b *schemaBuilder) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		name, opts := field.Name, ""
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			if tag == "-" {
+				continue
+			}
+			if comma := strings.Index(tag, ","); comma >= 0 {
+				name, opts = tag[:comma], tag[comma:]
+			} else {
+				name = tag
+			}
+			if name == "" {
+				name = field.Name
+			}
+		}
+
+		fieldType := field.Type
+		if field.Anonymous && !strings.Contains(string(field.Tag), "json:") {
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				embedded := b.structSchema(fieldType)
+				for key, value := range embedded.Properties {
+					schema.Properties[key] = value
+				}
+				schema.Required = append(schema.Required, embedded.Required...)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		property := b.schemaOf(fieldType)
+		if strings.Contains(opts, ",string") && property.Type != "" {
+			property = &Schema{Type: "string", Nullable: property.Nullable}
+		}
+		schema.Properties[name] = property
+		if isRequired(field) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
+// isRequired reports whether validation requires a field to be set
+func isRequired(field reflect.StructField) bool {
+	for _, key := range []string{"validate", "binding"} {
+		for _, rule := range strings.Split(field.Tag.Get(key), ",") {
+			if rule == "required" {
+				return true
+			}
+		}
+	}
+	return false
+}
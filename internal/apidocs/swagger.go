@@ -0,0 +1,16 @@
+package apidocs
+
+import (
+	_ "embed"
+)
+
+// swaggerUI is a page rendering the document served next to it, at openapi.json, with Swagger UI
+//
+//go:embed swagger.html
+var swaggerUI []byte
+
+// SwaggerUI returns the Swagger UI page, which loads the document from openapi.json relative to its
+// own URL and Swagger UI's assets from unpkg
+func SwaggerUI() []byte {
+	return swaggerUI
+}
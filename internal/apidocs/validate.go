@@ -0,0 +1,153 @@
+package apidocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"job-scheduler/internal/models"
+)
+
+// ValidateJobConfig checks a config against its job type's config schema and the settings every job type
+// shares, returning what is wrong with it. Job types without a schema accept any config
+// Settings the schemas don't describe are allowed, as executors ignore them
+func ValidateJobConfig(jobType models.JobType, config models.JobConfig) []string {
+	components := jobConfigComponents()
+	schema, ok := components[configComponent(jobType)]
+	if !ok {
+		return nil
+	}
+
+	// Check the config as it is stored, so numbers are float64 however they were given
+	encoded, err := json.Marshal(config)
+	if err != nil {
+		return []string{fmt.Sprintf("config can't be encoded as JSON: %v", err)}
+	}
+	var value interface{}
+	if err := json.Unmarshal(encoded, &value); err != nil {
+		return []string{fmt.Sprintf("config can't be encoded as JSON: %v", err)}
+	}
+	if value == nil {
+		value = map[string]interface{}{}
+	}
+
+	shared := &Schema{Type: "object", Properties: components["JobConfig"].Properties}
+	problems := schema.validate(value, "config", components)
+	return append(problems, shared.validate(value, "config", components)...)
+}
+
+// validate returns what is wrong with a decoded JSON value for the schema, naming values by their path
+// It covers the parts of JSON Schema the config schemas use
+func (s *Schema) validate(value interface{}, path string, components map[string]*Schema) []string {
+	if s.Ref != "" {
+		target, ok := components[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+		if !ok {
+			return nil
+		}
+		return target.validate(value, path, components)
+	}
+	if value == nil {
+		if s.Nullable || s.Type == "" {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s must be %s, not null", path, article(s.Type))}
+	}
+
+	var problems []string
+	for _, schema := range s.AllOf {
+		problems = append(problems, schema.validate(value, path, components)...)
+	}
+	if len(s.AnyOf) > 0 && s.Type != "object" {
+		matched := false
+		for _, schema := range s.AnyOf {
+			if len(schema.validate(value, path, components)) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			problems = append(problems, fmt.Sprintf("%s doesn't match any of its allowed forms", path))
+		}
+	}
+
+	switch s.Type {
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return append(problems, fmt.Sprintf("%s must be a string", path))
+		}
+		if len(s.Enum) > 0 && !containsString(s.Enum, text) {
+			problems = append(problems, fmt.Sprintf("%s must be one of %s", path, strings.Join(s.Enum, ", ")))
+		}
+	case "number", "integer":
+		number, ok := value.(float64)
+		if !ok {
+			return append(problems, fmt.Sprintf("%s must be a number", path))
+		}
+		if s.Type == "integer" && number != math.Trunc(number) {
+			problems = append(problems, fmt.Sprintf("%s must be a whole number", path))
+		}
+		if s.Minimum != nil && number < *s.Minimum {
+			problems = append(problems, fmt.Sprintf("%s must be at least %v", path, *s.Minimum))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return append(problems, fmt.Sprintf("%s must be true or false", path))
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return append(problems, fmt.Sprintf("%s must be an array", path))
+		}
+		if s.Items != nil {
+			for i, item := range items {
+				problems = append(problems, s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i), components)...)
+			}
+		}
+	case "object":
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return append(problems, fmt.Sprintf("%s must be an object", path))
+		}
+		for _, name := range s.Required {
+			if _, ok := fields[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s is required", path, name))
+			}
+		}
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			field := s.Properties[name]
+			if field == nil {
+				field, _ = s.AdditionalProperties.(*Schema)
+			}
+			if field != nil {
+				problems = append(problems, field.validate(fields[name], path+"."+name, components)...)
+			}
+		}
+	}
+	return problems
+}
+
+// article returns a JSON type's name with "a" or "an" before it
+func article(schemaType string) string {
+	if schemaType == "object" || schemaType == "array" || schemaType == "integer" {
+		return "an " + schemaType
+	}
+	return "a " + schemaType
+}
+
+// containsString reports whether a list of strings holds a value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
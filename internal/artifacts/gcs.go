@@ -0,0 +1,215 @@
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/sigv4"
+)
+
+// gcsHost is the Cloud Storage XML API host
+const gcsHost = "storage.googleapis.com"
+
+// GCSStore keeps artifacts in a Google Cloud Storage bucket
+// Uploads, deletes and downloads all go through V4 signed URLs, signed with
+// the service account's private key
+type GCSStore struct {
+	bucket      string
+	prefix      string
+	clientEmail string
+	privateKey  *rsa.PrivateKey
+	httpClient  *http.Client
+}
+
+// serviceAccountKey is the subset of a service account JSON key used for signing
+type serviceAccountKey struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+}
+
+// NewGCSStore creates a new GCS artifact store from a service account key file
+func NewGCSStore(cfg config.ArtifactsConfig, httpClient *http.Client) (*GCSStore, error) {
+	data, err := ioutil.ReadFile(cfg.GCSCredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GCS credentials: %w", err)
+	}
+
+	var key serviceAccountKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("failed to parse GCS credentials: %w", err)
+	}
+
+	privateKey, err := parseRSAPrivateKey(key.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GCS private key: %w", err)
+	}
+
+	return &GCSStore{
+		bucket:      cfg.GCSBucket,
+		prefix:      strings.Trim(cfg.GCSPrefix, "/"),
+		clientEmail: key.ClientEmail,
+		privateKey:  privateKey,
+		httpClient:  httpClient,
+	}, nil
+}
+
+// Put uploads the file at path under key, removing the local copy once stored
+func (s *GCSStore) Put(ctx context.Context, key, filePath, contentType string) error {
+	body, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read artifact: %w", err)
+	}
+
+	signedURL, err := s.sign(http.MethodPut, key, nil, time.Now().UTC(), 15*time.Minute)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, signedURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create GCS request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	if err := s.do(req, http.StatusOK); err != nil {
+		return fmt.Errorf("failed to upload artifact: %w", err)
+	}
+	return os.Remove(filePath)
+}
+
+// Delete removes the object stored under key
+func (s *GCSStore) Delete(ctx context.Context, key string) error {
+	signedURL, err := s.sign(http.MethodDelete, key, nil, time.Now().UTC(), 15*time.Minute)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, signedURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create GCS request: %w", err)
+	}
+
+	// Objects that are already gone count as deleted
+	if err := s.do(req, http.StatusNoContent, http.StatusNotFound); err != nil {
+		return fmt.Errorf("failed to delete artifact: %w", err)
+	}
+	return nil
+}
+
+// SignedURL returns a V4 signed GET URL for the artifact
+func (s *GCSStore) SignedURL(artifact *models.Artifact, expiresAt time.Time) (string, error) {
+	now := time.Now().UTC()
+	expiry := expiresAt.Sub(now)
+	if expiry <= 0 {
+		return "", fmt.Errorf("expiry must be in the future")
+	}
+	if expiry > maxPresignExpiry {
+		expiry = maxPresignExpiry
+	}
+
+	extra := url.Values{}
+	extra.Set("response-content-disposition", fmt.Sprintf("attachment; filename=%q", artifact.Name))
+	return s.sign(http.MethodGet, artifact.StorageKey, extra, now, expiry)
+}
+
+// sign builds a V4 signed URL for the object stored under key
+func (s *GCSStore) sign(method, key string, extra url.Values, now time.Time, expiry time.Duration) (string, error) {
+	objectPath := "/" + s.bucket + "/" + key
+	if s.prefix != "" {
+		objectPath = "/" + s.bucket + "/" + s.prefix + "/" + key
+	}
+	escapedPath := (&url.URL{Path: objectPath}).EscapedPath()
+
+	datetime := now.Format("20060102T150405Z")
+	scope := fmt.Sprintf("%s/auto/storage/goog4_request", now.Format("20060102"))
+
+	query := url.Values{}
+	for k, v := range extra {
+		query[k] = v
+	}
+	query.Set("X-Goog-Algorithm", "GOOG4-RSA-SHA256")
+	query.Set("X-Goog-Credential", s.clientEmail+"/"+scope)
+	query.Set("X-Goog-Date", datetime)
+	query.Set("X-Goog-Expires", strconv.Itoa(int(expiry.Round(time.Second).Seconds())))
+	query.Set("X-Goog-SignedHeaders", "host")
+
+	canonicalQuery := sigv4.CanonicalQueryString(query)
+	canonicalRequest := strings.Join([]string{
+		method,
+		escapedPath,
+		canonicalQuery,
+		"host:" + gcsHost + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"GOOG4-RSA-SHA256",
+		datetime,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	digest := sha256.Sum256([]byte(stringToSign))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GCS URL: %w", err)
+	}
+
+	return fmt.Sprintf("https://%s%s?%s&X-Goog-Signature=%s",
+		gcsHost, escapedPath, canonicalQuery, hex.EncodeToString(signature)), nil
+}
+
+// do sends a request and checks the response status
+func (s *GCSStore) do(req *http.Request, okStatuses ...int) error {
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	for _, status := range okStatuses {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("GCS returned status %d: %s", resp.StatusCode, string(respBody))
+}
+
+// parseRSAPrivateKey parses a PEM encoded PKCS#8 or PKCS#1 RSA private key
+func parseRSAPrivateKey(pemKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("private key is not an RSA key")
+		}
+		return rsaKey, nil
+	}
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
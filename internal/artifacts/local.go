@@ -0,0 +1,137 @@
+package artifacts
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+)
+
+// LocalStore keeps artifacts on the local filesystem
+// Its signed URLs point back at the artifact download endpoint, which
+// verifies the signature before serving the file
+type LocalStore struct {
+	dir     string
+	baseURL string
+	secret  []byte
+}
+
+// NewLocalStore creates a new local artifact store
+// When secret is empty a random one is generated, so URLs don't survive restarts
+func NewLocalStore(dir, baseURL, secret string) *LocalStore {
+	key := []byte(secret)
+	if secret == "" {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			panic(fmt.Sprintf("failed to generate signing secret: %v", err))
+		}
+		logrus.Warn("REPORTS_SIGNING_SECRET is not set - download URLs will be invalidated on restart")
+	}
+
+	return &LocalStore{
+		dir:     dir,
+		baseURL: baseURL,
+		secret:  key,
+	}
+}
+
+// Put moves the file at path into the store under key
+func (s *LocalStore) Put(ctx context.Context, key, path, contentType string) error {
+	dest := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create artifact directory: %w", err)
+	}
+
+	// Renaming fails across filesystems, so fall back to copying
+	if err := os.Rename(path, dest); err == nil {
+		return nil
+	}
+	if err := copyFile(path, dest); err != nil {
+		return fmt.Errorf("failed to store artifact: %w", err)
+	}
+	return os.Remove(path)
+}
+
+// Delete removes the file stored under key
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete artifact: %w", err)
+	}
+	return nil
+}
+
+// SignedURL returns a download endpoint URL signed until expiresAt
+func (s *LocalStore) SignedURL(artifact *models.Artifact, expiresAt time.Time) (string, error) {
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	return fmt.Sprintf("%s/api/v1/artifacts/%s/download?expires=%s&signature=%s",
+		s.baseURL, artifact.ID, expires, s.sign(artifact.ID, expires)), nil
+}
+
+// Verify checks a download URL's signature and expiry
+func (s *LocalStore) Verify(id uuid.UUID, expires, signature string, now time.Time) error {
+	expected := s.sign(id, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if now.Unix() > expiresAt {
+		return ErrURLExpired
+	}
+	return nil
+}
+
+// Open opens a stored artifact for reading
+func (s *LocalStore) Open(key string) (*os.File, error) {
+	file, err := os.Open(s.path(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open artifact: %w", err)
+	}
+	return file, nil
+}
+
+// path returns the filesystem path of a key, which can't escape the store directory
+func (s *LocalStore) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(filepath.Clean("/"+key)))
+}
+
+// sign returns the hex HMAC-SHA256 of the artifact ID and expiry
+func (s *LocalStore) sign(id uuid.UUID, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(id.String() + "|" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// copyFile copies the file at src to dst
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,186 @@
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/sigv4"
+)
+
+// maxPresignExpiry is the longest expiry S3 accepts for presigned URLs
+const maxPresignExpiry = 7 * 24 * time.Hour
+
+// S3Store keeps artifacts in an S3 (or S3-compatible) bucket and hands out
+// presigned GET URLs, signed with AWS Signature Version 4
+type S3Store struct {
+	bucket     string
+	region     string
+	endpoint   string
+	prefix     string
+	signer     sigv4.Signer
+	httpClient *http.Client
+}
+
+// NewS3Store creates a new S3 artifact store
+// Without an endpoint the bucket is addressed virtual-hosted style on AWS;
+// with one (e.g. MinIO) it is addressed path style
+func NewS3Store(cfg config.ArtifactsConfig, creds config.TriggersConfig, httpClient *http.Client) *S3Store {
+	return &S3Store{
+		bucket:   cfg.S3Bucket,
+		region:   cfg.S3Region,
+		endpoint: cfg.S3Endpoint,
+		prefix:   strings.Trim(cfg.S3Prefix, "/"),
+		signer: sigv4.Signer{
+			AccessKeyID:  creds.AWSAccessKeyID,
+			SecretKey:    creds.AWSSecretKey,
+			SessionToken: creds.AWSSessionToken,
+			Region:       cfg.S3Region,
+			Service:      "s3",
+		},
+		httpClient: httpClient,
+	}
+}
+
+// Put uploads the file at path under key, removing the local copy once stored
+func (s *S3Store) Put(ctx context.Context, key, filePath, contentType string) error {
+	body, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read artifact: %w", err)
+	}
+
+	objectURL := s.objectURL(key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create S3 request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	s.signRequest(req, sigv4.PayloadHash(body), time.Now().UTC())
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload artifact: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("S3 upload returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return os.Remove(filePath)
+}
+
+// Delete removes the object stored under key
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	objectURL := s.objectURL(key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, objectURL.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create S3 request: %w", err)
+	}
+
+	s.signRequest(req, sigv4.PayloadHash(nil), time.Now().UTC())
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete artifact: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// S3 answers 204 whether or not the object existed
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("S3 delete returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// Ping checks the bucket exists and the credentials can reach it
+func (s *S3Store) Ping(ctx context.Context) error {
+	bucketURL := s.bucketURL()
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, bucketURL.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create S3 request: %w", err)
+	}
+
+	s.signRequest(req, sigv4.PayloadHash(nil), time.Now().UTC())
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach S3 bucket: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("S3 bucket check returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SignedURL returns a presigned GET URL for the artifact
+func (s *S3Store) SignedURL(artifact *models.Artifact, expiresAt time.Time) (string, error) {
+	now := time.Now().UTC()
+	expiry := expiresAt.Sub(now)
+	if expiry <= 0 {
+		return "", fmt.Errorf("expiry must be in the future")
+	}
+	if expiry > maxPresignExpiry {
+		expiry = maxPresignExpiry
+	}
+
+	objectURL := s.objectURL(artifact.StorageKey)
+	objectURL.RawQuery = url.Values{
+		"response-content-disposition": {fmt.Sprintf("attachment; filename=%q", artifact.Name)},
+	}.Encode()
+	return s.signer.Presign(http.MethodGet, objectURL, expiry, now), nil
+}
+
+// signRequest adds AWS Signature Version 4 headers to the request
+func (s *S3Store) signRequest(req *http.Request, payloadHash string, now time.Time) {
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	s.signer.Sign(req, payloadHash, now)
+}
+
+// objectURL returns the URL of the object stored under key
+func (s *S3Store) objectURL(key string) *url.URL {
+	objectKey := key
+	if s.prefix != "" {
+		objectKey = s.prefix + "/" + key
+	}
+
+	if s.endpoint != "" {
+		u, _ := url.Parse(s.endpoint)
+		u.Path = path.Join("/", u.Path, s.bucket, objectKey)
+		return u
+	}
+	return &url.URL{
+		Scheme: "https",
+		Host:   fmt.Sprintf("%s.s3.%s.amazonaws.com", s.bucket, s.region),
+		Path:   "/" + objectKey,
+	}
+}
+
+// bucketURL returns the URL of the bucket itself
+func (s *S3Store) bucketURL() *url.URL {
+	if s.endpoint != "" {
+		u, _ := url.Parse(s.endpoint)
+		u.Path = path.Join("/", u.Path, s.bucket)
+		return u
+	}
+	return &url.URL{
+		Scheme: "https",
+		Host:   fmt.Sprintf("%s.s3.%s.amazonaws.com", s.bucket, s.region),
+		Path:   "/",
+	}
+}
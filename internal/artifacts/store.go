@@ -0,0 +1,54 @@
+// Package artifacts stores files produced by job runs, such as reports,
+// exports and logs, and signs expiring download URLs for them
+package artifacts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+)
+
+var (
+	// ErrInvalidSignature is returned when a download URL's signature doesn't match
+	ErrInvalidSignature = errors.New("invalid download signature")
+	// ErrURLExpired is returned when a signed download URL is used after it expired
+	ErrURLExpired = errors.New("download URL has expired")
+)
+
+// ArtifactStore keeps artifact files and hands out signed URLs to download them
+type ArtifactStore interface {
+	// Put stores the local file at path under key
+	Put(ctx context.Context, key, path, contentType string) error
+	// Delete removes the file stored under key
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a URL that downloads the artifact until expiresAt
+	SignedURL(artifact *models.Artifact, expiresAt time.Time) (string, error)
+}
+
+// NewStoreFromConfig creates the artifact store selected by ARTIFACTS_STORAGE
+func NewStoreFromConfig(cfg *config.Config) (ArtifactStore, error) {
+	artifactsCfg := cfg.Artifacts
+	httpClient := &http.Client{Timeout: time.Minute}
+
+	switch artifactsCfg.Backend {
+	case "", "local":
+		return NewLocalStore(artifactsCfg.Directory, artifactsCfg.PublicBaseURL, artifactsCfg.SigningSecret), nil
+	case "s3":
+		if artifactsCfg.S3Bucket == "" {
+			return nil, fmt.Errorf("ARTIFACTS_S3_BUCKET is required for s3 artifact storage")
+		}
+		return NewS3Store(artifactsCfg, cfg.Triggers, httpClient), nil
+	case "gcs":
+		if artifactsCfg.GCSBucket == "" || artifactsCfg.GCSCredentialsFile == "" {
+			return nil, fmt.Errorf("ARTIFACTS_GCS_BUCKET and ARTIFACTS_GCS_CREDENTIALS_FILE are required for gcs artifact storage")
+		}
+		return NewGCSStore(artifactsCfg, httpClient)
+	default:
+		return nil, fmt.Errorf("unknown artifact storage backend: %s", artifactsCfg.Backend)
+	}
+}
@@ -1,9 +1,11 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -21,14 +23,64 @@ type Config struct {
 	// Application configuration
 	App AppConfig
 
+	// Log shipping configuration
+	LogShipping LogShippingConfig
+
 	// Scheduler configuration
 	Scheduler SchedulerConfig
 
 	// Health check configuration
 	HealthCheck HealthCheckConfig
 
+	// Outbound HTTP client configuration
+	HTTPClient HTTPClientConfig
+
 	// Reports configuration
 	Reports ReportsConfig
+
+	// Data processing pipeline configuration
+	DataProcessing DataProcessingConfig
+
+	// Artifact storage configuration
+	Artifacts ArtifactsConfig
+
+	// Message queue trigger configuration
+	Triggers TriggersConfig
+
+	// Notification configuration
+	Notifications NotificationsConfig
+
+	// Run approval configuration
+	Approvals ApprovalsConfig
+
+	// Remediation action link configuration
+	Remediation RemediationConfig
+	// Run history retention configuration
+	History HistoryConfig
+	// Alert rule configuration
+	Alerts AlertsConfig
+	// Table maintenance configuration
+	TableMaintenance TableMaintenanceConfig
+	// db_maintenance job configuration
+	DBMaintenance DBMaintenanceConfig
+	// Connection health probe configuration
+	ConnectionHealth ConnectionHealthConfig
+	// Per-run log capture configuration
+	ExecutionLogs ExecutionLogsConfig
+
+	// Protected job change control configuration
+	ChangeControl ChangeControlConfig
+	// Role-based access control configuration
+	RBAC RBACConfig
+
+	// API versioning configuration
+	API APIConfig
+
+	// Kubernetes ScheduledJob sync configuration
+	KubernetesSync KubernetesSyncConfig
+
+	// Cross-region replication configuration
+	Replication ReplicationConfig
 }
 
 // DatabaseConfig holds database-related configuration
@@ -39,12 +91,22 @@ type DatabaseConfig struct {
 	Password string
 	Name     string
 	SSLMode  string
+	// SlowQueryThreshold is how long a query may take before it is logged as slow
+	SlowQueryThreshold time.Duration
+	// PrepareStmt caches prepared statements per connection, so repeated queries skip parsing and planning
+	PrepareStmt bool
+	// SkipDefaultTransaction saves single-statement writes without wrapping them in a transaction
+	SkipDefaultTransaction bool
+	// CreateBatchSize is how many rows each insert of a slice writes at once
+	CreateBatchSize int
 }
 
 // ServerConfig holds server-related configuration
 type ServerConfig struct {
 	Host string
 	Port int
+	// RequestTimeout bounds how long a request may take, 0 disables it; streaming endpoints are exempt
+	RequestTimeout time.Duration
 }
 
 // AppConfig holds general application configuration
@@ -53,10 +115,87 @@ type AppConfig struct {
 	LogLevel    string
 }
 
+// LogShippingConfig holds configuration for forwarding logs to a central logging backend
+type LogShippingConfig struct {
+	// Backend is loki, elasticsearch or syslog; when empty logs are only written to stdout
+	Backend string
+	// URL is the backend's address, e.g. http://loki:3100, http://elasticsearch:9200 or udp://syslog:514
+	URL string
+	// Username and Password authenticate to Loki or Elasticsearch with basic auth, if set
+	Username string
+	Password string
+	// Index is the Elasticsearch index logs are written to
+	Index string
+	// Service identifies this service in shipped logs, as a Loki label, document field or syslog app name
+	Service string
+	// Level is the least severe level that is shipped
+	Level string
+	// BatchSize is how many entries are sent at once
+	BatchSize int
+	// FlushInterval is the longest an entry waits before its batch is sent
+	FlushInterval time.Duration
+	// BufferSize is how many entries may wait to be sent; entries beyond it are dropped rather than block logging
+	BufferSize int
+	// Timeout bounds each send to the backend
+	Timeout time.Duration
+}
+
+// RateLimit allows Limit runs to start in any Window; a zero Limit is unlimited
+type RateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
+// MarshalText formats the rate limit as it is configured, e.g. 100/1m0s
+func (r RateLimit) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d/%s", r.Limit, r.Window)), nil
+}
+
 // SchedulerConfig holds scheduler-related configuration
 type SchedulerConfig struct {
 	Enabled           bool
 	MaxConcurrentJobs int
+	// OverloadThreshold is the percentage of MaxConcurrentJobs in use at which the scheduler is overloaded
+	OverloadThreshold int
+	// OverloadQueueWait is how long a run may wait for a slot before the scheduler counts as overloaded
+	OverloadQueueWait time.Duration
+	// MaxQueueWait is how long a run waits for a free slot before it is skipped
+	MaxQueueWait time.Duration
+	// MaxQueueDepth is how many runs may wait for a free slot; runs beyond it are skipped
+	MaxQueueDepth int
+	// ShedBelowSeverity defers scheduled runs of jobs below this severity while overloaded
+	ShedBelowSeverity string
+	// TeamWeights are the teams' shares of contended execution slots; teams without a weight have 1
+	TeamWeights map[string]int
+	// ConcurrencyGroupLimits cap how many runs of the jobs in each concurrency group execute at once;
+	// groups without a limit are only bound by MaxConcurrentJobs
+	ConcurrencyGroupLimits map[string]int
+	// StartRateLimit caps how many runs start per time window across all jobs; a zero limit is unlimited
+	StartRateLimit RateLimit
+	// JobTypeStartRateLimits cap how many runs of each job type start per time window
+	JobTypeStartRateLimits map[string]RateLimit
+	// InstanceID identifies this instance when claiming scheduled runs shared with other replicas
+	InstanceID string
+	// ExecutionTimeout is how long a run may execute before it is stopped
+	ExecutionTimeout time.Duration
+	// MaxExecutionTime caps how long a run may execute once its deadline has been extended
+	MaxExecutionTime time.Duration
+	// ShutdownGracePeriod is how long stopping the scheduler waits for running runs to finish
+	// before interrupting them
+	ShutdownGracePeriod time.Duration
+	// InterruptedRuns decides what happens to runs interrupted by a shutdown or crash once an
+	// instance starts: "requeue" runs them again as a new attempt, "fail" records them as failed
+	InterruptedRuns string
+	// RetryStalledRuns retries runs failed for stalling under their job's retry policy
+	RetryStalledRuns bool
+	// TimeoutWarningPercent is the percentage of ExecutionTimeout after which operators are warned
+	TimeoutWarningPercent int
+	// HealthScoreInterval is how often jobs' health scores are recalculated
+	HealthScoreInterval time.Duration
+	// CronSeconds lets new jobs' schedules start with a seconds field unless they set cron_seconds
+	CronSeconds bool
+	// UniqueJobNames rejects creating or renaming a job to a name its team already uses
+	UniqueJobNames bool
 }
 
 // HealthCheckConfig holds health check configuration
@@ -65,11 +204,244 @@ type HealthCheckConfig struct {
 	Timeout time.Duration
 }
 
+// HTTPClientConfig holds configuration for the HTTP clients shared by executors and notifications
+type HTTPClientConfig struct {
+	// MaxRetries is how many times a request is retried after a connection error or 429/502/503/504 response
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry, doubled for each further retry
+	RetryBackoff time.Duration
+	// Connection pool limits, shared by every client
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	// ProxyURL routes requests through a proxy; when empty HTTP_PROXY/HTTPS_PROXY/NO_PROXY apply
+	ProxyURL string
+	// CAFile is a PEM bundle of extra CAs to trust, e.g. for internal services
+	CAFile string
+	// InsecureSkipVerify disables TLS certificate verification; only for testing
+	InsecureSkipVerify bool
+}
+
 // ReportsConfig holds reports configuration
 type ReportsConfig struct {
 	Directory string
 }
 
+// DataProcessingConfig holds configuration for data_processing pipelines
+type DataProcessingConfig struct {
+	// Directory holds the files pipelines read and write; file paths can't leave it
+	Directory string
+	// InternalTables are the tables of the scheduler's own database that table sinks without a
+	// "connection" may write to; pipelines can't touch any other table of it
+	InternalTables []string
+}
+
+// ArtifactsConfig holds configuration for storing run outputs such as reports, exports and logs
+type ArtifactsConfig struct {
+	// Backend is where artifacts are kept: "local", "s3" or "gcs"
+	Backend string
+	// Directory holds artifacts for the local backend
+	Directory string
+	// URLExpiry is how long signed download URLs stay valid
+	URLExpiry time.Duration
+	// Retention is how long artifacts are kept unless a job overrides it; zero keeps them forever
+	Retention time.Duration
+	// JobQuotaBytes and TeamQuotaBytes cap the artifact bytes stored per job and per team; zero is unlimited
+	JobQuotaBytes  int64
+	TeamQuotaBytes int64
+	// EvictionThreshold is the percentage of a quota above which the oldest artifacts are evicted
+	EvictionThreshold int
+	// MaintenanceInterval is how often expired artifacts are purged and quotas enforced
+	MaintenanceInterval time.Duration
+	// SigningSecret signs local download URLs; a random secret is used when empty
+	SigningSecret string
+	// PublicBaseURL prefixes local download URLs, e.g. https://scheduler.example.com
+	PublicBaseURL string
+
+	// S3 settings - credentials are shared with queue triggers
+	S3Bucket   string
+	S3Region   string
+	S3Endpoint string
+	S3Prefix   string
+
+	// GCS settings - the service account key signs uploads and download URLs
+	GCSBucket          string
+	GCSCredentialsFile string
+	GCSPrefix          string
+}
+
+// TriggersConfig holds configuration for SQS / Pub/Sub trigger sources
+type TriggersConfig struct {
+	Enabled         bool
+	ReloadInterval  time.Duration
+	AWSRegion       string
+	AWSAccessKeyID  string
+	AWSSecretKey    string
+	AWSSessionToken string
+	// GCPAccessToken is a static OAuth token; when empty the GCE metadata server is used
+	GCPAccessToken string
+}
+
+// NotificationsConfig holds notification channel configuration
+type NotificationsConfig struct {
+	// WebhookURL and SlackWebhookURL are the default channels, used for jobs
+	// whose team has no channel of its own
+	WebhookURL      string
+	SlackWebhookURL string
+	Timeout         time.Duration
+
+	// Default email channel - enabled when SMTPHost and EmailTo are set
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	// SMTPPoolSize is how many SMTP connections email jobs keep open for reuse
+	SMTPPoolSize int
+	EmailFrom    string
+	EmailTo      []string
+	// EmailEventsToken authenticates bounce, complaint and unsubscribe events posted by the email
+	// provider; the events endpoint is disabled without it
+	EmailEventsToken string
+
+	// FailureThreshold is how many runs of a job must fail in a row before its failures are notified;
+	// a job overrides it with config["alert_after_failures"]
+	FailureThreshold int
+	// DurationThreshold notifies runs that take longer, 0 disables it; a job overrides it with
+	// config["alert_duration_threshold_seconds"]
+	DurationThreshold time.Duration
+}
+
+// ApprovalsConfig holds configuration for jobs that require run approval
+type ApprovalsConfig struct {
+	// Timeout is how long a run waits for approval before it expires
+	Timeout time.Duration
+}
+
+// RemediationConfig holds configuration for the remediation action links in failure notifications
+type RemediationConfig struct {
+	// SigningSecret signs action links; a random secret is used when empty
+	SigningSecret string
+	// PublicBaseURL prefixes action links, e.g. https://scheduler.example.com
+	PublicBaseURL string
+	// LinkExpiry is how long action links stay valid
+	LinkExpiry time.Duration
+}
+
+// HistoryConfig holds configuration for pruning old run history
+type HistoryConfig struct {
+	// Retention is how long finished runs are kept unless a job overrides it; zero keeps them forever
+	Retention time.Duration
+	// DeleteBatchSize is how many runs each delete statement removes, keeping row locks short
+	DeleteBatchSize int
+	// CleanupInterval is how often old runs are pruned
+	CleanupInterval time.Duration
+}
+
+// TableMaintenanceConfig holds configuration for analyzing and vacuuming the scheduler's hot tables
+type TableMaintenanceConfig struct {
+	// Enabled runs the table maintenance system job
+	Enabled bool
+	// Interval is how often the tables are analyzed
+	Interval time.Duration
+	// Vacuum also vacuums the tables, reclaiming the space of deleted and updated runs
+	Vacuum bool
+}
+
+// DBMaintenanceConfig holds configuration for db_maintenance jobs
+type DBMaintenanceConfig struct {
+	// Statements are the SQL statements db_maintenance jobs may run, by name; jobs can't run any other SQL
+	Statements map[string]string
+}
+
+// ConnectionHealthConfig holds configuration for probing the external connections jobs depend on
+type ConnectionHealthConfig struct {
+	// Enabled runs the connection health probes; jobs fail fast while a connection they use is down
+	Enabled bool
+	// Interval is how often every connection is probed
+	Interval time.Duration
+	// Timeout bounds each probe
+	Timeout time.Duration
+	// FailureThreshold is how many probes in a row must fail before a connection is down
+	FailureThreshold int
+}
+
+// ExecutionLogsConfig holds configuration for capturing the log lines of each run
+type ExecutionLogsConfig struct {
+	// MaxLines is how many lines a run keeps; later lines are dropped
+	MaxLines int
+	// FlushInterval is how often captured lines are written, and so how far behind live tailing runs
+	FlushInterval time.Duration
+}
+
+// AlertsConfig holds configuration for evaluating alert rules and pushing their alerts
+type AlertsConfig struct {
+	// AlertmanagerURL is the Alertmanager alerts are pushed to, e.g. http://alertmanager:9093;
+	// when empty alerts are only served on /api/v1/alerts
+	AlertmanagerURL string
+	// EvaluationInterval is how often alert rules are evaluated and firing alerts re-sent
+	EvaluationInterval time.Duration
+	// Timeout bounds each push to Alertmanager
+	Timeout time.Duration
+}
+
+// ChangeControlConfig holds configuration for changes to protected jobs
+type ChangeControlConfig struct {
+	// TwoPersonRuleEnabled requires a second approver for destructive changes to protected jobs
+	TwoPersonRuleEnabled bool
+}
+
+// RBACConfig holds role-based access control configuration
+type RBACConfig struct {
+	// Enabled requires every API request to authenticate its user, with a bearer token signed
+	// with TokenSecret or a verified client certificate, and checks the user's role
+	Enabled bool
+	// TokenSecret signs the bearer tokens identifying users; empty accepts only client certificates
+	TokenSecret string
+	// DefaultRole is the role of users without a role assignment; empty refuses them
+	DefaultRole string
+	// Admins are always admins, so roles can be assigned before any assignment exists
+	Admins []string
+}
+
+// APIConfig holds API versioning configuration
+type APIConfig struct {
+	// V1Sunset is when /api/v1 will be removed, advertised in the Sunset header; zero if not scheduled
+	V1Sunset time.Time
+}
+
+// KubernetesSyncConfig holds configuration for syncing jobs from ScheduledJob resources in a cluster
+type KubernetesSyncConfig struct {
+	Enabled bool
+	// APIServer is the Kubernetes API URL; empty uses the cluster the service runs in
+	APIServer string
+	// ServiceAccountDir holds the service account's token and the cluster's CA certificate
+	ServiceAccountDir string
+	// Namespace limits syncing to one namespace; empty syncs every namespace
+	Namespace string
+	// ResyncInterval is how often every resource is synced again, refreshing their statuses
+	ResyncInterval time.Duration
+	// Actor is the user changes made by syncing are recorded as
+	Actor string
+}
+
+// Replication roles
+const (
+	ReplicationRolePrimary = "primary"
+	ReplicationRoleStandby = "standby"
+)
+
+// ReplicationConfig holds configuration for replicating job definitions to a standby region
+type ReplicationConfig struct {
+	// Role is primary to serve job changes, standby to follow a primary, or empty for neither
+	Role string
+	// PrimaryURL is the base URL of the primary a standby follows
+	PrimaryURL string
+	// Token authenticates a standby to the primary; both must have the same one
+	Token string
+	// PollInterval is how often a standby asks the primary for new changes
+	PollInterval time.Duration
+}
+
 // Load loads configuration from environment variables
 // It first tries to load from .env file, then from system environment
 func Load() (*Config, error) {
@@ -79,6 +451,15 @@ func Load() (*Config, error) {
 	config := &Config{}
 
 	// Load database configuration
+	slowQueryThreshold, err := time.ParseDuration(getEnv("DB_SLOW_QUERY_THRESHOLD", "200ms"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DB_SLOW_QUERY_THRESHOLD: %w", err)
+	}
+	createBatchSize := getEnvAsInt("DB_CREATE_BATCH_SIZE", 100)
+	if createBatchSize < 1 {
+		return nil, fmt.Errorf("invalid DB_CREATE_BATCH_SIZE: %d", createBatchSize)
+	}
+
 	config.Database = DatabaseConfig{
 		Host:     getEnv("DB_HOST", "localhost"),
 		Port:     getEnvAsInt("DB_PORT", 5432),
@@ -86,12 +467,26 @@ func Load() (*Config, error) {
 		Password: getEnv("DB_PASSWORD", "postgres"),
 		Name:     getEnv("DB_NAME", "my_aibo_app"),
 		SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+		SlowQueryThreshold:     slowQueryThreshold,
+		PrepareStmt:            getEnvAsBool("DB_PREPARE_STMT", true),
+		SkipDefaultTransaction: getEnvAsBool("DB_SKIP_DEFAULT_TRANSACTION", true),
+		CreateBatchSize:        createBatchSize,
 	}
 
 	// Load server configuration
+	requestTimeout, err := time.ParseDuration(getEnv("SERVER_REQUEST_TIMEOUT", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SERVER_REQUEST_TIMEOUT: %w", err)
+	}
+	if requestTimeout < 0 {
+		return nil, fmt.Errorf("invalid SERVER_REQUEST_TIMEOUT: %s", requestTimeout)
+	}
+
 	config.Server = ServerConfig{
-		Host: getEnv("SERVER_HOST", "0.0.0.0"),
-		Port: getEnvAsInt("SERVER_PORT", 8080),
+		Host:           getEnv("SERVER_HOST", "0.0.0.0"),
+		Port:           getEnvAsInt("SERVER_PORT", 8080),
+		RequestTimeout: requestTimeout,
 	}
 
 	// Load application configuration
@@ -100,10 +495,140 @@ func Load() (*Config, error) {
 		LogLevel:    getEnv("LOG_LEVEL", "info"),
 	}
 
+	// Load log shipping configuration
+	logFlushInterval, err := time.ParseDuration(getEnv("LOG_SHIPPING_FLUSH_INTERVAL", "1s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOG_SHIPPING_FLUSH_INTERVAL: %w", err)
+	}
+	if logFlushInterval <= 0 {
+		return nil, fmt.Errorf("invalid LOG_SHIPPING_FLUSH_INTERVAL: %s", logFlushInterval)
+	}
+	logShippingTimeout, err := time.ParseDuration(getEnv("LOG_SHIPPING_TIMEOUT", "5s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOG_SHIPPING_TIMEOUT: %w", err)
+	}
+	logBatchSize := getEnvAsInt("LOG_SHIPPING_BATCH_SIZE", 100)
+	if logBatchSize < 1 {
+		return nil, fmt.Errorf("invalid LOG_SHIPPING_BATCH_SIZE: %d", logBatchSize)
+	}
+	logBufferSize := getEnvAsInt("LOG_SHIPPING_BUFFER_SIZE", 10000)
+	if logBufferSize < logBatchSize {
+		return nil, fmt.Errorf("invalid LOG_SHIPPING_BUFFER_SIZE: %d is smaller than the batch size", logBufferSize)
+	}
+
+	config.LogShipping = LogShippingConfig{
+		Backend:       getEnv("LOG_SHIPPING_BACKEND", ""),
+		URL:           strings.TrimSuffix(getEnv("LOG_SHIPPING_URL", ""), "/"),
+		Username:      getEnv("LOG_SHIPPING_USERNAME", ""),
+		Password:      getEnv("LOG_SHIPPING_PASSWORD", ""),
+		Index:         getEnv("LOG_SHIPPING_INDEX", "job-scheduler-logs"),
+		Service:       getEnv("LOG_SHIPPING_SERVICE", "job-scheduler"),
+		Level:         getEnv("LOG_SHIPPING_LEVEL", "info"),
+		BatchSize:     logBatchSize,
+		FlushInterval: logFlushInterval,
+		BufferSize:    logBufferSize,
+		Timeout:       logShippingTimeout,
+	}
+
 	// Load scheduler configuration
+	overloadQueueWait, err := time.ParseDuration(getEnv("SCHEDULER_OVERLOAD_QUEUE_WAIT", "5s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCHEDULER_OVERLOAD_QUEUE_WAIT: %w", err)
+	}
+	maxQueueWait, err := time.ParseDuration(getEnv("SCHEDULER_MAX_QUEUE_WAIT", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCHEDULER_MAX_QUEUE_WAIT: %w", err)
+	}
+	maxQueueDepth := getEnvAsInt("SCHEDULER_MAX_QUEUE_DEPTH", 100)
+	if maxQueueDepth < 1 {
+		return nil, fmt.Errorf("invalid SCHEDULER_MAX_QUEUE_DEPTH: %d", maxQueueDepth)
+	}
+	shedBelowSeverity := getEnv("SCHEDULER_SHED_BELOW_SEVERITY", "high")
+	switch shedBelowSeverity {
+	case "low", "medium", "high", "critical":
+	default:
+		return nil, fmt.Errorf("invalid SCHEDULER_SHED_BELOW_SEVERITY: %s", shedBelowSeverity)
+	}
+
+	executionTimeout, err := time.ParseDuration(getEnv("SCHEDULER_EXECUTION_TIMEOUT", "10m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCHEDULER_EXECUTION_TIMEOUT: %w", err)
+	}
+	maxExecutionTime, err := time.ParseDuration(getEnv("SCHEDULER_MAX_EXECUTION_TIME", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCHEDULER_MAX_EXECUTION_TIME: %w", err)
+	}
+	if maxExecutionTime < executionTimeout {
+		return nil, fmt.Errorf("invalid SCHEDULER_MAX_EXECUTION_TIME: %s is shorter than SCHEDULER_EXECUTION_TIMEOUT", maxExecutionTime)
+	}
+	shutdownGracePeriod, err := time.ParseDuration(getEnv("SCHEDULER_SHUTDOWN_GRACE_PERIOD", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCHEDULER_SHUTDOWN_GRACE_PERIOD: %w", err)
+	}
+	if shutdownGracePeriod < 0 {
+		return nil, fmt.Errorf("invalid SCHEDULER_SHUTDOWN_GRACE_PERIOD: %s", shutdownGracePeriod)
+	}
+	interruptedRuns := getEnv("SCHEDULER_INTERRUPTED_RUNS", "requeue")
+	switch interruptedRuns {
+	case "requeue", "fail":
+	default:
+		return nil, fmt.Errorf("invalid SCHEDULER_INTERRUPTED_RUNS: %s", interruptedRuns)
+	}
+	timeoutWarningPercent := getEnvAsInt("SCHEDULER_TIMEOUT_WARNING_PERCENT", 80)
+	if timeoutWarningPercent < 1 || timeoutWarningPercent > 99 {
+		return nil, fmt.Errorf("invalid SCHEDULER_TIMEOUT_WARNING_PERCENT: %d", timeoutWarningPercent)
+	}
+
+	healthScoreInterval, err := time.ParseDuration(getEnv("SCHEDULER_HEALTH_SCORE_INTERVAL", "15m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCHEDULER_HEALTH_SCORE_INTERVAL: %w", err)
+	}
+	if healthScoreInterval <= 0 {
+		return nil, fmt.Errorf("invalid SCHEDULER_HEALTH_SCORE_INTERVAL: %s", healthScoreInterval)
+	}
+
+	teamWeights, err := parseTeamWeights(getEnvAsList("SCHEDULER_TEAM_WEIGHTS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCHEDULER_TEAM_WEIGHTS: %w", err)
+	}
+	groupLimits, err := parseConcurrencyGroupLimits(getEnvAsList("SCHEDULER_CONCURRENCY_GROUP_LIMITS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCHEDULER_CONCURRENCY_GROUP_LIMITS: %w", err)
+	}
+	startRateLimit, err := parseRateLimit(getEnv("SCHEDULER_START_RATE_LIMIT", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCHEDULER_START_RATE_LIMIT: %w", err)
+	}
+	jobTypeRateLimits, err := parseJobTypeRateLimits(getEnvAsList("SCHEDULER_JOB_TYPE_START_RATE_LIMITS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCHEDULER_JOB_TYPE_START_RATE_LIMITS: %w", err)
+	}
+	hostname, _ := os.Hostname()
+
 	config.Scheduler = SchedulerConfig{
 		Enabled:           getEnvAsBool("SCHEDULER_ENABLED", true),
 		MaxConcurrentJobs: getEnvAsInt("MAX_CONCURRENT_JOBS", 10),
+		OverloadThreshold: getEnvAsInt("SCHEDULER_OVERLOAD_THRESHOLD", 80),
+		OverloadQueueWait: overloadQueueWait,
+		MaxQueueWait:      maxQueueWait,
+		MaxQueueDepth:     maxQueueDepth,
+		ShedBelowSeverity: shedBelowSeverity,
+		TeamWeights:       teamWeights,
+		InstanceID:        getEnv("SCHEDULER_INSTANCE_ID", hostname),
+
+		ConcurrencyGroupLimits: groupLimits,
+		StartRateLimit:         startRateLimit,
+		JobTypeStartRateLimits: jobTypeRateLimits,
+
+		ExecutionTimeout:      executionTimeout,
+		MaxExecutionTime:      maxExecutionTime,
+		ShutdownGracePeriod:   shutdownGracePeriod,
+		InterruptedRuns:       interruptedRuns,
+		RetryStalledRuns:      getEnvAsBool("SCHEDULER_RETRY_STALLED_RUNS", false),
+		TimeoutWarningPercent: timeoutWarningPercent,
+		HealthScoreInterval:   healthScoreInterval,
+		CronSeconds:           getEnvAsBool("SCHEDULER_CRON_SECONDS", false),
+		UniqueJobNames:        getEnvAsBool("SCHEDULER_UNIQUE_JOB_NAMES", false),
 	}
 
 	// Load health check configuration
@@ -117,11 +642,346 @@ func Load() (*Config, error) {
 		Timeout: healthCheckTimeout,
 	}
 
+	// Load outbound HTTP client configuration
+	httpRetryBackoff, err := time.ParseDuration(getEnv("HTTP_CLIENT_RETRY_BACKOFF", "250ms"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTTP_CLIENT_RETRY_BACKOFF: %w", err)
+	}
+	httpIdleConnTimeout, err := time.ParseDuration(getEnv("HTTP_CLIENT_IDLE_CONN_TIMEOUT", "90s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTTP_CLIENT_IDLE_CONN_TIMEOUT: %w", err)
+	}
+
+	config.HTTPClient = HTTPClientConfig{
+		MaxRetries:          getEnvAsInt("HTTP_CLIENT_MAX_RETRIES", 2),
+		RetryBackoff:        httpRetryBackoff,
+		MaxIdleConns:        getEnvAsInt("HTTP_CLIENT_MAX_IDLE_CONNS", 100),
+		MaxIdleConnsPerHost: getEnvAsInt("HTTP_CLIENT_MAX_IDLE_CONNS_PER_HOST", 10),
+		IdleConnTimeout:     httpIdleConnTimeout,
+		ProxyURL:            getEnv("HTTP_CLIENT_PROXY_URL", ""),
+		CAFile:              getEnv("HTTP_CLIENT_CA_FILE", ""),
+		InsecureSkipVerify:  getEnvAsBool("HTTP_CLIENT_INSECURE_SKIP_VERIFY", false),
+	}
+
 	// Load reports configuration
 	config.Reports = ReportsConfig{
 		Directory: getEnv("REPORTS_DIR", "./reports"),
 	}
 
+	// Load data processing configuration
+	config.DataProcessing = DataProcessingConfig{
+		Directory:      getEnv("DATA_PROCESSING_DIR", "./data"),
+		InternalTables: getEnvAsList("DATA_PROCESSING_INTERNAL_TABLES"),
+	}
+
+	// Load artifact storage configuration
+	artifactURLExpiry, err := time.ParseDuration(getEnv("ARTIFACTS_URL_EXPIRY", "15m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ARTIFACTS_URL_EXPIRY: %w", err)
+	}
+	artifactRetention, err := time.ParseDuration(getEnv("ARTIFACTS_RETENTION", "720h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ARTIFACTS_RETENTION: %w", err)
+	}
+
+	artifactJobQuota, err := parseByteSize(getEnv("ARTIFACTS_JOB_QUOTA", "0"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ARTIFACTS_JOB_QUOTA: %w", err)
+	}
+	artifactTeamQuota, err := parseByteSize(getEnv("ARTIFACTS_TEAM_QUOTA", "0"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ARTIFACTS_TEAM_QUOTA: %w", err)
+	}
+	artifactMaintenanceInterval, err := time.ParseDuration(getEnv("ARTIFACTS_MAINTENANCE_INTERVAL", "15m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ARTIFACTS_MAINTENANCE_INTERVAL: %w", err)
+	}
+
+	config.Artifacts = ArtifactsConfig{
+		Backend:             getEnv("ARTIFACTS_STORAGE", "local"),
+		Directory:           getEnv("ARTIFACTS_DIR", "./artifacts"),
+		URLExpiry:           artifactURLExpiry,
+		Retention:           artifactRetention,
+		JobQuotaBytes:       artifactJobQuota,
+		TeamQuotaBytes:      artifactTeamQuota,
+		EvictionThreshold:   getEnvAsInt("ARTIFACTS_EVICTION_THRESHOLD", 90),
+		MaintenanceInterval: artifactMaintenanceInterval,
+		SigningSecret:       getEnv("ARTIFACTS_SIGNING_SECRET", ""),
+		PublicBaseURL:       strings.TrimSuffix(getEnv("ARTIFACTS_PUBLIC_BASE_URL", ""), "/"),
+		S3Bucket:            getEnv("ARTIFACTS_S3_BUCKET", ""),
+		S3Region:            getEnv("ARTIFACTS_S3_REGION", getEnv("AWS_REGION", "us-east-1")),
+		S3Endpoint:          strings.TrimSuffix(getEnv("ARTIFACTS_S3_ENDPOINT", ""), "/"),
+		S3Prefix:            getEnv("ARTIFACTS_S3_PREFIX", ""),
+		GCSBucket:           getEnv("ARTIFACTS_GCS_BUCKET", ""),
+		GCSCredentialsFile:  getEnv("ARTIFACTS_GCS_CREDENTIALS_FILE", ""),
+		GCSPrefix:           getEnv("ARTIFACTS_GCS_PREFIX", ""),
+	}
+
+	// Load message queue trigger configuration
+	triggerReloadInterval, err := time.ParseDuration(getEnv("TRIGGERS_RELOAD_INTERVAL", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRIGGERS_RELOAD_INTERVAL: %w", err)
+	}
+
+	config.Triggers = TriggersConfig{
+		Enabled:         getEnvAsBool("TRIGGERS_ENABLED", false),
+		ReloadInterval:  triggerReloadInterval,
+		AWSRegion:       getEnv("AWS_REGION", "us-east-1"),
+		AWSAccessKeyID:  getEnv("AWS_ACCESS_KEY_ID", ""),
+		AWSSecretKey:    getEnv("AWS_SECRET_ACCESS_KEY", ""),
+		AWSSessionToken: getEnv("AWS_SESSION_TOKEN", ""),
+		GCPAccessToken:  getEnv("GCP_ACCESS_TOKEN", ""),
+	}
+
+	// Load notification configuration
+	notificationTimeout, err := time.ParseDuration(getEnv("NOTIFICATION_TIMEOUT", "10s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid NOTIFICATION_TIMEOUT: %w", err)
+	}
+
+	notificationFailureThreshold := getEnvAsInt("NOTIFICATION_FAILURE_THRESHOLD", 1)
+	if notificationFailureThreshold < 1 {
+		return nil, fmt.Errorf("invalid NOTIFICATION_FAILURE_THRESHOLD: %d", notificationFailureThreshold)
+	}
+	notificationDurationThreshold, err := time.ParseDuration(getEnv("NOTIFICATION_DURATION_THRESHOLD", "0"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid NOTIFICATION_DURATION_THRESHOLD: %w", err)
+	}
+	if notificationDurationThreshold < 0 {
+		return nil, fmt.Errorf("invalid NOTIFICATION_DURATION_THRESHOLD: %s", notificationDurationThreshold)
+	}
+
+	config.Notifications = NotificationsConfig{
+		WebhookURL:      getEnv("NOTIFICATION_WEBHOOK_URL", ""),
+		SlackWebhookURL: getEnv("NOTIFICATION_SLACK_WEBHOOK_URL", ""),
+		Timeout:         notificationTimeout,
+		SMTPHost:        getEnv("SMTP_HOST", ""),
+		SMTPPort:        getEnvAsInt("SMTP_PORT", 587),
+		SMTPUsername:    getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:    getEnv("SMTP_PASSWORD", ""),
+		SMTPPoolSize:    getEnvAsInt("SMTP_POOL_SIZE", 2),
+		EmailFrom:       getEnv("NOTIFICATION_EMAIL_FROM", "job-scheduler@localhost"),
+		EmailTo:         getEnvAsList("NOTIFICATION_EMAIL_TO"),
+
+		EmailEventsToken: getEnv("EMAIL_EVENTS_TOKEN", ""),
+
+		FailureThreshold:  notificationFailureThreshold,
+		DurationThreshold: notificationDurationThreshold,
+	}
+
+	// Load approval configuration
+	approvalTimeout, err := time.ParseDuration(getEnv("APPROVAL_TIMEOUT", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid APPROVAL_TIMEOUT: %w", err)
+	}
+
+	config.Approvals = ApprovalsConfig{
+		Timeout: approvalTimeout,
+	}
+
+	// Load remediation configuration
+	remediationLinkExpiry, err := time.ParseDuration(getEnv("REMEDIATION_LINK_EXPIRY", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REMEDIATION_LINK_EXPIRY: %w", err)
+	}
+
+	config.Remediation = RemediationConfig{
+		SigningSecret: getEnv("REMEDIATION_SIGNING_SECRET", ""),
+		PublicBaseURL: strings.TrimSuffix(getEnv("REMEDIATION_PUBLIC_BASE_URL", "http://localhost:8080"), "/"),
+		LinkExpiry:    remediationLinkExpiry,
+	}
+
+	// Load run history retention configuration
+	historyRetention, err := time.ParseDuration(getEnv("HISTORY_RETENTION", "2160h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid HISTORY_RETENTION: %w", err)
+	}
+	historyCleanupInterval, err := time.ParseDuration(getEnv("HISTORY_CLEANUP_INTERVAL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid HISTORY_CLEANUP_INTERVAL: %w", err)
+	}
+	if historyCleanupInterval <= 0 {
+		return nil, fmt.Errorf("invalid HISTORY_CLEANUP_INTERVAL: %s", historyCleanupInterval)
+	}
+	historyBatchSize := getEnvAsInt("HISTORY_DELETE_BATCH_SIZE", 1000)
+	if historyBatchSize <= 0 {
+		return nil, fmt.Errorf("invalid HISTORY_DELETE_BATCH_SIZE: %d", historyBatchSize)
+	}
+
+	config.History = HistoryConfig{
+		Retention:       historyRetention,
+		DeleteBatchSize: historyBatchSize,
+		CleanupInterval: historyCleanupInterval,
+	}
+
+	// Load table maintenance configuration
+	tableMaintenanceInterval, err := time.ParseDuration(getEnv("TABLE_MAINTENANCE_INTERVAL", "6h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TABLE_MAINTENANCE_INTERVAL: %w", err)
+	}
+	if tableMaintenanceInterval <= 0 {
+		return nil, fmt.Errorf("invalid TABLE_MAINTENANCE_INTERVAL: %s", tableMaintenanceInterval)
+	}
+
+	config.TableMaintenance = TableMaintenanceConfig{
+		Enabled:  getEnvAsBool("TABLE_MAINTENANCE_ENABLED", true),
+		Interval: tableMaintenanceInterval,
+		Vacuum:   getEnvAsBool("TABLE_MAINTENANCE_VACUUM", false),
+	}
+
+	// Load db_maintenance job configuration
+	maintenanceStatements := map[string]string{}
+	if raw := getEnv("DB_MAINTENANCE_STATEMENTS", ""); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &maintenanceStatements); err != nil {
+			return nil, fmt.Errorf("invalid DB_MAINTENANCE_STATEMENTS: %w", err)
+		}
+	}
+	for name, statement := range maintenanceStatements {
+		if name == "" || strings.TrimSpace(statement) == "" {
+			return nil, fmt.Errorf("invalid DB_MAINTENANCE_STATEMENTS: statement %q is empty", name)
+		}
+	}
+
+	config.DBMaintenance = DBMaintenanceConfig{
+		Statements: maintenanceStatements,
+	}
+
+	// Load connection health configuration
+	connectionHealthInterval, err := time.ParseDuration(getEnv("CONNECTION_HEALTH_INTERVAL", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CONNECTION_HEALTH_INTERVAL: %w", err)
+	}
+	if connectionHealthInterval <= 0 {
+		return nil, fmt.Errorf("invalid CONNECTION_HEALTH_INTERVAL: %s", connectionHealthInterval)
+	}
+	connectionHealthTimeout, err := time.ParseDuration(getEnv("CONNECTION_HEALTH_TIMEOUT", "5s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CONNECTION_HEALTH_TIMEOUT: %w", err)
+	}
+	connectionHealthThreshold := getEnvAsInt("CONNECTION_HEALTH_FAILURE_THRESHOLD", 3)
+	if connectionHealthThreshold < 1 {
+		return nil, fmt.Errorf("invalid CONNECTION_HEALTH_FAILURE_THRESHOLD: %d", connectionHealthThreshold)
+	}
+
+	config.ConnectionHealth = ConnectionHealthConfig{
+		Enabled:          getEnvAsBool("CONNECTION_HEALTH_ENABLED", true),
+		Interval:         connectionHealthInterval,
+		Timeout:          connectionHealthTimeout,
+		FailureThreshold: connectionHealthThreshold,
+	}
+
+	// Load execution log configuration
+	executionLogsMaxLines := getEnvAsInt("EXECUTION_LOGS_MAX_LINES", 5000)
+	if executionLogsMaxLines < 1 {
+		return nil, fmt.Errorf("invalid EXECUTION_LOGS_MAX_LINES: %d", executionLogsMaxLines)
+	}
+	executionLogsFlushInterval, err := time.ParseDuration(getEnv("EXECUTION_LOGS_FLUSH_INTERVAL", "1s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid EXECUTION_LOGS_FLUSH_INTERVAL: %w", err)
+	}
+	if executionLogsFlushInterval <= 0 {
+		return nil, fmt.Errorf("invalid EXECUTION_LOGS_FLUSH_INTERVAL: %s", executionLogsFlushInterval)
+	}
+
+	config.ExecutionLogs = ExecutionLogsConfig{
+		MaxLines:      executionLogsMaxLines,
+		FlushInterval: executionLogsFlushInterval,
+	}
+
+	// Load alerting configuration
+	alertEvaluationInterval, err := time.ParseDuration(getEnv("ALERTS_EVALUATION_INTERVAL", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ALERTS_EVALUATION_INTERVAL: %w", err)
+	}
+	if alertEvaluationInterval <= 0 {
+		return nil, fmt.Errorf("invalid ALERTS_EVALUATION_INTERVAL: %s", alertEvaluationInterval)
+	}
+	alertmanagerTimeout, err := time.ParseDuration(getEnv("ALERTMANAGER_TIMEOUT", "10s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ALERTMANAGER_TIMEOUT: %w", err)
+	}
+
+	config.Alerts = AlertsConfig{
+		AlertmanagerURL:    strings.TrimSuffix(getEnv("ALERTMANAGER_URL", ""), "/"),
+		EvaluationInterval: alertEvaluationInterval,
+		Timeout:            alertmanagerTimeout,
+	}
+
+	// Load change control configuration
+	config.ChangeControl = ChangeControlConfig{
+		TwoPersonRuleEnabled: getEnvAsBool("TWO_PERSON_RULE_ENABLED", false),
+	}
+
+	// Load role-based access control configuration
+	rbacDefaultRole := getEnv("RBAC_DEFAULT_ROLE", "viewer")
+	switch rbacDefaultRole {
+	case "", "viewer", "operator", "admin":
+	default:
+		return nil, fmt.Errorf("invalid RBAC_DEFAULT_ROLE: %s", rbacDefaultRole)
+	}
+
+	config.RBAC = RBACConfig{
+		Enabled:     getEnvAsBool("RBAC_ENABLED", false),
+		DefaultRole: rbacDefaultRole,
+		Admins:      getEnvAsList("RBAC_ADMINS"),
+		TokenSecret: getEnv("RBAC_TOKEN_SECRET", ""),
+	}
+
+	// Load API versioning configuration
+	if sunset := getEnv("API_V1_SUNSET", ""); sunset != "" {
+		v1Sunset, err := time.Parse("2006-01-02", sunset)
+		if err != nil {
+			return nil, fmt.Errorf("invalid API_V1_SUNSET: %w", err)
+		}
+		config.API.V1Sunset = v1Sunset
+	}
+
+	// Load Kubernetes sync configuration
+	kubernetesResyncInterval, err := time.ParseDuration(getEnv("KUBERNETES_SYNC_RESYNC_INTERVAL", "5m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid KUBERNETES_SYNC_RESYNC_INTERVAL: %w", err)
+	}
+	if kubernetesResyncInterval < time.Second {
+		return nil, fmt.Errorf("invalid KUBERNETES_SYNC_RESYNC_INTERVAL: must be at least 1s")
+	}
+
+	config.KubernetesSync = KubernetesSyncConfig{
+		Enabled:           getEnvAsBool("KUBERNETES_SYNC_ENABLED", false),
+		APIServer:         getEnv("KUBERNETES_API_SERVER", ""),
+		ServiceAccountDir: getEnv("KUBERNETES_SERVICE_ACCOUNT_DIR", "/var/run/secrets/kubernetes.io/serviceaccount"),
+		Namespace:         getEnv("KUBERNETES_SYNC_NAMESPACE", ""),
+		ResyncInterval:    kubernetesResyncInterval,
+		Actor:             getEnv("KUBERNETES_SYNC_ACTOR", "kubernetes"),
+	}
+
+	// Load replication configuration
+	replicationRole := getEnv("REPLICATION_ROLE", "")
+	switch replicationRole {
+	case "", ReplicationRolePrimary, ReplicationRoleStandby:
+	default:
+		return nil, fmt.Errorf("invalid REPLICATION_ROLE: %s", replicationRole)
+	}
+	replicationPollInterval, err := time.ParseDuration(getEnv("REPLICATION_POLL_INTERVAL", "5s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REPLICATION_POLL_INTERVAL: %w", err)
+	}
+	if replicationPollInterval <= 0 {
+		return nil, fmt.Errorf("invalid REPLICATION_POLL_INTERVAL: %s", replicationPollInterval)
+	}
+
+	config.Replication = ReplicationConfig{
+		Role:         replicationRole,
+		PrimaryURL:   getEnv("REPLICATION_PRIMARY_URL", ""),
+		Token:        getEnv("REPLICATION_TOKEN", ""),
+		PollInterval: replicationPollInterval,
+	}
+	if config.Replication.Role != "" && config.Replication.Token == "" {
+		return nil, fmt.Errorf("REPLICATION_TOKEN must be set when REPLICATION_ROLE is set")
+	}
+	if config.Replication.Role == ReplicationRoleStandby && config.Replication.PrimaryURL == "" {
+		return nil, fmt.Errorf("REPLICATION_PRIMARY_URL must be set on a standby")
+	}
+
 	return config, nil
 }
 
@@ -188,3 +1048,116 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+// getEnvAsList gets a comma-separated environment variable as a list, skipping empty entries
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// parseTeamWeights parses weights such as ["payments=3", "reports=1"]
+func parseTeamWeights(entries []string) (map[string]int, error) {
+	weights := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("expected team=weight, got %q", entry)
+		}
+		weight, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || weight < 1 {
+			return nil, fmt.Errorf("weight for team %s must be a positive integer", parts[0])
+		}
+		weights[strings.TrimSpace(parts[0])] = weight
+	}
+	return weights, nil
+}
+
+// parseConcurrencyGroupLimits parses limits such as ["reporting=2", "etl=1"]
+func parseConcurrencyGroupLimits(entries []string) (map[string]int, error) {
+	limits := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("expected group=limit, got %q", entry)
+		}
+		limit, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || limit < 1 {
+			return nil, fmt.Errorf("limit for group %s must be a positive integer", parts[0])
+		}
+		limits[strings.TrimSpace(parts[0])] = limit
+	}
+	return limits, nil
+}
+
+// parseRateLimit parses a rate limit such as "100/1m"; an empty value is unlimited
+func parseRateLimit(value string) (RateLimit, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return RateLimit{}, nil
+	}
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return RateLimit{}, fmt.Errorf("expected limit/window, got %q", value)
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || limit < 1 {
+		return RateLimit{}, fmt.Errorf("limit of %q must be a positive integer", value)
+	}
+	window, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+	if err != nil || window <= 0 {
+		return RateLimit{}, fmt.Errorf("window of %q must be a positive duration", value)
+	}
+	return RateLimit{Limit: limit, Window: window}, nil
+}
+
+// parseJobTypeRateLimits parses rate limits such as ["email_notification=100/1m", "report_generation=10/1m"]
+func parseJobTypeRateLimits(entries []string) (map[string]RateLimit, error) {
+	limits := make(map[string]RateLimit, len(entries))
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("expected job_type=limit/window, got %q", entry)
+		}
+		limit, err := parseRateLimit(parts[1])
+		if err != nil || limit.Limit == 0 {
+			return nil, fmt.Errorf("rate limit for job type %s must be limit/window, e.g. 100/1m", parts[0])
+		}
+		limits[strings.TrimSpace(parts[0])] = limit
+	}
+	return limits, nil
+}
+
+// parseByteSize parses a size such as "500MB" or "10GB" into bytes; plain numbers are bytes
+func parseByteSize(value string) (int64, error) {
+	units := []struct {
+		suffix     string
+		multiplier int64
+	}{
+		{"TB", 1 << 40},
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	}
+
+	value = strings.ToUpper(strings.TrimSpace(value))
+	multiplier := int64(1)
+	for _, unit := range units {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			multiplier = unit.multiplier
+			break
+		}
+	}
+
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("invalid size: %s", value)
+	}
+	return size * multiplier, nil
+}
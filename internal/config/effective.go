@@ -0,0 +1,100 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// redactedValue replaces secrets that are set in the effective configuration
+const redactedValue = "[REDACTED]"
+
+// secretFieldMarkers are the parts of field names holding secrets, such as passwords and access keys
+var secretFieldMarkers = []string{"Password", "Secret", "Token", "AccessKey"}
+
+// Effective returns the configuration this instance runs with, after .env, environment variables and
+// defaults were merged, as nested maps keyed by field name. Secrets are redacted: passwords, keys and
+// tokens are masked when set, webhook URLs (which embed their credentials) are masked, and other URLs
+// lose the password in their user info
+func (c *Config) Effective() map[string]interface{} {
+	return effectiveStruct(reflect.ValueOf(*c))
+}
+
+// effectiveStruct maps a config struct's exported fields
+func effectiveStruct(v reflect.Value) map[string]interface{} {
+	fields := make(map[string]interface{}, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		fields[field.Name] = effectiveValue(field.Name, v.Field(i))
+	}
+	return fields
+}
+
+// effectiveValue maps one field's value, redacting it if it holds a secret
+func effectiveValue(name string, v reflect.Value) interface{} {
+	if duration, ok := v.Interface().(time.Duration); ok {
+		return duration.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return effectiveStruct(v)
+	case reflect.String:
+		return redactString(name, v.String())
+	default:
+		return v.Interface()
+	}
+}
+
+// redactString masks a secret or webhook URL and strips the password from other URLs
+func redactString(name, value string) string {
+	if value == "" {
+		return value
+	}
+	if isSecretField(name) || strings.HasSuffix(name, "WebhookURL") {
+		return redactedValue
+	}
+	if strings.HasSuffix(name, "URL") {
+		if parsed, err := url.Parse(value); err == nil && parsed.User != nil {
+			return parsed.Redacted()
+		}
+	}
+	return value
+}
+
+// isSecretField reports whether a field's name marks it as holding a secret
+func isSecretField(name string) bool {
+	for _, marker := range secretFieldMarkers {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// LogStartupBanner logs a summary of the effective configuration, without secrets, so the logs show
+// what an instance was started with. The full configuration is served on GET /api/v1/admin/config
+func (c *Config) LogStartupBanner() {
+	logrus.WithFields(logrus.Fields{
+		"environment":          c.App.Environment,
+		"log_level":            c.App.LogLevel,
+		"address":              c.GetServerAddress(),
+		"database":             fmt.Sprintf("%s:%d/%s", c.Database.Host, c.Database.Port, c.Database.Name),
+		"max_concurrent_jobs":  c.Scheduler.MaxConcurrentJobs,
+		"execution_timeout":    c.Scheduler.ExecutionTimeout.String(),
+		"artifacts_backend":    c.Artifacts.Backend,
+		"log_shipping":         c.LogShipping.Backend,
+		"rbac_enabled":         c.RBAC.Enabled,
+		"notification_webhook": c.Notifications.WebhookURL != "",
+		"notification_slack":   c.Notifications.SlackWebhookURL != "",
+		"notification_email":   c.Notifications.SMTPHost != "" && len(c.Notifications.EmailTo) > 0,
+		"alertmanager":         redactString("AlertmanagerURL", c.Alerts.AlertmanagerURL),
+	}).Info("Job scheduler starting")
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// AlertRuleResponse is the public representation of an alert rule
+type AlertRuleResponse struct {
+	ID          uuid.UUID          `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Metric      models.AlertMetric `json:"metric"`
+	Threshold   float64            `json:"threshold"`
+	Window      int                `json:"window"`
+	JobID       *uuid.UUID         `json:"job_id,omitempty"`
+	Team        string             `json:"team,omitempty"`
+	Severity    string             `json:"severity"`
+	IsActive    bool               `json:"is_active"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// FromAlertRule maps an alert rule to its public representation
+func FromAlertRule(rule *models.AlertRule) AlertRuleResponse {
+	return AlertRuleResponse{
+		ID:          rule.ID,
+		Name:        rule.Name,
+		Description: rule.Description,
+		Metric:      rule.Metric,
+		Threshold:   rule.Threshold,
+		Window:      rule.Window,
+		JobID:       rule.JobID,
+		Team:        rule.Team,
+		Severity:    rule.Severity,
+		IsActive:    rule.IsActive,
+		CreatedAt:   rule.CreatedAt,
+		UpdatedAt:   rule.UpdatedAt,
+	}
+}
+
+// FromAlertRules maps a slice of alert rules
+func FromAlertRules(rules []models.AlertRule) []AlertRuleResponse {
+	responses := make([]AlertRuleResponse, 0, len(rules))
+	for i := range rules {
+		responses = append(responses, FromAlertRule(&rules[i]))
+	}
+	return responses
+}
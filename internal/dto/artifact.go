@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// ArtifactResponse is the public representation of a run artifact
+// The storage location is never exposed - downloads go through signed URLs
+type ArtifactResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	ExecutionID uuid.UUID  `json:"execution_id"`
+	Kind        string     `json:"kind"`
+	Name        string     `json:"name"`
+	ContentType string     `json:"content_type"`
+	SizeBytes   int64      `json:"size_bytes"`
+	DownloadURL string     `json:"download_url"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ExecutionArtifactsResponse groups the artifacts produced by one execution
+type ExecutionArtifactsResponse struct {
+	ExecutionID uuid.UUID          `json:"execution_id"`
+	Artifacts   []ArtifactResponse `json:"artifacts"`
+}
+
+// ArtifactDownloadPath returns the path that issues signed download URLs for an artifact
+func ArtifactDownloadPath(artifact *models.Artifact) string {
+	return "/api/v1/artifacts/" + artifact.ID.String() + "/download"
+}
+
+// FromArtifact maps an artifact to its public representation
+func FromArtifact(artifact *models.Artifact) ArtifactResponse {
+	return ArtifactResponse{
+		ID:          artifact.ID,
+		ExecutionID: artifact.ExecutionID,
+		Kind:        string(artifact.Kind),
+		Name:        artifact.Name,
+		ContentType: artifact.ContentType,
+		SizeBytes:   artifact.SizeBytes,
+		DownloadURL: ArtifactDownloadPath(artifact),
+		ExpiresAt:   artifact.ExpiresAt,
+		CreatedAt:   artifact.CreatedAt,
+	}
+}
+
+// FromArtifacts maps a slice of artifacts
+func FromArtifacts(artifacts []models.Artifact) []ArtifactResponse {
+	responses := make([]ArtifactResponse, 0, len(artifacts))
+	for i := range artifacts {
+		responses = append(responses, FromArtifact(&artifacts[i]))
+	}
+	return responses
+}
+
+// FromArtifactsByExecution groups artifacts by execution, keeping their order
+func FromArtifactsByExecution(artifacts []models.Artifact) []ExecutionArtifactsResponse {
+	responses := make([]ExecutionArtifactsResponse, 0)
+	index := make(map[uuid.UUID]int)
+	for i := range artifacts {
+		artifact := &artifacts[i]
+		pos, ok := index[artifact.ExecutionID]
+		if !ok {
+			pos = len(responses)
+			index[artifact.ExecutionID] = pos
+			responses = append(responses, ExecutionArtifactsResponse{ExecutionID: artifact.ExecutionID})
+		}
+		responses[pos].Artifacts = append(responses[pos].Artifacts, FromArtifact(artifact))
+	}
+	return responses
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// DatabaseConnectionResponse is the public representation of a registered external database
+// Passwords are never stored, so only the reference to the secret holding it is exposed
+type DatabaseConnectionResponse struct {
+	ID                     uuid.UUID `json:"id"`
+	Name                   string    `json:"name"`
+	Description            string    `json:"description"`
+	Driver                 string    `json:"driver"`
+	DSN                    string    `json:"dsn"`
+	Username               string    `json:"username,omitempty"`
+	PasswordSecret         string    `json:"password_secret,omitempty"`
+	MaxOpenConns           int       `json:"max_open_conns"`
+	MaxIdleConns           int       `json:"max_idle_conns"`
+	ConnMaxLifetimeSeconds int       `json:"conn_max_lifetime_seconds"`
+	CreatedAt              time.Time `json:"created_at"`
+	UpdatedAt              time.Time `json:"updated_at"`
+}
+
+// FromDatabaseConnection maps a database connection to its public representation
+func FromDatabaseConnection(connection *models.DatabaseConnection) DatabaseConnectionResponse {
+	return DatabaseConnectionResponse{
+		ID:                     connection.ID,
+		Name:                   connection.Name,
+		Description:            connection.Description,
+		Driver:                 string(connection.Driver),
+		DSN:                    connection.DSN,
+		Username:               connection.Username,
+		PasswordSecret:         connection.PasswordSecret,
+		MaxOpenConns:           connection.MaxOpenConns,
+		MaxIdleConns:           connection.MaxIdleConns,
+		ConnMaxLifetimeSeconds: connection.ConnMaxLifetimeSeconds,
+		CreatedAt:              connection.CreatedAt,
+		UpdatedAt:              connection.UpdatedAt,
+	}
+}
+
+// FromDatabaseConnections maps a slice of database connections
+func FromDatabaseConnections(connections []models.DatabaseConnection) []DatabaseConnectionResponse {
+	responses := make([]DatabaseConnectionResponse, 0, len(connections))
+	for i := range connections {
+		responses = append(responses, FromDatabaseConnection(&connections[i]))
+	}
+	return responses
+}
@@ -0,0 +1,38 @@
+package dto
+
+// Error codes used in the v2 error envelope
+const (
+	ErrorCodeInvalidRequest = "invalid_request"
+	ErrorCodeNotFound       = "not_found"
+	ErrorCodeUnauthorized   = "unauthorized"
+	ErrorCodeForbidden      = "forbidden"
+	ErrorCodeConflict       = "conflict"
+	ErrorCodeNotAcceptable  = "not_acceptable"
+	ErrorCodeInternal       = "internal_error"
+	ErrorCodeTimeout        = "timeout"
+)
+
+// ErrorBody describes what went wrong
+type ErrorBody struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Details   string `json:"details,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// ErrorResponse is the v2 error envelope: {"error": {"code", "message", "details", "request_id"}}
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// NewErrorResponse builds an error envelope, using err for the details when set
+func NewErrorResponse(code, message string, err error) ErrorResponse {
+	body := ErrorBody{
+		Code:    code,
+		Message: message,
+	}
+	if err != nil {
+		body.Details = err.Error()
+	}
+	return ErrorResponse{Error: body}
+}
@@ -0,0 +1,100 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// ExecutionResponse is the public representation of a job run
+type ExecutionResponse struct {
+	ID                  uuid.UUID              `json:"id"`
+	JobID               uuid.UUID              `json:"job_id"`
+	Job                 *JobSummary            `json:"job,omitempty"`
+	Status              string                 `json:"status"`
+	StartedAt           time.Time              `json:"started_at"`
+	CompletedAt         *time.Time             `json:"completed_at"`
+	ScheduledFor        *time.Time             `json:"scheduled_for,omitempty"`
+	ErrorMessage        *string                `json:"error_message"`
+	ExecutionDurationMs *int64                 `json:"execution_duration_ms"`
+	Parameters          map[string]interface{} `json:"parameters,omitempty"`
+	ApprovedBy          *string                `json:"approved_by,omitempty"`
+	ApprovedAt          *time.Time             `json:"approved_at,omitempty"`
+	ApprovalExpiresAt   *time.Time             `json:"approval_expires_at,omitempty"`
+	ResourceUsage       *models.ResourceUsage  `json:"resource_usage,omitempty"`
+	SideEffects         map[string]string      `json:"side_effects,omitempty"`
+	Termination         *string                `json:"termination,omitempty"`
+	Attempt             int                    `json:"attempt"`
+	RetryOfID           *uuid.UUID             `json:"retry_of_id,omitempty"`
+	CreatedAt           time.Time              `json:"created_at"`
+}
+
+// FromExecution maps a job run to its public representation
+func FromExecution(execution *models.JobExecution) ExecutionResponse {
+	var errorMessage *string
+	if execution.ErrorMessage != nil {
+		message := execution.ErrorMessage.String()
+		errorMessage = &message
+	}
+
+	var termination *string
+	if execution.Termination != nil {
+		value := string(*execution.Termination)
+		termination = &value
+	}
+
+	return ExecutionResponse{
+		ID:                  execution.ID,
+		JobID:               execution.JobID,
+		Job:                 summarizeJob(&execution.Job),
+		Status:              string(execution.Status),
+		StartedAt:           execution.StartedAt,
+		CompletedAt:         execution.CompletedAt,
+		ScheduledFor:        execution.ScheduledFor,
+		ErrorMessage:        errorMessage,
+		ExecutionDurationMs: execution.ExecutionDuration,
+		Parameters:          execution.Parameters,
+		ApprovedBy:          execution.ApprovedBy,
+		ApprovedAt:          execution.ApprovedAt,
+		ApprovalExpiresAt:   execution.ApprovalExpiresAt,
+		ResourceUsage:       execution.ResourceUsage,
+		SideEffects:         execution.SideEffects,
+		Termination:         termination,
+		Attempt:             execution.Attempt,
+		RetryOfID:           execution.RetryOfID,
+		CreatedAt:           execution.CreatedAt,
+	}
+}
+
+// ExecutionListResponse is a page of a job's runs
+type ExecutionListResponse struct {
+	Executions          []ExecutionResponse `json:"executions"`
+	TotalCount          int64               `json:"total_count"`
+	TotalCountEstimated bool                `json:"total_count_estimated"`
+	Page                int                 `json:"page"`
+	Limit               int                 `json:"limit"`
+	TotalPages          int                 `json:"total_pages"`
+}
+
+// FromExecutionList maps a page of job runs
+func FromExecutionList(list *models.JobExecutionListResponse) ExecutionListResponse {
+	return ExecutionListResponse{
+		Executions:          FromExecutions(list.Executions),
+		TotalCount:          list.TotalCount,
+		TotalCountEstimated: list.TotalCountEstimated,
+		Page:                list.Page,
+		Limit:               list.Limit,
+		TotalPages:          list.TotalPages,
+	}
+}
+
+// FromExecutions maps a slice of job runs
+func FromExecutions(executions []models.JobExecution) []ExecutionResponse {
+	responses := make([]ExecutionResponse, 0, len(executions))
+	for i := range executions {
+		responses = append(responses, FromExecution(&executions[i]))
+	}
+	return responses
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"job-scheduler/internal/models"
+)
+
+// ExecutionLogLineResponse is the public representation of a line a run logged
+type ExecutionLogLineResponse struct {
+	Sequence int                    `json:"sequence"`
+	Level    string                 `json:"level"`
+	Message  string                 `json:"message"`
+	Fields   map[string]interface{} `json:"fields,omitempty"`
+	LoggedAt time.Time              `json:"logged_at"`
+}
+
+// FromExecutionLogLine maps a run log line to its public representation
+func FromExecutionLogLine(line *models.JobExecutionLog) ExecutionLogLineResponse {
+	return ExecutionLogLineResponse{
+		Sequence: line.Sequence,
+		Level:    line.Level,
+		Message:  line.Message,
+		Fields:   line.Fields,
+		LoggedAt: line.LoggedAt,
+	}
+}
+
+// FromExecutionLogLines maps a slice of run log lines
+func FromExecutionLogLines(lines []models.JobExecutionLog) []ExecutionLogLineResponse {
+	responses := make([]ExecutionLogLineResponse, 0, len(lines))
+	for i := range lines {
+		responses = append(responses, FromExecutionLogLine(&lines[i]))
+	}
+	return responses
+}
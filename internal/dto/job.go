@@ -0,0 +1,180 @@
+// Package dto defines the public API representations of the scheduler's models
+// Handlers map models to these types so the JSON contract is decoupled from
+// database columns and internal or sensitive fields are never serialized
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// JobResponse is the public representation of a job
+type JobResponse struct {
+	ID                  uuid.UUID                  `json:"id"`
+	Name                string                     `json:"name"`
+	Description         string                     `json:"description"`
+	Schedule            string                     `json:"schedule"`
+	ScheduleType        string                     `json:"schedule_type"`
+	CronSeconds         bool                       `json:"cron_seconds"`
+	RunAt               *time.Time                 `json:"run_at,omitempty"`
+	CompletedAt         *time.Time                 `json:"completed_at,omitempty"`
+	JobType             string                     `json:"job_type"`
+	Config              map[string]interface{}     `json:"config"`
+	IsActive            bool                       `json:"is_active"`
+	State               string                     `json:"state"`
+	ErroredAt           *time.Time                 `json:"errored_at,omitempty"`
+	ErrorReason         string                     `json:"error_reason,omitempty"`
+	PausedAt            *time.Time                 `json:"paused_at,omitempty"`
+	PausedBy            string                     `json:"paused_by,omitempty"`
+	PauseReason         string                     `json:"pause_reason,omitempty"`
+	RequiresApproval    bool                       `json:"requires_approval"`
+	Tags                []string                   `json:"tags"`
+	Team                string                     `json:"team,omitempty"`
+	Owner               string                     `json:"owner,omitempty"`
+	RunbookURL          string                     `json:"runbook_url,omitempty"`
+	Docs                string                     `json:"docs,omitempty"`
+	Severity            string                     `json:"severity"`
+	RemediationActions  []models.RemediationAction `json:"remediation_actions"`
+	MaxRetries          int                        `json:"max_retries"`
+	BackoffStrategy     string                     `json:"backoff_strategy"`
+	InitialDelaySeconds int                        `json:"initial_delay_seconds"`
+	TimeoutSeconds      int                        `json:"timeout_seconds,omitempty"`
+	MisfirePolicy       string                     `json:"misfire_policy"`
+	ConcurrencyPolicy   string                     `json:"concurrency_policy"`
+	ConcurrencyGroup    string                     `json:"concurrency_group,omitempty"`
+	NextRunAt           *time.Time                 `json:"next_run_at"`
+	LastRunAt           *time.Time                 `json:"last_run_at"`
+	HealthScore         *int                       `json:"health_score"`
+	HealthScoredAt      *time.Time                 `json:"health_scored_at,omitempty"`
+	CreatedAt           time.Time                  `json:"created_at"`
+	UpdatedAt           time.Time                  `json:"updated_at"`
+}
+
+// JobSummary identifies a job within another resource
+type JobSummary struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	JobType  string    `json:"job_type"`
+	Severity string    `json:"severity"`
+}
+
+// JobListResponse is a page of jobs
+type JobListResponse struct {
+	Jobs       []JobResponse `json:"jobs"`
+	TotalCount int64         `json:"total_count"`
+	Page       int           `json:"page"`
+	Limit      int           `json:"limit"`
+	TotalPages int           `json:"total_pages"`
+}
+
+// FromJob maps a job to its public representation
+func FromJob(job *models.Job) JobResponse {
+	tags := []string(job.Tags)
+	if tags == nil {
+		tags = []string{}
+	}
+
+	remediationActions := []models.RemediationAction(job.RemediationActions)
+	if remediationActions == nil {
+		remediationActions = []models.RemediationAction{}
+	}
+
+	scheduleType := job.ScheduleType
+	if scheduleType == "" {
+		scheduleType = models.ScheduleTypeCron
+	}
+
+	return JobResponse{
+		ID:                  job.ID,
+		Name:                job.Name,
+		Description:         job.Description,
+		Schedule:            job.Schedule,
+		ScheduleType:        string(scheduleType),
+		CronSeconds:         job.CronSeconds,
+		RunAt:               job.RunAt,
+		CompletedAt:         job.CompletedAt,
+		JobType:             string(job.JobType),
+		Config:              job.Config,
+		IsActive:            job.IsActive(),
+		State:               string(job.State),
+		ErroredAt:           job.ErroredAt,
+		ErrorReason:         job.ErrorReason,
+		PausedAt:            job.PausedAt,
+		PausedBy:            job.PausedBy,
+		PauseReason:         job.PauseReason,
+		RequiresApproval:    job.RequiresApproval,
+		Tags:                tags,
+		Team:                job.Team,
+		Owner:               job.Owner,
+		RunbookURL:          job.RunbookURL,
+		Docs:                job.Docs,
+		Severity:            string(job.Severity),
+		RemediationActions:  remediationActions,
+		MaxRetries:          job.MaxRetries,
+		BackoffStrategy:     string(job.BackoffStrategy),
+		InitialDelaySeconds: job.InitialDelaySeconds,
+		TimeoutSeconds:      job.TimeoutSeconds,
+		MisfirePolicy:       string(job.MisfirePolicy),
+		ConcurrencyPolicy:   string(job.ConcurrencyPolicy),
+		ConcurrencyGroup:    job.ConcurrencyGroup,
+		NextRunAt:           job.NextRunAt,
+		LastRunAt:           job.LastRunAt,
+		HealthScore:         job.HealthScore,
+		HealthScoredAt:      job.HealthScoredAt,
+		CreatedAt:           job.CreatedAt,
+		UpdatedAt:           job.UpdatedAt,
+	}
+}
+
+// FromJobs maps a slice of jobs
+func FromJobs(jobs []models.Job) []JobResponse {
+	responses := make([]JobResponse, 0, len(jobs))
+	for i := range jobs {
+		responses = append(responses, FromJob(&jobs[i]))
+	}
+	return responses
+}
+
+// FromJobList maps a page of jobs
+func FromJobList(list *models.JobListResponse) JobListResponse {
+	return JobListResponse{
+		Jobs:       FromJobs(list.Jobs),
+		TotalCount: list.TotalCount,
+		Page:       list.Page,
+		Limit:      list.Limit,
+		TotalPages: list.TotalPages,
+	}
+}
+
+// summarizeJob maps a job to a summary, returning nil for unloaded relations
+func summarizeJob(job *models.Job) *JobSummary {
+	if job == nil || job.ID == uuid.Nil {
+		return nil
+	}
+	return &JobSummary{
+		ID:       job.ID,
+		Name:     job.Name,
+		JobType:  string(job.JobType),
+		Severity: string(job.Severity),
+	}
+}
+
+// JobPageResponse is a cursor-paginated page of jobs
+// Pass NextCursor as the cursor query parameter to fetch the next page
+type JobPageResponse struct {
+	Jobs       []JobResponse `json:"jobs"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+	Limit      int           `json:"limit"`
+}
+
+// FromJobPage maps a cursor-paginated page of jobs
+func FromJobPage(page *models.JobPage) JobPageResponse {
+	return JobPageResponse{
+		Jobs:       FromJobs(page.Jobs),
+		NextCursor: page.NextCursor,
+		Limit:      page.Limit,
+	}
+}
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// JobAcknowledgmentResponse is the public representation of an operator's acknowledgment of a job
+type JobAcknowledgmentResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	JobID          uuid.UUID  `json:"job_id"`
+	Note           string     `json:"note"`
+	AcknowledgedBy string     `json:"acknowledged_by"`
+	Scope          string     `json:"scope"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	Active         bool       `json:"active"`
+	ClearedBy      *string    `json:"cleared_by,omitempty"`
+	ClearedAt      *time.Time `json:"cleared_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// FromJobAcknowledgment maps a job acknowledgment to its public representation
+func FromJobAcknowledgment(acknowledgment *models.JobAcknowledgment) JobAcknowledgmentResponse {
+	return JobAcknowledgmentResponse{
+		ID:             acknowledgment.ID,
+		JobID:          acknowledgment.JobID,
+		Note:           acknowledgment.Note,
+		AcknowledgedBy: acknowledgment.AcknowledgedBy,
+		Scope:          string(acknowledgment.Scope),
+		ExpiresAt:      acknowledgment.ExpiresAt,
+		Active:         acknowledgment.IsActive(time.Now().UTC()),
+		ClearedBy:      acknowledgment.ClearedBy,
+		ClearedAt:      acknowledgment.ClearedAt,
+		CreatedAt:      acknowledgment.CreatedAt,
+	}
+}
+
+// FromJobAcknowledgments maps a slice of job acknowledgments
+func FromJobAcknowledgments(acknowledgments []models.JobAcknowledgment) []JobAcknowledgmentResponse {
+	responses := make([]JobAcknowledgmentResponse, 0, len(acknowledgments))
+	for i := range acknowledgments {
+		responses = append(responses, FromJobAcknowledgment(&acknowledgments[i]))
+	}
+	return responses
+}
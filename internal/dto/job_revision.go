@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// JobRevisionResponse is the public representation of a revision of a job's name, schedule and config
+type JobRevisionResponse struct {
+	ID           uuid.UUID           `json:"id"`
+	JobID        uuid.UUID           `json:"job_id"`
+	Revision     int                 `json:"revision"`
+	Name         string              `json:"name"`
+	ScheduleType models.ScheduleType `json:"schedule_type"`
+	Schedule     string              `json:"schedule"`
+	Config       models.JobConfig    `json:"config"`
+	Author       string              `json:"author,omitempty"`
+	// Changes are the fields changed from the revision before, empty for a job's first revision
+	Changes   []string  `json:"changes"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FromJobRevision maps a job revision to its public representation, given the revision before it if any
+func FromJobRevision(revision, previous *models.JobRevision) JobRevisionResponse {
+	changes := []string{}
+	if previous != nil {
+		changes = append(changes, revision.ChangedFrom(previous)...)
+	}
+	return JobRevisionResponse{
+		ID:           revision.ID,
+		JobID:        revision.JobID,
+		Revision:     revision.Revision,
+		Name:         revision.Name,
+		ScheduleType: revision.ScheduleType,
+		Schedule:     revision.Schedule,
+		Config:       revision.Config,
+		Author:       revision.Author,
+		Changes:      changes,
+		CreatedAt:    revision.CreatedAt,
+	}
+}
+
+// FromJobRevisions maps a job's revisions, newest first
+func FromJobRevisions(revisions []models.JobRevision) []JobRevisionResponse {
+	responses := make([]JobRevisionResponse, 0, len(revisions))
+	for i := range revisions {
+		var previous *models.JobRevision
+		if i+1 < len(revisions) {
+			previous = &revisions[i+1]
+		}
+		responses = append(responses, FromJobRevision(&revisions[i], previous))
+	}
+	return responses
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// NotificationTemplateResponse is the public representation of a notification template
+type NotificationTemplateResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Channel   string    `json:"channel"`
+	Event     string    `json:"event,omitempty"`
+	Subject   string    `json:"subject,omitempty"`
+	Body      string    `json:"body"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FromNotificationTemplate maps a notification template to its public representation
+func FromNotificationTemplate(template *models.NotificationTemplate) NotificationTemplateResponse {
+	return NotificationTemplateResponse{
+		ID:        template.ID,
+		Name:      template.Name,
+		Channel:   string(template.Channel),
+		Event:     template.Event,
+		Subject:   template.Subject,
+		Body:      template.Body,
+		IsActive:  template.IsActive,
+		CreatedAt: template.CreatedAt,
+		UpdatedAt: template.UpdatedAt,
+	}
+}
+
+// FromNotificationTemplates maps a slice of notification templates
+func FromNotificationTemplates(templates []models.NotificationTemplate) []NotificationTemplateResponse {
+	responses := make([]NotificationTemplateResponse, 0, len(templates))
+	for i := range templates {
+		responses = append(responses, FromNotificationTemplate(&templates[i]))
+	}
+	return responses
+}
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// PendingChangeResponse is the public representation of a change awaiting a second approver
+type PendingChangeResponse struct {
+	ID           uuid.UUID              `json:"id"`
+	JobID        uuid.UUID              `json:"job_id"`
+	Action       string                 `json:"action"`
+	Payload      map[string]interface{} `json:"payload,omitempty"`
+	RequestedBy  string                 `json:"requested_by"`
+	Status       string                 `json:"status"`
+	ReviewedBy   *string                `json:"reviewed_by,omitempty"`
+	ReviewedAt   *time.Time             `json:"reviewed_at,omitempty"`
+	ReviewReason *string                `json:"review_reason,omitempty"`
+	CreatedAt    time.Time              `json:"created_at"`
+}
+
+// FromPendingChange maps a pending change to its public representation
+func FromPendingChange(change *models.PendingChange) PendingChangeResponse {
+	return PendingChangeResponse{
+		ID:           change.ID,
+		JobID:        change.JobID,
+		Action:       string(change.Action),
+		Payload:      change.Payload,
+		RequestedBy:  change.RequestedBy,
+		Status:       string(change.Status),
+		ReviewedBy:   change.ReviewedBy,
+		ReviewedAt:   change.ReviewedAt,
+		ReviewReason: change.ReviewReason,
+		CreatedAt:    change.CreatedAt,
+	}
+}
+
+// FromPendingChanges maps a slice of pending changes
+func FromPendingChanges(changes []models.PendingChange) []PendingChangeResponse {
+	responses := make([]PendingChangeResponse, 0, len(changes))
+	for i := range changes {
+		responses = append(responses, FromPendingChange(&changes[i]))
+	}
+	return responses
+}
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// ReportTemplateResponse is the public representation of a report template
+type ReportTemplateResponse struct {
+	ID          uuid.UUID            `json:"id"`
+	Name        string               `json:"name"`
+	Description string               `json:"description,omitempty"`
+	Format      string               `json:"format"`
+	Layout      models.ReportLayout  `json:"layout"`
+	Columns     models.ReportColumns `json:"columns"`
+	Queries     models.ReportQueries `json:"queries"`
+	IsActive    bool                 `json:"is_active"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+// FromReportTemplate maps a report template to its public representation
+func FromReportTemplate(template *models.ReportTemplate) ReportTemplateResponse {
+	return ReportTemplateResponse{
+		ID:          template.ID,
+		Name:        template.Name,
+		Description: template.Description,
+		Format:      template.Format,
+		Layout:      template.Layout,
+		Columns:     template.Columns,
+		Queries:     template.Queries,
+		IsActive:    template.IsActive,
+		CreatedAt:   template.CreatedAt,
+		UpdatedAt:   template.UpdatedAt,
+	}
+}
+
+// FromReportTemplates maps a slice of report templates
+func FromReportTemplates(templates []models.ReportTemplate) []ReportTemplateResponse {
+	responses := make([]ReportTemplateResponse, 0, len(templates))
+	for i := range templates {
+		responses = append(responses, FromReportTemplate(&templates[i]))
+	}
+	return responses
+}
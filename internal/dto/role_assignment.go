@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// RoleAssignmentResponse is the public representation of a user's role
+type RoleAssignmentResponse struct {
+	ID         uuid.UUID `json:"id"`
+	User       string    `json:"user"`
+	Role       string    `json:"role"`
+	AssignedBy string    `json:"assigned_by,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// FromRoleAssignment maps a role assignment to its public representation
+func FromRoleAssignment(assignment *models.RoleAssignment) RoleAssignmentResponse {
+	return RoleAssignmentResponse{
+		ID:         assignment.ID,
+		User:       assignment.User,
+		Role:       string(assignment.Role),
+		AssignedBy: assignment.AssignedBy,
+		CreatedAt:  assignment.CreatedAt,
+		UpdatedAt:  assignment.UpdatedAt,
+	}
+}
+
+// FromRoleAssignments maps a slice of role assignments
+func FromRoleAssignments(assignments []models.RoleAssignment) []RoleAssignmentResponse {
+	responses := make([]RoleAssignmentResponse, 0, len(assignments))
+	for i := range assignments {
+		responses = append(responses, FromRoleAssignment(&assignments[i]))
+	}
+	return responses
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// TeamChannelResponse is the public representation of a team notification channel
+// Channel URLs carry credentials (e.g. Slack webhook tokens), so only the host is exposed
+type TeamChannelResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Team      string    `json:"team"`
+	Kind      string    `json:"kind"`
+	URL       string    `json:"url"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FromTeamChannel maps a team channel to its public representation
+func FromTeamChannel(channel *models.TeamChannel) TeamChannelResponse {
+	return TeamChannelResponse{
+		ID:        channel.ID,
+		Team:      channel.Team,
+		Kind:      string(channel.Kind),
+		URL:       redactURL(channel.URL),
+		IsActive:  channel.IsActive,
+		CreatedAt: channel.CreatedAt,
+		UpdatedAt: channel.UpdatedAt,
+	}
+}
+
+// FromTeamChannels maps a slice of team channels
+func FromTeamChannels(channels []models.TeamChannel) []TeamChannelResponse {
+	responses := make([]TeamChannelResponse, 0, len(channels))
+	for i := range channels {
+		responses = append(responses, FromTeamChannel(&channels[i]))
+	}
+	return responses
+}
+
+// redactURL keeps a URL's scheme and host and hides everything else
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "***"
+	}
+	return u.Scheme + "://" + u.Host + "/***"
+}
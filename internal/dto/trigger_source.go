@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// TriggerSourceResponse is the public representation of a queue trigger source
+type TriggerSourceResponse struct {
+	ID                       uuid.UUID `json:"id"`
+	JobID                    uuid.UUID `json:"job_id"`
+	Kind                     string    `json:"kind"`
+	Target                   string    `json:"target"`
+	VisibilityTimeoutSeconds int       `json:"visibility_timeout_seconds"`
+	MaxMessages              int       `json:"max_messages"`
+	Condition                string    `json:"condition,omitempty"`
+	IsActive                 bool      `json:"is_active"`
+	CreatedAt                time.Time `json:"created_at"`
+	UpdatedAt                time.Time `json:"updated_at"`
+}
+
+// FromTriggerSource maps a trigger source to its public representation
+func FromTriggerSource(source *models.TriggerSource) TriggerSourceResponse {
+	return TriggerSourceResponse{
+		ID:                       source.ID,
+		JobID:                    source.JobID,
+		Kind:                     string(source.Kind),
+		Target:                   source.Target,
+		VisibilityTimeoutSeconds: source.VisibilityTimeoutSeconds,
+		MaxMessages:              source.MaxMessages,
+		Condition:                source.Condition,
+		IsActive:                 source.IsActive,
+		CreatedAt:                source.CreatedAt,
+		UpdatedAt:                source.UpdatedAt,
+	}
+}
+
+// FromTriggerSources maps a slice of trigger sources
+func FromTriggerSources(sources []models.TriggerSource) []TriggerSourceResponse {
+	responses := make([]TriggerSourceResponse, 0, len(sources))
+	for i := range sources {
+		responses = append(responses, FromTriggerSource(&sources[i]))
+	}
+	return responses
+}
@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// WebhookResponse is the public representation of an inbound webhook
+// The signing secret is never included
+type WebhookResponse struct {
+	ID                 uuid.UUID  `json:"id"`
+	JobID              uuid.UUID  `json:"job_id"`
+	URL                string     `json:"url"`
+	SignatureHeader    string     `json:"signature_header"`
+	SignatureScheme    string     `json:"signature_scheme"`
+	RateLimitPerMinute int        `json:"rate_limit_per_minute"`
+	IsActive           bool       `json:"is_active"`
+	LastTriggeredAt    *time.Time `json:"last_triggered_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+// CreatedWebhookResponse is returned once on creation and is the only
+// time the signing secret is revealed
+type CreatedWebhookResponse struct {
+	Webhook WebhookResponse `json:"webhook"`
+	Secret  string          `json:"secret"`
+	URL     string          `json:"url"`
+}
+
+// WebhookPath returns the path external systems call to trigger a webhook
+func WebhookPath(webhook *models.JobWebhook) string {
+	return "/api/v1/hooks/" + webhook.Token
+}
+
+// FromWebhook maps an inbound webhook to its public representation
+func FromWebhook(webhook *models.JobWebhook) WebhookResponse {
+	return WebhookResponse{
+		ID:                 webhook.ID,
+		JobID:              webhook.JobID,
+		URL:                WebhookPath(webhook),
+		SignatureHeader:    webhook.SignatureHeader,
+		SignatureScheme:    string(webhook.SignatureScheme),
+		RateLimitPerMinute: webhook.RateLimitPerMinute,
+		IsActive:           webhook.IsActive,
+		LastTriggeredAt:    webhook.LastTriggeredAt,
+		CreatedAt:          webhook.CreatedAt,
+	}
+}
+
+// FromWebhooks maps a slice of inbound webhooks
+func FromWebhooks(webhooks []models.JobWebhook) []WebhookResponse {
+	responses := make([]WebhookResponse, 0, len(webhooks))
+	for i := range webhooks {
+		responses = append(responses, FromWebhook(&webhooks[i]))
+	}
+	return responses
+}
+
+// NewCreatedWebhookResponse maps a newly created webhook and its secret
+func NewCreatedWebhookResponse(webhook *models.JobWebhook, secret string) CreatedWebhookResponse {
+	return CreatedWebhookResponse{
+		Webhook: FromWebhook(webhook),
+		Secret:  secret,
+		URL:     WebhookPath(webhook),
+	}
+}
@@ -0,0 +1,238 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// WorkflowResponse is the public representation of a workflow
+type WorkflowResponse struct {
+	ID          uuid.UUID            `json:"id"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Graph       models.WorkflowGraph `json:"graph"`
+	IsActive    bool                 `json:"is_active"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+// FromWorkflow maps a workflow to its public representation
+func FromWorkflow(workflow *models.Workflow) WorkflowResponse {
+	return WorkflowResponse{
+		ID:          workflow.ID,
+		Name:        workflow.Name,
+		Description: workflow.Description,
+		Graph:       workflow.Graph,
+		IsActive:    workflow.IsActive,
+		CreatedAt:   workflow.CreatedAt,
+		UpdatedAt:   workflow.UpdatedAt,
+	}
+}
+
+// FromWorkflows maps a slice of workflows
+func FromWorkflows(workflows []models.Workflow) []WorkflowResponse {
+	responses := make([]WorkflowResponse, 0, len(workflows))
+	for i := range workflows {
+		responses = append(responses, FromWorkflow(&workflows[i]))
+	}
+	return responses
+}
+
+// WorkflowExecutionResponse is the public representation of a workflow run and the progress of its nodes
+type WorkflowExecutionResponse struct {
+	ID          uuid.UUID                      `json:"id"`
+	WorkflowID  uuid.UUID                      `json:"workflow_id"`
+	Status      models.WorkflowExecutionStatus `json:"status"`
+	Nodes       models.WorkflowNodeStates      `json:"nodes"`
+	Parameters  models.JobConfig               `json:"parameters,omitempty"`
+	TriggeredBy string                         `json:"triggered_by,omitempty"`
+	StartedAt   time.Time                      `json:"started_at"`
+	CompletedAt *time.Time                     `json:"completed_at,omitempty"`
+	DurationMs  *int64                         `json:"duration_ms,omitempty"`
+}
+
+// FromWorkflowExecution maps a workflow execution to its public representation
+func FromWorkflowExecution(execution *models.WorkflowExecution) WorkflowExecutionResponse {
+	response := WorkflowExecutionResponse{
+		ID:          execution.ID,
+		WorkflowID:  execution.WorkflowID,
+		Status:      execution.Status,
+		Nodes:       execution.Nodes,
+		Parameters:  execution.Parameters,
+		TriggeredBy: execution.TriggeredBy,
+		StartedAt:   execution.StartedAt,
+		CompletedAt: execution.CompletedAt,
+	}
+	if execution.CompletedAt != nil {
+		duration := execution.CompletedAt.Sub(execution.StartedAt).Milliseconds()
+		response.DurationMs = &duration
+	}
+	return response
+}
+
+// FromWorkflowExecutions maps a slice of workflow executions
+func FromWorkflowExecutions(executions []models.WorkflowExecution) []WorkflowExecutionResponse {
+	responses := make([]WorkflowExecutionResponse, 0, len(executions))
+	for i := range executions {
+		responses = append(responses, FromWorkflowExecution(&executions[i]))
+	}
+	return responses
+}
+
+// WorkflowRunResponse is the public representation of a workflow run as a tree of its nodes and their job runs
+// Durations of nodes and runs still going are how long they have run so far
+type WorkflowRunResponse struct {
+	ID           uuid.UUID                      `json:"id"`
+	WorkflowID   uuid.UUID                      `json:"workflow_id"`
+	WorkflowName string                         `json:"workflow_name"`
+	Status       models.WorkflowExecutionStatus `json:"status"`
+	TriggeredBy  string                         `json:"triggered_by,omitempty"`
+	StartedAt    time.Time                      `json:"started_at"`
+	CompletedAt  *time.Time                     `json:"completed_at,omitempty"`
+	DurationMs   int64                          `json:"duration_ms"`
+	Nodes        []WorkflowRunNodeResponse      `json:"nodes"`
+	Links        map[string]string              `json:"links"`
+}
+
+// WorkflowRunNodeResponse is one node of a workflow run and the job runs it started, oldest first
+type WorkflowRunNodeResponse struct {
+	Key         string                    `json:"key"`
+	JobID       uuid.UUID                 `json:"job_id"`
+	Status      models.WorkflowNodeStatus `json:"status"`
+	StartedAt   *time.Time                `json:"started_at,omitempty"`
+	CompletedAt *time.Time                `json:"completed_at,omitempty"`
+	DurationMs  *int64                    `json:"duration_ms,omitempty"`
+	Error       string                    `json:"error,omitempty"`
+	DependsOn   []string                  `json:"depends_on"`
+	WaitingOn   []string                  `json:"waiting_on,omitempty"`
+	Runs        []WorkflowRunJobRun       `json:"runs"`
+	Links       map[string]string         `json:"links"`
+}
+
+// WorkflowRunJobRun is a job run started by a workflow node, with links to its details
+type WorkflowRunJobRun struct {
+	ExecutionResponse
+	Links map[string]string `json:"links"`
+}
+
+// FromWorkflowRun maps a workflow run tree to its public representation
+func FromWorkflowRun(run *models.WorkflowRun) WorkflowRunResponse {
+	now := time.Now().UTC()
+	execution := run.Execution
+	response := WorkflowRunResponse{
+		ID:           execution.ID,
+		WorkflowID:   execution.WorkflowID,
+		WorkflowName: run.WorkflowName,
+		Status:       execution.Status,
+		TriggeredBy:  execution.TriggeredBy,
+		StartedAt:    execution.StartedAt,
+		CompletedAt:  execution.CompletedAt,
+		DurationMs:   elapsedMs(execution.StartedAt, execution.CompletedAt, now),
+		Nodes:        make([]WorkflowRunNodeResponse, 0, len(run.Nodes)),
+		Links: map[string]string{
+			"self":      "/api/v1/workflows/runs/" + execution.ID.String(),
+			"execution": "/api/v1/workflow-executions/" + execution.ID.String(),
+			"workflow":  "/api/v1/workflows/" + execution.WorkflowID.String(),
+		},
+	}
+
+	for _, node := range run.Nodes {
+		nodeResponse := WorkflowRunNodeResponse{
+			Key:         node.Key,
+			JobID:       node.State.JobID,
+			Status:      node.State.Status,
+			StartedAt:   node.State.StartedAt,
+			CompletedAt: node.State.CompletedAt,
+			Error:       node.State.Error,
+			DependsOn:   append([]string{}, node.DependsOn...),
+			WaitingOn:   node.WaitingOn,
+			Runs:        make([]WorkflowRunJobRun, 0, len(node.Runs)),
+			Links: map[string]string{
+				"job": "/api/v1/jobs/" + node.State.JobID.String(),
+			},
+		}
+		if node.State.StartedAt != nil {
+			duration := elapsedMs(*node.State.StartedAt, node.State.CompletedAt, now)
+			nodeResponse.DurationMs = &duration
+		}
+		for i := range node.Runs {
+			id := node.Runs[i].ID.String()
+			nodeResponse.Runs = append(nodeResponse.Runs, WorkflowRunJobRun{
+				ExecutionResponse: FromExecution(&node.Runs[i]),
+				Links: map[string]string{
+					"self":   "/api/v1/executions/" + id,
+					"logs":   "/api/v1/executions/" + id + "/logs",
+					"output": "/api/v1/executions/" + id + "/output",
+				},
+			})
+		}
+		response.Nodes = append(response.Nodes, nodeResponse)
+	}
+	return response
+}
+
+// elapsedMs returns how long something that started ran for, up to now if it hasn't finished
+func elapsedMs(startedAt time.Time, completedAt *time.Time, now time.Time) int64 {
+	end := now
+	if completedAt != nil {
+		end = *completedAt
+	}
+	return end.Sub(startedAt).Milliseconds()
+}
+
+// WorkflowCriticalPathResponse is the public representation of a workflow run's critical path and each node's
+// slack, with times in milliseconds from the start of the run
+type WorkflowCriticalPathResponse struct {
+	WorkflowExecutionID uuid.UUID                      `json:"workflow_execution_id"`
+	Path                []string                       `json:"path"`
+	DurationMs          int64                          `json:"duration_ms"`
+	DeadlineMs          *int64                         `json:"deadline_ms,omitempty"`
+	OverrunMs           *int64                         `json:"overrun_ms,omitempty"`
+	Nodes               []WorkflowNodeScheduleResponse `json:"nodes"`
+}
+
+// WorkflowNodeScheduleResponse is when a node of a workflow run can start and finish, and its slack
+type WorkflowNodeScheduleResponse struct {
+	Key              string `json:"key"`
+	DurationMs       int64  `json:"duration_ms"`
+	Estimated        bool   `json:"estimated"`
+	EarliestStartMs  int64  `json:"earliest_start_ms"`
+	EarliestFinishMs int64  `json:"earliest_finish_ms"`
+	LatestStartMs    int64  `json:"latest_start_ms"`
+	LatestFinishMs   int64  `json:"latest_finish_ms"`
+	SlackMs          int64  `json:"slack_ms"`
+	Critical         bool   `json:"critical"`
+}
+
+// FromWorkflowCriticalPath maps a workflow run's critical path to its public representation
+func FromWorkflowCriticalPath(executionID uuid.UUID, path *models.WorkflowCriticalPath) WorkflowCriticalPathResponse {
+	response := WorkflowCriticalPathResponse{
+		WorkflowExecutionID: executionID,
+		Path:                append([]string{}, path.Path...),
+		DurationMs:          path.Duration.Milliseconds(),
+		Nodes:               make([]WorkflowNodeScheduleResponse, 0, len(path.Nodes)),
+	}
+	if path.Deadline > 0 {
+		deadline := path.Deadline.Milliseconds()
+		overrun := path.Overrun.Milliseconds()
+		response.DeadlineMs = &deadline
+		response.OverrunMs = &overrun
+	}
+	for _, node := range path.Nodes {
+		response.Nodes = append(response.Nodes, WorkflowNodeScheduleResponse{
+			Key:              node.Key,
+			DurationMs:       node.Duration.Milliseconds(),
+			Estimated:        node.Estimated,
+			EarliestStartMs:  node.EarliestStart.Milliseconds(),
+			EarliestFinishMs: node.EarliestFinish.Milliseconds(),
+			LatestStartMs:    node.LatestStart.Milliseconds(),
+			LatestFinishMs:   node.LatestFinish.Milliseconds(),
+			SlackMs:          node.Slack.Milliseconds(),
+			Critical:         node.Critical,
+		})
+	}
+	return response
+}
@@ -0,0 +1,110 @@
+// Package events publishes execution status transitions and job lifecycle events to in-process subscribers
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+)
+
+// ExecutionTransition is published once for every status change saved for an execution
+type ExecutionTransition struct {
+	ExecutionID uuid.UUID
+	JobID       uuid.UUID
+	Attempt     int
+	// From is empty when the execution was just created
+	From models.ExecutionStatus
+	To   models.ExecutionStatus
+	At   time.Time
+}
+
+// Handler receives transitions; it runs on the publishing goroutine and must not block
+type Handler func(ExecutionTransition)
+
+// subscription is a handler and the statuses it wants
+type subscription struct {
+	id      int
+	handler Handler
+	to      map[models.ExecutionStatus]bool // nil means every status
+}
+
+// Bus delivers execution transitions to subscribers
+type Bus struct {
+	mu            sync.RWMutex
+	subscriptions []subscription
+	nextID        int
+}
+
+// NewBus creates a bus with no subscribers
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers a handler for transitions into the given statuses, or all transitions when none are given
+// The returned function removes the subscription
+func (b *Bus) Subscribe(handler Handler, to ...models.ExecutionStatus) func() {
+	sub := subscription{handler: handler}
+	if len(to) > 0 {
+		sub.to = make(map[models.ExecutionStatus]bool, len(to))
+		for _, status := range to {
+			sub.to[status] = true
+		}
+	}
+
+	b.mu.Lock()
+	b.nextID++
+	sub.id = b.nextID
+	b.subscriptions = append(b.subscriptions, sub)
+	b.mu.Unlock()
+
+	return func() { b.unsubscribe(sub.id) }
+}
+
+// unsubscribe removes the subscription with the given id
+func (b *Bus) unsubscribe(id int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, sub := range b.subscriptions {
+		if sub.id == id {
+			b.subscriptions = append(b.subscriptions[:i:i], b.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+// Publish delivers a transition to every matching subscriber
+// A panicking handler is logged and doesn't stop delivery to the others
+func (b *Bus) Publish(transition ExecutionTransition) {
+	if b == nil {
+		return
+	}
+
+	b.mu.RLock()
+	subscriptions := b.subscriptions
+	b.mu.RUnlock()
+
+	for _, sub := range subscriptions {
+		if sub.to != nil && !sub.to[transition.To] {
+			continue
+		}
+		deliver(sub.handler, transition)
+	}
+}
+
+// deliver calls one handler, recovering from panics
+func deliver(handler Handler, transition ExecutionTransition) {
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.WithFields(logrus.Fields{
+				"execution_id": transition.ExecutionID,
+				"status":       transition.To,
+				"panic":        r,
+			}).Error("Execution transition subscriber panicked")
+		}
+	}()
+	handler(transition)
+}
@@ -0,0 +1,144 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// JobEventType is the stage of a job's lifecycle an event reports
+type JobEventType string
+
+// Job lifecycle event types
+const (
+	JobEventCreated   JobEventType = "job_created"
+	JobEventScheduled JobEventType = "job_scheduled"
+	JobEventStarted   JobEventType = "job_started"
+	JobEventCompleted JobEventType = "job_completed"
+	JobEventFailed    JobEventType = "job_failed"
+)
+
+// JobEventTypes lists every job lifecycle event type
+var JobEventTypes = []JobEventType{
+	JobEventCreated,
+	JobEventScheduled,
+	JobEventStarted,
+	JobEventCompleted,
+	JobEventFailed,
+}
+
+// IsValidJobEventType checks if an event type is one of the job lifecycle event types
+func IsValidJobEventType(eventType JobEventType) bool {
+	for _, valid := range JobEventTypes {
+		if eventType == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// JobEvent is published when a job is created or scheduled and when one of its runs starts or ends
+type JobEvent struct {
+	Type    JobEventType `json:"type"`
+	JobID   uuid.UUID    `json:"job_id"`
+	JobName string       `json:"job_name"`
+	// Set for run events
+	ExecutionID *uuid.UUID `json:"execution_id,omitempty"`
+	Attempt     int        `json:"attempt,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	// Set for scheduled events
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+	At        time.Time  `json:"at"`
+}
+
+// JobEventHandler receives job events; it runs on the publishing goroutine and must not block
+type JobEventHandler func(JobEvent)
+
+// jobEventSubscription is a handler and the event types it wants
+type jobEventSubscription struct {
+	id      int
+	handler JobEventHandler
+	types   map[JobEventType]bool // nil means every type
+}
+
+// JobEventBus delivers job lifecycle events to subscribers
+type JobEventBus struct {
+	mu            sync.RWMutex
+	subscriptions []jobEventSubscription
+	nextID        int
+}
+
+// NewJobEventBus creates a job event bus with no subscribers
+func NewJobEventBus() *JobEventBus {
+	return &JobEventBus{}
+}
+
+// Subscribe registers a handler for events of the given types, or all events when none are given
+// The returned function removes the subscription
+func (b *JobEventBus) Subscribe(handler JobEventHandler, types ...JobEventType) func() {
+	sub := jobEventSubscription{handler: handler}
+	if len(types) > 0 {
+		sub.types = make(map[JobEventType]bool, len(types))
+		for _, eventType := range types {
+			sub.types[eventType] = true
+		}
+	}
+
+	b.mu.Lock()
+	b.nextID++
+	sub.id = b.nextID
+	b.subscriptions = append(b.subscriptions, sub)
+	b.mu.Unlock()
+
+	return func() { b.unsubscribe(sub.id) }
+}
+
+// unsubscribe removes the subscription with the given id
+func (b *JobEventBus) unsubscribe(id int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, sub := range b.subscriptions {
+		if sub.id == id {
+			b.subscriptions = append(b.subscriptions[:i:i], b.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+// Publish delivers an event to every matching subscriber, stamping it with the current time if unset
+// A panicking handler is logged and doesn't stop delivery to the others
+func (b *JobEventBus) Publish(event JobEvent) {
+	if b == nil {
+		return
+	}
+	if event.At.IsZero() {
+		event.At = time.Now().UTC()
+	}
+
+	b.mu.RLock()
+	subscriptions := b.subscriptions
+	b.mu.RUnlock()
+
+	for _, sub := range subscriptions {
+		if sub.types != nil && !sub.types[event.Type] {
+			continue
+		}
+		deliverJobEvent(sub.handler, event)
+	}
+}
+
+// deliverJobEvent calls one handler, recovering from panics
+func deliverJobEvent(handler JobEventHandler, event JobEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.WithFields(logrus.Fields{
+				"job_id": event.JobID,
+				"type":   event.Type,
+				"panic":  r,
+			}).Error("Job event subscriber panicked")
+		}
+	}()
+	handler(event)
+}
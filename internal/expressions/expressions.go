@@ -0,0 +1,230 @@
+// Package expressions compiles and evaluates the CEL expressions users attach to jobs, trigger sources
+// and pipelines. Expressions run in a sandbox: they can only read the variables of their environment,
+// have no functions doing I/O, and each evaluation is bounded in cost
+package expressions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/google/cel-go/ext"
+)
+
+const (
+	// MaxLength caps how long an expression may be, in characters
+	MaxLength = 2000
+	// maxCost caps the work one evaluation may do, in CEL's cost units; an expression going over
+	// it fails instead of tying up the scheduler, e.g. one looping over a huge list several times
+	maxCost = 100000
+	// interruptCheckFrequency is how many comprehension iterations run between checks of the
+	// evaluation's context
+	interruptCheckFrequency = 100
+)
+
+// Environment declares the variables expressions used in one place may read
+type Environment struct {
+	name string
+	env  *cel.Env
+}
+
+var (
+	// SuccessCriteria decides whether a run that returned without error succeeded, from the
+	// output it recorded, how long it took in milliseconds and its attempt
+	SuccessCriteria = mustEnvironment("success criteria",
+		cel.Variable("output", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("duration_ms", cel.IntType),
+		cel.Variable("attempt", cel.IntType),
+	)
+	// TriggerCondition decides whether a queue message runs its job, from the message's decoded
+	// JSON body, its attributes and its ID
+	TriggerCondition = mustEnvironment("trigger condition",
+		cel.Variable("body", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("attributes", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("message_id", cel.StringType),
+	)
+	// PipelineRow filters and transforms the rows flowing through a data pipeline, one at a time
+	PipelineRow = mustEnvironment("pipeline expression",
+		cel.Variable("row", cel.MapType(cel.StringType, cel.DynType)),
+	)
+	// NotificationFilter decides whether a notification about a job is delivered, from its event,
+	// the job, the run it is about and its fields
+	NotificationFilter = mustEnvironment("notification filter",
+		cel.Variable("event", cel.StringType),
+		cel.Variable("job", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("execution", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("fields", cel.MapType(cel.StringType, cel.DynType)),
+	)
+)
+
+// mustEnvironment creates an environment with the standard library, the string functions of the
+// extensions library (lowerAscii, split, replace...) and the given variables
+func mustEnvironment(name string, variables ...cel.EnvOption) *Environment {
+	options := append([]cel.EnvOption{
+		ext.Strings(),
+		cel.CrossTypeNumericComparisons(true),
+		cel.DefaultUTCTimeZone(true),
+		cel.ParserExpressionSizeLimit(MaxLength),
+	}, variables...)
+	env, err := cel.NewEnv(options...)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s environment: %v", name, err))
+	}
+	return &Environment{name: name, env: env}
+}
+
+// Expression is a compiled expression, safe for concurrent evaluation
+type Expression struct {
+	source  string
+	program cel.Program
+}
+
+// Compile parses and type-checks an expression producing any value
+func (e *Environment) Compile(source string) (*Expression, error) {
+	return e.compile(source, false)
+}
+
+// CompileCondition parses and type-checks an expression that must produce a bool
+func (e *Environment) CompileCondition(source string) (*Expression, error) {
+	return e.compile(source, true)
+}
+
+func (e *Environment) compile(source string, condition bool) (*Expression, error) {
+	if len(source) > MaxLength {
+		return nil, fmt.Errorf("invalid %s: longer than %d characters", e.name, MaxLength)
+	}
+	ast, issues := e.env.Compile(source)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid %s: %w", e.name, issues.Err())
+	}
+	if condition && !ast.OutputType().IsExactType(cel.BoolType) && !ast.OutputType().IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("invalid %s: must be true or false, not %s", e.name, ast.OutputType())
+	}
+	program, err := e.env.Program(ast,
+		cel.CostLimit(maxCost),
+		cel.InterruptCheckFrequency(interruptCheckFrequency),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", e.name, err)
+	}
+	return &Expression{source: source, program: program}, nil
+}
+
+// String returns the expression's source
+func (x *Expression) String() string {
+	return x.source
+}
+
+// Eval evaluates the expression with the given variables, returning its result as a Go value
+func (x *Expression) Eval(ctx context.Context, vars map[string]interface{}) (interface{}, error) {
+	activation := make(map[string]interface{}, len(vars))
+	for name, value := range vars {
+		activation[name] = normalize(value)
+	}
+	result, _, err := x.program.ContextEval(ctx, activation)
+	if err != nil {
+		return nil, err
+	}
+	return native(result)
+}
+
+// EvalBool evaluates a condition with the given variables
+func (x *Expression) EvalBool(ctx context.Context, vars map[string]interface{}) (bool, error) {
+	result, err := x.Eval(ctx, vars)
+	if err != nil {
+		return false, err
+	}
+	matched, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression returned %T, not true or false", result)
+	}
+	return matched, nil
+}
+
+// normalize converts a variable to values CEL reads: JSON numbers become integers or floats, and
+// structs and other composite values are read as their JSON
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil, bool, string, int, int32, int64, uint, uint32, uint64, float32, float64, []byte, time.Time, time.Duration:
+		return v
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[key] = normalize(item)
+		}
+		return normalized
+	case map[string]string:
+		return v
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized[i] = normalize(item)
+		}
+		return normalized
+	case []string:
+		return v
+	}
+
+	// Named map and slice types and structs, e.g. job configs and recorded output
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return fmt.Sprint(value)
+	}
+	return normalize(decoded)
+}
+
+// native converts a CEL result to a Go value: lists and maps become []interface{} and
+// map[string]interface{}, converted all the way down
+func native(value ref.Val) (interface{}, error) {
+	switch v := value.(type) {
+	case traits.Lister:
+		size, _ := v.Size().(types.Int)
+		list := make([]interface{}, 0, size)
+		for it := v.Iterator(); it.HasNext() == types.True; {
+			item, err := native(it.Next())
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		return list, nil
+	case traits.Mapper:
+		size, _ := v.Size().(types.Int)
+		m := make(map[string]interface{}, size)
+		for it := v.Iterator(); it.HasNext() == types.True; {
+			key := it.Next()
+			name, ok := key.Value().(string)
+			if !ok {
+				return nil, fmt.Errorf("map keys must be strings, not %s", key.Type().TypeName())
+			}
+			item, err := native(v.Get(key))
+			if err != nil {
+				return nil, err
+			}
+			m[name] = item
+		}
+		return m, nil
+	case types.Null:
+		return nil, nil
+	default:
+		return value.Value(), nil
+	}
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+	"job-scheduler/internal/usertokens"
+)
+
+const (
+	// roleContextKey is where AccessControl leaves the requesting user's role
+	roleContextKey = "role"
+	// actorContextKey is where AccessControl leaves the authenticated user
+	actorContextKey = "actor"
+)
+
+var (
+	// ErrNotJobOwner is returned when an operator changes a job someone else owns
+	ErrNotJobOwner = errors.New("operators may only change jobs they own")
+	// ErrJobOwnerChange is returned when an operator gives their job to someone else
+	ErrJobOwnerChange = errors.New("only admins may change a job's owner")
+)
+
+// publicRoutes are reached without a user: they are either unauthenticated by nature or carry
+// their own credentials, such as inbound hook tokens, signed remediation links, the replication token
+// and the email events token
+var publicRoutes = map[string]bool{
+	"GET /health":                        true,
+	"POST /hooks/:token":                 true,
+	"GET /actions/:execution_id/:index":  true,
+	"POST /actions/:execution_id/:index": true,
+	"GET /openapi.json":                  true,
+	"GET /docs":                          true,
+	"GET /replication/changes":           true,
+	"GET /replication/snapshot":          true,
+	"POST /email/events":                 true,
+}
+
+// operatorRoutes change how jobs run without creating or deleting anything
+var operatorRoutes = map[string]bool{
+	"PUT /jobs/:id":                                       true,
+	"PATCH /jobs/:id":                                     true,
+	"POST /jobs/:id/pause":                                true,
+	"POST /jobs/:id/resume":                               true,
+	"POST /jobs/:id/trigger":                              true,
+	"POST /executions/:id/cancel":                         true,
+	"POST /executions/:id/extend":                         true,
+	"PUT /executions/:id/deadline":                        true,
+	"POST /runs/:id/approve":                              true,
+	"POST /runs/:id/reject":                               true,
+	"POST /jobs/:id/acknowledgments":                      true,
+	"DELETE /jobs/:id/acknowledgments/:acknowledgment_id": true,
+	"POST /workflows/:id/trigger":                         true,
+	"POST /jobs/:id/silence":                              true,
+	"DELETE /jobs/:id/silence":                            true,
+	"POST /jobs/preview":                                  true,
+}
+
+// adminPrefixes are the paths whose every route, reads included, is for admins only
+var adminPrefixes = []string{"/admin", "/role-assignments"}
+
+// AccessControl enforces roles on API routes
+type AccessControl struct {
+	roles  services.RoleService
+	cfg    config.RBACConfig
+	tokens *usertokens.Signer
+}
+
+// NewAccessControl creates a new access control
+func NewAccessControl(roles services.RoleService, cfg config.RBACConfig) *AccessControl {
+	return &AccessControl{
+		roles:  roles,
+		cfg:    cfg,
+		tokens: usertokens.NewSigner(cfg.TokenSecret),
+	}
+}
+
+// Middleware returns middleware for a versioned route group authenticating the user and checking
+// their role against the route: viewers may read, operators may also pause, resume and update their
+// jobs and act on runs, and everything else, creating and deleting included, is for admins.
+// The X-User header is ignored, since anyone could send it
+func (a *AccessControl) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !a.cfg.Enabled {
+			c.Next()
+			return
+		}
+		// Set before anything else, so actorFromRequest never falls back to X-User
+		c.Set(actorContextKey, "")
+
+		route := c.FullPath()
+		if route == "" {
+			// Unknown routes fall through to the 404 handler
+			c.Next()
+			return
+		}
+		required, public := requiredRole(c.Request.Method, route)
+		if public {
+			c.Next()
+			return
+		}
+
+		user, err := a.authenticate(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.NewErrorResponse(
+				dto.ErrorCodeUnauthorized,
+				"A valid bearer token or client certificate is required",
+				err,
+			))
+			return
+		}
+		c.Set(actorContextKey, user)
+
+		role, err := a.roles.ResolveRole(user)
+		if err != nil {
+			logrus.WithError(err).WithField("user", user).Error("Failed to resolve role")
+			c.AbortWithStatusJSON(http.StatusInternalServerError, dto.NewErrorResponse(dto.ErrorCodeInternal, "Failed to resolve role", err))
+			return
+		}
+		if !role.Allows(required) {
+			logrus.WithFields(logrus.Fields{
+				"user":     user,
+				"role":     role,
+				"required": required,
+				"route":    c.Request.Method + " " + route,
+			}).Warn("Request refused by access control")
+			c.AbortWithStatusJSON(http.StatusForbidden, dto.NewErrorResponse(
+				dto.ErrorCodeForbidden,
+				fmt.Sprintf("This action requires the %s role", required),
+				nil,
+			))
+			return
+		}
+
+		c.Set(roleContextKey, role)
+		c.Next()
+	}
+}
+
+// authenticate returns the user a request proves it comes from: the common name of a verified
+// client certificate, or the user named by a bearer token signed with the token secret
+func (a *AccessControl) authenticate(c *gin.Context) (string, error) {
+	if state := c.Request.TLS; state != nil && len(state.VerifiedChains) > 0 && len(state.VerifiedChains[0]) > 0 {
+		if user := strings.TrimSpace(state.VerifiedChains[0][0].Subject.CommonName); user != "" {
+			return user, nil
+		}
+	}
+
+	header := c.GetHeader("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return "", errors.New("no credentials")
+	}
+	return a.tokens.Verify(strings.TrimSpace(strings.TrimPrefix(header, "Bearer ")), time.Now())
+}
+
+// requiredRole returns the role a route requires, or public for routes anyone may reach
+// Routes are matched without their /api/vN prefix, so every version shares the policy
+func requiredRole(method, route string) (required models.Role, public bool) {
+	if strings.HasPrefix(route, "/api/") {
+		rest := strings.TrimPrefix(route, "/api/")
+		if i := strings.Index(rest, "/"); i >= 0 {
+			route = rest[i:]
+		}
+	}
+	key := method + " " + route
+
+	if publicRoutes[key] {
+		return "", true
+	}
+	for _, prefix := range adminPrefixes {
+		if route == prefix || strings.HasPrefix(route, prefix+"/") {
+			return models.RoleAdmin, false
+		}
+	}
+	if operatorRoutes[key] {
+		return models.RoleOperator, false
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return models.RoleViewer, false
+	}
+	return models.RoleAdmin, false
+}
+
+// authorizeJobChange checks that the requesting user may change a job
+// Admins may change any job; operators only the jobs they own, and may not give them away.
+// Without access control every user may change every job
+func authorizeJobChange(c *gin.Context, jobService services.JobService, jobID uuid.UUID, req *models.UpdateJobRequest) error {
+	value, ok := c.Get(roleContextKey)
+	if !ok {
+		return nil
+	}
+	if role, _ := value.(models.Role); role.Allows(models.RoleAdmin) {
+		return nil
+	}
+
+	job, err := jobService.GetJobByID(jobID)
+	if err != nil {
+		return err
+	}
+	actor := actorFromRequest(c)
+	if job.Owner != actor {
+		return ErrNotJobOwner
+	}
+	if req != nil && req.Owner != nil && *req.Owner != actor {
+		return ErrJobOwnerChange
+	}
+	return nil
+}
+
+// isAccessDenied reports whether an error from authorizeJobChange refused the change,
+// rather than failing to find the job
+func isAccessDenied(err error) bool {
+	return errors.Is(err, ErrNotJobOwner) || errors.Is(err, ErrJobOwnerChange)
+}
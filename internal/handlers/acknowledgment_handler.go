@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// AcknowledgmentHandler handles HTTP requests for operator acknowledgments and silences of jobs
+type AcknowledgmentHandler struct {
+	acknowledgmentService services.AcknowledgmentService
+}
+
+// NewAcknowledgmentHandler creates a new acknowledgment handler
+func NewAcknowledgmentHandler(acknowledgmentService services.AcknowledgmentService) *AcknowledgmentHandler {
+	return &AcknowledgmentHandler{
+		acknowledgmentService: acknowledgmentService,
+	}
+}
+
+// AcknowledgeJob handles POST /api/v1/jobs/{id}/acknowledgments
+func (h *AcknowledgmentHandler) AcknowledgeJob(c *gin.Context) {
+	// Parse job ID from URL parameter
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+
+	var req models.CreateJobAcknowledgmentRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind acknowledge job request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	acknowledgment, err := h.acknowledgmentService.AcknowledgeJob(jobID, &req, actorFromRequest(c))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to acknowledge job")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to acknowledge job",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":        "Job acknowledged successfully",
+		"acknowledgment": dto.FromJobAcknowledgment(acknowledgment),
+	})
+}
+
+// GetJobAcknowledgments handles GET /api/v1/jobs/{id}/acknowledgments
+func (h *AcknowledgmentHandler) GetJobAcknowledgments(c *gin.Context) {
+	// Parse job ID from URL parameter
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+
+	acknowledgments, err := h.acknowledgmentService.GetJobAcknowledgments(jobID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get job acknowledgments")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve job acknowledgments",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"acknowledgments": dto.FromJobAcknowledgments(acknowledgments),
+	})
+}
+
+// ClearAcknowledgment handles DELETE /api/v1/jobs/{id}/acknowledgments/{acknowledgment_id}
+// The acknowledgment is kept in the job's history, marked as cleared
+func (h *AcknowledgmentHandler) ClearAcknowledgment(c *gin.Context) {
+	// Parse job and acknowledgment IDs from URL parameters
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+	acknowledgmentID, err := uuid.Parse(c.Param("acknowledgment_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid acknowledgment ID format",
+		})
+		return
+	}
+
+	acknowledgment, err := h.acknowledgmentService.ClearAcknowledgment(jobID, acknowledgmentID, actorFromRequest(c))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to clear job acknowledgment")
+		status := http.StatusNotFound
+		if errors.Is(err, services.ErrAcknowledgmentInactive) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to clear job acknowledgment",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "Job acknowledgment cleared successfully",
+		"acknowledgment": dto.FromJobAcknowledgment(acknowledgment),
+	})
+}
+
+// SilenceJob handles POST /api/v1/jobs/{id}/silence
+// A silence is an acknowledgment in the notifications scope, for a duration
+func (h *AcknowledgmentHandler) SilenceJob(c *gin.Context) {
+	// Parse job ID from URL parameter
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+
+	var req models.CreateJobSilenceRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind silence job request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	silence, err := h.acknowledgmentService.SilenceJob(jobID, &req, actorFromRequest(c))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to silence job")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to silence job",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Job silenced successfully",
+		"silence": dto.FromJobAcknowledgment(silence),
+	})
+}
+
+// LiftSilence handles DELETE /api/v1/jobs/{id}/silence
+// It clears the job's acknowledgments in the notifications scope, which stay in its history
+func (h *AcknowledgmentHandler) LiftSilence(c *gin.Context) {
+	// Parse job ID from URL parameter
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+
+	silences, err := h.acknowledgmentService.ClearScope(jobID, models.AcknowledgmentScopeNotifications, actorFromRequest(c))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to lift job silence")
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrJobNotAcknowledged) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to lift job silence",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Job silence lifted successfully",
+		"silences": dto.FromJobAcknowledgments(silences),
+	})
+}
+
+// ListActiveSilences handles GET /api/v1/silences
+func (h *AcknowledgmentHandler) ListActiveSilences(c *gin.Context) {
+	silences, err := h.acknowledgmentService.ListActiveAcknowledgments(models.AcknowledgmentScopeNotifications)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get active silences")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve active silences",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"silences": dto.FromJobAcknowledgments(silences),
+	})
+}
+
+// RegisterRoutes registers all acknowledgment routes, silences included
+func (h *AcknowledgmentHandler) RegisterRoutes(router *gin.RouterGroup) {
+	jobs := router.Group("/jobs")
+	{
+		jobs.POST("/:id/acknowledgments", h.AcknowledgeJob)
+		jobs.GET("/:id/acknowledgments", h.GetJobAcknowledgments)
+		jobs.DELETE("/:id/acknowledgments/:acknowledgment_id", h.ClearAcknowledgment)
+		jobs.POST("/:id/silence", h.SilenceJob)
+		jobs.DELETE("/:id/silence", h.LiftSilence)
+	}
+
+	router.GET("/silences", h.ListActiveSilences)
+}
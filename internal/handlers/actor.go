@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActorHeader identifies the user making a request when access control is off; it is only
+// used for attribution, since anyone could send it
+const ActorHeader = "X-User"
+
+// actorFromRequest returns the user making the request, or "" if unidentified
+// With access control on, this is the user AccessControl authenticated and never the header
+func actorFromRequest(c *gin.Context) string {
+	if value, ok := c.Get(actorContextKey); ok {
+		user, _ := value.(string)
+		return user
+	}
+	return strings.TrimSpace(c.GetHeader(ActorHeader))
+}
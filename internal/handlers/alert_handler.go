@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// AlertHandler handles HTTP requests for alert rules and the alerts they fire
+type AlertHandler struct {
+	alertService services.AlertService
+}
+
+// NewAlertHandler creates a new alert handler
+func NewAlertHandler(alertService services.AlertService) *AlertHandler {
+	return &AlertHandler{
+		alertService: alertService,
+	}
+}
+
+// GetAlerts handles GET /api/v1/alerts
+// The response is in Alertmanager's v2 format, so it can be scraped or forwarded as is
+func (h *AlertHandler) GetAlerts(c *gin.Context) {
+	c.JSON(http.StatusOK, h.alertService.GetFiringAlerts())
+}
+
+// CreateAlertRule handles POST /api/v1/alert-rules
+func (h *AlertHandler) CreateAlertRule(c *gin.Context) {
+	var req models.CreateAlertRuleRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind create alert rule request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.alertService.CreateAlertRule(&req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create alert rule")
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrAlertRuleExists) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to create alert rule",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    "Alert rule created successfully",
+		"alert_rule": dto.FromAlertRule(rule),
+	})
+}
+
+// GetAlertRules handles GET /api/v1/alert-rules
+func (h *AlertHandler) GetAlertRules(c *gin.Context) {
+	rules, err := h.alertService.GetAlertRules()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get alert rules")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve alert rules",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"alert_rules": dto.FromAlertRules(rules),
+	})
+}
+
+// UpdateAlertRule handles PUT /api/v1/alert-rules/{id}
+func (h *AlertHandler) UpdateAlertRule(c *gin.Context) {
+	// Parse alert rule ID from URL parameter
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid alert rule ID format",
+		})
+		return
+	}
+
+	var req models.UpdateAlertRuleRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind update alert rule request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.alertService.UpdateAlertRule(ruleID, &req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to update alert rule")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to update alert rule",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Alert rule updated successfully",
+		"alert_rule": dto.FromAlertRule(rule),
+	})
+}
+
+// DeleteAlertRule handles DELETE /api/v1/alert-rules/{id}
+func (h *AlertHandler) DeleteAlertRule(c *gin.Context) {
+	// Parse alert rule ID from URL parameter
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid alert rule ID format",
+		})
+		return
+	}
+
+	if err := h.alertService.DeleteAlertRule(ruleID); err != nil {
+		logrus.WithError(err).Error("Failed to delete alert rule")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Failed to delete alert rule",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Alert rule deleted successfully",
+	})
+}
+
+// RegisterRoutes registers all alert routes
+func (h *AlertHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/alerts", h.GetAlerts)
+
+	rules := router.Group("/alert-rules")
+	{
+		rules.POST("", h.CreateAlertRule)
+		rules.GET("", h.GetAlertRules)
+		rules.PUT("/:id", h.UpdateAlertRule)
+		rules.DELETE("/:id", h.DeleteAlertRule)
+	}
+}
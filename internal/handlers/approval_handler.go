@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/services"
+)
+
+// ApprovalHandler handles HTTP requests for run approvals
+type ApprovalHandler struct {
+	approvalService services.ApprovalService
+}
+
+// NewApprovalHandler creates a new approval handler
+func NewApprovalHandler(approvalService services.ApprovalService) *ApprovalHandler {
+	return &ApprovalHandler{
+		approvalService: approvalService,
+	}
+}
+
+// ApprovalRequest represents the request payload for approving or rejecting a run
+type ApprovalRequest struct {
+	Approver string `json:"approver" binding:"required"`
+	Reason   string `json:"reason"`
+}
+
+// GetPendingApprovals handles GET /api/v1/runs/pending-approval
+func (h *ApprovalHandler) GetPendingApprovals(c *gin.Context) {
+	runs, err := h.approvalService.GetPendingApprovals()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get pending approvals")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve pending approvals",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"runs": dto.FromExecutions(runs),
+	})
+}
+
+// ApproveRun handles POST /api/v1/runs/{id}/approve
+func (h *ApprovalHandler) ApproveRun(c *gin.Context) {
+	runID, req, ok := h.parseApprovalRequest(c)
+	if !ok {
+		return
+	}
+
+	run, err := h.approvalService.Approve(runID, req.Approver)
+	if err != nil {
+		h.respondError(c, "Failed to approve run", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Run approved",
+		"run":     dto.FromExecution(run),
+	})
+}
+
+// RejectRun handles POST /api/v1/runs/{id}/reject
+func (h *ApprovalHandler) RejectRun(c *gin.Context) {
+	runID, req, ok := h.parseApprovalRequest(c)
+	if !ok {
+		return
+	}
+
+	run, err := h.approvalService.Reject(runID, req.Approver, req.Reason)
+	if err != nil {
+		h.respondError(c, "Failed to reject run", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Run rejected",
+		"run":     dto.FromExecution(run),
+	})
+}
+
+// parseApprovalRequest parses the run ID and body, writing an error response on failure
+func (h *ApprovalHandler) parseApprovalRequest(c *gin.Context) (uuid.UUID, *ApprovalRequest, bool) {
+	runID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid run ID format",
+		})
+		return uuid.Nil, nil, false
+	}
+
+	var req ApprovalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return uuid.Nil, nil, false
+	}
+
+	return runID, &req, true
+}
+
+// respondError maps approval errors to HTTP status codes
+func (h *ApprovalHandler) respondError(c *gin.Context, message string, err error) {
+	logrus.WithError(err).Error(message)
+
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, services.ErrNotAwaitingApproval):
+		status = http.StatusConflict
+	case errors.Is(err, services.ErrApprovalExpired):
+		status = http.StatusGone
+	}
+
+	c.JSON(status, gin.H{
+		"error":   message,
+		"details": err.Error(),
+	})
+}
+
+// RegisterRoutes registers all approval-related routes
+func (h *ApprovalHandler) RegisterRoutes(router *gin.RouterGroup) {
+	runs := router.Group("/runs")
+	{
+		runs.GET("/pending-approval", h.GetPendingApprovals)
+		runs.POST("/:id/approve", h.ApproveRun)
+		runs.POST("/:id/reject", h.RejectRun)
+	}
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/services"
+)
+
+// ArtifactHandler handles HTTP requests for run artifacts such as generated reports
+type ArtifactHandler struct {
+	artifactService services.ArtifactService
+}
+
+// NewArtifactHandler creates a new artifact handler
+func NewArtifactHandler(artifactService services.ArtifactService) *ArtifactHandler {
+	return &ArtifactHandler{
+		artifactService: artifactService,
+	}
+}
+
+// GetJobReports handles GET /api/v1/jobs/{id}/reports
+func (h *ArtifactHandler) GetJobReports(c *gin.Context) {
+	// Parse job ID from URL parameter
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+
+	reports, err := h.artifactService.GetJobReports(jobID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get job reports")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Failed to retrieve reports",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"job_id":     jobID,
+		"executions": dto.FromArtifactsByExecution(reports),
+	})
+}
+
+// GetRunArtifacts handles GET /api/v1/runs/{id}/artifacts
+func (h *ArtifactHandler) GetRunArtifacts(c *gin.Context) {
+	// Parse run ID from URL parameter
+	runID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid run ID format",
+		})
+		return
+	}
+
+	artifactList, err := h.artifactService.GetExecutionArtifacts(runID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get run artifacts")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve artifacts",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"run_id":    runID,
+		"artifacts": dto.FromArtifacts(artifactList),
+	})
+}
+
+// DownloadArtifact handles GET /api/v1/artifacts/{id}/download
+// Without a signature it issues a signed URL (redirecting to it with ?redirect=true);
+// with one it serves the file from local storage
+func (h *ArtifactHandler) DownloadArtifact(c *gin.Context) {
+	// Parse artifact ID from URL parameter
+	artifactID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid artifact ID format",
+		})
+		return
+	}
+
+	if signature := c.Query("signature"); signature != "" {
+		h.serveSignedDownload(c, artifactID, c.Query("expires"), signature)
+		return
+	}
+
+	url, expiresAt, err := h.artifactService.GetDownloadURL(artifactID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create download URL")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Failed to create download URL",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if c.Query("redirect") == "true" {
+		c.Redirect(http.StatusFound, url)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"url":        url,
+		"expires_at": expiresAt,
+	})
+}
+
+// GetExecutionArtifact handles GET /api/v1/executions/{id}/artifacts/{name}
+// It issues a signed URL for the named file of the run, redirecting to it with ?redirect=true
+func (h *ArtifactHandler) GetExecutionArtifact(c *gin.Context) {
+	// Parse execution ID from URL parameter
+	executionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid execution ID format",
+		})
+		return
+	}
+
+	artifact, url, expiresAt, err := h.artifactService.GetExecutionArtifactURL(executionID, c.Param("name"))
+	if err != nil {
+		if errors.Is(err, services.ErrArtifactNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "Artifact not found",
+				"details": err.Error(),
+			})
+			return
+		}
+		logrus.WithError(err).Error("Failed to create download URL")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to create download URL",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if c.Query("redirect") == "true" {
+		c.Redirect(http.StatusFound, url)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"artifact":   dto.FromArtifact(artifact),
+		"url":        url,
+		"expires_at": expiresAt,
+	})
+}
+
+// serveSignedDownload streams an artifact after verifying its signed URL
+func (h *ArtifactHandler) serveSignedDownload(c *gin.Context, artifactID uuid.UUID, expires, signature string) {
+	artifact, file, err := h.artifactService.OpenSignedDownload(artifactID, expires, signature)
+	if err != nil {
+		logrus.WithError(err).Warn("Rejected artifact download")
+		status := http.StatusNotFound
+		switch {
+		case errors.Is(err, services.ErrDownloadLinkInvalid):
+			status = http.StatusForbidden
+		case errors.Is(err, services.ErrDownloadLinkExpired):
+			status = http.StatusGone
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to download artifact",
+			"details": err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, artifact.SizeBytes, artifact.ContentType, file, map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=%q", artifact.Name),
+	})
+}
+
+// RegisterRoutes registers all artifact routes
+func (h *ArtifactHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/jobs/:id/reports", h.GetJobReports)
+	router.GET("/runs/:id/artifacts", h.GetRunArtifacts)
+	router.GET("/executions/:id/artifacts", h.GetRunArtifacts)
+	router.GET("/executions/:id/artifacts/:name", h.GetExecutionArtifact)
+	router.GET("/artifacts/:id/download", h.DownloadArtifact)
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/services"
+)
+
+// ChangeControlHandler handles HTTP requests for reviewing protected job changes
+type ChangeControlHandler struct {
+	changeControl services.ChangeControlService
+}
+
+// NewChangeControlHandler creates a new change control handler
+func NewChangeControlHandler(changeControl services.ChangeControlService) *ChangeControlHandler {
+	return &ChangeControlHandler{
+		changeControl: changeControl,
+	}
+}
+
+// ReviewChangeRequest represents the request payload for rejecting a change
+type ReviewChangeRequest struct {
+	Reason string `json:"reason"`
+}
+
+// GetPendingChanges handles GET /api/v1/pending-changes
+func (h *ChangeControlHandler) GetPendingChanges(c *gin.Context) {
+	changes, err := h.changeControl.GetPendingChanges()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get pending changes")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve pending changes",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pending_changes": dto.FromPendingChanges(changes),
+	})
+}
+
+// ApproveChange handles POST /api/v1/pending-changes/{id}/approve
+func (h *ChangeControlHandler) ApproveChange(c *gin.Context) {
+	changeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid change ID format",
+		})
+		return
+	}
+
+	change, err := h.changeControl.Approve(changeID, actorFromRequest(c))
+	if err != nil {
+		h.respondError(c, "Failed to approve change", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "Change approved and applied",
+		"pending_change": dto.FromPendingChange(change),
+	})
+}
+
+// RejectChange handles POST /api/v1/pending-changes/{id}/reject
+func (h *ChangeControlHandler) RejectChange(c *gin.Context) {
+	changeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid change ID format",
+		})
+		return
+	}
+
+	// Body is optional
+	var req ReviewChangeRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request body",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	change, err := h.changeControl.Reject(changeID, actorFromRequest(c), req.Reason)
+	if err != nil {
+		h.respondError(c, "Failed to reject change", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "Change rejected",
+		"pending_change": dto.FromPendingChange(change),
+	})
+}
+
+// respondError maps change control errors to HTTP status codes
+func (h *ChangeControlHandler) respondError(c *gin.Context, message string, err error) {
+	logrus.WithError(err).Error(message)
+
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, services.ErrActorRequired), errors.Is(err, services.ErrSelfApproval):
+		status = http.StatusForbidden
+	case errors.Is(err, services.ErrChangeNotPending):
+		status = http.StatusConflict
+	}
+
+	c.JSON(status, gin.H{
+		"error":   message,
+		"details": err.Error(),
+	})
+}
+
+// RegisterRoutes registers all change control routes
+func (h *ChangeControlHandler) RegisterRoutes(router *gin.RouterGroup) {
+	changes := router.Group("/pending-changes")
+	{
+		changes.GET("", h.GetPendingChanges)
+		changes.POST("/:id/approve", h.ApproveChange)
+		changes.POST("/:id/reject", h.RejectChange)
+	}
+}
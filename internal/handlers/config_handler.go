@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"job-scheduler/internal/config"
+)
+
+// ConfigHandler handles the admin endpoint reporting the configuration this instance runs with
+type ConfigHandler struct {
+	cfg *config.Config
+}
+
+// NewConfigHandler creates a new config handler
+func NewConfigHandler(cfg *config.Config) *ConfigHandler {
+	return &ConfigHandler{
+		cfg: cfg,
+	}
+}
+
+// GetEffectiveConfig handles GET /api/v1/admin/config
+// It returns the merged configuration from .env, the environment and defaults, with secrets redacted
+func (h *ConfigHandler) GetEffectiveConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"config": h.cfg.Effective(),
+	})
+}
+
+// RegisterRoutes registers all config routes
+func (h *ConfigHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/admin/config", h.GetEffectiveConfig)
+}
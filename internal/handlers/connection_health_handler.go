@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// ConnectionHealthHandler handles the endpoint reporting the health of the external connections jobs use
+type ConnectionHealthHandler struct {
+	connections services.ConnectionMonitor
+}
+
+// NewConnectionHealthHandler creates a new connection health handler
+func NewConnectionHealthHandler(connections services.ConnectionMonitor) *ConnectionHealthHandler {
+	return &ConnectionHealthHandler{
+		connections: connections,
+	}
+}
+
+// GetConnectionHealth handles GET /api/v1/connections/health
+// It lists every probed connection with its status and probe stats; runs of jobs using a down one fail fast
+func (h *ConnectionHealthHandler) GetConnectionHealth(c *gin.Context) {
+	connections := h.connections.GetConnectionHealth()
+
+	down := 0
+	for _, connection := range connections {
+		if connection.Status == models.ConnectionStatusDown {
+			down++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"connections": connections,
+		"count":       len(connections),
+		"down":        down,
+	})
+}
+
+// RegisterRoutes registers all connection health routes
+func (h *ConnectionHealthHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/connections/health", h.GetConnectionHealth)
+}
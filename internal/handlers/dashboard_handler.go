@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/services"
+)
+
+// DashboardHandler handles HTTP requests for the on-call dashboard
+type DashboardHandler struct {
+	dashboardService services.DashboardService
+}
+
+// NewDashboardHandler creates a new dashboard handler
+func NewDashboardHandler(dashboardService services.DashboardService) *DashboardHandler {
+	return &DashboardHandler{
+		dashboardService: dashboardService,
+	}
+}
+
+// GetDashboard handles GET /api/v1/dashboard
+func (h *DashboardHandler) GetDashboard(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	dashboard, err := h.dashboardService.GetDashboard(limit)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get dashboard")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve dashboard",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dashboard)
+}
+
+// GetFailingJobs handles GET /api/v1/jobs/failing
+func (h *DashboardHandler) GetFailingJobs(c *gin.Context) {
+	failing, err := h.dashboardService.GetFailingJobs()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get failing jobs")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve failing jobs",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	acknowledged := 0
+	for _, job := range failing {
+		if job.Acknowledgment != nil {
+			acknowledged++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"jobs":         failing,
+		"count":        len(failing),
+		"acknowledged": acknowledged,
+	})
+}
+
+// RegisterRoutes registers all dashboard routes
+func (h *DashboardHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/dashboard", h.GetDashboard)
+	router.GET("/jobs/failing", h.GetFailingJobs)
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// DatabaseConnectionHandler handles HTTP requests for registered external databases
+type DatabaseConnectionHandler struct {
+	connectionService services.DatabaseConnectionService
+}
+
+// NewDatabaseConnectionHandler creates a new database connection handler
+func NewDatabaseConnectionHandler(connectionService services.DatabaseConnectionService) *DatabaseConnectionHandler {
+	return &DatabaseConnectionHandler{
+		connectionService: connectionService,
+	}
+}
+
+// CreateDatabaseConnection handles POST /api/v1/database-connections
+func (h *DatabaseConnectionHandler) CreateDatabaseConnection(c *gin.Context) {
+	var req models.CreateDatabaseConnectionRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind create database connection request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	connection, err := h.connectionService.CreateDatabaseConnection(&req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create database connection")
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrDatabaseConnectionExists) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to create database connection",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":             "Database connection registered successfully",
+		"database_connection": dto.FromDatabaseConnection(connection),
+	})
+}
+
+// GetDatabaseConnections handles GET /api/v1/database-connections
+func (h *DatabaseConnectionHandler) GetDatabaseConnections(c *gin.Context) {
+	connections, err := h.connectionService.GetDatabaseConnections()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get database connections")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve database connections",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"database_connections": dto.FromDatabaseConnections(connections),
+	})
+}
+
+// UpdateDatabaseConnection handles PUT /api/v1/database-connections/{id}
+func (h *DatabaseConnectionHandler) UpdateDatabaseConnection(c *gin.Context) {
+	// Parse database connection ID from URL parameter
+	connectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid database connection ID format",
+		})
+		return
+	}
+
+	var req models.UpdateDatabaseConnectionRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind update database connection request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	connection, err := h.connectionService.UpdateDatabaseConnection(connectionID, &req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to update database connection")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to update database connection",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":             "Database connection updated successfully",
+		"database_connection": dto.FromDatabaseConnection(connection),
+	})
+}
+
+// DeleteDatabaseConnection handles DELETE /api/v1/database-connections/{id}
+func (h *DatabaseConnectionHandler) DeleteDatabaseConnection(c *gin.Context) {
+	// Parse database connection ID from URL parameter
+	connectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid database connection ID format",
+		})
+		return
+	}
+
+	if err := h.connectionService.DeleteDatabaseConnection(connectionID); err != nil {
+		logrus.WithError(err).Error("Failed to delete database connection")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Failed to delete database connection",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Database connection deleted successfully",
+	})
+}
+
+// RegisterRoutes registers all database connection routes
+func (h *DatabaseConnectionHandler) RegisterRoutes(router *gin.RouterGroup) {
+	connections := router.Group("/database-connections")
+	{
+		connections.POST("", h.CreateDatabaseConnection)
+		connections.GET("", h.GetDatabaseConnections)
+		connections.PUT("/:id", h.UpdateDatabaseConnection)
+		connections.DELETE("/:id", h.DeleteDatabaseConnection)
+	}
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"job-scheduler/internal/apidocs"
+)
+
+// DocsHandler serves the OpenAPI document describing the API and a Swagger UI page browsing it
+type DocsHandler struct {
+	routes func() gin.RoutesInfo
+
+	once sync.Once
+	doc  *apidocs.Document
+}
+
+// NewDocsHandler creates a new docs handler describing the routes returned by routes, usually the
+// router's Routes method
+// The document is generated on the first request, once every route has been registered
+func NewDocsHandler(routes func() gin.RoutesInfo) *DocsHandler {
+	return &DocsHandler{
+		routes: routes,
+	}
+}
+
+// GetOpenAPI handles GET /api/v1/openapi.json
+func (h *DocsHandler) GetOpenAPI(c *gin.Context) {
+	h.once.Do(func() {
+		h.doc = apidocs.Generate(h.routes(), apidocs.Options{
+			Title:      "Job Scheduler API",
+			Version:    "1.0.0",
+			BearerAuth: true,
+			Access: func(method, path string) (string, bool) {
+				role, public := requiredRole(method, path)
+				return string(role), public
+			},
+		})
+	})
+
+	c.JSON(http.StatusOK, h.doc)
+}
+
+// GetSwaggerUI handles GET /api/v1/docs
+func (h *DocsHandler) GetSwaggerUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", apidocs.SwaggerUI())
+}
+
+// RegisterRoutes registers the docs routes
+func (h *DocsHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/openapi.json", h.GetOpenAPI)
+	router.GET("/docs", h.GetSwaggerUI)
+}
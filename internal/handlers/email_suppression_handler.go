@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// EmailSuppressionHandler handles the addresses email jobs don't send to, and the email provider's
+// events that add to them
+type EmailSuppressionHandler struct {
+	suppressionService services.EmailSuppressionService
+	eventsToken        string
+}
+
+// NewEmailSuppressionHandler creates a new email suppression handler
+func NewEmailSuppressionHandler(suppressionService services.EmailSuppressionService, cfg config.NotificationsConfig) *EmailSuppressionHandler {
+	return &EmailSuppressionHandler{
+		suppressionService: suppressionService,
+		eventsToken:        cfg.EmailEventsToken,
+	}
+}
+
+// ListSuppressions handles GET /api/v1/admin/email-suppressions
+func (h *EmailSuppressionHandler) ListSuppressions(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	list, err := h.suppressionService.ListSuppressions(page, limit)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get email suppressions")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve email suppressions",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// Suppress handles POST /api/v1/admin/email-suppressions
+func (h *EmailSuppressionHandler) Suppress(c *gin.Context) {
+	var req models.CreateEmailSuppressionRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind email suppression request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	suppression, err := h.suppressionService.Suppress(&req, actorFromRequest(c))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to suppress email address")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to suppress email address",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":     "Email address suppressed successfully",
+		"suppression": suppression,
+	})
+}
+
+// Unsuppress handles DELETE /api/v1/admin/email-suppressions/{email}
+func (h *EmailSuppressionHandler) Unsuppress(c *gin.Context) {
+	if err := h.suppressionService.Unsuppress(c.Param("email"), actorFromRequest(c)); err != nil {
+		logrus.WithError(err).Error("Failed to lift email suppression")
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrEmailNotSuppressed) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to lift email suppression",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Email suppression lifted successfully",
+	})
+}
+
+// HandleEmailEvents handles POST /api/v1/email/events
+// The email provider posts its events as a JSON array, authenticated by the events token as a
+// bearer token or a ?token= query parameter
+func (h *EmailSuppressionHandler) HandleEmailEvents(c *gin.Context) {
+	if h.eventsToken == "" {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Email events are not enabled",
+		})
+		return
+	}
+	token := c.Query("token")
+	if header := c.GetHeader("Authorization"); header != "" {
+		token = strings.TrimPrefix(header, "Bearer ")
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.eventsToken)) != 1 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid email events token",
+		})
+		return
+	}
+
+	var events []models.EmailEvent
+	if err := c.ShouldBindJSON(&events); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	suppressed, err := h.suppressionService.HandleEmailEvents(events)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to handle email events")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to handle email events",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"received":   len(events),
+		"suppressed": suppressed,
+	})
+}
+
+// RegisterRoutes registers all email suppression routes
+func (h *EmailSuppressionHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/admin/email-suppressions", h.ListSuppressions)
+	router.POST("/admin/email-suppressions", h.Suppress)
+	router.DELETE("/admin/email-suppressions/:email", h.Unsuppress)
+	router.POST("/email/events", h.HandleEmailEvents)
+}
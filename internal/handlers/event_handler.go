@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/events"
+)
+
+// eventStreamBuffer is how many events a slow client may fall behind before events are dropped for it
+const eventStreamBuffer = 64
+
+// eventStreamHeartbeat is how often an idle stream is sent a ping, so proxies don't close it
+const eventStreamHeartbeat = 30 * time.Second
+
+// EventHandler handles HTTP requests streaming job lifecycle events
+type EventHandler struct {
+	jobEvents *events.JobEventBus
+}
+
+// NewEventHandler creates a new event handler
+func NewEventHandler(jobEvents *events.JobEventBus) *EventHandler {
+	return &EventHandler{
+		jobEvents: jobEvents,
+	}
+}
+
+// StreamEvents handles GET /api/v1/events
+// Job lifecycle events are streamed as server-sent events named after their type, optionally only
+// those of ?types=<type>,<type> and ?job_id=<id>
+func (h *EventHandler) StreamEvents(c *gin.Context) {
+	var types []events.JobEventType
+	if param := c.Query("types"); param != "" {
+		for _, name := range strings.Split(param, ",") {
+			eventType := events.JobEventType(strings.TrimSpace(name))
+			if !events.IsValidJobEventType(eventType) {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error":   "Invalid event type",
+					"details": string(eventType),
+				})
+				return
+			}
+			types = append(types, eventType)
+		}
+	}
+
+	var jobID *uuid.UUID
+	if param := c.Query("job_id"); param != "" {
+		id, err := uuid.Parse(param)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid job ID format",
+			})
+			return
+		}
+		jobID = &id
+	}
+
+	// The bus must not block, so events for a client that falls too far behind are dropped
+	stream := make(chan events.JobEvent, eventStreamBuffer)
+	unsubscribe := h.jobEvents.Subscribe(func(event events.JobEvent) {
+		if jobID != nil && event.JobID != *jobID {
+			return
+		}
+		select {
+		case stream <- event:
+		default:
+			logrus.WithFields(logrus.Fields{
+				"job_id": event.JobID,
+				"type":   event.Type,
+			}).Warn("Event stream client too slow - event dropped")
+		}
+	}, types...)
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(eventStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case event := <-stream:
+			c.SSEvent(string(event.Type), event)
+		case <-heartbeat.C:
+			c.SSEvent("ping", gin.H{"at": time.Now().UTC()})
+		}
+		return true
+	})
+}
+
+// RegisterRoutes registers all event routes
+func (h *EventHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/events", h.StreamEvents)
+}
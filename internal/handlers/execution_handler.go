@@ -0,0 +1,300 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// TotalCountHeader reports how many items a HEAD request on a list would return
+const TotalCountHeader = "X-Total-Count"
+
+// ExecutionHandler handles HTTP requests for individual runs
+type ExecutionHandler struct {
+	executionService services.ExecutionService
+}
+
+// NewExecutionHandler creates a new execution handler
+func NewExecutionHandler(executionService services.ExecutionService) *ExecutionHandler {
+	return &ExecutionHandler{
+		executionService: executionService,
+	}
+}
+
+// GetJobExecutions handles GET /api/v1/jobs/{id}/executions?exact=false
+// With exact=false the total is estimated for jobs with many runs, which is much faster than counting
+func (h *ExecutionHandler) GetJobExecutions(c *gin.Context) {
+	// Parse job ID from URL parameter
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	exact := c.Query("exact") != "false"
+
+	list, err := h.executionService.GetJobExecutions(c.Request.Context(), jobID, page, limit, exact)
+	if err != nil {
+		if requestTimedOut(c, err) {
+			return
+		}
+		logrus.WithError(err).Error("Failed to get job executions")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Failed to retrieve job executions",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.FromExecutionList(list))
+}
+
+// CountJobExecutions handles HEAD /api/v1/jobs/{id}/executions
+// It counts the job's runs exactly, reporting the count in the X-Total-Count header
+func (h *ExecutionHandler) CountJobExecutions(c *gin.Context) {
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	count, err := h.executionService.CountJobExecutions(c.Request.Context(), jobID)
+	if err != nil {
+		if requestTimedOut(c, err) {
+			return
+		}
+		logrus.WithError(err).Error("Failed to count job executions")
+		c.Status(http.StatusNotFound)
+		return
+	}
+
+	c.Header(TotalCountHeader, strconv.FormatInt(count, 10))
+	c.Status(http.StatusOK)
+}
+
+// GetRecentExecutions handles GET /api/v1/executions/recent
+func (h *ExecutionHandler) GetRecentExecutions(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	executions, err := h.executionService.GetRecentExecutions(c.Request.Context(), limit)
+	if err != nil {
+		if requestTimedOut(c, err) {
+			return
+		}
+		logrus.WithError(err).Error("Failed to get recent executions")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve recent executions",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"executions": dto.FromExecutions(executions),
+	})
+}
+
+// GetExecution handles GET /api/v1/executions/{id}
+func (h *ExecutionHandler) GetExecution(c *gin.Context) {
+	executionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid execution ID format",
+		})
+		return
+	}
+
+	execution, err := h.executionService.GetExecution(executionID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get execution")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Execution not found",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"execution": dto.FromExecution(execution),
+	})
+}
+
+// GetExecutionOutput handles GET /api/v1/executions/{id}/output
+// Output is recorded once the run finishes, so a run still in progress has none yet
+func (h *ExecutionHandler) GetExecutionOutput(c *gin.Context) {
+	executionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid execution ID format",
+		})
+		return
+	}
+
+	execution, err := h.executionService.GetExecution(executionID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get execution output")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Execution not found",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	output := execution.Output
+	if output == nil {
+		output = models.ExecutionOutput{}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"execution_id": execution.ID,
+		"status":       execution.Status,
+		"output":       output,
+	})
+}
+
+// CancelExecution handles POST /api/v1/executions/{id}/cancel
+// With ?force=true the run is killed without waiting for its executor to stop
+func (h *ExecutionHandler) CancelExecution(c *gin.Context) {
+	executionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid execution ID format",
+		})
+		return
+	}
+
+	force := c.Query("force") == "true"
+	execution, err := h.executionService.CancelExecution(executionID, force)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to cancel execution")
+
+		status := http.StatusNotFound
+		if errors.Is(err, services.ErrExecutionFinished) || errors.Is(err, services.ErrExecutionNotRunning) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to cancel execution",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":   "Execution cancellation requested",
+		"execution": dto.FromExecution(execution),
+	})
+}
+
+// ExtendExecution handles POST /api/v1/executions/{id}/extend?by=10m
+func (h *ExecutionHandler) ExtendExecution(c *gin.Context) {
+	executionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid execution ID format",
+		})
+		return
+	}
+
+	by, err := time.ParseDuration(c.Query("by"))
+	if err != nil || by <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid extension",
+			"details": "by must be a positive duration, e.g. 10m",
+		})
+		return
+	}
+
+	execution, deadline, err := h.executionService.ExtendExecution(executionID, by)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to extend execution")
+
+		status := http.StatusNotFound
+		if errors.Is(err, services.ErrExecutionFinished) || errors.Is(err, services.ErrExecutionNotRunning) ||
+			errors.Is(err, services.ErrExtensionLimit) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to extend execution",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Execution deadline extended",
+		"deadline":  deadline,
+		"execution": dto.FromExecution(execution),
+	})
+}
+
+// SetExecutionDeadline handles PUT /api/v1/executions/{id}/deadline
+// The deadline may be earlier or later than the current one, up to the run's maximum execution time
+func (h *ExecutionHandler) SetExecutionDeadline(c *gin.Context) {
+	executionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid execution ID format",
+		})
+		return
+	}
+
+	var req models.SetExecutionDeadlineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	execution, deadline, err := h.executionService.SetExecutionDeadline(executionID, req.Deadline)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to change execution deadline")
+
+		status := http.StatusNotFound
+		if errors.Is(err, services.ErrInvalidDeadline) {
+			status = http.StatusBadRequest
+		} else if errors.Is(err, services.ErrExecutionFinished) || errors.Is(err, services.ErrExecutionNotRunning) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to change execution deadline",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Execution deadline changed",
+		"deadline":  deadline,
+		"execution": dto.FromExecution(execution),
+	})
+}
+
+// RegisterRoutes registers all execution routes
+func (h *ExecutionHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/jobs/:id/executions", h.GetJobExecutions)
+	router.HEAD("/jobs/:id/executions", h.CountJobExecutions)
+
+	executions := router.Group("/executions")
+	{
+		executions.GET("/recent", h.GetRecentExecutions)
+		executions.GET("/:id", h.GetExecution)
+		executions.GET("/:id/output", h.GetExecutionOutput)
+		executions.POST("/:id/cancel", h.CancelExecution)
+		executions.POST("/:id/extend", h.ExtendExecution)
+		executions.PUT("/:id/deadline", h.SetExecutionDeadline)
+	}
+}
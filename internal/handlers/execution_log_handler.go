@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/services"
+)
+
+// Page sizes for a run's log lines
+const (
+	defaultExecutionLogLimit = 100
+	maxExecutionLogLimit     = 1000
+)
+
+// ExecutionLogHandler handles HTTP requests for the lines runs logged
+type ExecutionLogHandler struct {
+	logService   services.ExecutionLogService
+	pollInterval time.Duration
+}
+
+// NewExecutionLogHandler creates a new execution log handler
+// Followers are sent new lines every pollInterval, which should match how often the lines are written
+func NewExecutionLogHandler(logService services.ExecutionLogService, pollInterval time.Duration) *ExecutionLogHandler {
+	return &ExecutionLogHandler{
+		logService:   logService,
+		pollInterval: pollInterval,
+	}
+}
+
+// GetExecutionLogs handles GET /api/v1/executions/{id}/logs
+// Lines are paged with ?after=<sequence>&limit=; with ?follow=true they are streamed as
+// server-sent events until the run finishes
+func (h *ExecutionLogHandler) GetExecutionLogs(c *gin.Context) {
+	executionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid execution ID format",
+		})
+		return
+	}
+
+	after, _ := strconv.Atoi(c.DefaultQuery("after", "0"))
+	if after < 0 {
+		after = 0
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultExecutionLogLimit)))
+	if limit < 1 || limit > maxExecutionLogLimit {
+		limit = defaultExecutionLogLimit
+	}
+
+	page, err := h.logService.GetExecutionLogs(executionID, after, limit)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get execution logs")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Execution not found",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if c.Query("follow") != "true" {
+		c.JSON(http.StatusOK, gin.H{
+			"execution_id": executionID,
+			"lines":        dto.FromExecutionLogLines(page.Lines),
+			"next_after":   page.NextAfter,
+			"finished":     page.Finished,
+		})
+		return
+	}
+
+	h.followExecutionLogs(c, executionID, page.NextAfter, limit, dto.FromExecutionLogLines(page.Lines), page.Finished && len(page.Lines) < limit)
+}
+
+// followExecutionLogs streams a run's lines as "log" events, polling for new ones until the run
+// has finished and every line has been sent, then sends an "end" event
+func (h *ExecutionLogHandler) followExecutionLogs(c *gin.Context, executionID uuid.UUID, after, limit int, pending []dto.ExecutionLogLineResponse, finished bool) {
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		for _, line := range pending {
+			c.SSEvent("log", line)
+		}
+		if finished {
+			c.SSEvent("end", gin.H{
+				"execution_id": executionID,
+				"next_after":   after,
+			})
+			return false
+		}
+
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-ticker.C:
+		}
+
+		page, err := h.logService.GetExecutionLogs(executionID, after, limit)
+		if err != nil {
+			logrus.WithError(err).Error("Failed to get execution logs")
+			c.SSEvent("error", gin.H{
+				"error":   "Failed to retrieve execution logs",
+				"details": err.Error(),
+			})
+			return false
+		}
+		pending = dto.FromExecutionLogLines(page.Lines)
+		after = page.NextAfter
+		// A full page may have more lines behind it, so keep going until a short page of a finished run
+		finished = page.Finished && len(page.Lines) < limit
+		return true
+	})
+}
+
+// RegisterRoutes registers all execution log routes
+func (h *ExecutionLogHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/executions/:id/logs", h.GetExecutionLogs)
+}
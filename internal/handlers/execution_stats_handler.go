@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/services"
+)
+
+// defaultStatsWindow is how far back per-type stats look unless a window is given
+const defaultStatsWindow = 24 * time.Hour
+
+// maxStatsWindow is the longest window per-type stats may look back over, and the longest range
+// throughput may be counted over
+const maxStatsWindow = 90 * 24 * time.Hour
+
+// Throughput buckets
+const (
+	// defaultThroughputInterval is how long each bucket is unless an interval is given
+	defaultThroughputInterval = time.Hour
+	// minThroughputInterval is the shortest interval throughput may be counted in
+	minThroughputInterval = time.Minute
+	// maxThroughputBuckets caps the buckets one request may return
+	maxThroughputBuckets = 1000
+)
+
+// ExecutionStatsHandler handles HTTP requests for run statistics and resource usage
+type ExecutionStatsHandler struct {
+	statsService services.ExecutionStatsService
+}
+
+// NewExecutionStatsHandler creates a new execution stats handler
+func NewExecutionStatsHandler(statsService services.ExecutionStatsService) *ExecutionStatsHandler {
+	return &ExecutionStatsHandler{
+		statsService: statsService,
+	}
+}
+
+// GetJobStats handles GET /api/v1/jobs/{id}/stats
+func (h *ExecutionStatsHandler) GetJobStats(c *gin.Context) {
+	// Parse job ID from URL parameter
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+
+	stats, err := h.statsService.GetJobStats(jobID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get job stats")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Failed to retrieve job stats",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"job_id": jobID,
+		"stats":  stats,
+	})
+}
+
+// GetOverallStats handles GET /api/v1/stats
+func (h *ExecutionStatsHandler) GetOverallStats(c *gin.Context) {
+	stats, err := h.statsService.GetOverallStats()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get overall stats")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve stats",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"stats": stats,
+	})
+}
+
+// GetStatsByJobType handles GET /api/v1/stats/by-type?window=7d
+func (h *ExecutionStatsHandler) GetStatsByJobType(c *gin.Context) {
+	window, err := parseStatsWindow(c.Query("window"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid window",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	stats, err := h.statsService.GetStatsByJobType(window)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get stats by job type")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve stats",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"window": window.String(),
+		"stats":  stats,
+	})
+}
+
+// GetThroughput handles GET /api/v1/stats/throughput?interval=1h&from=...&to=...
+// It counts the runs started, completed and failed in each interval, by default over the last 24 hours
+func (h *ExecutionStatsHandler) GetThroughput(c *gin.Context) {
+	from, to, interval, err := parseThroughputRange(c.Query("from"), c.Query("to"), c.Query("interval"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid throughput range",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	buckets, err := h.statsService.GetThroughput(from, to, interval)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get execution throughput")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve throughput",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"interval": interval.String(),
+		"from":     from,
+		"to":       to,
+		"buckets":  buckets,
+	})
+}
+
+// parseStatsWindow reads a stats window: a duration such as 12h, or a number of days such as 7d
+func parseStatsWindow(value string) (time.Duration, error) {
+	if value == "" {
+		return defaultStatsWindow, nil
+	}
+
+	window, err := parseStatsDuration("window", value)
+	if err != nil {
+		return 0, err
+	}
+	if window <= 0 || window > maxStatsWindow {
+		return 0, fmt.Errorf("window must be positive and at most %d days", int(maxStatsWindow/(24*time.Hour)))
+	}
+	return window, nil
+}
+
+// parseThroughputRange reads the range throughput is counted over and the interval of its buckets
+// from and to are RFC 3339 times, to defaulting to now and from to a day before to. from is rounded
+// down to a whole interval, so hourly and daily buckets start on the hour and at midnight UTC
+func parseThroughputRange(fromValue, toValue, intervalValue string) (from, to time.Time, interval time.Duration, err error) {
+	interval = defaultThroughputInterval
+	if intervalValue != "" {
+		if interval, err = parseStatsDuration("interval", intervalValue); err != nil {
+			return from, to, 0, err
+		}
+	}
+	if interval < minThroughputInterval {
+		return from, to, 0, fmt.Errorf("interval must be at least %s", minThroughputInterval)
+	}
+
+	to = time.Now().UTC()
+	if toValue != "" {
+		if to, err = time.Parse(time.RFC3339, toValue); err != nil {
+			return from, to, 0, fmt.Errorf("to must be an RFC 3339 time such as 2024-01-01T00:00:00Z")
+		}
+	}
+	from = to.Add(-24 * time.Hour)
+	if fromValue != "" {
+		if from, err = time.Parse(time.RFC3339, fromValue); err != nil {
+			return from, to, 0, fmt.Errorf("from must be an RFC 3339 time such as 2024-01-01T00:00:00Z")
+		}
+	}
+	from = from.UTC().Truncate(interval)
+	to = to.UTC()
+
+	if !from.Before(to) {
+		return from, to, 0, fmt.Errorf("from must be before to")
+	}
+	if to.Sub(from) > maxStatsWindow {
+		return from, to, 0, fmt.Errorf("range must be at most %d days", int(maxStatsWindow/(24*time.Hour)))
+	}
+	if buckets := (to.Sub(from) + interval - 1) / interval; buckets > maxThroughputBuckets {
+		return from, to, 0, fmt.Errorf("range must span at most %d intervals, not %d", maxThroughputBuckets, buckets)
+	}
+	return from, to, interval, nil
+}
+
+// parseStatsDuration reads a duration such as 12h, or a number of days such as 7d
+func parseStatsDuration(name, value string) (time.Duration, error) {
+	if strings.HasSuffix(value, "d") {
+		n, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("%s must be a duration such as 12h or a number of days such as 7d", name)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a duration such as 12h or a number of days such as 7d", name)
+	}
+	return parsed, nil
+}
+
+// GetJobResourceUsage handles GET /api/v1/jobs/{id}/stats/usage
+func (h *ExecutionStatsHandler) GetJobResourceUsage(c *gin.Context) {
+	// Parse job ID from URL parameter
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+	points, err := h.statsService.GetResourceUsage(jobID, limit)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get job resource usage")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Failed to retrieve resource usage",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"job_id": jobID,
+		"points": points,
+	})
+}
+
+// RegisterRoutes registers all execution stats routes
+func (h *ExecutionStatsHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/stats", h.GetOverallStats)
+	router.GET("/stats/by-type", h.GetStatsByJobType)
+	router.GET("/stats/throughput", h.GetThroughput)
+	router.GET("/jobs/:id/stats", h.GetJobStats)
+	router.GET("/jobs/:id/stats/usage", h.GetJobResourceUsage)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -25,6 +26,9 @@ func NewHealthHandler(db *database.Connection, scheduler *scheduler.Scheduler) *
 	}
 }
 
+// integrationCheckTimeout bounds how long the health check waits for integrations to answer
+const integrationCheckTimeout = 5 * time.Second
+
 // HealthResponse represents the health check response
 type HealthResponse struct {
 	Status    string                 `json:"status"`
@@ -50,6 +54,18 @@ func (h *HealthHandler) HealthCheck(c *gin.Context) {
 	schedulerStatus := h.checkSchedulerHealth()
 	response.Services["scheduler"] = schedulerStatus
 
+	// Report errors the API recovered from
+	response.Services["api"] = map[string]interface{}{
+		"recovered_panics": RecoveredPanics(),
+	}
+
+	// Check shared integrations; they reconnect on next use, so they don't fail the health check
+	ctx, cancel := context.WithTimeout(c.Request.Context(), integrationCheckTimeout)
+	defer cancel()
+	if integrations := h.scheduler.CheckIntegrations(ctx); integrations != nil {
+		response.Services["integrations"] = integrations
+	}
+
 	// Determine overall status
 	if dbStatus["status"] != "healthy" || schedulerStatus["status"] != "healthy" {
 		response.Status = "unhealthy"
@@ -71,6 +87,9 @@ func (h *HealthHandler) checkDatabaseHealth() map[string]interface{} {
 	duration := time.Since(start)
 
 	status["response_time_ms"] = duration.Milliseconds()
+	if h.db.QueryMetrics != nil {
+		status["queries"] = h.db.QueryMetrics.Summary()
+	}
 
 	if err != nil {
 		status["status"] = "unhealthy"
@@ -84,9 +103,25 @@ func (h *HealthHandler) checkDatabaseHealth() map[string]interface{} {
 // checkSchedulerHealth checks the scheduler health
 func (h *HealthHandler) checkSchedulerHealth() map[string]interface{} {
 	status := map[string]interface{}{
-		"status":         "healthy",
-		"is_running":     h.scheduler.IsRunning(),
-		"scheduled_jobs": h.scheduler.GetScheduledJobsCount(),
+		"status":          "healthy",
+		"is_running":      h.scheduler.IsRunning(),
+		"scheduled_jobs":  h.scheduler.GetScheduledJobsCount(),
+		"overloaded":      h.scheduler.IsOverloaded(),
+		"queued_runs":     h.scheduler.GetQueuedRuns(),
+		"http_clients":    h.scheduler.GetHTTPClientStats(),
+		"history_cleanup": h.scheduler.GetHistoryCleanupStats(),
+	}
+	if maintenance := h.scheduler.GetTableMaintenanceStats(); maintenance != nil {
+		status["table_maintenance"] = maintenance
+	}
+	if groups := h.scheduler.GetConcurrencyGroups(); len(groups) > 0 {
+		status["concurrency_groups"] = groups
+	}
+	if rateLimits := h.scheduler.GetRateLimits(); len(rateLimits) > 0 {
+		status["rate_limits"] = rateLimits
+	}
+	if connections := h.scheduler.GetConnectionHealth(); connections != nil {
+		status["connections"] = connections
 	}
 
 	if !h.scheduler.IsRunning() {
@@ -1,26 +1,33 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
+	"job-scheduler/internal/dto"
 	"job-scheduler/internal/models"
 	"job-scheduler/internal/services"
 )
 
 // JobHandler handles HTTP requests for job operations
 type JobHandler struct {
-	jobService services.JobService
+	jobService    services.JobService
+	changeControl services.ChangeControlService
 }
 
 // NewJobHandler creates a new job handler
-func NewJobHandler(jobService services.JobService) *JobHandler {
+func NewJobHandler(jobService services.JobService, changeControl services.ChangeControlService) *JobHandler {
 	return &JobHandler{
-		jobService: jobService,
+		jobService:    jobService,
+		changeControl: changeControl,
 	}
 }
 
@@ -46,7 +53,7 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 		return
 	}
 
-	if req.Schedule == "" {
+	if req.Schedule == "" && req.ScheduleType != models.ScheduleTypeOnce {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Job schedule is required",
 		})
@@ -60,9 +67,22 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 		return
 	}
 
+	// Jobs are owned by their creator unless given to someone else
+	if req.Owner == "" {
+		req.Owner = actorFromRequest(c)
+	}
+
 	// Create job
+	req.ChangedBy = actorFromRequest(c)
 	job, err := h.jobService.CreateJob(&req)
 	if err != nil {
+		if errors.Is(err, services.ErrJobNameTaken) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "Job name already taken",
+				"details": err.Error(),
+			})
+			return
+		}
 		logrus.WithError(err).Error("Failed to create job")
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error":   "Failed to create job",
@@ -78,10 +98,147 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Job created successfully",
-		"job":     job,
+		"job":     dto.FromJob(job),
 	})
 }
 
+// UpsertJobByName handles PUT /api/v1/jobs/by-name/{name}
+// It creates the job if its team has no job with the name (201), or makes that job match the
+// request (200), so declarative tooling can apply jobs without looking them up first
+func (h *JobHandler) UpsertJobByName(c *gin.Context) {
+	name := c.Param("name")
+
+	var req models.CreateJobRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind upsert job request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// The name comes from the URL; a name in the body must agree with it
+	if req.Name != "" && req.Name != name {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Job name in the body doesn't match the URL",
+		})
+		return
+	}
+	req.Name = name
+
+	if req.Schedule == "" && req.ScheduleType != models.ScheduleTypeOnce {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Job schedule is required",
+		})
+		return
+	}
+
+	if req.JobType == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Job type is required",
+		})
+		return
+	}
+
+	// Destructive changes to an existing protected job wait for a second approver
+	existing, err := h.jobService.GetJobByName(req.Team, name)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get job by name")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to upsert job",
+			"details": err.Error(),
+		})
+		return
+	}
+	if existing != nil {
+		update := h.jobService.ReplacementRequest(existing, &req)
+		if !h.authorizeJobChange(c, existing.ID, update, "Failed to upsert job") {
+			return
+		}
+		if change, err := h.changeControl.ProposeUpdate(existing.ID, update, actorFromRequest(c)); err != nil || change != nil {
+			h.respondChangeControl(c, change, err)
+			return
+		}
+	} else if req.Owner == "" {
+		req.Owner = actorFromRequest(c)
+	}
+
+	req.ChangedBy = actorFromRequest(c)
+	job, created, err := h.jobService.UpsertJobByName(&req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to upsert job")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to upsert job",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":   job.ID,
+		"job_name": job.Name,
+		"created":  created,
+	}).Info("Job upserted via API")
+
+	if created {
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Job created successfully",
+			"job":     dto.FromJob(job),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Job updated successfully",
+		"job":     dto.FromJob(job),
+	})
+}
+
+// GetJobByName handles GET /api/v1/jobs/by-name/{name}?team=...
+func (h *JobHandler) GetJobByName(c *gin.Context) {
+	job, ok := h.jobByName(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"job": dto.FromJob(job),
+	})
+}
+
+// DeleteJobByName handles DELETE /api/v1/jobs/by-name/{name}?team=...
+func (h *JobHandler) DeleteJobByName(c *gin.Context) {
+	job, ok := h.jobByName(c)
+	if !ok {
+		return
+	}
+
+	h.deleteJob(c, job.ID)
+}
+
+// jobByName gets the job named in the URL, of the team given by the team query parameter, responding
+// with 404 if there is none
+func (h *JobHandler) jobByName(c *gin.Context) (*models.Job, bool) {
+	job, err := h.jobService.GetJobByName(c.Query("team"), c.Param("name"))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get job by name")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve job",
+			"details": err.Error(),
+		})
+		return nil, false
+	}
+	if job == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Job not found",
+		})
+		return nil, false
+	}
+	return job, true
+}
+
 // GetJob handles GET /api/v1/jobs/{id}
 func (h *JobHandler) GetJob(c *gin.Context) {
 	// Parse job ID from URL parameter
@@ -106,7 +263,7 @@ func (h *JobHandler) GetJob(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"job": job,
+		"job": dto.FromJob(job),
 	})
 }
 
@@ -128,9 +285,35 @@ func (h *JobHandler) GetJobs(c *gin.Context) {
 		}
 	}
 
-	// Get jobs
-	response, err := h.jobService.GetAllJobs(page, limit)
+	filter, err := jobFilterFromQuery(c)
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid job filter",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Get jobs matching the filter, optionally sorted by name, next run or health score
+	response, err := h.jobService.GetAllJobs(c.Request.Context(), page, limit, models.JobSort(c.Query("sort")), filter)
+	if err != nil {
+		if requestTimedOut(c, err) {
+			return
+		}
+		if errors.Is(err, services.ErrInvalidJobSort) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid sort order",
+				"details": err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, services.ErrInvalidJobFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid job filter",
+				"details": err.Error(),
+			})
+			return
+		}
 		logrus.WithError(err).Error("Failed to get jobs")
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to retrieve jobs",
@@ -139,7 +322,55 @@ func (h *JobHandler) GetJobs(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, dto.FromJobList(response))
+}
+
+// GetErroredJobs handles GET /api/v1/jobs/errored
+// It lists the active jobs whose stored schedules the scheduler can't use, with why
+func (h *JobHandler) GetErroredJobs(c *gin.Context) {
+	jobs, err := h.jobService.GetErroredJobs(c.Request.Context())
+	if err != nil {
+		if requestTimedOut(c, err) {
+			return
+		}
+		logrus.WithError(err).Error("Failed to get errored jobs")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve errored jobs",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"jobs":        dto.FromJobs(jobs),
+		"total_count": len(jobs),
+	})
+}
+
+// PreviewJob handles POST /api/v1/jobs/preview
+// It lists when a schedule would next fire in a time zone and what is wrong with a job's schedule and
+// config, saving nothing. Problems are in the response rather than failing the request
+func (h *JobHandler) PreviewJob(c *gin.Context) {
+	var req models.PreviewJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	preview, err := h.jobService.PreviewJob(&req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to preview job")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to preview job",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
 }
 
 // UpdateJob handles PUT /api/v1/jobs/{id}
@@ -166,9 +397,34 @@ func (h *JobHandler) UpdateJob(c *gin.Context) {
 		return
 	}
 
+	if !h.authorizeJobChange(c, jobID, &req, "Failed to update job") {
+		return
+	}
+
+	// Destructive changes to protected jobs wait for a second approver
+	if change, err := h.changeControl.ProposeUpdate(jobID, &req, actorFromRequest(c)); err != nil || change != nil {
+		h.respondChangeControl(c, change, err)
+		return
+	}
+
 	// Update job
+	req.ChangedBy = actorFromRequest(c)
 	job, err := h.jobService.UpdateJob(jobID, &req)
 	if err != nil {
+		if errors.Is(err, services.ErrJobNameTaken) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "Job name already taken",
+				"details": err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, services.ErrInvalidStateTransition) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "Invalid job state transition",
+				"details": err.Error(),
+			})
+			return
+		}
 		logrus.WithError(err).Error("Failed to update job")
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error":   "Failed to update job",
@@ -184,7 +440,7 @@ func (h *JobHandler) UpdateJob(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Job updated successfully",
-		"job":     job,
+		"job":     dto.FromJob(job),
 	})
 }
 
@@ -200,6 +456,21 @@ func (h *JobHandler) DeleteJob(c *gin.Context) {
 		return
 	}
 
+	h.deleteJob(c, jobID)
+}
+
+// deleteJob deletes a job, or queues its deletion if it is protected
+func (h *JobHandler) deleteJob(c *gin.Context, jobID uuid.UUID) {
+	if !h.authorizeJobChange(c, jobID, nil, "Failed to delete job") {
+		return
+	}
+
+	// Deleting a protected job waits for a second approver
+	if change, err := h.changeControl.ProposeDelete(jobID, actorFromRequest(c)); err != nil || change != nil {
+		h.respondChangeControl(c, change, err)
+		return
+	}
+
 	// Delete job
 	if err := h.jobService.DeleteJob(jobID); err != nil {
 		logrus.WithError(err).Error("Failed to delete job")
@@ -217,14 +488,196 @@ func (h *JobHandler) DeleteJob(c *gin.Context) {
 	})
 }
 
+// PauseJob handles POST /api/v1/jobs/{id}/pause
+func (h *JobHandler) PauseJob(c *gin.Context) {
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+
+	var req models.PauseJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if !h.authorizeJobChange(c, jobID, nil, "Failed to pause job") {
+		return
+	}
+
+	job, err := h.jobService.PauseJob(jobID, actorFromRequest(c), req.Reason)
+	if err != nil {
+		h.respondPauseError(c, "Failed to pause job", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Job paused",
+		"job":     dto.FromJob(job),
+	})
+}
+
+// ResumeJob handles POST /api/v1/jobs/{id}/resume
+func (h *JobHandler) ResumeJob(c *gin.Context) {
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+
+	if !h.authorizeJobChange(c, jobID, nil, "Failed to resume job") {
+		return
+	}
+
+	// Resuming a protected job enables it, so it waits for a second approver
+	active := true
+	actor := actorFromRequest(c)
+	if change, err := h.changeControl.ProposeUpdate(jobID, &models.UpdateJobRequest{IsActive: &active}, actor); err != nil || change != nil {
+		h.respondChangeControl(c, change, err)
+		return
+	}
+
+	job, err := h.jobService.ResumeJob(jobID, actor)
+	if err != nil {
+		h.respondPauseError(c, "Failed to resume job", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Job resumed",
+		"job":     dto.FromJob(job),
+	})
+}
+
+// authorizeJobChange checks that the requesting user may change a job, responding if not
+// Operators may only change the jobs they own
+func (h *JobHandler) authorizeJobChange(c *gin.Context, jobID uuid.UUID, req *models.UpdateJobRequest, message string) bool {
+	err := authorizeJobChange(c, h.jobService, jobID, req)
+	if err == nil {
+		return true
+	}
+	status := http.StatusNotFound
+	if isAccessDenied(err) {
+		status = http.StatusForbidden
+	}
+	c.JSON(status, gin.H{
+		"error":   message,
+		"details": err.Error(),
+	})
+	return false
+}
+
+// jobFilterFromQuery reads the filter of a job listing from its query string
+// state takes a comma-separated list of job states, and created_after and created_before take RFC 3339
+// times or dates such as 2024-01-01
+func jobFilterFromQuery(c *gin.Context) (models.JobFilter, error) {
+	filter := models.JobFilter{
+		Name:    strings.TrimSpace(c.Query("name")),
+		JobType: models.JobType(c.Query("job_type")),
+		Tag:     c.Query("tag"),
+	}
+	for _, state := range strings.Split(c.Query("state"), ",") {
+		if state = strings.TrimSpace(state); state != "" {
+			filter.States = append(filter.States, models.JobState(state))
+		}
+	}
+	if value := c.Query("is_active"); value != "" {
+		isActive, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, fmt.Errorf("is_active must be true or false")
+		}
+		filter.IsActive = &isActive
+	}
+
+	var err error
+	if filter.CreatedAfter, err = parseCreatedTime("created_after", c.Query("created_after")); err != nil {
+		return filter, err
+	}
+	if filter.CreatedBefore, err = parseCreatedTime("created_before", c.Query("created_before")); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// parseCreatedTime reads a bound of a created range, an RFC 3339 time or a date at midnight UTC
+func parseCreatedTime(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if t, err = time.Parse("2006-01-02", value); err != nil {
+			return nil, fmt.Errorf("%s must be an RFC 3339 time such as 2024-01-01T00:00:00Z or a date such as 2024-01-01", name)
+		}
+	}
+	t = t.UTC()
+	return &t, nil
+}
+
+// respondPauseError responds to a failed pause or resume
+func (h *JobHandler) respondPauseError(c *gin.Context, message string, err error) {
+	logrus.WithError(err).Error(message)
+
+	status := http.StatusNotFound
+	switch {
+	case errors.Is(err, services.ErrPauseActorRequired):
+		status = http.StatusForbidden
+	case errors.Is(err, services.ErrPauseReasonRequired):
+		status = http.StatusBadRequest
+	case errors.Is(err, services.ErrJobAlreadyPaused), errors.Is(err, services.ErrJobNotPaused),
+		errors.Is(err, services.ErrRunAtPassed), errors.Is(err, services.ErrInvalidStateTransition):
+		status = http.StatusConflict
+	}
+	c.JSON(status, gin.H{
+		"error":   message,
+		"details": err.Error(),
+	})
+}
+
+// respondChangeControl responds to a change that was queued for approval or refused
+func (h *JobHandler) respondChangeControl(c *gin.Context, change *models.PendingChange, err error) {
+	if err != nil {
+		logrus.WithError(err).Error("Failed to queue protected job change")
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrActorRequired) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to change protected job",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":        "Job is protected - change queued for a second approver",
+		"pending_change": dto.FromPendingChange(change),
+	})
+}
+
 // RegisterRoutes registers all job-related routes
 func (h *JobHandler) RegisterRoutes(router *gin.RouterGroup) {
 	jobs := router.Group("/jobs")
 	{
 		jobs.POST("", h.CreateJob)
 		jobs.GET("", h.GetJobs)
+		jobs.GET("/errored", h.GetErroredJobs)
+		jobs.POST("/preview", h.PreviewJob)
 		jobs.GET("/:id", h.GetJob)
 		jobs.PUT("/:id", h.UpdateJob)
+		jobs.GET("/by-name/:name", h.GetJobByName)
+		jobs.PUT("/by-name/:name", h.UpsertJobByName)
+		jobs.DELETE("/by-name/:name", h.DeleteJobByName)
 		jobs.DELETE("/:id", h.DeleteJob)
+		jobs.POST("/:id/pause", h.PauseJob)
+		jobs.POST("/:id/resume", h.ResumeJob)
 	}
 }
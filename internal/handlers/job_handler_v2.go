@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// JobHandlerV2 handles /api/v2 job requests
+// It is served by the same services as v1 but returns DTOs directly, uses cursor
+// pagination and reports errors in the error envelope
+type JobHandlerV2 struct {
+	jobService    services.JobService
+	changeControl services.ChangeControlService
+}
+
+// NewJobHandlerV2 creates a new v2 job handler
+func NewJobHandlerV2(jobService services.JobService, changeControl services.ChangeControlService) *JobHandlerV2 {
+	return &JobHandlerV2{
+		jobService:    jobService,
+		changeControl: changeControl,
+	}
+}
+
+// ListJobs handles GET /api/v2/jobs?cursor=...&limit=...&state=...
+// It takes the filters of GET /api/v1/jobs
+func (h *JobHandlerV2) ListJobs(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	filter, err := jobFilterFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(dto.ErrorCodeInvalidRequest, "Invalid job filter", err))
+		return
+	}
+
+	page, err := h.jobService.ListJobs(c.Request.Context(), c.Query("cursor"), limit, filter)
+	if err != nil {
+		if requestTimedOut(c, err) {
+			return
+		}
+		if errors.Is(err, services.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse(dto.ErrorCodeInvalidRequest, "Invalid cursor", err))
+			return
+		}
+		if errors.Is(err, services.ErrInvalidJobFilter) {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse(dto.ErrorCodeInvalidRequest, "Invalid job filter", err))
+			return
+		}
+		logrus.WithError(err).Error("Failed to list jobs")
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(dto.ErrorCodeInternal, "Failed to retrieve jobs", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.FromJobPage(page))
+}
+
+// GetJob handles GET /api/v2/jobs/{id}
+func (h *JobHandlerV2) GetJob(c *gin.Context) {
+	jobID, ok := h.parseJobID(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.jobService.GetJobByID(jobID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(dto.ErrorCodeNotFound, "Job not found", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.FromJob(job))
+}
+
+// CreateJob handles POST /api/v2/jobs
+func (h *JobHandlerV2) CreateJob(c *gin.Context) {
+	var req models.CreateJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(dto.ErrorCodeInvalidRequest, "Invalid request body", err))
+		return
+	}
+
+	if req.Name == "" || (req.Schedule == "" && req.ScheduleType != models.ScheduleTypeOnce) || req.JobType == "" {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(dto.ErrorCodeInvalidRequest, "name, schedule (or run_at for one-time jobs) and job_type are required", nil))
+		return
+	}
+
+	if req.Owner == "" {
+		req.Owner = actorFromRequest(c)
+	}
+
+	req.ChangedBy = actorFromRequest(c)
+	job, err := h.jobService.CreateJob(&req)
+	if err != nil {
+		if errors.Is(err, services.ErrJobNameTaken) {
+			c.JSON(http.StatusConflict, dto.NewErrorResponse(dto.ErrorCodeConflict, "Job name already taken", err))
+			return
+		}
+		logrus.WithError(err).Error("Failed to create job")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(dto.ErrorCodeInvalidRequest, "Failed to create job", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.FromJob(job))
+}
+
+// UpdateJob handles PATCH /api/v2/jobs/{id}
+// Changes to protected jobs that need a second approver return 202 with the pending change
+func (h *JobHandlerV2) UpdateJob(c *gin.Context) {
+	jobID, ok := h.parseJobID(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(dto.ErrorCodeInvalidRequest, "Invalid request body", err))
+		return
+	}
+
+	if err := authorizeJobChange(c, h.jobService, jobID, &req); err != nil {
+		if isAccessDenied(err) {
+			c.JSON(http.StatusForbidden, dto.NewErrorResponse(dto.ErrorCodeForbidden, "Failed to update job", err))
+			return
+		}
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(dto.ErrorCodeNotFound, "Job not found", err))
+		return
+	}
+
+	if change, err := h.changeControl.ProposeUpdate(jobID, &req, actorFromRequest(c)); err != nil || change != nil {
+		h.respondChangeControl(c, change, err)
+		return
+	}
+
+	req.ChangedBy = actorFromRequest(c)
+	job, err := h.jobService.UpdateJob(jobID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrJobNameTaken) {
+			c.JSON(http.StatusConflict, dto.NewErrorResponse(dto.ErrorCodeConflict, "Job name already taken", err))
+			return
+		}
+		logrus.WithError(err).Error("Failed to update job")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(dto.ErrorCodeInvalidRequest, "Failed to update job", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.FromJob(job))
+}
+
+// DeleteJob handles DELETE /api/v2/jobs/{id}
+func (h *JobHandlerV2) DeleteJob(c *gin.Context) {
+	jobID, ok := h.parseJobID(c)
+	if !ok {
+		return
+	}
+
+	if err := authorizeJobChange(c, h.jobService, jobID, nil); err != nil {
+		if isAccessDenied(err) {
+			c.JSON(http.StatusForbidden, dto.NewErrorResponse(dto.ErrorCodeForbidden, "Failed to delete job", err))
+			return
+		}
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(dto.ErrorCodeNotFound, "Job not found", err))
+		return
+	}
+
+	if change, err := h.changeControl.ProposeDelete(jobID, actorFromRequest(c)); err != nil || change != nil {
+		h.respondChangeControl(c, change, err)
+		return
+	}
+
+	if err := h.jobService.DeleteJob(jobID); err != nil {
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(dto.ErrorCodeNotFound, "Failed to delete job", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseJobID parses the job ID URL parameter, writing an error response on failure
+func (h *JobHandlerV2) parseJobID(c *gin.Context) (uuid.UUID, bool) {
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(dto.ErrorCodeInvalidRequest, "Invalid job ID format", nil))
+		return uuid.Nil, false
+	}
+	return jobID, true
+}
+
+// respondChangeControl responds to a change that was queued for approval or refused
+func (h *JobHandlerV2) respondChangeControl(c *gin.Context, change *models.PendingChange, err error) {
+	if err != nil {
+		if errors.Is(err, services.ErrActorRequired) {
+			c.JSON(http.StatusForbidden, dto.NewErrorResponse(dto.ErrorCodeForbidden, "Failed to change protected job", err))
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(dto.ErrorCodeInvalidRequest, "Failed to change protected job", err))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.FromPendingChange(change))
+}
+
+// RegisterRoutes registers all v2 job routes
+func (h *JobHandlerV2) RegisterRoutes(router *gin.RouterGroup) {
+	jobs := router.Group("/jobs")
+	{
+		jobs.GET("", h.ListJobs)
+		jobs.GET("/:id", h.GetJob)
+		jobs.POST("", h.CreateJob)
+		jobs.PATCH("/:id", h.UpdateJob)
+		jobs.DELETE("/:id", h.DeleteJob)
+	}
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// maxManifestBytes is the largest job manifest accepted for import
+const maxManifestBytes = 10 << 20
+
+// JobManifestHandler exports and imports jobs as declarative YAML or JSON manifests
+type JobManifestHandler struct {
+	jobService    services.JobService
+	changeControl services.ChangeControlService
+}
+
+// NewJobManifestHandler creates a new job manifest handler
+func NewJobManifestHandler(jobService services.JobService, changeControl services.ChangeControlService) *JobManifestHandler {
+	return &JobManifestHandler{
+		jobService:    jobService,
+		changeControl: changeControl,
+	}
+}
+
+// ExportJobs handles GET /api/v1/jobs/export?format=yaml|json&team=...
+func (h *JobManifestHandler) ExportJobs(c *gin.Context) {
+	format := strings.ToLower(c.DefaultQuery("format", models.JobManifestFormatYAML))
+	if !models.IsValidJobManifestFormat(format) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid format",
+			"details": "format must be yaml or json",
+		})
+		return
+	}
+
+	var team *string
+	if value, ok := c.GetQuery("team"); ok {
+		team = &value
+	}
+
+	manifest, err := h.jobService.ExportJobs(c.Request.Context(), team)
+	if err != nil {
+		if requestTimedOut(c, err) {
+			return
+		}
+		logrus.WithError(err).Error("Failed to export jobs")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to export jobs",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	data, err := models.EncodeJobManifest(manifest, format)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to encode job manifest")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to export jobs",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	contentType := "application/yaml"
+	if format == models.JobManifestFormatJSON {
+		contentType = "application/json"
+	}
+	c.Header("Content-Disposition", `attachment; filename="jobs.`+format+`"`)
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// ImportJobs handles POST /api/v1/jobs/import?dry_run=true&diff=true
+// It creates the manifest's jobs that don't exist and makes those that do match the manifest, matching
+// jobs by team and name. Jobs missing from the manifest are left alone. Nothing is applied if any job
+// in the manifest is invalid; dry_run reports what would be done, and diff lists the settings each
+// update changes
+func (h *JobManifestHandler) ImportJobs(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxManifestBytes+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to read request body",
+			"details": err.Error(),
+		})
+		return
+	}
+	if len(body) > maxManifestBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error": "Job manifest is too large",
+		})
+		return
+	}
+
+	manifest, err := models.DecodeJobManifest(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid job manifest",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	items, err := h.jobService.PlanJobImport(manifest, c.Query("diff") == "true")
+	if err != nil {
+		logrus.WithError(err).Error("Failed to plan job import")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to import jobs",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result := &models.JobImportResult{DryRun: c.Query("dry_run") == "true", Jobs: items}
+	result.Count()
+	if result.Failed > 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":  "Job manifest has invalid jobs - nothing was imported",
+			"import": result,
+		})
+		return
+	}
+	if result.DryRun {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Dry run - nothing was imported",
+			"import":  result,
+		})
+		return
+	}
+
+	actor := actorFromRequest(c)
+	for i := range result.Jobs {
+		h.importJob(&manifest.Jobs[i], &result.Jobs[i], actor)
+	}
+	result.Count()
+
+	logrus.WithFields(logrus.Fields{
+		"created":          result.Created,
+		"updated":          result.Updated,
+		"unchanged":        result.Unchanged,
+		"pending_approval": result.PendingApproval,
+		"failed":           result.Failed,
+	}).Info("Jobs imported via API")
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Jobs imported",
+		"import":  result,
+	})
+}
+
+// importJob applies the planned import of a job, recording the outcome on its item
+// Destructive changes to protected jobs wait for a second approver, as they do when replacing a job by name
+func (h *JobManifestHandler) importJob(def *models.JobDefinition, item *models.JobImportItem, actor string) {
+	req := def.CreateRequest()
+
+	switch item.Action {
+	case models.JobImportCreate:
+		if req.Owner == "" {
+			req.Owner = actor
+		}
+	case models.JobImportUpdate:
+		existing, err := h.jobService.GetJobByName(req.Team, req.Name)
+		if err != nil {
+			importFailed(item, err)
+			return
+		}
+		if existing != nil {
+			update := h.jobService.ReplacementRequest(existing, req)
+			change, err := h.changeControl.ProposeUpdate(existing.ID, update, actor)
+			if err != nil {
+				importFailed(item, err)
+				return
+			}
+			if change != nil {
+				item.Action = models.JobImportPendingApproval
+				return
+			}
+		}
+	default:
+		return
+	}
+
+	req.ChangedBy = actor
+	job, created, err := h.jobService.UpsertJobByName(req)
+	if err != nil {
+		importFailed(item, err)
+		return
+	}
+	item.ID = &job.ID
+	if created {
+		item.Action = models.JobImportCreate
+	} else {
+		item.Action = models.JobImportUpdate
+	}
+}
+
+// importFailed records why a job couldn't be imported
+func importFailed(item *models.JobImportItem, err error) {
+	logrus.WithFields(logrus.Fields{
+		"name":  item.Name,
+		"team":  item.Team,
+		"error": err,
+	}).Error("Failed to import job")
+	item.Action = models.JobImportFailed
+	item.Error = err.Error()
+}
+
+// RegisterRoutes registers the job manifest routes
+func (h *JobManifestHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/jobs/export", h.ExportJobs)
+	router.POST("/jobs/import", h.ImportJobs)
+}
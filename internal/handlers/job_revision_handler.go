@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// JobRevisionHandler handles HTTP requests for the history of changes to jobs and rolling them back
+type JobRevisionHandler struct {
+	revisionService services.JobRevisionService
+	jobService      services.JobService
+	changeControl   services.ChangeControlService
+}
+
+// NewJobRevisionHandler creates a new job revision handler
+func NewJobRevisionHandler(revisionService services.JobRevisionService, jobService services.JobService, changeControl services.ChangeControlService) *JobRevisionHandler {
+	return &JobRevisionHandler{
+		revisionService: revisionService,
+		jobService:      jobService,
+		changeControl:   changeControl,
+	}
+}
+
+// GetJobRevisions handles GET /api/v1/jobs/{id}/revisions
+func (h *JobRevisionHandler) GetJobRevisions(c *gin.Context) {
+	// Parse job ID from URL parameter
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+
+	revisions, err := h.revisionService.GetRevisions(jobID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get job revisions")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve job revisions",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"revisions": dto.FromJobRevisions(revisions),
+	})
+}
+
+// RollbackJob handles POST /api/v1/jobs/{id}/rollback/{revision}
+// The job's name, schedule and config are restored as an update, so protected jobs wait for a second approver
+func (h *JobRevisionHandler) RollbackJob(c *gin.Context) {
+	// Parse job ID and revision from URL parameters
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil || revision < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid revision - must be a positive number",
+		})
+		return
+	}
+
+	actor := actorFromRequest(c)
+	req, err := h.revisionService.RollbackRequest(jobID, revision, actor)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Job revision not found",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Operators may only change the jobs they own
+	if err := authorizeJobChange(c, h.jobService, jobID, req); err != nil {
+		status := http.StatusNotFound
+		if isAccessDenied(err) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to roll back job",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if change, err := h.changeControl.ProposeUpdate(jobID, req, actor); err != nil || change != nil {
+		h.respondChangeControl(c, change, err)
+		return
+	}
+
+	job, err := h.jobService.UpdateJob(jobID, req)
+	if err != nil {
+		if errors.Is(err, services.ErrJobNameTaken) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "Job name already taken",
+				"details": err.Error(),
+			})
+			return
+		}
+		logrus.WithError(err).Error("Failed to roll back job")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to roll back job",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":   job.ID,
+		"revision": revision,
+		"actor":    actor,
+	}).Info("Job rolled back via API")
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Job rolled back successfully",
+		"job":     dto.FromJob(job),
+	})
+}
+
+// respondChangeControl responds to a change that was queued for approval or refused
+func (h *JobRevisionHandler) respondChangeControl(c *gin.Context, change *models.PendingChange, err error) {
+	if err != nil {
+		logrus.WithError(err).Error("Failed to queue protected job change")
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrActorRequired) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to change protected job",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":        "Job is protected - change queued for a second approver",
+		"pending_change": dto.FromPendingChange(change),
+	})
+}
+
+// RegisterRoutes registers all job revision routes
+func (h *JobRevisionHandler) RegisterRoutes(router *gin.RouterGroup) {
+	jobs := router.Group("/jobs")
+	{
+		jobs.GET("/:id/revisions", h.GetJobRevisions)
+		jobs.POST("/:id/rollback/:revision", h.RollbackJob)
+	}
+}
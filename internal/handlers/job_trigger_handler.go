@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// JobTriggerHandler starts runs of jobs on demand
+type JobTriggerHandler struct {
+	jobService services.JobService
+	trigger    services.JobTrigger
+}
+
+// NewJobTriggerHandler creates a new job trigger handler
+func NewJobTriggerHandler(jobService services.JobService, trigger services.JobTrigger) *JobTriggerHandler {
+	return &JobTriggerHandler{
+		jobService: jobService,
+		trigger:    trigger,
+	}
+}
+
+// TriggerJob handles POST /api/v1/jobs/{id}/trigger
+// The run starts in the background, outside the job's schedule
+func (h *JobTriggerHandler) TriggerJob(c *gin.Context) {
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+
+	var req models.TriggerJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Operators may only run the jobs they own
+	if err := authorizeJobChange(c, h.jobService, jobID, nil); err != nil {
+		status := http.StatusNotFound
+		if isAccessDenied(err) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to trigger job",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	job, err := h.jobService.GetJobByID(jobID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Job not found",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.trigger.TriggerJob(job, req.Params); err != nil {
+		logrus.WithError(err).Error("Failed to trigger job")
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":   "Failed to trigger job",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id": job.ID,
+		"actor":  actorFromRequest(c),
+	}).Info("Job triggered via API")
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Job triggered",
+		"job_id":  job.ID,
+	})
+}
+
+// RegisterRoutes registers the job trigger routes
+func (h *JobTriggerHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/jobs/:id/trigger", h.TriggerJob)
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// NotificationTemplateHandler handles HTTP requests for notification templates
+type NotificationTemplateHandler struct {
+	templateService services.NotificationTemplateService
+}
+
+// NewNotificationTemplateHandler creates a new notification template handler
+func NewNotificationTemplateHandler(templateService services.NotificationTemplateService) *NotificationTemplateHandler {
+	return &NotificationTemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// CreateTemplate handles POST /api/v1/templates
+func (h *NotificationTemplateHandler) CreateTemplate(c *gin.Context) {
+	var req models.CreateNotificationTemplateRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind create template request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	template, err := h.templateService.CreateTemplate(&req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create template")
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrTemplateNameTaken) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to create template",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "Template created successfully",
+		"template": dto.FromNotificationTemplate(template),
+	})
+}
+
+// GetTemplates handles GET /api/v1/templates
+func (h *NotificationTemplateHandler) GetTemplates(c *gin.Context) {
+	templates, err := h.templateService.GetTemplates()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get templates")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve templates",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"templates": dto.FromNotificationTemplates(templates),
+	})
+}
+
+// GetTemplate handles GET /api/v1/templates/{id}
+func (h *NotificationTemplateHandler) GetTemplate(c *gin.Context) {
+	// Parse template ID from URL parameter
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid template ID format",
+		})
+		return
+	}
+
+	template, err := h.templateService.GetTemplateByID(templateID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get template")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Template not found",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"template": dto.FromNotificationTemplate(template),
+	})
+}
+
+// UpdateTemplate handles PUT /api/v1/templates/{id}
+func (h *NotificationTemplateHandler) UpdateTemplate(c *gin.Context) {
+	// Parse template ID from URL parameter
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid template ID format",
+		})
+		return
+	}
+
+	var req models.UpdateNotificationTemplateRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind update template request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	template, err := h.templateService.UpdateTemplate(templateID, &req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to update template")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to update template",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Template updated successfully",
+		"template": dto.FromNotificationTemplate(template),
+	})
+}
+
+// DeleteTemplate handles DELETE /api/v1/templates/{id}
+func (h *NotificationTemplateHandler) DeleteTemplate(c *gin.Context) {
+	// Parse template ID from URL parameter
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid template ID format",
+		})
+		return
+	}
+
+	if err := h.templateService.DeleteTemplate(templateID); err != nil {
+		logrus.WithError(err).Error("Failed to delete template")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Failed to delete template",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Template deleted successfully",
+	})
+}
+
+// RegisterRoutes registers all notification template routes
+func (h *NotificationTemplateHandler) RegisterRoutes(router *gin.RouterGroup) {
+	templates := router.Group("/templates")
+	{
+		templates.POST("", h.CreateTemplate)
+		templates.GET("", h.GetTemplates)
+		templates.GET("/:id", h.GetTemplate)
+		templates.PUT("/:id", h.UpdateTemplate)
+		templates.DELETE("/:id", h.DeleteTemplate)
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/services"
+)
+
+// OccurrenceHandler handles HTTP requests for a job's scheduled occurrences
+type OccurrenceHandler struct {
+	occurrenceService services.OccurrenceService
+}
+
+// NewOccurrenceHandler creates a new occurrence handler
+func NewOccurrenceHandler(occurrenceService services.OccurrenceService) *OccurrenceHandler {
+	return &OccurrenceHandler{
+		occurrenceService: occurrenceService,
+	}
+}
+
+// GetMissedOccurrences handles GET /api/v1/jobs/{id}/occurrences
+// Lists the job's scheduled occurrences that didn't run, with the reason each was missed
+func (h *OccurrenceHandler) GetMissedOccurrences(c *gin.Context) {
+	// Parse job ID from URL parameter
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	list, err := h.occurrenceService.GetMissedOccurrences(jobID, page, limit)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get missed occurrences")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Failed to retrieve missed occurrences",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// RegisterRoutes registers all occurrence routes
+func (h *OccurrenceHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/jobs/:id/occurrences", h.GetMissedOccurrences)
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"job-scheduler/pkg/database"
+)
+
+// defaultQueryStatsLimit is how many statements are listed unless the request asks for more
+const defaultQueryStatsLimit = 20
+
+// QueryStatsHandler handles the admin endpoints reporting database query latency
+type QueryStatsHandler struct {
+	db *database.Connection
+}
+
+// NewQueryStatsHandler creates a new query stats handler
+func NewQueryStatsHandler(db *database.Connection) *QueryStatsHandler {
+	return &QueryStatsHandler{
+		db: db,
+	}
+}
+
+// GetQueryStats handles GET /api/v1/admin/queries
+// It lists the statements that took the most time in total, with their latency histograms
+func (h *QueryStatsHandler) GetQueryStats(c *gin.Context) {
+	limit := defaultQueryStatsLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid limit",
+			})
+			return
+		}
+		limit = parsed
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"summary":                 h.db.QueryMetrics.Summary(),
+		"slow_query_threshold_ms": h.db.Config.Database.SlowQueryThreshold.Milliseconds(),
+		"queries":                 h.db.QueryMetrics.Top(limit),
+	})
+}
+
+// ResetQueryStats handles DELETE /api/v1/admin/queries
+// It starts the stats afresh, e.g. to measure the effect of an index
+func (h *QueryStatsHandler) ResetQueryStats(c *gin.Context) {
+	h.db.QueryMetrics.Reset()
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Query stats reset successfully",
+	})
+}
+
+// RegisterRoutes registers all query stats routes
+func (h *QueryStatsHandler) RegisterRoutes(router *gin.RouterGroup) {
+	admin := router.Group("/admin")
+	{
+		admin.GET("/queries", h.GetQueryStats)
+		admin.DELETE("/queries", h.ResetQueryStats)
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+)
+
+// RequestIDHeader carries the ID identifying a request in responses and logs
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDContextKey is the gin context key holding the request's ID
+const requestIDContextKey = "request_id"
+
+// maxRequestIDLength bounds request IDs passed in by clients or proxies
+const maxRequestIDLength = 128
+
+// recoveredPanics counts the handler panics recovered since startup
+var recoveredPanics int64
+
+// RequestID returns middleware giving each request an ID, taken from the X-Request-ID header set by a
+// proxy or client, or generated, and echoing it in the response so errors can be traced in the logs
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = uuid.New().String()
+		}
+		c.Set(requestIDContextKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// requestID returns the request's ID, assigning one if the RequestID middleware didn't
+func requestID(c *gin.Context) string {
+	if id := c.GetString(requestIDContextKey); id != "" {
+		return id
+	}
+	id := uuid.New().String()
+	c.Set(requestIDContextKey, id)
+	c.Header(RequestIDHeader, id)
+	return id
+}
+
+// Recovery returns middleware turning a handler panic into a 500 with the standard error envelope
+// and the request's ID, logging the panic with its stack trace and counting it. It replaces gin's
+// default recovery, which answers with an empty body
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			atomic.AddInt64(&recoveredPanics, 1)
+			id := requestID(c)
+			logrus.WithFields(logrus.Fields{
+				"request_id": id,
+				"method":     c.Request.Method,
+				"route":      c.FullPath(),
+				"path":       c.Request.URL.Path,
+				"panic":      fmt.Sprint(recovered),
+				"stack":      string(debug.Stack()),
+			}).Error("Recovered from panic in request handler")
+
+			// A handler that already started its response can't send an error anymore
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+
+			response := dto.NewErrorResponse(dto.ErrorCodeInternal, "An unexpected error occurred", nil)
+			response.Error.RequestID = id
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		}()
+
+		c.Next()
+	}
+}
+
+// RecoveredPanics returns how many handler panics were recovered since startup
+func RecoveredPanics() int64 {
+	return atomic.LoadInt64(&recoveredPanics)
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/scheduler"
+)
+
+// ReloadHandler handles the admin endpoint reloading the scheduled jobs on demand
+type ReloadHandler struct {
+	scheduler *scheduler.Scheduler
+}
+
+// NewReloadHandler creates a new reload handler
+func NewReloadHandler(scheduler *scheduler.Scheduler) *ReloadHandler {
+	return &ReloadHandler{
+		scheduler: scheduler,
+	}
+}
+
+// ReloadJobs handles POST /api/v1/admin/reload
+// It runs the periodic reload straight away and reports which jobs were added, updated and removed
+func (h *ReloadHandler) ReloadJobs(c *gin.Context) {
+	reload, err := h.scheduler.Reload()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to reload jobs")
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":   "Failed to reload jobs",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"actor":   actorFromRequest(c),
+		"added":   len(reload.Added),
+		"updated": len(reload.Updated),
+		"removed": len(reload.Removed),
+	}).Info("Jobs reloaded on request")
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Jobs reloaded successfully",
+		"reload":  reload,
+	})
+}
+
+// RegisterRoutes registers all reload routes
+func (h *ReloadHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/admin/reload", h.ReloadJobs)
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/services"
+)
+
+// RemediationHandler handles the signed remediation action links sent in failure notifications
+type RemediationHandler struct {
+	remediationService services.RemediationService
+}
+
+// NewRemediationHandler creates a new remediation handler
+func NewRemediationHandler(remediationService services.RemediationService) *RemediationHandler {
+	return &RemediationHandler{
+		remediationService: remediationService,
+	}
+}
+
+// ConfirmAction handles GET /api/v1/actions/{execution_id}/{index}
+// It only shows a confirmation page, so link previews in chat and email never apply an action
+func (h *RemediationHandler) ConfirmAction(c *gin.Context) {
+	executionID, index, ok := parseActionLink(c)
+	if !ok {
+		return
+	}
+
+	action, job, err := h.remediationService.Describe(executionID, index, c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		respondActionError(c, "Failed to load remediation action", err)
+		return
+	}
+
+	page := fmt.Sprintf(`<!DOCTYPE html><html><body><h2>%s</h2><p>Job: %s<br>Run: %s</p>`+
+		`<form method="post" action="%s"><button type="submit">Confirm</button></form></body></html>`,
+		html.EscapeString(action.DisplayLabel()), html.EscapeString(job.Name), executionID,
+		html.EscapeString(c.Request.URL.RequestURI()))
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(page))
+}
+
+// PerformAction handles POST /api/v1/actions/{execution_id}/{index}
+func (h *RemediationHandler) PerformAction(c *gin.Context) {
+	executionID, index, ok := parseActionLink(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.remediationService.Perform(executionID, index, c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		respondActionError(c, "Failed to apply remediation action", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": result.Message,
+		"result":  result,
+	})
+}
+
+// parseActionLink reads the run and action index from an action link
+func parseActionLink(c *gin.Context) (uuid.UUID, int, bool) {
+	executionID, err := uuid.Parse(c.Param("execution_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid execution ID format",
+		})
+		return uuid.Nil, 0, false
+	}
+
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid action index",
+		})
+		return uuid.Nil, 0, false
+	}
+
+	return executionID, index, true
+}
+
+// respondActionError maps remediation errors to HTTP status codes
+func respondActionError(c *gin.Context, message string, err error) {
+	logrus.WithError(err).Warn("Rejected remediation action")
+	status := http.StatusNotFound
+	switch {
+	case errors.Is(err, services.ErrActionLinkInvalid):
+		status = http.StatusForbidden
+	case errors.Is(err, services.ErrActionLinkExpired):
+		status = http.StatusGone
+	}
+	c.JSON(status, gin.H{
+		"error":   message,
+		"details": err.Error(),
+	})
+}
+
+// RegisterRoutes registers all remediation routes
+func (h *RemediationHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/actions/:execution_id/:index", h.ConfirmAction)
+	router.POST("/actions/:execution_id/:index", h.PerformAction)
+}
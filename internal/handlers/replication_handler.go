@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/replication"
+	"job-scheduler/internal/services"
+)
+
+// Page sizes for the job change feed
+const (
+	defaultJobChangeLimit = 500
+	maxJobChangeLimit     = 1000
+)
+
+// ReplicationHandler handles a primary's job change feed and a standby's status and promotion
+type ReplicationHandler struct {
+	replication services.ReplicationService
+	standby     *replication.Standby
+	config      config.ReplicationConfig
+}
+
+// NewReplicationHandler creates a new replication handler
+// standby is nil on instances that aren't standbys
+func NewReplicationHandler(replicationService services.ReplicationService, standby *replication.Standby, cfg config.ReplicationConfig) *ReplicationHandler {
+	return &ReplicationHandler{
+		replication: replicationService,
+		standby:     standby,
+		config:      cfg,
+	}
+}
+
+// GetChanges handles GET /api/v1/replication/changes
+// Changes are paged with ?after=<sequence>&limit=
+func (h *ReplicationHandler) GetChanges(c *gin.Context) {
+	if !h.authorizeStandby(c) {
+		return
+	}
+
+	after, _ := strconv.ParseInt(c.DefaultQuery("after", "0"), 10, 64)
+	if after < 0 {
+		after = 0
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultJobChangeLimit)))
+	if limit < 1 || limit > maxJobChangeLimit {
+		limit = defaultJobChangeLimit
+	}
+
+	feed, err := h.replication.GetChanges(after, limit)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get job changes")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to get job changes",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+// GetSnapshot handles GET /api/v1/replication/snapshot
+func (h *ReplicationHandler) GetSnapshot(c *gin.Context) {
+	if !h.authorizeStandby(c) {
+		return
+	}
+
+	snapshot, err := h.replication.GetSnapshot(c.Request.Context())
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get job snapshot")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to get job snapshot",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, snapshot)
+}
+
+// authorizeStandby checks that the instance is a primary and the request has its replication token
+func (h *ReplicationHandler) authorizeStandby(c *gin.Context) bool {
+	if h.config.Role != config.ReplicationRolePrimary {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "This instance is not a replication primary",
+		})
+		return false
+	}
+
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.config.Token)) != 1 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid replication token",
+		})
+		return false
+	}
+	return true
+}
+
+// GetStatus handles GET /api/v1/admin/replication
+func (h *ReplicationHandler) GetStatus(c *gin.Context) {
+	var status *models.ReplicationStatus
+	var err error
+	if h.standby != nil {
+		status, err = h.standby.Status()
+	} else {
+		status = &models.ReplicationStatus{Role: h.config.Role}
+		status.LatestSequence, err = h.replication.LatestSequence()
+	}
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get replication status")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to get replication status",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"replication": status})
+}
+
+// PromoteStandby handles POST /api/v1/admin/replication/promote
+// It makes a standby stop following its primary and start running the jobs
+func (h *ReplicationHandler) PromoteStandby(c *gin.Context) {
+	if h.standby == nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "Failed to promote standby",
+			"details": services.ErrNotStandby.Error(),
+		})
+		return
+	}
+
+	actor := actorFromRequest(c)
+	status, err := h.standby.Promote(actor)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, services.ErrNotStandby) || errors.Is(err, services.ErrAlreadyPromoted) {
+			statusCode = http.StatusConflict
+		}
+		logrus.WithError(err).Error("Failed to promote standby")
+		c.JSON(statusCode, gin.H{
+			"error":   "Failed to promote standby",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Standby promoted successfully",
+		"replication": status,
+	})
+}
+
+// RegisterRoutes registers all replication routes
+func (h *ReplicationHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/replication/changes", h.GetChanges)
+	router.GET("/replication/snapshot", h.GetSnapshot)
+	router.GET("/admin/replication", h.GetStatus)
+	router.POST("/admin/replication/promote", h.PromoteStandby)
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// ReportTemplateHandler handles HTTP requests for report templates
+type ReportTemplateHandler struct {
+	templateService services.ReportTemplateService
+}
+
+// NewReportTemplateHandler creates a new report template handler
+func NewReportTemplateHandler(templateService services.ReportTemplateService) *ReportTemplateHandler {
+	return &ReportTemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// CreateReportTemplate handles POST /api/v1/report-templates
+func (h *ReportTemplateHandler) CreateReportTemplate(c *gin.Context) {
+	var req models.CreateReportTemplateRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind create report template request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	template, err := h.templateService.CreateReportTemplate(&req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create report template")
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrReportTemplateNameTaken) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to create report template",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":         "Report template created successfully",
+		"report_template": dto.FromReportTemplate(template),
+	})
+}
+
+// GetReportTemplates handles GET /api/v1/report-templates
+func (h *ReportTemplateHandler) GetReportTemplates(c *gin.Context) {
+	templates, err := h.templateService.GetReportTemplates()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get report templates")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve report templates",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"report_templates": dto.FromReportTemplates(templates),
+	})
+}
+
+// GetReportTemplate handles GET /api/v1/report-templates/{id}
+func (h *ReportTemplateHandler) GetReportTemplate(c *gin.Context) {
+	// Parse report template ID from URL parameter
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid report template ID format",
+		})
+		return
+	}
+
+	template, err := h.templateService.GetReportTemplateByID(templateID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get report template")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Report template not found",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"report_template": dto.FromReportTemplate(template),
+	})
+}
+
+// UpdateReportTemplate handles PUT /api/v1/report-templates/{id}
+func (h *ReportTemplateHandler) UpdateReportTemplate(c *gin.Context) {
+	// Parse report template ID from URL parameter
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid report template ID format",
+		})
+		return
+	}
+
+	var req models.UpdateReportTemplateRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind update report template request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	template, err := h.templateService.UpdateReportTemplate(templateID, &req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to update report template")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to update report template",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":         "Report template updated successfully",
+		"report_template": dto.FromReportTemplate(template),
+	})
+}
+
+// DeleteReportTemplate handles DELETE /api/v1/report-templates/{id}
+func (h *ReportTemplateHandler) DeleteReportTemplate(c *gin.Context) {
+	// Parse report template ID from URL parameter
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid report template ID format",
+		})
+		return
+	}
+
+	if err := h.templateService.DeleteReportTemplate(templateID); err != nil {
+		logrus.WithError(err).Error("Failed to delete report template")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Failed to delete report template",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Report template deleted successfully",
+	})
+}
+
+// RegisterRoutes registers all report template routes
+func (h *ReportTemplateHandler) RegisterRoutes(router *gin.RouterGroup) {
+	templates := router.Group("/report-templates")
+	{
+		templates.POST("", h.CreateReportTemplate)
+		templates.GET("", h.GetReportTemplates)
+		templates.GET("/:id", h.GetReportTemplate)
+		templates.PUT("/:id", h.UpdateReportTemplate)
+		templates.DELETE("/:id", h.DeleteReportTemplate)
+	}
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// RoleHandler handles HTTP requests for role assignments
+type RoleHandler struct {
+	roleService services.RoleService
+}
+
+// NewRoleHandler creates a new role handler
+func NewRoleHandler(roleService services.RoleService) *RoleHandler {
+	return &RoleHandler{
+		roleService: roleService,
+	}
+}
+
+// CreateRoleAssignment handles POST /api/v1/role-assignments
+func (h *RoleHandler) CreateRoleAssignment(c *gin.Context) {
+	var req models.CreateRoleAssignmentRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind create role assignment request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	assignment, err := h.roleService.CreateRoleAssignment(&req, actorFromRequest(c))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create role assignment")
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrRoleAssignmentExists) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to create role assignment",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":         "Role assigned successfully",
+		"role_assignment": dto.FromRoleAssignment(assignment),
+	})
+}
+
+// GetRoleAssignments handles GET /api/v1/role-assignments
+func (h *RoleHandler) GetRoleAssignments(c *gin.Context) {
+	assignments, err := h.roleService.GetRoleAssignments()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get role assignments")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve role assignments",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"role_assignments": dto.FromRoleAssignments(assignments),
+	})
+}
+
+// UpdateRoleAssignment handles PUT /api/v1/role-assignments/{id}
+func (h *RoleHandler) UpdateRoleAssignment(c *gin.Context) {
+	// Parse role assignment ID from URL parameter
+	assignmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid role assignment ID format",
+		})
+		return
+	}
+
+	var req models.UpdateRoleAssignmentRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind update role assignment request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	assignment, err := h.roleService.UpdateRoleAssignment(assignmentID, &req, actorFromRequest(c))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to update role assignment")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to update role assignment",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":         "Role assignment updated successfully",
+		"role_assignment": dto.FromRoleAssignment(assignment),
+	})
+}
+
+// DeleteRoleAssignment handles DELETE /api/v1/role-assignments/{id}
+func (h *RoleHandler) DeleteRoleAssignment(c *gin.Context) {
+	// Parse role assignment ID from URL parameter
+	assignmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid role assignment ID format",
+		})
+		return
+	}
+
+	if err := h.roleService.DeleteRoleAssignment(assignmentID); err != nil {
+		logrus.WithError(err).Error("Failed to delete role assignment")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Failed to delete role assignment",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Role assignment deleted successfully",
+	})
+}
+
+// RegisterRoutes registers all role assignment routes
+func (h *RoleHandler) RegisterRoutes(router *gin.RouterGroup) {
+	assignments := router.Group("/role-assignments")
+	{
+		assignments.POST("", h.CreateRoleAssignment)
+		assignments.GET("", h.GetRoleAssignments)
+		assignments.PUT("/:id", h.UpdateRoleAssignment)
+		assignments.DELETE("/:id", h.DeleteRoleAssignment)
+	}
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// TeamChannelHandler handles HTTP requests for team notification channels
+type TeamChannelHandler struct {
+	channelService services.TeamChannelService
+}
+
+// NewTeamChannelHandler creates a new team channel handler
+func NewTeamChannelHandler(channelService services.TeamChannelService) *TeamChannelHandler {
+	return &TeamChannelHandler{
+		channelService: channelService,
+	}
+}
+
+// CreateTeamChannel handles POST /api/v1/team-channels
+func (h *TeamChannelHandler) CreateTeamChannel(c *gin.Context) {
+	var req models.CreateTeamChannelRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind create team channel request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	channel, err := h.channelService.CreateTeamChannel(&req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create team channel")
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrTeamChannelExists) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to create team channel",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":      "Team channel created successfully",
+		"team_channel": dto.FromTeamChannel(channel),
+	})
+}
+
+// GetTeamChannels handles GET /api/v1/team-channels
+func (h *TeamChannelHandler) GetTeamChannels(c *gin.Context) {
+	channels, err := h.channelService.GetTeamChannels()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get team channels")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve team channels",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"team_channels": dto.FromTeamChannels(channels),
+	})
+}
+
+// UpdateTeamChannel handles PUT /api/v1/team-channels/{id}
+func (h *TeamChannelHandler) UpdateTeamChannel(c *gin.Context) {
+	// Parse team channel ID from URL parameter
+	channelID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid team channel ID format",
+		})
+		return
+	}
+
+	var req models.UpdateTeamChannelRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind update team channel request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	channel, err := h.channelService.UpdateTeamChannel(channelID, &req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to update team channel")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to update team channel",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Team channel updated successfully",
+		"team_channel": dto.FromTeamChannel(channel),
+	})
+}
+
+// DeleteTeamChannel handles DELETE /api/v1/team-channels/{id}
+func (h *TeamChannelHandler) DeleteTeamChannel(c *gin.Context) {
+	// Parse team channel ID from URL parameter
+	channelID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid team channel ID format",
+		})
+		return
+	}
+
+	if err := h.channelService.DeleteTeamChannel(channelID); err != nil {
+		logrus.WithError(err).Error("Failed to delete team channel")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Failed to delete team channel",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Team channel deleted successfully",
+	})
+}
+
+// RegisterRoutes registers all team channel routes
+func (h *TeamChannelHandler) RegisterRoutes(router *gin.RouterGroup) {
+	channels := router.Group("/team-channels")
+	{
+		channels.POST("", h.CreateTeamChannel)
+		channels.GET("", h.GetTeamChannels)
+		channels.PUT("/:id", h.UpdateTeamChannel)
+		channels.DELETE("/:id", h.DeleteTeamChannel)
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+)
+
+// RequestTimeout returns middleware giving each request's context a deadline, so the services and
+// repositories it reaches stop work once the request has taken too long, and answering requests
+// that ran out of time with 504. A timeout of 0 disables it. Streaming endpoints, the event stream
+// and followed run logs, stay open for as long as the client listens and are exempt
+func RequestTimeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 || isStreamingRequest(c) {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			respondTimedOut(c)
+		}
+	}
+}
+
+// isStreamingRequest reports whether a request is for a server-sent event stream
+func isStreamingRequest(c *gin.Context) bool {
+	return strings.HasSuffix(c.FullPath(), "/events") || c.Query("follow") == "true"
+}
+
+// requestTimedOut answers with 504 and reports true when err came from the request's deadline
+// passing, so list handlers tell a slow query apart from a failed one. The driver doesn't always
+// wrap the context's error, so the deadline is checked too
+func requestTimedOut(c *gin.Context, err error) bool {
+	if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(c.Request.Context().Err(), context.DeadlineExceeded) {
+		return false
+	}
+	respondTimedOut(c)
+	return true
+}
+
+// respondTimedOut aborts the request with 504 Gateway Timeout
+func respondTimedOut(c *gin.Context) {
+	logrus.WithField("route", c.Request.Method+" "+c.FullPath()).Warn("Request timed out")
+	c.AbortWithStatusJSON(http.StatusGatewayTimeout, dto.NewErrorResponse(
+		dto.ErrorCodeTimeout,
+		"The request took too long and was cancelled",
+		nil,
+	))
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// TriggerSourceHandler handles HTTP requests for SQS / Pub/Sub trigger sources
+type TriggerSourceHandler struct {
+	sourceService services.TriggerSourceService
+}
+
+// NewTriggerSourceHandler creates a new trigger source handler
+func NewTriggerSourceHandler(sourceService services.TriggerSourceService) *TriggerSourceHandler {
+	return &TriggerSourceHandler{
+		sourceService: sourceService,
+	}
+}
+
+// CreateTriggerSource handles POST /api/v1/jobs/{id}/trigger-sources
+func (h *TriggerSourceHandler) CreateTriggerSource(c *gin.Context) {
+	// Parse job ID from URL parameter
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+
+	var req models.CreateTriggerSourceRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind create trigger source request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	source, err := h.sourceService.CreateTriggerSource(jobID, &req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create trigger source")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to create trigger source",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":        "Trigger source created successfully",
+		"trigger_source": dto.FromTriggerSource(source),
+	})
+}
+
+// GetTriggerSources handles GET /api/v1/jobs/{id}/trigger-sources
+func (h *TriggerSourceHandler) GetTriggerSources(c *gin.Context) {
+	// Parse job ID from URL parameter
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+
+	sources, err := h.sourceService.GetTriggerSourcesByJobID(jobID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get trigger sources")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve trigger sources",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"trigger_sources": dto.FromTriggerSources(sources),
+	})
+}
+
+// DeleteTriggerSource handles DELETE /api/v1/trigger-sources/{id}
+func (h *TriggerSourceHandler) DeleteTriggerSource(c *gin.Context) {
+	// Parse trigger source ID from URL parameter
+	sourceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid trigger source ID format",
+		})
+		return
+	}
+
+	if err := h.sourceService.DeleteTriggerSource(sourceID); err != nil {
+		logrus.WithError(err).Error("Failed to delete trigger source")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Failed to delete trigger source",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Trigger source deleted successfully",
+	})
+}
+
+// RegisterRoutes registers all trigger source routes
+func (h *TriggerSourceHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/jobs/:id/trigger-sources", h.CreateTriggerSource)
+	router.GET("/jobs/:id/trigger-sources", h.GetTriggerSources)
+	router.DELETE("/trigger-sources/:id", h.DeleteTriggerSource)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/dto"
+)
+
+// Versioning headers and media types
+const (
+	// APIVersionHeader reports the API version that served the request
+	APIVersionHeader = "API-Version"
+
+	// vendorMediaTypePrefix is the versioned media type clients may send in Accept,
+	// e.g. application/vnd.job-scheduler.v2+json
+	vendorMediaTypePrefix = "application/vnd.job-scheduler.v"
+	vendorMediaTypeSuffix = "+json"
+)
+
+// VersionDeprecation describes a deprecated API version
+type VersionDeprecation struct {
+	// Sunset is when the version will be removed; zero if not yet scheduled
+	Sunset time.Time
+	// Successor is the base path of the version that replaces it
+	Successor string
+}
+
+// V1Deprecation describes the deprecation of /api/v1 in favour of /api/v2
+func V1Deprecation(cfg *config.Config) *VersionDeprecation {
+	return &VersionDeprecation{
+		Sunset:    cfg.API.V1Sunset,
+		Successor: "/api/v2",
+	}
+}
+
+// APIVersion returns middleware for a versioned route group
+// It reports the version in the API-Version header, rejects Accept headers that
+// ask for a different version with 406, and marks deprecated versions with
+// Deprecation, Sunset and Link headers
+func APIVersion(version int, deprecation *VersionDeprecation) gin.HandlerFunc {
+	mediaType := fmt.Sprintf("%s%d%s", vendorMediaTypePrefix, version, vendorMediaTypeSuffix)
+
+	return func(c *gin.Context) {
+		requested, ok := requestedVersions(c.GetHeader("Accept"))
+		if ok && !requested[version] {
+			c.AbortWithStatusJSON(http.StatusNotAcceptable, dto.NewErrorResponse(
+				dto.ErrorCodeNotAcceptable,
+				fmt.Sprintf("This endpoint serves API version %d", version),
+				nil,
+			))
+			return
+		}
+		if ok {
+			c.Header("Content-Type", mediaType+"; charset=utf-8")
+		}
+
+		c.Header(APIVersionHeader, strconv.Itoa(version))
+		if deprecation != nil {
+			c.Header("Deprecation", "true")
+			if !deprecation.Sunset.IsZero() {
+				c.Header("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if deprecation.Successor != "" {
+				c.Header("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", deprecation.Successor))
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// requestedVersions returns the versions named by vendor media types in an Accept header
+// ok is false when the header names no vendor media type, so any version is acceptable
+func requestedVersions(accept string) (map[int]bool, bool) {
+	versions := make(map[int]bool)
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(mediaRange, ";", 2)[0])
+		if !strings.HasPrefix(mediaType, vendorMediaTypePrefix) || !strings.HasSuffix(mediaType, vendorMediaTypeSuffix) {
+			continue
+		}
+		number := strings.TrimSuffix(strings.TrimPrefix(mediaType, vendorMediaTypePrefix), vendorMediaTypeSuffix)
+		if version, err := strconv.Atoi(number); err == nil {
+			versions[version] = true
+		}
+	}
+	return versions, len(versions) > 0
+}
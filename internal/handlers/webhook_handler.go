@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// maxWebhookBodyBytes caps the size of inbound webhook payloads
+const maxWebhookBodyBytes = 1 << 20
+
+// WebhookHandler handles HTTP requests for inbound webhook triggers
+type WebhookHandler struct {
+	webhookService services.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookService services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhook handles POST /api/v1/jobs/{id}/webhooks
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	// Parse job ID from URL parameter
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+
+	var req models.CreateJobWebhookRequest
+
+	// Body is optional - all fields have defaults
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logrus.WithError(err).Error("Failed to bind create webhook request")
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request body",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	webhook, secret, err := h.webhookService.CreateWebhook(jobID, &req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create webhook")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to create webhook",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewCreatedWebhookResponse(webhook, secret))
+}
+
+// GetWebhooks handles GET /api/v1/jobs/{id}/webhooks
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	// Parse job ID from URL parameter
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID format",
+		})
+		return
+	}
+
+	webhooks, err := h.webhookService.GetWebhooksByJobID(jobID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get webhooks")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve webhooks",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"webhooks": dto.FromWebhooks(webhooks),
+	})
+}
+
+// DeleteWebhook handles DELETE /api/v1/webhooks/{id}
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	// Parse webhook ID from URL parameter
+	webhookID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid webhook ID format",
+		})
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(webhookID); err != nil {
+		logrus.WithError(err).Error("Failed to delete webhook")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Failed to delete webhook",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook deleted successfully",
+	})
+}
+
+// ReceiveHook handles POST /api/v1/hooks/{token}
+func (h *WebhookHandler) ReceiveHook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodyBytes+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to read request body",
+		})
+		return
+	}
+	if len(body) > maxWebhookBodyBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error": "Webhook payload too large",
+		})
+		return
+	}
+
+	webhook, awaiting, err := h.webhookService.HandleInbound(c.Param("token"), c.Request.Header, body)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrWebhookNotFound):
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Webhook not found",
+			})
+		case errors.Is(err, services.ErrInvalidSignature):
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Invalid signature",
+			})
+		case errors.Is(err, services.ErrRateLimited):
+			c.Header("Retry-After", "60")
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": "Rate limit exceeded",
+			})
+		case errors.Is(err, services.ErrWebhookJobInactive):
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Job is not active",
+			})
+		default:
+			logrus.WithError(err).Error("Failed to handle webhook")
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Failed to trigger job",
+				"details": err.Error(),
+			})
+		}
+		return
+	}
+
+	if awaiting != nil {
+		c.JSON(http.StatusAccepted, gin.H{
+			"message":      "Run awaiting approval",
+			"job_id":       webhook.JobID,
+			"execution_id": awaiting.ID,
+		})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Job triggered",
+		"job_id":  webhook.JobID,
+	})
+}
+
+// RegisterRoutes registers all webhook-related routes
+func (h *WebhookHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/jobs/:id/webhooks", h.CreateWebhook)
+	router.GET("/jobs/:id/webhooks", h.GetWebhooks)
+	router.DELETE("/webhooks/:id", h.DeleteWebhook)
+	router.POST("/hooks/:token", h.ReceiveHook)
+}
@@ -0,0 +1,358 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// WorkflowHandler handles HTTP requests for workflows and their executions
+type WorkflowHandler struct {
+	workflowService services.WorkflowService
+}
+
+// NewWorkflowHandler creates a new workflow handler
+func NewWorkflowHandler(workflowService services.WorkflowService) *WorkflowHandler {
+	return &WorkflowHandler{
+		workflowService: workflowService,
+	}
+}
+
+// CreateWorkflow handles POST /api/v1/workflows
+func (h *WorkflowHandler) CreateWorkflow(c *gin.Context) {
+	var req models.CreateWorkflowRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind create workflow request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	workflow, err := h.workflowService.CreateWorkflow(&req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create workflow")
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrWorkflowExists) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to create workflow",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "Workflow created successfully",
+		"workflow": dto.FromWorkflow(workflow),
+	})
+}
+
+// GetWorkflows handles GET /api/v1/workflows
+func (h *WorkflowHandler) GetWorkflows(c *gin.Context) {
+	workflows, err := h.workflowService.GetWorkflows()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get workflows")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve workflows",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"workflows": dto.FromWorkflows(workflows),
+	})
+}
+
+// GetWorkflow handles GET /api/v1/workflows/{id}
+func (h *WorkflowHandler) GetWorkflow(c *gin.Context) {
+	// Parse workflow ID from URL parameter
+	workflowID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid workflow ID format",
+		})
+		return
+	}
+
+	workflow, err := h.workflowService.GetWorkflow(workflowID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get workflow")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Workflow not found",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"workflow": dto.FromWorkflow(workflow),
+	})
+}
+
+// UpdateWorkflow handles PUT /api/v1/workflows/{id}
+func (h *WorkflowHandler) UpdateWorkflow(c *gin.Context) {
+	// Parse workflow ID from URL parameter
+	workflowID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid workflow ID format",
+		})
+		return
+	}
+
+	var req models.UpdateWorkflowRequest
+
+	// Bind JSON request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logrus.WithError(err).Error("Failed to bind update workflow request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	workflow, err := h.workflowService.UpdateWorkflow(workflowID, &req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to update workflow")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to update workflow",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Workflow updated successfully",
+		"workflow": dto.FromWorkflow(workflow),
+	})
+}
+
+// DeleteWorkflow handles DELETE /api/v1/workflows/{id}
+func (h *WorkflowHandler) DeleteWorkflow(c *gin.Context) {
+	// Parse workflow ID from URL parameter
+	workflowID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid workflow ID format",
+		})
+		return
+	}
+
+	if err := h.workflowService.DeleteWorkflow(workflowID); err != nil {
+		logrus.WithError(err).Error("Failed to delete workflow")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Failed to delete workflow",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Workflow deleted successfully",
+	})
+}
+
+// TriggerWorkflow handles POST /api/v1/workflows/{id}/trigger
+// The workflow runs in the background; the response carries its execution for polling
+func (h *WorkflowHandler) TriggerWorkflow(c *gin.Context) {
+	// Parse workflow ID from URL parameter
+	workflowID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid workflow ID format",
+		})
+		return
+	}
+
+	// The body is optional; without one the workflow runs without parameters
+	var req models.TriggerWorkflowRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logrus.WithError(err).Error("Failed to bind trigger workflow request")
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request body",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	execution, err := h.workflowService.TriggerWorkflow(workflowID, req.Parameters, actorFromRequest(c))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to trigger workflow")
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrWorkflowInactive) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to trigger workflow",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":            "Workflow triggered successfully",
+		"workflow_execution": dto.FromWorkflowExecution(execution),
+	})
+}
+
+// GetWorkflowExecutions handles GET /api/v1/workflows/{id}/executions
+func (h *WorkflowHandler) GetWorkflowExecutions(c *gin.Context) {
+	// Parse workflow ID from URL parameter
+	workflowID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid workflow ID format",
+		})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	executions, err := h.workflowService.GetWorkflowExecutions(workflowID, limit)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get workflow executions")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Failed to retrieve workflow executions",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"workflow_executions": dto.FromWorkflowExecutions(executions),
+	})
+}
+
+// GetWorkflowExecution handles GET /api/v1/workflow-executions/{id}
+func (h *WorkflowHandler) GetWorkflowExecution(c *gin.Context) {
+	// Parse workflow execution ID from URL parameter
+	executionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid workflow execution ID format",
+		})
+		return
+	}
+
+	execution, err := h.workflowService.GetWorkflowExecution(executionID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get workflow execution")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Workflow execution not found",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"workflow_execution": dto.FromWorkflowExecution(execution),
+	})
+}
+
+// GetWorkflowRun handles GET /api/v1/workflows/runs/{rootRunId}
+// It shows a workflow run, from the workflow execution at the root of its tree, with its nodes, the
+// job runs each started, their statuses, durations and links, and which nodes pending ones are
+// waiting on, so it's clear where a run stalled
+func (h *WorkflowHandler) GetWorkflowRun(c *gin.Context) {
+	// Parse root run ID from URL parameter
+	rootRunID, err := uuid.Parse(c.Param("rootRunId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid workflow run ID format",
+		})
+		return
+	}
+
+	run, err := h.workflowService.GetWorkflowRun(rootRunID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get workflow run")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Workflow run not found",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"workflow_run": dto.FromWorkflowRun(run),
+	})
+}
+
+// GetWorkflowCriticalPath handles GET /api/v1/workflow-executions/{id}/critical-path?deadline=45m
+// It shows the chain of nodes that decides how long the run takes and how much each node could slip,
+// against the optional deadline for the whole run
+func (h *WorkflowHandler) GetWorkflowCriticalPath(c *gin.Context) {
+	// Parse workflow execution ID from URL parameter
+	executionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid workflow execution ID format",
+		})
+		return
+	}
+
+	var deadline time.Duration
+	if value := c.Query("deadline"); value != "" {
+		deadline, err = time.ParseDuration(value)
+		if err != nil || deadline <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid deadline",
+				"details": "deadline must be a positive duration, e.g. 45m",
+			})
+			return
+		}
+	}
+
+	path, err := h.workflowService.GetWorkflowCriticalPath(executionID, deadline)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get workflow critical path")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Failed to get workflow critical path",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"critical_path": dto.FromWorkflowCriticalPath(executionID, path),
+	})
+}
+
+// RegisterRoutes registers all workflow routes
+func (h *WorkflowHandler) RegisterRoutes(router *gin.RouterGroup) {
+	workflows := router.Group("/workflows")
+	{
+		workflows.POST("", h.CreateWorkflow)
+		workflows.GET("", h.GetWorkflows)
+		workflows.GET("/:id", h.GetWorkflow)
+		workflows.GET("/runs/:rootRunId", h.GetWorkflowRun)
+		workflows.PUT("/:id", h.UpdateWorkflow)
+		workflows.DELETE("/:id", h.DeleteWorkflow)
+		workflows.POST("/:id/trigger", h.TriggerWorkflow)
+		workflows.GET("/:id/executions", h.GetWorkflowExecutions)
+	}
+
+	router.GET("/workflow-executions/:id", h.GetWorkflowExecution)
+	router.GET("/workflow-executions/:id/critical-path", h.GetWorkflowCriticalPath)
+}
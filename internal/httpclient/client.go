@@ -0,0 +1,135 @@
+// Package httpclient builds the outbound HTTP clients shared by executors and
+// notification webhooks, with connection pooling, retries, metrics and trace headers
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"job-scheduler/internal/config"
+)
+
+// Factory hands out named clients that share one connection pool
+// Each client keeps its own timeout and metrics
+type Factory struct {
+	transport *http.Transport
+	cfg       config.HTTPClientConfig
+
+	mu      sync.Mutex
+	metrics map[string]*metrics
+}
+
+// NewFactory creates a factory whose clients use the configured pool, proxy and TLS settings
+func NewFactory(cfg config.HTTPClientConfig) (*Factory, error) {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return &Factory{
+		transport: transport,
+		cfg:       cfg,
+		metrics:   make(map[string]*metrics),
+	}, nil
+}
+
+// newTLSConfig trusts the system roots plus any CAs in cfg.CAFile
+func newTLSConfig(cfg config.HTTPClientConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+	if cfg.CAFile == "" {
+		return tlsConfig, nil
+	}
+
+	pem, err := ioutil.ReadFile(cfg.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA file %s", cfg.CAFile)
+	}
+	tlsConfig.RootCAs = pool
+
+	return tlsConfig, nil
+}
+
+// Client returns a client for the named integration, e.g. "health_check" or "slack"
+// Requests are retried, traced and counted under name
+func (f *Factory) Client(name string, timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &instrumentedTransport{
+			name:       name,
+			next:       f.transport,
+			metrics:    f.metricsFor(name),
+			maxRetries: f.cfg.MaxRetries,
+			backoff:    f.cfg.RetryBackoff,
+		},
+	}
+}
+
+// Stats returns the request metrics of every client, keyed by name
+func (f *Factory) Stats() map[string]ClientStats {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stats := make(map[string]ClientStats, len(f.metrics))
+	for name, m := range f.metrics {
+		stats[name] = m.snapshot()
+	}
+	return stats
+}
+
+// CloseIdleConnections closes pooled connections that aren't in use
+func (f *Factory) CloseIdleConnections() {
+	f.transport.CloseIdleConnections()
+}
+
+// metricsFor returns the metrics shared by clients with the given name
+func (f *Factory) metricsFor(name string) *metrics {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	m, ok := f.metrics[name]
+	if !ok {
+		m = &metrics{}
+		f.metrics[name] = m
+	}
+	return m
+}
@@ -0,0 +1,165 @@
+package httpclient
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TraceHeader carries the W3C trace context of outbound requests
+const TraceHeader = "traceparent"
+
+// ClientStats summarizes the requests a client has made
+type ClientStats struct {
+	Requests          int64 `json:"requests"`
+	Retries           int64 `json:"retries"`
+	Errors            int64 `json:"errors"`
+	ServerErrors      int64 `json:"server_errors"`
+	AverageDurationMs int64 `json:"average_duration_ms"`
+}
+
+// metrics counts a client's requests
+type metrics struct {
+	mu            sync.Mutex
+	requests      int64
+	retries       int64
+	errors        int64
+	serverErrors  int64
+	totalDuration time.Duration
+}
+
+// record counts one attempt and its outcome
+func (m *metrics) record(resp *http.Response, err error, duration time.Duration, retry bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests++
+	m.totalDuration += duration
+	if retry {
+		m.retries++
+	}
+	if err != nil {
+		m.errors++
+	} else if resp.StatusCode >= 500 {
+		m.serverErrors++
+	}
+}
+
+// snapshot returns the current counters
+func (m *metrics) snapshot() ClientStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := ClientStats{
+		Requests:     m.requests,
+		Retries:      m.retries,
+		Errors:       m.errors,
+		ServerErrors: m.serverErrors,
+	}
+	if m.requests > 0 {
+		stats.AverageDurationMs = (m.totalDuration / time.Duration(m.requests)).Milliseconds()
+	}
+	return stats
+}
+
+// instrumentedTransport traces, retries and counts requests before handing them to the shared pool
+type instrumentedTransport struct {
+	name       string
+	next       http.RoundTripper
+	metrics    *metrics
+	maxRetries int
+	backoff    time.Duration
+}
+
+// RoundTrip sends the request, retrying connection errors and 429/502/503/504 responses
+// Requests with a body are only retried when the body can be replayed
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = withTrace(req)
+	delay := t.backoff
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to replay request body: %w", err)
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		start := time.Now()
+		resp, err := t.next.RoundTrip(req)
+		duration := time.Since(start)
+		t.metrics.record(resp, err, duration, attempt > 0)
+
+		logrus.WithFields(logrus.Fields{
+			"client":      t.name,
+			"method":      req.Method,
+			"host":        req.URL.Host,
+			"attempt":     attempt + 1,
+			"duration_ms": duration.Milliseconds(),
+			"traceparent": req.Header.Get(TraceHeader),
+		}).Debug("Outbound HTTP request")
+
+		if attempt >= t.maxRetries || !retryable(req, resp, err) {
+			return resp, err
+		}
+
+		// Drain the response so its connection returns to the pool
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// retryable reports whether a failed attempt may be sent again
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// withTrace starts a trace for the request unless the caller already set one
+func withTrace(req *http.Request) *http.Request {
+	if req.Header.Get(TraceHeader) != "" {
+		return req
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set(TraceHeader, fmt.Sprintf("00-%s-%s-01", randomHex(16), randomHex(8)))
+	return req
+}
+
+// randomHex returns n random bytes, hex encoded
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,170 @@
+package integrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// databaseIntegrationPrefix prefixes the names registered databases are managed under
+const databaseIntegrationPrefix = "database:"
+
+// ErrDSNPassword is returned for DSNs that embed a password instead of referencing a secret
+var ErrDSNPassword = errors.New("dsn must not contain a password; reference it with password_secret")
+
+// DatabaseIntegration returns the name a registered database is managed under
+func DatabaseIntegration(name string) string {
+	return databaseIntegrationPrefix + name
+}
+
+// IsDatabaseIntegration reports whether an integration is a registered database
+func IsDatabaseIntegration(name string) bool {
+	return strings.HasPrefix(name, databaseIntegrationPrefix)
+}
+
+// DatabaseConfig configures a pool of connections to an external database
+type DatabaseConfig struct {
+	// DSN is a postgres:// URL or key=value DSN without credentials
+	DSN      string
+	Username string
+	Password string
+
+	// Pool limits
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// DatabasePool is a pool of connections to an external database
+type DatabasePool struct {
+	db *sql.DB
+}
+
+// OpenDatabase returns an OpenFunc that opens a connection pool and checks the database answers
+func OpenDatabase(cfg DatabaseConfig) OpenFunc {
+	return func(ctx context.Context) (Resource, error) {
+		dsn, err := DatabaseDSN(cfg.DSN, cfg.Username, cfg.Password)
+		if err != nil {
+			return nil, err
+		}
+
+		gormDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+			DisableAutomaticPing: true,
+			Logger:               logger.Default.LogMode(logger.Silent),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database: %w", err)
+		}
+		db, err := gormDB.DB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get database pool: %w", err)
+		}
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+		pool := &DatabasePool{db: db}
+		if err := pool.Ping(ctx); err != nil {
+			db.Close()
+			return nil, err
+		}
+		return pool, nil
+	}
+}
+
+// DB returns the pool for running queries
+func (p *DatabasePool) DB() *sql.DB {
+	return p.db
+}
+
+// Ping checks the database answers
+func (p *DatabasePool) Ping(ctx context.Context) error {
+	if err := p.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
+
+// Close closes every connection of the pool
+func (p *DatabasePool) Close() error {
+	return p.db.Close()
+}
+
+// CheckDSN checks that a DSN doesn't embed a password
+func CheckDSN(dsn string) error {
+	dsn = strings.TrimSpace(dsn)
+	if dsn == "" {
+		return errors.New("dsn is required")
+	}
+	if isURLDSN(dsn) {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return fmt.Errorf("invalid dsn: %w", err)
+		}
+		if _, ok := u.User.Password(); ok || u.Query().Has("password") {
+			return ErrDSNPassword
+		}
+		return nil
+	}
+	for _, field := range strings.Fields(dsn) {
+		if strings.HasPrefix(strings.ToLower(field), "password=") {
+			return ErrDSNPassword
+		}
+	}
+	return nil
+}
+
+// DatabaseDSN adds credentials to a DSN without any
+func DatabaseDSN(dsn, username, password string) (string, error) {
+	if err := CheckDSN(dsn); err != nil {
+		return "", err
+	}
+	if username == "" && password == "" {
+		return dsn, nil
+	}
+
+	if isURLDSN(dsn) {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return "", fmt.Errorf("invalid dsn: %w", err)
+		}
+		if username == "" && u.User != nil {
+			username = u.User.Username()
+		}
+		u.User = url.User(username)
+		if password != "" {
+			u.User = url.UserPassword(username, password)
+		}
+		return u.String(), nil
+	}
+
+	var b strings.Builder
+	b.WriteString(dsn)
+	if username != "" {
+		b.WriteString(" user=" + quoteDSNValue(username))
+	}
+	if password != "" {
+		b.WriteString(" password=" + quoteDSNValue(password))
+	}
+	return b.String(), nil
+}
+
+// isURLDSN reports whether a DSN is a URL rather than key=value pairs
+func isURLDSN(dsn string) bool {
+	return strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://")
+}
+
+// quoteDSNValue quotes a key=value DSN value, escaping quotes and backslashes
+func quoteDSNValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
@@ -0,0 +1,292 @@
+// Package integrations owns the long-lived connections executors share, such as
+// SMTP connection pools, opening them on first use and closing them on shutdown
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/config"
+)
+
+var (
+	// ErrUnknownIntegration is returned when getting an integration that was never registered
+	ErrUnknownIntegration = errors.New("integration not registered")
+	// ErrManagerClosed is returned when getting an integration after shutdown
+	ErrManagerClosed = errors.New("integration manager is closed")
+)
+
+// Integration statuses reported by Check
+const (
+	StatusHealthy   = "healthy"
+	StatusUnhealthy = "unhealthy"
+	StatusIdle      = "idle" // registered but not opened yet
+)
+
+// Resource is a long-lived client owned by the manager
+type Resource interface {
+	// Ping checks the connection is usable
+	Ping(ctx context.Context) error
+	// Close releases the connection
+	Close() error
+}
+
+// OpenFunc connects a resource; it is called on first use
+type OpenFunc func(ctx context.Context) (Resource, error)
+
+// Status is the health of one integration
+type Status struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// entry is a registered integration and its resource once opened
+type entry struct {
+	mu       sync.Mutex
+	open     OpenFunc
+	resource Resource
+}
+
+// Manager opens integrations lazily, shares them between runs and closes them on shutdown
+type Manager struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+	opened  []string // names in the order they were opened, closed in reverse
+	closed  bool
+}
+
+// NewManager creates an empty integration manager
+func NewManager() *Manager {
+	return &Manager{
+		entries: make(map[string]*entry),
+	}
+}
+
+// Register adds an integration, replacing any registered under the same name
+// Nothing is connected until the integration is first used
+func (m *Manager) Register(name string, open OpenFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[name] = &entry{open: open}
+}
+
+// Remove unregisters an integration, closing it if it was opened
+// Runs still holding the resource see it closed, so remove integrations only when they change
+func (m *Manager) Remove(name string) error {
+	m.mu.Lock()
+	e, ok := m.entries[name]
+	if ok {
+		delete(m.entries, name)
+		for i, opened := range m.opened {
+			if opened == name {
+				m.opened = append(m.opened[:i], m.opened[i+1:]...)
+				break
+			}
+		}
+	}
+	m.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.resource == nil {
+		return nil
+	}
+	err := e.resource.Close()
+	e.resource = nil
+	if err != nil {
+		return fmt.Errorf("failed to close %s integration: %w", name, err)
+	}
+	logrus.WithField("integration", name).Info("Integration closed")
+	return nil
+}
+
+// Has reports whether an integration is registered under name
+func (m *Manager) Has(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.entries[name]
+	return ok
+}
+
+// Names returns the names of the registered integrations, sorted
+func (m *Manager) Names() []string {
+	m.mu.Lock()
+	names := make([]string, 0, len(m.entries))
+	for name := range m.entries {
+		names = append(names, name)
+	}
+	m.mu.Unlock()
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the named integration, opening it on first use
+// Concurrent callers share a single open; a failed open is retried by the next caller
+func (m *Manager) Get(ctx context.Context, name string) (Resource, error) {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil, ErrManagerClosed
+	}
+	e, ok := m.entries[name]
+	m.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownIntegration, name)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.resource != nil {
+		return e.resource, nil
+	}
+
+	resource, err := e.open(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s integration: %w", name, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		resource.Close()
+		return nil, ErrManagerClosed
+	}
+	if m.entries[name] != e {
+		// Removed or replaced while opening
+		resource.Close()
+		return nil, fmt.Errorf("%w: %s", ErrUnknownIntegration, name)
+	}
+	e.resource = resource
+	m.opened = append(m.opened, name)
+
+	logrus.WithField("integration", name).Info("Integration opened")
+	return resource, nil
+}
+
+// Probe opens the named integration if it isn't open yet and pings it
+// Unlike Check it reaches integrations no run has used yet; a failed ping closes the integration
+func (m *Manager) Probe(ctx context.Context, name string) error {
+	if _, err := m.Get(ctx, name); err != nil {
+		return err
+	}
+	if status := m.check(ctx, name); status.Status == StatusUnhealthy {
+		return errors.New(status.Error)
+	}
+	return nil
+}
+
+// Check pings every opened integration
+// Unhealthy integrations are closed so the next Get reconnects
+func (m *Manager) Check(ctx context.Context) map[string]Status {
+	names := m.Names()
+
+	statuses := make(map[string]Status, len(names))
+	for _, name := range names {
+		statuses[name] = m.check(ctx, name)
+	}
+	return statuses
+}
+
+// check pings one integration, dropping its resource if the ping fails
+func (m *Manager) check(ctx context.Context, name string) Status {
+	m.mu.Lock()
+	e := m.entries[name]
+	m.mu.Unlock()
+	if e == nil {
+		// Removed since it was listed
+		return Status{Status: StatusIdle}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.resource == nil {
+		return Status{Status: StatusIdle}
+	}
+
+	if err := e.resource.Ping(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"integration": name,
+			"error":       err,
+		}).Warn("Integration health check failed - reconnecting on next use")
+
+		if closeErr := e.resource.Close(); closeErr != nil {
+			logrus.WithField("integration", name).WithError(closeErr).Warn("Failed to close unhealthy integration")
+		}
+		e.resource = nil
+		m.forget(name)
+		return Status{Status: StatusUnhealthy, Error: err.Error()}
+	}
+	return Status{Status: StatusHealthy}
+}
+
+// forget removes name from the opened list
+func (m *Manager) forget(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, opened := range m.opened {
+		if opened == name {
+			m.opened = append(m.opened[:i], m.opened[i+1:]...)
+			return
+		}
+	}
+}
+
+// Close closes every opened integration, most recently opened first, and returns the first error
+// Integrations can't be used after Close
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil
+	}
+	m.closed = true
+	opened := make([]*entry, len(m.opened))
+	for i, name := range m.opened {
+		opened[i] = m.entries[name]
+	}
+	names := m.opened
+	m.opened = nil
+	m.mu.Unlock()
+
+	var firstErr error
+	for i := len(opened) - 1; i >= 0; i-- {
+		name, e := names[i], opened[i]
+
+		e.mu.Lock()
+		if e.resource != nil {
+			if err := e.resource.Close(); err != nil {
+				logrus.WithField("integration", name).WithError(err).Warn("Failed to close integration")
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to close %s integration: %w", name, err)
+				}
+			}
+			e.resource = nil
+		}
+		e.mu.Unlock()
+	}
+	return firstErr
+}
+
+// NewFromConfig creates a manager with the integrations configured for this instance
+// The SMTP pool is registered when SMTP_HOST is set
+func NewFromConfig(cfg *config.Config) *Manager {
+	manager := NewManager()
+	if cfg.Notifications.SMTPHost != "" {
+		manager.Register(SMTPIntegration, OpenSMTPPool(SMTPConfig{
+			Host:     cfg.Notifications.SMTPHost,
+			Port:     cfg.Notifications.SMTPPort,
+			Username: cfg.Notifications.SMTPUsername,
+			Password: cfg.Notifications.SMTPPassword,
+			PoolSize: cfg.Notifications.SMTPPoolSize,
+		}))
+	}
+	return manager
+}
@@ -0,0 +1,209 @@
+package integrations
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SMTPIntegration is the name the SMTP pool is registered under
+const SMTPIntegration = "smtp"
+
+// SMTPConfig configures an SMTP connection pool
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// PoolSize is how many idle connections are kept open for reuse
+	PoolSize int
+}
+
+// MailSender sends raw email messages
+type MailSender interface {
+	Send(ctx context.Context, from string, to []string, message []byte) error
+}
+
+// SMTPPool keeps SMTP connections open between messages
+type SMTPPool struct {
+	addr string
+	host string
+	auth smtp.Auth
+	size int
+
+	mu     sync.Mutex
+	idle   []*smtp.Client
+	closed bool
+}
+
+// NewSMTPPool creates an SMTP pool; connections are dialled when first needed
+func NewSMTPPool(cfg SMTPConfig) *SMTPPool {
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	size := cfg.PoolSize
+	if size < 1 {
+		size = 1
+	}
+
+	return &SMTPPool{
+		addr: net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		host: cfg.Host,
+		auth: auth,
+		size: size,
+	}
+}
+
+// OpenSMTPPool returns an OpenFunc that connects an SMTP pool and checks the server answers
+func OpenSMTPPool(cfg SMTPConfig) OpenFunc {
+	return func(ctx context.Context) (Resource, error) {
+		pool := NewSMTPPool(cfg)
+		if err := pool.Ping(ctx); err != nil {
+			pool.Close()
+			return nil, err
+		}
+		return pool, nil
+	}
+}
+
+// Send delivers a message on a pooled connection
+// Connections that fail mid-message are dropped rather than returned to the pool
+func (p *SMTPPool) Send(ctx context.Context, from string, to []string, message []byte) error {
+	client, err := p.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := send(client, from, to, message); err != nil {
+		client.Close()
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	p.put(client)
+	return nil
+}
+
+// send writes one message on the connection
+func send(client *smtp.Client, from string, to []string, message []byte) error {
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(message); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// Ping checks a connection with NOOP
+func (p *SMTPPool) Ping(ctx context.Context) error {
+	client, err := p.get(ctx)
+	if err != nil {
+		return err
+	}
+	if err := client.Noop(); err != nil {
+		client.Close()
+		return fmt.Errorf("smtp server did not answer: %w", err)
+	}
+	p.put(client)
+	return nil
+}
+
+// Close quits every idle connection; connections in use are closed when returned
+func (p *SMTPPool) Close() error {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.closed = true
+	p.mu.Unlock()
+
+	var firstErr error
+	for _, client := range idle {
+		if err := client.Quit(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// get reuses an idle connection that still answers, or dials a new one
+func (p *SMTPPool) get(ctx context.Context) (*smtp.Client, error) {
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, errors.New("smtp pool is closed")
+		}
+		if len(p.idle) == 0 {
+			p.mu.Unlock()
+			return p.dial(ctx)
+		}
+		client := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		p.mu.Unlock()
+
+		// The server may have dropped an idle connection
+		if err := client.Reset(); err == nil {
+			return client, nil
+		}
+		client.Close()
+	}
+}
+
+// put returns a connection to the pool, quitting it when the pool is full or closed
+func (p *SMTPPool) put(client *smtp.Client) {
+	p.mu.Lock()
+	if !p.closed && len(p.idle) < p.size {
+		p.idle = append(p.idle, client)
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+	client.Quit()
+}
+
+// dial opens a connection, upgrading to TLS and authenticating when the server supports it
+func (p *SMTPPool) dial(ctx context.Context) (*smtp.Client, error) {
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", p.addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, p.host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to start smtp session: %w", err)
+	}
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: p.host, MinVersion: tls.VersionTLS12}); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+	if p.auth != nil {
+		if ok, _ := client.Extension("AUTH"); ok {
+			if err := client.Auth(p.auth); err != nil {
+				client.Close()
+				return nil, fmt.Errorf("smtp authentication failed: %w", err)
+			}
+		}
+	}
+	return client, nil
+}
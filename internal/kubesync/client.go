@@ -0,0 +1,242 @@
+package kubesync
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"job-scheduler/internal/config"
+)
+
+// requestTimeout bounds each request other than watches
+const requestTimeout = 30 * time.Second
+
+// ErrResourceVersionExpired is returned when a watch starts from a version the API server no longer
+// has, so the resources must be listed again
+var ErrResourceVersionExpired = errors.New("resource version expired")
+
+// Client reads and writes ScheduledJob resources through the Kubernetes API
+type Client interface {
+	// List returns every ScheduledJob, and the version of the list to watch from
+	List(ctx context.Context) (*ScheduledJobList, error)
+	// Watch passes the changes after resourceVersion to fn until the API server ends the watch after
+	// timeout, fn returns an error, or ctx is done
+	Watch(ctx context.Context, resourceVersion string, timeout time.Duration, fn func(WatchEvent) error) error
+	// UpdateStatus writes a ScheduledJob's status
+	UpdateStatus(ctx context.Context, sj *ScheduledJob) error
+	// SetFinalizers replaces a ScheduledJob's finalizers, failing if it changed since it was read
+	SetFinalizers(ctx context.Context, sj *ScheduledJob, finalizers []string) error
+}
+
+// apiClient implements Client over the API server's REST API
+type apiClient struct {
+	baseURL   string
+	namespace string
+	tokenFile string
+	// httpClient has no timeout, as watches stay open until the API server ends them; other requests
+	// are bounded by requestTimeout
+	httpClient *http.Client
+}
+
+// NewClient creates a client of the API server in the configuration, or of the cluster the service
+// runs in. Requests authenticate with the service account token, which is read for every request as
+// Kubernetes rotates it
+func NewClient(cfg config.KubernetesSyncConfig) (Client, error) {
+	baseURL := cfg.APIServer
+	if baseURL == "" {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if host == "" || port == "" {
+			return nil, fmt.Errorf("KUBERNETES_API_SERVER must be set when not running in a cluster")
+		}
+		baseURL = "https://" + net.JoinHostPort(host, port)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	caFile := filepath.Join(cfg.ServiceAccountDir, "ca.crt")
+	if ca, err := os.ReadFile(caFile); err == nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed to parse CA certificate %s", caFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	return &apiClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		namespace:  cfg.Namespace,
+		tokenFile:  filepath.Join(cfg.ServiceAccountDir, "token"),
+		httpClient: &http.Client{Transport: transport},
+	}, nil
+}
+
+// List returns every ScheduledJob in the namespace, or in all namespaces
+func (c *apiClient) List(ctx context.Context) (*ScheduledJobList, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	resp, err := c.send(ctx, http.MethodGet, c.collectionPath(), "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled jobs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var list ScheduledJobList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to decode scheduled jobs: %w", err)
+	}
+	return &list, nil
+}
+
+// Watch streams the changes after resourceVersion to fn
+func (c *apiClient) Watch(ctx context.Context, resourceVersion string, timeout time.Duration, fn func(WatchEvent) error) error {
+	query := url.Values{}
+	query.Set("watch", "true")
+	query.Set("resourceVersion", resourceVersion)
+	query.Set("allowWatchBookmarks", "true")
+	query.Set("timeoutSeconds", strconv.Itoa(int(timeout/time.Second)))
+
+	resp, err := c.send(ctx, http.MethodGet, c.collectionPath()+"?"+query.Encode(), "", nil)
+	if err != nil {
+		return fmt.Errorf("failed to watch scheduled jobs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var event WatchEvent
+		if err := decoder.Decode(&event); err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to read watch event: %w", err)
+		}
+		if event.Type == eventError {
+			return watchError(event)
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+}
+
+// watchError returns the error an ERROR watch event carries
+func watchError(event WatchEvent) error {
+	var status struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	_ = json.Unmarshal(event.Object, &status)
+	if status.Code == http.StatusGone {
+		return ErrResourceVersionExpired
+	}
+	return fmt.Errorf("watch failed (%d): %s", status.Code, status.Message)
+}
+
+// UpdateStatus writes a ScheduledJob's status through its status subresource
+func (c *apiClient) UpdateStatus(ctx context.Context, sj *ScheduledJob) error {
+	patch := map[string]interface{}{"status": sj.Status}
+	if err := c.patch(ctx, c.objectPath(sj)+"/status", patch); err != nil {
+		return fmt.Errorf("failed to update status of scheduled job %s/%s: %w", sj.Metadata.Namespace, sj.Metadata.Name, err)
+	}
+	return nil
+}
+
+// SetFinalizers replaces a ScheduledJob's finalizers
+// The patch carries the resource version it was read at, so it fails if the resource changed since
+func (c *apiClient) SetFinalizers(ctx context.Context, sj *ScheduledJob, finalizers []string) error {
+	if finalizers == nil {
+		finalizers = []string{}
+	}
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"finalizers":      finalizers,
+			"resourceVersion": sj.Metadata.ResourceVersion,
+		},
+	}
+	if err := c.patch(ctx, c.objectPath(sj), patch); err != nil {
+		return fmt.Errorf("failed to set finalizers of scheduled job %s/%s: %w", sj.Metadata.Namespace, sj.Metadata.Name, err)
+	}
+	return nil
+}
+
+// patch sends a JSON merge patch
+func (c *apiClient) patch(ctx context.Context, path string, patch interface{}) error {
+	body, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("failed to encode patch: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	resp, err := c.send(ctx, http.MethodPatch, path, "application/merge-patch+json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// collectionPath is the path of the ScheduledJobs the client syncs
+func (c *apiClient) collectionPath() string {
+	if c.namespace == "" {
+		return fmt.Sprintf("/apis/%s/%s/%s", Group, Version, Resource)
+	}
+	return fmt.Sprintf("/apis/%s/%s/namespaces/%s/%s", Group, Version, url.PathEscape(c.namespace), Resource)
+}
+
+// objectPath is the path of a ScheduledJob
+func (c *apiClient) objectPath(sj *ScheduledJob) string {
+	return fmt.Sprintf("/apis/%s/%s/namespaces/%s/%s/%s",
+		Group, Version, url.PathEscape(sj.Metadata.Namespace), Resource, url.PathEscape(sj.Metadata.Name))
+}
+
+// send sends a request, returning an error for responses that aren't successful
+// The caller must close the body of the response
+func (c *apiClient) send(ctx context.Context, method, path, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if token, err := os.ReadFile(c.tokenFile); err == nil {
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	var status struct {
+		Message string `json:"message"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err := json.Unmarshal(data, &status); err != nil || status.Message == "" {
+		status.Message = http.StatusText(resp.StatusCode)
+	}
+	if resp.StatusCode == http.StatusGone {
+		return nil, fmt.Errorf("%w: %s", ErrResourceVersionExpired, status.Message)
+	}
+	return nil, fmt.Errorf("%s %s returned %d: %s", method, path, resp.StatusCode, status.Message)
+}
@@ -0,0 +1,271 @@
+package kubesync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// errorBackoff is how long the controller waits after a failed list or watch
+const errorBackoff = 10 * time.Second
+
+// Controller reconciles ScheduledJob resources into jobs, and writes the jobs' status back
+// Each resource's job is created or updated to match its spec, through change control like an import,
+// and deleted when the resource is deleted
+type Controller struct {
+	client        Client
+	jobService    services.JobService
+	changeControl services.ChangeControlService
+	config        config.KubernetesSyncConfig
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewController creates a new ScheduledJob controller
+func NewController(
+	client Client,
+	jobService services.JobService,
+	changeControl services.ChangeControlService,
+	cfg *config.Config,
+) *Controller {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Controller{
+		client:        client,
+		jobService:    jobService,
+		changeControl: changeControl,
+		config:        cfg.KubernetesSync,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Start syncs every ScheduledJob, then keeps watching them in the background
+func (c *Controller) Start() error {
+	if !c.config.Enabled {
+		logrus.Info("Kubernetes sync disabled")
+		return nil
+	}
+
+	c.wg.Add(1)
+	go c.run()
+
+	logrus.WithField("namespace", c.config.Namespace).Info("Kubernetes sync started")
+	return nil
+}
+
+// Stop stops watching and waits for the resource being synced
+func (c *Controller) Stop() {
+	c.cancel()
+	c.wg.Wait()
+	logrus.Info("Kubernetes sync stopped")
+}
+
+// run lists and syncs every resource, then applies changes as they are watched until the watch ends
+// after the resync interval, and starts over
+func (c *Controller) run() {
+	defer c.wg.Done()
+
+	for c.ctx.Err() == nil {
+		resourceVersion, err := c.SyncAll(c.ctx)
+		if err == nil {
+			err = c.client.Watch(c.ctx, resourceVersion, c.config.ResyncInterval, c.handleEvent)
+		}
+		if err == nil || c.ctx.Err() != nil {
+			continue
+		}
+
+		if errors.Is(err, ErrResourceVersionExpired) {
+			logrus.Debug("Scheduled job watch expired - listing again")
+			continue
+		}
+		logrus.WithError(err).Error("Failed to sync scheduled jobs")
+		select {
+		case <-c.ctx.Done():
+		case <-time.After(errorBackoff):
+		}
+	}
+}
+
+// SyncAll syncs every ScheduledJob, returning the resource version to watch for changes from
+// A resource that fails to sync is logged and reported in its status, and doesn't stop the others
+func (c *Controller) SyncAll(ctx context.Context) (string, error) {
+	list, err := c.client.List(ctx)
+	if err != nil {
+		return "", err
+	}
+	for i := range list.Items {
+		c.sync(ctx, &list.Items[i])
+	}
+	return list.Metadata.ResourceVersion, nil
+}
+
+// handleEvent syncs the resource a watch event changed
+func (c *Controller) handleEvent(event WatchEvent) error {
+	switch event.Type {
+	case eventAdded, eventModified:
+		var sj ScheduledJob
+		if err := json.Unmarshal(event.Object, &sj); err != nil {
+			return fmt.Errorf("failed to decode scheduled job: %w", err)
+		}
+		c.sync(c.ctx, &sj)
+	case eventDeleted, eventBookmark:
+		// Jobs are deleted before their resource is removed, while it has the finalizer
+	}
+	return nil
+}
+
+// sync makes a resource's job match it and writes the result to its status
+func (c *Controller) sync(ctx context.Context, sj *ScheduledJob) {
+	log := logrus.WithFields(logrus.Fields{
+		"namespace": sj.Metadata.Namespace,
+		"name":      sj.Metadata.Name,
+	})
+
+	if sj.Metadata.DeletionTimestamp != nil {
+		if err := c.deleteJob(ctx, sj); err != nil {
+			log.WithError(err).Error("Failed to delete job of scheduled job")
+		}
+		return
+	}
+
+	// The finalizer is added before the job is created, so deleting the resource always deletes it
+	if !sj.hasFinalizer() {
+		finalizers := append(append([]string{}, sj.Metadata.Finalizers...), finalizer)
+		if err := c.client.SetFinalizers(ctx, sj, finalizers); err != nil {
+			log.WithError(err).Error("Failed to add finalizer to scheduled job")
+			return
+		}
+	}
+
+	status := ScheduledJobStatus{ObservedGeneration: sj.Metadata.Generation}
+	job, err := c.applyJob(sj)
+	switch {
+	case err != nil:
+		log.WithError(err).Warn("Failed to sync scheduled job")
+		status.Message = err.Error()
+	case job == nil:
+		status.Message = "change is waiting for a second approver"
+	default:
+		status.Synced = true
+	}
+	if job == nil {
+		// The job is reported as it is while the spec can't be applied
+		def := sj.definition()
+		job, _ = c.jobService.GetJobByName(def.Team, def.Name)
+	}
+	if job != nil {
+		status.JobID = job.ID.String()
+		status.State = string(job.State)
+		status.NextRunAt = job.NextRunAt
+		status.LastRunAt = job.LastRunAt
+	}
+
+	if status.equal(sj.Status) {
+		return
+	}
+	sj.Status = status
+	if err := c.client.UpdateStatus(ctx, sj); err != nil {
+		log.WithError(err).Error("Failed to update scheduled job status")
+	}
+}
+
+// applyJob creates or updates a resource's job to match its spec, as importing its definition would
+// It returns nil without an error when the update is waiting for a second approver
+func (c *Controller) applyJob(sj *ScheduledJob) (*models.Job, error) {
+	def := sj.definition()
+	manifest := &models.JobManifest{Version: models.JobManifestVersion, Jobs: []models.JobDefinition{def}}
+	items, err := c.jobService.PlanJobImport(manifest, false)
+	if err != nil {
+		return nil, err
+	}
+	def = manifest.Jobs[0]
+	item := items[0]
+
+	req := def.CreateRequest()
+	switch item.Action {
+	case models.JobImportFailed:
+		return nil, errors.New(item.Error)
+	case models.JobImportUnchanged:
+		return c.jobService.GetJobByName(def.Team, def.Name)
+	case models.JobImportCreate:
+		if req.Owner == "" {
+			req.Owner = c.config.Actor
+		}
+	case models.JobImportUpdate:
+		existing, err := c.jobService.GetJobByName(def.Team, def.Name)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			change, err := c.changeControl.ProposeUpdate(existing.ID, c.jobService.ReplacementRequest(existing, req), c.config.Actor)
+			if err != nil || change != nil {
+				return nil, err
+			}
+		}
+	}
+
+	req.ChangedBy = c.config.Actor
+	job, created, err := c.jobService.UpsertJobByName(req)
+	if err != nil {
+		return nil, err
+	}
+	logrus.WithFields(logrus.Fields{
+		"job_id":    job.ID,
+		"namespace": sj.Metadata.Namespace,
+		"name":      sj.Metadata.Name,
+		"created":   created,
+	}).Info("Job synced from scheduled job")
+	return job, nil
+}
+
+// deleteJob deletes a deleted resource's job, then removes the finalizer so the resource is removed
+// Deleting a protected job waits for a second approver, and the resource stays until it is approved
+func (c *Controller) deleteJob(ctx context.Context, sj *ScheduledJob) error {
+	if !sj.hasFinalizer() {
+		return nil
+	}
+
+	def := sj.definition()
+	job, err := c.jobService.GetJobByName(def.Team, def.Name)
+	if err != nil {
+		return err
+	}
+	if job != nil {
+		change, err := c.changeControl.ProposeDelete(job.ID, c.config.Actor)
+		if err != nil {
+			return err
+		}
+		if change != nil {
+			status := sj.Status
+			status.Message = "deletion is waiting for a second approver"
+			if !status.equal(sj.Status) {
+				sj.Status = status
+				return c.client.UpdateStatus(ctx, sj)
+			}
+			return nil
+		}
+		if err := c.jobService.DeleteJob(job.ID); err != nil {
+			return err
+		}
+	}
+
+	var finalizers []string
+	for _, f := range sj.Metadata.Finalizers {
+		if f != finalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	return c.client.SetFinalizers(ctx, sj, finalizers)
+}
@@ -0,0 +1,119 @@
+// Package kubesync keeps jobs in sync with ScheduledJob resources in a Kubernetes cluster, so jobs can
+// be managed with kubectl and GitOps tooling
+package kubesync
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"job-scheduler/internal/models"
+)
+
+// The ScheduledJob custom resource, defined by deploy/kubernetes/scheduledjob-crd.yaml
+const (
+	Group    = "job-scheduler.io"
+	Version  = "v1alpha1"
+	Kind     = "ScheduledJob"
+	Resource = "scheduledjobs"
+)
+
+// finalizer keeps a deleted ScheduledJob until its job has been deleted
+const finalizer = "job-scheduler.io/delete-job"
+
+// Watch event types
+const (
+	eventAdded    = "ADDED"
+	eventModified = "MODIFIED"
+	eventDeleted  = "DELETED"
+	eventBookmark = "BOOKMARK"
+	eventError    = "ERROR"
+)
+
+// ObjectMeta is the metadata of a resource that syncing reads and writes
+type ObjectMeta struct {
+	Name              string     `json:"name"`
+	Namespace         string     `json:"namespace,omitempty"`
+	UID               string     `json:"uid,omitempty"`
+	ResourceVersion   string     `json:"resourceVersion,omitempty"`
+	Generation        int64      `json:"generation,omitempty"`
+	DeletionTimestamp *time.Time `json:"deletionTimestamp,omitempty"`
+	Finalizers        []string   `json:"finalizers,omitempty"`
+}
+
+// ScheduledJob declares a job in a cluster
+// The spec takes the settings of a job manifest entry. name defaults to the resource's name and team
+// to its namespace
+type ScheduledJob struct {
+	APIVersion string               `json:"apiVersion,omitempty"`
+	Kind       string               `json:"kind,omitempty"`
+	Metadata   ObjectMeta           `json:"metadata"`
+	Spec       models.JobDefinition `json:"spec"`
+	Status     ScheduledJobStatus   `json:"status,omitempty"`
+}
+
+// ScheduledJobStatus reports the job a ScheduledJob was synced to
+type ScheduledJobStatus struct {
+	// ObservedGeneration is the generation of the spec last synced
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Synced is true when the job matches the spec
+	Synced bool `json:"synced"`
+	// Message says why the job doesn't match the spec, such as an invalid setting or a change waiting
+	// for a second approver
+	Message   string     `json:"message,omitempty"`
+	JobID     string     `json:"jobId,omitempty"`
+	State     string     `json:"state,omitempty"`
+	NextRunAt *time.Time `json:"nextRunAt,omitempty"`
+	LastRunAt *time.Time `json:"lastRunAt,omitempty"`
+}
+
+// equal reports whether two statuses say the same, ignoring how their times are represented
+func (s ScheduledJobStatus) equal(other ScheduledJobStatus) bool {
+	return s.ObservedGeneration == other.ObservedGeneration && s.Synced == other.Synced &&
+		s.Message == other.Message && s.JobID == other.JobID && s.State == other.State &&
+		sameTime(s.NextRunAt, other.NextRunAt) && sameTime(s.LastRunAt, other.LastRunAt)
+}
+
+// sameTime compares optional times to the second, the precision Kubernetes stores them at
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Truncate(time.Second).Equal(b.Truncate(time.Second))
+}
+
+// definition returns the job the resource declares
+func (sj *ScheduledJob) definition() models.JobDefinition {
+	def := sj.Spec
+	if def.Name == "" {
+		def.Name = sj.Metadata.Name
+	}
+	if strings.TrimSpace(def.Team) == "" {
+		def.Team = sj.Metadata.Namespace
+	}
+	return def
+}
+
+// hasFinalizer reports whether the resource waits for its job to be deleted before it is removed
+func (sj *ScheduledJob) hasFinalizer() bool {
+	for _, f := range sj.Metadata.Finalizers {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// ScheduledJobList is a page of ScheduledJob resources
+type ScheduledJobList struct {
+	Metadata struct {
+		ResourceVersion string `json:"resourceVersion"`
+	} `json:"metadata"`
+	Items []ScheduledJob `json:"items"`
+}
+
+// WatchEvent is a change to a ScheduledJob streamed by a watch
+type WatchEvent struct {
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object"`
+}
@@ -0,0 +1,97 @@
+package logship
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"job-scheduler/internal/config"
+)
+
+// ElasticsearchSink indexes entries as documents through Elasticsearch's bulk API
+type ElasticsearchSink struct {
+	url        string
+	index      string
+	service    string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// NewElasticsearchSink creates a new Elasticsearch sink
+func NewElasticsearchSink(cfg config.LogShippingConfig, httpClient *http.Client) *ElasticsearchSink {
+	return &ElasticsearchSink{
+		url:        cfg.URL + "/_bulk",
+		index:      cfg.Index,
+		service:    cfg.Service,
+		username:   cfg.Username,
+		password:   cfg.Password,
+		httpClient: httpClient,
+	}
+}
+
+// Send indexes a batch with a single bulk request
+func (s *ElasticsearchSink) Send(ctx context.Context, entries []Entry) error {
+	action, err := json.Marshal(map[string]interface{}{
+		"index": map[string]string{"_index": s.index},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode bulk action: %w", err)
+	}
+
+	var body bytes.Buffer
+	for _, entry := range entries {
+		doc := entry.Document()
+		delete(doc, "time")
+		doc["@timestamp"] = entry.Time.UTC().Format(time.RFC3339Nano)
+		doc["service"] = s.service
+
+		source, err := json.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("failed to encode log entry: %w", err)
+		}
+		body.Write(action)
+		body.WriteByte('\n')
+		body.Write(source)
+		body.WriteByte('\n')
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, &body)
+	if err != nil {
+		return fmt.Errorf("failed to create Elasticsearch request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if s.username != "" {
+		req.SetBasicAuth(s.username, s.password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send bulk request to Elasticsearch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("elasticsearch returned status %d", resp.StatusCode)
+	}
+
+	// A bulk request succeeds as a whole even when some documents are rejected
+	var result struct {
+		Errors bool `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode bulk response: %w", err)
+	}
+	if result.Errors {
+		return fmt.Errorf("elasticsearch rejected some log entries")
+	}
+	return nil
+}
+
+// Close has nothing to release; the HTTP client is shared
+func (s *ElasticsearchSink) Close() error {
+	return nil
+}
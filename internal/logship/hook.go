@@ -0,0 +1,232 @@
+// Package logship forwards structured logs to a central logging backend
+// (Loki, Elasticsearch or syslog) through a logrus hook that batches entries
+// in the background, so logging never waits on the network
+package logship
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/config"
+)
+
+// Entry is a log entry waiting to be shipped
+type Entry struct {
+	Time    time.Time
+	Level   logrus.Level
+	Message string
+	Fields  map[string]interface{}
+}
+
+// Document returns the entry as a flat JSON-ready document: its fields plus time, level and msg
+func (e Entry) Document() map[string]interface{} {
+	doc := make(map[string]interface{}, len(e.Fields)+3)
+	for key, value := range e.Fields {
+		doc[key] = value
+	}
+	doc["time"] = e.Time.Format(time.RFC3339Nano)
+	doc["level"] = e.Level.String()
+	doc["msg"] = e.Message
+	return doc
+}
+
+// Sink sends batches of entries to a logging backend
+type Sink interface {
+	// Send delivers a batch; the batch must not be retained after Send returns
+	Send(ctx context.Context, entries []Entry) error
+	// Close releases the sink's connections
+	Close() error
+}
+
+// Stats counts what the hook has done with the entries it received
+type Stats struct {
+	Shipped uint64 `json:"shipped"`
+	// Dropped entries arrived while the buffer was full
+	Dropped uint64 `json:"dropped"`
+	// Failed entries were in batches the backend didn't accept
+	Failed uint64 `json:"failed"`
+}
+
+// HookOptions tune how a hook batches entries
+type HookOptions struct {
+	Level         logrus.Level
+	BatchSize     int
+	FlushInterval time.Duration
+	BufferSize    int
+	Timeout       time.Duration
+}
+
+// Hook is a logrus hook that ships entries to a Sink in batches
+// Entries are queued in a bounded buffer; when the backend falls behind and the buffer fills,
+// new entries are dropped and counted rather than blocking the goroutine that logged them
+type Hook struct {
+	sink    Sink
+	opts    HookOptions
+	entries chan Entry
+	done    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+
+	shipped uint64
+	dropped uint64
+	failed  uint64
+}
+
+// NewHook creates a hook shipping to sink and starts its background sender
+func NewHook(sink Sink, opts HookOptions) *Hook {
+	h := &Hook{
+		sink:    sink,
+		opts:    opts,
+		entries: make(chan Entry, opts.BufferSize),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go h.run()
+	return h
+}
+
+// NewHookFromConfig creates the hook selected by LOG_SHIPPING_BACKEND
+// It returns nil when log shipping is disabled
+func NewHookFromConfig(cfg config.LogShippingConfig) (*Hook, error) {
+	if cfg.Backend == "" {
+		return nil, nil
+	}
+	if cfg.URL == "" {
+		return nil, errors.New("LOG_SHIPPING_URL is required for log shipping")
+	}
+
+	level, err := logrus.ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOG_SHIPPING_LEVEL: %w", err)
+	}
+
+	httpClient := &http.Client{Timeout: cfg.Timeout}
+	var sink Sink
+	switch cfg.Backend {
+	case "loki":
+		sink = NewLokiSink(cfg, httpClient)
+	case "elasticsearch":
+		sink = NewElasticsearchSink(cfg, httpClient)
+	case "syslog":
+		sink, err = NewSyslogSink(cfg)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown log shipping backend: %s", cfg.Backend)
+	}
+
+	return NewHook(sink, HookOptions{
+		Level:         level,
+		BatchSize:     cfg.BatchSize,
+		FlushInterval: cfg.FlushInterval,
+		BufferSize:    cfg.BufferSize,
+		Timeout:       cfg.Timeout,
+	}), nil
+}
+
+// Levels returns the levels shipped: the configured level and everything more severe
+func (h *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels[:h.opts.Level+1]
+}
+
+// Fire queues an entry for shipping, dropping it if the buffer is full
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	fields := make(map[string]interface{}, len(entry.Data))
+	for key, value := range entry.Data {
+		// Errors marshal to {} otherwise
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		fields[key] = value
+	}
+
+	select {
+	case <-h.done:
+		atomic.AddUint64(&h.dropped, 1)
+	case h.entries <- Entry{Time: entry.Time, Level: entry.Level, Message: entry.Message, Fields: fields}:
+	default:
+		atomic.AddUint64(&h.dropped, 1)
+	}
+	return nil
+}
+
+// Stats returns how many entries have been shipped, dropped and failed
+func (h *Hook) Stats() Stats {
+	return Stats{
+		Shipped: atomic.LoadUint64(&h.shipped),
+		Dropped: atomic.LoadUint64(&h.dropped),
+		Failed:  atomic.LoadUint64(&h.failed),
+	}
+}
+
+// Close sends the entries still buffered and closes the sink
+// Entries logged after Close are dropped
+func (h *Hook) Close() error {
+	h.once.Do(func() {
+		close(h.done)
+	})
+	<-h.stopped
+	return h.sink.Close()
+}
+
+// run batches queued entries, sending a batch when it is full or FlushInterval has passed
+func (h *Hook) run() {
+	defer close(h.stopped)
+
+	ticker := time.NewTicker(h.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Entry, 0, h.opts.BatchSize)
+	for {
+		select {
+		case entry := <-h.entries:
+			batch = append(batch, entry)
+			if len(batch) >= h.opts.BatchSize {
+				batch = h.flush(batch)
+			}
+		case <-ticker.C:
+			batch = h.flush(batch)
+		case <-h.done:
+			for {
+				select {
+				case entry := <-h.entries:
+					batch = append(batch, entry)
+					if len(batch) >= h.opts.BatchSize {
+						batch = h.flush(batch)
+					}
+				default:
+					h.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush sends a batch and returns an empty batch to fill next
+// Failures are reported on stderr, since logging them would feed them back into the hook
+func (h *Hook) flush(batch []Entry) []Entry {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.opts.Timeout)
+	defer cancel()
+
+	if err := h.sink.Send(ctx, batch); err != nil {
+		atomic.AddUint64(&h.failed, uint64(len(batch)))
+		fmt.Fprintf(os.Stderr, "log shipping: failed to send %d entries: %v\n", len(batch), err)
+	} else {
+		atomic.AddUint64(&h.shipped, uint64(len(batch)))
+	}
+	return batch[:0]
+}
@@ -0,0 +1,101 @@
+package logship
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"job-scheduler/internal/config"
+)
+
+// LokiSink pushes entries to Loki's push API, one stream per level
+type LokiSink struct {
+	url        string
+	service    string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// NewLokiSink creates a new Loki sink
+func NewLokiSink(cfg config.LogShippingConfig, httpClient *http.Client) *LokiSink {
+	return &LokiSink{
+		url:        cfg.URL + "/loki/api/v1/push",
+		service:    cfg.Service,
+		username:   cfg.Username,
+		password:   cfg.Password,
+		httpClient: httpClient,
+	}
+}
+
+// lokiStream is a set of log lines sharing the same labels
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// Send pushes a batch, labelled with the service and level; the other fields stay in the JSON line
+// so they don't multiply Loki's streams
+func (s *LokiSink) Send(ctx context.Context, entries []Entry) error {
+	streams := make(map[string]*lokiStream)
+	var order []string
+	for _, entry := range entries {
+		level := entry.Level.String()
+		stream, ok := streams[level]
+		if !ok {
+			stream = &lokiStream{Stream: map[string]string{"service": s.service, "level": level}}
+			streams[level] = stream
+			order = append(order, level)
+		}
+
+		line, err := json.Marshal(entry.Document())
+		if err != nil {
+			return fmt.Errorf("failed to encode log entry: %w", err)
+		}
+		stream.Values = append(stream.Values, [2]string{strconv.FormatInt(entry.Time.UnixNano(), 10), string(line)})
+	}
+
+	payload := struct {
+		Streams []*lokiStream `json:"streams"`
+	}{}
+	for _, level := range order {
+		payload.Streams = append(payload.Streams, streams[level])
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode Loki push: %w", err)
+	}
+	return s.post(ctx, body)
+}
+
+// post sends a push request
+func (s *LokiSink) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Loki request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.username != "" {
+		req.SetBasicAuth(s.username, s.password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to push to Loki: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("loki returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Close has nothing to release; the HTTP client is shared
+func (s *LokiSink) Close() error {
+	return nil
+}
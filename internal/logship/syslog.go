@@ -0,0 +1,145 @@
+package logship
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/config"
+)
+
+// syslogFacility is the local0 facility entries are logged under
+const syslogFacility = 16
+
+// SyslogSink writes entries as RFC 5424 messages to a syslog endpoint over UDP or TCP
+type SyslogSink struct {
+	network  string
+	address  string
+	appName  string
+	hostname string
+	mu       sync.Mutex
+	conn     net.Conn
+}
+
+// NewSyslogSink creates a new syslog sink for a udp://host:port or tcp://host:port URL
+// The connection is opened on the first send
+func NewSyslogSink(cfg config.LogShippingConfig) (*SyslogSink, error) {
+	parsed, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOG_SHIPPING_URL: %w", err)
+	}
+	if parsed.Scheme != "udp" && parsed.Scheme != "tcp" {
+		return nil, fmt.Errorf("syslog LOG_SHIPPING_URL must be udp:// or tcp://, got %s", cfg.URL)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+
+	return &SyslogSink{
+		network:  parsed.Scheme,
+		address:  parsed.Host,
+		appName:  cfg.Service,
+		hostname: hostname,
+	}, nil
+}
+
+// Send writes each entry as a syslog message, reconnecting once if the connection was lost
+func (s *SyslogSink) Send(ctx context.Context, entries []Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, entry := range entries {
+		message, err := s.format(entry)
+		if err != nil {
+			return err
+		}
+		if err := s.write(ctx, message); err != nil {
+			s.closeConn()
+			if err := s.write(ctx, message); err != nil {
+				s.closeConn()
+				return fmt.Errorf("failed to write to syslog: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// write sends one message, dialling the endpoint first if needed
+func (s *SyslogSink) write(ctx context.Context, message []byte) error {
+	if s.conn == nil {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, s.network, s.address)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		s.conn.SetWriteDeadline(deadline)
+	}
+	_, err := s.conn.Write(message)
+	return err
+}
+
+// format renders an entry as an RFC 5424 message, framed by octet counting over TCP
+func (s *SyslogSink) format(entry Entry) ([]byte, error) {
+	doc, err := json.Marshal(entry.Document())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode log entry: %w", err)
+	}
+
+	message := fmt.Sprintf("<%d>1 %s %s %s %d - - %s",
+		syslogFacility*8+syslogSeverity(entry.Level),
+		entry.Time.UTC().Format(time.RFC3339Nano),
+		s.hostname, s.appName, os.Getpid(), doc)
+
+	if s.network == "tcp" {
+		message = strconv.Itoa(len(message)) + " " + message
+	}
+	return []byte(message), nil
+}
+
+// closeConn drops the connection so the next write redials
+func (s *SyslogSink) closeConn() {
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+// Close closes the connection to the syslog endpoint
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeConn()
+	return nil
+}
+
+// syslogSeverity maps a logrus level to a syslog severity
+func syslogSeverity(level logrus.Level) int {
+	switch level {
+	case logrus.PanicLevel:
+		return 0 // emerg
+	case logrus.FatalLevel:
+		return 2 // crit
+	case logrus.ErrorLevel:
+		return 3 // err
+	case logrus.WarnLevel:
+		return 4 // warning
+	case logrus.InfoLevel:
+		return 6 // info
+	default:
+		return 7 // debug
+	}
+}
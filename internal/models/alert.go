@@ -0,0 +1,127 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AlertMetric is the measure of a job's recent runs that an alert rule checks
+type AlertMetric string
+
+const (
+	// AlertMetricFailureRate is the percentage of recent finished runs that failed or stalled
+	AlertMetricFailureRate AlertMetric = "failure_rate"
+	// AlertMetricLateness is the average start delay of recent scheduled runs, in seconds
+	AlertMetricLateness AlertMetric = "lateness"
+	// AlertMetricDuration is the average duration of recent runs, in seconds
+	AlertMetricDuration AlertMetric = "duration"
+)
+
+const (
+	// DefaultAlertRuleWindow is how many recent finished runs a rule evaluates unless it sets a window
+	DefaultAlertRuleWindow = 20
+	// MaxAlertRuleWindow is the largest window a rule may set
+	MaxAlertRuleWindow = 200
+	// DefaultAlertSeverity is the severity label of alerts from rules that don't set one
+	DefaultAlertSeverity = "warning"
+)
+
+// AlertRule fires an alert for each matching job whose metric is above the threshold
+type AlertRule struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// Name becomes the alertname label of the rule's alerts
+	Name        string `json:"name" gorm:"not null;size:100;uniqueIndex"`
+	Description string `json:"description" gorm:"type:text"`
+
+	// Condition - the metric over the job's last Window finished runs must not exceed Threshold
+	Metric    AlertMetric `json:"metric" gorm:"not null;size:30"`
+	Threshold float64     `json:"threshold" gorm:"not null"`
+	Window    int         `json:"window" gorm:"column:window_runs;not null;default:20"`
+
+	// Selector - a single job, a team's jobs, or every active job when both are empty
+	JobID *uuid.UUID `json:"job_id,omitempty" gorm:"type:uuid"`
+	Team  string     `json:"team,omitempty" gorm:"size:100"`
+
+	// Severity is the severity label of the rule's alerts
+	Severity string `json:"severity" gorm:"size:20;default:'warning'"`
+
+	// Status
+	IsActive bool `json:"is_active" gorm:"default:true"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating an alert rule
+func (r *AlertRule) BeforeCreate(tx *gorm.DB) error {
+	// Generate UUID if not provided
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the AlertRule model
+func (AlertRule) TableName() string {
+	return "alert_rules"
+}
+
+// Matches returns true if the rule applies to the job
+func (r *AlertRule) Matches(job *Job) bool {
+	if r.JobID != nil && *r.JobID != job.ID {
+		return false
+	}
+	if r.Team != "" && r.Team != job.Team {
+		return false
+	}
+	return true
+}
+
+// IsValidAlertMetric checks if the alert metric is supported
+func IsValidAlertMetric(metric string) bool {
+	switch AlertMetric(metric) {
+	case AlertMetricFailureRate, AlertMetricLateness, AlertMetricDuration:
+		return true
+	default:
+		return false
+	}
+}
+
+// CreateAlertRuleRequest represents the request payload for creating an alert rule
+type CreateAlertRuleRequest struct {
+	Name        string      `json:"name" validate:"required"`
+	Description string      `json:"description"`
+	Metric      AlertMetric `json:"metric" validate:"required"`
+	Threshold   float64     `json:"threshold"`
+	Window      int         `json:"window"` // Defaults to 20 runs
+	JobID       *uuid.UUID  `json:"job_id"`
+	Team        string      `json:"team"`
+	Severity    string      `json:"severity"` // Defaults to warning
+	IsActive    *bool       `json:"is_active"`
+}
+
+// UpdateAlertRuleRequest represents the request payload for updating an alert rule
+type UpdateAlertRuleRequest struct {
+	Description *string      `json:"description"`
+	Metric      *AlertMetric `json:"metric"`
+	Threshold   *float64     `json:"threshold"`
+	Window      *int         `json:"window"`
+	JobID       *uuid.UUID   `json:"job_id"`
+	Team        *string      `json:"team"`
+	Severity    *string      `json:"severity"`
+	IsActive    *bool        `json:"is_active"`
+}
+
+// Alert is a firing or resolved alert, in the format of Alertmanager's v2 API
+type Alert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	StartsAt    time.Time         `json:"startsAt"`
+	// EndsAt is set once the alert has resolved
+	EndsAt *time.Time `json:"endsAt,omitempty"`
+}
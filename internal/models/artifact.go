@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ArtifactKind is the kind of output an artifact holds
+type ArtifactKind string
+
+const (
+	ArtifactKindReport ArtifactKind = "report"
+	ArtifactKindExport ArtifactKind = "export"
+	ArtifactKindLog    ArtifactKind = "log"
+)
+
+// IsValidArtifactKind checks if the artifact kind is valid
+func IsValidArtifactKind(kind string) bool {
+	switch ArtifactKind(kind) {
+	case ArtifactKindReport, ArtifactKindExport, ArtifactKindLog:
+		return true
+	default:
+		return false
+	}
+}
+
+// Artifact is a file produced by a job execution, such as a report, export or log
+type Artifact struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// Foreign keys to Job and JobExecution
+	JobID       uuid.UUID `json:"job_id" gorm:"type:uuid;not null;index"`
+	ExecutionID uuid.UUID `json:"execution_id" gorm:"type:uuid;not null;index"`
+
+	// File details - StorageKey locates the file in the configured artifact store
+	Kind        ArtifactKind `json:"kind" gorm:"not null;size:20;default:'report'"`
+	Name        string       `json:"name" gorm:"not null;size:255"`
+	StorageKey  string       `json:"storage_key" gorm:"not null;size:500"`
+	ContentType string       `json:"content_type" gorm:"not null;size:100"`
+	SizeBytes   int64        `json:"size_bytes"`
+
+	// Retention - nil keeps the artifact forever
+	ExpiresAt *time.Time `json:"expires_at,omitempty" gorm:"index"`
+
+	// Metadata
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating an artifact
+func (a *Artifact) BeforeCreate(tx *gorm.DB) error {
+	// Generate UUID if not provided
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the Artifact model
+func (Artifact) TableName() string {
+	return "artifacts"
+}
@@ -0,0 +1,29 @@
+package models
+
+import "github.com/google/uuid"
+
+// ArtifactUsage is the storage used by one job's artifacts
+type ArtifactUsage struct {
+	JobID   uuid.UUID `json:"job_id"`
+	JobName string    `json:"job_name"`
+	Team    string    `json:"team,omitempty"`
+	Count   int64     `json:"count"`
+	Bytes   int64     `json:"bytes"`
+}
+
+// TeamArtifactUsage is the storage used by the artifacts of a team's jobs
+type TeamArtifactUsage struct {
+	Team  string `json:"team"`
+	Count int64  `json:"count"`
+	Bytes int64  `json:"bytes"`
+}
+
+// StorageUsage summarizes artifact storage against the configured quotas
+// A zero quota is unlimited
+type StorageUsage struct {
+	TotalBytes     int64               `json:"total_bytes"`
+	JobQuotaBytes  int64               `json:"job_quota_bytes"`
+	TeamQuotaBytes int64               `json:"team_quota_bytes"`
+	TopJobs        []ArtifactUsage     `json:"top_jobs"`
+	Teams          []TeamArtifactUsage `json:"teams"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditEntry records who did what to which entity
+type AuditEntry struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// Entity the action applies to
+	EntityType string    `json:"entity_type" gorm:"not null;size:50;index:idx_audit_entries_entity"`
+	EntityID   uuid.UUID `json:"entity_id" gorm:"type:uuid;not null;index:idx_audit_entries_entity"`
+
+	// Action and actor
+	Action  string    `json:"action" gorm:"not null;size:100"`
+	Actor   string    `json:"actor" gorm:"not null;size:255"`
+	Details JobConfig `json:"details,omitempty" gorm:"type:jsonb"`
+
+	// Metadata
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating an audit entry
+func (a *AuditEntry) BeforeCreate(tx *gorm.DB) error {
+	// Generate UUID if not provided
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the AuditEntry model
+func (AuditEntry) TableName() string {
+	return "audit_entries"
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql/driver"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CompressThreshold is the size in bytes above which CompressedText is stored compressed
+const CompressThreshold = 4 * 1024
+
+// compressedPrefix marks a stored value as base64-encoded gzip
+const compressedPrefix = "gzip:"
+
+// CompressedText is text that is stored gzip-compressed when it is larger than
+// CompressThreshold, so huge error messages and outputs don't bloat the table
+// It is decompressed when read, so callers and API responses see plain text
+type CompressedText string
+
+// NewCompressedText returns a pointer to a CompressedText holding s
+func NewCompressedText(s string) *CompressedText {
+	ct := CompressedText(s)
+	return &ct
+}
+
+// String returns the plain text
+func (ct CompressedText) String() string {
+	return string(ct)
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (ct CompressedText) Value() (driver.Value, error) {
+	if len(ct) <= CompressThreshold {
+		return string(ct), nil
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(ct)); err != nil {
+		return nil, fmt.Errorf("failed to compress text: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress text: %w", err)
+	}
+
+	return compressedPrefix + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (ct *CompressedText) Scan(value interface{}) error {
+	var stored string
+	switch v := value.(type) {
+	case nil:
+		*ct = ""
+		return nil
+	case string:
+		stored = v
+	case []byte:
+		stored = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into CompressedText", value)
+	}
+
+	if !strings.HasPrefix(stored, compressedPrefix) {
+		*ct = CompressedText(stored)
+		return nil
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(stored, compressedPrefix))
+	if err != nil {
+		return fmt.Errorf("failed to decode compressed text: %w", err)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return fmt.Errorf("failed to decompress text: %w", err)
+	}
+	defer reader.Close()
+
+	text, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("failed to decompress text: %w", err)
+	}
+
+	*ct = CompressedText(text)
+	return nil
+}
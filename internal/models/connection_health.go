@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// ConnectionStatus is the health of an external connection as seen by the connection probes
+type ConnectionStatus string
+
+const (
+	// ConnectionStatusUnknown is a connection that hasn't been probed, or hasn't failed enough to be down
+	ConnectionStatusUnknown ConnectionStatus = "unknown"
+	ConnectionStatusUp      ConnectionStatus = "up"
+	// ConnectionStatusDown is a connection whose last probes all failed; jobs using it fail fast
+	ConnectionStatusDown ConnectionStatus = "down"
+)
+
+// ConnectionHealth reports the probes of one external connection since startup
+type ConnectionHealth struct {
+	Name                string           `json:"name"`
+	Status              ConnectionStatus `json:"status"`
+	ConsecutiveFailures int              `json:"consecutive_failures"`
+	Probes              int64            `json:"probes"`
+	Failures            int64            `json:"failures"`
+	LastCheckedAt       *time.Time       `json:"last_checked_at,omitempty"`
+	LastLatencyMs       int64            `json:"last_latency_ms"`
+	LastError           string           `json:"last_error,omitempty"`
+	// ChangedAt is when the connection last went up or down
+	ChangedAt *time.Time `json:"changed_at,omitempty"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Dashboard is an on-call overview of the scheduler
+type Dashboard struct {
+	TotalJobs         int64                `json:"total_jobs"`
+	ActiveJobs        int                  `json:"active_jobs"`
+	RunningExecutions int                  `json:"running_executions"`
+	AwaitingApproval  int                  `json:"awaiting_approval"`
+	RecentFailures    []DashboardFailure   `json:"recent_failures"`
+	UnhealthiestJobs  []DashboardJobHealth `json:"unhealthiest_jobs"`
+	// Acknowledgments are the operator notes currently suppressing jobs' alerts
+	Acknowledgments []JobAcknowledgment `json:"acknowledgments"`
+	Storage         *StorageUsage       `json:"storage,omitempty"`
+}
+
+// DashboardFailure is a failed run together with the job's on-call documentation
+type DashboardFailure struct {
+	ExecutionID  uuid.UUID       `json:"execution_id"`
+	JobID        uuid.UUID       `json:"job_id"`
+	JobName      string          `json:"job_name"`
+	Severity     JobSeverity     `json:"severity"`
+	RunbookURL   string          `json:"runbook_url,omitempty"`
+	Docs         string          `json:"docs,omitempty"`
+	ErrorMessage *CompressedText `json:"error_message"`
+	FailedAt     *time.Time      `json:"failed_at"`
+	// Acknowledgment is the operator note on the job, if it is acknowledged
+	Acknowledgment *JobAcknowledgment `json:"acknowledgment,omitempty"`
+}
+
+// DashboardJobHealth is one of the jobs with the lowest health scores
+type DashboardJobHealth struct {
+	JobID       uuid.UUID   `json:"job_id"`
+	JobName     string      `json:"job_name"`
+	Severity    JobSeverity `json:"severity"`
+	Team        string      `json:"team,omitempty"`
+	HealthScore int         `json:"health_score"`
+	ScoredAt    *time.Time  `json:"scored_at"`
+}
+
+// FailingJob is a job whose latest runs have all failed since it last completed
+type FailingJob struct {
+	JobID          uuid.UUID       `json:"job_id"`
+	JobName        string          `json:"job_name"`
+	Team           string          `json:"team,omitempty"`
+	Owner          string          `json:"owner,omitempty"`
+	Severity       JobSeverity     `json:"severity"`
+	RunbookURL     string          `json:"runbook_url,omitempty"`
+	StreakLength   int             `json:"streak_length"`
+	FirstFailureAt time.Time       `json:"first_failure_at"`
+	LastFailureAt  time.Time       `json:"last_failure_at"`
+	LastError      *CompressedText `json:"last_error" gorm:"-"`
+	// Acknowledgment is the operator note on the job, if it is acknowledged
+	Acknowledgment *JobAcknowledgment `json:"acknowledgment,omitempty" gorm:"-"`
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DatabaseDriver is the kind of database a registered connection points at
+type DatabaseDriver string
+
+const (
+	// DatabaseDriverPostgres connects to PostgreSQL
+	DatabaseDriverPostgres DatabaseDriver = "postgres"
+)
+
+const (
+	// DefaultConnectionMaxOpenConns is how many connections a registered database opens unless it sets a limit
+	DefaultConnectionMaxOpenConns = 5
+	// DefaultConnectionMaxIdleConns is how many idle connections are kept unless it sets a limit
+	DefaultConnectionMaxIdleConns = 2
+	// DefaultConnectionMaxLifetimeSeconds is how long connections are reused unless it sets a lifetime
+	DefaultConnectionMaxLifetimeSeconds = 1800
+)
+
+// DatabaseConnection is a named external database that jobs reference by name in their config
+// (config.connection) instead of embedding a DSN. The password is never stored: PasswordSecret
+// references where it is read from when the connection is opened
+type DatabaseConnection struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// Name is what jobs reference the connection by
+	Name        string `json:"name" gorm:"not null;size:100;uniqueIndex"`
+	Description string `json:"description" gorm:"type:text"`
+
+	// Target - the DSN without credentials, e.g. postgres://reports-db:5432/reports?sslmode=require
+	Driver DatabaseDriver `json:"driver" gorm:"not null;size:20;default:'postgres'"`
+	DSN    string         `json:"dsn" gorm:"column:dsn;not null;type:text"`
+
+	// Credentials - PasswordSecret is a reference such as env:REPORTS_DB_PASSWORD or file:/run/secrets/reports-db
+	Username       string `json:"username,omitempty" gorm:"size:255"`
+	PasswordSecret string `json:"password_secret,omitempty" gorm:"size:255"`
+
+	// Pool limits
+	MaxOpenConns           int `json:"max_open_conns" gorm:"not null;default:5"`
+	MaxIdleConns           int `json:"max_idle_conns" gorm:"not null;default:2"`
+	ConnMaxLifetimeSeconds int `json:"conn_max_lifetime_seconds" gorm:"not null;default:1800"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating a database connection
+func (dc *DatabaseConnection) BeforeCreate(tx *gorm.DB) error {
+	// Generate UUID if not provided
+	if dc.ID == uuid.Nil {
+		dc.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the DatabaseConnection model
+func (DatabaseConnection) TableName() string {
+	return "database_connections"
+}
+
+// IsValidDatabaseDriver checks if the database driver is supported
+func IsValidDatabaseDriver(driver string) bool {
+	switch DatabaseDriver(driver) {
+	case DatabaseDriverPostgres:
+		return true
+	default:
+		return false
+	}
+}
+
+// CreateDatabaseConnectionRequest represents the request payload for registering a database connection
+type CreateDatabaseConnectionRequest struct {
+	Name                   string         `json:"name" validate:"required"`
+	Description            string         `json:"description"`
+	Driver                 DatabaseDriver `json:"driver"` // Defaults to postgres
+	DSN                    string         `json:"dsn" validate:"required"`
+	Username               string         `json:"username"`
+	PasswordSecret         string         `json:"password_secret"`
+	MaxOpenConns           int            `json:"max_open_conns"`            // Defaults to 5
+	MaxIdleConns           int            `json:"max_idle_conns"`            // Defaults to 2
+	ConnMaxLifetimeSeconds int            `json:"conn_max_lifetime_seconds"` // Defaults to 1800
+}
+
+// UpdateDatabaseConnectionRequest represents the request payload for updating a database connection
+type UpdateDatabaseConnectionRequest struct {
+	Description            *string `json:"description"`
+	DSN                    *string `json:"dsn"`
+	Username               *string `json:"username"`
+	PasswordSecret         *string `json:"password_secret"`
+	MaxOpenConns           *int    `json:"max_open_conns"`
+	MaxIdleConns           *int    `json:"max_idle_conns"`
+	ConnMaxLifetimeSeconds *int    `json:"conn_max_lifetime_seconds"`
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SuppressionReason is why email jobs no longer send to an address
+type SuppressionReason string
+
+const (
+	// SuppressionBounce means mail to the address bounced
+	SuppressionBounce SuppressionReason = "bounce"
+	// SuppressionComplaint means the recipient reported mail as spam
+	SuppressionComplaint SuppressionReason = "complaint"
+	// SuppressionUnsubscribe means the recipient unsubscribed
+	SuppressionUnsubscribe SuppressionReason = "unsubscribe"
+	// SuppressionManual means an operator suppressed the address
+	SuppressionManual SuppressionReason = "manual"
+)
+
+// EmailSuppression stops email jobs from sending to an address, such as one that bounced or unsubscribed
+type EmailSuppression struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// The suppressed address, normalized by NormalizeEmail
+	Email string `json:"email" gorm:"not null;size:320;uniqueIndex"`
+
+	// Why the address is suppressed and who suppressed it - a user or the email provider's events
+	Reason       SuppressionReason `json:"reason" gorm:"not null;size:20"`
+	Details      string            `json:"details,omitempty" gorm:"type:text"`
+	SuppressedBy string            `json:"suppressed_by" gorm:"not null;size:255"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating an email suppression
+func (s *EmailSuppression) BeforeCreate(tx *gorm.DB) error {
+	// Generate UUID if not provided
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the EmailSuppression model
+func (EmailSuppression) TableName() string {
+	return "email_suppressions"
+}
+
+// IsValidSuppressionReason checks if the suppression reason is valid
+func IsValidSuppressionReason(reason string) bool {
+	switch SuppressionReason(reason) {
+	case SuppressionBounce, SuppressionComplaint, SuppressionUnsubscribe, SuppressionManual:
+		return true
+	default:
+		return false
+	}
+}
+
+// NormalizeEmail returns the bare, lower-case address of an email such as "Ann <Ann@Example.com>",
+// so the same address always matches its suppression. ok is false if it isn't an address
+func NormalizeEmail(email string) (string, bool) {
+	address, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return "", false
+	}
+	return strings.ToLower(address.Address), true
+}
+
+// CreateEmailSuppressionRequest represents the request payload for suppressing an address
+type CreateEmailSuppressionRequest struct {
+	Email   string            `json:"email" validate:"required"`
+	Reason  SuppressionReason `json:"reason"` // Defaults to manual
+	Details string            `json:"details"`
+}
+
+// EmailEvent is an event posted by the email provider, in the SendGrid event webhook format
+// Bounces, drops, spam reports and unsubscribes suppress the address; other events are ignored
+type EmailEvent struct {
+	Event  string `json:"event"`
+	Email  string `json:"email"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// SuppressionReason returns why the event suppresses its address, or false if it doesn't
+func (e EmailEvent) SuppressionReason() (SuppressionReason, bool) {
+	switch strings.ToLower(e.Event) {
+	case "bounce", "bounced", "dropped":
+		return SuppressionBounce, true
+	case "spamreport", "complaint", "complained":
+		return SuppressionComplaint, true
+	case "unsubscribe", "unsubscribed", "group_unsubscribe":
+		return SuppressionUnsubscribe, true
+	default:
+		return "", false
+	}
+}
+
+// EmailSuppressionListResponse represents the response for listing suppressed addresses
+type EmailSuppressionListResponse struct {
+	Suppressions []EmailSuppression `json:"suppressions"`
+	TotalCount   int64              `json:"total_count"`
+	Page         int                `json:"page"`
+	Limit        int                `json:"limit"`
+	TotalPages   int                `json:"total_pages"`
+}
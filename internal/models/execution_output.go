@@ -0,0 +1,41 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// MaxExecutionOutputKeys is how many values a run may record; later keys are dropped
+	MaxExecutionOutputKeys = 50
+	// MaxExecutionOutputValueLength is the longest string value kept, such as a response body
+	MaxExecutionOutputValueLength = 4096
+)
+
+// ExecutionOutput is the structured output an executor recorded during a run, such as the report
+// it wrote, the rows it processed or the response it got, stored as JSONB
+type ExecutionOutput map[string]interface{}
+
+// Value implements the driver.Valuer interface for database storage
+func (o ExecutionOutput) Value() (driver.Value, error) {
+	if o == nil {
+		return nil, nil
+	}
+	return json.Marshal(o)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (o *ExecutionOutput) Scan(value interface{}) error {
+	if value == nil {
+		*o = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into ExecutionOutput", value)
+	}
+
+	return json.Unmarshal(bytes, o)
+}
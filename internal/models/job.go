@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,6 +19,7 @@ const (
 	JobTypeDataProcessing    JobType = "data_processing"
 	JobTypeReportGeneration  JobType = "report_generation"
 	JobTypeHealthCheck       JobType = "health_check"
+	JobTypeDBMaintenance     JobType = "db_maintenance"
 )
 
 // JobStatus represents the current status of a job
@@ -28,6 +30,100 @@ const (
 	JobStatusInactive JobStatus = "inactive"
 )
 
+// JobSeverity represents how urgent a failure of the job is for on-call engineers
+type JobSeverity string
+
+const (
+	JobSeverityLow      JobSeverity = "low"
+	JobSeverityMedium   JobSeverity = "medium"
+	JobSeverityHigh     JobSeverity = "high"
+	JobSeverityCritical JobSeverity = "critical"
+)
+
+// BackoffStrategy controls how the delay between retries of a failed run grows
+type BackoffStrategy string
+
+const (
+	BackoffFixed       BackoffStrategy = "fixed"
+	BackoffLinear      BackoffStrategy = "linear"
+	BackoffExponential BackoffStrategy = "exponential"
+)
+
+// ScheduleType controls whether a job recurs on a cron schedule or runs once
+type ScheduleType string
+
+const (
+	// ScheduleTypeCron runs the job on every occurrence of its cron schedule (default)
+	ScheduleTypeCron ScheduleType = "cron"
+	// ScheduleTypeOnce runs the job a single time, at its run_at, then completes it
+	ScheduleTypeOnce ScheduleType = "once"
+)
+
+// MisfirePolicy controls what happens to occurrences of a job missed while no scheduler was running
+type MisfirePolicy string
+
+const (
+	// MisfireIgnore skips missed occurrences; they are only recorded as missed
+	MisfireIgnore MisfirePolicy = "ignore"
+	// MisfireRunOnce runs the latest missed occurrence once on startup
+	MisfireRunOnce MisfirePolicy = "run_once_on_startup"
+	// MisfireRunAll runs every missed occurrence on startup, oldest first
+	MisfireRunAll MisfirePolicy = "run_all_missed"
+)
+
+// ConcurrencyPolicy controls what happens when a job's occurrence fires while its previous run is still going
+type ConcurrencyPolicy string
+
+const (
+	// ConcurrencyAllow starts the new run alongside the previous one (default)
+	ConcurrencyAllow ConcurrencyPolicy = "allow"
+	// ConcurrencyForbid skips the new run, recording it as skipped
+	ConcurrencyForbid ConcurrencyPolicy = "forbid"
+	// ConcurrencyReplace cancels the previous run and starts the new one
+	ConcurrencyReplace ConcurrencyPolicy = "replace"
+)
+
+const (
+	// MaxJobRetries is the most retries a job may configure
+	MaxJobRetries = 10
+	// MaxRetryDelay caps the delay before any retry
+	MaxRetryDelay = time.Hour
+)
+
+// JobSort orders a list of jobs
+type JobSort string
+
+const (
+	// JobSortNewest lists the most recently created jobs first (default)
+	JobSortNewest JobSort = "created_at"
+	// JobSortHealth lists the least healthy jobs first, then jobs not scored yet
+	JobSortHealth JobSort = "health"
+	// JobSortHealthDesc lists the healthiest jobs first, then jobs not scored yet
+	JobSortHealthDesc JobSort = "-health"
+	// JobSortName lists jobs by name, A to Z
+	JobSortName JobSort = "name"
+	// JobSortNextRun lists the jobs due to run soonest first, then jobs with no next run
+	JobSortNextRun JobSort = "next_run"
+)
+
+// JobFilter narrows a list of jobs; the zero value lists every job
+type JobFilter struct {
+	// States lists the jobs in any of these states
+	States []JobState
+	// Name lists the jobs whose names contain it, ignoring case
+	Name string
+	// JobType lists the jobs of the type
+	JobType JobType
+	// IsActive lists only active jobs when true, and only jobs in other states when false
+	IsActive *bool
+	// Tag lists the jobs with the tag
+	Tag string
+	// CreatedAfter and CreatedBefore list the jobs created in the range, from CreatedAfter up to but
+	// not including CreatedBefore
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
 // JobConfig holds configuration data for different job types
 // This is stored as JSONB in PostgreSQL for flexibility
 type JobConfig map[string]interface{}
@@ -55,6 +151,60 @@ func (jc *JobConfig) Scan(value interface{}) error {
 	return json.Unmarshal(bytes, jc)
 }
 
+// JobTags holds free-form labels attached to a job, stored as a JSONB array
+type JobTags []string
+
+// Value implements the driver.Valuer interface for database storage
+func (jt JobTags) Value() (driver.Value, error) {
+	if jt == nil {
+		return json.Marshal([]string{})
+	}
+	return json.Marshal([]string(jt))
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (jt *JobTags) Scan(value interface{}) error {
+	if value == nil {
+		*jt = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into JobTags", value)
+	}
+
+	return json.Unmarshal(bytes, jt)
+}
+
+// Contains returns true if the tag is present
+func (jt JobTags) Contains(tag string) bool {
+	for _, t := range jt {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// JobConfigFromPayload converts a raw JSON trigger payload into execution parameters
+// Non-object JSON payloads are wrapped under a "payload" key
+func JobConfigFromPayload(body []byte) (JobConfig, error) {
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return nil, nil
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil, fmt.Errorf("payload must be valid JSON: %w", err)
+	}
+
+	if obj, ok := decoded.(map[string]interface{}); ok {
+		return JobConfig(obj), nil
+	}
+	return JobConfig{"payload": decoded}, nil
+}
+
 // Job represents a scheduled job in the system
 type Job struct {
 	// Primary key - using UUID for better scalability
@@ -64,15 +214,74 @@ type Job struct {
 	Name        string `json:"name" gorm:"not null;size:255" validate:"required,min=1,max=255"`
 	Description string `json:"description" gorm:"type:text"`
 
-	// Scheduling information
-	Schedule string `json:"schedule" gorm:"not null;size:100" validate:"required,cron"`
+	// Scheduling information - one-time jobs have no cron schedule; they run at RunAt and are
+	// deactivated once it has fired, recording CompletedAt. CronSeconds jobs' schedules may start
+	// with a seconds field
+	Schedule     string       `json:"schedule" gorm:"not null;size:100" validate:"required,cron"`
+	ScheduleType ScheduleType `json:"schedule_type" gorm:"size:20;default:'cron'"`
+	CronSeconds  bool         `json:"cron_seconds" gorm:"default:false"`
+	RunAt        *time.Time   `json:"run_at,omitempty"`
+	CompletedAt  *time.Time   `json:"completed_at,omitempty"`
 
 	// Job type and configuration
-	JobType JobType   `json:"job_type" gorm:"not null;size:50" validate:"required,oneof=email_notification data_processing report_generation health_check"`
+	JobType JobType   `json:"job_type" gorm:"not null;size:50" validate:"required,oneof=email_notification data_processing report_generation health_check db_maintenance"`
 	Config  JobConfig `json:"config" gorm:"type:jsonb"`
 
-	// Status and metadata
-	IsActive bool `json:"is_active" gorm:"default:true"`
+	// Status and metadata - the job's lifecycle state decides whether it is scheduled
+	State JobState `json:"state" gorm:"size:20;not null;default:'active';index"`
+
+	// Pause record - who paused the job, when and why; cleared when it is resumed
+	PausedAt    *time.Time `json:"paused_at,omitempty"`
+	PausedBy    string     `json:"paused_by,omitempty" gorm:"size:255"`
+	PauseReason string     `json:"pause_reason,omitempty" gorm:"type:text"`
+
+	// Error record - when and why the scheduler found the job's stored schedule unusable and made the
+	// job errored; cleared once the job is scheduled again
+	ErroredAt   *time.Time `json:"errored_at,omitempty"`
+	ErrorReason string     `json:"error_reason,omitempty" gorm:"type:text"`
+
+	// RequiresApproval holds each due run until someone approves it
+	RequiresApproval bool `json:"requires_approval" gorm:"default:false"`
+
+	// Tags are free-form labels (e.g. "protected")
+	Tags JobTags `json:"tags" gorm:"type:jsonb"`
+
+	// Ownership - notifications are routed to the team's channel
+	Team  string `json:"team,omitempty" gorm:"size:100;index"`
+	Owner string `json:"owner,omitempty" gorm:"size:255"`
+
+	// On-call documentation - Docs is markdown
+	RunbookURL string      `json:"runbook_url,omitempty" gorm:"size:500"`
+	Docs       string      `json:"docs,omitempty" gorm:"type:text"`
+	Severity   JobSeverity `json:"severity" gorm:"size:20;default:'medium'"`
+
+	// RemediationActions are offered as signed one-click links in failure notifications
+	RemediationActions RemediationActions `json:"remediation_actions,omitempty" gorm:"type:jsonb"`
+
+	// Retry policy - a failed run is retried up to MaxRetries times, waiting
+	// InitialDelaySeconds before the first retry and growing per BackoffStrategy
+	MaxRetries          int             `json:"max_retries" gorm:"not null;default:0"`
+	BackoffStrategy     BackoffStrategy `json:"backoff_strategy" gorm:"size:20;default:'exponential'"`
+	InitialDelaySeconds int             `json:"initial_delay_seconds" gorm:"not null;default:0"`
+
+	// TimeoutSeconds limits how long each run may take, in place of the scheduler's execution timeout
+	// and maximum execution time; 0 uses the scheduler's
+	TimeoutSeconds int `json:"timeout_seconds" gorm:"not null;default:0"`
+
+	// Misfire handling - LastRunAt is the last occurrence that fired and NextRunAt the next one due,
+	// so a scheduler starting up can tell which occurrences were missed while none was running
+	MisfirePolicy MisfirePolicy `json:"misfire_policy" gorm:"size:30;default:'ignore'"`
+	LastRunAt     *time.Time    `json:"last_run_at,omitempty"`
+	NextRunAt     *time.Time    `json:"next_run_at,omitempty"`
+
+	// ConcurrencyPolicy decides whether a scheduled run may overlap the job's previous run
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrency_policy" gorm:"size:20;default:'allow'"`
+	// ConcurrencyGroup shares a limit on concurrent runs with the other jobs in the group, set by SCHEDULER_CONCURRENCY_GROUP_LIMITS
+	ConcurrencyGroup string `json:"concurrency_group,omitempty" gorm:"size:100;index"`
+
+	// Health - a 0-100 score from recent runs, recalculated periodically; nil until the job has finished runs
+	HealthScore    *int       `json:"health_score" gorm:"index"`
+	HealthScoredAt *time.Time `json:"health_scored_at,omitempty"`
 
 	// Timestamps
 	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
@@ -96,10 +305,123 @@ func (Job) TableName() string {
 	return "jobs"
 }
 
+// IsActive returns true if the job is scheduled to run
+func (j *Job) IsActive() bool {
+	return j.State == JobStateActive
+}
+
+// ProtectedTag marks jobs whose destructive changes need a second approver
+const ProtectedTag = "protected"
+
+// IsProtected returns true if the job is tagged protected
+func (j *Job) IsProtected() bool {
+	return j.Tags.Contains(ProtectedTag)
+}
+
+// IsOneTime returns true if the job runs once, at its run_at, rather than on a cron schedule
+func (j *Job) IsOneTime() bool {
+	return j.ScheduleType == ScheduleTypeOnce
+}
+
+// RetryDelay returns how long to wait before the given retry, counting from 1
+func (j *Job) RetryDelay(retry int) time.Duration {
+	delay := time.Duration(j.InitialDelaySeconds) * time.Second
+
+	switch j.BackoffStrategy {
+	case BackoffFixed:
+	case BackoffLinear:
+		delay *= time.Duration(retry)
+	default:
+		for i := 1; i < retry && delay < MaxRetryDelay; i++ {
+			delay *= 2
+		}
+	}
+
+	if delay > MaxRetryDelay {
+		delay = MaxRetryDelay
+	}
+	return delay
+}
+
+// IsValidBackoffStrategy checks if the backoff strategy is valid
+func IsValidBackoffStrategy(strategy string) bool {
+	switch BackoffStrategy(strategy) {
+	case BackoffFixed, BackoffLinear, BackoffExponential:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValidScheduleType checks if the schedule type is valid
+func IsValidScheduleType(scheduleType string) bool {
+	switch ScheduleType(scheduleType) {
+	case ScheduleTypeCron, ScheduleTypeOnce:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValidMisfirePolicy checks if the misfire policy is valid
+func IsValidMisfirePolicy(policy string) bool {
+	switch MisfirePolicy(policy) {
+	case MisfireIgnore, MisfireRunOnce, MisfireRunAll:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValidConcurrencyPolicy checks if the concurrency policy is valid
+func IsValidConcurrencyPolicy(policy string) bool {
+	switch ConcurrencyPolicy(policy) {
+	case ConcurrencyAllow, ConcurrencyForbid, ConcurrencyReplace:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValidJobSort checks if the job sort order is valid
+func IsValidJobSort(sort string) bool {
+	switch JobSort(sort) {
+	case JobSortNewest, JobSortHealth, JobSortHealthDesc, JobSortName, JobSortNextRun:
+		return true
+	default:
+		return false
+	}
+}
+
 // IsValidJobType checks if the job type is valid
 func IsValidJobType(jobType string) bool {
 	switch JobType(jobType) {
-	case JobTypeEmailNotification, JobTypeDataProcessing, JobTypeReportGeneration, JobTypeHealthCheck:
+	case JobTypeEmailNotification, JobTypeDataProcessing, JobTypeReportGeneration, JobTypeHealthCheck,
+		JobTypeDBMaintenance:
+		return true
+	default:
+		return false
+	}
+}
+
+// Rank orders severities from low (0) to critical (3); unknown severities rank as medium
+func (s JobSeverity) Rank() int {
+	switch s {
+	case JobSeverityLow:
+		return 0
+	case JobSeverityHigh:
+		return 2
+	case JobSeverityCritical:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// IsValidJobSeverity checks if the severity is valid
+func IsValidJobSeverity(severity string) bool {
+	switch JobSeverity(severity) {
+	case JobSeverityLow, JobSeverityMedium, JobSeverityHigh, JobSeverityCritical:
 		return true
 	default:
 		return false
@@ -133,6 +455,12 @@ func GetDefaultConfig(jobType JobType) JobConfig {
 			"timeout_seconds": 30,
 			"expected_status": 200,
 		}
+	case JobTypeDBMaintenance:
+		return JobConfig{
+			"operations": []interface{}{
+				map[string]interface{}{"type": "analyze"},
+			},
+		}
 	default:
 		return JobConfig{}
 	}
@@ -145,7 +473,37 @@ type CreateJobRequest struct {
 	Schedule    string    `json:"schedule" validate:"required"`
 	JobType     JobType   `json:"job_type" validate:"required"`
 	Config      JobConfig `json:"config"`
-	IsActive    *bool     `json:"is_active"` // Pointer to distinguish between false and nil
+	IsActive    *bool     `json:"is_active"` // Pointer to distinguish between false and nil; true is state active, false disabled
+	State       *JobState `json:"state"`     // Defaults to active
+
+	ScheduleType ScheduleType `json:"schedule_type"` // Defaults to cron
+	RunAt        *time.Time   `json:"run_at"`        // Required for one-time jobs
+	CronSeconds  *bool        `json:"cron_seconds"`  // Defaults to SCHEDULER_CRON_SECONDS
+
+	RequiresApproval bool    `json:"requires_approval"`
+	Tags             JobTags `json:"tags"`
+
+	Team  string `json:"team"`
+	Owner string `json:"owner"`
+
+	RunbookURL string      `json:"runbook_url"`
+	Docs       string      `json:"docs"`
+	Severity   JobSeverity `json:"severity"` // Defaults to medium
+
+	RemediationActions RemediationActions `json:"remediation_actions"`
+
+	MaxRetries          int             `json:"max_retries"`
+	BackoffStrategy     BackoffStrategy `json:"backoff_strategy"` // Defaults to exponential
+	InitialDelaySeconds int             `json:"initial_delay_seconds"`
+
+	TimeoutSeconds int `json:"timeout_seconds"` // 0 uses the scheduler's execution timeout
+
+	MisfirePolicy MisfirePolicy `json:"misfire_policy"` // Defaults to ignore
+
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrency_policy"` // Defaults to allow
+	ConcurrencyGroup  string            `json:"concurrency_group"`
+
+	ChangedBy string `json:"-"` // Who is making the change, recorded in the job's revisions
 }
 
 // UpdateJobRequest represents the request payload for updating a job
@@ -155,7 +513,48 @@ type UpdateJobRequest struct {
 	Schedule    *string    `json:"schedule" validate:"omitempty"`
 	JobType     *JobType   `json:"job_type" validate:"omitempty"`
 	Config      *JobConfig `json:"config"`
-	IsActive    *bool      `json:"is_active"`
+	IsActive    *bool      `json:"is_active"` // true is state active, false disabled
+	State       *JobState  `json:"state"`
+
+	ScheduleType *ScheduleType `json:"schedule_type"`
+	RunAt        *time.Time    `json:"run_at"`
+	CronSeconds  *bool         `json:"cron_seconds"`
+
+	RequiresApproval *bool    `json:"requires_approval"`
+	Tags             *JobTags `json:"tags"`
+
+	Team  *string `json:"team"`
+	Owner *string `json:"owner"`
+
+	RunbookURL *string      `json:"runbook_url"`
+	Docs       *string      `json:"docs"`
+	Severity   *JobSeverity `json:"severity"`
+
+	RemediationActions *RemediationActions `json:"remediation_actions"`
+
+	MaxRetries          *int             `json:"max_retries"`
+	BackoffStrategy     *BackoffStrategy `json:"backoff_strategy"`
+	InitialDelaySeconds *int             `json:"initial_delay_seconds"`
+
+	TimeoutSeconds *int `json:"timeout_seconds"`
+
+	MisfirePolicy *MisfirePolicy `json:"misfire_policy"`
+
+	ConcurrencyPolicy *ConcurrencyPolicy `json:"concurrency_policy"`
+	ConcurrencyGroup  *string            `json:"concurrency_group"`
+
+	ChangedBy string `json:"-"` // Who is making the change, recorded in the job's revisions
+}
+
+// PauseJobRequest represents the request payload for pausing a job
+type PauseJobRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// TriggerJobRequest represents the optional request payload for running a job on demand
+type TriggerJobRequest struct {
+	// Params are passed to the run as execution parameters, as a webhook payload is
+	Params JobConfig `json:"params,omitempty"`
 }
 
 // JobListResponse represents the response for listing jobs with pagination
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxAcknowledgmentDuration is the longest an acknowledgment that expires may last
+const MaxAcknowledgmentDuration = 7 * 24 * time.Hour
+
+// AcknowledgmentScope is what an acknowledgment holds back for its job
+type AcknowledgmentScope string
+
+const (
+	// AcknowledgmentScopeAlerts suppresses the job's alerts and failure notifications
+	AcknowledgmentScopeAlerts AcknowledgmentScope = "alerts"
+	// AcknowledgmentScopeNotifications mutes all of the job's notifications but leaves its alerts alone,
+	// as silencing a job does
+	AcknowledgmentScopeNotifications AcknowledgmentScope = "notifications"
+)
+
+// IsValid returns true if the scope is known
+func (s AcknowledgmentScope) IsValid() bool {
+	return s == AcknowledgmentScopeAlerts || s == AcknowledgmentScopeNotifications
+}
+
+// JobAcknowledgment is an operator's note on a job, such as a known vendor outage or planned maintenance,
+// that holds back what its scope covers until it expires or is cleared. Runs are recorded as usual
+type JobAcknowledgment struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// Foreign key to Job
+	JobID uuid.UUID `json:"job_id" gorm:"type:uuid;not null;index"`
+
+	// The note and who left it
+	Note           string              `json:"note" gorm:"type:text;not null"`
+	AcknowledgedBy string              `json:"acknowledged_by" gorm:"not null;size:255"`
+	Scope          AcknowledgmentScope `json:"scope" gorm:"size:20;not null;default:'alerts'"`
+
+	// ExpiresAt is nil for an acknowledgment that lasts until it is cleared
+	ExpiresAt *time.Time `json:"expires_at,omitempty" gorm:"index"`
+
+	// Set when the acknowledgment is ended before it expires
+	ClearedBy *string    `json:"cleared_by,omitempty" gorm:"size:255"`
+	ClearedAt *time.Time `json:"cleared_at,omitempty"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating a job acknowledgment
+func (a *JobAcknowledgment) BeforeCreate(tx *gorm.DB) error {
+	// Generate UUID if not provided
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the JobAcknowledgment model
+func (JobAcknowledgment) TableName() string {
+	return "job_acknowledgments"
+}
+
+// IsActive returns true if the acknowledgment is still in effect at the given time
+func (a *JobAcknowledgment) IsActive(now time.Time) bool {
+	return a.ClearedAt == nil && (a.ExpiresAt == nil || now.Before(*a.ExpiresAt))
+}
+
+// ExpiresBefore returns true if the acknowledgment ends before other does; one without an expiry never does
+func (a *JobAcknowledgment) ExpiresBefore(other *JobAcknowledgment) bool {
+	if a.ExpiresAt == nil {
+		return false
+	}
+	return other.ExpiresAt == nil || a.ExpiresAt.Before(*other.ExpiresAt)
+}
+
+// MarkCleared ends the acknowledgment early
+func (a *JobAcknowledgment) MarkCleared(actor string) {
+	now := time.Now().UTC()
+	a.ClearedBy = &actor
+	a.ClearedAt = &now
+}
+
+// CreateJobAcknowledgmentRequest represents the request payload for acknowledging a job
+// The acknowledgment lasts until ExpiresAt, for Duration (e.g. "4h") from now, or without either until
+// it is cleared. Scope defaults to alerts
+type CreateJobAcknowledgmentRequest struct {
+	Note      string              `json:"note" validate:"required"`
+	Scope     AcknowledgmentScope `json:"scope"`
+	ExpiresAt *time.Time          `json:"expires_at"`
+	Duration  string              `json:"duration"`
+}
+
+// CreateJobSilenceRequest represents the request payload for silencing a job, which acknowledges it
+// in the notifications scope for Duration
+type CreateJobSilenceRequest struct {
+	Duration string `json:"duration" validate:"required"` // e.g. "2h"
+	Reason   string `json:"reason" validate:"required"`
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +18,36 @@ const (
 	ExecutionStatusCompleted ExecutionStatus = "completed"
 	ExecutionStatusFailed    ExecutionStatus = "failed"
 	ExecutionStatusCancelled ExecutionStatus = "cancelled"
+
+	// Runs wait in this status for a free execution slot once the maximum concurrent jobs are running
+	ExecutionStatusQueued ExecutionStatus = "queued"
+
+	// Runs of jobs that require approval wait in this status until approved or expired
+	ExecutionStatusAwaitingApproval ExecutionStatus = "awaiting_approval"
+	ExecutionStatusExpired          ExecutionStatus = "expired"
+
+	// Runs left running by an instance that stopped before finishing them
+	ExecutionStatusStalled ExecutionStatus = "stalled"
+
+	// Runs stopped by their instance shutting down or found unfinished after it restarted,
+	// until an instance starts and requeues or fails them
+	ExecutionStatusInterrupted ExecutionStatus = "interrupted"
+
+	// Runs that didn't start because a connection the job uses was known to be down
+	ExecutionStatusDependencyUnavailable ExecutionStatus = "dependency_unavailable"
+
+	// Runs not started because the job's previous run was still going and its concurrency policy forbids overlap
+	ExecutionStatusSkipped ExecutionStatus = "skipped"
+)
+
+// ExecutionTermination records how a cancelled or timed out run was stopped
+type ExecutionTermination string
+
+const (
+	// ExecutionTerminationGraceful means the executor saw its context end and stopped itself
+	ExecutionTerminationGraceful ExecutionTermination = "graceful"
+	// ExecutionTerminationKilled means the run was abandoned without waiting for its executor to stop
+	ExecutionTerminationKilled ExecutionTermination = "killed"
 )
 
 // JobExecution represents a single execution of a scheduled job
@@ -30,18 +62,52 @@ type JobExecution struct {
 	StartedAt   time.Time  `json:"started_at" gorm:"not null"`
 	CompletedAt *time.Time `json:"completed_at"`
 
+	// The cron occurrence the run belongs to; nil for runs triggered outside the schedule
+	ScheduledFor *time.Time `json:"scheduled_for,omitempty" gorm:"index"`
+
 	// Execution status and results
-	Status       ExecutionStatus `json:"status" gorm:"not null;size:20;default:'pending'"`
-	ErrorMessage *string         `json:"error_message" gorm:"type:text"`
+	Status       ExecutionStatus `json:"status" gorm:"not null;size:30;default:'pending'"`
+	ErrorMessage *CompressedText `json:"error_message" gorm:"type:text"`
 
 	// Performance metrics
 	ExecutionDuration *int64 `json:"execution_duration_ms"` // Duration in milliseconds
 
+	// Parameters supplied by the trigger (e.g. a webhook payload)
+	Parameters JobConfig `json:"parameters,omitempty" gorm:"type:jsonb"`
+
+	// Approval information for jobs that require approval
+	ApprovedBy        *string    `json:"approved_by,omitempty" gorm:"size:255"`
+	ApprovedAt        *time.Time `json:"approved_at,omitempty"`
+	ApprovalExpiresAt *time.Time `json:"approval_expires_at,omitempty"`
+
+	// Resource telemetry captured during the run
+	ResourceUsage *ResourceUsage `json:"resource_usage,omitempty" gorm:"type:jsonb"`
+
+	// Structured output recorded by the executor, served by GET /executions/{id}/output
+	Output ExecutionOutput `json:"output,omitempty" gorm:"type:jsonb"`
+
+	// External side effects performed by this attempt or the ones it retries, saved with its outcome
+	SideEffects SideEffectTokens `json:"side_effects,omitempty" gorm:"type:jsonb"`
+
+	// How a cancelled or timed out run was stopped
+	Termination *ExecutionTermination `json:"termination,omitempty" gorm:"size:20"`
+
+	// The scheduler instance that ran the run, so it can tell its own unfinished runs after a restart
+	InstanceID string `json:"instance_id,omitempty" gorm:"size:255;index"`
+
+	// Retries - Attempt counts from 1 and RetryOfID links a retry to the attempt it retries
+	Attempt   int        `json:"attempt" gorm:"not null;default:1"`
+	RetryOfID *uuid.UUID `json:"retry_of_id,omitempty" gorm:"type:uuid;index"`
+
 	// Metadata
 	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
 
 	// Relationships
 	Job Job `json:"job,omitempty" gorm:"foreignKey:JobID;constraint:OnDelete:CASCADE"`
+
+	// The status as last read or saved, set once the execution transitions and cleared when it is saved
+	savedStatus ExecutionStatus
+	unsaved     bool
 }
 
 // BeforeCreate is a GORM hook that runs before creating a job execution
@@ -51,11 +117,6 @@ func (je *JobExecution) BeforeCreate(tx *gorm.DB) error {
 		je.ID = uuid.New()
 	}
 
-	// Set started_at if not provided and status is running
-	if je.StartedAt.IsZero() && je.Status == ExecutionStatusRunning {
-		je.StartedAt = time.Now().UTC()
-	}
-
 	return nil
 }
 
@@ -64,31 +125,68 @@ func (JobExecution) TableName() string {
 	return "job_executions"
 }
 
-// MarkAsRunning updates the execution status to running and sets the start time
-func (je *JobExecution) MarkAsRunning() {
-	je.Status = ExecutionStatusRunning
-	je.StartedAt = time.Now().UTC()
+// ErrIllegalTransition is returned when an execution is moved to a status it can't reach from its current one
+var ErrIllegalTransition = errors.New("illegal execution status transition")
+
+// executionTransitions lists the statuses each status may move to
+// "" is a run that hasn't been saved yet; terminal statuses have no entry
+var executionTransitions = map[ExecutionStatus][]ExecutionStatus{
+	"":                              {ExecutionStatusPending, ExecutionStatusQueued, ExecutionStatusRunning, ExecutionStatusAwaitingApproval, ExecutionStatusDependencyUnavailable, ExecutionStatusSkipped},
+	ExecutionStatusAwaitingApproval: {ExecutionStatusPending, ExecutionStatusCancelled, ExecutionStatusExpired},
+	ExecutionStatusPending:          {ExecutionStatusQueued, ExecutionStatusRunning, ExecutionStatusFailed, ExecutionStatusCancelled, ExecutionStatusDependencyUnavailable, ExecutionStatusSkipped},
+	ExecutionStatusQueued:           {ExecutionStatusRunning, ExecutionStatusFailed, ExecutionStatusCancelled, ExecutionStatusInterrupted},
+	ExecutionStatusRunning:          {ExecutionStatusCompleted, ExecutionStatusFailed, ExecutionStatusCancelled, ExecutionStatusStalled, ExecutionStatusInterrupted},
+	ExecutionStatusStalled:          {ExecutionStatusFailed, ExecutionStatusCancelled},
+	ExecutionStatusInterrupted:      {ExecutionStatusFailed, ExecutionStatusCancelled},
 }
 
-// MarkAsCompleted updates the execution status to completed and calculates duration
-func (je *JobExecution) MarkAsCompleted() {
-	now := time.Now().UTC()
-	je.Status = ExecutionStatusCompleted
-	je.CompletedAt = &now
+// CanTransition reports whether an execution may move from one status to another
+func CanTransition(from, to ExecutionStatus) bool {
+	for _, allowed := range executionTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
 
-	// Calculate execution duration in milliseconds
-	if !je.StartedAt.IsZero() {
-		duration := now.Sub(je.StartedAt).Milliseconds()
-		je.ExecutionDuration = &duration
+// transition moves the execution to the given status if the move is legal
+func (je *JobExecution) transition(to ExecutionStatus) error {
+	if !CanTransition(je.Status, to) {
+		return fmt.Errorf("%w: %s -> %s", ErrIllegalTransition, je.statusName(), to)
+	}
+	if !je.unsaved {
+		je.savedStatus = je.Status
+		je.unsaved = true
 	}
+	je.Status = to
+	return nil
 }
 
-// MarkAsFailed updates the execution status to failed with an error message
-func (je *JobExecution) MarkAsFailed(errorMsg string) {
+// UnsavedTransition returns the status the execution had when last read or saved,
+// and whether it has transitioned since
+func (je *JobExecution) UnsavedTransition() (ExecutionStatus, bool) {
+	return je.savedStatus, je.unsaved
+}
+
+// MarkSaved records that the execution's current status has been saved
+func (je *JobExecution) MarkSaved() {
+	je.savedStatus = je.Status
+	je.unsaved = false
+}
+
+// statusName names the status in errors, including unsaved runs
+func (je *JobExecution) statusName() string {
+	if je.Status == "" {
+		return "new"
+	}
+	return string(je.Status)
+}
+
+// finish records when a run ended and how long it ran
+func (je *JobExecution) finish() {
 	now := time.Now().UTC()
-	je.Status = ExecutionStatusFailed
 	je.CompletedAt = &now
-	je.ErrorMessage = &errorMsg
 
 	// Calculate execution duration in milliseconds
 	if !je.StartedAt.IsZero() {
@@ -97,24 +195,159 @@ func (je *JobExecution) MarkAsFailed(errorMsg string) {
 	}
 }
 
+// MarkAsRunning updates the execution status to running and sets the start time
+func (je *JobExecution) MarkAsRunning() error {
+	if err := je.transition(ExecutionStatusRunning); err != nil {
+		return err
+	}
+	je.StartedAt = time.Now().UTC()
+	return nil
+}
+
+// MarkAsQueued records that the execution is waiting for a free execution slot
+func (je *JobExecution) MarkAsQueued() error {
+	return je.transition(ExecutionStatusQueued)
+}
+
+// MarkAsCompleted updates the execution status to completed and calculates duration
+func (je *JobExecution) MarkAsCompleted() error {
+	if err := je.transition(ExecutionStatusCompleted); err != nil {
+		return err
+	}
+	je.finish()
+	return nil
+}
+
+// MarkAsFailed updates the execution status to failed with an error message
+func (je *JobExecution) MarkAsFailed(errorMsg string) error {
+	if err := je.transition(ExecutionStatusFailed); err != nil {
+		return err
+	}
+	je.finish()
+	je.ErrorMessage = NewCompressedText(errorMsg)
+	return nil
+}
+
 // MarkAsCancelled updates the execution status to cancelled
-func (je *JobExecution) MarkAsCancelled() {
+func (je *JobExecution) MarkAsCancelled() error {
+	if err := je.transition(ExecutionStatusCancelled); err != nil {
+		return err
+	}
+	je.finish()
+	return nil
+}
+
+// MarkAsDependencyUnavailable ends a run that didn't start because a connection its job uses is down
+func (je *JobExecution) MarkAsDependencyUnavailable(reason string) error {
+	if err := je.transition(ExecutionStatusDependencyUnavailable); err != nil {
+		return err
+	}
+	if je.StartedAt.IsZero() {
+		je.StartedAt = time.Now().UTC()
+	}
+	je.finish()
+	je.ErrorMessage = NewCompressedText(reason)
+	return nil
+}
+
+// MarkAsSkipped ends a run that didn't start because the job's previous run was still going
+func (je *JobExecution) MarkAsSkipped(reason string) error {
+	if err := je.transition(ExecutionStatusSkipped); err != nil {
+		return err
+	}
+	if je.StartedAt.IsZero() {
+		je.StartedAt = time.Now().UTC()
+	}
+	je.finish()
+	je.ErrorMessage = NewCompressedText(reason)
+	return nil
+}
+
+// SetTermination records how a cancelled or timed out run was stopped
+func (je *JobExecution) SetTermination(termination ExecutionTermination) {
+	je.Termination = &termination
+}
+
+// MarkAsAwaitingApproval puts the execution on hold until approved or until expiresAt
+func (je *JobExecution) MarkAsAwaitingApproval(expiresAt time.Time) error {
+	if err := je.transition(ExecutionStatusAwaitingApproval); err != nil {
+		return err
+	}
+	je.StartedAt = time.Now().UTC()
+	je.ApprovalExpiresAt = &expiresAt
+	return nil
+}
+
+// MarkAsStalled marks a running execution whose instance stopped before finishing it
+func (je *JobExecution) MarkAsStalled() error {
+	if err := je.transition(ExecutionStatusStalled); err != nil {
+		return err
+	}
+	je.ErrorMessage = NewCompressedText("Run stalled - the instance running it stopped before it finished")
+	return nil
+}
+
+// MarkAsInterrupted marks a queued or running execution stopped because its instance shut down or restarted
+func (je *JobExecution) MarkAsInterrupted(reason string) error {
+	if err := je.transition(ExecutionStatusInterrupted); err != nil {
+		return err
+	}
+	je.finish()
+	je.ErrorMessage = NewCompressedText(reason)
+	return nil
+}
+
+// MarkAsApproved records the approver and releases the execution to run
+func (je *JobExecution) MarkAsApproved(approver string) error {
+	if err := je.transition(ExecutionStatusPending); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	je.ApprovedBy = &approver
+	je.ApprovedAt = &now
+	return nil
+}
+
+// MarkAsExpired marks an execution whose approval window passed
+func (je *JobExecution) MarkAsExpired() error {
+	if err := je.transition(ExecutionStatusExpired); err != nil {
+		return err
+	}
 	now := time.Now().UTC()
-	je.Status = ExecutionStatusCancelled
 	je.CompletedAt = &now
+	return nil
+}
 
-	// Calculate execution duration in milliseconds
-	if !je.StartedAt.IsZero() {
-		duration := now.Sub(je.StartedAt).Milliseconds()
-		je.ExecutionDuration = &duration
+// NextAttempt returns a pending execution retrying this one with the same parameters
+func (je *JobExecution) NextAttempt() *JobExecution {
+	retryOf := je.ID
+	return &JobExecution{
+		ID:         uuid.New(),
+		JobID:        je.JobID,
+		ScheduledFor: je.ScheduledFor,
+		Status:       ExecutionStatusPending,
+		Parameters:   je.Parameters,
+		ApprovedBy:   je.ApprovedBy,
+		ApprovedAt:   je.ApprovedAt,
+		Attempt:      je.Attempt + 1,
+		RetryOfID:    &retryOf,
+		SideEffects:  je.SideEffects.Copy(),
 	}
 }
 
+// IsAwaitingApproval returns true if the execution is waiting for approval
+func (je *JobExecution) IsAwaitingApproval() bool {
+	return je.Status == ExecutionStatusAwaitingApproval
+}
+
 // IsCompleted returns true if the execution has completed (successfully or with failure)
 func (je *JobExecution) IsCompleted() bool {
 	return je.Status == ExecutionStatusCompleted ||
 		je.Status == ExecutionStatusFailed ||
-		je.Status == ExecutionStatusCancelled
+		je.Status == ExecutionStatusCancelled ||
+		je.Status == ExecutionStatusExpired ||
+		je.Status == ExecutionStatusDependencyUnavailable ||
+		je.Status == ExecutionStatusSkipped
 }
 
 // IsRunning returns true if the execution is currently running
@@ -139,6 +372,14 @@ type JobExecutionListResponse struct {
 	Page       int            `json:"page"`
 	Limit      int            `json:"limit"`
 	TotalPages int            `json:"total_pages"`
+
+	// TotalCountEstimated is set when TotalCount is the planner's estimate rather than a count
+	TotalCountEstimated bool `json:"total_count_estimated"`
+}
+
+// SetExecutionDeadlineRequest moves a running execution's deadline earlier or later
+type SetExecutionDeadlineRequest struct {
+	Deadline time.Time `json:"deadline" binding:"required"`
 }
 
 // JobExecutionStats represents statistics about job executions
@@ -148,4 +389,49 @@ type JobExecutionStats struct {
 	FailedExecutions    int64   `json:"failed_executions"`
 	AverageExecutionTime *int64  `json:"average_execution_time_ms"`
 	SuccessRate         float64 `json:"success_rate"`
+
+	// Average resource usage of runs that recorded it
+	AverageCPUTimeMs      *int64 `json:"average_cpu_time_ms"`
+	AverageAllocatedBytes *int64 `json:"average_allocated_bytes"`
+}
+
+// OverallExecutionStats summarizes runs across all jobs
+type OverallExecutionStats struct {
+	TotalJobs            int64   `json:"total_jobs"`
+	TotalExecutions      int64   `json:"total_executions"`
+	SuccessfulExecutions int64   `json:"successful_executions"`
+	FailedExecutions     int64   `json:"failed_executions"`
+	AverageExecutionTime *int64  `json:"average_execution_time_ms"`
+	SuccessRate          float64 `json:"success_rate"`
+
+	// Failures of runs that finished in the last 24 hours
+	FailuresLast24h int64 `json:"failures_last_24h"`
+}
+
+// JobTypeExecutionStats summarizes the runs of one job type, for sizing what its executor depends on
+type JobTypeExecutionStats struct {
+	JobType              JobType `json:"job_type"`
+	TotalExecutions      int64   `json:"total_executions"`
+	SuccessfulExecutions int64   `json:"successful_executions"`
+	FailedExecutions     int64   `json:"failed_executions"`
+	FailureRate          float64 `json:"failure_rate"`
+	AverageExecutionTime *int64  `json:"average_execution_time_ms"`
+}
+
+// ThroughputBucket counts the runs that started, completed and failed in one interval of time
+type ThroughputBucket struct {
+	Start     time.Time `json:"start"`
+	Started   int64     `json:"started"`
+	Completed int64     `json:"completed"`
+	Failed    int64     `json:"failed"`
+}
+
+// HistoryCleanupStats reports how much run history has been pruned since startup
+type HistoryCleanupStats struct {
+	Runs           int64      `json:"runs"`
+	TotalPruned    int64      `json:"total_pruned"`
+	LastRunAt      *time.Time `json:"last_run_at,omitempty"`
+	LastPruned     int64      `json:"last_pruned"`
+	LastDurationMs int64      `json:"last_duration_ms"`
+	LastError      string     `json:"last_error,omitempty"`
 }
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// JobExecutionLog is one line an executor logged during a run
+type JobExecutionLog struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// Foreign key to JobExecution; Sequence numbers a run's lines from 1 in the order they were logged
+	ExecutionID uuid.UUID `json:"execution_id" gorm:"type:uuid;not null;uniqueIndex:idx_job_execution_logs_sequence"`
+	Sequence    int       `json:"sequence" gorm:"not null;uniqueIndex:idx_job_execution_logs_sequence"`
+
+	// The line
+	Level    string    `json:"level" gorm:"not null;size:10"`
+	Message  string    `json:"message" gorm:"type:text;not null"`
+	Fields   JobConfig `json:"fields,omitempty" gorm:"type:jsonb"`
+	LoggedAt time.Time `json:"logged_at" gorm:"not null"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating a job execution log line
+func (l *JobExecutionLog) BeforeCreate(tx *gorm.DB) error {
+	// Generate UUID if not provided
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the JobExecutionLog model
+func (JobExecutionLog) TableName() string {
+	return "job_execution_logs"
+}
+
+// ExecutionLogPage is a page of a run's log lines
+type ExecutionLogPage struct {
+	Lines []JobExecutionLog `json:"lines"`
+	// NextAfter is the sequence to ask for lines after to get the next page
+	NextAfter int `json:"next_after"`
+	// Finished is true once the run has ended, after which it logs no more lines
+	Finished bool `json:"finished"`
+}
@@ -0,0 +1,288 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// JobManifestVersion is the version of the job manifest format
+const JobManifestVersion = 1
+
+// Job manifest formats
+const (
+	JobManifestFormatYAML = "yaml"
+	JobManifestFormatJSON = "json"
+)
+
+// JobManifest is a declarative document of job definitions, exported and imported as YAML or JSON so
+// job configuration can live in version control
+type JobManifest struct {
+	Version int             `json:"version"`
+	Jobs    []JobDefinition `json:"jobs"`
+}
+
+// JobDefinition declares a job, which is identified by its team and name
+// Settings left out take their defaults, as they do when creating a job, except that importing a
+// definition of an existing job keeps its owner, run_at and state unless they are given
+type JobDefinition struct {
+	Name        string  `json:"name"`
+	Team        string  `json:"team,omitempty"`
+	Description string  `json:"description,omitempty"`
+	JobType     JobType `json:"job_type"`
+
+	ScheduleType ScheduleType `json:"schedule_type,omitempty"`
+	Schedule     string       `json:"schedule,omitempty"`
+	RunAt        *time.Time   `json:"run_at,omitempty"`
+	CronSeconds  *bool        `json:"cron_seconds,omitempty"`
+
+	State  JobState  `json:"state,omitempty"`
+	Config JobConfig `json:"config,omitempty"`
+
+	RequiresApproval bool    `json:"requires_approval,omitempty"`
+	Tags             JobTags `json:"tags,omitempty"`
+	Owner            string  `json:"owner,omitempty"`
+
+	RunbookURL string      `json:"runbook_url,omitempty"`
+	Docs       string      `json:"docs,omitempty"`
+	Severity   JobSeverity `json:"severity,omitempty"`
+
+	RemediationActions RemediationActions `json:"remediation_actions,omitempty"`
+
+	MaxRetries          int             `json:"max_retries,omitempty"`
+	BackoffStrategy     BackoffStrategy `json:"backoff_strategy,omitempty"`
+	InitialDelaySeconds int             `json:"initial_delay_seconds,omitempty"`
+
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+
+	MisfirePolicy MisfirePolicy `json:"misfire_policy,omitempty"`
+
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrency_policy,omitempty"`
+	ConcurrencyGroup  string            `json:"concurrency_group,omitempty"`
+}
+
+// NewJobDefinition returns the definition of a job
+func NewJobDefinition(job *Job) JobDefinition {
+	cronSeconds := job.CronSeconds
+	def := JobDefinition{
+		Name:        job.Name,
+		Team:        job.Team,
+		Description: job.Description,
+		JobType:     job.JobType,
+
+		Schedule:    job.Schedule,
+		RunAt:       job.RunAt,
+		CronSeconds: &cronSeconds,
+
+		State:  job.State,
+		Config: job.Config,
+
+		RequiresApproval: job.RequiresApproval,
+		Tags:             job.Tags,
+		Owner:            job.Owner,
+
+		RunbookURL: job.RunbookURL,
+		Docs:       job.Docs,
+		Severity:   job.Severity,
+
+		RemediationActions: job.RemediationActions,
+
+		MaxRetries:          job.MaxRetries,
+		BackoffStrategy:     job.BackoffStrategy,
+		InitialDelaySeconds: job.InitialDelaySeconds,
+
+		TimeoutSeconds: job.TimeoutSeconds,
+
+		MisfirePolicy: job.MisfirePolicy,
+
+		ConcurrencyPolicy: job.ConcurrencyPolicy,
+		ConcurrencyGroup:  job.ConcurrencyGroup,
+	}
+	if job.ScheduleType != ScheduleTypeCron {
+		def.ScheduleType = job.ScheduleType
+	}
+	return def
+}
+
+// CreateRequest returns the request creating the defined job
+func (d *JobDefinition) CreateRequest() *CreateJobRequest {
+	req := &CreateJobRequest{
+		Name:        d.Name,
+		Description: d.Description,
+		Schedule:    d.Schedule,
+		JobType:     d.JobType,
+		Config:      d.Config,
+
+		ScheduleType: d.ScheduleType,
+		RunAt:        d.RunAt,
+		CronSeconds:  d.CronSeconds,
+
+		RequiresApproval: d.RequiresApproval,
+		Tags:             d.Tags,
+
+		Team:  d.Team,
+		Owner: d.Owner,
+
+		RunbookURL: d.RunbookURL,
+		Docs:       d.Docs,
+		Severity:   d.Severity,
+
+		RemediationActions: d.RemediationActions,
+
+		MaxRetries:          d.MaxRetries,
+		BackoffStrategy:     d.BackoffStrategy,
+		InitialDelaySeconds: d.InitialDelaySeconds,
+
+		TimeoutSeconds: d.TimeoutSeconds,
+
+		MisfirePolicy: d.MisfirePolicy,
+
+		ConcurrencyPolicy: d.ConcurrencyPolicy,
+		ConcurrencyGroup:  d.ConcurrencyGroup,
+	}
+	if d.State != "" {
+		state := d.State
+		req.State = &state
+	}
+	return req
+}
+
+// DecodeJobManifest parses a job manifest written in YAML or JSON, which is also YAML
+// Unknown settings are rejected, so a misspelt setting isn't silently left at its default
+func DecodeJobManifest(data []byte) (*JobManifest, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid job manifest: %w", err)
+	}
+	// YAML is decoded through JSON so the manifest has the same field names as the API
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("invalid job manifest: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	var manifest JobManifest
+	if err := decoder.Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("invalid job manifest: %w", err)
+	}
+	if manifest.Version == 0 {
+		manifest.Version = JobManifestVersion
+	}
+	if manifest.Version != JobManifestVersion {
+		return nil, fmt.Errorf("invalid job manifest: unsupported version %d", manifest.Version)
+	}
+	return &manifest, nil
+}
+
+// EncodeJobManifest writes a job manifest in the given format, YAML or JSON
+func EncodeJobManifest(manifest *JobManifest, format string) ([]byte, error) {
+	encoded, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job manifest: %w", err)
+	}
+	if format == JobManifestFormatJSON {
+		return append(encoded, '\n'), nil
+	}
+
+	// The JSON is read back as YAML nodes, which keep its field order, and written in block style
+	var document yaml.Node
+	if err := yaml.Unmarshal(encoded, &document); err != nil {
+		return nil, fmt.Errorf("failed to encode job manifest: %w", err)
+	}
+	blockStyle(&document)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, fmt.Errorf("failed to encode job manifest: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode job manifest: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// blockStyle clears the flow and quoting styles of YAML read from JSON, quoting only where YAML needs it
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// IsValidJobManifestFormat checks if a job manifest format is valid
+func IsValidJobManifestFormat(format string) bool {
+	switch strings.ToLower(format) {
+	case JobManifestFormatYAML, JobManifestFormatJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// JobImportAction is what importing a manifest does to a job
+type JobImportAction string
+
+const (
+	JobImportCreate    JobImportAction = "create"
+	JobImportUpdate    JobImportAction = "update"
+	JobImportUnchanged JobImportAction = "unchanged"
+	// JobImportPendingApproval updates a protected job once a second approver accepts the change
+	JobImportPendingApproval JobImportAction = "pending_approval"
+	JobImportFailed          JobImportAction = "failed"
+)
+
+// JobImportChange is a setting an import changes
+type JobImportChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// JobImportItem reports what importing a manifest does, or did, to one of its jobs
+type JobImportItem struct {
+	Name   string          `json:"name"`
+	Team   string          `json:"team,omitempty"`
+	ID     *uuid.UUID      `json:"id,omitempty"`
+	Action JobImportAction `json:"action"`
+	// Changes lists the settings an update changes, when a diff is asked for
+	Changes []JobImportChange `json:"changes,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// JobImportResult reports what importing a manifest does, or did when it isn't a dry run
+type JobImportResult struct {
+	DryRun          bool            `json:"dry_run"`
+	Created         int             `json:"created"`
+	Updated         int             `json:"updated"`
+	Unchanged       int             `json:"unchanged"`
+	PendingApproval int             `json:"pending_approval"`
+	Failed          int             `json:"failed"`
+	Jobs            []JobImportItem `json:"jobs"`
+}
+
+// Count tallies the jobs of the result by action
+func (r *JobImportResult) Count() {
+	r.Created, r.Updated, r.Unchanged, r.PendingApproval, r.Failed = 0, 0, 0, 0, 0
+	for _, item := range r.Jobs {
+		switch item.Action {
+		case JobImportCreate:
+			r.Created++
+		case JobImportUpdate:
+			r.Updated++
+		case JobImportUnchanged:
+			r.Unchanged++
+		case JobImportPendingApproval:
+			r.PendingApproval++
+		case JobImportFailed:
+			r.Failed++
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Limits on how many fire times a schedule preview lists
+const (
+	DefaultPreviewCount = 5
+	MaxPreviewCount     = 100
+)
+
+// PreviewJobRequest represents the request payload for previewing a job's schedule and config without saving it
+type PreviewJobRequest struct {
+	Schedule    string `json:"schedule"`
+	Timezone    string `json:"timezone"`     // IANA name such as Europe/London; defaults to the scheduler's time zone
+	CronSeconds *bool  `json:"cron_seconds"` // Defaults to SCHEDULER_CRON_SECONDS
+	Count       int    `json:"count"`        // Fire times to list, 5 by default and at most 100
+
+	// The config is checked against the job type's config schema when both are given
+	JobType JobType   `json:"job_type"`
+	Config  JobConfig `json:"config"`
+}
+
+// JobPreviewError is a problem with one field of a previewed job
+type JobPreviewError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// JobPreview is when a schedule would next fire and what is wrong with a job's schedule and config
+type JobPreview struct {
+	Valid     bool              `json:"valid"`
+	Timezone  string            `json:"timezone"`
+	FireTimes []time.Time       `json:"fire_times"`
+	Errors    []JobPreviewError `json:"errors"`
+}
+
+// AddError records a problem with a field, making the preview invalid
+func (p *JobPreview) AddError(field, message string) {
+	p.Errors = append(p.Errors, JobPreviewError{Field: field, Message: message})
+	p.Valid = false
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobChangeAction is what a replicated change does to a job
+type JobChangeAction string
+
+const (
+	// JobChangeUpsert creates the job or replaces it with the change's copy
+	JobChangeUpsert JobChangeAction = "upsert"
+	// JobChangeDelete deletes the job
+	JobChangeDelete JobChangeAction = "delete"
+)
+
+// JobChange is an entry of the outbox of job definition changes a primary serves to standbys
+type JobChange struct {
+	// Sequence orders the changes; standbys apply them in sequence order
+	Sequence int64 `json:"sequence" gorm:"primaryKey;autoIncrement"`
+
+	JobID  uuid.UUID       `json:"job_id" gorm:"type:uuid;not null"`
+	Action JobChangeAction `json:"action" gorm:"not null;size:20"`
+	// Payload holds the saved job for upserts
+	Payload JobConfig `json:"payload,omitempty" gorm:"type:jsonb"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for the JobChange model
+func (JobChange) TableName() string {
+	return "job_changes"
+}
+
+// JobChangeFeed is a page of the changes after a sequence
+type JobChangeFeed struct {
+	Changes []JobChange `json:"changes"`
+	// LatestSequence is the sequence of the primary's latest change, so a standby can tell how far behind it is
+	LatestSequence int64 `json:"latest_sequence"`
+}
+
+// JobSnapshot is every job on a primary, which a new standby starts from
+type JobSnapshot struct {
+	// Sequence is the latest change the jobs include; the standby follows the changes after it
+	Sequence int64 `json:"sequence"`
+	Jobs     []Job `json:"jobs"`
+}
+
+// ReplicationState records how far a standby has followed its primary, and whether it was promoted
+// It is a single row
+type ReplicationState struct {
+	ID           int        `json:"-" gorm:"primary_key"`
+	LastSequence int64      `json:"last_sequence" gorm:"not null;default:0"`
+	LastSyncedAt *time.Time `json:"last_synced_at,omitempty"`
+	PromotedAt   *time.Time `json:"promoted_at,omitempty"`
+	PromotedBy   string     `json:"promoted_by,omitempty" gorm:"size:255"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for the ReplicationState model
+func (ReplicationState) TableName() string {
+	return "replication_state"
+}
+
+// IsPromoted reports whether the standby was promoted to run the jobs itself
+func (s *ReplicationState) IsPromoted() bool {
+	return s.PromotedAt != nil
+}
+
+// ReplicationStatus reports an instance's replication role and progress
+type ReplicationStatus struct {
+	Role string `json:"role"`
+	// PrimaryURL is the primary a standby follows
+	PrimaryURL string `json:"primary_url,omitempty"`
+	ReplicationState
+	// LatestSequence is the primary's latest change when the standby last asked for changes
+	LatestSequence int64 `json:"latest_sequence"`
+	// Lag is how many of the primary's changes the standby hasn't applied
+	Lag int64 `json:"lag"`
+}
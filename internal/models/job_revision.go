@@ -0,0 +1,86 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// JobRevision records a job's name, schedule and config as they were after a change, and who made it
+// A job's revisions are numbered from 1, so a bad change can be found and rolled back
+type JobRevision struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// Foreign key to Job
+	JobID uuid.UUID `json:"job_id" gorm:"type:uuid;not null;uniqueIndex:idx_job_revisions_job_revision"`
+
+	// Revision numbers count up from 1 for each job
+	Revision int `json:"revision" gorm:"not null;uniqueIndex:idx_job_revisions_job_revision"`
+
+	// The revised definition
+	Name         string       `json:"name" gorm:"not null;size:255"`
+	ScheduleType ScheduleType `json:"schedule_type" gorm:"size:20;not null;default:'cron'"`
+	Schedule     string       `json:"schedule" gorm:"not null;size:100"`
+	Config       JobConfig    `json:"config" gorm:"type:jsonb"`
+
+	// Who made the change - empty for changes made before revisions were kept, or by unidentified callers
+	Author string `json:"author" gorm:"size:255"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating a job revision
+func (r *JobRevision) BeforeCreate(tx *gorm.DB) error {
+	// Generate UUID if not provided
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the JobRevision model
+func (JobRevision) TableName() string {
+	return "job_revisions"
+}
+
+// NewJobRevision returns the revision recording a job's current name, schedule and config
+func NewJobRevision(job *Job, revision int, author string) *JobRevision {
+	return &JobRevision{
+		JobID:        job.ID,
+		Revision:     revision,
+		Name:         job.Name,
+		ScheduleType: job.ScheduleType,
+		Schedule:     job.Schedule,
+		Config:       job.Config,
+		Author:       author,
+	}
+}
+
+// ChangedFrom lists which of name, schedule and config differ from an earlier revision
+func (r *JobRevision) ChangedFrom(previous *JobRevision) []string {
+	var fields []string
+	if r.Name != previous.Name {
+		fields = append(fields, "name")
+	}
+	if r.ScheduleType != previous.ScheduleType || r.Schedule != previous.Schedule {
+		fields = append(fields, "schedule")
+	}
+	if !sameJobConfig(r.Config, previous.Config) {
+		fields = append(fields, "config")
+	}
+	return fields
+}
+
+// sameJobConfig compares configs as JSON, so a config read back from the database matches the one saved
+func sameJobConfig(a, b JobConfig) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(encodedA) == string(encodedB)
+}
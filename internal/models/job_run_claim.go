@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobRunClaim records which scheduler instance claimed a scheduled run of a job
+// Instances sharing a database claim each run before executing it, so only one executes it
+type JobRunClaim struct {
+	// The claimed run - a job's run at its scheduled time can only be claimed once
+	JobID        uuid.UUID `json:"job_id" gorm:"type:uuid;primary_key"`
+	ScheduledFor time.Time `json:"scheduled_for" gorm:"primary_key"`
+
+	// InstanceID identifies the scheduler instance that claimed the run
+	InstanceID string `json:"instance_id" gorm:"not null;size:255"`
+
+	// Timestamps
+	ClaimedAt time.Time `json:"claimed_at" gorm:"autoCreateTime;index"`
+}
+
+// TableName returns the table name for the JobRunClaim model
+func (JobRunClaim) TableName() string {
+	return "job_run_claims"
+}
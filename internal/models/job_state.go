@@ -0,0 +1,93 @@
+package models
+
+import "fmt"
+
+// JobState is where a job is in its lifecycle, which decides whether the scheduler runs it
+type JobState string
+
+const (
+	// JobStateActive jobs are scheduled
+	JobStateActive JobState = "active"
+	// JobStatePaused jobs were paused through the API, recording who paused them and why, until resumed
+	JobStatePaused JobState = "paused"
+	// JobStateDisabled jobs were turned off and aren't scheduled
+	JobStateDisabled JobState = "disabled"
+	// JobStateErrored jobs have a stored schedule the scheduler can't use; each reload tries them again
+	JobStateErrored JobState = "errored"
+	// JobStateExpired one-time jobs have run, and don't run again unless they are rescheduled
+	JobStateExpired JobState = "expired"
+	// JobStateArchived jobs are retired but kept, with their run history
+	JobStateArchived JobState = "archived"
+)
+
+// jobStateTransitions lists the states each state may change to
+// Jobs are paused and resumed through their own routes, and the scheduler errors and expires them
+var jobStateTransitions = map[JobState][]JobState{
+	JobStateActive:   {JobStatePaused, JobStateDisabled, JobStateErrored, JobStateExpired, JobStateArchived},
+	JobStatePaused:   {JobStateActive, JobStateDisabled, JobStateArchived},
+	JobStateDisabled: {JobStateActive, JobStateArchived},
+	JobStateErrored:  {JobStateActive, JobStatePaused, JobStateDisabled, JobStateArchived},
+	JobStateExpired:  {JobStateActive, JobStateDisabled, JobStateArchived},
+	JobStateArchived: {JobStateDisabled},
+}
+
+// SchedulableJobStates are the states of the jobs the scheduler loads
+// Errored jobs are loaded so they are scheduled again once their schedule can be used
+var SchedulableJobStates = []JobState{JobStateActive, JobStateErrored}
+
+// IsValidJobState checks if a job state is valid
+func IsValidJobState(state string) bool {
+	_, ok := jobStateTransitions[JobState(state)]
+	return ok
+}
+
+// CanTransitionTo reports whether a job in the state may change to next
+func (s JobState) CanTransitionTo(next JobState) bool {
+	for _, allowed := range jobStateTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSchedulable reports whether the scheduler schedules jobs in the state
+func (s JobState) IsSchedulable() bool {
+	for _, schedulable := range SchedulableJobStates {
+		if s == schedulable {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRequestable reports whether jobs can be put in the state by creating or updating them
+// The other states are entered by pausing a job, or set by the scheduler
+func (s JobState) IsRequestable() bool {
+	switch s {
+	case JobStateActive, JobStateDisabled, JobStateArchived:
+		return true
+	default:
+		return false
+	}
+}
+
+// RequestedJobState returns the state a create or update request asks for, if any
+// is_active is kept for older clients: true asks for active, false for disabled
+func RequestedJobState(isActive *bool, state *JobState) (*JobState, error) {
+	if state != nil && !IsValidJobState(string(*state)) {
+		return nil, fmt.Errorf("invalid job state: %s", *state)
+	}
+	if isActive == nil {
+		return state, nil
+	}
+
+	fromActive := JobStateDisabled
+	if *isActive {
+		fromActive = JobStateActive
+	}
+	if state != nil && *state != fromActive {
+		return nil, fmt.Errorf("is_active %t conflicts with state %s", *isActive, *state)
+	}
+	return &fromActive, nil
+}
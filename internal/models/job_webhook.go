@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookSignatureScheme describes how an inbound webhook signature is computed
+type WebhookSignatureScheme string
+
+const (
+	// WebhookSignatureSchemeHex is HMAC-SHA256 over the raw body, hex encoded,
+	// optionally prefixed with "sha256=" (GitHub style)
+	WebhookSignatureSchemeHex WebhookSignatureScheme = "hmac_sha256_hex"
+	// WebhookSignatureSchemeStripe is the Stripe "t=<ts>,v1=<sig>" scheme where
+	// the signed payload is "<ts>.<body>"
+	WebhookSignatureSchemeStripe WebhookSignatureScheme = "stripe"
+)
+
+// DefaultWebhookSignatureHeader is the header used when a webhook doesn't specify one
+const DefaultWebhookSignatureHeader = "X-Signature-256"
+
+// DefaultWebhookRateLimitPerMinute is the rate limit applied when none is configured
+const DefaultWebhookRateLimitPerMinute = 60
+
+// JobWebhook represents an inbound webhook URL that triggers a job
+type JobWebhook struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// Foreign key to Job
+	JobID uuid.UUID `json:"job_id" gorm:"type:uuid;not null;index"`
+
+	// Token is the unguessable path segment of /api/v1/hooks/:token
+	Token string `json:"token" gorm:"not null;size:64;uniqueIndex"`
+
+	// Secret used for HMAC signature validation - never serialized
+	Secret string `json:"-" gorm:"not null;size:128"`
+
+	// Signature validation settings
+	SignatureHeader string                 `json:"signature_header" gorm:"not null;size:100"`
+	SignatureScheme WebhookSignatureScheme `json:"signature_scheme" gorm:"not null;size:30"`
+
+	// Rate limiting
+	RateLimitPerMinute int `json:"rate_limit_per_minute" gorm:"not null;default:60"`
+
+	// Status and metadata
+	IsActive        bool       `json:"is_active" gorm:"default:true"`
+	LastTriggeredAt *time.Time `json:"last_triggered_at"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating a webhook
+func (w *JobWebhook) BeforeCreate(tx *gorm.DB) error {
+	// Generate UUID if not provided
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the JobWebhook model
+func (JobWebhook) TableName() string {
+	return "job_webhooks"
+}
+
+// IsValidWebhookSignatureScheme checks if the signature scheme is supported
+func IsValidWebhookSignatureScheme(scheme string) bool {
+	switch WebhookSignatureScheme(scheme) {
+	case WebhookSignatureSchemeHex, WebhookSignatureSchemeStripe:
+		return true
+	default:
+		return false
+	}
+}
+
+// CreateJobWebhookRequest represents the request payload for creating a webhook
+type CreateJobWebhookRequest struct {
+	SignatureHeader    string                 `json:"signature_header"`
+	SignatureScheme    WebhookSignatureScheme `json:"signature_scheme"`
+	RateLimitPerMinute *int                   `json:"rate_limit_per_minute"`
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSONPath selects a value inside a JSON document, such as a run's output
+// It supports the subset of JSONPath needed to pick one value: $ for the root, .field or ['field']
+// for an object's field and [n] for an array's element, e.g. $.files[0].path
+type JSONPath struct {
+	expr  string
+	steps []jsonPathStep
+}
+
+// jsonPathStep is one field or index of a path
+type jsonPathStep struct {
+	field   string
+	index   int
+	isIndex bool
+}
+
+// ParseJSONPath parses a path starting at $
+func ParseJSONPath(expr string) (JSONPath, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return JSONPath{}, fmt.Errorf("invalid JSONPath %q: must start with $", expr)
+	}
+
+	path := JSONPath{expr: expr}
+	rest := expr[1:]
+	for rest != "" {
+		switch {
+		case rest[0] == '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			field := rest[1 : end+1]
+			if field == "" {
+				return JSONPath{}, fmt.Errorf("invalid JSONPath %q: empty field name", expr)
+			}
+			path.steps = append(path.steps, jsonPathStep{field: field})
+			rest = rest[end+1:]
+		case strings.HasPrefix(rest, "['"):
+			end := strings.Index(rest, "']")
+			if end < 0 {
+				return JSONPath{}, fmt.Errorf("invalid JSONPath %q: unclosed ['", expr)
+			}
+			path.steps = append(path.steps, jsonPathStep{field: rest[2:end]})
+			rest = rest[end+2:]
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return JSONPath{}, fmt.Errorf("invalid JSONPath %q: unclosed [", expr)
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil || index < 0 {
+				return JSONPath{}, fmt.Errorf("invalid JSONPath %q: %q is not an array index", expr, rest[1:end])
+			}
+			path.steps = append(path.steps, jsonPathStep{index: index, isIndex: true})
+			rest = rest[end+1:]
+		default:
+			return JSONPath{}, fmt.Errorf("invalid JSONPath %q: unexpected %q", expr, rest[0])
+		}
+	}
+	return path, nil
+}
+
+// Lookup returns the value the path selects from a decoded JSON document, and whether there is one
+func (p JSONPath) Lookup(document interface{}) (interface{}, bool) {
+	value := document
+	for _, step := range p.steps {
+		if step.isIndex {
+			items, ok := value.([]interface{})
+			if !ok || step.index >= len(items) {
+				return nil, false
+			}
+			value = items[step.index]
+			continue
+		}
+
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = fields[step.field]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// String returns the path as written
+func (p JSONPath) String() string {
+	return p.expr
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MissedOccurrenceReason explains why a scheduled occurrence of a job didn't run
+type MissedOccurrenceReason string
+
+const (
+	// MissedOccurrenceOverloadShed means the scheduler was overloaded and deferred the run
+	MissedOccurrenceOverloadShed MissedOccurrenceReason = "overload_shed"
+	// MissedOccurrenceConcurrencySkip means no execution slot, or slot of the job's concurrency group, freed up for the run
+	MissedOccurrenceConcurrencySkip MissedOccurrenceReason = "concurrency_skip"
+	// MissedOccurrenceRateLimited means runs kept starting at their start rate limit until the run gave up waiting
+	MissedOccurrenceRateLimited MissedOccurrenceReason = "rate_limited"
+	// MissedOccurrenceSchedulerDown means no scheduler instance was running when the occurrence was due
+	MissedOccurrenceSchedulerDown MissedOccurrenceReason = "scheduler_down"
+)
+
+// MissedOccurrence records a scheduled occurrence of a job that didn't run, and why
+type MissedOccurrence struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// The occurrence - each occurrence of a job is recorded at most once
+	JobID        uuid.UUID `json:"job_id" gorm:"type:uuid;not null;uniqueIndex:idx_missed_occurrences_occurrence"`
+	ScheduledFor time.Time `json:"scheduled_for" gorm:"not null;uniqueIndex:idx_missed_occurrences_occurrence"`
+
+	// Why it didn't run
+	Reason  MissedOccurrenceReason `json:"reason" gorm:"not null;size:50"`
+	Details string                 `json:"details,omitempty" gorm:"type:text"`
+
+	// InstanceID identifies the scheduler instance that recorded the miss
+	InstanceID string `json:"instance_id" gorm:"size:255"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating a missed occurrence
+func (m *MissedOccurrence) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the MissedOccurrence model
+func (MissedOccurrence) TableName() string {
+	return "missed_occurrences"
+}
+
+// MissedOccurrenceListResponse represents the response for listing a job's missed occurrences
+type MissedOccurrenceListResponse struct {
+	Occurrences []MissedOccurrence `json:"occurrences"`
+	TotalCount  int64              `json:"total_count"`
+	Page        int                `json:"page"`
+	Limit       int                `json:"limit"`
+	TotalPages  int                `json:"total_pages"`
+}
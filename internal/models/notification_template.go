@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TemplateChannel is the kind of notification content a template renders
+type TemplateChannel string
+
+const (
+	// TemplateChannelSlack renders a Slack message payload (e.g. blocks) as JSON
+	TemplateChannelSlack TemplateChannel = "slack"
+	// TemplateChannelWebhook renders the JSON body posted to webhook channels
+	TemplateChannelWebhook TemplateChannel = "webhook"
+	// TemplateChannelEmail renders an HTML email body and a text subject
+	TemplateChannelEmail TemplateChannel = "email"
+)
+
+// NotificationTemplate customizes the content of notifications sent to a channel
+// Body (and Subject for email) are Go templates with access to the job, execution,
+// error and duration of the notification
+type NotificationTemplate struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// Template identity
+	Name    string          `json:"name" gorm:"not null;size:100;uniqueIndex"`
+	Channel TemplateChannel `json:"channel" gorm:"not null;size:20;index:idx_notification_templates_lookup"`
+	// Event restricts the template to one event; empty applies to every event
+	Event string `json:"event" gorm:"size:50;index:idx_notification_templates_lookup"`
+
+	// Template content
+	Subject string `json:"subject,omitempty" gorm:"size:500"`
+	Body    string `json:"body" gorm:"type:text;not null"`
+
+	// Status
+	IsActive bool `json:"is_active" gorm:"default:true"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating a notification template
+func (nt *NotificationTemplate) BeforeCreate(tx *gorm.DB) error {
+	// Generate UUID if not provided
+	if nt.ID == uuid.Nil {
+		nt.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the NotificationTemplate model
+func (NotificationTemplate) TableName() string {
+	return "notification_templates"
+}
+
+// IsValidTemplateChannel checks if the template channel is supported
+func IsValidTemplateChannel(channel string) bool {
+	switch TemplateChannel(channel) {
+	case TemplateChannelSlack, TemplateChannelWebhook, TemplateChannelEmail:
+		return true
+	default:
+		return false
+	}
+}
+
+// CreateNotificationTemplateRequest represents the request payload for creating a template
+type CreateNotificationTemplateRequest struct {
+	Name     string          `json:"name" validate:"required"`
+	Channel  TemplateChannel `json:"channel" validate:"required"`
+	Event    string          `json:"event"`
+	Subject  string          `json:"subject"`
+	Body     string          `json:"body" validate:"required"`
+	IsActive *bool           `json:"is_active"`
+}
+
+// UpdateNotificationTemplateRequest represents the request payload for updating a template
+type UpdateNotificationTemplateRequest struct {
+	Event    *string `json:"event"`
+	Subject  *string `json:"subject"`
+	Body     *string `json:"body"`
+	IsActive *bool   `json:"is_active"`
+}
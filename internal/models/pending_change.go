@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PendingChangeAction is the kind of change waiting for a second approver
+type PendingChangeAction string
+
+const (
+	PendingChangeActionUpdate PendingChangeAction = "update"
+	PendingChangeActionDelete PendingChangeAction = "delete"
+)
+
+// PendingChangeStatus represents the review state of a pending change
+type PendingChangeStatus string
+
+const (
+	PendingChangeStatusPending  PendingChangeStatus = "pending"
+	PendingChangeStatusApproved PendingChangeStatus = "approved"
+	PendingChangeStatusRejected PendingChangeStatus = "rejected"
+)
+
+// PendingChange is a destructive change to a protected job queued for a second approver
+type PendingChange struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// Foreign key to Job
+	JobID uuid.UUID `json:"job_id" gorm:"type:uuid;not null;index"`
+
+	// The requested change - Payload holds the UpdateJobRequest for updates
+	Action      PendingChangeAction `json:"action" gorm:"not null;size:20"`
+	Payload     JobConfig           `json:"payload,omitempty" gorm:"type:jsonb"`
+	RequestedBy string              `json:"requested_by" gorm:"not null;size:255"`
+
+	// Review state
+	Status       PendingChangeStatus `json:"status" gorm:"not null;size:20;default:'pending';index"`
+	ReviewedBy   *string             `json:"reviewed_by,omitempty" gorm:"size:255"`
+	ReviewedAt   *time.Time          `json:"reviewed_at,omitempty"`
+	ReviewReason *string             `json:"review_reason,omitempty" gorm:"type:text"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating a pending change
+func (pc *PendingChange) BeforeCreate(tx *gorm.DB) error {
+	// Generate UUID if not provided
+	if pc.ID == uuid.Nil {
+		pc.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the PendingChange model
+func (PendingChange) TableName() string {
+	return "pending_changes"
+}
+
+// MarkReviewed records the reviewer's decision
+func (pc *PendingChange) MarkReviewed(status PendingChangeStatus, reviewer, reason string) {
+	now := time.Now().UTC()
+	pc.Status = status
+	pc.ReviewedBy = &reviewer
+	pc.ReviewedAt = &now
+	if reason != "" {
+		pc.ReviewReason = &reason
+	}
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// RemediationActionType is what a remediation action does when it is selected
+type RemediationActionType string
+
+const (
+	// RemediationRetryNow runs the job again straight away, with the failed run's parameters
+	RemediationRetryNow RemediationActionType = "retry_now"
+	// RemediationRunFallback runs another job in place of the failed one
+	RemediationRunFallback RemediationActionType = "run_fallback_job"
+	// RemediationDisableJob pauses the job until someone resumes it
+	RemediationDisableJob RemediationActionType = "disable_job"
+	// RemediationCallWebhook posts the failed run to a URL, e.g. to open a ticket or restart a dependency
+	RemediationCallWebhook RemediationActionType = "call_webhook"
+)
+
+// MaxRemediationActions is the most remediation actions a job may declare
+const MaxRemediationActions = 5
+
+// RemediationAction is a fix on-call engineers can apply from a job's failure notifications
+type RemediationAction struct {
+	Type  RemediationActionType `json:"type"`
+	Label string                `json:"label,omitempty"`
+	// FallbackJobID is the job run_fallback_job runs
+	FallbackJobID *uuid.UUID `json:"fallback_job_id,omitempty"`
+	// WebhookURL is where call_webhook posts the failed run
+	WebhookURL string `json:"webhook_url,omitempty"`
+}
+
+// DisplayLabel returns the action's label, or a default one for its type
+func (a RemediationAction) DisplayLabel() string {
+	if a.Label != "" {
+		return a.Label
+	}
+	switch a.Type {
+	case RemediationRetryNow:
+		return "Retry now"
+	case RemediationRunFallback:
+		return "Run fallback job"
+	case RemediationDisableJob:
+		return "Disable job"
+	case RemediationCallWebhook:
+		return "Call webhook"
+	default:
+		return string(a.Type)
+	}
+}
+
+// RemediationActions holds a job's remediation actions, stored as a JSONB array
+type RemediationActions []RemediationAction
+
+// Value implements the driver.Valuer interface for database storage
+func (ra RemediationActions) Value() (driver.Value, error) {
+	if ra == nil {
+		return json.Marshal([]RemediationAction{})
+	}
+	return json.Marshal([]RemediationAction(ra))
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (ra *RemediationActions) Scan(value interface{}) error {
+	if value == nil {
+		*ra = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into RemediationActions", value)
+	}
+
+	return json.Unmarshal(bytes, ra)
+}
+
+// RemediationLink is a signed link that applies one of a job's remediation actions to a failed run
+type RemediationLink struct {
+	Type  RemediationActionType `json:"type"`
+	Label string                `json:"label"`
+	URL   string                `json:"url"`
+}
+
+// RemediationResult describes a remediation action that was applied
+type RemediationResult struct {
+	Type        RemediationActionType `json:"type"`
+	Label       string                `json:"label"`
+	JobID       uuid.UUID             `json:"job_id"`
+	ExecutionID uuid.UUID             `json:"execution_id"`
+	Message     string                `json:"message"`
+}
@@ -0,0 +1,190 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Supported report output formats
+const (
+	ReportFormatText = "txt"
+	ReportFormatCSV  = "csv"
+	ReportFormatJSON = "json"
+	ReportFormatXLSX = "xlsx"
+	ReportFormatPDF  = "pdf"
+)
+
+// IsValidReportFormat checks if the report format is supported
+func IsValidReportFormat(format string) bool {
+	switch format {
+	case ReportFormatText, ReportFormatCSV, ReportFormatJSON, ReportFormatXLSX, ReportFormatPDF:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReportLayout controls the framing of a generated report
+type ReportLayout struct {
+	Title          string `json:"title,omitempty"`
+	Header         string `json:"header,omitempty"`
+	Footer         string `json:"footer,omitempty"`
+	IncludeSummary bool   `json:"include_summary"`
+	IncludeCharts  bool   `json:"include_charts"`
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (rl ReportLayout) Value() (driver.Value, error) {
+	return json.Marshal(rl)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (rl *ReportLayout) Scan(value interface{}) error {
+	if value == nil {
+		*rl = ReportLayout{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into ReportLayout", value)
+	}
+
+	return json.Unmarshal(bytes, rl)
+}
+
+// ReportColumn is a column of a report's tables
+type ReportColumn struct {
+	Key   string `json:"key"`
+	Label string `json:"label,omitempty"`
+}
+
+// Heading returns the column label, falling back to its key
+func (rc ReportColumn) Heading() string {
+	if rc.Label != "" {
+		return rc.Label
+	}
+	return rc.Key
+}
+
+// ReportColumns holds the ordered columns of a report, stored as a JSONB array
+type ReportColumns []ReportColumn
+
+// Value implements the driver.Valuer interface for database storage
+func (rc ReportColumns) Value() (driver.Value, error) {
+	if rc == nil {
+		return nil, nil
+	}
+	return json.Marshal(rc)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (rc *ReportColumns) Scan(value interface{}) error {
+	if value == nil {
+		*rc = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into ReportColumns", value)
+	}
+
+	return json.Unmarshal(bytes, rc)
+}
+
+// ReportQuery is a named query whose results make up a section of the report
+type ReportQuery struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+// ReportQueries holds the queries of a report, stored as a JSONB array
+type ReportQueries []ReportQuery
+
+// Value implements the driver.Valuer interface for database storage
+func (rq ReportQueries) Value() (driver.Value, error) {
+	if rq == nil {
+		return nil, nil
+	}
+	return json.Marshal(rq)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (rq *ReportQueries) Scan(value interface{}) error {
+	if value == nil {
+		*rq = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into ReportQueries", value)
+	}
+
+	return json.Unmarshal(bytes, rq)
+}
+
+// ReportTemplate is a stored report definition that report_generation jobs
+// reference by name via config["report_template"]
+type ReportTemplate struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// Template identity
+	Name        string `json:"name" gorm:"not null;size:100;uniqueIndex"`
+	Description string `json:"description" gorm:"type:text"`
+
+	// Report definition
+	Format  string        `json:"format" gorm:"not null;size:10;default:'txt'"`
+	Layout  ReportLayout  `json:"layout" gorm:"type:jsonb"`
+	Columns ReportColumns `json:"columns" gorm:"type:jsonb"`
+	Queries ReportQueries `json:"queries" gorm:"type:jsonb"`
+
+	// Status
+	IsActive bool `json:"is_active" gorm:"default:true"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating a report template
+func (rt *ReportTemplate) BeforeCreate(tx *gorm.DB) error {
+	// Generate UUID if not provided
+	if rt.ID == uuid.Nil {
+		rt.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the ReportTemplate model
+func (ReportTemplate) TableName() string {
+	return "report_templates"
+}
+
+// CreateReportTemplateRequest represents the request payload for creating a report template
+type CreateReportTemplateRequest struct {
+	Name        string        `json:"name" binding:"required"`
+	Description string        `json:"description"`
+	Format      string        `json:"format"`
+	Layout      ReportLayout  `json:"layout"`
+	Columns     ReportColumns `json:"columns" binding:"required"`
+	Queries     ReportQueries `json:"queries" binding:"required"`
+	IsActive    *bool         `json:"is_active"`
+}
+
+// UpdateReportTemplateRequest represents the request payload for updating a report template
+type UpdateReportTemplateRequest struct {
+	Description *string        `json:"description"`
+	Format      *string        `json:"format"`
+	Layout      *ReportLayout  `json:"layout"`
+	Columns     *ReportColumns `json:"columns"`
+	Queries     *ReportQueries `json:"queries"`
+	IsActive    *bool          `json:"is_active"`
+}
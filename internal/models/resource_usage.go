@@ -0,0 +1,62 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResourceUsageSource says how a run's resource usage was measured
+type ResourceUsageSource string
+
+const (
+	// ResourceUsageSourceProcess is measured from the scheduler process's own counters
+	ResourceUsageSourceProcess ResourceUsageSource = "process"
+	// ResourceUsageSourceContainer is reported by executors that run jobs in containers
+	ResourceUsageSourceContainer ResourceUsageSource = "container"
+)
+
+// ResourceUsage is the resource telemetry captured during a run, stored as JSONB
+// Process counters are process-wide, so they include other runs overlapping with this one
+type ResourceUsage struct {
+	Source         ResourceUsageSource `json:"source"`
+	GoroutineDelta int                 `json:"goroutine_delta"`
+	AllocatedBytes uint64              `json:"allocated_bytes"`
+	Allocations    uint64              `json:"allocations"`
+	// CPUTimeMs is user plus system CPU time; nil where it can't be measured
+	CPUTimeMs *int64 `json:"cpu_time_ms,omitempty"`
+	// PeakMemoryBytes is only reported for containers
+	PeakMemoryBytes *uint64 `json:"peak_memory_bytes,omitempty"`
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (ru ResourceUsage) Value() (driver.Value, error) {
+	return json.Marshal(ru)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (ru *ResourceUsage) Scan(value interface{}) error {
+	if value == nil {
+		*ru = ResourceUsage{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into ResourceUsage", value)
+	}
+
+	return json.Unmarshal(bytes, ru)
+}
+
+// ExecutionUsagePoint is one run's resource usage, for graphing usage over time
+type ExecutionUsagePoint struct {
+	ExecutionID uuid.UUID       `json:"execution_id"`
+	StartedAt   time.Time       `json:"started_at"`
+	Status      ExecutionStatus `json:"status"`
+	DurationMs  *int64          `json:"execution_duration_ms"`
+	Usage       *ResourceUsage  `json:"resource_usage"`
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Role is what a user may do through the API
+type Role string
+
+const (
+	// RoleViewer may list and read jobs, runs and their stats
+	RoleViewer Role = "viewer"
+	// RoleOperator may also pause, resume and update the jobs they own, and cancel and approve runs
+	RoleOperator Role = "operator"
+	// RoleAdmin may do anything, including creating and deleting jobs and assigning roles
+	RoleAdmin Role = "admin"
+)
+
+// roleRanks orders the roles, each allowing everything the lower ones do
+var roleRanks = map[Role]int{
+	RoleViewer:   1,
+	RoleOperator: 2,
+	RoleAdmin:    3,
+}
+
+// IsValidRole checks if the role is supported
+func IsValidRole(role string) bool {
+	_, ok := roleRanks[Role(role)]
+	return ok
+}
+
+// Allows reports whether the role includes the required role
+func (r Role) Allows(required Role) bool {
+	rank, ok := roleRanks[r]
+	return ok && rank >= roleRanks[required]
+}
+
+// RoleAssignment grants a user a role
+type RoleAssignment struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// User the role is granted to - matches the authenticated user
+	User string `json:"user" gorm:"column:username;not null;size:255;uniqueIndex"`
+	Role Role   `json:"role" gorm:"not null;size:20"`
+
+	// AssignedBy is the admin who last granted the role
+	AssignedBy string `json:"assigned_by,omitempty" gorm:"size:255"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating a role assignment
+func (ra *RoleAssignment) BeforeCreate(tx *gorm.DB) error {
+	// Generate UUID if not provided
+	if ra.ID == uuid.Nil {
+		ra.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the RoleAssignment model
+func (RoleAssignment) TableName() string {
+	return "role_assignments"
+}
+
+// CreateRoleAssignmentRequest represents the request payload for granting a user a role
+type CreateRoleAssignmentRequest struct {
+	User string `json:"user" validate:"required"`
+	Role Role   `json:"role" validate:"required"`
+}
+
+// UpdateRoleAssignmentRequest represents the request payload for changing a user's role
+type UpdateRoleAssignmentRequest struct {
+	Role Role `json:"role" validate:"required"`
+}
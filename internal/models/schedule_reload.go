@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReloadedJob is a job whose schedule a reload changed
+type ReloadedJob struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name,omitempty"`
+	// Error is why the job couldn't be scheduled
+	Error string `json:"error,omitempty"`
+}
+
+// ScheduleReload reports how reloading the active jobs changed this instance's schedule
+type ScheduleReload struct {
+	// Added jobs weren't scheduled before; Updated jobs changed since they were scheduled
+	Added   []ReloadedJob `json:"added"`
+	Updated []ReloadedJob `json:"updated"`
+	// Removed jobs were deleted or deactivated
+	Removed []ReloadedJob `json:"removed"`
+	// Failed jobs have a schedule that can't be used and are left unscheduled
+	Failed        []ReloadedJob `json:"failed"`
+	Unchanged     int           `json:"unchanged"`
+	ScheduledJobs int           `json:"scheduled_jobs"`
+	ReloadedAt    time.Time     `json:"reloaded_at"`
+}
+
+// HasChanges reports whether the reload changed the schedule
+func (r *ScheduleReload) HasChanges() bool {
+	return len(r.Added)+len(r.Updated)+len(r.Removed)+len(r.Failed) > 0
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// SideEffectTokens are the external side effects a run performed, such as the message-id of an email it
+// sent or the idempotency key of a payment it posted, keyed by the name the executor gave each one.
+// They are saved with the run's outcome and carried over to its retries, which skip the side effects
+// already performed instead of repeating them. Stored as JSONB
+type SideEffectTokens map[string]string
+
+// Value implements the driver.Valuer interface for database storage
+func (t SideEffectTokens) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+	return json.Marshal(t)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (t *SideEffectTokens) Scan(value interface{}) error {
+	if value == nil {
+		*t = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into SideEffectTokens", value)
+	}
+
+	return json.Unmarshal(bytes, t)
+}
+
+// Copy returns a copy of the tokens, or nil if there are none
+func (t SideEffectTokens) Copy() SideEffectTokens {
+	if len(t) == 0 {
+		return nil
+	}
+	tokens := make(SideEffectTokens, len(t))
+	for key, token := range t {
+		tokens[key] = token
+	}
+	return tokens
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// TableMaintenanceResult is the outcome of maintaining one table in the last maintenance run
+type TableMaintenanceResult struct {
+	Table      string `json:"table"`
+	Operation  string `json:"operation"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// TableMaintenanceStats reports the table maintenance runs since startup
+type TableMaintenanceStats struct {
+	Runs           int64                    `json:"runs"`
+	LastRunAt      *time.Time               `json:"last_run_at,omitempty"`
+	LastDurationMs int64                    `json:"last_duration_ms"`
+	LastTables     []TableMaintenanceResult `json:"last_tables,omitempty"`
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ChannelKind represents how notifications are delivered to a channel
+type ChannelKind string
+
+const (
+	// ChannelKindSlack posts a text message to a Slack incoming webhook
+	ChannelKindSlack ChannelKind = "slack"
+	// ChannelKindWebhook posts the notification as JSON
+	ChannelKindWebhook ChannelKind = "webhook"
+)
+
+// TeamChannel maps a team to the channel its jobs' notifications are routed to
+type TeamChannel struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// Team the channel belongs to - matches Job.Team
+	Team string `json:"team" gorm:"not null;size:100;uniqueIndex"`
+
+	// Channel settings
+	Kind ChannelKind `json:"kind" gorm:"not null;size:20"`
+	URL  string      `json:"url" gorm:"not null;size:500"`
+
+	// Status
+	IsActive bool `json:"is_active" gorm:"default:true"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating a team channel
+func (tc *TeamChannel) BeforeCreate(tx *gorm.DB) error {
+	// Generate UUID if not provided
+	if tc.ID == uuid.Nil {
+		tc.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the TeamChannel model
+func (TeamChannel) TableName() string {
+	return "team_channels"
+}
+
+// IsValidChannelKind checks if the channel kind is supported
+func IsValidChannelKind(kind string) bool {
+	switch ChannelKind(kind) {
+	case ChannelKindSlack, ChannelKindWebhook:
+		return true
+	default:
+		return false
+	}
+}
+
+// CreateTeamChannelRequest represents the request payload for creating a team channel
+type CreateTeamChannelRequest struct {
+	Team     string      `json:"team" validate:"required"`
+	Kind     ChannelKind `json:"kind" validate:"required"`
+	URL      string      `json:"url" validate:"required"`
+	IsActive *bool       `json:"is_active"`
+}
+
+// UpdateTeamChannelRequest represents the request payload for updating a team channel
+type UpdateTeamChannelRequest struct {
+	Kind     *ChannelKind `json:"kind"`
+	URL      *string      `json:"url"`
+	IsActive *bool        `json:"is_active"`
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TriggerSourceKind represents the type of message queue a trigger source reads from
+type TriggerSourceKind string
+
+const (
+	TriggerSourceKindSQS    TriggerSourceKind = "sqs"
+	TriggerSourceKindPubSub TriggerSourceKind = "pubsub"
+)
+
+// TriggerSource maps a message queue (SQS queue or Pub/Sub subscription) to a job
+// Every message received launches one run of the job; the message is acked
+// when the run succeeds and released for redelivery when it fails. Messages
+// not matching the source's condition are acked without running the job
+type TriggerSource struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// Foreign key to Job
+	JobID uuid.UUID `json:"job_id" gorm:"type:uuid;not null;index"`
+
+	// Source settings
+	Kind TriggerSourceKind `json:"kind" gorm:"not null;size:20"`
+	// Target is the SQS queue URL or the Pub/Sub subscription
+	// ("projects/<project>/subscriptions/<name>")
+	Target string `json:"target" gorm:"not null;size:500"`
+
+	// VisibilityTimeoutSeconds is the SQS visibility timeout / Pub/Sub ack deadline
+	// It is extended while a run is in progress
+	VisibilityTimeoutSeconds int `json:"visibility_timeout_seconds" gorm:"not null;default:300"`
+	MaxMessages              int `json:"max_messages" gorm:"not null;default:10"`
+
+	// Condition is a CEL expression over the message's body, attributes and message_id; empty runs
+	// the job for every message
+	Condition string `json:"condition,omitempty" gorm:"type:text"`
+
+	// Status
+	IsActive bool `json:"is_active" gorm:"default:true"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating a trigger source
+func (ts *TriggerSource) BeforeCreate(tx *gorm.DB) error {
+	// Generate UUID if not provided
+	if ts.ID == uuid.Nil {
+		ts.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the TriggerSource model
+func (TriggerSource) TableName() string {
+	return "trigger_sources"
+}
+
+// IsValidTriggerSourceKind checks if the trigger source kind is supported
+func IsValidTriggerSourceKind(kind string) bool {
+	switch TriggerSourceKind(kind) {
+	case TriggerSourceKindSQS, TriggerSourceKindPubSub:
+		return true
+	default:
+		return false
+	}
+}
+
+// CreateTriggerSourceRequest represents the request payload for creating a trigger source
+type CreateTriggerSourceRequest struct {
+	Kind                     TriggerSourceKind `json:"kind" validate:"required"`
+	Target                   string            `json:"target" validate:"required"`
+	VisibilityTimeoutSeconds *int              `json:"visibility_timeout_seconds"`
+	MaxMessages              *int              `json:"max_messages"`
+	Condition                string            `json:"condition"`
+	IsActive                 *bool             `json:"is_active"`
+}
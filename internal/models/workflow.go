@@ -0,0 +1,359 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxWorkflowNodes is the largest number of jobs a workflow may group
+const MaxWorkflowNodes = 100
+
+// workflowNodeKeyPattern is what node keys look like, so edges read well
+var workflowNodeKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
+// WorkflowNode is one step of a workflow: a job, identified within the workflow by its key
+type WorkflowNode struct {
+	Key   string    `json:"key"`
+	JobID uuid.UUID `json:"job_id"`
+}
+
+// WorkflowEdge makes the To node wait for the From node to complete
+// Params hands the From node's output to the To node: each To run parameter is set to the value a
+// JSONPath selects from the From run's output, e.g. {"report_path": "$.path"}
+type WorkflowEdge struct {
+	From   string            `json:"from"`
+	To     string            `json:"to"`
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// workflowReservedParams are set on every node's run by the workflow, so edges can't map onto them
+var workflowReservedParams = map[string]bool{"workflow_execution_id": true, "workflow_node": true}
+
+// WorkflowGraph is a workflow's nodes and the edges between them, stored as JSONB
+// A node starts once every node with an edge into it has completed, so nodes with the same
+// upstream node fan out and a node with several upstream nodes fans in
+type WorkflowGraph struct {
+	Nodes []WorkflowNode `json:"nodes"`
+	Edges []WorkflowEdge `json:"edges"`
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (g WorkflowGraph) Value() (driver.Value, error) {
+	return json.Marshal(g)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (g *WorkflowGraph) Scan(value interface{}) error {
+	if value == nil {
+		*g = WorkflowGraph{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into WorkflowGraph", value)
+	}
+
+	return json.Unmarshal(bytes, g)
+}
+
+// Validate checks the nodes have unique keys and the edges join existing nodes without forming a cycle
+func (g WorkflowGraph) Validate() error {
+	if len(g.Nodes) == 0 {
+		return fmt.Errorf("a workflow needs at least one node")
+	}
+	if len(g.Nodes) > MaxWorkflowNodes {
+		return fmt.Errorf("a workflow can have at most %d nodes", MaxWorkflowNodes)
+	}
+
+	keys := make(map[string]bool, len(g.Nodes))
+	for _, node := range g.Nodes {
+		if !workflowNodeKeyPattern.MatchString(node.Key) {
+			return fmt.Errorf("invalid node key %q: use lowercase letters, digits, - and _, up to 50 characters", node.Key)
+		}
+		if keys[node.Key] {
+			return fmt.Errorf("duplicate node key %q", node.Key)
+		}
+		if node.JobID == uuid.Nil {
+			return fmt.Errorf("node %q has no job_id", node.Key)
+		}
+		keys[node.Key] = true
+	}
+
+	type edgeKey struct{ from, to string }
+	edges := make(map[edgeKey]bool, len(g.Edges))
+	mapped := make(map[string]string) // node key + parameter -> the node whose output sets it
+	for _, edge := range g.Edges {
+		if !keys[edge.From] {
+			return fmt.Errorf("edge from unknown node %q", edge.From)
+		}
+		if !keys[edge.To] {
+			return fmt.Errorf("edge to unknown node %q", edge.To)
+		}
+		if edge.From == edge.To {
+			return fmt.Errorf("node %q can't depend on itself", edge.From)
+		}
+		if edges[edgeKey{edge.From, edge.To}] {
+			return fmt.Errorf("duplicate edge %s -> %s", edge.From, edge.To)
+		}
+		edges[edgeKey{edge.From, edge.To}] = true
+
+		for param, path := range edge.Params {
+			if param == "" || workflowReservedParams[param] {
+				return fmt.Errorf("edge %s -> %s: invalid parameter name %q", edge.From, edge.To, param)
+			}
+			if _, err := ParseJSONPath(path); err != nil {
+				return fmt.Errorf("edge %s -> %s: parameter %q: %w", edge.From, edge.To, param, err)
+			}
+			if from, ok := mapped[edge.To+"."+param]; ok {
+				return fmt.Errorf("parameter %q of node %q is mapped from both %q and %q", param, edge.To, from, edge.From)
+			}
+			mapped[edge.To+"."+param] = edge.From
+		}
+	}
+
+	if order := g.TopologicalOrder(); len(order) != len(g.Nodes) {
+		return fmt.Errorf("workflow edges form a cycle")
+	}
+	return nil
+}
+
+// TopologicalOrder returns the node keys with every node after the nodes it depends on,
+// ties broken by key. Nodes on a cycle are left out
+func (g WorkflowGraph) TopologicalOrder() []string {
+	waiting := make(map[string]int, len(g.Nodes))
+	for _, node := range g.Nodes {
+		waiting[node.Key] = 0
+	}
+	for _, edge := range g.Edges {
+		waiting[edge.To]++
+	}
+
+	var ready []string
+	for key, count := range waiting {
+		if count == 0 {
+			ready = append(ready, key)
+		}
+	}
+
+	order := make([]string, 0, len(g.Nodes))
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		key := ready[0]
+		ready = ready[1:]
+		order = append(order, key)
+		for _, next := range g.Downstream(key) {
+			waiting[next]--
+			if waiting[next] == 0 {
+				ready = append(ready, next)
+			}
+		}
+	}
+	return order
+}
+
+// Inbound returns the edges into the given node
+func (g WorkflowGraph) Inbound(key string) []WorkflowEdge {
+	var inbound []WorkflowEdge
+	for _, edge := range g.Edges {
+		if edge.To == key {
+			inbound = append(inbound, edge)
+		}
+	}
+	return inbound
+}
+
+// Upstream returns the keys of the nodes the given node waits for
+func (g WorkflowGraph) Upstream(key string) []string {
+	var upstream []string
+	for _, edge := range g.Edges {
+		if edge.To == key {
+			upstream = append(upstream, edge.From)
+		}
+	}
+	return upstream
+}
+
+// Downstream returns the keys of the nodes waiting for the given node
+func (g WorkflowGraph) Downstream(key string) []string {
+	var downstream []string
+	for _, edge := range g.Edges {
+		if edge.From == key {
+			downstream = append(downstream, edge.To)
+		}
+	}
+	return downstream
+}
+
+// Node returns the node with the given key
+func (g WorkflowGraph) Node(key string) (WorkflowNode, bool) {
+	for _, node := range g.Nodes {
+		if node.Key == key {
+			return node, true
+		}
+	}
+	return WorkflowNode{}, false
+}
+
+// Workflow groups jobs into a directed acyclic graph that runs as a whole
+type Workflow struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// Workflow information
+	Name        string        `json:"name" gorm:"not null;size:100;uniqueIndex"`
+	Description string        `json:"description" gorm:"type:text"`
+	Graph       WorkflowGraph `json:"graph" gorm:"type:jsonb;not null"`
+
+	// Status
+	IsActive bool `json:"is_active" gorm:"default:true"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating a workflow
+func (w *Workflow) BeforeCreate(tx *gorm.DB) error {
+	// Generate UUID if not provided
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the Workflow model
+func (Workflow) TableName() string {
+	return "workflows"
+}
+
+// WorkflowExecutionStatus represents the status of a workflow execution
+type WorkflowExecutionStatus string
+
+const (
+	WorkflowExecutionStatusRunning   WorkflowExecutionStatus = "running"
+	WorkflowExecutionStatusCompleted WorkflowExecutionStatus = "completed"
+	WorkflowExecutionStatusFailed    WorkflowExecutionStatus = "failed"
+)
+
+// WorkflowNodeStatus represents the status of one node of a workflow execution
+type WorkflowNodeStatus string
+
+const (
+	WorkflowNodeStatusPending   WorkflowNodeStatus = "pending"
+	WorkflowNodeStatusRunning   WorkflowNodeStatus = "running"
+	WorkflowNodeStatusCompleted WorkflowNodeStatus = "completed"
+	WorkflowNodeStatusFailed    WorkflowNodeStatus = "failed"
+	// WorkflowNodeStatusSkipped is a node that didn't run because a node it depends on failed
+	WorkflowNodeStatusSkipped WorkflowNodeStatus = "skipped"
+)
+
+// IsFinished returns true if the node won't change status again
+func (s WorkflowNodeStatus) IsFinished() bool {
+	return s == WorkflowNodeStatusCompleted || s == WorkflowNodeStatusFailed || s == WorkflowNodeStatusSkipped
+}
+
+// WorkflowNodeState is the progress of one node in a workflow execution
+type WorkflowNodeState struct {
+	Status      WorkflowNodeStatus `json:"status"`
+	JobID       uuid.UUID          `json:"job_id"`
+	StartedAt   *time.Time         `json:"started_at,omitempty"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty"`
+	Error       string             `json:"error,omitempty"`
+}
+
+// WorkflowNodeStates holds the progress of each node by key, stored as JSONB
+type WorkflowNodeStates map[string]*WorkflowNodeState
+
+// Value implements the driver.Valuer interface for database storage
+func (s WorkflowNodeStates) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (s *WorkflowNodeStates) Scan(value interface{}) error {
+	if value == nil {
+		*s = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into WorkflowNodeStates", value)
+	}
+
+	return json.Unmarshal(bytes, s)
+}
+
+// WorkflowExecution is one run of a workflow and the progress of each of its nodes
+type WorkflowExecution struct {
+	// Primary key
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	// Foreign key to Workflow
+	WorkflowID uuid.UUID `json:"workflow_id" gorm:"type:uuid;not null;index"`
+
+	// Execution status and per-node progress
+	Status WorkflowExecutionStatus `json:"status" gorm:"not null;size:20;default:'running'"`
+	Nodes  WorkflowNodeStates      `json:"nodes" gorm:"type:jsonb"`
+
+	// The graph the execution runs, as the workflow's was when it was triggered
+	Graph WorkflowGraph `json:"graph" gorm:"type:jsonb"`
+
+	// Parameters supplied by the trigger, passed to every node's run
+	Parameters  JobConfig `json:"parameters,omitempty" gorm:"type:jsonb"`
+	TriggeredBy string    `json:"triggered_by,omitempty" gorm:"size:255"`
+
+	// Execution timing
+	StartedAt   time.Time  `json:"started_at" gorm:"not null"`
+	CompletedAt *time.Time `json:"completed_at"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating a workflow execution
+func (we *WorkflowExecution) BeforeCreate(tx *gorm.DB) error {
+	// Generate UUID if not provided
+	if we.ID == uuid.Nil {
+		we.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name for the WorkflowExecution model
+func (WorkflowExecution) TableName() string {
+	return "workflow_executions"
+}
+
+// CreateWorkflowRequest represents the request payload for defining a workflow
+type CreateWorkflowRequest struct {
+	Name        string        `json:"name" validate:"required"`
+	Description string        `json:"description"`
+	Graph       WorkflowGraph `json:"graph" validate:"required"`
+	IsActive    *bool         `json:"is_active"`
+}
+
+// UpdateWorkflowRequest represents the request payload for updating a workflow
+// Executions already running keep the graph they started with
+type UpdateWorkflowRequest struct {
+	Description *string        `json:"description"`
+	Graph       *WorkflowGraph `json:"graph"`
+	IsActive    *bool          `json:"is_active"`
+}
+
+// TriggerWorkflowRequest represents the request payload for triggering a workflow
+type TriggerWorkflowRequest struct {
+	Parameters JobConfig `json:"parameters"`
+}
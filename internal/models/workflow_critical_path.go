@@ -0,0 +1,119 @@
+package models
+
+import "time"
+
+// WorkflowNodeSchedule is when a node of a workflow run can start and finish relative to the run's start,
+// if every node starts as soon as the nodes it depends on have completed
+// Slack is how much later the node could finish without delaying the run past its deadline, or past the
+// critical path when there is no deadline. Negative slack is how much too late the node finishes
+type WorkflowNodeSchedule struct {
+	Key            string
+	Duration       time.Duration
+	Estimated      bool
+	EarliestStart  time.Duration
+	EarliestFinish time.Duration
+	LatestStart    time.Duration
+	LatestFinish   time.Duration
+	Slack          time.Duration
+	Critical       bool
+}
+
+// WorkflowCriticalPath is the longest chain of dependent nodes through a workflow run, which decides how
+// long the run takes, along with every node's schedule and slack
+type WorkflowCriticalPath struct {
+	Path     []string
+	Duration time.Duration
+	// Deadline is how long the run may take, if one was given; Overrun is how much longer than it the
+	// critical path takes, so how much it must be cut by
+	Deadline time.Duration
+	Overrun  time.Duration
+	Nodes    []WorkflowNodeSchedule
+}
+
+// CriticalPath works out the critical path through the graph given how long each node takes, with nodes
+// missing from durations taking no time. A deadline of 0 means none
+// Nodes are in the order they can run. The critical path runs from a node without upstream nodes to
+// the node finishing last, the latest in that order if several do; of upstream nodes finishing
+// together, it goes through the earliest in that order
+func (g WorkflowGraph) CriticalPath(durations map[string]time.Duration, deadline time.Duration) WorkflowCriticalPath {
+	order := g.TopologicalOrder()
+	schedules := make(map[string]*WorkflowNodeSchedule, len(order))
+
+	// Forward pass: each node starts once the last of its upstream nodes finishes
+	var length time.Duration
+	for _, key := range order {
+		schedule := &WorkflowNodeSchedule{Key: key, Duration: durations[key]}
+		for _, upstream := range g.Upstream(key) {
+			if finish := schedules[upstream].EarliestFinish; finish > schedule.EarliestStart {
+				schedule.EarliestStart = finish
+			}
+		}
+		schedule.EarliestFinish = schedule.EarliestStart + schedule.Duration
+		if schedule.EarliestFinish > length {
+			length = schedule.EarliestFinish
+		}
+		schedules[key] = schedule
+	}
+
+	// Backward pass: each node must finish by the time the first of its downstream nodes has to start
+	horizon := length
+	if deadline > 0 {
+		horizon = deadline
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		schedule := schedules[order[i]]
+		schedule.LatestFinish = horizon
+		for _, downstream := range g.Downstream(schedule.Key) {
+			if start := schedules[downstream].LatestStart; start < schedule.LatestFinish {
+				schedule.LatestFinish = start
+			}
+		}
+		schedule.LatestStart = schedule.LatestFinish - schedule.Duration
+		schedule.Slack = schedule.LatestStart - schedule.EarliestStart
+		schedule.Critical = schedule.Slack == horizon-length
+	}
+
+	result := WorkflowCriticalPath{
+		Duration: length,
+		Nodes:    make([]WorkflowNodeSchedule, 0, len(order)),
+	}
+	if deadline > 0 {
+		result.Deadline = deadline
+		if length > deadline {
+			result.Overrun = length - deadline
+		}
+	}
+
+	// Walk back from the node finishing last through the upstream nodes holding it up
+	var last *WorkflowNodeSchedule
+	for _, key := range order {
+		if schedule := schedules[key]; schedule.EarliestFinish == length {
+			last = schedule
+		}
+	}
+	for last != nil {
+		result.Path = append([]string{last.Key}, result.Path...)
+		current := last
+		last = nil
+		for _, upstream := range g.Upstream(current.Key) {
+			if schedule := schedules[upstream]; schedule.EarliestFinish == current.EarliestStart && (last == nil || indexOfKey(order, upstream) < indexOfKey(order, last.Key)) {
+				last = schedule
+			}
+		}
+	}
+
+	for _, key := range order {
+		result.Nodes = append(result.Nodes, *schedules[key])
+	}
+	return result
+}
+
+// indexOfKey returns where a key is in a list of keys, or -1
+func indexOfKey(keys []string, key string) int {
+	for i, k := range keys {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
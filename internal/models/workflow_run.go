@@ -0,0 +1,76 @@
+package models
+
+import "sort"
+
+// WorkflowRun is one run of a workflow as a tree: the workflow execution, each of its nodes and each
+// node's job runs, retries included, so it shows where a run is stuck or failed
+type WorkflowRun struct {
+	Execution    WorkflowExecution
+	WorkflowName string
+	Nodes        []WorkflowRunNode
+}
+
+// WorkflowRunNode is one node of a workflow run and the job runs it started
+type WorkflowRunNode struct {
+	Key   string
+	State WorkflowNodeState
+	// DependsOn are the nodes this one waits for; WaitingOn are those still to complete while it's pending
+	DependsOn []string
+	WaitingOn []string
+	// Runs are the node's job runs, oldest first
+	Runs []JobExecution
+}
+
+// NewWorkflowRun builds the tree of a workflow execution run over the given graph, from the job runs
+// its nodes started. Nodes are in the order they can run, with nodes the graph doesn't have last
+func NewWorkflowRun(execution *WorkflowExecution, workflowName string, graph WorkflowGraph, runs []JobExecution) *WorkflowRun {
+	runsByNode := make(map[string][]JobExecution)
+	for _, run := range runs {
+		if key, ok := run.Parameters["workflow_node"].(string); ok {
+			runsByNode[key] = append(runsByNode[key], run)
+		}
+	}
+
+	keys := make([]string, 0, len(execution.Nodes))
+	seen := make(map[string]bool, len(execution.Nodes))
+	for _, key := range graph.TopologicalOrder() {
+		if _, ok := execution.Nodes[key]; ok {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+	var rest []string
+	for key := range execution.Nodes {
+		if !seen[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	keys = append(keys, rest...)
+
+	tree := &WorkflowRun{
+		Execution:    *execution,
+		WorkflowName: workflowName,
+		Nodes:        make([]WorkflowRunNode, 0, len(keys)),
+	}
+	for _, key := range keys {
+		state := execution.Nodes[key]
+		node := WorkflowRunNode{
+			Key:   key,
+			State: *state,
+			Runs:  runsByNode[key],
+		}
+		for _, upstream := range graph.Upstream(key) {
+			upstreamState, ok := execution.Nodes[upstream]
+			if !ok {
+				continue
+			}
+			node.DependsOn = append(node.DependsOn, upstream)
+			if state.Status == WorkflowNodeStatusPending && upstreamState.Status != WorkflowNodeStatusCompleted {
+				node.WaitingOn = append(node.WaitingOn, upstream)
+			}
+		}
+		tree.Nodes = append(tree.Nodes, node)
+	}
+	return tree
+}
@@ -0,0 +1,82 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+)
+
+// EmailNotifier sends notifications as HTML email over SMTP
+type EmailNotifier struct {
+	addr     string
+	auth     smtp.Auth
+	from     string
+	to       []string
+	renderer *Renderer
+}
+
+// NewEmailNotifier creates a new email notifier
+// renderer may be nil, in which case the built-in format is always used
+func NewEmailNotifier(cfg config.NotificationsConfig, renderer *Renderer) *EmailNotifier {
+	var auth smtp.Auth
+	if cfg.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPHost)
+	}
+
+	return &EmailNotifier{
+		addr:     net.JoinHostPort(cfg.SMTPHost, strconv.Itoa(cfg.SMTPPort)),
+		auth:     auth,
+		from:     cfg.EmailFrom,
+		to:       cfg.EmailTo,
+		renderer: renderer,
+	}
+}
+
+// Notify sends the notification, using the email template when one applies
+func (e *EmailNotifier) Notify(ctx context.Context, n Notification) error {
+	subject, body := n.Title, defaultEmailBody(n)
+	if rendered, ok := e.renderer.Render(models.TemplateChannelEmail, n); ok {
+		body = rendered.Body
+		if rendered.Subject != "" {
+			subject = rendered.Subject
+		}
+	}
+
+	// Header values must not contain line breaks
+	subject = strings.NewReplacer("\r", " ", "\n", " ").Replace(subject)
+
+	message := strings.Join([]string{
+		"From: " + e.from,
+		"To: " + strings.Join(e.to, ", "),
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/html; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	if err := smtp.SendMail(e.addr, e.auth, e.from, e.to, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send notification email: %w", err)
+	}
+	return nil
+}
+
+// defaultEmailBody renders the built-in HTML body
+func defaultEmailBody(n Notification) string {
+	body := fmt.Sprintf("<h2>%s</h2><p>%s</p>", html.EscapeString(n.Title), html.EscapeString(n.Message))
+	if n.Job != nil && n.Job.RunbookURL != "" {
+		runbook := html.EscapeString(n.Job.RunbookURL)
+		body += fmt.Sprintf(`<p>Runbook: <a href="%s">%s</a></p>`, runbook, runbook)
+	}
+	for _, action := range n.Actions {
+		body += fmt.Sprintf(`<p><a href="%s">%s</a></p>`, html.EscapeString(action.URL), html.EscapeString(action.Label))
+	}
+	return body
+}
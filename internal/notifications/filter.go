@@ -0,0 +1,90 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/expressions"
+	"job-scheduler/internal/models"
+)
+
+// ParseNotificationFilter compiles the job's notification filter from its config, a CEL expression
+// over the notification's event, job, execution and fields deciding whether it is delivered:
+//
+//	"notification_filter": "event != 'timeout_warning' && execution.attempt >= 2"
+//
+// It returns nil when the job has no filter
+func ParseNotificationFilter(config models.JobConfig) (*expressions.Expression, error) {
+	raw, ok := config["notification_filter"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	source, ok := raw.(string)
+	if !ok {
+		return nil, fmt.Errorf("notification_filter must be a CEL expression")
+	}
+	return expressions.NotificationFilter.CompileCondition(source)
+}
+
+// FilteringNotifier delivers a job's notifications only when they match the job's notification filter
+// Notifications about no job, and about jobs without a filter, are always delivered
+type FilteringNotifier struct {
+	next Notifier
+}
+
+// NewFilteringNotifier creates a notifier passing the notifications jobs' filters match on to next
+func NewFilteringNotifier(next Notifier) *FilteringNotifier {
+	return &FilteringNotifier{next: next}
+}
+
+// Notify delivers the notification unless its job's filter drops it
+func (f *FilteringNotifier) Notify(ctx context.Context, n Notification) error {
+	if n.Job != nil && !f.matches(ctx, n) {
+		return nil
+	}
+	return f.next.Notify(ctx, n)
+}
+
+// matches returns true if the job's filter matches the notification
+// A filter that doesn't compile or evaluate lets the notification through rather than lose it
+func (f *FilteringNotifier) matches(ctx context.Context, n Notification) bool {
+	log := logrus.WithFields(logrus.Fields{
+		"job_id": n.Job.ID,
+		"event":  n.Event,
+	})
+	filter, err := ParseNotificationFilter(n.Job.Config)
+	if err != nil {
+		log.WithError(err).Warn("Invalid notification filter - delivering notification")
+		return true
+	}
+	if filter == nil {
+		return true
+	}
+
+	payload := n.toPayload()
+	fields := n.Fields
+	if fields == nil {
+		fields = map[string]interface{}{}
+	}
+	vars := map[string]interface{}{
+		"event":     string(n.Event),
+		"job":       payload.Job,
+		"execution": map[string]interface{}{},
+		"fields":    fields,
+	}
+	if payload.Execution != nil {
+		vars["execution"] = payload.Execution
+	}
+
+	matched, err := filter.EvalBool(ctx, vars)
+	if err != nil {
+		log.WithError(err).Warn("Notification filter failed - delivering notification")
+		return true
+	}
+	if !matched {
+		log.Debug("Notification dropped by the job's notification filter")
+	}
+	return matched
+}
@@ -0,0 +1,240 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/httpclient"
+	"job-scheduler/internal/models"
+)
+
+// Event identifies why a notification is sent
+type Event string
+
+const (
+	EventApprovalRequested Event = "approval_requested"
+	EventApprovalExpired   Event = "approval_expired"
+	EventDurationExceeded  Event = "duration_exceeded"
+	EventJobFailed         Event = "job_failed"
+	EventOverloaded        Event = "overloaded"
+	EventTimeoutWarning    Event = "timeout_warning"
+)
+
+// Notification is a message about a job or one of its runs
+type Notification struct {
+	Event     Event
+	Title     string
+	Message   string
+	Job       *models.Job
+	Execution *models.JobExecution
+	Fields    map[string]interface{}
+	// Actions are signed links that apply the job's remediation actions to the failed run
+	Actions   []models.RemediationLink
+	Timestamp time.Time
+}
+
+// payload is the JSON body posted to webhook channels
+type payload struct {
+	Event     Event                    `json:"event"`
+	Title     string                   `json:"title"`
+	Message   string                   `json:"message"`
+	Job       *dto.JobResponse         `json:"job,omitempty"`
+	Execution *dto.ExecutionResponse   `json:"execution,omitempty"`
+	Fields    map[string]interface{}   `json:"fields,omitempty"`
+	Actions   []models.RemediationLink `json:"actions,omitempty"`
+	Timestamp time.Time                `json:"timestamp"`
+}
+
+// toPayload maps the notification to its public JSON representation
+func (n Notification) toPayload() payload {
+	p := payload{
+		Event:     n.Event,
+		Title:     n.Title,
+		Message:   n.Message,
+		Fields:    n.Fields,
+		Actions:   n.Actions,
+		Timestamp: n.Timestamp,
+	}
+	if n.Job != nil {
+		job := dto.FromJob(n.Job)
+		p.Job = &job
+	}
+	if n.Execution != nil {
+		execution := dto.FromExecution(n.Execution)
+		p.Execution = &execution
+	}
+	return p
+}
+
+// Notifier delivers notifications to a channel
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// NewFromConfig builds the notifier configured for this instance
+// Notifications are always logged. When channels is set, notifications for jobs
+// whose team has a channel go to that channel; all others go to the configured
+// default webhook, Slack and email channels. When templates is set, stored
+// templates customize the content sent to each channel. Webhook and Slack
+// deliveries use clients from the shared pool. Notifications a job's
+// notification filter drops are logged but not delivered
+func NewFromConfig(cfg *config.Config, channels ChannelLookup, templates TemplateLookup, clients *httpclient.Factory) Notifier {
+	var renderer *Renderer
+	if templates != nil {
+		renderer = NewRenderer(templates)
+	}
+
+	var defaults MultiNotifier
+	if cfg.Notifications.WebhookURL != "" {
+		defaults = append(defaults, NewWebhookNotifier(cfg.Notifications.WebhookURL, clients.Client("notification_webhook", cfg.Notifications.Timeout), renderer))
+	}
+	if cfg.Notifications.SlackWebhookURL != "" {
+		defaults = append(defaults, NewSlackNotifier(cfg.Notifications.SlackWebhookURL, clients.Client("notification_slack", cfg.Notifications.Timeout), renderer))
+	}
+	if cfg.Notifications.SMTPHost != "" && len(cfg.Notifications.EmailTo) > 0 {
+		defaults = append(defaults, NewEmailNotifier(cfg.Notifications, renderer))
+	}
+
+	var delivery Notifier = defaults
+	if channels != nil {
+		delivery = NewRoutingNotifier(channels, defaults, clients.Client("notification_team_channel", cfg.Notifications.Timeout), renderer)
+	}
+	return MultiNotifier{&LogNotifier{}, NewFilteringNotifier(delivery)}
+}
+
+// MultiNotifier fans a notification out to several notifiers
+type MultiNotifier []Notifier
+
+// Notify sends to every notifier and returns the first error
+func (m MultiNotifier) Notify(ctx context.Context, n Notification) error {
+	var firstErr error
+	for _, notifier := range m {
+		if err := notifier.Notify(ctx, n); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// LogNotifier writes notifications to the application log
+type LogNotifier struct{}
+
+// Notify logs the notification
+func (l *LogNotifier) Notify(ctx context.Context, n Notification) error {
+	fields := logrus.Fields{
+		"event": n.Event,
+	}
+	if n.Job != nil {
+		fields["job_id"] = n.Job.ID
+		fields["job_name"] = n.Job.Name
+		fields["severity"] = n.Job.Severity
+		if n.Job.RunbookURL != "" {
+			fields["runbook_url"] = n.Job.RunbookURL
+		}
+	}
+	if n.Execution != nil {
+		fields["execution_id"] = n.Execution.ID
+	}
+
+	logrus.WithFields(fields).Infof("Notification: %s - %s", n.Title, n.Message)
+	return nil
+}
+
+// WebhookNotifier posts notifications as JSON to a URL
+type WebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+	renderer   *Renderer
+}
+
+// NewWebhookNotifier creates a new webhook notifier
+// renderer may be nil, in which case the built-in payload is always used
+func NewWebhookNotifier(url string, httpClient *http.Client, renderer *Renderer) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:        url,
+		httpClient: httpClient,
+		renderer:   renderer,
+	}
+}
+
+// Notify posts the notification, using the webhook template when one applies
+func (w *WebhookNotifier) Notify(ctx context.Context, n Notification) error {
+	if rendered, ok := w.renderer.Render(models.TemplateChannelWebhook, n); ok {
+		return postJSON(ctx, w.httpClient, w.url, []byte(rendered.Body))
+	}
+
+	body, err := json.Marshal(n.toPayload())
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	return postJSON(ctx, w.httpClient, w.url, body)
+}
+
+// SlackNotifier posts notifications as text messages to a Slack incoming webhook
+type SlackNotifier struct {
+	url        string
+	httpClient *http.Client
+	renderer   *Renderer
+}
+
+// NewSlackNotifier creates a new Slack notifier
+// renderer may be nil, in which case the built-in message is always used
+func NewSlackNotifier(url string, httpClient *http.Client, renderer *Renderer) *SlackNotifier {
+	return &SlackNotifier{
+		url:        url,
+		httpClient: httpClient,
+		renderer:   renderer,
+	}
+}
+
+// Notify posts the notification as a Slack message, using the Slack template
+// (e.g. blocks) when one applies
+func (s *SlackNotifier) Notify(ctx context.Context, n Notification) error {
+	if rendered, ok := s.renderer.Render(models.TemplateChannelSlack, n); ok {
+		return postJSON(ctx, s.httpClient, s.url, []byte(rendered.Body))
+	}
+
+	text := fmt.Sprintf("*%s*\n%s", n.Title, n.Message)
+	if n.Job != nil && n.Job.RunbookURL != "" {
+		text = fmt.Sprintf("%s\nRunbook: %s", text, n.Job.RunbookURL)
+	}
+	for _, action := range n.Actions {
+		text = fmt.Sprintf("%s\n<%s|%s>", text, action.URL, action.Label)
+	}
+
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	return postJSON(ctx, s.httpClient, s.url, body)
+}
+
+// postJSON posts a JSON body and treats non-2xx responses as errors
+func postJSON(ctx context.Context, httpClient *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("notification webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
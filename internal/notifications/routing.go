@@ -0,0 +1,72 @@
+package notifications
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+)
+
+// ChannelLookup finds the channel configured for a team
+// It returns nil when the team has no channel
+type ChannelLookup interface {
+	FindByTeam(team string) (*models.TeamChannel, error)
+}
+
+// RoutingNotifier sends each notification to the channel of the job's team,
+// falling back to the default notifier when the team has no active channel
+type RoutingNotifier struct {
+	channels   ChannelLookup
+	fallback   Notifier
+	httpClient *http.Client
+	renderer   *Renderer
+}
+
+// NewRoutingNotifier creates a new routing notifier
+// Team channels are delivered with httpClient
+func NewRoutingNotifier(channels ChannelLookup, fallback Notifier, httpClient *http.Client, renderer *Renderer) *RoutingNotifier {
+	return &RoutingNotifier{
+		channels:   channels,
+		fallback:   fallback,
+		httpClient: httpClient,
+		renderer:   renderer,
+	}
+}
+
+// Notify routes the notification to the team channel or the fallback
+func (r *RoutingNotifier) Notify(ctx context.Context, n Notification) error {
+	if notifier := r.teamNotifier(n.Job); notifier != nil {
+		return notifier.Notify(ctx, n)
+	}
+	return r.fallback.Notify(ctx, n)
+}
+
+// teamNotifier returns a notifier for the job's team channel, or nil to use the fallback
+func (r *RoutingNotifier) teamNotifier(job *models.Job) Notifier {
+	if job == nil || job.Team == "" {
+		return nil
+	}
+
+	channel, err := r.channels.FindByTeam(job.Team)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"team":  job.Team,
+			"error": err,
+		}).Warn("Failed to look up team channel - using default channel")
+		return nil
+	}
+	if channel == nil || !channel.IsActive {
+		return nil
+	}
+
+	switch channel.Kind {
+	case models.ChannelKindSlack:
+		return &SlackNotifier{url: channel.URL, httpClient: r.httpClient, renderer: r.renderer}
+	case models.ChannelKindWebhook:
+		return &WebhookNotifier{url: channel.URL, httpClient: r.httpClient, renderer: r.renderer}
+	default:
+		return nil
+	}
+}
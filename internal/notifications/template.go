@@ -0,0 +1,219 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+)
+
+// TemplateLookup finds the stored template for a channel and event
+// It returns nil when no template applies
+type TemplateLookup interface {
+	FindTemplate(channel models.TemplateChannel, event string) (*models.NotificationTemplate, error)
+}
+
+// TemplateData is the data available to notification templates, e.g.
+// {{.Job.Name}}, {{.Execution.Status}}, {{.Error}}, {{.Duration}},
+// {{range .Actions}}{{.Label}}: {{.URL}}{{end}}
+type TemplateData struct {
+	Event     Event
+	Title     string
+	Message   string
+	Job       *dto.JobResponse
+	Execution *dto.ExecutionResponse
+	Error     string
+	Duration  string
+	Fields    map[string]interface{}
+	Actions   []models.RemediationLink
+	Timestamp time.Time
+}
+
+// Rendered is the output of a notification template
+type Rendered struct {
+	Subject string
+	Body    string
+}
+
+// templateFuncs are available in every template
+// json encodes a value as JSON, e.g. "text": {{json .Error}} in JSON payloads
+var templateFuncs = map[string]interface{}{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"upper": strings.ToUpper,
+}
+
+// Renderer renders notifications with stored templates
+type Renderer struct {
+	templates TemplateLookup
+}
+
+// NewRenderer creates a new renderer
+func NewRenderer(templates TemplateLookup) *Renderer {
+	return &Renderer{
+		templates: templates,
+	}
+}
+
+// Render renders the notification with the channel's template for its event
+// ok is false when no template applies or rendering fails, and the notifier
+// should use its built-in format
+func (r *Renderer) Render(channel models.TemplateChannel, n Notification) (*Rendered, bool) {
+	if r == nil || r.templates == nil {
+		return nil, false
+	}
+
+	tmpl, err := r.templates.FindTemplate(channel, string(n.Event))
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to look up notification template - using default format")
+		return nil, false
+	}
+	if tmpl == nil {
+		return nil, false
+	}
+
+	rendered, err := renderTemplate(channel, tmpl.Subject, tmpl.Body, newTemplateData(n))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": tmpl.Name,
+			"error":    err,
+		}).Warn("Failed to render notification template - using default format")
+		return nil, false
+	}
+	return rendered, true
+}
+
+// ValidateTemplate checks that a template parses and renders sample data,
+// and that JSON channels produce valid JSON
+func ValidateTemplate(channel models.TemplateChannel, subject, body string) error {
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("template body is required")
+	}
+
+	rendered, err := renderTemplate(channel, subject, body, sampleTemplateData())
+	if err != nil {
+		return err
+	}
+
+	if channel == models.TemplateChannelSlack || channel == models.TemplateChannelWebhook {
+		if !json.Valid([]byte(rendered.Body)) {
+			return fmt.Errorf("%s template must render valid JSON", channel)
+		}
+	}
+	return nil
+}
+
+// renderTemplate renders the subject and body; email bodies are HTML-escaped
+func renderTemplate(channel models.TemplateChannel, subject, body string, data TemplateData) (*Rendered, error) {
+	rendered := &Rendered{}
+
+	if subject != "" {
+		text, err := renderText("subject", subject, data)
+		if err != nil {
+			return nil, err
+		}
+		rendered.Subject = text
+	}
+
+	if channel == models.TemplateChannelEmail {
+		tmpl, err := htmltemplate.New("body").Funcs(templateFuncs).Parse(body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid body template: %w", err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render body template: %w", err)
+		}
+		rendered.Body = buf.String()
+		return rendered, nil
+	}
+
+	text, err := renderText("body", body, data)
+	if err != nil {
+		return nil, err
+	}
+	rendered.Body = text
+	return rendered, nil
+}
+
+// renderText renders a text template
+func renderText(name, source string, data TemplateData) (string, error) {
+	tmpl, err := texttemplate.New(name).Funcs(templateFuncs).Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s template: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// newTemplateData builds the template data for a notification
+func newTemplateData(n Notification) TemplateData {
+	p := n.toPayload()
+	data := TemplateData{
+		Event:     n.Event,
+		Title:     n.Title,
+		Message:   n.Message,
+		Job:       p.Job,
+		Execution: p.Execution,
+		Fields:    n.Fields,
+		Actions:   n.Actions,
+		Timestamp: n.Timestamp,
+	}
+	if n.Execution != nil {
+		data.Duration = n.Execution.GetDurationString()
+		if n.Execution.ErrorMessage != nil {
+			data.Error = n.Execution.ErrorMessage.String()
+		}
+	}
+	return data
+}
+
+// sampleTemplateData is used to validate templates on save
+func sampleTemplateData() TemplateData {
+	duration := int64(1500)
+	now := time.Now().UTC()
+	job := &models.Job{
+		ID:         uuid.New(),
+		Name:       "Sample Job",
+		Schedule:   "0 9 * * *",
+		JobType:    models.JobTypeHealthCheck,
+		Severity:   models.JobSeverityHigh,
+		Team:       "platform",
+		RunbookURL: "https://wiki.example.com/runbooks/sample",
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	execution := &models.JobExecution{
+		ID:                uuid.New(),
+		JobID:             job.ID,
+		Status:            models.ExecutionStatusFailed,
+		StartedAt:         now,
+		CompletedAt:       &now,
+		ExecutionDuration: &duration,
+		ErrorMessage:      models.NewCompressedText("health check returned status 503"),
+	}
+
+	return newTemplateData(Notification{
+		Event:     EventJobFailed,
+		Title:     "Job failed: Sample Job",
+		Message:   "Run failed: health check returned status 503",
+		Job:       job,
+		Execution: execution,
+		Fields:    map[string]interface{}{"severity": job.Severity, "runbook_url": job.RunbookURL},
+		Timestamp: now,
+	})
+}
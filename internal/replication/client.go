@@ -0,0 +1,95 @@
+// Package replication keeps a standby region's job definitions in step with a primary's, so a
+// failover doesn't depend on restoring the database by hand
+package replication
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+)
+
+// requestTimeout bounds each request to the primary
+const requestTimeout = 30 * time.Second
+
+// Client reads a primary's job changes
+type Client interface {
+	// Changes returns up to limit changes after a sequence
+	Changes(ctx context.Context, after int64, limit int) (*models.JobChangeFeed, error)
+	// Snapshot returns every job on the primary
+	Snapshot(ctx context.Context) (*models.JobSnapshot, error)
+}
+
+// apiClient implements Client over the primary's replication API
+type apiClient struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient creates a client of the primary in the configuration
+func NewClient(cfg config.ReplicationConfig) Client {
+	return &apiClient{
+		baseURL:    strings.TrimRight(cfg.PrimaryURL, "/"),
+		token:      cfg.Token,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Changes returns up to limit changes after a sequence
+func (c *apiClient) Changes(ctx context.Context, after int64, limit int) (*models.JobChangeFeed, error) {
+	query := url.Values{}
+	query.Set("after", strconv.FormatInt(after, 10))
+	query.Set("limit", strconv.Itoa(limit))
+
+	var feed models.JobChangeFeed
+	if err := c.get(ctx, "/api/v1/replication/changes?"+query.Encode(), &feed); err != nil {
+		return nil, fmt.Errorf("failed to get job changes: %w", err)
+	}
+	return &feed, nil
+}
+
+// Snapshot returns every job on the primary
+func (c *apiClient) Snapshot(ctx context.Context) (*models.JobSnapshot, error) {
+	var snapshot models.JobSnapshot
+	if err := c.get(ctx, "/api/v1/replication/snapshot", &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to get job snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// get decodes the JSON response of a GET request, returning an error for responses that aren't successful
+func (c *apiClient) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var body struct {
+			Error string `json:"error"`
+		}
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		if err := json.Unmarshal(data, &body); err != nil || body.Error == "" {
+			body.Error = http.StatusText(resp.StatusCode)
+		}
+		return fmt.Errorf("primary returned %d: %s", resp.StatusCode, body.Error)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,206 @@
+package replication
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// batchSize is how many changes a standby asks for at a time
+const batchSize = 500
+
+// Scheduler is the scheduler a standby keeps stopped until it is promoted
+type Scheduler interface {
+	Start() error
+}
+
+// Standby follows a primary's job changes with its scheduler stopped, until it is promoted to run
+// the jobs itself
+type Standby struct {
+	client      Client
+	replication services.ReplicationService
+	scheduler   Scheduler
+	config      config.ReplicationConfig
+
+	// syncMu is held while changes are applied, so promoting waits for the batch being applied
+	syncMu sync.Mutex
+	mu     sync.RWMutex
+	// latestSequence is the primary's latest change when it was last asked
+	latestSequence int64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewStandby creates a new standby
+func NewStandby(client Client, replication services.ReplicationService, scheduler Scheduler, cfg *config.Config) *Standby {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Standby{
+		client:      client,
+		replication: replication,
+		scheduler:   scheduler,
+		config:      cfg.Replication,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// Start follows the primary in the background, or starts the scheduler if the standby was promoted
+// Instances that aren't standbys start their scheduler as usual, so Start does nothing for them
+func (s *Standby) Start() error {
+	if s.config.Role != config.ReplicationRoleStandby {
+		logrus.Info("Replication standby disabled")
+		return nil
+	}
+
+	state, err := s.replication.GetState()
+	if err != nil {
+		return err
+	}
+	if state.IsPromoted() {
+		logrus.WithField("promoted_at", state.PromotedAt).Warn("Standby was promoted - starting scheduler")
+		return s.scheduler.Start()
+	}
+
+	s.wg.Add(1)
+	go s.follow()
+
+	logrus.WithField("primary", s.config.PrimaryURL).Info("Replication standby started - scheduler paused")
+	return nil
+}
+
+// Stop stops following the primary
+func (s *Standby) Stop() {
+	s.cancel()
+	s.wg.Wait()
+	logrus.Info("Replication standby stopped")
+}
+
+// follow applies the primary's changes every poll interval until the standby stops or is promoted
+func (s *Standby) follow() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Sync(s.ctx); err != nil && s.ctx.Err() == nil {
+			logrus.WithError(err).Error("Failed to sync job changes from primary")
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync applies the primary's changes since the last one applied, returning how many were applied
+// A standby that hasn't synced before starts from a snapshot of the primary's jobs
+func (s *Standby) Sync(ctx context.Context) (int, error) {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	state, err := s.replication.GetState()
+	if err != nil {
+		return 0, err
+	}
+	if state.IsPromoted() {
+		return 0, services.ErrAlreadyPromoted
+	}
+
+	after := state.LastSequence
+	if state.LastSyncedAt == nil {
+		snapshot, err := s.client.Snapshot(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if err := s.replication.ApplySnapshot(snapshot); err != nil {
+			return 0, err
+		}
+		after = snapshot.Sequence
+	}
+
+	applied := 0
+	for {
+		feed, err := s.client.Changes(ctx, after, batchSize)
+		if err != nil {
+			return applied, err
+		}
+		s.mu.Lock()
+		s.latestSequence = feed.LatestSequence
+		s.mu.Unlock()
+
+		for i := range feed.Changes {
+			if err := s.replication.ApplyChange(&feed.Changes[i]); err != nil {
+				return applied, err
+			}
+			after = feed.Changes[i].Sequence
+			applied++
+		}
+		if len(feed.Changes) < batchSize {
+			break
+		}
+	}
+
+	if applied > 0 {
+		logrus.WithFields(logrus.Fields{
+			"applied":       applied,
+			"last_sequence": after,
+		}).Info("Job changes applied from primary")
+	}
+	return applied, nil
+}
+
+// Promote stops following the primary and starts the scheduler, so this region runs the jobs
+// The promotion is recorded, so the standby stays promoted if it restarts
+func (s *Standby) Promote(actor string) (*models.ReplicationStatus, error) {
+	if s.config.Role != config.ReplicationRoleStandby {
+		return nil, services.ErrNotStandby
+	}
+
+	s.cancel()
+	s.wg.Wait()
+
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+	if _, err := s.replication.Promote(actor); err != nil {
+		return nil, err
+	}
+	if err := s.scheduler.Start(); err != nil {
+		return nil, err
+	}
+	return s.Status()
+}
+
+// Status reports how far the standby has followed its primary
+func (s *Standby) Status() (*models.ReplicationStatus, error) {
+	state, err := s.replication.GetState()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	latest := s.latestSequence
+	s.mu.RUnlock()
+
+	status := &models.ReplicationStatus{
+		Role:             s.config.Role,
+		PrimaryURL:       s.config.PrimaryURL,
+		ReplicationState: *state,
+		LatestSequence:   latest,
+	}
+	if latest > state.LastSequence && !state.IsPromoted() {
+		status.Lag = latest - state.LastSequence
+	}
+	return status, nil
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"job-scheduler/internal/models"
+)
+
+// AlertRuleRepository defines the interface for alert rule data operations
+type AlertRuleRepository interface {
+	Create(rule *models.AlertRule) error
+	GetByID(id uuid.UUID) (*models.AlertRule, error)
+	FindByName(name string) (*models.AlertRule, error)
+	GetAll() ([]models.AlertRule, error)
+	GetActive() ([]models.AlertRule, error)
+	Update(rule *models.AlertRule) error
+	Delete(id uuid.UUID) error
+}
+
+// alertRuleRepository implements AlertRuleRepository interface
+type alertRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewAlertRuleRepository creates a new alert rule repository
+func NewAlertRuleRepository(db *gorm.DB) AlertRuleRepository {
+	return &alertRuleRepository{
+		db: db,
+	}
+}
+
+// Create creates a new alert rule in the database
+func (r *alertRuleRepository) Create(rule *models.AlertRule) error {
+	if err := r.db.Create(rule).Error; err != nil {
+		return fmt.Errorf("failed to create alert rule: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an alert rule by its ID
+func (r *alertRuleRepository) GetByID(id uuid.UUID) (*models.AlertRule, error) {
+	var rule models.AlertRule
+	err := r.db.Where("id = ?", id).First(&rule).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("alert rule with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get alert rule by ID: %w", err)
+	}
+	return &rule, nil
+}
+
+// FindByName retrieves an alert rule by its name, returning nil when there is none
+func (r *alertRuleRepository) FindByName(name string) (*models.AlertRule, error) {
+	var rule models.AlertRule
+	err := r.db.Where("name = ?", name).First(&rule).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get alert rule: %w", err)
+	}
+	return &rule, nil
+}
+
+// GetAll retrieves all alert rules
+func (r *alertRuleRepository) GetAll() ([]models.AlertRule, error) {
+	var rules []models.AlertRule
+	err := r.db.Order("name ASC").Find(&rules).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert rules: %w", err)
+	}
+	return rules, nil
+}
+
+// GetActive retrieves the alert rules that are evaluated
+func (r *alertRuleRepository) GetActive() ([]models.AlertRule, error) {
+	var rules []models.AlertRule
+	err := r.db.Where("is_active = ?", true).Order("name ASC").Find(&rules).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active alert rules: %w", err)
+	}
+	return rules, nil
+}
+
+// Update updates an existing alert rule
+func (r *alertRuleRepository) Update(rule *models.AlertRule) error {
+	result := r.db.Save(rule)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update alert rule: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("alert rule with ID %s not found", rule.ID)
+	}
+
+	return nil
+}
+
+// Delete deletes an alert rule by its ID
+func (r *alertRuleRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.AlertRule{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete alert rule: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("alert rule with ID %s not found", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"job-scheduler/internal/models"
+)
+
+// ArtifactRepository defines the interface for artifact data operations
+type ArtifactRepository interface {
+	Create(artifact *models.Artifact) error
+	GetByID(id uuid.UUID) (*models.Artifact, error)
+	GetByJobID(jobID uuid.UUID, kind models.ArtifactKind, limit int) ([]models.Artifact, error)
+	GetByExecutionID(executionID uuid.UUID) ([]models.Artifact, error)
+	GetExpired(before time.Time, limit int) ([]models.Artifact, error)
+	GetOldestByJob(jobID uuid.UUID, limit int) ([]models.Artifact, error)
+	GetOldestByTeam(team string, limit int) ([]models.Artifact, error)
+	SumBytesByJob(jobID uuid.UUID) (int64, error)
+	SumBytesByTeam(team string) (int64, error)
+	GetUsageByJob(limit int) ([]models.ArtifactUsage, error)
+	GetUsageByTeam() ([]models.TeamArtifactUsage, error)
+	Delete(id uuid.UUID) error
+}
+
+// artifactRepository implements ArtifactRepository interface
+type artifactRepository struct {
+	db *gorm.DB
+}
+
+// NewArtifactRepository creates a new artifact repository
+func NewArtifactRepository(db *gorm.DB) ArtifactRepository {
+	return &artifactRepository{
+		db: db,
+	}
+}
+
+// Create creates a new artifact in the database
+func (r *artifactRepository) Create(artifact *models.Artifact) error {
+	if err := r.db.Create(artifact).Error; err != nil {
+		return fmt.Errorf("failed to create artifact: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an artifact by its ID
+func (r *artifactRepository) GetByID(id uuid.UUID) (*models.Artifact, error) {
+	var artifact models.Artifact
+	err := r.db.Where("id = ?", id).First(&artifact).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("artifact with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get artifact by ID: %w", err)
+	}
+	return &artifact, nil
+}
+
+// GetByJobID retrieves a job's most recent artifacts of a kind, newest first
+func (r *artifactRepository) GetByJobID(jobID uuid.UUID, kind models.ArtifactKind, limit int) ([]models.Artifact, error) {
+	var artifacts []models.Artifact
+	err := r.db.Where("job_id = ? AND kind = ?", jobID, kind).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&artifacts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifacts by job ID: %w", err)
+	}
+	return artifacts, nil
+}
+
+// GetByExecutionID retrieves all artifacts produced by an execution
+func (r *artifactRepository) GetByExecutionID(executionID uuid.UUID) ([]models.Artifact, error) {
+	var artifacts []models.Artifact
+	err := r.db.Where("execution_id = ?", executionID).
+		Order("created_at ASC").
+		Find(&artifacts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifacts by execution ID: %w", err)
+	}
+	return artifacts, nil
+}
+
+// GetExpired retrieves artifacts whose retention ended before the given time
+func (r *artifactRepository) GetExpired(before time.Time, limit int) ([]models.Artifact, error) {
+	var artifacts []models.Artifact
+	err := r.db.Where("expires_at IS NOT NULL AND expires_at < ?", before).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&artifacts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expired artifacts: %w", err)
+	}
+	return artifacts, nil
+}
+
+// GetOldestByJob retrieves a job's oldest artifacts, used to evict over-quota jobs
+func (r *artifactRepository) GetOldestByJob(jobID uuid.UUID, limit int) ([]models.Artifact, error) {
+	var artifacts []models.Artifact
+	err := r.db.Where("job_id = ?", jobID).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&artifacts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oldest artifacts by job: %w", err)
+	}
+	return artifacts, nil
+}
+
+// GetOldestByTeam retrieves the oldest artifacts of a team's jobs, used to evict over-quota teams
+func (r *artifactRepository) GetOldestByTeam(team string, limit int) ([]models.Artifact, error) {
+	var artifacts []models.Artifact
+	err := r.db.Joins("JOIN jobs ON jobs.id = artifacts.job_id").
+		Where("jobs.team = ?", team).
+		Order("artifacts.created_at ASC").
+		Limit(limit).
+		Find(&artifacts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oldest artifacts by team: %w", err)
+	}
+	return artifacts, nil
+}
+
+// SumBytesByJob returns the bytes stored for a job's artifacts
+func (r *artifactRepository) SumBytesByJob(jobID uuid.UUID) (int64, error) {
+	var total int64
+	err := r.db.Model(&models.Artifact{}).
+		Select("COALESCE(SUM(size_bytes), 0)").
+		Where("job_id = ?", jobID).
+		Scan(&total).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum artifact bytes by job: %w", err)
+	}
+	return total, nil
+}
+
+// SumBytesByTeam returns the bytes stored for the artifacts of a team's jobs
+func (r *artifactRepository) SumBytesByTeam(team string) (int64, error) {
+	var total int64
+	err := r.db.Model(&models.Artifact{}).
+		Select("COALESCE(SUM(artifacts.size_bytes), 0)").
+		Joins("JOIN jobs ON jobs.id = artifacts.job_id").
+		Where("jobs.team = ?", team).
+		Scan(&total).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum artifact bytes by team: %w", err)
+	}
+	return total, nil
+}
+
+// GetUsageByJob returns artifact usage per job, largest first; a limit below 1 returns every job
+func (r *artifactRepository) GetUsageByJob(limit int) ([]models.ArtifactUsage, error) {
+	if limit < 1 {
+		limit = -1
+	}
+
+	var usage []models.ArtifactUsage
+	err := r.db.Model(&models.Artifact{}).
+		Select("artifacts.job_id, jobs.name AS job_name, COALESCE(jobs.team, '') AS team, COUNT(*) AS count, SUM(artifacts.size_bytes) AS bytes").
+		Joins("JOIN jobs ON jobs.id = artifacts.job_id").
+		Group("artifacts.job_id, jobs.name, jobs.team").
+		Order("bytes DESC").
+		Limit(limit).
+		Scan(&usage).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact usage by job: %w", err)
+	}
+	return usage, nil
+}
+
+// GetUsageByTeam returns artifact usage per team, largest first
+func (r *artifactRepository) GetUsageByTeam() ([]models.TeamArtifactUsage, error) {
+	var usage []models.TeamArtifactUsage
+	err := r.db.Model(&models.Artifact{}).
+		Select("jobs.team AS team, COUNT(*) AS count, SUM(artifacts.size_bytes) AS bytes").
+		Joins("JOIN jobs ON jobs.id = artifacts.job_id").
+		Where("jobs.team IS NOT NULL AND jobs.team <> ''").
+		Group("jobs.team").
+		Order("bytes DESC").
+		Scan(&usage).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact usage by team: %w", err)
+	}
+	return usage, nil
+}
+
+// Delete deletes an artifact by its ID
+func (r *artifactRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.Artifact{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete artifact: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("artifact with ID %s not found", id)
+	}
+
+	return nil
+}
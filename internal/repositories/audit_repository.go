@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"job-scheduler/internal/models"
+)
+
+// AuditRepository defines the interface for audit entry data operations
+type AuditRepository interface {
+	Create(entry *models.AuditEntry) error
+	GetByEntity(entityType string, entityID uuid.UUID, limit int) ([]models.AuditEntry, error)
+}
+
+// auditRepository implements AuditRepository interface
+type auditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository creates a new audit repository
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &auditRepository{
+		db: db,
+	}
+}
+
+// Create creates a new audit entry in the database
+func (r *auditRepository) Create(entry *models.AuditEntry) error {
+	if err := r.db.Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+	return nil
+}
+
+// GetByEntity retrieves the most recent audit entries for an entity
+func (r *auditRepository) GetByEntity(entityType string, entityID uuid.UUID, limit int) ([]models.AuditEntry, error) {
+	var entries []models.AuditEntry
+	err := r.db.Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&entries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit entries: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"job-scheduler/internal/models"
+)
+
+// DatabaseConnectionRepository defines the interface for database connection data operations
+type DatabaseConnectionRepository interface {
+	Create(connection *models.DatabaseConnection) error
+	GetByID(id uuid.UUID) (*models.DatabaseConnection, error)
+	FindByName(name string) (*models.DatabaseConnection, error)
+	GetAll() ([]models.DatabaseConnection, error)
+	Update(connection *models.DatabaseConnection) error
+	Delete(id uuid.UUID) error
+}
+
+// databaseConnectionRepository implements DatabaseConnectionRepository interface
+type databaseConnectionRepository struct {
+	db *gorm.DB
+}
+
+// NewDatabaseConnectionRepository creates a new database connection repository
+func NewDatabaseConnectionRepository(db *gorm.DB) DatabaseConnectionRepository {
+	return &databaseConnectionRepository{
+		db: db,
+	}
+}
+
+// Create creates a new database connection in the database
+func (r *databaseConnectionRepository) Create(connection *models.DatabaseConnection) error {
+	if err := r.db.Create(connection).Error; err != nil {
+		return fmt.Errorf("failed to create database connection: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a database connection by its ID
+func (r *databaseConnectionRepository) GetByID(id uuid.UUID) (*models.DatabaseConnection, error) {
+	var connection models.DatabaseConnection
+	err := r.db.Where("id = ?", id).First(&connection).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("database connection with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get database connection by ID: %w", err)
+	}
+	return &connection, nil
+}
+
+// FindByName retrieves a database connection by name, returning nil when none has the name
+func (r *databaseConnectionRepository) FindByName(name string) (*models.DatabaseConnection, error) {
+	var connection models.DatabaseConnection
+	err := r.db.Where("name = ?", name).First(&connection).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+	return &connection, nil
+}
+
+// GetAll retrieves all database connections
+func (r *databaseConnectionRepository) GetAll() ([]models.DatabaseConnection, error) {
+	var connections []models.DatabaseConnection
+	err := r.db.Order("name ASC").Find(&connections).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connections: %w", err)
+	}
+	return connections, nil
+}
+
+// Update updates an existing database connection
+func (r *databaseConnectionRepository) Update(connection *models.DatabaseConnection) error {
+	result := r.db.Save(connection)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update database connection: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("database connection with ID %s not found", connection.ID)
+	}
+
+	return nil
+}
+
+// Delete deletes a database connection by its ID
+func (r *databaseConnectionRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.DatabaseConnection{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete database connection: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("database connection with ID %s not found", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"job-scheduler/internal/models"
+)
+
+// EmailSuppressionRepository defines the interface for email suppression data operations
+type EmailSuppressionRepository interface {
+	Upsert(suppression *models.EmailSuppression) error
+	Delete(email string) (bool, error)
+	List(page, limit int) ([]models.EmailSuppression, int64, error)
+	FindSuppressed(emails []string) ([]string, error)
+}
+
+// emailSuppressionRepository implements EmailSuppressionRepository interface
+type emailSuppressionRepository struct {
+	db *gorm.DB
+}
+
+// NewEmailSuppressionRepository creates a new email suppression repository
+func NewEmailSuppressionRepository(db *gorm.DB) EmailSuppressionRepository {
+	return &emailSuppressionRepository{
+		db: db,
+	}
+}
+
+// Upsert suppresses an address, replacing the reason of an address already suppressed
+func (r *emailSuppressionRepository) Upsert(suppression *models.EmailSuppression) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "email"}},
+		DoUpdates: clause.AssignmentColumns([]string{"reason", "details", "suppressed_by", "updated_at"}),
+	}).Create(suppression).Error
+	if err != nil {
+		return fmt.Errorf("failed to save email suppression: %w", err)
+	}
+	return nil
+}
+
+// Delete lifts the suppression of an address, returning false if it wasn't suppressed
+func (r *emailSuppressionRepository) Delete(email string) (bool, error) {
+	result := r.db.Where("email = ?", email).Delete(&models.EmailSuppression{})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to delete email suppression: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// List retrieves a page of suppressed addresses, most recently suppressed first
+func (r *emailSuppressionRepository) List(page, limit int) ([]models.EmailSuppression, int64, error) {
+	var suppressions []models.EmailSuppression
+	var totalCount int64
+
+	if err := r.db.Model(&models.EmailSuppression{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count email suppressions: %w", err)
+	}
+
+	err := r.db.Order("updated_at DESC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Find(&suppressions).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get email suppressions: %w", err)
+	}
+
+	return suppressions, totalCount, nil
+}
+
+// FindSuppressed returns which of the given normalized addresses are suppressed
+func (r *emailSuppressionRepository) FindSuppressed(emails []string) ([]string, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
+	var suppressed []string
+	err := r.db.Model(&models.EmailSuppression{}).Where("email IN ?", emails).Pluck("email", &suppressed).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find suppressed emails: %w", err)
+	}
+	return suppressed, nil
+}
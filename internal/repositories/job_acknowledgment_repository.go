@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"job-scheduler/internal/models"
+)
+
+// JobAcknowledgmentRepository defines the interface for job acknowledgment data operations
+type JobAcknowledgmentRepository interface {
+	Create(acknowledgment *models.JobAcknowledgment) error
+	GetByID(id uuid.UUID) (*models.JobAcknowledgment, error)
+	GetByJobID(jobID uuid.UUID) ([]models.JobAcknowledgment, error)
+	GetActive(now time.Time) ([]models.JobAcknowledgment, error)
+	Update(acknowledgment *models.JobAcknowledgment) error
+}
+
+// jobAcknowledgmentRepository implements JobAcknowledgmentRepository interface
+type jobAcknowledgmentRepository struct {
+	db *gorm.DB
+}
+
+// NewJobAcknowledgmentRepository creates a new job acknowledgment repository
+func NewJobAcknowledgmentRepository(db *gorm.DB) JobAcknowledgmentRepository {
+	return &jobAcknowledgmentRepository{
+		db: db,
+	}
+}
+
+// Create creates a new job acknowledgment in the database
+func (r *jobAcknowledgmentRepository) Create(acknowledgment *models.JobAcknowledgment) error {
+	if err := r.db.Create(acknowledgment).Error; err != nil {
+		return fmt.Errorf("failed to create job acknowledgment: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a job acknowledgment by its ID
+func (r *jobAcknowledgmentRepository) GetByID(id uuid.UUID) (*models.JobAcknowledgment, error) {
+	var acknowledgment models.JobAcknowledgment
+	err := r.db.Where("id = ?", id).First(&acknowledgment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("job acknowledgment with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get job acknowledgment by ID: %w", err)
+	}
+	return &acknowledgment, nil
+}
+
+// GetByJobID retrieves a job's acknowledgments, expired and cleared ones included, newest first
+func (r *jobAcknowledgmentRepository) GetByJobID(jobID uuid.UUID) ([]models.JobAcknowledgment, error) {
+	var acknowledgments []models.JobAcknowledgment
+	err := r.db.Where("job_id = ?", jobID).Order("created_at DESC").Find(&acknowledgments).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job acknowledgments: %w", err)
+	}
+	return acknowledgments, nil
+}
+
+// GetActive retrieves the acknowledgments in effect at the given time, latest expiry first and
+// those without an expiry before them
+func (r *jobAcknowledgmentRepository) GetActive(now time.Time) ([]models.JobAcknowledgment, error) {
+	var acknowledgments []models.JobAcknowledgment
+	err := r.db.Where("cleared_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", now).
+		Order("expires_at DESC NULLS FIRST").
+		Find(&acknowledgments).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active job acknowledgments: %w", err)
+	}
+	return acknowledgments, nil
+}
+
+// Update updates an existing job acknowledgment
+func (r *jobAcknowledgmentRepository) Update(acknowledgment *models.JobAcknowledgment) error {
+	if err := r.db.Save(acknowledgment).Error; err != nil {
+		return fmt.Errorf("failed to update job acknowledgment: %w", err)
+	}
+	return nil
+}
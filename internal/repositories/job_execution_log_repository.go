@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"job-scheduler/internal/models"
+)
+
+// JobExecutionLogRepository defines the interface for run log line data operations
+type JobExecutionLogRepository interface {
+	CreateBatch(lines []models.JobExecutionLog) error
+	GetByExecutionID(executionID uuid.UUID, after, limit int) ([]models.JobExecutionLog, error)
+}
+
+// jobExecutionLogRepository implements JobExecutionLogRepository interface
+type jobExecutionLogRepository struct {
+	db *gorm.DB
+}
+
+// NewJobExecutionLogRepository creates a new job execution log repository
+func NewJobExecutionLogRepository(db *gorm.DB) JobExecutionLogRepository {
+	return &jobExecutionLogRepository{
+		db: db,
+	}
+}
+
+// CreateBatch stores log lines in one statement
+func (r *jobExecutionLogRepository) CreateBatch(lines []models.JobExecutionLog) error {
+	if len(lines) == 0 {
+		return nil
+	}
+	if err := r.db.Create(&lines).Error; err != nil {
+		return fmt.Errorf("failed to create job execution logs: %w", err)
+	}
+	return nil
+}
+
+// GetByExecutionID retrieves up to limit of a run's log lines with a sequence after the given one, in order
+func (r *jobExecutionLogRepository) GetByExecutionID(executionID uuid.UUID, after, limit int) ([]models.JobExecutionLog, error) {
+	var lines []models.JobExecutionLog
+	err := r.db.Where("execution_id = ? AND sequence > ?", executionID, after).
+		Order("sequence ASC").
+		Limit(limit).
+		Find(&lines).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job execution logs: %w", err)
+	}
+	return lines, nil
+}
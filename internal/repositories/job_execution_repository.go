@@ -1,11 +1,16 @@
 package repositories
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"job-scheduler/internal/events"
 	"job-scheduler/internal/models"
 )
 
@@ -13,31 +18,54 @@ import (
 type JobExecutionRepository interface {
 	Create(execution *models.JobExecution) error
 	GetByID(id uuid.UUID) (*models.JobExecution, error)
-	GetByJobID(jobID uuid.UUID, page, limit int) ([]models.JobExecution, int64, error)
+	GetByJobID(ctx context.Context, jobID uuid.UUID, page, limit int) ([]models.JobExecution, int64, error)
+	GetPageByJobID(ctx context.Context, jobID uuid.UUID, page, limit int) ([]models.JobExecution, error)
+	CountByJobID(ctx context.Context, jobID uuid.UUID) (int64, error)
+	EstimateCountByJobID(ctx context.Context, jobID uuid.UUID) (int64, error)
 	Update(execution *models.JobExecution) error
 	Delete(id uuid.UUID) error
 	GetRunningExecutions() ([]models.JobExecution, error)
+	GetInterruptedExecutions() ([]models.JobExecution, error)
+	GetByWorkflowExecution(workflowExecutionID uuid.UUID) ([]models.JobExecution, error)
 	GetExecutionStats(jobID uuid.UUID) (*models.JobExecutionStats, error)
-	GetRecentExecutions(limit int) ([]models.JobExecution, error)
+	GetOverallStats(failuresSince time.Time) (*models.OverallExecutionStats, error)
+	GetStatsByJobType(since time.Time) ([]models.JobTypeExecutionStats, error)
+	GetThroughput(from, to time.Time, interval time.Duration) ([]models.ThroughputBucket, error)
+	GetRecentExecutions(ctx context.Context, limit int) ([]models.JobExecution, error)
+	GetAwaitingApproval() ([]models.JobExecution, error)
+	GetExpiredApprovals(now time.Time) ([]models.JobExecution, error)
+	GetRecentFailures(limit int) ([]models.JobExecution, error)
+	GetWithResourceUsage(jobID uuid.UUID, limit int) ([]models.JobExecution, error)
+	GetRecentFinished(jobID uuid.UUID, limit int) ([]models.JobExecution, error)
+	GetLatestStartTimes(jobIDs []uuid.UUID) (map[uuid.UUID]time.Time, error)
+	GetFailingStreaks() ([]models.FailingJob, error)
+	DeleteFinishedBefore(jobID uuid.UUID, before time.Time, limit int) (int64, error)
 }
 
 // jobExecutionRepository implements JobExecutionRepository interface
 type jobExecutionRepository struct {
-	db *gorm.DB
+	db          *gorm.DB
+	transitions *events.Bus
 }
 
 // NewJobExecutionRepository creates a new job execution repository
-func NewJobExecutionRepository(db *gorm.DB) JobExecutionRepository {
+// Saved status transitions are published on transitions, which may be nil
+func NewJobExecutionRepository(db *gorm.DB, transitions *events.Bus) JobExecutionRepository {
 	return &jobExecutionRepository{
-		db: db,
+		db:          db,
+		transitions: transitions,
 	}
 }
 
 // Create creates a new job execution in the database
+// Runs are created on every occurrence, so the job they belong to is never upserted alongside
 func (r *jobExecutionRepository) Create(execution *models.JobExecution) error {
-	if err := r.db.Create(execution).Error; err != nil {
+	if err := r.db.Omit(clause.Associations).Create(execution).Error; err != nil {
 		return fmt.Errorf("failed to create job execution: %w", err)
 	}
+
+	execution.MarkSaved()
+	r.publish(execution, "")
 	return nil
 }
 
@@ -55,45 +83,114 @@ func (r *jobExecutionRepository) GetByID(id uuid.UUID) (*models.JobExecution, er
 }
 
 // GetByJobID retrieves job executions for a specific job with pagination
-func (r *jobExecutionRepository) GetByJobID(jobID uuid.UUID, page, limit int) ([]models.JobExecution, int64, error) {
+func (r *jobExecutionRepository) GetByJobID(ctx context.Context, jobID uuid.UUID, page, limit int) ([]models.JobExecution, int64, error) {
+	// Get total count for the specific job
+	totalCount, err := r.CountByJobID(ctx, jobID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	executions, err := r.GetPageByJobID(ctx, jobID, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return executions, totalCount, nil
+}
+
+// GetPageByJobID retrieves a page of a job's executions, newest first, without counting them
+func (r *jobExecutionRepository) GetPageByJobID(ctx context.Context, jobID uuid.UUID, page, limit int) ([]models.JobExecution, error) {
 	var executions []models.JobExecution
-	var totalCount int64
 
 	// Calculate offset
 	offset := (page - 1) * limit
 
-	// Get total count for the specific job
-	if err := r.db.Model(&models.JobExecution{}).Where("job_id = ?", jobID).Count(&totalCount).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to count job executions: %w", err)
-	}
-
 	// Get executions with pagination, ordered by started_at desc
-	err := r.db.Where("job_id = ?", jobID).
+	err := r.db.WithContext(ctx).Where("job_id = ?", jobID).
 		Order("started_at DESC").
 		Limit(limit).
 		Offset(offset).
 		Find(&executions).Error
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get job executions: %w", err)
+		return nil, fmt.Errorf("failed to get job executions: %w", err)
 	}
 
-	return executions, totalCount, nil
+	return executions, nil
+}
+
+// CountByJobID counts a job's executions exactly
+func (r *jobExecutionRepository) CountByJobID(ctx context.Context, jobID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.JobExecution{}).Where("job_id = ?", jobID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count job executions: %w", err)
+	}
+	return count, nil
+}
+
+// EstimateCountByJobID estimates how many executions a job has from the planner's statistics, without
+// reading them. The planner scales pg_class.reltuples for job_executions by how common the job is in
+// the column statistics, so the estimate is as fresh as the table's last ANALYZE, which also follows
+// history pruning
+func (r *jobExecutionRepository) EstimateCountByJobID(ctx context.Context, jobID uuid.UUID) (int64, error) {
+	// A UUID is only hex digits and dashes, so it can't escape the literal
+	var plan string
+	query := fmt.Sprintf("EXPLAIN (FORMAT JSON) SELECT 1 FROM job_executions WHERE job_id = '%s'", jobID)
+	if err := r.db.WithContext(ctx).Raw(query).Row().Scan(&plan); err != nil {
+		return 0, fmt.Errorf("failed to estimate job executions: %w", err)
+	}
+
+	var explained []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(plan), &explained); err != nil || len(explained) == 0 {
+		return 0, fmt.Errorf("failed to estimate job executions: unexpected plan %q", plan)
+	}
+	return int64(explained[0].Plan.Rows), nil
 }
 
 // Update updates an existing job execution
+// A status transition is only saved if the stored status is still the one it transitioned from,
+// so an execution another writer already moved on is never overwritten
 func (r *jobExecutionRepository) Update(execution *models.JobExecution) error {
-	err := r.db.Model(execution).Select("*").Where("id = ?", execution.ID).Updates(execution).Error
-	if err != nil {
-		return fmt.Errorf("failed to update job execution: %w", err)
+	query := r.db.Model(execution).Omit("Job").Select("*").Where("id = ?", execution.ID)
+	from, transitioned := execution.UnsavedTransition()
+	if transitioned {
+		query = query.Where("status = ?", from)
 	}
 
-	if r.db.RowsAffected == 0 {
+	result := query.Updates(execution)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update job execution: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		if transitioned {
+			return fmt.Errorf("%w: job execution %s is no longer %s", models.ErrIllegalTransition, execution.ID, from)
+		}
 		return fmt.Errorf("job execution with ID %s not found", execution.ID)
 	}
 
+	if transitioned {
+		execution.MarkSaved()
+		r.publish(execution, from)
+	}
 	return nil
 }
 
+// publish announces a saved transition to subscribers
+func (r *jobExecutionRepository) publish(execution *models.JobExecution, from models.ExecutionStatus) {
+	r.transitions.Publish(events.ExecutionTransition{
+		ExecutionID: execution.ID,
+		JobID:       execution.JobID,
+		Attempt:     execution.Attempt,
+		From:        from,
+		To:          execution.Status,
+		At:          time.Now().UTC(),
+	})
+}
+
 // Delete deletes a job execution by its ID
 func (r *jobExecutionRepository) Delete(id uuid.UUID) error {
 	result := r.db.Where("id = ?", id).Delete(&models.JobExecution{})
@@ -120,6 +217,33 @@ func (r *jobExecutionRepository) GetRunningExecutions() ([]models.JobExecution,
 	return executions, nil
 }
 
+// GetInterruptedExecutions retrieves the runs interrupted by a shutdown or restart, oldest first
+func (r *jobExecutionRepository) GetInterruptedExecutions() ([]models.JobExecution, error) {
+	var executions []models.JobExecution
+	err := r.db.Preload("Job").
+		Where("status = ?", models.ExecutionStatusInterrupted).
+		Order("started_at ASC").
+		Find(&executions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interrupted executions: %w", err)
+	}
+	return executions, nil
+}
+
+// GetByWorkflowExecution retrieves the runs of a workflow execution's nodes, retries included, oldest first
+// Node runs are found by the workflow_execution_id parameter the workflow passes them
+func (r *jobExecutionRepository) GetByWorkflowExecution(workflowExecutionID uuid.UUID) ([]models.JobExecution, error) {
+	var executions []models.JobExecution
+	err := r.db.Preload("Job").
+		Where("parameters->>'workflow_execution_id' = ?", workflowExecutionID.String()).
+		Order("started_at ASC, attempt ASC").
+		Find(&executions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow execution runs: %w", err)
+	}
+	return executions, nil
+}
+
 // GetExecutionStats calculates statistics for job executions of a specific job
 func (r *jobExecutionRepository) GetExecutionStats(jobID uuid.UUID) (*models.JobExecutionStats, error) {
 	var stats models.JobExecutionStats
@@ -165,13 +289,173 @@ func (r *jobExecutionRepository) GetExecutionStats(jobID uuid.UUID) (*models.Job
 		stats.AverageExecutionTime = &avgDurationInt
 	}
 
+	// Get average resource usage of runs that recorded it
+	var avgUsage struct {
+		CPUTimeMs      *float64
+		AllocatedBytes *float64
+	}
+	err = r.db.Model(&models.JobExecution{}).
+		Select("AVG((resource_usage->>'cpu_time_ms')::bigint) AS cpu_time_ms, AVG((resource_usage->>'allocated_bytes')::bigint) AS allocated_bytes").
+		Where("job_id = ? AND resource_usage IS NOT NULL", jobID).
+		Scan(&avgUsage).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate average resource usage: %w", err)
+	}
+
+	if avgUsage.CPUTimeMs != nil {
+		avgCPUTime := int64(*avgUsage.CPUTimeMs)
+		stats.AverageCPUTimeMs = &avgCPUTime
+	}
+	if avgUsage.AllocatedBytes != nil {
+		avgAllocated := int64(*avgUsage.AllocatedBytes)
+		stats.AverageAllocatedBytes = &avgAllocated
+	}
+
 	return &stats, nil
 }
 
+// GetOverallStats calculates statistics for the executions of all jobs
+// FailuresLast24h counts failed runs that finished after failuresSince
+func (r *jobExecutionRepository) GetOverallStats(failuresSince time.Time) (*models.OverallExecutionStats, error) {
+	var totals struct {
+		Total       int64
+		Successful  int64
+		Failed      int64
+		RecentFails int64
+		AvgDuration *float64
+	}
+	err := r.db.Model(&models.JobExecution{}).
+		Select(`COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE status = ?) AS successful,
+			COUNT(*) FILTER (WHERE status = ?) AS failed,
+			COUNT(*) FILTER (WHERE status = ? AND completed_at > ?) AS recent_fails,
+			AVG(execution_duration) FILTER (WHERE status = ? AND execution_duration IS NOT NULL) AS avg_duration`,
+			models.ExecutionStatusCompleted,
+			models.ExecutionStatusFailed,
+			models.ExecutionStatusFailed, failuresSince,
+			models.ExecutionStatusCompleted).
+		Scan(&totals).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate execution stats: %w", err)
+	}
+
+	stats := &models.OverallExecutionStats{
+		TotalExecutions:      totals.Total,
+		SuccessfulExecutions: totals.Successful,
+		FailedExecutions:     totals.Failed,
+		FailuresLast24h:      totals.RecentFails,
+	}
+
+	// Calculate success rate
+	if stats.TotalExecutions > 0 {
+		stats.SuccessRate = float64(stats.SuccessfulExecutions) / float64(stats.TotalExecutions) * 100
+	}
+
+	if totals.AvgDuration != nil {
+		avgDurationInt := int64(*totals.AvgDuration)
+		stats.AverageExecutionTime = &avgDurationInt
+	}
+
+	return stats, nil
+}
+
+// GetStatsByJobType calculates statistics for the executions started since a time, per job type
+// Job types without runs in the window are left out
+func (r *jobExecutionRepository) GetStatsByJobType(since time.Time) ([]models.JobTypeExecutionStats, error) {
+	var rows []struct {
+		JobType     models.JobType
+		Total       int64
+		Successful  int64
+		Failed      int64
+		AvgDuration *float64
+	}
+	err := r.db.Model(&models.JobExecution{}).
+		Select(`jobs.job_type AS job_type,
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE job_executions.status = ?) AS successful,
+			COUNT(*) FILTER (WHERE job_executions.status = ?) AS failed,
+			AVG(job_executions.execution_duration) FILTER (WHERE job_executions.status = ? AND job_executions.execution_duration IS NOT NULL) AS avg_duration`,
+			models.ExecutionStatusCompleted,
+			models.ExecutionStatusFailed,
+			models.ExecutionStatusCompleted).
+		Joins("JOIN jobs ON jobs.id = job_executions.job_id").
+		Where("job_executions.started_at >= ?", since).
+		Group("jobs.job_type").
+		Order("jobs.job_type").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate execution stats by job type: %w", err)
+	}
+
+	stats := make([]models.JobTypeExecutionStats, len(rows))
+	for i, row := range rows {
+		stats[i] = models.JobTypeExecutionStats{
+			JobType:              row.JobType,
+			TotalExecutions:      row.Total,
+			SuccessfulExecutions: row.Successful,
+			FailedExecutions:     row.Failed,
+		}
+		if row.Total > 0 {
+			stats[i].FailureRate = float64(row.Failed) / float64(row.Total) * 100
+		}
+		if row.AvgDuration != nil {
+			avgDurationInt := int64(*row.AvgDuration)
+			stats[i].AverageExecutionTime = &avgDurationInt
+		}
+	}
+	return stats, nil
+}
+
+// GetThroughput counts the runs started, completed and failed in each interval from from until to, in a
+// single query. Runs count as started when they started and as completed or failed when they finished.
+// Buckets start at from; those without any runs are left out
+func (r *jobExecutionRepository) GetThroughput(from, to time.Time, interval time.Duration) ([]models.ThroughputBucket, error) {
+	var rows []struct {
+		Bucket    int64
+		Started   int64
+		Completed int64
+		Failed    int64
+	}
+	fromEpoch := float64(from.UnixNano()) / float64(time.Second)
+	err := r.db.Raw(`SELECT FLOOR((EXTRACT(EPOCH FROM events.at)::float8 - ?::float8) / ?::float8)::bigint AS bucket,
+			COUNT(*) FILTER (WHERE events.event = 'started') AS started,
+			COUNT(*) FILTER (WHERE events.event = ?) AS completed,
+			COUNT(*) FILTER (WHERE events.event = ?) AS failed
+		FROM (
+			SELECT started_at AS at, 'started'::text AS event
+			FROM job_executions WHERE started_at >= ? AND started_at < ?
+			UNION ALL
+			SELECT completed_at, status::text
+			FROM job_executions WHERE status IN (?, ?) AND completed_at >= ? AND completed_at < ?
+		) AS events
+		GROUP BY bucket
+		ORDER BY bucket`,
+		fromEpoch, interval.Seconds(),
+		models.ExecutionStatusCompleted, models.ExecutionStatusFailed,
+		from, to,
+		models.ExecutionStatusCompleted, models.ExecutionStatusFailed, from, to).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate execution throughput: %w", err)
+	}
+
+	buckets := make([]models.ThroughputBucket, len(rows))
+	for i, row := range rows {
+		buckets[i] = models.ThroughputBucket{
+			Start:     from.Add(time.Duration(row.Bucket) * interval),
+			Started:   row.Started,
+			Completed: row.Completed,
+			Failed:    row.Failed,
+		}
+	}
+	return buckets, nil
+}
+
 // GetRecentExecutions retrieves the most recent job executions across all jobs
-func (r *jobExecutionRepository) GetRecentExecutions(limit int) ([]models.JobExecution, error) {
+// The query is cancelled when ctx is done
+func (r *jobExecutionRepository) GetRecentExecutions(ctx context.Context, limit int) ([]models.JobExecution, error) {
 	var executions []models.JobExecution
-	err := r.db.Preload("Job").
+	err := r.db.WithContext(ctx).Preload("Job").
 		Order("started_at DESC").
 		Limit(limit).
 		Find(&executions).Error
@@ -180,3 +464,172 @@ func (r *jobExecutionRepository) GetRecentExecutions(limit int) ([]models.JobExe
 	}
 	return executions, nil
 }
+
+// GetAwaitingApproval retrieves all executions waiting for approval
+func (r *jobExecutionRepository) GetAwaitingApproval() ([]models.JobExecution, error) {
+	var executions []models.JobExecution
+	err := r.db.Preload("Job").
+		Where("status = ?", models.ExecutionStatusAwaitingApproval).
+		Order("started_at ASC").
+		Find(&executions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get executions awaiting approval: %w", err)
+	}
+	return executions, nil
+}
+
+// GetExpiredApprovals retrieves executions whose approval window has passed
+func (r *jobExecutionRepository) GetExpiredApprovals(now time.Time) ([]models.JobExecution, error) {
+	var executions []models.JobExecution
+	err := r.db.Preload("Job").
+		Where("status = ? AND approval_expires_at < ?", models.ExecutionStatusAwaitingApproval, now).
+		Find(&executions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expired approvals: %w", err)
+	}
+	return executions, nil
+}
+
+// GetRecentFailures retrieves the most recent failed executions across all jobs
+func (r *jobExecutionRepository) GetRecentFailures(limit int) ([]models.JobExecution, error) {
+	var executions []models.JobExecution
+	err := r.db.Preload("Job").
+		Where("status = ?", models.ExecutionStatusFailed).
+		Order("completed_at DESC").
+		Limit(limit).
+		Find(&executions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent failures: %w", err)
+	}
+	return executions, nil
+}
+
+// GetWithResourceUsage retrieves a job's most recent executions that recorded resource usage
+func (r *jobExecutionRepository) GetWithResourceUsage(jobID uuid.UUID, limit int) ([]models.JobExecution, error) {
+	var executions []models.JobExecution
+	err := r.db.Where("job_id = ? AND resource_usage IS NOT NULL", jobID).
+		Order("started_at DESC").
+		Limit(limit).
+		Find(&executions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get executions with resource usage: %w", err)
+	}
+	return executions, nil
+}
+
+// GetRecentFinished retrieves a job's most recent runs that completed, failed or stalled, newest first
+func (r *jobExecutionRepository) GetRecentFinished(jobID uuid.UUID, limit int) ([]models.JobExecution, error) {
+	var executions []models.JobExecution
+	err := r.db.Where("job_id = ? AND status IN ?", jobID, []models.ExecutionStatus{
+		models.ExecutionStatusCompleted,
+		models.ExecutionStatusFailed,
+		models.ExecutionStatusStalled,
+	}).
+		Order("started_at DESC").
+		Limit(limit).
+		Find(&executions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent finished executions: %w", err)
+	}
+	return executions, nil
+}
+
+// GetLatestStartTimes retrieves when each of the jobs' latest runs started
+// Jobs that have never run have no entry
+func (r *jobExecutionRepository) GetLatestStartTimes(jobIDs []uuid.UUID) (map[uuid.UUID]time.Time, error) {
+	var rows []struct {
+		JobID     uuid.UUID
+		StartedAt time.Time
+	}
+	err := r.db.Model(&models.JobExecution{}).
+		Select("job_id, MAX(started_at) AS started_at").
+		Where("job_id IN ? AND status IN ?", jobIDs, []models.ExecutionStatus{
+			models.ExecutionStatusRunning,
+			models.ExecutionStatusCompleted,
+			models.ExecutionStatusFailed,
+			models.ExecutionStatusStalled,
+		}).
+		Group("job_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest start times: %w", err)
+	}
+
+	startTimes := make(map[uuid.UUID]time.Time, len(rows))
+	for _, row := range rows {
+		startTimes[row.JobID] = row.StartedAt.UTC()
+	}
+	return startTimes, nil
+}
+
+// GetFailingStreaks retrieves the active jobs whose runs have all failed or stalled since their last
+// completed run, failing longest first, with the error of the latest failure
+func (r *jobExecutionRepository) GetFailingStreaks() ([]models.FailingJob, error) {
+	failedStatuses := []models.ExecutionStatus{models.ExecutionStatusFailed, models.ExecutionStatusStalled}
+
+	var failing []models.FailingJob
+	err := r.db.Model(&models.JobExecution{}).
+		Select("job_executions.job_id, jobs.name AS job_name, COALESCE(jobs.team, '') AS team, "+
+			"COALESCE(jobs.owner, '') AS owner, jobs.severity, COALESCE(jobs.runbook_url, '') AS runbook_url, "+
+			"COUNT(*) AS streak_length, MIN(job_executions.started_at) AS first_failure_at, "+
+			"MAX(job_executions.started_at) AS last_failure_at").
+		Joins("JOIN jobs ON jobs.id = job_executions.job_id").
+		Where("jobs.state = ? AND job_executions.status IN ?", models.JobStateActive, failedStatuses).
+		Where("job_executions.started_at > COALESCE((SELECT MAX(completed.started_at) FROM job_executions completed "+
+			"WHERE completed.job_id = job_executions.job_id AND completed.status = ?), '-infinity')", models.ExecutionStatusCompleted).
+		Group("job_executions.job_id, jobs.name, jobs.team, jobs.owner, jobs.severity, jobs.runbook_url").
+		Order("first_failure_at ASC").
+		Scan(&failing).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get failing streaks: %w", err)
+	}
+	if len(failing) == 0 {
+		return failing, nil
+	}
+
+	jobIDs := make([]uuid.UUID, len(failing))
+	for i := range failing {
+		jobIDs[i] = failing[i].JobID
+	}
+	var latest []models.JobExecution
+	err = r.db.Select("DISTINCT ON (job_id) *").
+		Where("job_id IN ? AND status IN ?", jobIDs, failedStatuses).
+		Order("job_id, started_at DESC").
+		Find(&latest).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest failures: %w", err)
+	}
+
+	lastErrors := make(map[uuid.UUID]*models.CompressedText, len(latest))
+	for _, execution := range latest {
+		lastErrors[execution.JobID] = execution.ErrorMessage
+	}
+	for i := range failing {
+		failing[i].LastError = lastErrors[failing[i].JobID]
+	}
+	return failing, nil
+}
+
+// DeleteFinishedBefore deletes up to limit of a job's finished runs that started before the cutoff,
+// oldest first, and returns how many were deleted
+// Runs that still have artifacts are kept until artifact maintenance removes them, so stored files
+// aren't orphaned
+func (r *jobExecutionRepository) DeleteFinishedBefore(jobID uuid.UUID, before time.Time, limit int) (int64, error) {
+	batch := r.db.Model(&models.JobExecution{}).
+		Select("id").
+		Where("job_id = ? AND started_at < ? AND status NOT IN ?", jobID, before, []models.ExecutionStatus{
+			models.ExecutionStatusPending,
+			models.ExecutionStatusQueued,
+			models.ExecutionStatusRunning,
+			models.ExecutionStatusAwaitingApproval,
+		}).
+		Where("NOT EXISTS (SELECT 1 FROM artifacts WHERE artifacts.execution_id = job_executions.id)").
+		Order("started_at ASC").
+		Limit(limit)
+
+	result := r.db.Where("id IN (?)", batch).Delete(&models.JobExecution{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete old job executions: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
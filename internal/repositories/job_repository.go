@@ -1,7 +1,10 @@
 package repositories
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -12,12 +15,22 @@ import (
 // JobRepository defines the interface for job data operations
 type JobRepository interface {
 	Create(job *models.Job) error
+	CreateIfNameFree(job *models.Job) (*models.Job, error)
 	GetByID(id uuid.UUID) (*models.Job, error)
-	GetAll(page, limit int) ([]models.Job, int64, error)
+	FindByName(team, name string) (*models.Job, error)
+	GetAll(ctx context.Context, page, limit int, sort models.JobSort, filter models.JobFilter) ([]models.Job, int64, error)
+	GetPage(ctx context.Context, after *models.Cursor, limit int, filter models.JobFilter) ([]models.Job, error)
 	Update(job *models.Job) error
+	UpdateIfNameFree(job *models.Job) (*models.Job, error)
 	Delete(id uuid.UUID) error
 	GetActiveJobs() ([]models.Job, error)
+	GetSchedulableJobs() ([]models.Job, error)
 	GetByJobType(jobType models.JobType) ([]models.Job, error)
+	UpdateRunTimes(id uuid.UUID, lastRunAt, nextRunAt time.Time) error
+	UpdateHealthScore(id uuid.UUID, score int, scoredAt time.Time) error
+	MarkErrored(id uuid.UUID, reason string, erroredAt time.Time) error
+	ClearError(id uuid.UUID) error
+	GetErroredJobs(ctx context.Context) ([]models.Job, error)
 }
 
 // jobRepository implements JobRepository interface
@@ -40,6 +53,25 @@ func (r *jobRepository) Create(job *models.Job) error {
 	return nil
 }
 
+// CreateIfNameFree creates a job unless another job of its team has its name, returning that job instead
+// The check and insert run in one transaction holding a lock on the name, so concurrent creates of the
+// same name can't both succeed
+func (r *jobRepository) CreateIfNameFree(job *models.Job) (*models.Job, error) {
+	var holder *models.Job
+	err := r.withNameLock(job.Team, job.Name, func(tx *gorm.DB) error {
+		existing, err := findByName(tx, job.Team, job.Name)
+		if err != nil || existing != nil {
+			holder = existing
+			return err
+		}
+		return tx.Create(job).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+	return holder, nil
+}
+
 // GetByID retrieves a job by its ID
 func (r *jobRepository) GetByID(id uuid.UUID) (*models.Job, error) {
 	var job models.Job
@@ -53,21 +85,34 @@ func (r *jobRepository) GetByID(id uuid.UUID) (*models.Job, error) {
 	return &job, nil
 }
 
-// GetAll retrieves all jobs with pagination
-func (r *jobRepository) GetAll(page, limit int) ([]models.Job, int64, error) {
+// GetAll retrieves the jobs matching the filter with pagination, in the given order
+// The queries are cancelled when ctx is done
+func (r *jobRepository) GetAll(ctx context.Context, page, limit int, sort models.JobSort, filter models.JobFilter) ([]models.Job, int64, error) {
 	var jobs []models.Job
 	var totalCount int64
+	db := r.db.WithContext(ctx)
 
 	// Calculate offset
 	offset := (page - 1) * limit
 
 	// Get total count
-	if err := r.db.Model(&models.Job{}).Count(&totalCount).Error; err != nil {
+	if err := filterJobs(db.Model(&models.Job{}), filter).Count(&totalCount).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to count jobs: %w", err)
 	}
 
-	// Get jobs with pagination, ordered by created_at desc
-	err := r.db.Order("created_at DESC").
+	// Get jobs with pagination; jobs without a health score or next run go last when sorting by them
+	query := filterJobs(db, filter)
+	switch sort {
+	case models.JobSortHealth:
+		query = query.Order("health_score ASC NULLS LAST")
+	case models.JobSortHealthDesc:
+		query = query.Order("health_score DESC NULLS LAST")
+	case models.JobSortName:
+		query = query.Order("name ASC")
+	case models.JobSortNextRun:
+		query = query.Order("next_run_at ASC NULLS LAST")
+	}
+	err := query.Order("created_at DESC").
 		Limit(limit).
 		Offset(offset).
 		Find(&jobs).Error
@@ -78,16 +123,125 @@ func (r *jobRepository) GetAll(page, limit int) ([]models.Job, int64, error) {
 	return jobs, totalCount, nil
 }
 
+// GetPage retrieves up to limit jobs matching the filter created before the cursor, newest first
+// A nil cursor starts from the newest job; the query is cancelled when ctx is done
+func (r *jobRepository) GetPage(ctx context.Context, after *models.Cursor, limit int, filter models.JobFilter) ([]models.Job, error) {
+	var jobs []models.Job
+
+	query := filterJobs(r.db.WithContext(ctx), filter).Order("created_at DESC").Order("id DESC").Limit(limit)
+	if after != nil {
+		query = query.Where("(created_at, id) < (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	if err := query.Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern, so searches match them literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// filterJobs narrows a query of jobs to those matching the filter
+func filterJobs(query *gorm.DB, filter models.JobFilter) *gorm.DB {
+	if len(filter.States) > 0 {
+		query = query.Where("state IN ?", filter.States)
+	}
+	if filter.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+likeEscaper.Replace(filter.Name)+"%")
+	}
+	if filter.JobType != "" {
+		query = query.Where("job_type = ?", filter.JobType)
+	}
+	if filter.IsActive != nil {
+		if *filter.IsActive {
+			query = query.Where("state = ?", models.JobStateActive)
+		} else {
+			query = query.Where("state <> ?", models.JobStateActive)
+		}
+	}
+	if filter.Tag != "" {
+		query = query.Where("tags @> ?", models.JobTags{filter.Tag})
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	return query
+}
+
+// FindByName retrieves a team's job by name, returning nil when none exists
+// Jobs without a team share the empty team; if several jobs have the name the oldest is returned
+func (r *jobRepository) FindByName(team, name string) (*models.Job, error) {
+	job, err := findByName(r.db, team, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job by name: %w", err)
+	}
+	return job, nil
+}
+
 // Update updates an existing job
 func (r *jobRepository) Update(job *models.Job) error {
-	// Use Select to update all fields including zero values
-	err := r.db.Model(job).Select("*").Where("id = ?", job.ID).Updates(job).Error
+	return updateJob(r.db, job)
+}
+
+// UpdateIfNameFree updates a job unless another job of its team has its name, returning that job instead
+func (r *jobRepository) UpdateIfNameFree(job *models.Job) (*models.Job, error) {
+	var holder *models.Job
+	err := r.withNameLock(job.Team, job.Name, func(tx *gorm.DB) error {
+		var existing models.Job
+		err := tx.Where("team = ? AND name = ? AND id <> ?", job.Team, job.Name, job.ID).First(&existing).Error
+		if err == nil {
+			holder = &existing
+			return nil
+		}
+		if err != gorm.ErrRecordNotFound {
+			return fmt.Errorf("failed to get job by name: %w", err)
+		}
+		return updateJob(tx, job)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return holder, nil
+}
+
+// withNameLock runs fn in a transaction holding an advisory lock on a team's job name, released
+// when the transaction ends
+func (r *jobRepository) withNameLock(team, name string, fn func(tx *gorm.DB) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "jobs:"+team+"/"+name).Error; err != nil {
+			return fmt.Errorf("failed to lock job name: %w", err)
+		}
+		return fn(tx)
+	})
+}
+
+// findByName retrieves the oldest job of a team with a name, or nil
+func findByName(db *gorm.DB, team, name string) (*models.Job, error) {
+	var job models.Job
+	err := db.Where("team = ? AND name = ?", team, name).Order("created_at ASC").First(&job).Error
 	if err != nil {
-		return fmt.Errorf("failed to update job: %w", err)
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// updateJob saves every field of a job, including zero values
+func updateJob(db *gorm.DB, job *models.Job) error {
+	// Use Select to update all fields including zero values
+	result := db.Model(job).Select("*").Where("id = ?", job.ID).Updates(job)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update job: %w", result.Error)
 	}
 
 	// Check if any rows were affected
-	if r.db.RowsAffected == 0 {
+	if result.RowsAffected == 0 {
 		return fmt.Errorf("job with ID %s not found", job.ID)
 	}
 
@@ -111,13 +265,23 @@ func (r *jobRepository) Delete(id uuid.UUID) error {
 // GetActiveJobs retrieves all active jobs
 func (r *jobRepository) GetActiveJobs() ([]models.Job, error) {
 	var jobs []models.Job
-	err := r.db.Where("is_active = ?", true).Find(&jobs).Error
+	err := r.db.Where("state = ?", models.JobStateActive).Find(&jobs).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get active jobs: %w", err)
 	}
 	return jobs, nil
 }
 
+// GetSchedulableJobs retrieves the jobs the scheduler schedules, those in a schedulable state
+func (r *jobRepository) GetSchedulableJobs() ([]models.Job, error) {
+	var jobs []models.Job
+	err := r.db.Where("state IN ?", models.SchedulableJobStates).Find(&jobs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedulable jobs: %w", err)
+	}
+	return jobs, nil
+}
+
 // GetByJobType retrieves jobs by their type
 func (r *jobRepository) GetByJobType(jobType models.JobType) ([]models.Job, error) {
 	var jobs []models.Job
@@ -127,3 +291,70 @@ func (r *jobRepository) GetByJobType(jobType models.JobType) ([]models.Job, erro
 	}
 	return jobs, nil
 }
+
+// UpdateRunTimes records the job's last fired occurrence and the next one due
+// It leaves updated_at alone, as the job itself hasn't changed
+func (r *jobRepository) UpdateRunTimes(id uuid.UUID, lastRunAt, nextRunAt time.Time) error {
+	err := r.db.Model(&models.Job{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"last_run_at": lastRunAt,
+		"next_run_at": nextRunAt,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update job run times: %w", err)
+	}
+	return nil
+}
+
+// UpdateHealthScore records the job's recalculated health score
+// It leaves updated_at alone, as the job itself hasn't changed
+func (r *jobRepository) UpdateHealthScore(id uuid.UUID, score int, scoredAt time.Time) error {
+	err := r.db.Model(&models.Job{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"health_score":     score,
+		"health_scored_at": scoredAt,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update job health score: %w", err)
+	}
+	return nil
+}
+
+// MarkErrored moves a scheduled job to the errored state, recording that its stored schedule can't
+// be used and why. Jobs changed to another state since they were loaded are left alone
+// It leaves updated_at alone, as the job's settings haven't changed
+func (r *jobRepository) MarkErrored(id uuid.UUID, reason string, erroredAt time.Time) error {
+	err := r.db.Model(&models.Job{}).Where("id = ? AND state IN ?", id, models.SchedulableJobStates).UpdateColumns(map[string]interface{}{
+		"state":        models.JobStateErrored,
+		"errored_at":   erroredAt,
+		"error_reason": reason,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark job errored: %w", err)
+	}
+	return nil
+}
+
+// ClearError clears the job's error record once it can be scheduled again, making an errored job active
+func (r *jobRepository) ClearError(id uuid.UUID) error {
+	err := r.db.Model(&models.Job{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"state":        gorm.Expr("CASE WHEN state = ? THEN ? ELSE state END", models.JobStateErrored, models.JobStateActive),
+		"errored_at":   nil,
+		"error_reason": "",
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to clear job error: %w", err)
+	}
+	return nil
+}
+
+// GetErroredJobs retrieves the errored jobs, whose schedules can't be used, most recently errored first
+func (r *jobRepository) GetErroredJobs(ctx context.Context) ([]models.Job, error) {
+	var jobs []models.Job
+	err := r.db.WithContext(ctx).
+		Where("state = ?", models.JobStateErrored).
+		Order("errored_at DESC").
+		Find(&jobs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get errored jobs: %w", err)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"job-scheduler/internal/models"
+)
+
+// JobRevisionRepository defines the interface for job revision data operations
+type JobRevisionRepository interface {
+	Create(revision *models.JobRevision) error
+	GetByJobID(jobID uuid.UUID) ([]models.JobRevision, error)
+	GetByRevision(jobID uuid.UUID, revision int) (*models.JobRevision, error)
+	GetLatest(jobID uuid.UUID) (*models.JobRevision, error)
+}
+
+// jobRevisionRepository implements JobRevisionRepository interface
+type jobRevisionRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRevisionRepository creates a new job revision repository
+func NewJobRevisionRepository(db *gorm.DB) JobRevisionRepository {
+	return &jobRevisionRepository{
+		db: db,
+	}
+}
+
+// Create creates a new job revision in the database
+func (r *jobRevisionRepository) Create(revision *models.JobRevision) error {
+	if err := r.db.Create(revision).Error; err != nil {
+		return fmt.Errorf("failed to create job revision: %w", err)
+	}
+	return nil
+}
+
+// GetByJobID retrieves a job's revisions, newest first
+func (r *jobRevisionRepository) GetByJobID(jobID uuid.UUID) ([]models.JobRevision, error) {
+	var revisions []models.JobRevision
+	err := r.db.Where("job_id = ?", jobID).Order("revision DESC").Find(&revisions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// GetByRevision retrieves one of a job's revisions by its number
+func (r *jobRevisionRepository) GetByRevision(jobID uuid.UUID, revision int) (*models.JobRevision, error) {
+	var found models.JobRevision
+	err := r.db.Where("job_id = ? AND revision = ?", jobID, revision).First(&found).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("job %s has no revision %d", jobID, revision)
+		}
+		return nil, fmt.Errorf("failed to get job revision: %w", err)
+	}
+	return &found, nil
+}
+
+// GetLatest retrieves a job's newest revision, or nil if it has none
+func (r *jobRevisionRepository) GetLatest(jobID uuid.UUID) (*models.JobRevision, error) {
+	var revisions []models.JobRevision
+	err := r.db.Where("job_id = ?", jobID).Order("revision DESC").Limit(1).Find(&revisions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest job revision: %w", err)
+	}
+	if len(revisions) == 0 {
+		return nil, nil
+	}
+	return &revisions[0], nil
+}
@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"job-scheduler/internal/models"
+)
+
+// JobRunClaimRepository defines the interface for claiming scheduled runs across instances
+type JobRunClaimRepository interface {
+	Claim(claim *models.JobRunClaim) (bool, error)
+	DeleteBefore(before time.Time) (int64, error)
+}
+
+// jobRunClaimRepository implements JobRunClaimRepository interface
+type jobRunClaimRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRunClaimRepository creates a new job run claim repository
+func NewJobRunClaimRepository(db *gorm.DB) JobRunClaimRepository {
+	return &jobRunClaimRepository{
+		db: db,
+	}
+}
+
+// Claim records the claim unless another instance already claimed the run
+// It returns true when this claim won
+func (r *jobRunClaimRepository) Claim(claim *models.JobRunClaim) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(claim)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim job run: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteBefore deletes claims made before the given time and returns how many were deleted
+func (r *jobRunClaimRepository) DeleteBefore(before time.Time) (int64, error) {
+	result := r.db.Where("claimed_at < ?", before).Delete(&models.JobRunClaim{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete job run claims: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"job-scheduler/internal/models"
+)
+
+// JobWebhookRepository defines the interface for job webhook data operations
+type JobWebhookRepository interface {
+	Create(webhook *models.JobWebhook) error
+	GetByID(id uuid.UUID) (*models.JobWebhook, error)
+	GetByToken(token string) (*models.JobWebhook, error)
+	GetByJobID(jobID uuid.UUID) ([]models.JobWebhook, error)
+	Delete(id uuid.UUID) error
+	MarkTriggered(id uuid.UUID, at time.Time) error
+}
+
+// jobWebhookRepository implements JobWebhookRepository interface
+type jobWebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewJobWebhookRepository creates a new job webhook repository
+func NewJobWebhookRepository(db *gorm.DB) JobWebhookRepository {
+	return &jobWebhookRepository{
+		db: db,
+	}
+}
+
+// Create creates a new webhook in the database
+func (r *jobWebhookRepository) Create(webhook *models.JobWebhook) error {
+	if err := r.db.Create(webhook).Error; err != nil {
+		return fmt.Errorf("failed to create job webhook: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a webhook by its ID
+func (r *jobWebhookRepository) GetByID(id uuid.UUID) (*models.JobWebhook, error) {
+	var webhook models.JobWebhook
+	err := r.db.Where("id = ?", id).First(&webhook).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("job webhook with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get job webhook by ID: %w", err)
+	}
+	return &webhook, nil
+}
+
+// GetByToken retrieves a webhook by its URL token
+func (r *jobWebhookRepository) GetByToken(token string) (*models.JobWebhook, error) {
+	var webhook models.JobWebhook
+	err := r.db.Where("token = ?", token).First(&webhook).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("job webhook not found")
+		}
+		return nil, fmt.Errorf("failed to get job webhook by token: %w", err)
+	}
+	return &webhook, nil
+}
+
+// GetByJobID retrieves all webhooks for a specific job
+func (r *jobWebhookRepository) GetByJobID(jobID uuid.UUID) ([]models.JobWebhook, error) {
+	var webhooks []models.JobWebhook
+	err := r.db.Where("job_id = ?", jobID).
+		Order("created_at DESC").
+		Find(&webhooks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// Delete deletes a webhook by its ID
+func (r *jobWebhookRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.JobWebhook{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete job webhook: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("job webhook with ID %s not found", id)
+	}
+
+	return nil
+}
+
+// MarkTriggered records the time a webhook last triggered its job
+func (r *jobWebhookRepository) MarkTriggered(id uuid.UUID, at time.Time) error {
+	err := r.db.Model(&models.JobWebhook{}).
+		Where("id = ?", id).
+		Update("last_triggered_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to update job webhook: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"job-scheduler/internal/models"
+)
+
+// MissedOccurrenceRepository defines the interface for the ledger of occurrences that didn't run
+type MissedOccurrenceRepository interface {
+	Record(occurrence *models.MissedOccurrence) error
+	RecordAll(occurrences []models.MissedOccurrence) (int64, error)
+	GetByJobID(jobID uuid.UUID, page, limit int) ([]models.MissedOccurrence, int64, error)
+}
+
+// missedOccurrenceRepository implements MissedOccurrenceRepository interface
+type missedOccurrenceRepository struct {
+	db *gorm.DB
+}
+
+// NewMissedOccurrenceRepository creates a new missed occurrence repository
+func NewMissedOccurrenceRepository(db *gorm.DB) MissedOccurrenceRepository {
+	return &missedOccurrenceRepository{
+		db: db,
+	}
+}
+
+// Record adds a missed occurrence to the ledger; an occurrence already recorded is left as it is
+func (r *missedOccurrenceRepository) Record(occurrence *models.MissedOccurrence) error {
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(occurrence).Error; err != nil {
+		return fmt.Errorf("failed to record missed occurrence: %w", err)
+	}
+	return nil
+}
+
+// RecordAll adds missed occurrences to the ledger in batched inserts, leaving any already recorded
+// It returns how many were added
+func (r *missedOccurrenceRepository) RecordAll(occurrences []models.MissedOccurrence) (int64, error) {
+	if len(occurrences) == 0 {
+		return 0, nil
+	}
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&occurrences)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to record missed occurrences: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// GetByJobID retrieves a page of a job's missed occurrences, newest first
+func (r *missedOccurrenceRepository) GetByJobID(jobID uuid.UUID, page, limit int) ([]models.MissedOccurrence, int64, error) {
+	var occurrences []models.MissedOccurrence
+	var totalCount int64
+
+	if err := r.db.Model(&models.MissedOccurrence{}).Where("job_id = ?", jobID).Count(&totalCount).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count missed occurrences: %w", err)
+	}
+
+	err := r.db.Where("job_id = ?", jobID).
+		Order("scheduled_for DESC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Find(&occurrences).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get missed occurrences: %w", err)
+	}
+
+	return occurrences, totalCount, nil
+}
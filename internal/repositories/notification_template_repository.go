@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"job-scheduler/internal/models"
+)
+
+// NotificationTemplateRepository defines the interface for notification template data operations
+type NotificationTemplateRepository interface {
+	Create(template *models.NotificationTemplate) error
+	GetByID(id uuid.UUID) (*models.NotificationTemplate, error)
+	FindByName(name string) (*models.NotificationTemplate, error)
+	GetAll() ([]models.NotificationTemplate, error)
+	FindTemplate(channel models.TemplateChannel, event string) (*models.NotificationTemplate, error)
+	Update(template *models.NotificationTemplate) error
+	Delete(id uuid.UUID) error
+}
+
+// notificationTemplateRepository implements NotificationTemplateRepository interface
+type notificationTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationTemplateRepository creates a new notification template repository
+func NewNotificationTemplateRepository(db *gorm.DB) NotificationTemplateRepository {
+	return &notificationTemplateRepository{
+		db: db,
+	}
+}
+
+// Create creates a new notification template in the database
+func (r *notificationTemplateRepository) Create(template *models.NotificationTemplate) error {
+	if err := r.db.Create(template).Error; err != nil {
+		return fmt.Errorf("failed to create notification template: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a notification template by its ID
+func (r *notificationTemplateRepository) GetByID(id uuid.UUID) (*models.NotificationTemplate, error) {
+	var template models.NotificationTemplate
+	err := r.db.Where("id = ?", id).First(&template).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("notification template with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get notification template by ID: %w", err)
+	}
+	return &template, nil
+}
+
+// FindByName retrieves a notification template by name, returning nil when there is none
+func (r *notificationTemplateRepository) FindByName(name string) (*models.NotificationTemplate, error) {
+	var template models.NotificationTemplate
+	err := r.db.Where("name = ?", name).First(&template).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get notification template: %w", err)
+	}
+	return &template, nil
+}
+
+// GetAll retrieves all notification templates
+func (r *notificationTemplateRepository) GetAll() ([]models.NotificationTemplate, error) {
+	var templates []models.NotificationTemplate
+	err := r.db.Order("name ASC").Find(&templates).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification templates: %w", err)
+	}
+	return templates, nil
+}
+
+// FindTemplate retrieves the active template for a channel and event
+// A template for the specific event wins over a catch-all template; nil is returned when there is none
+func (r *notificationTemplateRepository) FindTemplate(channel models.TemplateChannel, event string) (*models.NotificationTemplate, error) {
+	var template models.NotificationTemplate
+	err := r.db.Where("channel = ? AND is_active = ? AND (event = ? OR event = '')", channel, true, event).
+		Order("event DESC").
+		First(&template).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find notification template: %w", err)
+	}
+	return &template, nil
+}
+
+// Update updates an existing notification template
+func (r *notificationTemplateRepository) Update(template *models.NotificationTemplate) error {
+	result := r.db.Save(template)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update notification template: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("notification template with ID %s not found", template.ID)
+	}
+
+	return nil
+}
+
+// Delete deletes a notification template by its ID
+func (r *notificationTemplateRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.NotificationTemplate{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete notification template: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("notification template with ID %s not found", id)
+	}
+
+	return nil
+}
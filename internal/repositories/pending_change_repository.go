@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"job-scheduler/internal/models"
+)
+
+// PendingChangeRepository defines the interface for pending change data operations
+type PendingChangeRepository interface {
+	Create(change *models.PendingChange) error
+	GetByID(id uuid.UUID) (*models.PendingChange, error)
+	GetPending() ([]models.PendingChange, error)
+	Update(change *models.PendingChange) error
+}
+
+// pendingChangeRepository implements PendingChangeRepository interface
+type pendingChangeRepository struct {
+	db *gorm.DB
+}
+
+// NewPendingChangeRepository creates a new pending change repository
+func NewPendingChangeRepository(db *gorm.DB) PendingChangeRepository {
+	return &pendingChangeRepository{
+		db: db,
+	}
+}
+
+// Create creates a new pending change in the database
+func (r *pendingChangeRepository) Create(change *models.PendingChange) error {
+	if err := r.db.Create(change).Error; err != nil {
+		return fmt.Errorf("failed to create pending change: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a pending change by its ID
+func (r *pendingChangeRepository) GetByID(id uuid.UUID) (*models.PendingChange, error) {
+	var change models.PendingChange
+	err := r.db.Where("id = ?", id).First(&change).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("pending change with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get pending change by ID: %w", err)
+	}
+	return &change, nil
+}
+
+// GetPending retrieves all changes waiting for review
+func (r *pendingChangeRepository) GetPending() ([]models.PendingChange, error) {
+	var changes []models.PendingChange
+	err := r.db.Where("status = ?", models.PendingChangeStatusPending).
+		Order("created_at ASC").
+		Find(&changes).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending changes: %w", err)
+	}
+	return changes, nil
+}
+
+// Update updates an existing pending change
+func (r *pendingChangeRepository) Update(change *models.PendingChange) error {
+	result := r.db.Model(change).Select("*").Where("id = ?", change.ID).Updates(change)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update pending change: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("pending change with ID %s not found", change.ID)
+	}
+
+	return nil
+}
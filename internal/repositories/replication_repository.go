@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"job-scheduler/internal/models"
+)
+
+// replicationStateID is the ID of the replication state's single row
+const replicationStateID = 1
+
+// ReplicationRepository defines the interface for the job change outbox and a standby's replication state
+type ReplicationRepository interface {
+	AppendChange(change *models.JobChange) error
+	GetChangesAfter(after int64, limit int) ([]models.JobChange, error)
+	LatestSequence() (int64, error)
+	GetSnapshot(ctx context.Context) (*models.JobSnapshot, error)
+	GetState() (*models.ReplicationState, error)
+	SaveState(state *models.ReplicationState) error
+	ApplyChange(change *models.JobChange, job *models.Job) error
+	ApplySnapshot(snapshot *models.JobSnapshot) error
+}
+
+// replicationRepository implements ReplicationRepository interface
+type replicationRepository struct {
+	db *gorm.DB
+}
+
+// NewReplicationRepository creates a new replication repository
+func NewReplicationRepository(db *gorm.DB) ReplicationRepository {
+	return &replicationRepository{
+		db: db,
+	}
+}
+
+// AppendChange adds a change to the end of the outbox, setting its sequence
+func (r *replicationRepository) AppendChange(change *models.JobChange) error {
+	if err := r.db.Create(change).Error; err != nil {
+		return fmt.Errorf("failed to record job change: %w", err)
+	}
+	return nil
+}
+
+// GetChangesAfter retrieves up to limit changes after a sequence, oldest first
+func (r *replicationRepository) GetChangesAfter(after int64, limit int) ([]models.JobChange, error) {
+	var changes []models.JobChange
+	err := r.db.Where("sequence > ?", after).
+		Order("sequence ASC").
+		Limit(limit).
+		Find(&changes).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job changes: %w", err)
+	}
+	return changes, nil
+}
+
+// LatestSequence returns the sequence of the latest change, or 0 when there are none
+func (r *replicationRepository) LatestSequence() (int64, error) {
+	return latestSequence(r.db)
+}
+
+// latestSequence returns the sequence of the latest change in db
+func latestSequence(db *gorm.DB) (int64, error) {
+	var sequence int64
+	err := db.Model(&models.JobChange{}).Select("COALESCE(MAX(sequence), 0)").Scan(&sequence).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest job change: %w", err)
+	}
+	return sequence, nil
+}
+
+// GetSnapshot retrieves every job and the latest change they include
+// Both are read in one repeatable-read transaction, so the jobs are exactly as of that change
+func (r *replicationRepository) GetSnapshot(ctx context.Context) (*models.JobSnapshot, error) {
+	var snapshot models.JobSnapshot
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		sequence, err := latestSequence(tx)
+		if err != nil {
+			return err
+		}
+		snapshot.Sequence = sequence
+		if err := tx.Order("created_at ASC").Find(&snapshot.Jobs).Error; err != nil {
+			return fmt.Errorf("failed to get jobs: %w", err)
+		}
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// GetState retrieves the replication state, which is empty until the first change is applied
+func (r *replicationRepository) GetState() (*models.ReplicationState, error) {
+	var state models.ReplicationState
+	err := r.db.Where("id = ?", replicationStateID).First(&state).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &models.ReplicationState{ID: replicationStateID}, nil
+		}
+		return nil, fmt.Errorf("failed to get replication state: %w", err)
+	}
+	return &state, nil
+}
+
+// SaveState saves the replication state
+func (r *replicationRepository) SaveState(state *models.ReplicationState) error {
+	return saveReplicationState(r.db, state)
+}
+
+// saveReplicationState inserts or replaces the replication state's row
+func saveReplicationState(db *gorm.DB, state *models.ReplicationState) error {
+	state.ID = replicationStateID
+	if err := db.Clauses(clause.OnConflict{UpdateAll: true}).Create(state).Error; err != nil {
+		return fmt.Errorf("failed to save replication state: %w", err)
+	}
+	return nil
+}
+
+// ApplyChange applies a change from the primary and records it as the last applied, in one transaction
+// Upserts write the job as given, deletes of jobs that don't exist do nothing
+func (r *replicationRepository) ApplyChange(change *models.JobChange, job *models.Job) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		switch change.Action {
+		case models.JobChangeUpsert:
+			if err := upsertJob(tx, job); err != nil {
+				return err
+			}
+		case models.JobChangeDelete:
+			if err := tx.Where("id = ?", change.JobID).Delete(&models.Job{}).Error; err != nil {
+				return fmt.Errorf("failed to delete job: %w", err)
+			}
+		default:
+			return fmt.Errorf("unknown job change action: %s", change.Action)
+		}
+		return advanceReplicationState(tx, change.Sequence)
+	})
+}
+
+// ApplySnapshot replaces every job with the snapshot's, in one transaction
+func (r *replicationRepository) ApplySnapshot(snapshot *models.JobSnapshot) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		ids := make([]uuid.UUID, len(snapshot.Jobs))
+		for i := range snapshot.Jobs {
+			ids[i] = snapshot.Jobs[i].ID
+			if err := upsertJob(tx, &snapshot.Jobs[i]); err != nil {
+				return err
+			}
+		}
+
+		stale := tx.Where("1 = 1")
+		if len(ids) > 0 {
+			stale = tx.Where("id NOT IN ?", ids)
+		}
+		if err := stale.Delete(&models.Job{}).Error; err != nil {
+			return fmt.Errorf("failed to delete jobs missing from snapshot: %w", err)
+		}
+		return advanceReplicationState(tx, snapshot.Sequence)
+	})
+}
+
+// upsertJob inserts a job or overwrites every column of the job with its ID
+func upsertJob(db *gorm.DB, job *models.Job) error {
+	err := db.Omit(clause.Associations).Clauses(clause.OnConflict{UpdateAll: true}).Create(job).Error
+	if err != nil {
+		return fmt.Errorf("failed to save replicated job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+// advanceReplicationState records the sequence of the last change applied
+func advanceReplicationState(tx *gorm.DB, sequence int64) error {
+	var state models.ReplicationState
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", replicationStateID).First(&state).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return fmt.Errorf("failed to get replication state: %w", err)
+	}
+
+	now := time.Now().UTC()
+	state.LastSequence = sequence
+	state.LastSyncedAt = &now
+	return saveReplicationState(tx, &state)
+}
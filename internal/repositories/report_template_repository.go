@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"job-scheduler/internal/models"
+)
+
+// ReportTemplateRepository defines the interface for report template data operations
+type ReportTemplateRepository interface {
+	Create(template *models.ReportTemplate) error
+	GetByID(id uuid.UUID) (*models.ReportTemplate, error)
+	FindByName(name string) (*models.ReportTemplate, error)
+	GetAll() ([]models.ReportTemplate, error)
+	Update(template *models.ReportTemplate) error
+	Delete(id uuid.UUID) error
+}
+
+// reportTemplateRepository implements ReportTemplateRepository interface
+type reportTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewReportTemplateRepository creates a new report template repository
+func NewReportTemplateRepository(db *gorm.DB) ReportTemplateRepository {
+	return &reportTemplateRepository{
+		db: db,
+	}
+}
+
+// Create creates a new report template in the database
+func (r *reportTemplateRepository) Create(template *models.ReportTemplate) error {
+	if err := r.db.Create(template).Error; err != nil {
+		return fmt.Errorf("failed to create report template: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a report template by its ID
+func (r *reportTemplateRepository) GetByID(id uuid.UUID) (*models.ReportTemplate, error) {
+	var template models.ReportTemplate
+	err := r.db.Where("id = ?", id).First(&template).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("report template with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get report template by ID: %w", err)
+	}
+	return &template, nil
+}
+
+// FindByName retrieves a report template by name, returning nil when none exists
+func (r *reportTemplateRepository) FindByName(name string) (*models.ReportTemplate, error) {
+	var template models.ReportTemplate
+	err := r.db.Where("name = ?", name).First(&template).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get report template by name: %w", err)
+	}
+	return &template, nil
+}
+
+// GetAll retrieves all report templates
+func (r *reportTemplateRepository) GetAll() ([]models.ReportTemplate, error) {
+	var templates []models.ReportTemplate
+	err := r.db.Order("name ASC").Find(&templates).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report templates: %w", err)
+	}
+	return templates, nil
+}
+
+// Update updates an existing report template
+func (r *reportTemplateRepository) Update(template *models.ReportTemplate) error {
+	result := r.db.Save(template)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update report template: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("report template with ID %s not found", template.ID)
+	}
+
+	return nil
+}
+
+// Delete deletes a report template by its ID
+func (r *reportTemplateRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.ReportTemplate{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete report template: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("report template with ID %s not found", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"job-scheduler/internal/models"
+)
+
+// RoleAssignmentRepository defines the interface for role assignment data operations
+type RoleAssignmentRepository interface {
+	Create(assignment *models.RoleAssignment) error
+	GetByID(id uuid.UUID) (*models.RoleAssignment, error)
+	FindByUser(user string) (*models.RoleAssignment, error)
+	GetAll() ([]models.RoleAssignment, error)
+	Update(assignment *models.RoleAssignment) error
+	Delete(id uuid.UUID) error
+}
+
+// roleAssignmentRepository implements RoleAssignmentRepository interface
+type roleAssignmentRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleAssignmentRepository creates a new role assignment repository
+func NewRoleAssignmentRepository(db *gorm.DB) RoleAssignmentRepository {
+	return &roleAssignmentRepository{
+		db: db,
+	}
+}
+
+// Create creates a new role assignment in the database
+func (r *roleAssignmentRepository) Create(assignment *models.RoleAssignment) error {
+	if err := r.db.Create(assignment).Error; err != nil {
+		return fmt.Errorf("failed to create role assignment: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a role assignment by its ID
+func (r *roleAssignmentRepository) GetByID(id uuid.UUID) (*models.RoleAssignment, error) {
+	var assignment models.RoleAssignment
+	err := r.db.Where("id = ?", id).First(&assignment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("role assignment with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get role assignment by ID: %w", err)
+	}
+	return &assignment, nil
+}
+
+// FindByUser retrieves a user's role assignment, returning nil when the user has none
+func (r *roleAssignmentRepository) FindByUser(user string) (*models.RoleAssignment, error) {
+	var assignment models.RoleAssignment
+	err := r.db.Where("username = ?", user).First(&assignment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get role assignment: %w", err)
+	}
+	return &assignment, nil
+}
+
+// GetAll retrieves all role assignments
+func (r *roleAssignmentRepository) GetAll() ([]models.RoleAssignment, error) {
+	var assignments []models.RoleAssignment
+	err := r.db.Order("username ASC").Find(&assignments).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role assignments: %w", err)
+	}
+	return assignments, nil
+}
+
+// Update updates an existing role assignment
+func (r *roleAssignmentRepository) Update(assignment *models.RoleAssignment) error {
+	result := r.db.Save(assignment)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update role assignment: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("role assignment with ID %s not found", assignment.ID)
+	}
+
+	return nil
+}
+
+// Delete deletes a role assignment by its ID
+func (r *roleAssignmentRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.RoleAssignment{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete role assignment: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("role assignment with ID %s not found", id)
+	}
+
+	return nil
+}
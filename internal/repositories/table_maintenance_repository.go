@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TableMaintenanceRepository defines the interface for refreshing planner statistics and
+// reclaiming dead rows of tables
+type TableMaintenanceRepository interface {
+	Analyze(ctx context.Context, table string) error
+	VacuumAnalyze(ctx context.Context, table string) error
+}
+
+// tableMaintenanceRepository implements TableMaintenanceRepository interface
+type tableMaintenanceRepository struct {
+	db *gorm.DB
+}
+
+// NewTableMaintenanceRepository creates a new table maintenance repository
+func NewTableMaintenanceRepository(db *gorm.DB) TableMaintenanceRepository {
+	return &tableMaintenanceRepository{
+		db: db,
+	}
+}
+
+// Analyze refreshes the planner statistics of a table
+func (r *tableMaintenanceRepository) Analyze(ctx context.Context, table string) error {
+	if err := r.db.WithContext(ctx).Exec("ANALYZE ?", clause.Table{Name: table}).Error; err != nil {
+		return fmt.Errorf("failed to analyze %s: %w", table, err)
+	}
+	return nil
+}
+
+// VacuumAnalyze reclaims the dead rows of a table and refreshes its planner statistics
+// VACUUM can't run inside a transaction, so this must not be called within one
+func (r *tableMaintenanceRepository) VacuumAnalyze(ctx context.Context, table string) error {
+	if err := r.db.WithContext(ctx).Exec("VACUUM (ANALYZE) ?", clause.Table{Name: table}).Error; err != nil {
+		return fmt.Errorf("failed to vacuum %s: %w", table, err)
+	}
+	return nil
+}
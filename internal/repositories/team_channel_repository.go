@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"job-scheduler/internal/models"
+)
+
+// TeamChannelRepository defines the interface for team channel data operations
+type TeamChannelRepository interface {
+	Create(channel *models.TeamChannel) error
+	GetByID(id uuid.UUID) (*models.TeamChannel, error)
+	FindByTeam(team string) (*models.TeamChannel, error)
+	GetAll() ([]models.TeamChannel, error)
+	Update(channel *models.TeamChannel) error
+	Delete(id uuid.UUID) error
+}
+
+// teamChannelRepository implements TeamChannelRepository interface
+type teamChannelRepository struct {
+	db *gorm.DB
+}
+
+// NewTeamChannelRepository creates a new team channel repository
+func NewTeamChannelRepository(db *gorm.DB) TeamChannelRepository {
+	return &teamChannelRepository{
+		db: db,
+	}
+}
+
+// Create creates a new team channel in the database
+func (r *teamChannelRepository) Create(channel *models.TeamChannel) error {
+	if err := r.db.Create(channel).Error; err != nil {
+		return fmt.Errorf("failed to create team channel: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a team channel by its ID
+func (r *teamChannelRepository) GetByID(id uuid.UUID) (*models.TeamChannel, error) {
+	var channel models.TeamChannel
+	err := r.db.Where("id = ?", id).First(&channel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("team channel with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get team channel by ID: %w", err)
+	}
+	return &channel, nil
+}
+
+// FindByTeam retrieves a team's channel, returning nil when the team has none
+func (r *teamChannelRepository) FindByTeam(team string) (*models.TeamChannel, error) {
+	var channel models.TeamChannel
+	err := r.db.Where("team = ?", team).First(&channel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get team channel: %w", err)
+	}
+	return &channel, nil
+}
+
+// GetAll retrieves all team channels
+func (r *teamChannelRepository) GetAll() ([]models.TeamChannel, error) {
+	var channels []models.TeamChannel
+	err := r.db.Order("team ASC").Find(&channels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team channels: %w", err)
+	}
+	return channels, nil
+}
+
+// Update updates an existing team channel
+func (r *teamChannelRepository) Update(channel *models.TeamChannel) error {
+	result := r.db.Save(channel)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update team channel: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("team channel with ID %s not found", channel.ID)
+	}
+
+	return nil
+}
+
+// Delete deletes a team channel by its ID
+func (r *teamChannelRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.TeamChannel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete team channel: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("team channel with ID %s not found", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"job-scheduler/internal/models"
+)
+
+// TriggerSourceRepository defines the interface for trigger source data operations
+type TriggerSourceRepository interface {
+	Create(source *models.TriggerSource) error
+	GetByID(id uuid.UUID) (*models.TriggerSource, error)
+	GetByJobID(jobID uuid.UUID) ([]models.TriggerSource, error)
+	GetActive() ([]models.TriggerSource, error)
+	Delete(id uuid.UUID) error
+}
+
+// triggerSourceRepository implements TriggerSourceRepository interface
+type triggerSourceRepository struct {
+	db *gorm.DB
+}
+
+// NewTriggerSourceRepository creates a new trigger source repository
+func NewTriggerSourceRepository(db *gorm.DB) TriggerSourceRepository {
+	return &triggerSourceRepository{
+		db: db,
+	}
+}
+
+// Create creates a new trigger source in the database
+func (r *triggerSourceRepository) Create(source *models.TriggerSource) error {
+	if err := r.db.Create(source).Error; err != nil {
+		return fmt.Errorf("failed to create trigger source: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a trigger source by its ID
+func (r *triggerSourceRepository) GetByID(id uuid.UUID) (*models.TriggerSource, error) {
+	var source models.TriggerSource
+	err := r.db.Where("id = ?", id).First(&source).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("trigger source with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get trigger source by ID: %w", err)
+	}
+	return &source, nil
+}
+
+// GetByJobID retrieves all trigger sources for a specific job
+func (r *triggerSourceRepository) GetByJobID(jobID uuid.UUID) ([]models.TriggerSource, error) {
+	var sources []models.TriggerSource
+	err := r.db.Where("job_id = ?", jobID).
+		Order("created_at DESC").
+		Find(&sources).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trigger sources: %w", err)
+	}
+	return sources, nil
+}
+
+// GetActive retrieves all active trigger sources
+func (r *triggerSourceRepository) GetActive() ([]models.TriggerSource, error) {
+	var sources []models.TriggerSource
+	err := r.db.Where("is_active = ?", true).Find(&sources).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active trigger sources: %w", err)
+	}
+	return sources, nil
+}
+
+// Delete deletes a trigger source by its ID
+func (r *triggerSourceRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.TriggerSource{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete trigger source: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("trigger source with ID %s not found", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"job-scheduler/internal/models"
+)
+
+// WorkflowRepository defines the interface for workflow data operations
+type WorkflowRepository interface {
+	Create(workflow *models.Workflow) error
+	GetByID(id uuid.UUID) (*models.Workflow, error)
+	FindByName(name string) (*models.Workflow, error)
+	GetAll() ([]models.Workflow, error)
+	Update(workflow *models.Workflow) error
+	Delete(id uuid.UUID) error
+
+	CreateExecution(execution *models.WorkflowExecution) error
+	GetExecutionByID(id uuid.UUID) (*models.WorkflowExecution, error)
+	GetExecutionsByWorkflowID(workflowID uuid.UUID, limit int) ([]models.WorkflowExecution, error)
+	UpdateExecution(execution *models.WorkflowExecution) error
+}
+
+// workflowRepository implements WorkflowRepository interface
+type workflowRepository struct {
+	db *gorm.DB
+}
+
+// NewWorkflowRepository creates a new workflow repository
+func NewWorkflowRepository(db *gorm.DB) WorkflowRepository {
+	return &workflowRepository{
+		db: db,
+	}
+}
+
+// Create creates a new workflow in the database
+func (r *workflowRepository) Create(workflow *models.Workflow) error {
+	if err := r.db.Create(workflow).Error; err != nil {
+		return fmt.Errorf("failed to create workflow: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a workflow by its ID
+func (r *workflowRepository) GetByID(id uuid.UUID) (*models.Workflow, error) {
+	var workflow models.Workflow
+	err := r.db.Where("id = ?", id).First(&workflow).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("workflow with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get workflow by ID: %w", err)
+	}
+	return &workflow, nil
+}
+
+// FindByName retrieves a workflow by name, returning nil when none has the name
+func (r *workflowRepository) FindByName(name string) (*models.Workflow, error) {
+	var workflow models.Workflow
+	err := r.db.Where("name = ?", name).First(&workflow).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+	return &workflow, nil
+}
+
+// GetAll retrieves all workflows
+func (r *workflowRepository) GetAll() ([]models.Workflow, error) {
+	var workflows []models.Workflow
+	err := r.db.Order("name ASC").Find(&workflows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflows: %w", err)
+	}
+	return workflows, nil
+}
+
+// Update updates an existing workflow
+func (r *workflowRepository) Update(workflow *models.Workflow) error {
+	result := r.db.Save(workflow)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update workflow: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("workflow with ID %s not found", workflow.ID)
+	}
+
+	return nil
+}
+
+// Delete deletes a workflow and, through the foreign key, its executions
+func (r *workflowRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.Workflow{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete workflow: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("workflow with ID %s not found", id)
+	}
+
+	return nil
+}
+
+// CreateExecution creates a new workflow execution in the database
+func (r *workflowRepository) CreateExecution(execution *models.WorkflowExecution) error {
+	if err := r.db.Create(execution).Error; err != nil {
+		return fmt.Errorf("failed to create workflow execution: %w", err)
+	}
+	return nil
+}
+
+// GetExecutionByID retrieves a workflow execution by its ID
+func (r *workflowRepository) GetExecutionByID(id uuid.UUID) (*models.WorkflowExecution, error) {
+	var execution models.WorkflowExecution
+	err := r.db.Where("id = ?", id).First(&execution).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("workflow execution with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get workflow execution by ID: %w", err)
+	}
+	return &execution, nil
+}
+
+// GetExecutionsByWorkflowID retrieves a workflow's executions, newest first
+func (r *workflowRepository) GetExecutionsByWorkflowID(workflowID uuid.UUID, limit int) ([]models.WorkflowExecution, error) {
+	var executions []models.WorkflowExecution
+	query := r.db.Where("workflow_id = ?", workflowID).Order("started_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&executions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get workflow executions: %w", err)
+	}
+	return executions, nil
+}
+
+// UpdateExecution updates an existing workflow execution
+func (r *workflowRepository) UpdateExecution(execution *models.WorkflowExecution) error {
+	if err := r.db.Save(execution).Error; err != nil {
+		return fmt.Errorf("failed to update workflow execution: %w", err)
+	}
+	return nil
+}
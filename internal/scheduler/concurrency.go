@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+)
+
+// ErrRunSkipped is returned when a scheduled run is skipped because the job's previous run is still
+// going and its concurrency policy forbids overlapping runs
+var ErrRunSkipped = errors.New("run skipped - previous run still running")
+
+// applyConcurrencyPolicy decides whether the job's scheduled run may start alongside its runs in progress
+// Under forbid the run is recorded as skipped; under replace the running ones are cancelled first
+func (e *JobExecutor) applyConcurrencyPolicy(job *models.Job, scheduledFor time.Time) error {
+	switch job.ConcurrencyPolicy {
+	case models.ConcurrencyForbid:
+		if e.jobRunning(job.ID) {
+			return e.skipRun(job, scheduledFor)
+		}
+	case models.ConcurrencyReplace:
+		if replaced := e.cancelJobRuns(job.ID); replaced > 0 {
+			logrus.WithFields(logrus.Fields{
+				"job_id":        job.ID,
+				"job_name":      job.Name,
+				"replaced":      replaced,
+				"scheduled_for": scheduledFor,
+			}).Warn("Cancelling previous run - replaced by the job's next occurrence")
+		}
+	}
+	return nil
+}
+
+// skipRun records a scheduled run that didn't start because the job's previous run is still going
+func (e *JobExecutor) skipRun(job *models.Job, scheduledFor time.Time) error {
+	logrus.WithFields(logrus.Fields{
+		"job_id":        job.ID,
+		"job_name":      job.Name,
+		"scheduled_for": scheduledFor,
+	}).Warn("Job execution skipped - previous run still running")
+
+	execution := &models.JobExecution{
+		ID:           uuid.New(),
+		JobID:        job.ID,
+		ScheduledFor: &scheduledFor,
+		Attempt:      1,
+	}
+	if err := execution.MarkAsSkipped(ErrRunSkipped.Error()); err != nil {
+		return fmt.Errorf("failed to record execution %s: %w", execution.ID, err)
+	}
+	if err := e.jobExecutionRepo.Create(execution); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"execution_id": execution.ID,
+			"error":        err,
+		}).Error("Failed to record skipped execution")
+	}
+	return ErrRunSkipped
+}
+
+// trackJobRun counts a run of the job as in progress, from queueing for a slot until it finishes
+func (e *JobExecutor) trackJobRun(jobID uuid.UUID) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.jobRuns[jobID]++
+}
+
+// untrackJobRun counts a run of the job as finished
+func (e *JobExecutor) untrackJobRun(jobID uuid.UUID) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.jobRuns[jobID] <= 1 {
+		delete(e.jobRuns, jobID)
+		return
+	}
+	e.jobRuns[jobID]--
+}
+
+// jobRunning reports whether a run of the job is queued or running on this instance
+func (e *JobExecutor) jobRunning(jobID uuid.UUID) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.jobRuns[jobID] > 0
+}
+
+// cancelJobRuns cancels the job's running executions, returning how many were cancelled
+// Their executors are given the usual grace period to stop
+func (e *JobExecutor) cancelJobRuns(jobID uuid.UUID) int {
+	e.mu.RLock()
+	var controls []*runControl
+	for executionID, execution := range e.runningJobs {
+		if execution.JobID == jobID {
+			controls = append(controls, e.controls[executionID])
+		}
+	}
+	e.mu.RUnlock()
+
+	for _, control := range controls {
+		control.stop(false)
+	}
+	return len(controls)
+}
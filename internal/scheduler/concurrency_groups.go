@@ -0,0 +1,117 @@
+package scheduler
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrConcurrencyGroupFull is returned when a run is skipped because its concurrency group stayed at its limit
+var ErrConcurrencyGroupFull = errors.New("concurrency group limit reached")
+
+// ConcurrencyGroupStats reports how a concurrency group's slots are used
+type ConcurrencyGroupStats struct {
+	Limit   int `json:"limit"`
+	Running int `json:"running"`
+	Waiting int `json:"waiting"`
+}
+
+// groupSemaphores limits how many runs of each concurrency group execute at once, keyed by group
+// Each limited group gets a semaphore the first time one of its runs asks for a slot; runs of groups
+// without a limit, or of jobs without a group, don't take one
+type groupSemaphores struct {
+	limits map[string]int
+
+	mu      sync.Mutex
+	slots   map[string]chan struct{}
+	waiting map[string]int
+}
+
+// newGroupSemaphores creates semaphores for the given group limits
+func newGroupSemaphores(limits map[string]int) *groupSemaphores {
+	return &groupSemaphores{
+		limits:  limits,
+		slots:   make(map[string]chan struct{}),
+		waiting: make(map[string]int),
+	}
+}
+
+// semaphore returns the group's semaphore, or nil if the group has no limit
+func (g *groupSemaphores) semaphore(group string) chan struct{} {
+	limit := g.limits[group]
+	if group == "" || limit < 1 {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	slots, ok := g.slots[group]
+	if !ok {
+		slots = make(chan struct{}, limit)
+		g.slots[group] = slots
+	}
+	return slots
+}
+
+// acquire takes a slot of the group, waiting up to timeout for one to free up
+// queued is called once the run has to wait. It returns false if no slot freed up in time
+func (g *groupSemaphores) acquire(group string, timeout time.Duration, queued func()) bool {
+	slots := g.semaphore(group)
+	if slots == nil {
+		return true
+	}
+
+	select {
+	case slots <- struct{}{}:
+		return true
+	default:
+	}
+	if timeout <= 0 {
+		return false
+	}
+
+	g.mu.Lock()
+	g.waiting[group]++
+	g.mu.Unlock()
+	defer func() {
+		g.mu.Lock()
+		g.waiting[group]--
+		g.mu.Unlock()
+	}()
+
+	if queued != nil {
+		queued()
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// release frees a slot of the group taken by acquire
+func (g *groupSemaphores) release(group string) {
+	if slots := g.semaphore(group); slots != nil {
+		<-slots
+	}
+}
+
+// stats reports the slots of every limited group
+func (g *groupSemaphores) stats() map[string]ConcurrencyGroupStats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	stats := make(map[string]ConcurrencyGroupStats, len(g.limits))
+	for group, limit := range g.limits {
+		stats[group] = ConcurrencyGroupStats{
+			Limit:   limit,
+			Running: len(g.slots[group]),
+			Waiting: g.waiting[group],
+		}
+	}
+	return stats
+}
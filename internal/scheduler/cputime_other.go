@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !freebsd
+
+package scheduler
+
+import "time"
+
+// processCPUTime is not measurable on this platform
+func processCPUTime() (time.Duration, bool) {
+	return 0, false
+}
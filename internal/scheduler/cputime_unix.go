@@ -0,0 +1,17 @@
+//go:build linux || darwin || freebsd
+
+package scheduler
+
+import (
+	"syscall"
+	"time"
+)
+
+// processCPUTime returns the user plus system CPU time the process has used
+func processCPUTime() (time.Duration, bool) {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0, false
+	}
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano()), true
+}
@@ -2,7 +2,11 @@ package scheduler
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
 	"sync"
 	"time"
 
@@ -10,122 +14,630 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"job-scheduler/internal/config"
+	"job-scheduler/internal/events"
+	"job-scheduler/internal/httpclient"
+	"job-scheduler/internal/integrations"
 	"job-scheduler/internal/models"
+	"job-scheduler/internal/notifications"
 	"job-scheduler/internal/repositories"
 	"job-scheduler/internal/services"
 )
 
+// ErrExecutionCancelled is returned for runs stopped by CancelExecution
+var ErrExecutionCancelled = errors.New("job execution cancelled")
+
+// ErrDependencyUnavailable is returned for runs not started because a connection their job uses is down
+var ErrDependencyUnavailable = errors.New("job dependency unavailable")
+
+// cancelGracePeriod is how long a cancelled or timed out executor has to stop before its run is killed
+const cancelGracePeriod = 5 * time.Second
+
+// Defaults for runs when the scheduler config leaves them unset
+const (
+	defaultExecutionTimeout      = 10 * time.Minute
+	defaultMaxExecutionTime      = time.Hour
+	defaultTimeoutWarningPercent = 80
+	defaultMaxQueueDepth         = 100
+	defaultExecutionLogMaxLines  = 5000
+	defaultExecutionLogFlush     = time.Second
+)
+
 // JobExecutor handles the execution of individual jobs
 type JobExecutor struct {
-	jobExecutionRepo repositories.JobExecutionRepository
-	executors        map[models.JobType]services.JobExecutor
-	config           *config.Config
-	semaphore        chan struct{} // Limits concurrent job executions
-	mu               sync.RWMutex
-	runningJobs      map[uuid.UUID]*models.JobExecution
+	jobExecutionRepo        repositories.JobExecutionRepository
+	executors               map[models.JobType]services.JobExecutor
+	config                  *config.Config
+	slots                   *fairQueue       // Limits concurrent job executions, shared fairly between teams
+	groups                  *groupSemaphores // Limits concurrent executions per concurrency group
+	rateLimits              *startLimiter    // Limits how many runs start per time window
+	mu                      sync.RWMutex
+	runningJobs             map[uuid.UUID]*models.JobExecution
+	controls                map[uuid.UUID]*runControl // stop running executions
+	jobRuns                 map[uuid.UUID]int         // runs in progress per job, queued or running
+	notifier                notifications.Notifier
+	artifacts               services.ArtifactService
+	remediation             services.RemediationService
+	retries                 map[uuid.UUID]*time.Timer // pending retries waiting out their backoff
+	overload                *overloadGuard
+	connections             services.ConnectionMonitor
+	emailSuppressions       services.SuppressionLookup
+	emailRecipientData      services.ReportDataSource
+	emailRecipientArtifacts services.ArtifactReader
+	acknowledgments         services.AcknowledgmentLookup
+	executionLogs           repositories.JobExecutionLogRepository
+	jobEvents               *events.JobEventBus
+	oneTimeJobs             oneTimeJobCompleter
+	draining                bool // set once the scheduler is shutting down, so no further run starts
+}
+
+// oneTimeJobCompleter expires one-time jobs once their run has ended
+type oneTimeJobCompleter interface {
+	CompleteOneTimeJob(id uuid.UUID, ranAt time.Time) error
 }
 
 // NewJobExecutor creates a new job executor
 func NewJobExecutor(jobExecutionRepo repositories.JobExecutionRepository, cfg *config.Config) *JobExecutor {
-	// Create semaphore to limit concurrent executions
-	semaphore := make(chan struct{}, cfg.Scheduler.MaxConcurrentJobs)
-
 	// Initialize job type executors
 	executors := map[models.JobType]services.JobExecutor{
 		models.JobTypeEmailNotification: &services.EmailNotificationExecutor{},
-		models.JobTypeDataProcessing:    &services.DataProcessingExecutor{},
+		models.JobTypeDataProcessing:    services.NewDataProcessingExecutor(cfg.DataProcessing.Directory, cfg.DataProcessing.InternalTables),
 		models.JobTypeReportGeneration:  services.NewReportGenerationExecutor(cfg.Reports.Directory),
-		models.JobTypeHealthCheck:       services.NewHealthCheckExecutor(cfg.HealthCheck.Timeout),
+		models.JobTypeHealthCheck:       services.NewHealthCheckExecutor(&http.Client{Timeout: cfg.HealthCheck.Timeout}),
+		models.JobTypeDBMaintenance:     services.NewDBMaintenanceExecutor(cfg.DBMaintenance.Statements),
+	}
+	for jobType, executor := range services.RegisteredExecutors() {
+		executors[jobType] = executor
 	}
 
 	return &JobExecutor{
 		jobExecutionRepo: jobExecutionRepo,
 		executors:        executors,
 		config:           cfg,
-		semaphore:        semaphore,
+		slots:            newFairQueue(cfg.Scheduler.MaxConcurrentJobs, maxQueueDepth(cfg), cfg.Scheduler.TeamWeights),
+		groups:           newGroupSemaphores(cfg.Scheduler.ConcurrencyGroupLimits),
+		rateLimits:       newStartLimiter(cfg.Scheduler.StartRateLimit, cfg.Scheduler.JobTypeStartRateLimits),
 		runningJobs:      make(map[uuid.UUID]*models.JobExecution),
+		controls:         make(map[uuid.UUID]*runControl),
+		jobRuns:          make(map[uuid.UUID]int),
+		retries:          make(map[uuid.UUID]*time.Timer),
+		overload: newOverloadGuard(cfg.Scheduler.MaxConcurrentJobs, cfg.Scheduler.OverloadThreshold,
+			cfg.Scheduler.OverloadQueueWait, models.JobSeverity(cfg.Scheduler.ShedBelowSeverity)),
+	}
+}
+
+// RegisterExecutor adds an executor for a custom job type, so embedders can run their own kinds of jobs
+// Call it before NewScheduler; JobService accepts jobs of the type once it is registered
+func RegisterExecutor(jobType models.JobType, executor services.JobExecutor) error {
+	return services.RegisterExecutor(jobType, executor)
+}
+
+// SetNotifier enables failure notifications
+func (e *JobExecutor) SetNotifier(notifier notifications.Notifier) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.notifier = notifier
+}
+
+// SetRemediation adds signed remediation action links to failure notifications
+func (e *JobExecutor) SetRemediation(remediation services.RemediationService) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.remediation = remediation
+}
+
+// SetArtifactService records files produced by runs, such as generated reports, for download
+func (e *JobExecutor) SetArtifactService(artifacts services.ArtifactService) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.artifacts = artifacts
+}
+
+// SetReportTemplates enables report_generation jobs that reference stored report templates
+func (e *JobExecutor) SetReportTemplates(templates services.ReportTemplateLookup, data services.ReportDataSource) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if reports, ok := e.executors[models.JobTypeReportGeneration].(*services.ReportGenerationExecutor); ok {
+		reports.SetTemplates(templates, data)
+	}
+}
+
+// SetMaintenanceDatabases lets db_maintenance jobs work on the scheduler's own database and on
+// registered external databases
+func (e *JobExecutor) SetMaintenanceDatabases(db *sql.DB, databases services.DatabaseRegistry) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if maintenance, ok := e.executors[models.JobTypeDBMaintenance].(*services.DBMaintenanceExecutor); ok {
+		maintenance.SetDatabases(db, databases)
+	}
+}
+
+// SetPipelineDatabases lets data_processing pipelines read from and write to registered external
+// databases, and write to the allowed internal tables of the scheduler's own database
+func (e *JobExecutor) SetPipelineDatabases(db *sql.DB, databases services.DatabaseRegistry) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if pipelines, ok := e.executors[models.JobTypeDataProcessing].(*services.DataProcessingExecutor); ok {
+		pipelines.SetDatabases(db, databases)
+	}
+}
+
+// SetHTTPClients makes HTTP-based executors use clients from the shared pool
+func (e *JobExecutor) SetHTTPClients(clients *httpclient.Factory) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.executors[models.JobTypeHealthCheck] = services.NewHealthCheckExecutor(
+		clients.Client("health_check", e.config.HealthCheck.Timeout))
+	if reports, ok := e.executors[models.JobTypeReportGeneration].(*services.ReportGenerationExecutor); ok {
+		reports.SetHTTPClient(clients.Client("report_source", 0))
+	}
+	if pipelines, ok := e.executors[models.JobTypeDataProcessing].(*services.DataProcessingExecutor); ok {
+		pipelines.SetHTTPClient(clients.Client("pipeline_source", 0))
+	}
+}
+
+// SetIntegrations makes executors use the shared long-lived connections, such as the SMTP pool
+func (e *JobExecutor) SetIntegrations(manager *integrations.Manager) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	email := services.NewEmailNotificationExecutor(manager, e.config.Notifications.EmailFrom)
+	email.SetSuppressions(e.emailSuppressions)
+	email.SetRecipientSources(e.emailRecipientData, e.emailRecipientArtifacts)
+	e.executors[models.JobTypeEmailNotification] = email
+}
+
+// SetEmailSuppressions makes email jobs skip recipients on the suppression list
+func (e *JobExecutor) SetEmailSuppressions(suppressions services.SuppressionLookup) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.emailSuppressions = suppressions
+	if email, ok := e.executors[models.JobTypeEmailNotification].(*services.EmailNotificationExecutor); ok {
+		email.SetSuppressions(suppressions)
+	}
+}
+
+// SetEmailRecipientSources lets batch email jobs read their recipients from SQL queries and CSV artifacts
+func (e *JobExecutor) SetEmailRecipientSources(data services.ReportDataSource, artifacts services.ArtifactReader) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.emailRecipientData = data
+	e.emailRecipientArtifacts = artifacts
+	if email, ok := e.executors[models.JobTypeEmailNotification].(*services.EmailNotificationExecutor); ok {
+		email.SetRecipientSources(data, artifacts)
+	}
+}
+
+// SetConnectionMonitor makes runs of jobs whose connection is known to be down fail fast
+func (e *JobExecutor) SetConnectionMonitor(connections services.ConnectionMonitor) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.connections = connections
+}
+
+// SetAcknowledgments holds back notifications of jobs an operator has acknowledged: failure notifications
+// in the alerts scope, and all of them in the notifications scope
+func (e *JobExecutor) SetAcknowledgments(acknowledgments services.AcknowledgmentLookup) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.acknowledgments = acknowledgments
+}
+
+// SetExecutionLogs keeps the lines each run logs, so they can be paged through or tailed live
+func (e *JobExecutor) SetExecutionLogs(executionLogs repositories.JobExecutionLogRepository) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.executionLogs = executionLogs
+}
+
+// SetJobEvents publishes when runs start, complete and fail
+func (e *JobExecutor) SetJobEvents(jobEvents *events.JobEventBus) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.jobEvents = jobEvents
+}
+
+// InitExecutors runs the Init hook of every executor that has one, in job type order
+// If one fails, those already initialized are closed again
+func (e *JobExecutor) InitExecutors(ctx context.Context) error {
+	lifecycles := e.lifecycleExecutors()
+	for i, lifecycle := range lifecycles {
+		if err := lifecycle.executor.Init(ctx); err != nil {
+			closeExecutors(lifecycles[:i])
+			return fmt.Errorf("failed to initialize %s executor: %w", lifecycle.jobType, err)
+		}
+	}
+	return nil
+}
+
+// CloseExecutors runs the Close hook of every executor that has one, in reverse job type order
+func (e *JobExecutor) CloseExecutors() {
+	closeExecutors(e.lifecycleExecutors())
+}
+
+// lifecycleExecutor is an executor with lifecycle hooks and the job type it runs
+type lifecycleExecutor struct {
+	jobType  models.JobType
+	executor services.LifecycleExecutor
+}
+
+// lifecycleExecutors returns the executors with lifecycle hooks, sorted by job type
+func (e *JobExecutor) lifecycleExecutors() []lifecycleExecutor {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var lifecycles []lifecycleExecutor
+	for jobType, executor := range e.executors {
+		if lifecycle, ok := executor.(services.LifecycleExecutor); ok {
+			lifecycles = append(lifecycles, lifecycleExecutor{jobType: jobType, executor: lifecycle})
+		}
+	}
+	sort.Slice(lifecycles, func(i, j int) bool { return lifecycles[i].jobType < lifecycles[j].jobType })
+	return lifecycles
+}
+
+// closeExecutors closes executors in reverse order, logging failures
+func closeExecutors(lifecycles []lifecycleExecutor) {
+	for i := len(lifecycles) - 1; i >= 0; i-- {
+		if err := lifecycles[i].executor.Close(); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"job_type": lifecycles[i].jobType,
+				"error":    err,
+			}).Warn("Failed to close executor")
+		}
 	}
 }
 
 // ExecuteJob executes a job with proper error handling and logging
 func (e *JobExecutor) ExecuteJob(job *models.Job) error {
-	// Acquire semaphore to limit concurrent executions
-	select {
-	case e.semaphore <- struct{}{}:
-		defer func() { <-e.semaphore }()
-	default:
+	return e.ExecuteJobWithParams(job, nil)
+}
+
+// ExecuteJobWithParams executes a job with trigger-supplied parameters
+// Parameters are recorded on the execution and exposed to the executor under config["params"]
+func (e *JobExecutor) ExecuteJobWithParams(job *models.Job, params models.JobConfig) error {
+	return e.executeNew(job, params, nil)
+}
+
+// executeNew creates and executes a run, recording the cron occurrence it belongs to if it was scheduled
+func (e *JobExecutor) executeNew(job *models.Job, params models.JobConfig, scheduledFor *time.Time) error {
+	// Create job execution record
+	execution := &models.JobExecution{
+		ID:           uuid.New(),
+		JobID:        job.ID,
+		ScheduledFor: scheduledFor,
+		Status:       models.ExecutionStatusPending,
+		Parameters:   params,
+		Attempt:      1,
+	}
+
+	return e.runExecution(job, execution, true)
+}
+
+// ExecuteJobWaitingForRetries executes a job and waits out its retries, returning the last attempt's error
+// Retries run in the caller's goroutine rather than on a timer; cancelling ctx abandons the retries still to come
+func (e *JobExecutor) ExecuteJobWaitingForRetries(ctx context.Context, job *models.Job, params models.JobConfig) error {
+	_, err := e.ExecuteJobWaitingForOutput(ctx, job, params)
+	return err
+}
+
+// ExecuteJobWaitingForOutput executes a job like ExecuteJobWaitingForRetries, also returning the output
+// the last attempt recorded
+func (e *JobExecutor) ExecuteJobWaitingForOutput(ctx context.Context, job *models.Job, params models.JobConfig) (models.ExecutionOutput, error) {
+	execution := &models.JobExecution{
+		ID:         uuid.New(),
+		JobID:      job.ID,
+		Status:     models.ExecutionStatusPending,
+		Parameters: params,
+		Attempt:    1,
+	}
+
+	for {
+		err := e.runAttempt(job, execution, true)
+		if execution.Status != models.ExecutionStatusFailed || !willRetry(job, execution) {
+			return execution.Output, err
+		}
+
+		timer := time.NewTimer(job.RetryDelay(execution.Attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return execution.Output, err
+		}
+		execution = execution.NextAttempt()
+	}
+}
+
+// ExecuteApprovedRun executes a run that was created awaiting approval and has since been approved
+func (e *JobExecutor) ExecuteApprovedRun(job *models.Job, execution *models.JobExecution) error {
+	return e.runExecution(job, execution, false)
+}
+
+// runExecution executes the job for the given execution record, scheduling a retry if it fails
+// When create is false the record already exists in the database
+func (e *JobExecutor) runExecution(job *models.Job, execution *models.JobExecution, create bool) error {
+	if execution.Attempt < 1 {
+		execution.Attempt = 1
+	}
+
+	err := e.runAttempt(job, execution, create)
+	if execution.Status == models.ExecutionStatusFailed && willRetry(job, execution) {
+		e.scheduleRetry(job, execution)
+	} else {
+		e.completeOneTimeJob(job, execution)
+	}
+	return err
+}
+
+// completeOneTimeJob expires a one-time job once the run of its occurrence has ended for good:
+// it succeeded, or failed with no retries left. Runs triggered outside the schedule don't complete it
+func (e *JobExecutor) completeOneTimeJob(job *models.Job, execution *models.JobExecution) {
+	e.mu.RLock()
+	oneTimeJobs := e.oneTimeJobs
+	e.mu.RUnlock()
+	if oneTimeJobs == nil || !job.IsOneTime() || execution.ScheduledFor == nil || !execution.IsCompleted() {
+		return
+	}
+
+	if err := oneTimeJobs.CompleteOneTimeJob(job.ID, *execution.ScheduledFor); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"job_id":       job.ID,
+			"execution_id": execution.ID,
+			"error":        err,
+		}).Error("Failed to complete one-time job")
+	}
+}
+
+// runAttempt acquires a concurrency slot and executes a single attempt
+func (e *JobExecutor) runAttempt(job *models.Job, execution *models.JobExecution, create bool) error {
+	// Don't take a slot, or retry, for a run that can't reach what it needs
+	if dependency, reason, down := e.unavailableDependency(job); down {
+		return e.failDependencyUnavailable(job, execution, create, dependency, reason)
+	}
+
+	// Count the run against its job until the attempt ends, for concurrency policies
+	e.trackJobRun(job.ID)
+	defer e.untrackJobRun(job.ID)
+
+	// Acquire a slot to limit concurrent executions, queueing the run until one frees up
+	queued := func() {
+		if execution.MarkAsQueued() != nil {
+			return
+		}
+		var err error
+		if create {
+			err = e.jobExecutionRepo.Create(execution)
+		} else {
+			err = e.jobExecutionRepo.Update(execution)
+		}
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"execution_id": execution.ID,
+				"error":        err,
+			}).Error("Failed to record queued execution")
+			return
+		}
+		create = false
+	}
+
+	// Hold the run back while runs start at their rate limit, before it takes any slot
+	if !e.rateLimits.acquire(job.JobType, e.config.Scheduler.MaxQueueWait, queued) {
+		logrus.WithFields(logrus.Fields{
+			"job_id":   job.ID,
+			"job_name": job.Name,
+			"job_type": job.JobType,
+		}).Warn("Job execution rejected - start rate limit reached")
+		err := fmt.Errorf("%w for %s jobs", ErrRateLimited, job.JobType)
+		return e.failUnstartedRun(execution, create, err)
+	}
+
+	// Take a slot of the job's concurrency group first, so a run waiting on its group doesn't hold an execution slot
+	group := job.ConcurrencyGroup
+	if !e.groups.acquire(group, e.config.Scheduler.MaxQueueWait, queued) {
+		logrus.WithFields(logrus.Fields{
+			"job_id":            job.ID,
+			"job_name":          job.Name,
+			"concurrency_group": group,
+		}).Warn("Job execution skipped - concurrency group limit reached")
+		err := fmt.Errorf("%w (%s: %d)", ErrConcurrencyGroupFull, group, e.config.Scheduler.ConcurrencyGroupLimits[group])
+		return e.failUnstartedRun(execution, create, err)
+	}
+	defer e.groups.release(group)
+
+	if err := e.acquireSlot(job, queued); err == nil {
+		defer e.slots.release()
+	} else {
 		logrus.WithFields(logrus.Fields{
 			"job_id":   job.ID,
 			"job_name": job.Name,
+			"reason":   err,
 		}).Warn("Job execution skipped - maximum concurrent jobs reached")
-		return fmt.Errorf("maximum concurrent jobs (%d) reached", e.config.Scheduler.MaxConcurrentJobs)
+		err := fmt.Errorf("%w (%d): %v", ErrMaxConcurrentJobs, e.config.Scheduler.MaxConcurrentJobs, err)
+		return e.failUnstartedRun(execution, create, err)
 	}
 
-	// Create job execution record
-	execution := &models.JobExecution{
-		ID:     uuid.New(),
-		JobID:  job.ID,
-		Status: models.ExecutionStatusPending,
+	// Don't start a run once the scheduler is shutting down; one saved while queued is left interrupted
+	if e.isDraining() {
+		return e.interruptUnstartedRun(execution, create)
+	}
+
+	// Expose parameters to the executor without mutating the caller's job
+	if len(execution.Parameters) > 0 {
+		job = withParams(job, execution.Parameters)
 	}
 
-	// Save initial execution record
-	if err := e.jobExecutionRepo.Create(execution); err != nil {
+	// Mark execution as running and save it; new runs are written once, already running
+	if err := execution.MarkAsRunning(); err != nil {
+		return fmt.Errorf("failed to start execution %s: %w", execution.ID, err)
+	}
+	execution.InstanceID = e.config.Scheduler.InstanceID
+	if create {
+		if err := e.jobExecutionRepo.Create(execution); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"job_id": job.ID,
+				"error":  err,
+			}).Error("Failed to create job execution record")
+			return fmt.Errorf("failed to create execution record: %w", err)
+		}
+	} else if err := e.jobExecutionRepo.Update(execution); err != nil {
 		logrus.WithFields(logrus.Fields{
-			"job_id": job.ID,
-			"error":  err,
-		}).Error("Failed to create job execution record")
-		return fmt.Errorf("failed to create execution record: %w", err)
+			"execution_id": execution.ID,
+			"error":        err,
+		}).Error("Failed to update execution status to running")
+		return fmt.Errorf("failed to update execution record: %w", err)
 	}
+	e.publishRunEvent(events.JobEventStarted, job, execution)
+
+	// Execute job with a context that times out at the run's deadline and that CancelExecution can also cancel
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	timeout := e.jobTimeout(job)
+	control := newRunControl(cancel, timeout, e.jobMaxExecutionTime(job), e.timeoutWarningBefore(timeout), func(deadline time.Time) {
+		e.notifyTimeoutWarning(job, execution, deadline)
+	})
+	defer control.release()
+
+	// Give the executor somewhere to record its side effects, holding those of the attempts it retries
+	sideEffects := services.NewSideEffectRecorder(execution.SideEffects)
+	ctx = services.WithSideEffectRecorder(ctx, sideEffects)
 
 	// Track running job
 	e.mu.Lock()
 	e.runningJobs[execution.ID] = execution
+	e.controls[execution.ID] = control
 	e.mu.Unlock()
 
 	// Clean up tracking when done
 	defer func() {
 		e.mu.Lock()
 		delete(e.runningJobs, execution.ID)
+		delete(e.controls, execution.ID)
 		e.mu.Unlock()
 	}()
 
-	// Execute job with timeout context
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
-	defer cancel()
-
 	// Execute in goroutine to handle timeout
 	errChan := make(chan error, 1)
 	go func() {
-		errChan <- e.executeJobWithContext(ctx, job, execution)
+		errChan <- e.executeJobWithContext(ctx, job, execution, control, sideEffects)
 	}()
 
-	// Wait for completion or timeout
+	// Wait for completion, timeout or cancellation
 	select {
 	case err := <-errChan:
 		return err
 	case <-ctx.Done():
-		execution.MarkAsFailed("Job execution timed out")
+	}
+
+	// Give the executor a moment to stop and record the outcome itself, unless the run is killed
+	select {
+	case err := <-errChan:
+		return err
+	case <-control.kill:
+	case <-time.After(cancelGracePeriod):
+	}
+
+	// The executor stopped just as the run was killed and is recording its outcome
+	if !control.settle() {
+		return <-errChan
+	}
+
+	// Kill the run; the executor's goroutine is abandoned and its outcome ignored
+	var markErr error
+	if control.isInterrupted() {
+		markErr = execution.MarkAsInterrupted(interruptedByShutdown)
+	} else if !control.isTimedOut() {
+		markErr = execution.MarkAsCancelled()
+	} else {
+		markErr = execution.MarkAsFailed("Job execution timed out")
+	}
+	execution.SetTermination(models.ExecutionTerminationKilled)
+	execution.SideEffects = sideEffects.Tokens()
+	logrus.WithFields(logrus.Fields{
+		"job_id":       job.ID,
+		"execution_id": execution.ID,
+	}).Warn("Job execution killed without waiting for its executor to stop")
+	if markErr != nil {
+		logrus.WithFields(logrus.Fields{
+			"execution_id": execution.ID,
+			"error":        markErr,
+		}).Error("Failed to record execution timeout")
+	} else if updateErr := e.jobExecutionRepo.Update(execution); updateErr != nil {
+		logrus.WithFields(logrus.Fields{
+			"execution_id": execution.ID,
+			"error":        updateErr,
+		}).Error("Failed to update execution record after timeout")
+	}
+	if execution.Status == models.ExecutionStatusCancelled {
+		return ErrExecutionCancelled
+	}
+	if execution.Status == models.ExecutionStatusInterrupted {
+		return ErrExecutionInterrupted
+	}
+	e.publishRunEvent(events.JobEventFailed, job, execution)
+	e.notifyFailure(job, execution)
+	return fmt.Errorf("job execution timed out")
+}
+
+// failUnstartedRun records a run that waited for a slot and didn't get one as failed, returning err
+// Runs that didn't have to wait were never saved, so there is nothing to record for them
+func (e *JobExecutor) failUnstartedRun(execution *models.JobExecution, create bool, err error) error {
+	if !create && execution.MarkAsFailed(err.Error()) == nil {
 		if updateErr := e.jobExecutionRepo.Update(execution); updateErr != nil {
 			logrus.WithFields(logrus.Fields{
 				"execution_id": execution.ID,
 				"error":        updateErr,
-			}).Error("Failed to update execution record after timeout")
+			}).Error("Failed to update execution record")
 		}
-		return fmt.Errorf("job execution timed out")
 	}
+	return err
 }
 
-// executeJobWithContext executes a job with the given context
-func (e *JobExecutor) executeJobWithContext(ctx context.Context, job *models.Job, execution *models.JobExecution) error {
-	// Mark execution as running
-	execution.MarkAsRunning()
-	if err := e.jobExecutionRepo.Update(execution); err != nil {
+// unavailableDependency returns the first connection the job uses that is known to be down
+func (e *JobExecutor) unavailableDependency(job *models.Job) (string, string, bool) {
+	e.mu.RLock()
+	connections := e.connections
+	e.mu.RUnlock()
+	if connections == nil {
+		return "", "", false
+	}
+
+	for _, dependency := range services.JobDependencies(job) {
+		if reason, down := connections.Unavailable(dependency); down {
+			return dependency, reason, true
+		}
+	}
+	return "", "", false
+}
+
+// failDependencyUnavailable records a run that didn't start because a connection its job uses is down
+func (e *JobExecutor) failDependencyUnavailable(job *models.Job, execution *models.JobExecution, create bool, dependency, reason string) error {
+	err := fmt.Errorf("%w: %s is down: %s", ErrDependencyUnavailable, dependency, reason)
+	logrus.WithFields(logrus.Fields{
+		"job_id":     job.ID,
+		"job_name":   job.Name,
+		"dependency": dependency,
+	}).Warn("Job execution skipped - dependency unavailable")
+
+	if markErr := execution.MarkAsDependencyUnavailable(err.Error()); markErr != nil {
+		return fmt.Errorf("failed to record execution %s: %w", execution.ID, markErr)
+	}
+	var saveErr error
+	if create {
+		saveErr = e.jobExecutionRepo.Create(execution)
+	} else {
+		saveErr = e.jobExecutionRepo.Update(execution)
+	}
+	if saveErr != nil {
 		logrus.WithFields(logrus.Fields{
 			"execution_id": execution.ID,
-			"error":        err,
-		}).Error("Failed to update execution status to running")
+			"error":        saveErr,
+		}).Error("Failed to record execution with unavailable dependency")
 	}
+	return err
+}
 
+// executeJobWithContext executes a job with the given context
+// Once the executor returns its outcome is recorded with its side effects, unless the run was killed in the meantime
+func (e *JobExecutor) executeJobWithContext(ctx context.Context, job *models.Job, execution *models.JobExecution, control *runControl, sideEffects *services.SideEffectRecorder) error {
 	logrus.WithFields(logrus.Fields{
 		"job_id":       job.ID,
 		"job_name":     job.Name,
@@ -134,21 +646,47 @@ func (e *JobExecutor) executeJobWithContext(ctx context.Context, job *models.Job
 	}).Info("Starting job execution")
 
 	// Get executor for job type
+	e.mu.RLock()
 	executor, exists := e.executors[job.JobType]
+	e.mu.RUnlock()
 	if !exists {
 		err := fmt.Errorf("no executor found for job type: %s", job.JobType)
-		execution.MarkAsFailed(err.Error())
+		if !control.settle() {
+			return err
+		}
+		if markErr := execution.MarkAsFailed(err.Error()); markErr != nil {
+			return markErr
+		}
 		if updateErr := e.jobExecutionRepo.Update(execution); updateErr != nil {
 			logrus.WithFields(logrus.Fields{
 				"execution_id": execution.ID,
 				"error":        updateErr,
 			}).Error("Failed to update execution record")
 		}
+		e.publishRunEvent(events.JobEventFailed, job, execution)
+		e.notifyFailure(job, execution)
 		return err
 	}
 
-	// Execute the job
+	// Execute the job, giving the executor somewhere to record its output
 	var executionErr error
+	var files []services.ArtifactFile
+	var usage *models.ResourceUsage
+	output := services.NewOutputRecorder()
+	ctx = services.WithOutputRecorder(ctx, output)
+	e.mu.RLock()
+	executionLogs := e.executionLogs
+	e.mu.RUnlock()
+	var runLog *services.RunLog
+	if executionLogs != nil {
+		ctx, runLog = services.StartRunLog(ctx, executionLogs, execution.ID, logrus.Fields{
+			"job_id":       job.ID,
+			"job_name":     job.Name,
+			"execution_id": execution.ID,
+			"attempt":      execution.Attempt,
+		}, e.executionLogsConfig())
+		defer runLog.Close()
+	}
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
@@ -169,13 +707,69 @@ func (e *JobExecutor) executeJobWithContext(ctx context.Context, job *models.Job
 		default:
 		}
 
-		// Execute the job
-		executionErr = executor.Execute(job)
+		// Executors that measure their own usage report it; otherwise sample the process
+		if reporter, ok := executor.(services.UsageReportingExecutor); ok {
+			usage, executionErr = reporter.ExecuteWithUsage(ctx, job)
+			return
+		}
+		sampler := startUsageSampler()
+		defer func() { usage = sampler.stop() }()
+
+		// Execute the job, collecting its files when they are recorded for download
+		if producer, ok := executor.(services.ArtifactExecutor); ok && e.artifactService() != nil {
+			files, executionErr = producer.ExecuteWithArtifacts(ctx, job)
+			return
+		}
+		executionErr = executor.Execute(ctx, job)
 	}()
 
+	// Store the run's last lines before its outcome, so a finished run's log is complete
+	if runLog != nil {
+		runLog.Close()
+	}
+
+	// A killed run has already been recorded
+	if !control.settle() {
+		logrus.WithFields(logrus.Fields{
+			"job_id":       job.ID,
+			"execution_id": execution.ID,
+		}).Warn("Executor stopped after its run was killed - outcome not recorded")
+		return ErrExecutionCancelled
+	}
+	execution.ResourceUsage = usage
+	execution.Output = output.Output()
+	execution.SideEffects = sideEffects.Tokens()
+
+	// A run that returned without error still fails when it doesn't meet the job's success criteria
+	if executionErr == nil {
+		executionErr = checkSuccessCriteria(job, execution)
+	}
+
 	// Update execution status based on result
-	if executionErr != nil {
-		execution.MarkAsFailed(executionErr.Error())
+	var markErr error
+	interrupted := executionErr != nil && ctx.Err() != nil && control.isInterrupted()
+	cancelled := executionErr != nil && ctx.Err() != nil && !control.isTimedOut()
+	if interrupted {
+		markErr = execution.MarkAsInterrupted(interruptedByShutdown)
+		executionErr = ErrExecutionInterrupted
+		logrus.WithFields(logrus.Fields{
+			"job_id":       job.ID,
+			"job_name":     job.Name,
+			"execution_id": execution.ID,
+		}).Warn("Job execution interrupted by shutdown")
+	} else if cancelled {
+		markErr = execution.MarkAsCancelled()
+		executionErr = ErrExecutionCancelled
+		logrus.WithFields(logrus.Fields{
+			"job_id":       job.ID,
+			"job_name":     job.Name,
+			"execution_id": execution.ID,
+		}).Warn("Job execution cancelled")
+	} else if executionErr != nil {
+		if ctx.Err() != nil && control.isTimedOut() {
+			executionErr = fmt.Errorf("job execution timed out")
+		}
+		markErr = execution.MarkAsFailed(executionErr.Error())
 		logrus.WithFields(logrus.Fields{
 			"job_id":       job.ID,
 			"job_name":     job.Name,
@@ -183,15 +777,26 @@ func (e *JobExecutor) executeJobWithContext(ctx context.Context, job *models.Job
 			"error":        executionErr,
 		}).Error("Job execution failed")
 	} else {
-		execution.MarkAsCompleted()
+		markErr = execution.MarkAsCompleted()
 		logrus.WithFields(logrus.Fields{
-			"job_id":            job.ID,
-			"job_name":          job.Name,
-			"execution_id":      execution.ID,
+			"job_id":             job.ID,
+			"job_name":           job.Name,
+			"execution_id":       execution.ID,
 			"execution_duration": execution.GetDurationString(),
 		}).Info("Job execution completed successfully")
 	}
 
+	if markErr != nil {
+		logrus.WithFields(logrus.Fields{
+			"execution_id": execution.ID,
+			"error":        markErr,
+		}).Warn("Execution outcome not recorded")
+		return markErr
+	}
+	if executionErr != nil && ctx.Err() != nil {
+		execution.SetTermination(models.ExecutionTerminationGraceful)
+	}
+
 	// Save final execution status
 	if err := e.jobExecutionRepo.Update(execution); err != nil {
 		logrus.WithFields(logrus.Fields{
@@ -201,9 +806,533 @@ func (e *JobExecutor) executeJobWithContext(ctx context.Context, job *models.Job
 		return fmt.Errorf("failed to update execution status: %w", err)
 	}
 
+	if interrupted || cancelled {
+		return executionErr
+	}
+	e.notifyDurationExceeded(job, execution)
+	if executionErr != nil {
+		e.publishRunEvent(events.JobEventFailed, job, execution)
+		e.notifyFailure(job, execution)
+	} else {
+		e.publishRunEvent(events.JobEventCompleted, job, execution)
+		if len(files) > 0 {
+			e.recordArtifacts(job, execution, files)
+		}
+	}
+
 	return executionErr
 }
 
+// scheduleRetry re-runs a failed execution after the job's backoff delay
+// The retry is a new execution linked to the failed one
+func (e *JobExecutor) scheduleRetry(job *models.Job, failed *models.JobExecution) {
+	retry := failed.NextAttempt()
+	delay := job.RetryDelay(failed.Attempt)
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":       job.ID,
+		"job_name":     job.Name,
+		"execution_id": failed.ID,
+		"attempt":      retry.Attempt,
+		"delay":        delay.String(),
+	}).Info("Scheduling retry of failed job execution")
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.retries[retry.ID] = time.AfterFunc(delay, func() {
+		e.mu.Lock()
+		delete(e.retries, retry.ID)
+		e.mu.Unlock()
+
+		if err := e.runExecution(job, retry, true); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"job_id":       job.ID,
+				"execution_id": retry.ID,
+				"attempt":      retry.Attempt,
+				"error":        err,
+			}).Warn("Job execution retry failed")
+		}
+	})
+}
+
+// MarkStalledRuns marks runs recorded as running for longer than any instance would run them as stalled,
+// then fails them, retrying them under their job's retry policy when the scheduler is configured to
+// It returns how many runs were marked stalled
+func (e *JobExecutor) MarkStalledRuns() (int, error) {
+	executions, err := e.jobExecutionRepo.GetRunningExecutions()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get running executions: %w", err)
+	}
+
+	// Every instance stops a run by its job's timeout, or the maximum execution time for jobs without one,
+	// so an older run has no instance left to finish it
+	now := time.Now().UTC()
+	stalled := 0
+	for i := range executions {
+		execution := &executions[i]
+		maxTime := e.jobMaxExecutionTime(&execution.Job)
+		cutoff := now.Add(-(maxTime + cancelGracePeriod + time.Minute))
+		if execution.StartedAt.After(cutoff) || e.isRunning(execution.ID) {
+			continue
+		}
+
+		if err := execution.MarkAsStalled(); err != nil {
+			continue
+		}
+		// The update only applies if the run is still recorded as running
+		if err := e.jobExecutionRepo.Update(execution); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"execution_id": execution.ID,
+				"error":        err,
+			}).Warn("Failed to mark run stalled")
+			continue
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"job_id":       execution.JobID,
+			"execution_id": execution.ID,
+			"started_at":   execution.StartedAt,
+		}).Warn("Run stalled")
+		stalled++
+		e.failStalledRun(execution, maxTime)
+	}
+	return stalled, nil
+}
+
+// failStalledRun records a stalled run as failed, scheduling a retry if enabled and the job has retries left
+// maxTime is how long the run could have taken
+func (e *JobExecutor) failStalledRun(execution *models.JobExecution, maxTime time.Duration) {
+	runningFor := time.Since(execution.StartedAt).Round(time.Second)
+	limit := fmt.Sprintf("the %s maximum execution time", maxTime)
+	if execution.Job.TimeoutSeconds > 0 {
+		limit = fmt.Sprintf("its job's %s timeout", maxTime)
+	}
+	reason := fmt.Sprintf("Run stalled - still recorded as running %s after it started, past %s; the instance running it most likely stopped", runningFor, limit)
+	if execution.MarkAsFailed(reason) != nil {
+		return
+	}
+	if err := e.jobExecutionRepo.Update(execution); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"execution_id": execution.ID,
+			"error":        err,
+		}).Warn("Failed to fail stalled run")
+		return
+	}
+
+	job := &execution.Job
+	if job.ID == uuid.Nil {
+		return
+	}
+	if e.config.Scheduler.RetryStalledRuns && willRetry(job, execution) {
+		e.scheduleRetry(job, execution)
+	} else {
+		e.completeOneTimeJob(job, execution)
+	}
+}
+
+// isRunning reports whether this executor is running the execution
+func (e *JobExecutor) isRunning(executionID uuid.UUID) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	_, ok := e.runningJobs[executionID]
+	return ok
+}
+
+// ExtendExecution moves a running execution's deadline later by d, up to the maximum execution time
+// It returns the new deadline
+func (e *JobExecutor) ExtendExecution(executionID uuid.UUID, d time.Duration) (time.Time, error) {
+	e.mu.RLock()
+	control, ok := e.controls[executionID]
+	e.mu.RUnlock()
+	if !ok {
+		return time.Time{}, services.ErrExecutionNotRunning
+	}
+
+	deadline, err := control.extend(d)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"execution_id": executionID,
+		"by":           d.String(),
+		"deadline":     deadline,
+	}).Info("Job execution deadline extended")
+	return deadline, nil
+}
+
+// SetExecutionDeadline moves a running execution's deadline to the given time, earlier or later,
+// up to the maximum execution time. It returns the new deadline
+func (e *JobExecutor) SetExecutionDeadline(executionID uuid.UUID, deadline time.Time) (time.Time, error) {
+	e.mu.RLock()
+	control, ok := e.controls[executionID]
+	e.mu.RUnlock()
+	if !ok {
+		return time.Time{}, services.ErrExecutionNotRunning
+	}
+
+	deadline, err := control.setDeadline(deadline)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"execution_id": executionID,
+		"deadline":     deadline,
+	}).Info("Job execution deadline changed")
+	return deadline, nil
+}
+
+// maxQueueDepth returns how many runs may wait for an execution slot
+func maxQueueDepth(cfg *config.Config) int {
+	if cfg.Scheduler.MaxQueueDepth > 0 {
+		return cfg.Scheduler.MaxQueueDepth
+	}
+	return defaultMaxQueueDepth
+}
+
+// executionTimeout returns how long a run may execute before it is stopped
+func (e *JobExecutor) executionTimeout() time.Duration {
+	if e.config.Scheduler.ExecutionTimeout > 0 {
+		return e.config.Scheduler.ExecutionTimeout
+	}
+	return defaultExecutionTimeout
+}
+
+// executionLogsConfig returns how run log lines are captured, with defaults for unset values
+func (e *JobExecutor) executionLogsConfig() config.ExecutionLogsConfig {
+	cfg := e.config.ExecutionLogs
+	if cfg.MaxLines <= 0 {
+		cfg.MaxLines = defaultExecutionLogMaxLines
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = defaultExecutionLogFlush
+	}
+	return cfg
+}
+
+// maxExecutionTime returns how long a run may execute once its deadline has been extended
+func (e *JobExecutor) maxExecutionTime() time.Duration {
+	maxTime := e.config.Scheduler.MaxExecutionTime
+	if maxTime <= 0 {
+		maxTime = defaultMaxExecutionTime
+	}
+	if timeout := e.executionTimeout(); maxTime < timeout {
+		return timeout
+	}
+	return maxTime
+}
+
+// jobTimeout returns how long the job's runs may take before they time out: the job's own timeout, or the
+// execution timeout for jobs without one
+func (e *JobExecutor) jobTimeout(job *models.Job) time.Duration {
+	if job.TimeoutSeconds > 0 {
+		return time.Duration(job.TimeoutSeconds) * time.Second
+	}
+	return e.executionTimeout()
+}
+
+// jobMaxExecutionTime returns how long after they start the job's runs may be extended to
+// A job's own timeout is a hard limit on its runs; jobs without one get the maximum execution time
+func (e *JobExecutor) jobMaxExecutionTime(job *models.Job) time.Duration {
+	if job.TimeoutSeconds > 0 {
+		return time.Duration(job.TimeoutSeconds) * time.Second
+	}
+	return e.maxExecutionTime()
+}
+
+// timeoutWarningBefore returns how long before its deadline a run's timeout warning is sent
+func (e *JobExecutor) timeoutWarningBefore(timeout time.Duration) time.Duration {
+	percent := e.config.Scheduler.TimeoutWarningPercent
+	if percent <= 0 || percent >= 100 {
+		percent = defaultTimeoutWarningPercent
+	}
+	return timeout * time.Duration(100-percent) / 100
+}
+
+// notifyTimeoutWarning tells operators a run is about to time out, so they can extend it
+func (e *JobExecutor) notifyTimeoutWarning(job *models.Job, execution *models.JobExecution, deadline time.Time) {
+	logrus.WithFields(logrus.Fields{
+		"job_id":       job.ID,
+		"job_name":     job.Name,
+		"execution_id": execution.ID,
+		"deadline":     deadline,
+	}).Warn("Job execution approaching its timeout")
+
+	e.mu.RLock()
+	notifier := e.notifier
+	e.mu.RUnlock()
+	if notifier == nil || e.acknowledged(job, execution, models.AcknowledgmentScopeNotifications) {
+		return
+	}
+
+	n := notifications.Notification{
+		Event:   notifications.EventTimeoutWarning,
+		Title:   fmt.Sprintf("[%s] Run about to time out: %s", job.Severity, job.Name),
+		Message: fmt.Sprintf("Run %s will be stopped at %s. Extend it with POST /api/v1/executions/%s/extend?by=10m", execution.ID, deadline.Format(time.RFC3339), execution.ID),
+		Job:     job,
+		Fields: map[string]interface{}{
+			"execution_id": execution.ID,
+			"deadline":     deadline.Format(time.RFC3339),
+			"severity":     job.Severity,
+			"runbook_url":  job.RunbookURL,
+		},
+		Timestamp: time.Now().UTC(),
+	}
+	if err := notifier.Notify(context.Background(), n); err != nil {
+		logrus.WithError(err).Warn("Failed to send notification")
+	}
+}
+
+// CancelRetries stops retries that are still waiting out their backoff and returns how many were cancelled
+func (e *JobExecutor) CancelRetries() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	cancelled := 0
+	for id, timer := range e.retries {
+		if timer.Stop() {
+			cancelled++
+		}
+		delete(e.retries, id)
+	}
+	return cancelled
+}
+
+// willRetry reports whether a failed execution has retries left under the job's policy
+func willRetry(job *models.Job, execution *models.JobExecution) bool {
+	return execution.Attempt <= job.MaxRetries
+}
+
+// artifactService returns the artifact service, if enabled
+func (e *JobExecutor) artifactService() services.ArtifactService {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.artifacts
+}
+
+// recordArtifacts records the files a successful run produced
+// Failing to record them doesn't fail the run - the files stay in place
+func (e *JobExecutor) recordArtifacts(job *models.Job, execution *models.JobExecution, files []services.ArtifactFile) {
+	if err := e.artifactService().RecordArtifacts(job, execution, files); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"job_id":       job.ID,
+			"execution_id": execution.ID,
+			"error":        err,
+		}).Error("Failed to record run artifacts")
+	}
+}
+
+// publishRunEvent tells job event subscribers about a run that started or ended
+func (e *JobExecutor) publishRunEvent(eventType events.JobEventType, job *models.Job, execution *models.JobExecution) {
+	e.mu.RLock()
+	jobEvents := e.jobEvents
+	e.mu.RUnlock()
+
+	event := events.JobEvent{
+		Type:        eventType,
+		JobID:       job.ID,
+		JobName:     job.Name,
+		ExecutionID: &execution.ID,
+		Attempt:     execution.Attempt,
+	}
+	if execution.ErrorMessage != nil {
+		event.Error = execution.ErrorMessage.String()
+	}
+	jobEvents.Publish(event)
+}
+
+// notifyFailure alerts on-call engineers about a failed run, with the job's runbook, severity
+// and links to its remediation actions
+// Failed attempts that will be retried aren't notified; only the last attempt is
+func (e *JobExecutor) notifyFailure(job *models.Job, execution *models.JobExecution) {
+	if willRetry(job, execution) {
+		return
+	}
+
+	e.mu.RLock()
+	notifier := e.notifier
+	remediation := e.remediation
+	e.mu.RUnlock()
+	if notifier == nil || e.acknowledged(job, execution, models.AcknowledgmentScopeAlerts, models.AcknowledgmentScopeNotifications) {
+		return
+	}
+
+	// Jobs with a failure threshold are only notified once enough runs have failed in a row
+	threshold := e.failureThreshold(job)
+	streak := 1
+	if threshold > 1 {
+		var err error
+		if streak, err = e.failureStreak(job, threshold); err != nil {
+			logrus.WithError(err).Warn("Failed to count consecutive failures - notifying anyway")
+			streak = threshold
+		} else if streak < threshold {
+			logrus.WithFields(logrus.Fields{
+				"job_id":       job.ID,
+				"execution_id": execution.ID,
+				"failures":     streak,
+				"threshold":    threshold,
+			}).Info("Failure notification held back - failure threshold not reached")
+			return
+		}
+	}
+
+	message := fmt.Sprintf("Run %s failed", execution.ID)
+	if execution.Attempt > 1 {
+		message = fmt.Sprintf("Run %s failed after %d attempts", execution.ID, execution.Attempt)
+	}
+	if threshold > 1 {
+		message = fmt.Sprintf("%s (%d runs failed in a row)", message, streak)
+	}
+	if execution.ErrorMessage != nil {
+		message = fmt.Sprintf("%s: %s", message, *execution.ErrorMessage)
+	}
+
+	n := notifications.Notification{
+		Event:     notifications.EventJobFailed,
+		Title:     fmt.Sprintf("[%s] Job failed: %s", job.Severity, job.Name),
+		Message:   message,
+		Job:       job,
+		Execution: execution,
+		Fields: map[string]interface{}{
+			"severity":             job.Severity,
+			"runbook_url":          job.RunbookURL,
+			"consecutive_failures": streak,
+		},
+		Timestamp: time.Now().UTC(),
+	}
+	if remediation != nil {
+		n.Actions = remediation.Links(job, execution)
+	}
+	if err := notifier.Notify(context.Background(), n); err != nil {
+		logrus.WithError(err).Warn("Failed to send notification")
+	}
+}
+
+// failureStreak counts the job's runs that failed in a row, up to threshold, ending with the latest
+// Attempts that were retried don't count; only a run's final attempt does
+func (e *JobExecutor) failureStreak(job *models.Job, threshold int) (int, error) {
+	executions, err := e.jobExecutionRepo.GetRecentFinished(job.ID, threshold*(job.MaxRetries+1))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get recent executions: %w", err)
+	}
+
+	streak := 0
+	for i := range executions {
+		if executions[i].Status == models.ExecutionStatusCompleted {
+			break
+		}
+		if !willRetry(job, &executions[i]) {
+			streak++
+		}
+	}
+	return streak, nil
+}
+
+// checkSuccessCriteria returns an error unless the run meets config["success_criteria"], if the job has any
+func checkSuccessCriteria(job *models.Job, execution *models.JobExecution) error {
+	criteria, err := services.ParseSuccessCriteria(job.Config)
+	if err != nil || criteria == nil {
+		return err
+	}
+	return criteria.Check(context.Background(), execution)
+}
+
+// failureThreshold returns how many runs of the job must fail in a row before its failures are notified
+// config["alert_after_failures"] overrides the instance's threshold
+func (e *JobExecutor) failureThreshold(job *models.Job) int {
+	if failures, ok := job.Config["alert_after_failures"].(float64); ok && failures >= 1 {
+		return int(failures)
+	}
+	if e.config.Notifications.FailureThreshold > 0 {
+		return e.config.Notifications.FailureThreshold
+	}
+	return 1
+}
+
+// durationThreshold returns how long the job's runs may take before they are notified, 0 if they never are
+// config["alert_duration_threshold_seconds"] overrides the instance's threshold, with 0 disabling it
+func (e *JobExecutor) durationThreshold(job *models.Job) time.Duration {
+	if seconds, ok := job.Config["alert_duration_threshold_seconds"].(float64); ok && seconds >= 0 {
+		return time.Duration(seconds * float64(time.Second))
+	}
+	return e.config.Notifications.DurationThreshold
+}
+
+// notifyDurationExceeded tells operators a run took longer than its job's duration threshold
+func (e *JobExecutor) notifyDurationExceeded(job *models.Job, execution *models.JobExecution) {
+	threshold := e.durationThreshold(job)
+	if threshold <= 0 || execution.ExecutionDuration == nil {
+		return
+	}
+	duration := time.Duration(*execution.ExecutionDuration) * time.Millisecond
+	if duration <= threshold {
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":       job.ID,
+		"job_name":     job.Name,
+		"execution_id": execution.ID,
+		"duration":     duration.String(),
+		"threshold":    threshold.String(),
+	}).Warn("Job execution exceeded its duration threshold")
+
+	e.mu.RLock()
+	notifier := e.notifier
+	e.mu.RUnlock()
+	if notifier == nil || e.acknowledged(job, execution, models.AcknowledgmentScopeNotifications) {
+		return
+	}
+
+	n := notifications.Notification{
+		Event:     notifications.EventDurationExceeded,
+		Title:     fmt.Sprintf("[%s] Run took too long: %s", job.Severity, job.Name),
+		Message:   fmt.Sprintf("Run %s took %s, longer than the %s threshold", execution.ID, duration, threshold),
+		Job:       job,
+		Execution: execution,
+		Fields: map[string]interface{}{
+			"duration":    duration.String(),
+			"threshold":   threshold.String(),
+			"severity":    job.Severity,
+			"runbook_url": job.RunbookURL,
+		},
+		Timestamp: time.Now().UTC(),
+	}
+	if err := notifier.Notify(context.Background(), n); err != nil {
+		logrus.WithError(err).Warn("Failed to send notification")
+	}
+}
+
+// acknowledged reports whether an acknowledgment in one of the scopes holds back the job's notification,
+// logging the acknowledgment that does. Acknowledgments that can't be checked hold back nothing
+func (e *JobExecutor) acknowledged(job *models.Job, execution *models.JobExecution, scopes ...models.AcknowledgmentScope) bool {
+	e.mu.RLock()
+	acknowledgments := e.acknowledgments
+	e.mu.RUnlock()
+	if acknowledgments == nil {
+		return false
+	}
+
+	active, err := acknowledgments.GetActiveAcknowledgments(scopes...)
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to check job acknowledgments - notifying anyway")
+		return false
+	}
+	acknowledgment, ok := active[job.ID]
+	if !ok {
+		return false
+	}
+	logrus.WithFields(logrus.Fields{
+		"job_id":          job.ID,
+		"execution_id":    execution.ID,
+		"acknowledged_by": acknowledgment.AcknowledgedBy,
+		"scope":           acknowledgment.Scope,
+		"expires_at":      acknowledgment.ExpiresAt,
+	}).Info("Notification held back - job acknowledged")
+	return true
+}
+
 // GetRunningJobs returns a list of currently running job executions
 func (e *JobExecutor) GetRunningJobs() []*models.JobExecution {
 	e.mu.RLock()
@@ -217,6 +1346,25 @@ func (e *JobExecutor) GetRunningJobs() []*models.JobExecution {
 	return running
 }
 
+// CancelExecution cancels the context of a running execution, stopping its executor
+// A forced cancel kills the run straight away instead of waiting for the executor to stop
+// It returns false if the execution isn't running on this instance
+func (e *JobExecutor) CancelExecution(executionID uuid.UUID, force bool) bool {
+	e.mu.RLock()
+	control, ok := e.controls[executionID]
+	e.mu.RUnlock()
+	if !ok {
+		return false
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"execution_id": executionID,
+		"force":        force,
+	}).Info("Cancelling job execution")
+	control.stop(force)
+	return true
+}
+
 // GetRunningJobsCount returns the number of currently running jobs
 func (e *JobExecutor) GetRunningJobsCount() int {
 	e.mu.RLock()
@@ -224,7 +1372,34 @@ func (e *JobExecutor) GetRunningJobsCount() int {
 	return len(e.runningJobs)
 }
 
+// GetQueuedRuns returns the number of runs waiting for a slot per team
+// Runs of jobs without a team are counted under ""
+func (e *JobExecutor) GetQueuedRuns() map[string]int {
+	return e.slots.queued()
+}
+
+// GetConcurrencyGroups returns the slots used by each concurrency group with a limit
+func (e *JobExecutor) GetConcurrencyGroups() map[string]ConcurrencyGroupStats {
+	return e.groups.stats()
+}
+
+// GetRateLimits returns how each start rate limit held runs back, with the limit on all jobs under "*"
+func (e *JobExecutor) GetRateLimits() map[string]RateLimitStats {
+	return e.rateLimits.stats()
+}
+
 // GetMaxConcurrentJobs returns the maximum number of concurrent jobs allowed
 func (e *JobExecutor) GetMaxConcurrentJobs() int {
 	return e.config.Scheduler.MaxConcurrentJobs
 }
+
+// withParams returns a copy of job whose config carries the given parameters
+func withParams(job *models.Job, params models.JobConfig) *models.Job {
+	jobCopy := *job
+	jobCopy.Config = make(models.JobConfig, len(job.Config)+1)
+	for k, v := range job.Config {
+		jobCopy.Config[k] = v
+	}
+	jobCopy.Config["params"] = map[string]interface{}(params)
+	return &jobCopy
+}
@@ -0,0 +1,188 @@
+package scheduler
+
+import (
+	"container/heap"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// errQueueFull is returned when a run can't wait for a slot because the queue is at its depth
+	errQueueFull = errors.New("run queue is full")
+	// errQueueWaitExceeded is returned when no slot freed up within the run's wait
+	errQueueWaitExceeded = errors.New("no execution slot freed up in time")
+)
+
+// fairQueue limits concurrent executions and, when every slot is taken, hands freed slots to
+// waiting runs by weighted fair queuing across teams, so one busy team can't monopolize the slots
+// Each waiting run is tagged with a virtual finish time that grows by 1/weight per run queued for
+// its team; freed slots go to the smallest tag
+type fairQueue struct {
+	mu          sync.Mutex
+	slots       int
+	inUse       int
+	depth       int // how many runs may wait for a slot
+	weights     map[string]int
+	virtualTime float64
+	lastFinish  map[string]float64 // team -> finish tag of its last queued run
+	waiting     waiterHeap
+	seq         uint64
+}
+
+// waiter is a run waiting for a slot
+type waiter struct {
+	team    string
+	finish  float64
+	seq     uint64 // breaks ties in queue order
+	ready   chan struct{}
+	granted bool
+	index   int
+}
+
+// newFairQueue creates a fair queue with the given number of slots, holding up to depth waiting runs
+// Teams without a weight, including jobs without a team, have weight 1
+func newFairQueue(slots, depth int, weights map[string]int) *fairQueue {
+	return &fairQueue{
+		slots:      slots,
+		depth:      depth,
+		weights:    weights,
+		lastFinish: make(map[string]float64),
+	}
+}
+
+// tryAcquire takes a free slot without waiting
+func (q *fairQueue) tryAcquire() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.inUse < q.slots && len(q.waiting) == 0 {
+		q.inUse++
+		return true
+	}
+	return false
+}
+
+// acquire waits up to timeout for a slot, queued fairly against other teams' runs
+// queued is called once the run is waiting in the queue, before it waits
+func (q *fairQueue) acquire(team string, timeout time.Duration, queued func()) error {
+	q.mu.Lock()
+	if q.inUse < q.slots && len(q.waiting) == 0 {
+		q.inUse++
+		q.mu.Unlock()
+		return nil
+	}
+	if len(q.waiting) >= q.depth {
+		q.mu.Unlock()
+		return errQueueFull
+	}
+
+	weight := q.weights[team]
+	if weight < 1 {
+		weight = 1
+	}
+	start := q.virtualTime
+	if last := q.lastFinish[team]; last > start {
+		start = last
+	}
+	w := &waiter{
+		team:   team,
+		finish: start + 1/float64(weight),
+		seq:    q.seq,
+		ready:  make(chan struct{}),
+	}
+	q.seq++
+	q.lastFinish[team] = w.finish
+	heap.Push(&q.waiting, w)
+	q.mu.Unlock()
+
+	if queued != nil {
+		queued()
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-timer.C:
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if w.granted {
+		// A slot was handed over as the wait timed out
+		return nil
+	}
+	heap.Remove(&q.waiting, w.index)
+	return errQueueWaitExceeded
+}
+
+// release frees a slot, handing it to the next waiting run if there is one
+func (q *fairQueue) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.waiting) == 0 {
+		q.inUse--
+		return
+	}
+
+	w := heap.Pop(&q.waiting).(*waiter)
+	w.granted = true
+	q.virtualTime = w.finish
+	close(w.ready)
+}
+
+// running returns the number of slots in use
+func (q *fairQueue) running() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.inUse
+}
+
+// queued returns the number of waiting runs per team
+func (q *fairQueue) queued() map[string]int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	counts := make(map[string]int)
+	for _, w := range q.waiting {
+		counts[w.team]++
+	}
+	return counts
+}
+
+// waiterHeap orders waiting runs by finish tag, then queue order
+type waiterHeap []*waiter
+
+func (h waiterHeap) Len() int { return len(h) }
+
+func (h waiterHeap) Less(i, j int) bool {
+	if h[i].finish != h[j].finish {
+		return h[i].finish < h[j].finish
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h waiterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *waiterHeap) Push(x interface{}) {
+	w := x.(*waiter)
+	w.index = len(*h)
+	*h = append(*h, w)
+}
+
+func (h *waiterHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	w := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return w
+}
@@ -0,0 +1,170 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+)
+
+// ErrExecutionInterrupted is returned for runs stopped because the scheduler shut down
+var ErrExecutionInterrupted = errors.New("job execution interrupted by shutdown")
+
+// Reasons recorded on interrupted runs
+const (
+	interruptedByShutdown  = "Run interrupted - the scheduler shut down before it finished"
+	interruptedBeforeStart = "Run interrupted - the scheduler shut down before it started"
+	interruptedByRestart   = "Run interrupted - the instance restarted before it finished"
+)
+
+// interruptedRunsFail is the InterruptedRuns setting that fails interrupted runs rather than requeueing them
+const interruptedRunsFail = "fail"
+
+// drainPollInterval is how often a draining scheduler checks whether its runs have finished
+const drainPollInterval = 100 * time.Millisecond
+
+// DrainRuns stops new runs from starting and waits up to gracePeriod for the running ones to finish
+// Runs still going after that are interrupted, recorded as such once their executor stops or is killed
+// It returns how many runs were interrupted
+func (e *JobExecutor) DrainRuns(gracePeriod time.Duration) int {
+	e.mu.Lock()
+	e.draining = true
+	e.mu.Unlock()
+
+	e.waitForRuns(time.Now().Add(gracePeriod))
+
+	e.mu.RLock()
+	controls := make([]*runControl, 0, len(e.controls))
+	for _, control := range e.controls {
+		controls = append(controls, control)
+	}
+	e.mu.RUnlock()
+	if len(controls) == 0 {
+		return 0
+	}
+
+	for _, control := range controls {
+		control.interrupt()
+	}
+	// Runs are killed cancelGracePeriod after they are interrupted, so they have all recorded their outcome by then
+	e.waitForRuns(time.Now().Add(cancelGracePeriod + time.Second))
+	return len(controls)
+}
+
+// waitForRuns waits until no run is executing or the deadline passes
+func (e *JobExecutor) waitForRuns(deadline time.Time) {
+	for e.GetRunningJobsCount() > 0 && time.Now().Before(deadline) {
+		time.Sleep(drainPollInterval)
+	}
+}
+
+// isDraining reports whether the scheduler is shutting down
+func (e *JobExecutor) isDraining() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.draining
+}
+
+// interruptUnstartedRun records a run that was saved while queued as interrupted, so it is requeued on startup
+// Runs that didn't have to wait were never saved, so there is nothing to record for them
+func (e *JobExecutor) interruptUnstartedRun(execution *models.JobExecution, create bool) error {
+	if !create && execution.MarkAsInterrupted(interruptedBeforeStart) == nil {
+		if err := e.jobExecutionRepo.Update(execution); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"execution_id": execution.ID,
+				"error":        err,
+			}).Error("Failed to record interrupted execution")
+		}
+	}
+	return ErrExecutionInterrupted
+}
+
+// RecoverInterruptedRuns resolves runs an earlier shutdown or crash left unfinished
+// This instance's runs still recorded as running were cut short by it stopping without draining them,
+// so they are marked interrupted first. Every interrupted run is then requeued as a new attempt, or
+// failed if the scheduler is configured not to requeue them. It returns how many were requeued and failed
+func (e *JobExecutor) RecoverInterruptedRuns() (int, int, error) {
+	running, err := e.jobExecutionRepo.GetRunningExecutions()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get running executions: %w", err)
+	}
+	for i := range running {
+		execution := &running[i]
+		if execution.InstanceID != e.config.Scheduler.InstanceID || e.isRunning(execution.ID) {
+			continue
+		}
+		if err := execution.MarkAsInterrupted(interruptedByRestart); err != nil {
+			continue
+		}
+		// The update only applies if the run is still recorded as running
+		if err := e.jobExecutionRepo.Update(execution); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"execution_id": execution.ID,
+				"error":        err,
+			}).Warn("Failed to mark run interrupted")
+		}
+	}
+
+	interrupted, err := e.jobExecutionRepo.GetInterruptedExecutions()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get interrupted executions: %w", err)
+	}
+
+	requeued, failed := 0, 0
+	for i := range interrupted {
+		execution := &interrupted[i]
+		reason := interruptedByShutdown
+		if execution.ErrorMessage != nil {
+			reason = execution.ErrorMessage.String()
+		}
+
+		if e.config.Scheduler.InterruptedRuns == interruptedRunsFail {
+			if e.resolveInterruptedRun(execution, reason) {
+				failed++
+			}
+			continue
+		}
+
+		// The interrupted attempt is closed first, so only one instance requeues it
+		retry := execution.NextAttempt()
+		if !e.resolveInterruptedRun(execution, fmt.Sprintf("%s; requeued as attempt %d", reason, retry.Attempt)) {
+			continue
+		}
+		job := execution.Job
+		go func() {
+			if err := e.runExecution(&job, retry, true); err != nil {
+				logrus.WithFields(logrus.Fields{
+					"job_id":       job.ID,
+					"execution_id": retry.ID,
+					"attempt":      retry.Attempt,
+					"error":        err,
+				}).Warn("Requeued interrupted run failed")
+			}
+		}()
+		requeued++
+	}
+	return requeued, failed, nil
+}
+
+// resolveInterruptedRun records an interrupted run as failed with the given reason, keeping when it stopped
+// It returns false if the run couldn't be updated, typically because another instance resolved it first
+func (e *JobExecutor) resolveInterruptedRun(execution *models.JobExecution, reason string) bool {
+	completedAt, duration := execution.CompletedAt, execution.ExecutionDuration
+	if err := execution.MarkAsFailed(reason); err != nil {
+		return false
+	}
+	if completedAt != nil {
+		execution.CompletedAt, execution.ExecutionDuration = completedAt, duration
+	}
+	if err := e.jobExecutionRepo.Update(execution); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"execution_id": execution.ID,
+			"error":        err,
+		}).Warn("Failed to resolve interrupted run")
+		return false
+	}
+	return true
+}
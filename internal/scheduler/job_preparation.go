@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// maxPrepareWorkers bounds how many jobs are prepared at once when loading or reloading jobs
+const maxPrepareWorkers = 16
+
+// preparedJob is a job whose schedule has been parsed and job function built, ready for cron
+type preparedJob struct {
+	job *models.Job
+	// hash identifies the settings the job was prepared from
+	hash     string
+	schedule cron.Schedule
+	run      func()
+	// err is why the job's schedule can't be used
+	err error
+}
+
+// prepareJob parses the job's schedule and builds its job function
+func (s *Scheduler) prepareJob(job *models.Job) preparedJob {
+	hash := jobHash(job)
+	schedule, err := services.JobSchedule(job)
+	if err != nil {
+		return preparedJob{job: job, hash: hash, err: err}
+	}
+	return preparedJob{job: job, hash: hash, schedule: schedule, run: s.createJobFunction(job)}
+}
+
+// jobHash hashes the settings a job's cron entry and job function are built from
+// Run times, health scores, states, error records and timestamps change without the job changing, so they are left out
+func jobHash(job *models.Job) string {
+	settings := *job
+	settings.LastRunAt, settings.NextRunAt = nil, nil
+	settings.HealthScore, settings.HealthScoredAt = nil, nil
+	settings.State, settings.ErroredAt, settings.ErrorReason = "", nil, ""
+	settings.CreatedAt, settings.UpdatedAt = time.Time{}, time.Time{}
+	settings.Executions = nil
+
+	encoded, err := json.Marshal(settings)
+	if err != nil {
+		// A job that can't be hashed never matches its entry, so it is always rebuilt
+		return ""
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+// prepareJobs prepares jobs on a bounded pool of workers, so loading thousands of jobs isn't
+// held up parsing their schedules one by one. The prepared jobs are in the order of jobs
+func (s *Scheduler) prepareJobs(jobs []*models.Job) []preparedJob {
+	prepared := make([]preparedJob, len(jobs))
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > maxPrepareWorkers {
+		workers = maxPrepareWorkers
+	}
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				prepared[i] = s.prepareJob(jobs[i])
+			}
+		}()
+	}
+	for i := range jobs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	return prepared
+}
@@ -0,0 +1,253 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+	"job-scheduler/internal/services"
+)
+
+const (
+	// maxMissedWhileDown caps how many occurrences of a job are recorded for a single downtime
+	maxMissedWhileDown = 100
+	// downtimeGrace leaves out occurrences due just before start, which another instance may still be firing
+	downtimeGrace = time.Minute
+)
+
+// SetMissedOccurrences records scheduled occurrences that don't run, and why, in the given ledger
+func (s *Scheduler) SetMissedOccurrences(ledger repositories.MissedOccurrenceRepository) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.missed = ledger
+}
+
+// missedOccurrences returns the ledger of missed occurrences, or nil if it isn't enabled
+func (s *Scheduler) missedOccurrences() repositories.MissedOccurrenceRepository {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.missed
+}
+
+// recordMissed adds an occurrence of the job that didn't run to the ledger, if it is enabled
+// It returns whether the occurrence was recorded
+func (s *Scheduler) recordMissed(job *models.Job, scheduledFor time.Time, reason models.MissedOccurrenceReason, details string) bool {
+	ledger := s.missedOccurrences()
+	if ledger == nil {
+		return false
+	}
+
+	err := ledger.Record(&models.MissedOccurrence{
+		JobID:        job.ID,
+		ScheduledFor: scheduledFor,
+		Reason:       reason,
+		Details:      details,
+		InstanceID:   s.config.Scheduler.InstanceID,
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"job_id":        job.ID,
+			"scheduled_for": scheduledFor,
+			"reason":        reason,
+			"error":         err,
+		}).Error("Failed to record missed occurrence")
+		return false
+	}
+	return true
+}
+
+// recordAllMissed adds occurrences of the job that didn't run to the ledger in one batch, if it is enabled
+// It returns how many were recorded
+func (s *Scheduler) recordAllMissed(job *models.Job, occurrences []time.Time, reason models.MissedOccurrenceReason, details string) int {
+	ledger := s.missedOccurrences()
+	if ledger == nil || len(occurrences) == 0 {
+		return 0
+	}
+
+	records := make([]models.MissedOccurrence, 0, len(occurrences))
+	for _, scheduledFor := range occurrences {
+		records = append(records, models.MissedOccurrence{
+			JobID:        job.ID,
+			ScheduledFor: scheduledFor,
+			Reason:       reason,
+			Details:      details,
+			InstanceID:   s.config.Scheduler.InstanceID,
+		})
+	}
+
+	recorded, err := ledger.RecordAll(records)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"job_id":      job.ID,
+			"occurrences": len(occurrences),
+			"reason":      reason,
+			"error":       err,
+		}).Error("Failed to record missed occurrences")
+		return 0
+	}
+	return int(recorded)
+}
+
+// recordScheduledOutcome records the occurrence as missed if the scheduled run didn't run
+func (s *Scheduler) recordScheduledOutcome(job *models.Job, scheduledFor time.Time, err error) {
+	switch {
+	case errors.Is(err, ErrRunDeferred):
+		s.recordMissed(job, scheduledFor, models.MissedOccurrenceOverloadShed, err.Error())
+	case errors.Is(err, ErrMaxConcurrentJobs), errors.Is(err, ErrConcurrencyGroupFull):
+		s.recordMissed(job, scheduledFor, models.MissedOccurrenceConcurrencySkip, err.Error())
+	case errors.Is(err, ErrRateLimited):
+		s.recordMissed(job, scheduledFor, models.MissedOccurrenceRateLimited, err.Error())
+	}
+}
+
+// CatchUpMissedRuns handles the occurrences of active jobs that fell due while no instance was running,
+// from each job's next due occurrence until now. Per the job's misfire policy they are run - the latest
+// one or all of them - and the rest are recorded as missed. It returns how many occurrences were run
+// and how many were recorded as missed
+func (s *Scheduler) CatchUpMissedRuns(now time.Time) (int, int, error) {
+	jobs, err := s.jobService.GetActiveJobs()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get active jobs: %w", err)
+	}
+
+	var (
+		wg          sync.WaitGroup
+		mu          sync.Mutex
+		ran, missed int
+	)
+	for i := range jobs {
+		job := &jobs[i]
+		if job.NextRunAt == nil {
+			continue
+		}
+
+		// Occurrences due just before start may still be firing on another instance
+		occurrences, truncated, err := occurrencesBetween(job, job.NextRunAt.Add(-time.Second), now.Add(-downtimeGrace), maxMissedWhileDown)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"job_id": job.ID,
+				"error":  err,
+			}).Warn("Failed to work out missed occurrences")
+			continue
+		}
+		if len(occurrences) == 0 {
+			continue
+		}
+		if truncated {
+			logrus.WithFields(logrus.Fields{
+				"job_id": job.ID,
+				"limit":  maxMissedWhileDown,
+			}).Warn("Too many occurrences missed while down - handling only the first")
+		}
+
+		toRun := catchUpRuns(job.MisfirePolicy, occurrences)
+		details := fmt.Sprintf("no scheduler instance was running; misfire policy %s", job.MisfirePolicy)
+		missed += s.recordAllMissed(job, occurrences[:len(occurrences)-len(toRun)], models.MissedOccurrenceSchedulerDown, details)
+		s.recordRunTimes(job, occurrences[len(occurrences)-1], now)
+		if job.IsOneTime() && len(toRun) == 0 {
+			// Its only occurrence won't run, so there is no run to wait for before completing it
+			if err := s.jobService.CompleteOneTimeJob(job.ID, occurrences[0]); err != nil {
+				logrus.WithFields(logrus.Fields{
+					"job_id": job.ID,
+					"error":  err,
+				}).Error("Failed to complete one-time job")
+			}
+			continue
+		}
+
+		// Each job catches up in order, alongside the other jobs
+		wg.Add(1)
+		go func(job *models.Job, toRun []time.Time) {
+			defer wg.Done()
+			for _, scheduledFor := range toRun {
+				if !s.claimRun(job, scheduledFor) {
+					continue
+				}
+				logrus.WithFields(logrus.Fields{
+					"job_id":        job.ID,
+					"name":          job.Name,
+					"scheduled_for": scheduledFor,
+				}).Info("Catching up on missed occurrence")
+				s.runOccurrence(job, scheduledFor)
+
+				mu.Lock()
+				ran++
+				mu.Unlock()
+			}
+		}(job, toRun)
+	}
+	wg.Wait()
+
+	return ran, missed, nil
+}
+
+// catchUpRuns returns the missed occurrences, oldest first, that the misfire policy runs
+func catchUpRuns(policy models.MisfirePolicy, occurrences []time.Time) []time.Time {
+	switch policy {
+	case models.MisfireRunAll:
+		return occurrences
+	case models.MisfireRunOnce:
+		return occurrences[len(occurrences)-1:]
+	default:
+		return nil
+	}
+}
+
+// catchUpOnStart handles the occurrences missed before this instance started
+func (s *Scheduler) catchUpOnStart(now time.Time) {
+	defer s.wg.Done()
+
+	ran, missed, err := s.CatchUpMissedRuns(now)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to catch up on occurrences missed while down")
+		return
+	}
+	if ran > 0 || missed > 0 {
+		logrus.WithFields(logrus.Fields{
+			"ran":    ran,
+			"missed": missed,
+		}).Warn("Caught up on occurrences missed while no scheduler was running")
+	}
+}
+
+// recordRunTimes records the job's last fired occurrence and the next one due after the given time
+// One-time jobs have no next occurrence; the executor completes them once their run is over
+func (s *Scheduler) recordRunTimes(job *models.Job, lastRunAt, after time.Time) {
+	if job.IsOneTime() {
+		return
+	}
+
+	schedule, err := services.JobSchedule(job)
+	if err != nil {
+		return
+	}
+	if err := s.jobService.RecordRunTimes(job.ID, lastRunAt, schedule.Next(after).UTC()); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"job_id": job.ID,
+			"error":  err,
+		}).Warn("Failed to record job run times")
+	}
+}
+
+// occurrencesBetween returns up to limit occurrences of the job's schedule after after and before before,
+// and whether there were more
+func occurrencesBetween(job *models.Job, after, before time.Time, limit int) ([]time.Time, bool, error) {
+	parsed, err := services.JobSchedule(job)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var occurrences []time.Time
+	for next := parsed.Next(after); !next.IsZero() && next.Before(before); next = parsed.Next(next) {
+		if len(occurrences) == limit {
+			return occurrences, true, nil
+		}
+		occurrences = append(occurrences, next.UTC())
+	}
+	return occurrences, false, nil
+}
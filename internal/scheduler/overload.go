@@ -0,0 +1,166 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/notifications"
+)
+
+// ErrRunDeferred is returned when a scheduled run is deferred to the job's next occurrence
+// because the scheduler is overloaded
+var ErrRunDeferred = errors.New("run deferred - scheduler overloaded")
+
+// ErrMaxConcurrentJobs is returned when a run is skipped because no execution slot freed up for it
+var ErrMaxConcurrentJobs = errors.New("maximum concurrent jobs reached")
+
+// overloadGuard tracks whether the scheduler is overloaded and which runs to shed
+type overloadGuard struct {
+	threshold int           // running executions at which the scheduler is overloaded
+	queueWait time.Duration // queue wait at which the scheduler is overloaded
+	shedBelow models.JobSeverity
+
+	mu         sync.Mutex
+	lastWait   time.Duration
+	overloaded bool
+}
+
+// newOverloadGuard creates an overload guard for the given number of execution slots
+func newOverloadGuard(slots, thresholdPercent int, queueWait time.Duration, shedBelow models.JobSeverity) *overloadGuard {
+	if thresholdPercent < 1 || thresholdPercent > 100 {
+		thresholdPercent = 80
+	}
+	threshold := (slots*thresholdPercent + 99) / 100
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	return &overloadGuard{
+		threshold: threshold,
+		queueWait: queueWait,
+		shedBelow: shedBelow,
+	}
+}
+
+// recordWait records how long the latest run waited for a slot
+func (g *overloadGuard) recordWait(wait time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.lastWait = wait
+}
+
+// update re-evaluates the overload state for the running executions
+// changed is true when the scheduler just became overloaded or recovered
+func (g *overloadGuard) update(running int) (overloaded, changed bool, wait time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	overloaded = running >= g.threshold || (g.queueWait > 0 && g.lastWait >= g.queueWait)
+	changed = overloaded != g.overloaded
+	g.overloaded = overloaded
+	return overloaded, changed, g.lastWait
+}
+
+// sheds reports whether runs of the job are deferred while overloaded
+func (g *overloadGuard) sheds(job *models.Job) bool {
+	return job.Severity.Rank() < g.shedBelow.Rank()
+}
+
+// ExecuteScheduledJob executes a job's run for the cron occurrence at scheduledFor
+// While the scheduler is overloaded, runs of lower-severity jobs are deferred to their next occurrence,
+// and runs overlapping the job's previous one are handled per its concurrency policy
+func (e *JobExecutor) ExecuteScheduledJob(job *models.Job, scheduledFor time.Time) error {
+	if e.checkOverload() && e.overload.sheds(job) {
+		logrus.WithFields(logrus.Fields{
+			"job_id":        job.ID,
+			"job_name":      job.Name,
+			"severity":      job.Severity,
+			"scheduled_for": scheduledFor,
+		}).Warn("Scheduler overloaded - deferring run to the job's next occurrence")
+		return ErrRunDeferred
+	}
+	if err := e.applyConcurrencyPolicy(job, scheduledFor); err != nil {
+		return err
+	}
+	return e.executeNew(job, nil, &scheduledFor)
+}
+
+// IsOverloaded returns whether the scheduler is currently overloaded
+func (e *JobExecutor) IsOverloaded() bool {
+	return e.checkOverload()
+}
+
+// acquireSlot takes an execution slot, waiting up to the configured queue wait for one to free up
+// Waiting runs get freed slots by weighted fair queuing across teams; queued is called once the run waits
+func (e *JobExecutor) acquireSlot(job *models.Job, queued func()) error {
+	if e.slots.tryAcquire() {
+		e.overload.recordWait(0)
+		return nil
+	}
+
+	if e.config.Scheduler.MaxQueueWait <= 0 {
+		return errQueueWaitExceeded
+	}
+
+	start := time.Now()
+	err := e.slots.acquire(job.Team, e.config.Scheduler.MaxQueueWait, queued)
+	if !errors.Is(err, errQueueFull) {
+		e.overload.recordWait(time.Since(start))
+	}
+	e.checkOverload()
+	return err
+}
+
+// checkOverload re-evaluates the overload state, announcing when the scheduler becomes overloaded
+func (e *JobExecutor) checkOverload() bool {
+	running := e.slots.running()
+	overloaded, changed, wait := e.overload.update(running)
+	if !changed {
+		return overloaded
+	}
+
+	fields := logrus.Fields{
+		"running":             running,
+		"max_concurrent_jobs": e.config.Scheduler.MaxConcurrentJobs,
+		"queue_wait_ms":       wait.Milliseconds(),
+	}
+	if !overloaded {
+		logrus.WithFields(fields).Info("Scheduler recovered from overload")
+		return overloaded
+	}
+
+	logrus.WithFields(fields).Warn("Scheduler overloaded")
+
+	e.mu.RLock()
+	notifier := e.notifier
+	e.mu.RUnlock()
+	if notifier == nil {
+		return overloaded
+	}
+
+	n := notifications.Notification{
+		Event: notifications.EventOverloaded,
+		Title: "Scheduler overloaded",
+		Message: fmt.Sprintf("%d of %d execution slots in use, last queue wait %s; deferring scheduled runs of jobs below %s severity",
+			running, e.config.Scheduler.MaxConcurrentJobs, wait.Round(time.Millisecond), e.overload.shedBelow),
+		Fields: map[string]interface{}{
+			"running":             running,
+			"max_concurrent_jobs": e.config.Scheduler.MaxConcurrentJobs,
+			"queue_wait_ms":       wait.Milliseconds(),
+			"shed_below_severity": e.overload.shedBelow,
+		},
+		Timestamp: time.Now().UTC(),
+	}
+	go func() {
+		if err := notifier.Notify(context.Background(), n); err != nil {
+			logrus.WithError(err).Warn("Failed to send notification")
+		}
+	}()
+	return overloaded
+}
@@ -0,0 +1,188 @@
+package scheduler
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+)
+
+// ErrRateLimited is returned when a run is rejected because runs kept starting at their rate limit
+var ErrRateLimited = errors.New("start rate limit reached")
+
+// globalRateLimit is the key of the limit on all jobs in the rate limit stats
+const globalRateLimit = "*"
+
+// RateLimitStats reports how a start rate limit held runs back
+type RateLimitStats struct {
+	Limit  int    `json:"limit"`
+	Window string `json:"window"`
+	// InWindow is how many runs started within the current window
+	InWindow int `json:"in_window"`
+	// Started, Deferred and Rejected count the runs started, those that waited for the limit first
+	// and those that gave up waiting, since the scheduler started
+	Started  int64 `json:"started"`
+	Deferred int64 `json:"deferred"`
+	Rejected int64 `json:"rejected"`
+}
+
+// startWindow remembers when runs started within a sliding window
+type startWindow struct {
+	limit  config.RateLimit
+	starts []time.Time
+
+	started, deferred, rejected int64
+}
+
+// prune forgets starts that left the window ending at now
+func (w *startWindow) prune(now time.Time) {
+	cutoff := now.Add(-w.limit.Window)
+	kept := 0
+	for kept < len(w.starts) && !w.starts[kept].After(cutoff) {
+		kept++
+	}
+	w.starts = w.starts[kept:]
+}
+
+// wait returns how long until a run may start, zero if it may start now
+func (w *startWindow) wait(now time.Time) time.Duration {
+	w.prune(now)
+	if len(w.starts) < w.limit.Limit {
+		return 0
+	}
+	return w.starts[len(w.starts)-w.limit.Limit].Add(w.limit.Window).Sub(now)
+}
+
+// startLimiter caps how many runs start per time window, across all jobs and per job type
+// A run is only counted against the limits once all of them let it start
+type startLimiter struct {
+	mu       sync.Mutex
+	global   *startWindow
+	jobTypes map[models.JobType]*startWindow
+}
+
+// newStartLimiter creates a limiter for the global and per job type limits; zero limits are unlimited
+func newStartLimiter(global config.RateLimit, jobTypes map[string]config.RateLimit) *startLimiter {
+	l := &startLimiter{jobTypes: make(map[models.JobType]*startWindow)}
+	if global.Limit > 0 {
+		l.global = &startWindow{limit: global}
+	}
+	for jobType, limit := range jobTypes {
+		if limit.Limit > 0 {
+			l.jobTypes[models.JobType(jobType)] = &startWindow{limit: limit}
+		}
+	}
+	return l
+}
+
+// windows returns the windows limiting runs of the job type
+func (l *startLimiter) windows(jobType models.JobType) []*startWindow {
+	var windows []*startWindow
+	if l.global != nil {
+		windows = append(windows, l.global)
+	}
+	if window, ok := l.jobTypes[jobType]; ok {
+		windows = append(windows, window)
+	}
+	return windows
+}
+
+// tryStart starts a run of the job type if every limit allows it, otherwise returning how long until
+// they might, along with the windows that were full
+func (l *startLimiter) tryStart(jobType models.JobType, now time.Time) (time.Duration, []*startWindow) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	windows := l.windows(jobType)
+	var longest time.Duration
+	var full []*startWindow
+	for _, window := range windows {
+		if wait := window.wait(now); wait > 0 {
+			full = append(full, window)
+			if wait > longest {
+				longest = wait
+			}
+		}
+	}
+	if len(full) > 0 {
+		return longest, full
+	}
+
+	for _, window := range windows {
+		window.starts = append(window.starts, now)
+		window.started++
+	}
+	return 0, nil
+}
+
+// acquire starts a run of the job type, waiting up to timeout for the limits to allow it
+// deferred is called once the run has to wait. It returns false if the limits didn't allow it in time
+func (l *startLimiter) acquire(jobType models.JobType, timeout time.Duration, deferred func()) bool {
+	deadline := time.Now().Add(timeout)
+	waited := false
+
+	for {
+		now := time.Now()
+		wait, full := l.tryStart(jobType, now)
+		if wait == 0 {
+			return true
+		}
+
+		rejected := now.Add(wait).After(deadline)
+		l.count(full, !waited && !rejected, rejected)
+		if rejected {
+			return false
+		}
+
+		if !waited {
+			waited = true
+			if deferred != nil {
+				deferred()
+			}
+		}
+		time.Sleep(wait)
+	}
+}
+
+// count counts a run as deferred or rejected by the full windows
+func (l *startLimiter) count(full []*startWindow, deferred, rejected bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, window := range full {
+		if deferred {
+			window.deferred++
+		}
+		if rejected {
+			window.rejected++
+		}
+	}
+}
+
+// stats reports every limit, keyed by job type, with the limit on all jobs under "*"
+func (l *startLimiter) stats() map[string]RateLimitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	stats := make(map[string]RateLimitStats, len(l.jobTypes)+1)
+	report := func(key string, window *startWindow) {
+		window.prune(now)
+		stats[key] = RateLimitStats{
+			Limit:    window.limit.Limit,
+			Window:   window.limit.Window.String(),
+			InWindow: len(window.starts),
+			Started:  window.started,
+			Deferred: window.deferred,
+			Rejected: window.rejected,
+		}
+	}
+	if l.global != nil {
+		report(globalRateLimit, l.global)
+	}
+	for jobType, window := range l.jobTypes {
+		report(string(jobType), window)
+	}
+	return stats
+}
@@ -0,0 +1,162 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"job-scheduler/internal/services"
+)
+
+// runControl stops a running execution and times it out at its deadline
+// The deadline can be moved earlier or later while the run executes, up to the run's maximum execution time
+type runControl struct {
+	cancel   context.CancelFunc
+	kill     chan struct{} // closed to stop waiting for the executor
+	killOnce sync.Once
+	settled  int32 // set once the executor or a kill has claimed the run's outcome
+
+	mu          sync.Mutex
+	startedAt   time.Time
+	deadline    time.Time
+	maxDeadline time.Time
+	warnBefore  time.Duration // how long before the deadline the timeout warning is sent
+	timer       *time.Timer   // times the run out at its deadline
+	warnTimer   *time.Timer   // sends the timeout warning
+	timedOut    bool
+	interrupted bool // stopped because the scheduler is shutting down
+}
+
+// newRunControl starts timing a run that times out after timeout and may be extended up to maxRuntime
+// warn is called once the run has warnBefore left before its deadline, and again after each extension
+func newRunControl(cancel context.CancelFunc, timeout, maxRuntime, warnBefore time.Duration, warn func(deadline time.Time)) *runControl {
+	now := time.Now().UTC()
+	if maxRuntime < timeout {
+		maxRuntime = timeout
+	}
+
+	c := &runControl{
+		cancel:      cancel,
+		kill:        make(chan struct{}),
+		startedAt:   now,
+		deadline:    now.Add(timeout),
+		maxDeadline: now.Add(maxRuntime),
+		warnBefore:  warnBefore,
+	}
+	c.timer = time.AfterFunc(timeout, c.timeOut)
+	if warnBefore > 0 && warn != nil {
+		c.warnTimer = time.AfterFunc(timeout-warnBefore, func() { warn(c.getDeadline()) })
+	}
+	return c
+}
+
+// timeOut cancels the run because its deadline passed
+func (c *runControl) timeOut() {
+	c.mu.Lock()
+	c.timedOut = true
+	c.mu.Unlock()
+	c.cancel()
+}
+
+// isTimedOut reports whether the run was stopped by its deadline rather than cancelled
+func (c *runControl) isTimedOut() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.timedOut
+}
+
+// getDeadline returns when the run times out
+func (c *runControl) getDeadline() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.deadline
+}
+
+// extend moves the deadline later by d, no further than the run's maximum execution time
+// It returns the new deadline
+func (c *runControl) extend(d time.Duration) (time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.timedOut {
+		return time.Time{}, services.ErrExecutionNotRunning
+	}
+	if !c.deadline.Before(c.maxDeadline) {
+		return time.Time{}, services.ErrExtensionLimit
+	}
+	return c.reset(c.deadline.Add(d))
+}
+
+// setDeadline moves the deadline to the given time, earlier or later, no further than the run's maximum execution time
+// It returns the new deadline
+func (c *runControl) setDeadline(deadline time.Time) (time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.timedOut {
+		return time.Time{}, services.ErrExecutionNotRunning
+	}
+	if !deadline.After(time.Now()) {
+		return time.Time{}, services.ErrInvalidDeadline
+	}
+	return c.reset(deadline)
+}
+
+// reset re-arms the timers for a new deadline, clamped to the maximum; c.mu must be held
+func (c *runControl) reset(deadline time.Time) (time.Time, error) {
+	if deadline.After(c.maxDeadline) {
+		deadline = c.maxDeadline
+	}
+
+	// The timer may fire while we move the deadline; the run has then already timed out
+	if !c.timer.Stop() {
+		return time.Time{}, services.ErrExecutionNotRunning
+	}
+	c.deadline = deadline
+	remaining := time.Until(deadline)
+	c.timer.Reset(remaining)
+	if c.warnTimer != nil {
+		c.warnTimer.Stop()
+		if warnIn := remaining - c.warnBefore; warnIn > 0 {
+			c.warnTimer.Reset(warnIn)
+		}
+	}
+	return deadline, nil
+}
+
+// settle claims the right to record the run's outcome; only the first caller gets it
+func (c *runControl) settle() bool {
+	return atomic.CompareAndSwapInt32(&c.settled, 0, 1)
+}
+
+// stop cancels the run's context; a forced stop also kills it without waiting for the executor
+func (c *runControl) stop(force bool) {
+	c.cancel()
+	if force {
+		c.killOnce.Do(func() { close(c.kill) })
+	}
+}
+
+// interrupt cancels the run's context because the scheduler is shutting down
+func (c *runControl) interrupt() {
+	c.mu.Lock()
+	c.interrupted = true
+	c.mu.Unlock()
+	c.cancel()
+}
+
+// isInterrupted reports whether the run was stopped by the scheduler shutting down
+func (c *runControl) isInterrupted() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.interrupted
+}
+
+// release stops the run's timers once it has finished
+func (c *runControl) release() {
+	c.timer.Stop()
+	if c.warnTimer != nil {
+		c.warnTimer.Stop()
+	}
+}
@@ -0,0 +1,37 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+)
+
+// NextRunTimes returns when the jobs this instance schedules are next due, from their cron entries
+// Jobs that aren't scheduled, or any job while the scheduler isn't running, have no entry
+func (s *Scheduler) NextRunTimes(jobIDs []uuid.UUID) map[uuid.UUID]time.Time {
+	entries := make(map[uuid.UUID]cron.EntryID, len(jobIDs))
+	for _, jobID := range jobIDs {
+		if scheduled, exists := s.schedule.get(jobID.String()); exists {
+			entries[jobID] = scheduled.entryID
+		}
+	}
+
+	nextRuns := make(map[uuid.UUID]time.Time, len(entries))
+	for jobID, entryID := range entries {
+		if next := s.cron.Entry(entryID).Next; !next.IsZero() {
+			nextRuns[jobID] = next.UTC()
+		}
+	}
+	return nextRuns
+}
+
+// LastRunTimes returns when the jobs' latest runs started
+func (s *Scheduler) LastRunTimes(jobIDs []uuid.UUID) (map[uuid.UUID]time.Time, error) {
+	lastRuns, err := s.jobExecutionRepo.GetLatestStartTimes(jobIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest runs: %w", err)
+	}
+	return lastRuns, nil
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+)
+
+// erroredJob is a job whose schedule couldn't be used, and why
+type erroredJob struct {
+	job *models.Job
+	err error
+}
+
+// recordScheduleError marks a job whose stored schedule can't be used as errored
+// Jobs already marked errored for the same reason are left alone, so a reload doesn't log or write
+// anything for jobs that stay broken
+func (s *Scheduler) recordScheduleError(job *models.Job, scheduleErr error) {
+	reason := scheduleErr.Error()
+	if job.State == models.JobStateErrored && job.ErrorReason == reason {
+		logrus.WithField("job_id", job.ID).Debug("Skipping errored job")
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":   job.ID,
+		"name":     job.Name,
+		"schedule": job.Schedule,
+		"error":    scheduleErr,
+	}).Error("Job's schedule can't be used - marking it errored")
+	if err := s.jobService.MarkJobErrored(job.ID, reason); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"job_id": job.ID,
+			"error":  err,
+		}).Error("Failed to mark job errored")
+	}
+}
+
+// clearScheduleError makes an errored job that has been scheduled again active, clearing its error record
+func (s *Scheduler) clearScheduleError(job *models.Job) {
+	if job.State != models.JobStateErrored {
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id": job.ID,
+		"name":   job.Name,
+	}).Info("Errored job scheduled again")
+	if err := s.jobService.ClearJobError(job.ID); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"job_id": job.ID,
+			"error":  err,
+		}).Error("Failed to clear job error")
+	}
+}
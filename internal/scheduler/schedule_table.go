@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/robfig/cron/v3"
+)
+
+// scheduleShardCount is how many shards the schedule table splits jobs across
+const scheduleShardCount = 64
+
+// scheduledJob is a job's cron entry, with the name and settings hash of the job it was created from
+type scheduledJob struct {
+	entryID cron.EntryID
+	name    string
+	hash    string
+}
+
+// unchanged reports whether a scheduled entry was built from the settings with the given hash
+func (e scheduledJob) unchanged(hash string) bool {
+	return e.hash != "" && e.hash == hash
+}
+
+// scheduleTable holds the scheduled jobs by job ID
+// Jobs are split across shards, each with its own lock, so scheduling a job, reading next run times
+// and counting jobs don't wait on each other or on a reload of thousands of jobs
+type scheduleTable struct {
+	shards [scheduleShardCount]scheduleShard
+	// count is the number of scheduled jobs, read without locking any shard
+	count int64
+}
+
+type scheduleShard struct {
+	mu   sync.RWMutex
+	jobs map[string]scheduledJob
+}
+
+func newScheduleTable() *scheduleTable {
+	t := &scheduleTable{}
+	for i := range t.shards {
+		t.shards[i].jobs = make(map[string]scheduledJob)
+	}
+	return t
+}
+
+// shard returns the shard holding a job
+func (t *scheduleTable) shard(jobID string) *scheduleShard {
+	h := fnv.New32a()
+	h.Write([]byte(jobID))
+	return &t.shards[h.Sum32()%scheduleShardCount]
+}
+
+// get returns a job's scheduled entry
+func (t *scheduleTable) get(jobID string) (scheduledJob, bool) {
+	shard := t.shard(jobID)
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+	job, exists := shard.jobs[jobID]
+	return job, exists
+}
+
+// update replaces a job's scheduled entry with the one fn returns, or unschedules the job if fn
+// returns false. fn is passed the current entry and runs holding the job's shard, so changes to
+// the same job apply one at a time while other shards stay available
+func (t *scheduleTable) update(jobID string, fn func(current scheduledJob, exists bool) (scheduledJob, bool)) {
+	shard := t.shard(jobID)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	current, exists := shard.jobs[jobID]
+	next, keep := fn(current, exists)
+	switch {
+	case keep:
+		shard.jobs[jobID] = next
+		if !exists {
+			atomic.AddInt64(&t.count, 1)
+		}
+	case exists:
+		delete(shard.jobs, jobID)
+		atomic.AddInt64(&t.count, -1)
+	}
+}
+
+// len returns the number of scheduled jobs
+func (t *scheduleTable) len() int {
+	return int(atomic.LoadInt64(&t.count))
+}
+
+// snapshot copies the scheduled jobs, locking one shard at a time
+func (t *scheduleTable) snapshot() map[string]scheduledJob {
+	jobs := make(map[string]scheduledJob, t.len())
+	for i := range t.shards {
+		shard := &t.shards[i]
+		shard.mu.RLock()
+		for jobID, job := range shard.jobs {
+			jobs[jobID] = job
+		}
+		shard.mu.RUnlock()
+	}
+	return jobs
+}
@@ -2,19 +2,29 @@ package scheduler
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/robfig/cron/v3"
 	"github.com/sirupsen/logrus"
 
 	"job-scheduler/internal/config"
+	"job-scheduler/internal/events"
+	"job-scheduler/internal/httpclient"
+	"job-scheduler/internal/integrations"
 	"job-scheduler/internal/models"
+	"job-scheduler/internal/notifications"
 	"job-scheduler/internal/repositories"
 	"job-scheduler/internal/services"
 )
 
+// runClaimRetention is how long run claims are kept before they are pruned
+const runClaimRetention = 24 * time.Hour
+
 // Scheduler manages the execution of scheduled jobs
 type Scheduler struct {
 	cron                *cron.Cron
@@ -26,8 +36,21 @@ type Scheduler struct {
 	cancel              context.CancelFunc
 	wg                  sync.WaitGroup
 	mu                  sync.RWMutex
-	scheduledJobs       map[string]cron.EntryID // job_id -> cron entry id
+	schedule            *scheduleTable // job_id -> cron entry and version, locked apart from mu
 	isRunning           bool
+	stopping            bool // Stop is waiting for runs to finish
+	approvals           services.ApprovalService
+	artifacts           services.ArtifactService
+	claims              repositories.JobRunClaimRepository
+	missed              repositories.MissedOccurrenceRepository
+	healthScores        services.HealthScoreService
+	history             services.HistoryService
+	alerts              services.AlertService
+	tableMaintenance    services.TableMaintenanceService
+	connections         services.ConnectionMonitor
+	httpClients         *httpclient.Factory
+	jobEvents           *events.JobEventBus
+	integrations        *integrations.Manager
 }
 
 // NewScheduler creates a new job scheduler
@@ -45,10 +68,11 @@ func NewScheduler(
 		cron.WithChain(cron.Recover(cronLogger)),
 	)
 
-	// Create job executor
+	// Create job executor, which completes one-time jobs once their run is over
 	executor := NewJobExecutor(jobExecutionRepo, cfg)
+	executor.oneTimeJobs = jobService
 
-	return &Scheduler{
+	s := &Scheduler{
 		cron:             c,
 		jobService:       jobService,
 		jobExecutionRepo: jobExecutionRepo,
@@ -56,8 +80,152 @@ func NewScheduler(
 		config:           cfg,
 		ctx:              ctx,
 		cancel:           cancel,
-		scheduledJobs:    make(map[string]cron.EntryID),
+		schedule:         newScheduleTable(),
 	}
+
+	// Apply jobs saved through the API straight away rather than on the next reload
+	jobService.SetChangeListener(s)
+	// Report live next and last run times on jobs
+	jobService.SetRunTimeSource(s)
+	// Let new jobs' schedules have a seconds field if enabled globally
+	jobService.SetCronSecondsDefault(cfg.Scheduler.CronSeconds)
+	// Keep each team's job names unique if required
+	jobService.SetUniqueJobNames(cfg.Scheduler.UniqueJobNames)
+	return s
+}
+
+// SetApprovalService enables approval gates for jobs with RequiresApproval set
+// It is set after construction because the approval service runs approved jobs through the scheduler
+func (s *Scheduler) SetApprovalService(approvals services.ApprovalService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.approvals = approvals
+}
+
+// SetNotifier enables failure notifications for job runs
+func (s *Scheduler) SetNotifier(notifier notifications.Notifier) {
+	s.executor.SetNotifier(notifier)
+}
+
+// SetRemediation adds signed remediation action links to failure notifications
+func (s *Scheduler) SetRemediation(remediation services.RemediationService) {
+	s.executor.SetRemediation(remediation)
+}
+
+// SetArtifactService records files produced by runs, such as generated reports, for download
+// and runs artifact maintenance, purging expired artifacts and evicting those over quota
+func (s *Scheduler) SetArtifactService(artifacts services.ArtifactService) {
+	s.mu.Lock()
+	s.artifacts = artifacts
+	s.mu.Unlock()
+	s.executor.SetArtifactService(artifacts)
+}
+
+// SetRunClaims makes replicas sharing the database claim each scheduled run before executing it,
+// so only one instance executes a job at its scheduled time
+func (s *Scheduler) SetRunClaims(claims repositories.JobRunClaimRepository) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.claims = claims
+}
+
+// SetHealthScores periodically recalculates every active job's health score
+func (s *Scheduler) SetHealthScores(healthScores services.HealthScoreService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.healthScores = healthScores
+}
+
+// SetHistoryService runs the run history cleanup system job, pruning finished runs past their retention
+func (s *Scheduler) SetHistoryService(history services.HistoryService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history = history
+}
+
+// SetAlerts periodically evaluates alert rules, pushing firing alerts to Alertmanager
+func (s *Scheduler) SetAlerts(alerts services.AlertService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alerts = alerts
+}
+
+// SetTableMaintenance runs the table maintenance system job, analyzing (and optionally vacuuming)
+// the tables every run churns
+func (s *Scheduler) SetTableMaintenance(maintenance services.TableMaintenanceService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tableMaintenance = maintenance
+}
+
+// SetConnectionMonitor periodically probes the external connections jobs use,
+// failing runs fast while a connection their job uses is down
+func (s *Scheduler) SetConnectionMonitor(connections services.ConnectionMonitor) {
+	s.mu.Lock()
+	s.connections = connections
+	s.mu.Unlock()
+	s.executor.SetConnectionMonitor(connections)
+}
+
+// SetAcknowledgments holds back notifications of jobs an operator has acknowledged or silenced
+func (s *Scheduler) SetAcknowledgments(acknowledgments services.AcknowledgmentLookup) {
+	s.executor.SetAcknowledgments(acknowledgments)
+}
+
+// SetEmailSuppressions makes email jobs skip recipients on the suppression list
+func (s *Scheduler) SetEmailSuppressions(suppressions services.SuppressionLookup) {
+	s.executor.SetEmailSuppressions(suppressions)
+}
+
+// SetEmailRecipientSources lets batch email jobs read their recipients from SQL queries and CSV artifacts
+func (s *Scheduler) SetEmailRecipientSources(data services.ReportDataSource, artifacts services.ArtifactReader) {
+	s.executor.SetEmailRecipientSources(data, artifacts)
+}
+
+// SetExecutionLogs keeps the lines each run logs for paging and live tailing
+func (s *Scheduler) SetExecutionLogs(executionLogs repositories.JobExecutionLogRepository) {
+	s.executor.SetExecutionLogs(executionLogs)
+}
+
+// SetJobEvents publishes job lifecycle events: jobs created and scheduled, and runs started and ended
+func (s *Scheduler) SetJobEvents(jobEvents *events.JobEventBus) {
+	s.mu.Lock()
+	s.jobEvents = jobEvents
+	s.mu.Unlock()
+	s.executor.SetJobEvents(jobEvents)
+}
+
+// SetHTTPClients makes HTTP-based executors share the pooled, instrumented clients
+func (s *Scheduler) SetHTTPClients(clients *httpclient.Factory) {
+	s.mu.Lock()
+	s.httpClients = clients
+	s.mu.Unlock()
+	s.executor.SetHTTPClients(clients)
+}
+
+// SetIntegrations shares long-lived connections between runs; Stop closes them
+func (s *Scheduler) SetIntegrations(manager *integrations.Manager) {
+	s.mu.Lock()
+	s.integrations = manager
+	s.mu.Unlock()
+	s.executor.SetIntegrations(manager)
+}
+
+// SetMaintenanceDatabases lets db_maintenance jobs work on the scheduler's own database and on
+// registered external databases
+func (s *Scheduler) SetMaintenanceDatabases(db *sql.DB, databases services.DatabaseRegistry) {
+	s.executor.SetMaintenanceDatabases(db, databases)
+}
+
+// SetPipelineDatabases lets data_processing pipelines read from and write to registered external
+// databases, and write to the allowed internal tables of the scheduler's own database
+func (s *Scheduler) SetPipelineDatabases(db *sql.DB, databases services.DatabaseRegistry) {
+	s.executor.SetPipelineDatabases(db, databases)
+}
+
+// SetReportTemplates enables report_generation jobs that reference stored report templates
+func (s *Scheduler) SetReportTemplates(templates services.ReportTemplateLookup, data services.ReportDataSource) {
+	s.executor.SetReportTemplates(templates, data)
 }
 
 // Start starts the scheduler and loads all active jobs
@@ -71,8 +239,14 @@ func (s *Scheduler) Start() error {
 
 	logrus.Info("Starting job scheduler...")
 
+	// Let executors validate their config and connect before the first run
+	if err := s.executor.InitExecutors(s.ctx); err != nil {
+		return err
+	}
+
 	// Load and schedule all active jobs
 	if err := s.loadActiveJobs(); err != nil {
+		s.executor.CloseExecutors()
 		return fmt.Errorf("failed to load active jobs: %w", err)
 	}
 
@@ -84,63 +258,163 @@ func (s *Scheduler) Start() error {
 	s.wg.Add(1)
 	go s.reloadJobsPeriodically()
 
-	logrus.WithField("scheduled_jobs", len(s.scheduledJobs)).Info("Job scheduler started successfully")
+	// Start background goroutine to mark runs no instance is finishing as stalled
+	s.wg.Add(1)
+	go s.markStalledRunsPeriodically()
+
+	// Start background goroutine to expire runs nobody approved
+	if s.approvals != nil {
+		s.wg.Add(1)
+		go s.expireApprovalsPeriodically()
+	}
+
+	// Catch up on occurrences that fell due while no instance was running
+	s.wg.Add(1)
+	go s.catchUpOnStart(time.Now().UTC())
+
+	// Requeue or fail runs an earlier shutdown or crash left unfinished
+	s.wg.Add(1)
+	go s.recoverInterruptedRunsOnStart()
+
+	// Start background goroutine to prune old run claims
+	if s.claims != nil {
+		s.wg.Add(1)
+		go s.pruneRunClaimsPeriodically()
+	}
+
+	// Start the artifact maintenance system job
+	if s.artifacts != nil {
+		s.wg.Add(1)
+		go s.maintainArtifactsPeriodically()
+	}
+
+	// Start background goroutine to recalculate job health scores
+	if s.healthScores != nil {
+		s.wg.Add(1)
+		go s.scoreJobHealthPeriodically()
+	}
+
+	// Start the run history cleanup system job
+	if s.history != nil {
+		s.wg.Add(1)
+		go s.pruneHistoryPeriodically()
+	}
+
+	// Start the table maintenance system job
+	if s.tableMaintenance != nil && s.config.TableMaintenance.Enabled {
+		s.wg.Add(1)
+		go s.maintainTablesPeriodically()
+	}
+
+	// Start the connection health probes
+	if s.connections != nil && s.config.ConnectionHealth.Enabled {
+		s.wg.Add(1)
+		go s.probeConnectionsPeriodically()
+	}
+
+	// Start background goroutine to evaluate alert rules
+	if s.alerts != nil {
+		s.wg.Add(1)
+		go s.evaluateAlertsPeriodically()
+	}
+
+	logrus.WithField("scheduled_jobs", s.schedule.len()).Info("Job scheduler started successfully")
 	return nil
 }
 
 // Stop stops the scheduler gracefully
+// s.mu is only held to mark the scheduler stopping, not while runs and background goroutines are waited
+// for, as cron job functions take it to read the scheduler's services
 func (s *Scheduler) Stop() error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	if !s.isRunning {
+	if !s.isRunning || s.stopping {
+		s.mu.Unlock()
 		return nil
 	}
+	s.stopping = true
+	integrations := s.integrations
+	s.mu.Unlock()
 
 	logrus.Info("Stopping job scheduler...")
 
 	// Cancel context to stop background goroutines
 	s.cancel()
 
+	// Drop retries still waiting out their backoff
+	if cancelled := s.executor.CancelRetries(); cancelled > 0 {
+		logrus.WithField("cancelled", cancelled).Info("Cancelled pending job retries")
+	}
+
 	// Stop cron scheduler
 	ctx := s.cron.Stop()
+
+	// Give running runs the grace period to finish, then interrupt the rest so they are resumed on startup
+	if interrupted := s.executor.DrainRuns(s.config.Scheduler.ShutdownGracePeriod); interrupted > 0 {
+		logrus.WithField("interrupted", interrupted).Warn("Interrupted runs still going at shutdown")
+	}
 	<-ctx.Done() // Wait for running jobs to complete
 
 	// Wait for background goroutines to finish
 	s.wg.Wait()
 
+	// Release executor resources, then the shared connections, once no run can use them
+	s.executor.CloseExecutors()
+	if integrations != nil {
+		if err := integrations.Close(); err != nil {
+			logrus.WithError(err).Warn("Failed to close integrations")
+		}
+	}
+
+	s.mu.Lock()
 	s.isRunning = false
+	s.stopping = false
+	s.mu.Unlock()
 	logrus.Info("Job scheduler stopped successfully")
 	return nil
 }
 
 // AddJob adds a new job to the scheduler
 func (s *Scheduler) AddJob(job *models.Job) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	s.mu.RLock()
+	jobEvents := s.jobEvents
+	s.mu.RUnlock()
+	return s.addJob(job, jobEvents)
+}
 
-	if !job.IsActive {
-		logrus.WithField("job_id", job.ID).Debug("Skipping inactive job")
+// addJob schedules a job, replacing its existing entry, and publishes that it was scheduled
+// It only locks the job's shard of the schedule, so callers may hold s.mu
+func (s *Scheduler) addJob(job *models.Job, jobEvents *events.JobEventBus) error {
+	if !job.State.IsSchedulable() {
+		logrus.WithField("job_id", job.ID).Debug("Skipping unschedulable job")
 		return nil
 	}
+	return s.schedulePrepared(s.prepareJob(job), jobEvents)
+}
 
-	// Remove existing job if it exists
-	if entryID, exists := s.scheduledJobs[job.ID.String()]; exists {
-		s.cron.Remove(entryID)
-		delete(s.scheduledJobs, job.ID.String())
-	}
+// schedulePrepared gives a prepared job a cron entry, replacing its existing entry, and publishes
+// that it was scheduled
+func (s *Scheduler) schedulePrepared(prepared preparedJob, jobEvents *events.JobEventBus) error {
+	job := prepared.job
 
-	// Create job function
-	jobFunc := s.createJobFunction(job)
+	var entryID cron.EntryID
+	s.schedule.update(job.ID.String(), func(current scheduledJob, exists bool) (scheduledJob, bool) {
+		// Remove existing job if it exists
+		if exists {
+			s.cron.Remove(current.entryID)
+		}
+		if prepared.err != nil {
+			return scheduledJob{}, false
+		}
 
-	// Add job to cron scheduler
-	entryID, err := s.cron.AddFunc(job.Schedule, jobFunc)
-	if err != nil {
-		return fmt.Errorf("failed to add job to scheduler: %w", err)
+		// Add job to cron scheduler
+		entryID = s.cron.Schedule(prepared.schedule, cron.FuncJob(prepared.run))
+		return scheduledJob{entryID: entryID, name: job.Name, hash: prepared.hash}, true
+	})
+	if prepared.err != nil {
+		s.recordScheduleError(job, prepared.err)
+		return fmt.Errorf("failed to add job to scheduler: %w", prepared.err)
 	}
-
-	// Store entry ID for later removal
-	s.scheduledJobs[job.ID.String()] = entryID
+	s.clearScheduleError(job)
 
 	logrus.WithFields(logrus.Fields{
 		"job_id":   job.ID,
@@ -149,58 +423,292 @@ func (s *Scheduler) AddJob(job *models.Job) error {
 		"entry_id": entryID,
 	}).Info("Job added to scheduler")
 
+	event := events.JobEvent{
+		Type:    events.JobEventScheduled,
+		JobID:   job.ID,
+		JobName: job.Name,
+	}
+	if next := prepared.schedule.Next(time.Now()); !next.IsZero() {
+		next = next.UTC()
+		event.NextRunAt = &next
+	}
+	jobEvents.Publish(event)
+
 	return nil
 }
 
 // RemoveJob removes a job from the scheduler
 func (s *Scheduler) RemoveJob(jobID string) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	s.schedule.update(jobID, func(current scheduledJob, exists bool) (scheduledJob, bool) {
+		if exists {
+			s.cron.Remove(current.entryID)
+
+			logrus.WithFields(logrus.Fields{
+				"job_id":   jobID,
+				"entry_id": current.entryID,
+			}).Info("Job removed from scheduler")
+		}
+		return scheduledJob{}, false
+	})
+}
+
+// JobCreated publishes that a job was created
+func (s *Scheduler) JobCreated(job *models.Job) {
+	s.mu.RLock()
+	jobEvents := s.jobEvents
+	s.mu.RUnlock()
+	jobEvents.Publish(events.JobEvent{
+		Type:    events.JobEventCreated,
+		JobID:   job.ID,
+		JobName: job.Name,
+	})
+}
 
-	if entryID, exists := s.scheduledJobs[jobID]; exists {
-		s.cron.Remove(entryID)
-		delete(s.scheduledJobs, jobID)
+// JobSaved reschedules a created or updated job, or unschedules it if it is no longer active
+// Other replicas pick the change up on their next periodic reload
+func (s *Scheduler) JobSaved(job *models.Job) {
+	if !s.IsRunning() {
+		return
+	}
 
+	if !job.State.IsSchedulable() {
+		s.RemoveJob(job.ID.String())
+		return
+	}
+	if err := s.AddJob(job); err != nil {
 		logrus.WithFields(logrus.Fields{
-			"job_id":   jobID,
-			"entry_id": entryID,
-		}).Info("Job removed from scheduler")
+			"job_id": job.ID,
+			"error":  err,
+		}).Error("Failed to reschedule saved job - it will be retried on the next reload")
 	}
 }
 
+// JobDeleted unschedules a deleted job
+func (s *Scheduler) JobDeleted(jobID uuid.UUID) {
+	s.RemoveJob(jobID.String())
+}
+
+// TriggerJob runs a job immediately, outside its cron schedule
+// The run happens in the background; Stop waits for it to finish
+func (s *Scheduler) TriggerJob(job *models.Job, params models.JobConfig) error {
+	if !s.trackRun() {
+		return fmt.Errorf("scheduler is not running")
+	}
+
+	// Create a copy of the job to avoid race conditions
+	jobCopy := *job
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":   jobCopy.ID,
+		"name":     jobCopy.Name,
+		"job_type": jobCopy.JobType,
+	}).Info("Triggering job")
+
+	go func() {
+		defer s.wg.Done()
+		if err := s.executor.ExecuteJobWithParams(&jobCopy, params); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"job_id": jobCopy.ID,
+				"name":   jobCopy.Name,
+				"error":  err,
+			}).Error("Triggered job execution failed")
+		}
+	}()
+
+	return nil
+}
+
+// RunApproved executes a previously approved run in the background
+func (s *Scheduler) RunApproved(job *models.Job, execution *models.JobExecution) error {
+	if !s.trackRun() {
+		return fmt.Errorf("scheduler is not running")
+	}
+
+	// Create a copy of the job to avoid race conditions
+	jobCopy := *job
+
+	go func() {
+		defer s.wg.Done()
+		if err := s.executor.ExecuteApprovedRun(&jobCopy, execution); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"job_id":       jobCopy.ID,
+				"execution_id": execution.ID,
+				"error":        err,
+			}).Error("Approved job execution failed")
+		}
+	}()
+
+	return nil
+}
+
+// RunJob runs a job immediately and waits for the result
+// Used by trigger sources that need the outcome to ack or nack a message; Stop waits for the run
+func (s *Scheduler) RunJob(job *models.Job, params models.JobConfig) error {
+	if !s.trackRun() {
+		return fmt.Errorf("scheduler is not running")
+	}
+	defer s.wg.Done()
+
+	// Create a copy of the job to avoid race conditions
+	jobCopy := *job
+	return s.executor.ExecuteJobWithParams(&jobCopy, params)
+}
+
+// RunWorkflowNode runs a workflow node's job and waits for it, retries included, returning the output
+// of its last attempt. Stop waits for the run; retries still to come when the scheduler stops are abandoned
+func (s *Scheduler) RunWorkflowNode(job *models.Job, params models.JobConfig) (models.ExecutionOutput, error) {
+	if !s.trackRun() {
+		return nil, fmt.Errorf("scheduler is not running")
+	}
+	defer s.wg.Done()
+
+	// Create a copy of the job to avoid race conditions
+	jobCopy := *job
+
+	return s.executor.ExecuteJobWaitingForOutput(s.ctx, &jobCopy, params)
+}
+
+// CancelRun cancels a run executing on this instance, stopping its executor
+// A forced cancel kills the run without waiting for the executor; it returns false if the run isn't executing here
+func (s *Scheduler) CancelRun(executionID uuid.UUID, force bool) bool {
+	return s.executor.CancelExecution(executionID, force)
+}
+
+// ExtendRun moves the deadline of a run executing on this instance later, up to the maximum execution time
+func (s *Scheduler) ExtendRun(executionID uuid.UUID, by time.Duration) (time.Time, error) {
+	return s.executor.ExtendExecution(executionID, by)
+}
+
+// SetRunDeadline moves the deadline of a run executing on this instance, up to the maximum execution time
+func (s *Scheduler) SetRunDeadline(executionID uuid.UUID, deadline time.Time) (time.Time, error) {
+	return s.executor.SetExecutionDeadline(executionID, deadline)
+}
+
 // GetScheduledJobsCount returns the number of currently scheduled jobs
 func (s *Scheduler) GetScheduledJobsCount() int {
+	return s.schedule.len()
+}
+
+// IsOverloaded returns whether the scheduler is currently overloaded and deferring low-severity runs
+func (s *Scheduler) IsOverloaded() bool {
+	return s.executor.IsOverloaded()
+}
+
+// GetQueuedRuns returns the number of runs waiting for an execution slot per team
+func (s *Scheduler) GetQueuedRuns() map[string]int {
+	return s.executor.GetQueuedRuns()
+}
+
+// GetConcurrencyGroups returns the slots used by each concurrency group with a limit
+func (s *Scheduler) GetConcurrencyGroups() map[string]ConcurrencyGroupStats {
+	return s.executor.GetConcurrencyGroups()
+}
+
+// GetRateLimits returns how each start rate limit held runs back, with the limit on all jobs under "*"
+func (s *Scheduler) GetRateLimits() map[string]RateLimitStats {
+	return s.executor.GetRateLimits()
+}
+
+// GetHTTPClientStats returns request metrics per outbound HTTP client, or nil without shared clients
+func (s *Scheduler) GetHTTPClientStats() map[string]httpclient.ClientStats {
+	s.mu.RLock()
+	clients := s.httpClients
+	s.mu.RUnlock()
+	if clients == nil {
+		return nil
+	}
+	return clients.Stats()
+}
+
+// GetHistoryCleanupStats returns how much run history has been pruned, or nil without the cleanup job
+func (s *Scheduler) GetHistoryCleanupStats() *models.HistoryCleanupStats {
+	s.mu.RLock()
+	history := s.history
+	s.mu.RUnlock()
+	if history == nil {
+		return nil
+	}
+
+	stats := history.GetCleanupStats()
+	return &stats
+}
+
+// GetTableMaintenanceStats returns the table maintenance runs, or nil without the maintenance job
+func (s *Scheduler) GetTableMaintenanceStats() *models.TableMaintenanceStats {
+	s.mu.RLock()
+	maintenance := s.tableMaintenance
+	s.mu.RUnlock()
+	if maintenance == nil || !s.config.TableMaintenance.Enabled {
+		return nil
+	}
+
+	stats := maintenance.GetMaintenanceStats()
+	return &stats
+}
+
+// GetConnectionHealth returns the health of the probed connections, or nil without the connection monitor
+func (s *Scheduler) GetConnectionHealth() []models.ConnectionHealth {
+	s.mu.RLock()
+	connections := s.connections
+	s.mu.RUnlock()
+	if connections == nil || !s.config.ConnectionHealth.Enabled {
+		return nil
+	}
+	return connections.GetConnectionHealth()
+}
+
+// CheckIntegrations pings the opened integrations, or returns nil without shared integrations
+func (s *Scheduler) CheckIntegrations(ctx context.Context) map[string]integrations.Status {
+	s.mu.RLock()
+	manager := s.integrations
+	s.mu.RUnlock()
+	if manager == nil {
+		return nil
+	}
+	return manager.Check(ctx)
+}
+
+// trackRun adds a run started outside the cron schedule to s.wg, so Stop waits for it, unless the
+// scheduler isn't running or is stopping. The check and s.wg.Add happen under s.mu, so no run is
+// added once Stop has started waiting; callers call s.wg.Done when the run finishes
+func (s *Scheduler) trackRun() bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return len(s.scheduledJobs)
+	if !s.isRunning || s.stopping {
+		return false
+	}
+	s.wg.Add(1)
+	return true
 }
 
-// IsRunning returns whether the scheduler is currently running
+// IsRunning returns whether the scheduler is currently running, and not stopping
 func (s *Scheduler) IsRunning() bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return s.isRunning
+	return s.isRunning && !s.stopping
 }
 
-// loadActiveJobs loads all active jobs from the database and schedules them
+// loadActiveJobs loads all schedulable jobs from the database and schedules them
+// Start calls it holding s.mu
 func (s *Scheduler) loadActiveJobs() error {
-	jobs, err := s.jobService.GetActiveJobs()
+	jobs, err := s.jobService.GetSchedulableJobs()
 	if err != nil {
-		return fmt.Errorf("failed to get active jobs: %w", err)
+		return fmt.Errorf("failed to get schedulable jobs: %w", err)
 	}
 
 	logrus.WithField("job_count", len(jobs)).Info("Loading active jobs...")
 
-	for _, job := range jobs {
-		if err := s.AddJob(&job); err != nil {
-			logrus.WithFields(logrus.Fields{
-				"job_id": job.ID,
-				"name":   job.Name,
-				"error":  err,
-			}).Error("Failed to add job to scheduler")
-			continue
+	// Jobs are prepared concurrently, then given their cron entries
+	active := make([]*models.Job, 0, len(jobs))
+	for i := range jobs {
+		if jobs[i].State.IsSchedulable() {
+			active = append(active, &jobs[i])
 		}
 	}
+	for _, prepared := range s.prepareJobs(active) {
+		// Jobs whose schedules can't be used are marked errored instead
+		_ = s.schedulePrepared(prepared, s.jobEvents)
+	}
 
 	return nil
 }
@@ -209,6 +717,7 @@ func (s *Scheduler) loadActiveJobs() error {
 func (s *Scheduler) reloadJobsPeriodically() {
 	defer s.wg.Done()
 
+	// Changes made through this instance apply straight away; the reload picks up changes made elsewhere
 	ticker := time.NewTicker(5 * time.Minute) // Reload every 5 minutes
 	defer ticker.Stop()
 
@@ -217,87 +726,499 @@ func (s *Scheduler) reloadJobsPeriodically() {
 		case <-s.ctx.Done():
 			return
 		case <-ticker.C:
-			if err := s.reloadJobs(); err != nil {
+			if _, err := s.reloadJobs(); err != nil {
 				logrus.WithError(err).Error("Failed to reload jobs")
 			}
 		}
 	}
 }
 
-// reloadJobs reloads all active jobs from the database
-func (s *Scheduler) reloadJobs() error {
+// expireApprovalsPeriodically expires runs whose approval window has passed
+func (s *Scheduler) expireApprovalsPeriodically() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := s.approvals.ExpireStale()
+			if err != nil {
+				logrus.WithError(err).Error("Failed to expire stale approvals")
+				continue
+			}
+			if expired > 0 {
+				logrus.WithField("expired", expired).Info("Expired runs awaiting approval")
+			}
+		}
+	}
+}
+
+// recoverInterruptedRunsOnStart requeues or fails runs left interrupted by an earlier shutdown or crash
+func (s *Scheduler) recoverInterruptedRunsOnStart() {
+	defer s.wg.Done()
+
+	requeued, failed, err := s.executor.RecoverInterruptedRuns()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to recover interrupted runs")
+		return
+	}
+	if requeued > 0 || failed > 0 {
+		logrus.WithFields(logrus.Fields{
+			"requeued": requeued,
+			"failed":   failed,
+		}).Warn("Recovered runs interrupted by an earlier shutdown")
+	}
+}
+
+// markStalledRunsPeriodically marks runs left running by instances that stopped
+func (s *Scheduler) markStalledRunsPeriodically() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			stalled, err := s.executor.MarkStalledRuns()
+			if err != nil {
+				logrus.WithError(err).Error("Failed to mark stalled runs")
+				continue
+			}
+			if stalled > 0 {
+				logrus.WithField("stalled", stalled).Warn("Marked stalled runs")
+			}
+		}
+	}
+}
+
+// pruneRunClaimsPeriodically deletes run claims once no replica can still be firing their run
+func (s *Scheduler) pruneRunClaimsPeriodically() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			pruned, err := s.claims.DeleteBefore(time.Now().UTC().Add(-runClaimRetention))
+			if err != nil {
+				logrus.WithError(err).Error("Failed to prune run claims")
+				continue
+			}
+			if pruned > 0 {
+				logrus.WithField("pruned", pruned).Debug("Pruned run claims")
+			}
+		}
+	}
+}
+
+// maintainArtifactsPeriodically is the artifact maintenance system job
+// It deletes artifacts whose retention has ended, then evicts the oldest artifacts of jobs and teams over quota
+func (s *Scheduler) maintainArtifactsPeriodically() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.Artifacts.MaintenanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := s.artifacts.PurgeExpired()
+			if err != nil {
+				logrus.WithError(err).Error("Failed to purge expired artifacts")
+			}
+			if purged > 0 {
+				logrus.WithField("purged", purged).Info("Purged expired artifacts")
+			}
+
+			evicted, err := s.artifacts.EvictOverQuota()
+			if err != nil {
+				logrus.WithError(err).Error("Failed to evict artifacts over quota")
+			}
+			if evicted > 0 {
+				logrus.WithField("evicted", evicted).Info("Evicted artifacts over quota")
+			}
+		}
+	}
+}
+
+// scoreJobHealthPeriodically recalculates job health scores from their recent runs
+func (s *Scheduler) scoreJobHealthPeriodically() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.Scheduler.HealthScoreInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			scored, err := s.healthScores.RecalculateHealthScores()
+			if err != nil {
+				logrus.WithError(err).Error("Failed to recalculate job health scores")
+				continue
+			}
+			logrus.WithField("scored", scored).Debug("Recalculated job health scores")
+		}
+	}
+}
+
+// pruneHistoryPeriodically is the run history cleanup system job
+// It deletes finished runs older than each job's retention so the executions table doesn't grow unbounded
+func (s *Scheduler) pruneHistoryPeriodically() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.History.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			pruned, err := s.history.PruneExecutions()
+			if err != nil {
+				logrus.WithError(err).Error("Failed to prune run history")
+			}
+			if pruned > 0 {
+				logrus.WithField("pruned", pruned).Info("Pruned old run history")
+			}
+		}
+	}
+}
+
+// maintainTablesPeriodically is the table maintenance system job
+// Run churn leaves dead rows and stale planner statistics faster than autovacuum catches up with in
+// busy deployments, so the hot tables are analyzed, and optionally vacuumed, on a fixed cadence
+func (s *Scheduler) maintainTablesPeriodically() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.TableMaintenance.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.tableMaintenance.MaintainTables(s.ctx); err != nil {
+				if s.ctx.Err() != nil {
+					return
+				}
+				logrus.WithError(err).Error("Failed to maintain tables")
+				continue
+			}
+			logrus.Debug("Maintained scheduler tables")
+		}
+	}
+}
+
+// probeConnectionsPeriodically probes the external connections jobs use, starting straight away
+// so runs fail fast on a connection that was down before the scheduler started
+func (s *Scheduler) probeConnectionsPeriodically() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.ConnectionHealth.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.connections.ProbeConnections(s.ctx)
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// evaluateAlertsPeriodically evaluates alert rules against the jobs' recent runs
+func (s *Scheduler) evaluateAlertsPeriodically() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.Alerts.EvaluationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			firing, err := s.alerts.EvaluateAlerts()
+			if err != nil {
+				logrus.WithError(err).Error("Failed to evaluate alert rules")
+				continue
+			}
+			logrus.WithField("firing", firing).Debug("Evaluated alert rules")
+		}
+	}
+}
+
+// Reload reloads the active jobs straight away, as the periodic reload does, and reports what changed
+// Useful after jobs were changed outside the API, such as by a bulk import
+func (s *Scheduler) Reload() (*models.ScheduleReload, error) {
+	if !s.IsRunning() {
+		return nil, fmt.Errorf("scheduler is not running")
+	}
+	return s.reloadJobs()
+}
+
+// reloadJobs reloads all schedulable jobs from the database and reports how the schedule changed
+func (s *Scheduler) reloadJobs() (*models.ScheduleReload, error) {
 	logrus.Debug("Reloading jobs from database...")
 
-	jobs, err := s.jobService.GetActiveJobs()
+	jobs, err := s.jobService.GetSchedulableJobs()
 	if err != nil {
-		return fmt.Errorf("failed to get active jobs: %w", err)
+		return nil, fmt.Errorf("failed to get schedulable jobs: %w", err)
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	reload := &models.ScheduleReload{
+		Added:   []models.ReloadedJob{},
+		Updated: []models.ReloadedJob{},
+		Removed: []models.ReloadedJob{},
+		Failed:  []models.ReloadedJob{},
+	}
 
-	// Create a map of current jobs for comparison
-	currentJobs := make(map[string]*models.Job)
+	// Create a set of current jobs for comparison
+	currentJobs := make(map[string]bool, len(jobs))
 	for _, job := range jobs {
-		currentJobs[job.ID.String()] = &job
+		currentJobs[job.ID.String()] = true
 	}
 
 	// Remove jobs that are no longer active or don't exist
-	for jobID, entryID := range s.scheduledJobs {
-		if _, exists := currentJobs[jobID]; !exists {
-			s.cron.Remove(entryID)
-			delete(s.scheduledJobs, jobID)
-			logrus.WithField("job_id", jobID).Info("Removed inactive job from scheduler")
+	// The schedule is compared one job at a time, so AddJob, RemoveJob and health queries aren't
+	// held up while thousands of jobs are reloaded
+	scheduled := s.schedule.snapshot()
+	for jobID := range scheduled {
+		if currentJobs[jobID] {
+			continue
+		}
+		s.schedule.update(jobID, func(current scheduledJob, exists bool) (scheduledJob, bool) {
+			if exists {
+				s.cron.Remove(current.entryID)
+				reload.Removed = append(reload.Removed, reloadedJob(jobID, current.name))
+				logrus.WithField("job_id", jobID).Info("Removed inactive job from scheduler")
+			}
+			return scheduledJob{}, false
+		})
+	}
+
+	// Only jobs whose settings changed since they were scheduled get a new job function and cron
+	// entry, so a reload changes next to nothing while jobs stay the same. They are prepared concurrently
+	hashes := make([]string, len(jobs))
+	var changed []*models.Job
+	for i := range jobs {
+		job := &jobs[i]
+		if !job.State.IsSchedulable() {
+			continue
+		}
+		hashes[i] = jobHash(job)
+		if current, exists := scheduled[job.ID.String()]; exists && current.unchanged(hashes[i]) {
+			continue
 		}
+		changed = append(changed, job)
+	}
+	prepared := make(map[uuid.UUID]preparedJob, len(changed))
+	for _, p := range s.prepareJobs(changed) {
+		prepared[p.job.ID] = p
 	}
 
 	// Add or update jobs
-	for _, job := range jobs {
-		if job.IsActive {
+	// Jobs are marked errored, or scheduled again, once the schedule has been updated, so the
+	// database isn't written holding a shard
+	var errored []erroredJob
+	var recovered []*models.Job
+	for i := range jobs {
+		job := &jobs[i]
+		if !job.State.IsSchedulable() {
+			continue
+		}
+		s.schedule.update(job.ID.String(), func(current scheduledJob, exists bool) (scheduledJob, bool) {
+			// Jobs whose settings are unchanged keep their entry
+			if exists && current.unchanged(hashes[i]) {
+				reload.Unchanged++
+				return current, true
+			}
+
 			// Remove existing entry if it exists
-			if entryID, exists := s.scheduledJobs[job.ID.String()]; exists {
-				s.cron.Remove(entryID)
-				delete(s.scheduledJobs, job.ID.String())
+			if exists {
+				s.cron.Remove(current.entryID)
 			}
 
-			// Add job with current configuration
-			jobFunc := s.createJobFunction(&job)
-			entryID, err := s.cron.AddFunc(job.Schedule, jobFunc)
-			if err != nil {
-				logrus.WithFields(logrus.Fields{
-					"job_id": job.ID,
-					"error":  err,
-				}).Error("Failed to add job during reload")
-				continue
+			// Add job with current configuration, preparing it now if it was unscheduled since the snapshot
+			p, ok := prepared[job.ID]
+			if !ok {
+				p = s.prepareJob(job)
+			}
+			if p.err != nil {
+				errored = append(errored, erroredJob{job: job, err: p.err})
+				reload.Failed = append(reload.Failed, models.ReloadedJob{ID: job.ID, Name: job.Name, Error: p.err.Error()})
+				return scheduledJob{}, false
+			}
+			entryID := s.cron.Schedule(p.schedule, cron.FuncJob(p.run))
+			if job.State == models.JobStateErrored {
+				recovered = append(recovered, job)
 			}
 
-			s.scheduledJobs[job.ID.String()] = entryID
-		}
+			if exists {
+				reload.Updated = append(reload.Updated, models.ReloadedJob{ID: job.ID, Name: job.Name})
+			} else {
+				reload.Added = append(reload.Added, models.ReloadedJob{ID: job.ID, Name: job.Name})
+			}
+			return scheduledJob{entryID: entryID, name: job.Name, hash: p.hash}, true
+		})
 	}
 
-	logrus.WithField("scheduled_jobs", len(s.scheduledJobs)).Debug("Jobs reloaded successfully")
-	return nil
+	for _, e := range errored {
+		s.recordScheduleError(e.job, e.err)
+	}
+	for _, job := range recovered {
+		s.clearScheduleError(job)
+	}
+
+	reload.ScheduledJobs = s.schedule.len()
+	reload.ReloadedAt = time.Now().UTC()
+
+	entry := logrus.WithFields(logrus.Fields{
+		"scheduled_jobs": reload.ScheduledJobs,
+		"added":          len(reload.Added),
+		"updated":        len(reload.Updated),
+		"removed":        len(reload.Removed),
+		"failed":         len(reload.Failed),
+	})
+	if reload.HasChanges() {
+		entry.Info("Jobs reloaded with schedule changes")
+	} else {
+		entry.Debug("Jobs reloaded successfully")
+	}
+	return reload, nil
+}
+
+// reloadedJob identifies a job by the ID it is scheduled under
+func reloadedJob(jobID, name string) models.ReloadedJob {
+	id, _ := uuid.Parse(jobID)
+	return models.ReloadedJob{ID: id, Name: name}
 }
 
 // createJobFunction creates a function that executes a specific job
 func (s *Scheduler) createJobFunction(job *models.Job) func() {
+	// The job is copied now, as callers may reuse what job points to
+	scheduled := *job
 	return func() {
 		// Create a copy of the job to avoid race conditions
-		jobCopy := *job
+		jobCopy := scheduled
 
-		logrus.WithFields(logrus.Fields{
-			"job_id":   jobCopy.ID,
-			"name":     jobCopy.Name,
-			"job_type": jobCopy.JobType,
-		}).Info("Executing scheduled job")
+		// The occurrence identifies the scheduled run across instances
+		scheduledFor := s.occurrence(&jobCopy)
+		if !s.claimRun(&jobCopy, scheduledFor) {
+			return
+		}
+		s.recordRunTimes(&jobCopy, scheduledFor, scheduledFor)
+		s.runOccurrence(&jobCopy, scheduledFor)
+	}
+}
 
-		// Execute the job
-		if err := s.executor.ExecuteJob(&jobCopy); err != nil {
+// runOccurrence executes the job's run for the occurrence at scheduledFor, or requests its approval
+func (s *Scheduler) runOccurrence(job *models.Job, scheduledFor time.Time) {
+	// Jobs that require approval only get a run awaiting approval
+	s.mu.RLock()
+	approvals := s.approvals
+	s.mu.RUnlock()
+	if job.RequiresApproval && approvals != nil {
+		if _, err := approvals.RequestApproval(job, scheduledFor); err != nil {
 			logrus.WithFields(logrus.Fields{
-				"job_id": jobCopy.ID,
-				"name":   jobCopy.Name,
+				"job_id": job.ID,
+				"name":   job.Name,
 				"error":  err,
-			}).Error("Job execution failed")
+			}).Error("Failed to request run approval")
+		}
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":        job.ID,
+		"name":          job.Name,
+		"job_type":      job.JobType,
+		"scheduled_for": scheduledFor,
+	}).Info("Executing scheduled job")
+
+	// Execute the job, unless the scheduler is overloaded and defers it or its previous run is still going
+	err := s.executor.ExecuteScheduledJob(job, scheduledFor)
+	s.recordScheduledOutcome(job, scheduledFor, err)
+	if err != nil && !errors.Is(err, ErrRunDeferred) && !errors.Is(err, ErrRunSkipped) {
+		logrus.WithFields(logrus.Fields{
+			"job_id": job.ID,
+			"name":   job.Name,
+			"error":  err,
+		}).Error("Job execution failed")
+	}
+}
+
+// occurrence returns the time the job's firing cron entry was scheduled for, which may be
+// slightly before it actually fired. cron records it as the entry's Prev before answering
+// for its entries again, so it is set by the time the job function asks
+func (s *Scheduler) occurrence(job *models.Job) time.Time {
+	scheduled, exists := s.schedule.get(job.ID.String())
+	if exists {
+		if prev := s.cron.Entry(scheduled.entryID).Prev; !prev.IsZero() {
+			return prev.UTC()
 		}
 	}
+	// The job was rescheduled while firing; @every occurrences are aligned to their interval, and
+	// cron schedules have minute resolution unless they have seconds
+	if schedule, err := services.JobSchedule(job); err == nil {
+		if every, ok := schedule.(services.EverySchedule); ok {
+			return every.Prev(time.Now().UTC())
+		}
+	}
+	if job.CronSeconds {
+		return time.Now().UTC().Truncate(time.Second)
+	}
+	return time.Now().UTC().Truncate(time.Minute)
+}
+
+// claimRun claims the job's run at its scheduled time for this instance
+// It returns false when another instance claimed it, or the claim can't be recorded
+func (s *Scheduler) claimRun(job *models.Job, scheduledFor time.Time) bool {
+	s.mu.RLock()
+	claims := s.claims
+	s.mu.RUnlock()
+	if claims == nil {
+		return true
+	}
+
+	claimed, err := claims.Claim(&models.JobRunClaim{
+		JobID:        job.ID,
+		ScheduledFor: scheduledFor,
+		InstanceID:   s.config.Scheduler.InstanceID,
+	})
+	if err != nil {
+		// Without a claim the run can't be recorded either, so skip it rather than risk running it twice
+		logrus.WithFields(logrus.Fields{
+			"job_id": job.ID,
+			"name":   job.Name,
+			"error":  err,
+		}).Error("Failed to claim scheduled run - skipping it")
+		return false
+	}
+	if !claimed {
+		logrus.WithFields(logrus.Fields{
+			"job_id":        job.ID,
+			"name":          job.Name,
+			"scheduled_for": scheduledFor,
+		}).Debug("Scheduled run already claimed by another instance")
+	}
+	return claimed
 }
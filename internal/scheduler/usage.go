@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"runtime"
+	"time"
+
+	"job-scheduler/internal/models"
+)
+
+// usageSampler captures process-level resource usage around a run
+type usageSampler struct {
+	goroutines int
+	totalAlloc uint64
+	mallocs    uint64
+	cpuTime    time.Duration
+	cpuOK      bool
+}
+
+// startUsageSampler records the process counters at the start of a run
+func startUsageSampler() *usageSampler {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	cpuTime, cpuOK := processCPUTime()
+	return &usageSampler{
+		goroutines: runtime.NumGoroutine(),
+		totalAlloc: mem.TotalAlloc,
+		mallocs:    mem.Mallocs,
+		cpuTime:    cpuTime,
+		cpuOK:      cpuOK,
+	}
+}
+
+// stop returns the usage since the sampler started
+func (s *usageSampler) stop() *models.ResourceUsage {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	usage := &models.ResourceUsage{
+		Source:         models.ResourceUsageSourceProcess,
+		GoroutineDelta: runtime.NumGoroutine() - s.goroutines,
+		AllocatedBytes: mem.TotalAlloc - s.totalAlloc,
+		Allocations:    mem.Mallocs - s.mallocs,
+	}
+
+	if cpuTime, ok := processCPUTime(); ok && s.cpuOK {
+		cpuMs := (cpuTime - s.cpuTime).Milliseconds()
+		usage.CPUTimeMs = &cpuMs
+	}
+	return usage
+}
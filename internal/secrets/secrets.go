@@ -0,0 +1,47 @@
+// Package secrets reads credentials that configuration references rather than stores,
+// so passwords stay in the environment or in mounted secret files
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrUnsupportedReference is returned for references that are neither env: nor file:
+var ErrUnsupportedReference = errors.New("secret reference must be env:NAME or file:/path")
+
+// Validate checks that a reference is well formed without reading the secret
+func Validate(ref string) error {
+	scheme, name, ok := strings.Cut(ref, ":")
+	if !ok || name == "" || (scheme != "env" && scheme != "file") {
+		return fmt.Errorf("%w, got %q", ErrUnsupportedReference, ref)
+	}
+	return nil
+}
+
+// Resolve reads the secret a reference points at:
+// env:NAME reads the environment variable NAME and file:/path reads a file, such as a mounted
+// Kubernetes or Docker secret, without its trailing newline
+func Resolve(ref string) (string, error) {
+	if err := Validate(ref); err != nil {
+		return "", err
+	}
+
+	scheme, name, _ := strings.Cut(ref, ":")
+	switch scheme {
+	case "env":
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("secret environment variable %s is not set", name)
+		}
+		return value, nil
+	default:
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+}
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+var (
+	// ErrAcknowledgmentInactive is returned when clearing an acknowledgment that already expired or was cleared
+	ErrAcknowledgmentInactive = errors.New("acknowledgment is no longer active")
+	// ErrJobNotAcknowledged is returned when clearing a scope a job has no acknowledgment in effect for
+	ErrJobNotAcknowledged = errors.New("job has no acknowledgment in effect")
+)
+
+// Audit actions for acknowledging jobs, recorded against the job
+const (
+	AuditActionJobAcknowledged          = "job_acknowledged"
+	AuditActionJobAcknowledgmentCleared = "job_acknowledgment_cleared"
+)
+
+// AcknowledgmentLookup finds the acknowledgments in effect, so alerts and notifications for acknowledged
+// jobs can be held back and failing jobs shown with their notes
+type AcknowledgmentLookup interface {
+	// GetActiveAcknowledgments returns each acknowledged job's acknowledgment in any of the given scopes
+	// that expires last, in every scope if none are given
+	GetActiveAcknowledgments(scopes ...models.AcknowledgmentScope) (map[uuid.UUID]models.JobAcknowledgment, error)
+}
+
+// AcknowledgmentService defines the interface for operator acknowledgments of jobs
+type AcknowledgmentService interface {
+	AcknowledgmentLookup
+	AcknowledgeJob(jobID uuid.UUID, req *models.CreateJobAcknowledgmentRequest, actor string) (*models.JobAcknowledgment, error)
+	SilenceJob(jobID uuid.UUID, req *models.CreateJobSilenceRequest, actor string) (*models.JobAcknowledgment, error)
+	GetJobAcknowledgments(jobID uuid.UUID) ([]models.JobAcknowledgment, error)
+	ListActiveAcknowledgments(scope models.AcknowledgmentScope) ([]models.JobAcknowledgment, error)
+	ClearAcknowledgment(jobID, id uuid.UUID, actor string) (*models.JobAcknowledgment, error)
+	ClearScope(jobID uuid.UUID, scope models.AcknowledgmentScope, actor string) ([]models.JobAcknowledgment, error)
+}
+
+// acknowledgmentService implements AcknowledgmentService interface
+type acknowledgmentService struct {
+	acknowledgmentRepo repositories.JobAcknowledgmentRepository
+	jobRepo            repositories.JobRepository
+	auditRepo          repositories.AuditRepository
+}
+
+// NewAcknowledgmentService creates a new acknowledgment service recording who acknowledged what in the audit log
+func NewAcknowledgmentService(acknowledgmentRepo repositories.JobAcknowledgmentRepository, jobRepo repositories.JobRepository, auditRepo repositories.AuditRepository) AcknowledgmentService {
+	return &acknowledgmentService{
+		acknowledgmentRepo: acknowledgmentRepo,
+		jobRepo:            jobRepo,
+		auditRepo:          auditRepo,
+	}
+}
+
+// AcknowledgeJob records a note on a job that holds back what its scope covers until it expires or is cleared
+func (s *acknowledgmentService) AcknowledgeJob(jobID uuid.UUID, req *models.CreateJobAcknowledgmentRequest, actor string) (*models.JobAcknowledgment, error) {
+	if actor == "" {
+		return nil, fmt.Errorf("the acknowledging user is required")
+	}
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		return nil, fmt.Errorf("note is required")
+	}
+	scope := req.Scope
+	if scope == "" {
+		scope = models.AcknowledgmentScopeAlerts
+	}
+	if !scope.IsValid() {
+		return nil, fmt.Errorf("scope must be %s or %s", models.AcknowledgmentScopeAlerts, models.AcknowledgmentScopeNotifications)
+	}
+
+	now := time.Now().UTC()
+	var expiresAt *time.Time
+	switch {
+	case req.ExpiresAt != nil && req.Duration != "":
+		return nil, fmt.Errorf("set either expires_at or duration, not both")
+	case req.ExpiresAt != nil:
+		at := req.ExpiresAt.UTC()
+		expiresAt = &at
+	case req.Duration != "":
+		duration, err := time.ParseDuration(req.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration: %w", err)
+		}
+		at := now.Add(duration)
+		expiresAt = &at
+	}
+	if expiresAt != nil {
+		if !expiresAt.After(now) {
+			return nil, fmt.Errorf("acknowledgment must expire in the future")
+		}
+		if expiresAt.Sub(now) > models.MaxAcknowledgmentDuration {
+			return nil, fmt.Errorf("acknowledgment can't last longer than %s", models.MaxAcknowledgmentDuration)
+		}
+	}
+
+	if _, err := s.jobRepo.GetByID(jobID); err != nil {
+		return nil, fmt.Errorf("failed to get job for acknowledgment: %w", err)
+	}
+
+	acknowledgment := &models.JobAcknowledgment{
+		ID:             uuid.New(),
+		JobID:          jobID,
+		Note:           note,
+		AcknowledgedBy: actor,
+		Scope:          scope,
+		ExpiresAt:      expiresAt,
+	}
+	if err := s.acknowledgmentRepo.Create(acknowledgment); err != nil {
+		return nil, fmt.Errorf("failed to create job acknowledgment: %w", err)
+	}
+	details := models.JobConfig{
+		"acknowledgment_id": acknowledgment.ID.String(),
+		"scope":             string(scope),
+		"note":              note,
+	}
+	if expiresAt != nil {
+		details["expires_at"] = expiresAt.Format(time.RFC3339)
+	}
+	s.audit(jobID, AuditActionJobAcknowledged, actor, details)
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":          jobID,
+		"acknowledged_by": actor,
+		"scope":           scope,
+		"expires_at":      expiresAt,
+	}).Info("Job acknowledged")
+
+	return acknowledgment, nil
+}
+
+// SilenceJob mutes a job's notifications for the requested duration, acknowledging it in the notifications scope
+func (s *acknowledgmentService) SilenceJob(jobID uuid.UUID, req *models.CreateJobSilenceRequest, actor string) (*models.JobAcknowledgment, error) {
+	if req.Duration == "" {
+		return nil, fmt.Errorf("duration is required")
+	}
+	return s.AcknowledgeJob(jobID, &models.CreateJobAcknowledgmentRequest{
+		Note:     req.Reason,
+		Scope:    models.AcknowledgmentScopeNotifications,
+		Duration: req.Duration,
+	}, actor)
+}
+
+// GetJobAcknowledgments lists a job's acknowledgments in every scope, newest first
+func (s *acknowledgmentService) GetJobAcknowledgments(jobID uuid.UUID) ([]models.JobAcknowledgment, error) {
+	acknowledgments, err := s.acknowledgmentRepo.GetByJobID(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job acknowledgments: %w", err)
+	}
+	return acknowledgments, nil
+}
+
+// ListActiveAcknowledgments lists the acknowledgments in effect in the scope, soonest to expire first
+func (s *acknowledgmentService) ListActiveAcknowledgments(scope models.AcknowledgmentScope) ([]models.JobAcknowledgment, error) {
+	acknowledgments, err := s.acknowledgmentRepo.GetActive(time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active acknowledgments: %w", err)
+	}
+
+	inScope := make([]models.JobAcknowledgment, 0, len(acknowledgments))
+	for _, acknowledgment := range acknowledgments {
+		if acknowledgment.Scope == scope {
+			inScope = append(inScope, acknowledgment)
+		}
+	}
+	sort.SliceStable(inScope, func(i, j int) bool {
+		return inScope[i].ExpiresBefore(&inScope[j])
+	})
+	return inScope, nil
+}
+
+// ClearAcknowledgment ends an acknowledgment before it expires, so what it held back is sent again
+func (s *acknowledgmentService) ClearAcknowledgment(jobID, id uuid.UUID, actor string) (*models.JobAcknowledgment, error) {
+	acknowledgment, err := s.acknowledgmentRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job acknowledgment: %w", err)
+	}
+	if acknowledgment.JobID != jobID {
+		return nil, fmt.Errorf("job acknowledgment with ID %s not found", id)
+	}
+	if !acknowledgment.IsActive(time.Now().UTC()) {
+		return nil, ErrAcknowledgmentInactive
+	}
+
+	if err := s.clear(acknowledgment, actor); err != nil {
+		return nil, err
+	}
+	return acknowledgment, nil
+}
+
+// ClearScope ends every acknowledgment of a job in the scope still in effect, as lifting a silence does
+func (s *acknowledgmentService) ClearScope(jobID uuid.UUID, scope models.AcknowledgmentScope, actor string) ([]models.JobAcknowledgment, error) {
+	acknowledgments, err := s.acknowledgmentRepo.GetByJobID(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job acknowledgments: %w", err)
+	}
+
+	now := time.Now().UTC()
+	var cleared []models.JobAcknowledgment
+	for i := range acknowledgments {
+		acknowledgment := &acknowledgments[i]
+		if acknowledgment.Scope != scope || !acknowledgment.IsActive(now) {
+			continue
+		}
+		if err := s.clear(acknowledgment, actor); err != nil {
+			return nil, err
+		}
+		cleared = append(cleared, *acknowledgment)
+	}
+	if len(cleared) == 0 {
+		return nil, ErrJobNotAcknowledged
+	}
+	return cleared, nil
+}
+
+// clear marks the acknowledgment cleared by actor and saves it
+func (s *acknowledgmentService) clear(acknowledgment *models.JobAcknowledgment, actor string) error {
+	acknowledgment.MarkCleared(actor)
+	if err := s.acknowledgmentRepo.Update(acknowledgment); err != nil {
+		return fmt.Errorf("failed to clear job acknowledgment: %w", err)
+	}
+	s.audit(acknowledgment.JobID, AuditActionJobAcknowledgmentCleared, actor, models.JobConfig{
+		"acknowledgment_id": acknowledgment.ID.String(),
+		"scope":             string(acknowledgment.Scope),
+	})
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":     acknowledgment.JobID,
+		"scope":      acknowledgment.Scope,
+		"cleared_by": actor,
+	}).Info("Job acknowledgment cleared")
+	return nil
+}
+
+// GetActiveAcknowledgments returns the acknowledgment in effect in any of the scopes for each acknowledged
+// job, the one expiring last when a job has several
+func (s *acknowledgmentService) GetActiveAcknowledgments(scopes ...models.AcknowledgmentScope) (map[uuid.UUID]models.JobAcknowledgment, error) {
+	acknowledgments, err := s.acknowledgmentRepo.GetActive(time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active acknowledgments: %w", err)
+	}
+
+	active := make(map[uuid.UUID]models.JobAcknowledgment, len(acknowledgments))
+	for _, acknowledgment := range acknowledgments {
+		if !inScopes(acknowledgment.Scope, scopes) {
+			continue
+		}
+		if existing, ok := active[acknowledgment.JobID]; ok && !existing.ExpiresBefore(&acknowledgment) {
+			continue
+		}
+		active[acknowledgment.JobID] = acknowledgment
+	}
+	return active, nil
+}
+
+// inScopes returns true if scope is one of scopes, or scopes is empty
+func inScopes(scope models.AcknowledgmentScope, scopes []models.AcknowledgmentScope) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// audit records an audit entry against the job, logging rather than failing when it can't
+func (s *acknowledgmentService) audit(jobID uuid.UUID, action, actor string, details models.JobConfig) {
+	if s.auditRepo == nil {
+		return
+	}
+	entry := &models.AuditEntry{
+		EntityType: AuditEntityJob,
+		EntityID:   jobID,
+		Action:     action,
+		Actor:      actor,
+		Details:    details,
+	}
+	if err := s.auditRepo.Create(entry); err != nil {
+		logrus.WithError(err).Error("Failed to write audit entry")
+	}
+}
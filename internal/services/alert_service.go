@@ -0,0 +1,432 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+// ErrAlertRuleExists is returned when creating a second alert rule with the same name
+var ErrAlertRuleExists = errors.New("an alert rule with this name already exists")
+
+// AlertService defines the interface for alert rules and the alerts they fire
+type AlertService interface {
+	CreateAlertRule(req *models.CreateAlertRuleRequest) (*models.AlertRule, error)
+	GetAlertRules() ([]models.AlertRule, error)
+	UpdateAlertRule(id uuid.UUID, req *models.UpdateAlertRuleRequest) (*models.AlertRule, error)
+	DeleteAlertRule(id uuid.UUID) error
+	EvaluateAlerts() (int, error)
+	GetFiringAlerts() []models.Alert
+	SetAcknowledgments(acknowledgments AcknowledgmentLookup)
+}
+
+// alertService implements AlertService interface
+type alertService struct {
+	ruleRepo        repositories.AlertRuleRepository
+	jobRepo         repositories.JobRepository
+	executionRepo   repositories.JobExecutionRepository
+	httpClient      *http.Client
+	alertmanagerURL string
+	mu              sync.RWMutex
+	firing          map[string]models.Alert // keyed by rule and job
+	suppressed      map[string]models.Alert // alerts of acknowledged jobs, not pushed or listed
+	acknowledgments AcknowledgmentLookup
+}
+
+// NewAlertService creates a new alert service
+// Alerts are pushed to Alertmanager only when cfg.AlertmanagerURL is set
+func NewAlertService(
+	ruleRepo repositories.AlertRuleRepository,
+	jobRepo repositories.JobRepository,
+	executionRepo repositories.JobExecutionRepository,
+	httpClient *http.Client,
+	cfg config.AlertsConfig,
+) AlertService {
+	return &alertService{
+		ruleRepo:        ruleRepo,
+		jobRepo:         jobRepo,
+		executionRepo:   executionRepo,
+		httpClient:      httpClient,
+		alertmanagerURL: cfg.AlertmanagerURL,
+		firing:          make(map[string]models.Alert),
+		suppressed:      make(map[string]models.Alert),
+	}
+}
+
+// SetAcknowledgments suppresses the alerts of jobs an operator has acknowledged until the acknowledgment ends
+func (s *alertService) SetAcknowledgments(acknowledgments AcknowledgmentLookup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.acknowledgments = acknowledgments
+}
+
+// CreateAlertRule creates a rule that fires for jobs whose metric exceeds its threshold
+func (s *alertService) CreateAlertRule(req *models.CreateAlertRuleRequest) (*models.AlertRule, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+
+	rule := &models.AlertRule{
+		ID:          uuid.New(),
+		Name:        name,
+		Description: req.Description,
+		Metric:      req.Metric,
+		Threshold:   req.Threshold,
+		Window:      req.Window,
+		JobID:       req.JobID,
+		Team:        strings.TrimSpace(req.Team),
+		Severity:    req.Severity,
+		IsActive:    true,
+	}
+	if rule.Window == 0 {
+		rule.Window = models.DefaultAlertRuleWindow
+	}
+	if rule.Severity == "" {
+		rule.Severity = models.DefaultAlertSeverity
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+	if err := s.validateAlertRule(rule); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.ruleRepo.FindByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check alert rule: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrAlertRuleExists
+	}
+
+	if err := s.ruleRepo.Create(rule); err != nil {
+		return nil, fmt.Errorf("failed to create alert rule: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"rule_id":   rule.ID,
+		"name":      rule.Name,
+		"metric":    rule.Metric,
+		"threshold": rule.Threshold,
+	}).Info("Alert rule created successfully")
+
+	return rule, nil
+}
+
+// GetAlertRules lists all alert rules
+func (s *alertService) GetAlertRules() ([]models.AlertRule, error) {
+	rules, err := s.ruleRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert rules: %w", err)
+	}
+	return rules, nil
+}
+
+// UpdateAlertRule updates an alert rule; the change applies from the next evaluation
+func (s *alertService) UpdateAlertRule(id uuid.UUID, req *models.UpdateAlertRuleRequest) (*models.AlertRule, error) {
+	rule, err := s.ruleRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert rule for update: %w", err)
+	}
+
+	if req.Description != nil {
+		rule.Description = *req.Description
+	}
+	if req.Metric != nil {
+		rule.Metric = *req.Metric
+	}
+	if req.Threshold != nil {
+		rule.Threshold = *req.Threshold
+	}
+	if req.Window != nil {
+		rule.Window = *req.Window
+	}
+	if req.JobID != nil {
+		// The nil UUID selects every job again
+		rule.JobID = req.JobID
+		if *req.JobID == uuid.Nil {
+			rule.JobID = nil
+		}
+	}
+	if req.Team != nil {
+		rule.Team = strings.TrimSpace(*req.Team)
+	}
+	if req.Severity != nil {
+		rule.Severity = *req.Severity
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+	if err := s.validateAlertRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Update(rule); err != nil {
+		return nil, fmt.Errorf("failed to update alert rule: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"rule_id": rule.ID,
+		"name":    rule.Name,
+	}).Info("Alert rule updated successfully")
+
+	return rule, nil
+}
+
+// DeleteAlertRule deletes an alert rule; its firing alerts resolve on the next evaluation
+func (s *alertService) DeleteAlertRule(id uuid.UUID) error {
+	if err := s.ruleRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete alert rule: %w", err)
+	}
+	return nil
+}
+
+// EvaluateAlerts checks every active rule against the active jobs it selects and returns how many
+// alerts are firing. Alerts that stopped firing are resolved. When Alertmanager is configured, firing
+// alerts are (re-)sent along with the ones that just resolved. Alerts of acknowledged jobs are held
+// back without resolving, and fire again, with their original start, once the acknowledgment ends
+func (s *alertService) EvaluateAlerts() (int, error) {
+	rules, err := s.ruleRepo.GetActive()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get alert rules: %w", err)
+	}
+	jobs, err := s.jobRepo.GetActiveJobs()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get active jobs: %w", err)
+	}
+
+	s.mu.RLock()
+	previous := s.firing
+	previousSuppressed := s.suppressed
+	acknowledgmentLookup := s.acknowledgments
+	s.mu.RUnlock()
+
+	var acknowledged map[uuid.UUID]models.JobAcknowledgment
+	if acknowledgmentLookup != nil {
+		acknowledged, err = acknowledgmentLookup.GetActiveAcknowledgments(models.AcknowledgmentScopeAlerts)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get job acknowledgments: %w", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	runs := make(map[uuid.UUID][]models.JobExecution)
+	firing := make(map[string]models.Alert)
+	suppressed := make(map[string]models.Alert)
+
+	for i := range rules {
+		rule := &rules[i]
+		for j := range jobs {
+			job := &jobs[j]
+			if !rule.Matches(job) {
+				continue
+			}
+
+			jobRuns, loaded := runs[job.ID]
+			if !loaded {
+				jobRuns, err = s.executionRepo.GetRecentFinished(job.ID, models.MaxAlertRuleWindow)
+				if err != nil {
+					logrus.WithFields(logrus.Fields{
+						"job_id": job.ID,
+						"error":  err,
+					}).Error("Failed to get runs for alert rules")
+					continue
+				}
+				runs[job.ID] = jobRuns
+			}
+			if len(jobRuns) > rule.Window {
+				jobRuns = jobRuns[:rule.Window]
+			}
+
+			value, ok := measureAlertMetric(rule.Metric, jobRuns)
+			if !ok || value <= rule.Threshold {
+				continue
+			}
+
+			key := alertKey(rule, job)
+			alert := newAlert(rule, job, value, now)
+			if existing, ok := previous[key]; ok {
+				alert.StartsAt = existing.StartsAt
+			} else if existing, ok := previousSuppressed[key]; ok {
+				alert.StartsAt = existing.StartsAt
+			}
+			if acknowledgment, ok := acknowledged[job.ID]; ok {
+				alert.Annotations["acknowledgment"] = acknowledgment.Note
+				suppressed[key] = alert
+				continue
+			}
+			firing[key] = alert
+		}
+	}
+
+	var resolved []models.Alert
+	for key, alert := range previous {
+		_, stillFiring := firing[key]
+		_, isSuppressed := suppressed[key]
+		if !stillFiring && !isSuppressed {
+			alert.EndsAt = &now
+			resolved = append(resolved, alert)
+		}
+	}
+
+	s.mu.Lock()
+	s.firing = firing
+	s.suppressed = suppressed
+	s.mu.Unlock()
+
+	if s.alertmanagerURL == "" {
+		return len(firing), nil
+	}
+	alerts := append(sortedAlerts(firing), resolved...)
+	if len(alerts) == 0 {
+		return 0, nil
+	}
+	if err := s.pushAlerts(alerts); err != nil {
+		return len(firing), err
+	}
+	return len(firing), nil
+}
+
+// GetFiringAlerts returns the alerts firing as of the last evaluation
+func (s *alertService) GetFiringAlerts() []models.Alert {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return sortedAlerts(s.firing)
+}
+
+// pushAlerts posts alerts to Alertmanager's v2 API
+func (s *alertService) pushAlerts(alerts []models.Alert) error {
+	body, err := json.Marshal(alerts)
+	if err != nil {
+		return fmt.Errorf("failed to encode alerts: %w", err)
+	}
+
+	resp, err := s.httpClient.Post(s.alertmanagerURL+"/api/v2/alerts", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to push alerts to Alertmanager: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("alertmanager returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// validateAlertRule checks a rule's metric, threshold and window
+func (s *alertService) validateAlertRule(rule *models.AlertRule) error {
+	if !models.IsValidAlertMetric(string(rule.Metric)) {
+		return fmt.Errorf("invalid alert metric: %s", rule.Metric)
+	}
+	if rule.Threshold < 0 {
+		return fmt.Errorf("threshold can't be negative")
+	}
+	if rule.Metric == models.AlertMetricFailureRate && rule.Threshold >= 100 {
+		return fmt.Errorf("failure_rate threshold is a percentage below 100")
+	}
+	if rule.Window < 1 || rule.Window > models.MaxAlertRuleWindow {
+		return fmt.Errorf("window must be between 1 and %d runs", models.MaxAlertRuleWindow)
+	}
+	if rule.JobID != nil {
+		if _, err := s.jobRepo.GetByID(*rule.JobID); err != nil {
+			return fmt.Errorf("invalid job: %w", err)
+		}
+	}
+	return nil
+}
+
+// measureAlertMetric returns the metric over a job's finished runs, newest first
+// It returns false when the runs don't measure it, e.g. lateness of jobs that only run on demand
+func measureAlertMetric(metric models.AlertMetric, runs []models.JobExecution) (float64, bool) {
+	if len(runs) == 0 {
+		return 0, false
+	}
+
+	switch metric {
+	case models.AlertMetricFailureRate:
+		return (1 - successRate(runs)) * 100, true
+	case models.AlertMetricLateness:
+		delay, ok := averageStartDelay(runs)
+		return delay.Seconds(), ok
+	case models.AlertMetricDuration:
+		var durations []int64
+		for _, run := range runs {
+			if run.ExecutionDuration != nil {
+				durations = append(durations, *run.ExecutionDuration)
+			}
+		}
+		if len(durations) == 0 {
+			return 0, false
+		}
+		return averageOf(durations) / 1000, true
+	default:
+		return 0, false
+	}
+}
+
+// newAlert builds the alert a rule fires for a job, labelled so Alertmanager can route and group it
+func newAlert(rule *models.AlertRule, job *models.Job, value float64, now time.Time) models.Alert {
+	labels := map[string]string{
+		"alertname": rule.Name,
+		"rule_id":   rule.ID.String(),
+		"metric":    string(rule.Metric),
+		"severity":  rule.Severity,
+		"job_id":    job.ID.String(),
+		"job_name":  job.Name,
+	}
+	if job.Team != "" {
+		labels["team"] = job.Team
+	}
+
+	annotations := map[string]string{
+		"summary": fmt.Sprintf("%s %s is %.1f, above %.1f over the last %d runs",
+			job.Name, rule.Metric, value, rule.Threshold, rule.Window),
+		"value": fmt.Sprintf("%.2f", value),
+	}
+	if rule.Description != "" {
+		annotations["description"] = rule.Description
+	}
+	if job.RunbookURL != "" {
+		annotations["runbook_url"] = job.RunbookURL
+	}
+
+	return models.Alert{
+		Labels:      labels,
+		Annotations: annotations,
+		StartsAt:    now,
+	}
+}
+
+// alertKey identifies the alert a rule fires for a job across evaluations
+func alertKey(rule *models.AlertRule, job *models.Job) string {
+	return rule.ID.String() + "/" + job.ID.String()
+}
+
+// sortedAlerts lists alerts by when they started firing, oldest first
+func sortedAlerts(alerts map[string]models.Alert) []models.Alert {
+	list := make([]models.Alert, 0, len(alerts))
+	for _, alert := range alerts {
+		list = append(list, alert)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].StartsAt.Equal(list[j].StartsAt) {
+			return list[i].Labels["alertname"]+list[i].Labels["job_id"] < list[j].Labels["alertname"]+list[j].Labels["job_id"]
+		}
+		return list[i].StartsAt.Before(list[j].StartsAt)
+	})
+	return list
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/notifications"
+	"job-scheduler/internal/repositories"
+)
+
+var (
+	// ErrNotAwaitingApproval is returned when approving a run that isn't waiting for approval
+	ErrNotAwaitingApproval = errors.New("run is not awaiting approval")
+	// ErrApprovalExpired is returned when approving a run after its approval window
+	ErrApprovalExpired = errors.New("run approval window has expired")
+)
+
+// ApprovedRunExecutor runs an approved execution in the background
+// It is implemented by the scheduler
+type ApprovedRunExecutor interface {
+	RunApproved(job *models.Job, execution *models.JobExecution) error
+}
+
+// ApprovalService defines the interface for run approval business logic
+type ApprovalService interface {
+	RequestApproval(job *models.Job, scheduledFor time.Time) (*models.JobExecution, error)
+	RequestTriggeredApproval(job *models.Job, params models.JobConfig) (*models.JobExecution, error)
+	GetPendingApprovals() ([]models.JobExecution, error)
+	Approve(executionID uuid.UUID, approver string) (*models.JobExecution, error)
+	Reject(executionID uuid.UUID, approver, reason string) (*models.JobExecution, error)
+	ExpireStale() (int, error)
+}
+
+// approvalService implements ApprovalService interface
+type approvalService struct {
+	executionRepo repositories.JobExecutionRepository
+	jobRepo       repositories.JobRepository
+	runner        ApprovedRunExecutor
+	notifier      notifications.Notifier
+	timeout       time.Duration
+}
+
+// NewApprovalService creates a new approval service
+func NewApprovalService(
+	executionRepo repositories.JobExecutionRepository,
+	jobRepo repositories.JobRepository,
+	runner ApprovedRunExecutor,
+	notifier notifications.Notifier,
+	timeout time.Duration,
+) ApprovalService {
+	return &approvalService{
+		executionRepo: executionRepo,
+		jobRepo:       jobRepo,
+		runner:        runner,
+		notifier:      notifier,
+		timeout:       timeout,
+	}
+}
+
+// RequestApproval creates a run of the cron occurrence at scheduledFor awaiting approval and notifies approvers
+func (s *approvalService) RequestApproval(job *models.Job, scheduledFor time.Time) (*models.JobExecution, error) {
+	return s.requestApproval(&models.JobExecution{
+		ID:           uuid.New(),
+		JobID:        job.ID,
+		ScheduledFor: &scheduledFor,
+	}, job)
+}
+
+// RequestTriggeredApproval creates a run triggered outside the schedule, e.g. by a webhook, awaiting
+// approval with the trigger's parameters, and notifies approvers
+func (s *approvalService) RequestTriggeredApproval(job *models.Job, params models.JobConfig) (*models.JobExecution, error) {
+	return s.requestApproval(&models.JobExecution{
+		ID:         uuid.New(),
+		JobID:      job.ID,
+		Parameters: params,
+	}, job)
+}
+
+// requestApproval saves the run awaiting approval and notifies approvers
+func (s *approvalService) requestApproval(execution *models.JobExecution, job *models.Job) (*models.JobExecution, error) {
+	if err := execution.MarkAsAwaitingApproval(time.Now().UTC().Add(s.timeout)); err != nil {
+		return nil, err
+	}
+
+	if err := s.executionRepo.Create(execution); err != nil {
+		return nil, fmt.Errorf("failed to create run awaiting approval: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":       job.ID,
+		"execution_id": execution.ID,
+		"expires_at":   execution.ApprovalExpiresAt,
+	}).Info("Run awaiting approval")
+
+	s.notify(notifications.Notification{
+		Event:     notifications.EventApprovalRequested,
+		Title:     fmt.Sprintf("Approval required: %s", job.Name),
+		Message:   fmt.Sprintf("Run %s is waiting for approval until %s", execution.ID, execution.ApprovalExpiresAt.Format(time.RFC3339)),
+		Job:       job,
+		Execution: execution,
+	})
+
+	return execution, nil
+}
+
+// GetPendingApprovals lists runs waiting for approval
+func (s *approvalService) GetPendingApprovals() ([]models.JobExecution, error) {
+	executions, err := s.executionRepo.GetAwaitingApproval()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending approvals: %w", err)
+	}
+	return executions, nil
+}
+
+// Approve records the approver and starts the run
+func (s *approvalService) Approve(executionID uuid.UUID, approver string) (*models.JobExecution, error) {
+	execution, err := s.getAwaiting(executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.jobRepo.GetByID(execution.JobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	if err := execution.MarkAsApproved(approver); err != nil {
+		return nil, err
+	}
+	// The approval is only saved while the run is still awaiting approval, so when approvers race
+	// exactly one of them starts it
+	if err := s.executionRepo.Update(execution); err != nil {
+		if errors.Is(err, models.ErrIllegalTransition) {
+			return nil, ErrNotAwaitingApproval
+		}
+		return nil, fmt.Errorf("failed to record approval: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":       job.ID,
+		"execution_id": execution.ID,
+		"approved_by":  approver,
+	}).Info("Run approved")
+
+	if err := s.runner.RunApproved(job, execution); err != nil {
+		// Fail the run rather than leave it approved with nothing to run it
+		if markErr := execution.MarkAsFailed(fmt.Sprintf("Failed to start approved run: %v", err)); markErr == nil {
+			if updateErr := s.executionRepo.Update(execution); updateErr != nil {
+				logrus.WithError(updateErr).WithField("execution_id", execution.ID).Error("Failed to record that the approved run didn't start")
+			}
+		}
+		return nil, fmt.Errorf("failed to start approved run: %w", err)
+	}
+
+	return execution, nil
+}
+
+// Reject cancels a run awaiting approval
+func (s *approvalService) Reject(executionID uuid.UUID, approver, reason string) (*models.JobExecution, error) {
+	execution, err := s.getAwaiting(executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := execution.MarkAsCancelled(); err != nil {
+		return nil, err
+	}
+	message := fmt.Sprintf("Rejected by %s", approver)
+	if reason != "" {
+		message = fmt.Sprintf("%s: %s", message, reason)
+	}
+	execution.ErrorMessage = models.NewCompressedText(message)
+
+	if err := s.executionRepo.Update(execution); err != nil {
+		if errors.Is(err, models.ErrIllegalTransition) {
+			return nil, ErrNotAwaitingApproval
+		}
+		return nil, fmt.Errorf("failed to record rejection: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":       execution.JobID,
+		"execution_id": execution.ID,
+		"rejected_by":  approver,
+	}).Info("Run rejected")
+
+	return execution, nil
+}
+
+// ExpireStale expires runs whose approval window has passed and returns how many were expired
+func (s *approvalService) ExpireStale() (int, error) {
+	executions, err := s.executionRepo.GetExpiredApprovals(time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired approvals: %w", err)
+	}
+
+	expired := 0
+	for i := range executions {
+		execution := &executions[i]
+		if err := execution.MarkAsExpired(); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"execution_id": execution.ID,
+				"error":        err,
+			}).Error("Failed to expire run awaiting approval")
+			continue
+		}
+		if err := s.executionRepo.Update(execution); err != nil {
+			// Approved or rejected since it was read
+			if errors.Is(err, models.ErrIllegalTransition) {
+				continue
+			}
+			logrus.WithFields(logrus.Fields{
+				"execution_id": execution.ID,
+				"error":        err,
+			}).Error("Failed to expire run awaiting approval")
+			continue
+		}
+		expired++
+
+		job := execution.Job
+		s.notify(notifications.Notification{
+			Event:     notifications.EventApprovalExpired,
+			Title:     fmt.Sprintf("Approval expired: %s", job.Name),
+			Message:   fmt.Sprintf("Run %s was not approved in time and will not execute", execution.ID),
+			Job:       &job,
+			Execution: execution,
+		})
+	}
+
+	return expired, nil
+}
+
+// getAwaiting loads an execution and checks it can still be approved or rejected
+func (s *approvalService) getAwaiting(executionID uuid.UUID) (*models.JobExecution, error) {
+	execution, err := s.executionRepo.GetByID(executionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run: %w", err)
+	}
+
+	if !execution.IsAwaitingApproval() {
+		return nil, ErrNotAwaitingApproval
+	}
+	if execution.ApprovalExpiresAt != nil && time.Now().UTC().After(*execution.ApprovalExpiresAt) {
+		return nil, ErrApprovalExpired
+	}
+
+	return execution, nil
+}
+
+// notify sends a notification, logging rather than failing on delivery errors
+func (s *approvalService) notify(n notifications.Notification) {
+	if s.notifier == nil {
+		return
+	}
+	n.Timestamp = time.Now().UTC()
+	if err := s.notifier.Notify(context.Background(), n); err != nil {
+		logrus.WithError(err).Warn("Failed to send notification")
+	}
+}
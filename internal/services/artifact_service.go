@@ -0,0 +1,467 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/artifacts"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+const (
+	// maxListedArtifacts caps how many artifacts are listed per job
+	maxListedArtifacts = 100
+	// purgeBatchSize is how many expired artifacts are deleted per query
+	purgeBatchSize = 100
+)
+
+var (
+	// ErrDownloadLinkInvalid is returned when a signed download link doesn't verify
+	ErrDownloadLinkInvalid = errors.New("download link is invalid")
+	// ErrDownloadLinkExpired is returned when a signed download link has expired
+	ErrDownloadLinkExpired = errors.New("download link has expired")
+	// ErrQuotaExceeded is returned when storing an artifact would exceed a storage quota
+	ErrQuotaExceeded = errors.New("artifact storage quota exceeded")
+	// ErrArtifactNotFound is returned when a run produced no artifact with the requested name
+	ErrArtifactNotFound = errors.New("artifact not found")
+)
+
+// ArtifactPolicy controls how long artifacts are kept and how much may be stored
+type ArtifactPolicy struct {
+	// URLExpiry is how long signed download URLs stay valid
+	URLExpiry time.Duration
+	// Retention is how long artifacts are kept unless a job overrides it; zero keeps them forever
+	Retention time.Duration
+	// JobQuotaBytes and TeamQuotaBytes cap the bytes stored per job and per team; zero is unlimited
+	JobQuotaBytes  int64
+	TeamQuotaBytes int64
+	// EvictionThreshold is the percentage of a quota above which the oldest artifacts are evicted
+	EvictionThreshold int
+}
+
+// ArtifactFile is a file written by an executor during a run
+type ArtifactFile struct {
+	Kind        models.ArtifactKind
+	Name        string
+	Path        string
+	ContentType string
+}
+
+// ArtifactExecutor is implemented by executors whose runs produce downloadable files
+type ArtifactExecutor interface {
+	ExecuteWithArtifacts(ctx context.Context, job *models.Job) ([]ArtifactFile, error)
+}
+
+// ArtifactService defines the interface for run artifacts and their downloads
+type ArtifactService interface {
+	RecordArtifacts(job *models.Job, execution *models.JobExecution, files []ArtifactFile) error
+	GetJobReports(jobID uuid.UUID) ([]models.Artifact, error)
+	GetExecutionArtifacts(executionID uuid.UUID) ([]models.Artifact, error)
+	GetDownloadURL(id uuid.UUID) (string, time.Time, error)
+	GetExecutionArtifactURL(executionID uuid.UUID, name string) (*models.Artifact, string, time.Time, error)
+	OpenSignedDownload(id uuid.UUID, expires, signature string) (*models.Artifact, io.ReadCloser, error)
+	OpenArtifact(ctx context.Context, id uuid.UUID) (*models.Artifact, io.ReadCloser, error)
+	PurgeExpired() (int, error)
+	EvictOverQuota() (int, error)
+	GetStorageUsage(topJobs int) (*models.StorageUsage, error)
+}
+
+// artifactService implements ArtifactService interface
+type artifactService struct {
+	jobRepo      repositories.JobRepository
+	artifactRepo repositories.ArtifactRepository
+	store        artifacts.ArtifactStore
+	policy       ArtifactPolicy
+	httpClient   *http.Client
+}
+
+// NewArtifactService creates a new artifact service
+func NewArtifactService(
+	jobRepo repositories.JobRepository,
+	artifactRepo repositories.ArtifactRepository,
+	store artifacts.ArtifactStore,
+	policy ArtifactPolicy,
+) ArtifactService {
+	if policy.EvictionThreshold < 1 || policy.EvictionThreshold > 100 {
+		policy.EvictionThreshold = 90
+	}
+
+	return &artifactService{
+		jobRepo:      jobRepo,
+		artifactRepo: artifactRepo,
+		store:        store,
+		policy:       policy,
+		httpClient:   &http.Client{Timeout: time.Minute},
+	}
+}
+
+// RecordArtifacts moves a run's files into the artifact store and records them
+func (s *artifactService) RecordArtifacts(job *models.Job, execution *models.JobExecution, files []ArtifactFile) error {
+	expiresAt := s.expiresAt(job)
+
+	for _, file := range files {
+		kind := file.Kind
+		if kind == "" {
+			kind = models.ArtifactKindReport
+		}
+
+		info, err := os.Stat(file.Path)
+		if err != nil {
+			return fmt.Errorf("failed to stat artifact %s: %w", file.Name, err)
+		}
+		if err := s.checkQuota(job, info.Size()); err != nil {
+			return fmt.Errorf("cannot store artifact %s: %w", file.Name, err)
+		}
+
+		artifact := &models.Artifact{
+			ID:          uuid.New(),
+			JobID:       job.ID,
+			ExecutionID: execution.ID,
+			Kind:        kind,
+			Name:        file.Name,
+			StorageKey:  path.Join(job.ID.String(), execution.ID.String(), file.Name),
+			ContentType: file.ContentType,
+			SizeBytes:   info.Size(),
+			ExpiresAt:   expiresAt,
+		}
+
+		if err := s.store.Put(context.Background(), artifact.StorageKey, file.Path, artifact.ContentType); err != nil {
+			return fmt.Errorf("failed to store artifact %s: %w", file.Name, err)
+		}
+		if err := s.artifactRepo.Create(artifact); err != nil {
+			return fmt.Errorf("failed to record artifact %s: %w", file.Name, err)
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"artifact_id":  artifact.ID,
+			"job_id":       job.ID,
+			"execution_id": execution.ID,
+			"kind":         artifact.Kind,
+			"name":         artifact.Name,
+		}).Info("Artifact recorded")
+	}
+	return nil
+}
+
+// GetJobReports lists a job's most recent report artifacts, newest first
+func (s *artifactService) GetJobReports(jobID uuid.UUID) ([]models.Artifact, error) {
+	if _, err := s.jobRepo.GetByID(jobID); err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	artifactList, err := s.artifactRepo.GetByJobID(jobID, models.ArtifactKindReport, maxListedArtifacts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifacts: %w", err)
+	}
+	return artifactList, nil
+}
+
+// GetExecutionArtifacts lists every artifact an execution produced
+func (s *artifactService) GetExecutionArtifacts(executionID uuid.UUID) ([]models.Artifact, error) {
+	artifactList, err := s.artifactRepo.GetByExecutionID(executionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifacts: %w", err)
+	}
+	return artifactList, nil
+}
+
+// GetDownloadURL returns a signed URL that downloads the artifact until it expires
+func (s *artifactService) GetDownloadURL(id uuid.UUID) (string, time.Time, error) {
+	artifact, err := s.artifactRepo.GetByID(id)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to get artifact: %w", err)
+	}
+	return s.signedURL(artifact)
+}
+
+// GetExecutionArtifactURL finds an execution's artifact by file name and returns it with a signed
+// URL that downloads it until it expires
+func (s *artifactService) GetExecutionArtifactURL(executionID uuid.UUID, name string) (*models.Artifact, string, time.Time, error) {
+	artifactList, err := s.artifactRepo.GetByExecutionID(executionID)
+	if err != nil {
+		return nil, "", time.Time{}, fmt.Errorf("failed to get artifacts: %w", err)
+	}
+
+	for i := range artifactList {
+		if artifactList[i].Name != name {
+			continue
+		}
+		url, expiresAt, err := s.signedURL(&artifactList[i])
+		if err != nil {
+			return nil, "", time.Time{}, err
+		}
+		return &artifactList[i], url, expiresAt, nil
+	}
+	return nil, "", time.Time{}, fmt.Errorf("%w: %s", ErrArtifactNotFound, name)
+}
+
+// signedURL signs a download URL for the artifact, valid for the configured expiry
+func (s *artifactService) signedURL(artifact *models.Artifact) (string, time.Time, error) {
+	expiresAt := time.Now().UTC().Add(s.policy.URLExpiry)
+	url, err := s.store.SignedURL(artifact, expiresAt)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign download URL: %w", err)
+	}
+	return url, expiresAt, nil
+}
+
+// OpenSignedDownload verifies a locally signed download link and opens the artifact
+// Object storage links are served by the storage provider, never by this service
+func (s *artifactService) OpenSignedDownload(id uuid.UUID, expires, signature string) (*models.Artifact, io.ReadCloser, error) {
+	local, ok := s.store.(*artifacts.LocalStore)
+	if !ok {
+		return nil, nil, ErrDownloadLinkInvalid
+	}
+
+	switch err := local.Verify(id, expires, signature, time.Now()); {
+	case errors.Is(err, artifacts.ErrURLExpired):
+		return nil, nil, ErrDownloadLinkExpired
+	case err != nil:
+		return nil, nil, ErrDownloadLinkInvalid
+	}
+
+	artifact, err := s.artifactRepo.GetByID(id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get artifact: %w", err)
+	}
+
+	file, err := local.Open(artifact.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return artifact, file, nil
+}
+
+// OpenArtifact opens a stored artifact for reading, such as a CSV file listing an email job's recipients
+// Artifacts in object storage are downloaded through a signed URL
+func (s *artifactService) OpenArtifact(ctx context.Context, id uuid.UUID) (*models.Artifact, io.ReadCloser, error) {
+	artifact, err := s.artifactRepo.GetByID(id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get artifact: %w", err)
+	}
+
+	if local, ok := s.store.(*artifacts.LocalStore); ok {
+		file, err := local.Open(artifact.StorageKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		return artifact, file, nil
+	}
+
+	url, _, err := s.signedURL(artifact)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download artifact: %w", err)
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download artifact: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, nil, fmt.Errorf("artifact download returned status %d", resp.StatusCode)
+	}
+	return artifact, resp.Body, nil
+}
+
+// PurgeExpired deletes artifacts whose retention has ended and returns how many were deleted
+func (s *artifactService) PurgeExpired() (int, error) {
+	purged := 0
+	for {
+		expired, err := s.artifactRepo.GetExpired(time.Now().UTC(), purgeBatchSize)
+		if err != nil {
+			return purged, fmt.Errorf("failed to get expired artifacts: %w", err)
+		}
+		if len(expired) == 0 {
+			return purged, nil
+		}
+
+		for i := range expired {
+			artifact := &expired[i]
+			// Keep the row when the file can't be removed so the purge is retried
+			if err := s.deleteArtifact(artifact); err != nil {
+				return purged, err
+			}
+			purged++
+		}
+	}
+}
+
+// expiresAt returns when a job's artifacts expire
+// config["artifact_retention_days"] overrides the default retention, with 0 keeping them forever
+func (s *artifactService) expiresAt(job *models.Job) *time.Time {
+	retention := s.policy.Retention
+	if days, ok := job.Config["artifact_retention_days"].(float64); ok && days >= 0 {
+		retention = time.Duration(days * float64(24*time.Hour))
+	}
+	if retention <= 0 {
+		return nil
+	}
+
+	expiresAt := time.Now().UTC().Add(retention)
+	return &expiresAt
+}
+
+// EvictOverQuota deletes the oldest artifacts of jobs and teams whose usage is above the
+// eviction threshold, until they are back under it, and returns how many were evicted
+func (s *artifactService) EvictOverQuota() (int, error) {
+	evicted := 0
+
+	if s.policy.JobQuotaBytes > 0 {
+		usage, err := s.artifactRepo.GetUsageByJob(0)
+		if err != nil {
+			return evicted, fmt.Errorf("failed to get artifact usage: %w", err)
+		}
+		for _, job := range usage {
+			jobID := job.JobID
+			n, err := s.evictOldest(job.Bytes, s.policy.JobQuotaBytes, func(limit int) ([]models.Artifact, error) {
+				return s.artifactRepo.GetOldestByJob(jobID, limit)
+			})
+			evicted += n
+			if err != nil {
+				return evicted, err
+			}
+			if n > 0 {
+				logrus.WithFields(logrus.Fields{
+					"job_id":  jobID,
+					"evicted": n,
+				}).Info("Evicted artifacts of job over its storage quota")
+			}
+		}
+	}
+
+	if s.policy.TeamQuotaBytes > 0 {
+		usage, err := s.artifactRepo.GetUsageByTeam()
+		if err != nil {
+			return evicted, fmt.Errorf("failed to get artifact usage: %w", err)
+		}
+		for _, team := range usage {
+			teamName := team.Team
+			n, err := s.evictOldest(team.Bytes, s.policy.TeamQuotaBytes, func(limit int) ([]models.Artifact, error) {
+				return s.artifactRepo.GetOldestByTeam(teamName, limit)
+			})
+			evicted += n
+			if err != nil {
+				return evicted, err
+			}
+			if n > 0 {
+				logrus.WithFields(logrus.Fields{
+					"team":    teamName,
+					"evicted": n,
+				}).Info("Evicted artifacts of team over its storage quota")
+			}
+		}
+	}
+
+	return evicted, nil
+}
+
+// GetStorageUsage summarizes artifact storage for the dashboard
+func (s *artifactService) GetStorageUsage(topJobs int) (*models.StorageUsage, error) {
+	jobs, err := s.artifactRepo.GetUsageByJob(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact usage: %w", err)
+	}
+	teams, err := s.artifactRepo.GetUsageByTeam()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact usage: %w", err)
+	}
+
+	usage := &models.StorageUsage{
+		JobQuotaBytes:  s.policy.JobQuotaBytes,
+		TeamQuotaBytes: s.policy.TeamQuotaBytes,
+		TopJobs:        jobs,
+		Teams:          teams,
+	}
+	for _, job := range jobs {
+		usage.TotalBytes += job.Bytes
+	}
+	if len(usage.TopJobs) > topJobs {
+		usage.TopJobs = usage.TopJobs[:topJobs]
+	}
+	if usage.TopJobs == nil {
+		usage.TopJobs = []models.ArtifactUsage{}
+	}
+	if usage.Teams == nil {
+		usage.Teams = []models.TeamArtifactUsage{}
+	}
+	return usage, nil
+}
+
+// checkQuota rejects an artifact that would take its job or team over quota
+func (s *artifactService) checkQuota(job *models.Job, size int64) error {
+	if s.policy.JobQuotaBytes > 0 {
+		used, err := s.artifactRepo.SumBytesByJob(job.ID)
+		if err != nil {
+			return fmt.Errorf("failed to check job quota: %w", err)
+		}
+		if used+size > s.policy.JobQuotaBytes {
+			return fmt.Errorf("%w: job %s would use %d of %d bytes", ErrQuotaExceeded, job.Name, used+size, s.policy.JobQuotaBytes)
+		}
+	}
+
+	if s.policy.TeamQuotaBytes > 0 && job.Team != "" {
+		used, err := s.artifactRepo.SumBytesByTeam(job.Team)
+		if err != nil {
+			return fmt.Errorf("failed to check team quota: %w", err)
+		}
+		if used+size > s.policy.TeamQuotaBytes {
+			return fmt.Errorf("%w: team %s would use %d of %d bytes", ErrQuotaExceeded, job.Team, used+size, s.policy.TeamQuotaBytes)
+		}
+	}
+	return nil
+}
+
+// evictOldest deletes the oldest artifacts returned by oldest until used is back under
+// the eviction threshold of quota, and returns how many were deleted
+func (s *artifactService) evictOldest(used, quota int64, oldest func(limit int) ([]models.Artifact, error)) (int, error) {
+	target := quota * int64(s.policy.EvictionThreshold) / 100
+	evicted := 0
+
+	for used > target {
+		batch, err := oldest(purgeBatchSize)
+		if err != nil {
+			return evicted, fmt.Errorf("failed to get oldest artifacts: %w", err)
+		}
+		if len(batch) == 0 {
+			return evicted, nil
+		}
+
+		for i := range batch {
+			if used <= target {
+				break
+			}
+			if err := s.deleteArtifact(&batch[i]); err != nil {
+				return evicted, err
+			}
+			used -= batch[i].SizeBytes
+			evicted++
+		}
+	}
+	return evicted, nil
+}
+
+// deleteArtifact removes an artifact's file and then its record
+// The record is kept when the file can't be removed so the deletion is retried
+func (s *artifactService) deleteArtifact(artifact *models.Artifact) error {
+	if err := s.store.Delete(context.Background(), artifact.StorageKey); err != nil {
+		return fmt.Errorf("failed to delete artifact %s: %w", artifact.ID, err)
+	}
+	if err := s.artifactRepo.Delete(artifact.ID); err != nil {
+		return fmt.Errorf("failed to delete artifact %s: %w", artifact.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"job-scheduler/internal/models"
+)
+
+// ErrCallBudgetExceeded is returned for external calls beyond a job's call budget
+var ErrCallBudgetExceeded = errors.New("external call budget exceeded")
+
+// Call budget actions once the budget is used up
+const (
+	BudgetExceededFail  = "fail"
+	BudgetExceededPause = "pause"
+)
+
+// CallBudget limits the external calls a run of a job may make, declared in the job's config as
+//
+//	"call_budget": {"max_calls": 1000, "window": "1m", "on_exceeded": "pause"}
+//
+// Without a window the budget covers the whole run. Once it is used up, further calls fail the
+// run, or with "pause" wait until the window refills
+type CallBudget struct {
+	MaxCalls   int
+	Window     time.Duration
+	OnExceeded string
+}
+
+// ParseCallBudget reads the job's call budget from its config; it returns nil when none is declared
+func ParseCallBudget(config models.JobConfig) (*CallBudget, error) {
+	raw, ok := config["call_budget"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	settings, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("call_budget must be an object")
+	}
+
+	budget := &CallBudget{OnExceeded: BudgetExceededFail}
+
+	maxCalls, ok := settings["max_calls"].(float64)
+	if !ok || maxCalls < 1 || maxCalls != float64(int(maxCalls)) {
+		return nil, fmt.Errorf("call_budget.max_calls must be a positive integer")
+	}
+	budget.MaxCalls = int(maxCalls)
+
+	if window, ok := settings["window"].(string); ok && window != "" {
+		duration, err := time.ParseDuration(window)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("invalid call_budget.window: %s", window)
+		}
+		budget.Window = duration
+	}
+
+	if action, ok := settings["on_exceeded"].(string); ok && action != "" {
+		if action != BudgetExceededFail && action != BudgetExceededPause {
+			return nil, fmt.Errorf("call_budget.on_exceeded must be %q or %q", BudgetExceededFail, BudgetExceededPause)
+		}
+		budget.OnExceeded = action
+	}
+	if budget.OnExceeded == BudgetExceededPause && budget.Window == 0 {
+		return nil, fmt.Errorf("call_budget.window is required to pause when the budget is exceeded")
+	}
+
+	return budget, nil
+}
+
+// Client returns a client that counts its requests against the budget
+// Each call starts a fresh budget, so call it once per run
+func (b *CallBudget) Client(base *http.Client) *http.Client {
+	next := base.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	client := *base
+	client.Transport = &budgetTransport{
+		next:   next,
+		budget: *b,
+		now:    time.Now,
+	}
+	return &client
+}
+
+// budgetTransport enforces a call budget on outgoing requests
+type budgetTransport struct {
+	next   http.RoundTripper
+	budget CallBudget
+	now    func() time.Time
+
+	mu          sync.Mutex
+	used        int
+	windowStart time.Time
+}
+
+// RoundTrip sends the request if the budget allows it
+func (t *budgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for {
+		wait, err := t.take()
+		if err != nil {
+			return nil, err
+		}
+		if wait == 0 {
+			return t.next.RoundTrip(req)
+		}
+
+		// Pause until the window refills
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// take uses one call from the budget, or returns how long to wait for the window to refill
+func (t *budgetTransport) take() (time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	if t.windowStart.IsZero() {
+		t.windowStart = now
+	}
+	if t.budget.Window > 0 && now.Sub(t.windowStart) >= t.budget.Window {
+		t.windowStart = now
+		t.used = 0
+	}
+
+	if t.used < t.budget.MaxCalls {
+		t.used++
+		return 0, nil
+	}
+
+	if t.budget.OnExceeded == BudgetExceededPause {
+		return t.windowStart.Add(t.budget.Window).Sub(now), nil
+	}
+	return 0, fmt.Errorf("%w: %d calls allowed", ErrCallBudgetExceeded, t.budget.MaxCalls)
+}
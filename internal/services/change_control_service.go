@@ -0,0 +1,302 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+var (
+	// ErrActorRequired is returned when a protected change is requested anonymously
+	ErrActorRequired = errors.New("changes to protected jobs require an identified user")
+	// ErrSelfApproval is returned when the requester tries to approve their own change
+	ErrSelfApproval = errors.New("a change must be approved by someone other than its requester")
+	// ErrChangeNotPending is returned when reviewing a change that was already reviewed
+	ErrChangeNotPending = errors.New("change is not pending")
+)
+
+// Audit entity types and actions for job change control
+const (
+	AuditEntityJob = "job"
+
+	AuditActionChangeRequested = "change_requested"
+	AuditActionChangeApproved  = "change_approved"
+	AuditActionChangeRejected  = "change_rejected"
+)
+
+// ChangeControlService enforces the two-person rule for destructive changes to protected jobs
+type ChangeControlService interface {
+	ProposeUpdate(jobID uuid.UUID, req *models.UpdateJobRequest, actor string) (*models.PendingChange, error)
+	ProposeDelete(jobID uuid.UUID, actor string) (*models.PendingChange, error)
+	GetPendingChanges() ([]models.PendingChange, error)
+	Approve(changeID uuid.UUID, approver string) (*models.PendingChange, error)
+	Reject(changeID uuid.UUID, reviewer, reason string) (*models.PendingChange, error)
+}
+
+// changeControlService implements ChangeControlService interface
+type changeControlService struct {
+	jobService JobService
+	jobRepo    repositories.JobRepository
+	changeRepo repositories.PendingChangeRepository
+	auditRepo  repositories.AuditRepository
+	enabled    bool
+}
+
+// NewChangeControlService creates a new change control service
+// When enabled is false every change is applied directly
+func NewChangeControlService(
+	jobService JobService,
+	jobRepo repositories.JobRepository,
+	changeRepo repositories.PendingChangeRepository,
+	auditRepo repositories.AuditRepository,
+	enabled bool,
+) ChangeControlService {
+	return &changeControlService{
+		jobService: jobService,
+		jobRepo:    jobRepo,
+		changeRepo: changeRepo,
+		auditRepo:  auditRepo,
+		enabled:    enabled,
+	}
+}
+
+// ProposeUpdate queues the update for approval if it is destructive and the job is protected
+// It returns nil when the update doesn't need a second approver
+func (s *changeControlService) ProposeUpdate(jobID uuid.UUID, req *models.UpdateJobRequest, actor string) (*models.PendingChange, error) {
+	if !s.enabled {
+		return nil, nil
+	}
+
+	job, err := s.jobRepo.GetByID(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if !job.IsProtected() || !isDestructiveUpdate(job, req) {
+		return nil, nil
+	}
+
+	payload, err := toJobConfig(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode change: %w", err)
+	}
+
+	return s.queue(job, models.PendingChangeActionUpdate, payload, actor)
+}
+
+// ProposeDelete queues the deletion for approval if the job is protected
+// It returns nil when the deletion doesn't need a second approver
+func (s *changeControlService) ProposeDelete(jobID uuid.UUID, actor string) (*models.PendingChange, error) {
+	if !s.enabled {
+		return nil, nil
+	}
+
+	job, err := s.jobRepo.GetByID(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if !job.IsProtected() {
+		return nil, nil
+	}
+
+	return s.queue(job, models.PendingChangeActionDelete, nil, actor)
+}
+
+// GetPendingChanges lists changes waiting for a second approver
+func (s *changeControlService) GetPendingChanges() ([]models.PendingChange, error) {
+	changes, err := s.changeRepo.GetPending()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending changes: %w", err)
+	}
+	return changes, nil
+}
+
+// Approve applies a pending change on behalf of a second person
+func (s *changeControlService) Approve(changeID uuid.UUID, approver string) (*models.PendingChange, error) {
+	change, err := s.getReviewable(changeID, approver)
+	if err != nil {
+		return nil, err
+	}
+	if change.RequestedBy == approver {
+		return nil, ErrSelfApproval
+	}
+
+	switch change.Action {
+	case models.PendingChangeActionUpdate:
+		var req models.UpdateJobRequest
+		if err := fromJobConfig(change.Payload, &req); err != nil {
+			return nil, fmt.Errorf("failed to decode change: %w", err)
+		}
+		req.ChangedBy = change.RequestedBy
+		if _, err := s.jobService.UpdateJob(change.JobID, &req); err != nil {
+			return nil, fmt.Errorf("failed to apply change: %w", err)
+		}
+	case models.PendingChangeActionDelete:
+		if err := s.jobService.DeleteJob(change.JobID); err != nil {
+			return nil, fmt.Errorf("failed to apply change: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown change action: %s", change.Action)
+	}
+
+	change.MarkReviewed(models.PendingChangeStatusApproved, approver, "")
+	if err := s.changeRepo.Update(change); err != nil {
+		return nil, fmt.Errorf("failed to record approval: %w", err)
+	}
+
+	s.audit(change, AuditActionChangeApproved, approver, nil)
+
+	logrus.WithFields(logrus.Fields{
+		"change_id":    change.ID,
+		"job_id":       change.JobID,
+		"requested_by": change.RequestedBy,
+		"approved_by":  approver,
+	}).Info("Protected job change approved and applied")
+
+	return change, nil
+}
+
+// Reject discards a pending change - requesters may withdraw their own changes
+func (s *changeControlService) Reject(changeID uuid.UUID, reviewer, reason string) (*models.PendingChange, error) {
+	change, err := s.getReviewable(changeID, reviewer)
+	if err != nil {
+		return nil, err
+	}
+
+	change.MarkReviewed(models.PendingChangeStatusRejected, reviewer, reason)
+	if err := s.changeRepo.Update(change); err != nil {
+		return nil, fmt.Errorf("failed to record rejection: %w", err)
+	}
+
+	s.audit(change, AuditActionChangeRejected, reviewer, models.JobConfig{"reason": reason})
+
+	logrus.WithFields(logrus.Fields{
+		"change_id":   change.ID,
+		"job_id":      change.JobID,
+		"rejected_by": reviewer,
+	}).Info("Protected job change rejected")
+
+	return change, nil
+}
+
+// queue stores a pending change and audits the request
+func (s *changeControlService) queue(job *models.Job, action models.PendingChangeAction, payload models.JobConfig, actor string) (*models.PendingChange, error) {
+	if actor == "" {
+		return nil, ErrActorRequired
+	}
+
+	change := &models.PendingChange{
+		ID:          uuid.New(),
+		JobID:       job.ID,
+		Action:      action,
+		Payload:     payload,
+		RequestedBy: actor,
+		Status:      models.PendingChangeStatusPending,
+	}
+
+	if err := s.changeRepo.Create(change); err != nil {
+		return nil, fmt.Errorf("failed to queue change: %w", err)
+	}
+
+	s.audit(change, AuditActionChangeRequested, actor, payload)
+
+	logrus.WithFields(logrus.Fields{
+		"change_id":    change.ID,
+		"job_id":       job.ID,
+		"action":       action,
+		"requested_by": actor,
+	}).Info("Protected job change queued for approval")
+
+	return change, nil
+}
+
+// getReviewable loads a change and checks the reviewer may act on it
+func (s *changeControlService) getReviewable(changeID uuid.UUID, reviewer string) (*models.PendingChange, error) {
+	if reviewer == "" {
+		return nil, ErrActorRequired
+	}
+
+	change, err := s.changeRepo.GetByID(changeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get change: %w", err)
+	}
+	if change.Status != models.PendingChangeStatusPending {
+		return nil, ErrChangeNotPending
+	}
+	return change, nil
+}
+
+// audit records an audit entry against the change's job
+func (s *changeControlService) audit(change *models.PendingChange, action, actor string, details models.JobConfig) {
+	entryDetails := models.JobConfig{}
+	for k, v := range details {
+		entryDetails[k] = v
+	}
+	entryDetails["change_id"] = change.ID.String()
+	entryDetails["change_action"] = string(change.Action)
+
+	entry := &models.AuditEntry{
+		EntityType: AuditEntityJob,
+		EntityID:   change.JobID,
+		Action:     action,
+		Actor:      actor,
+		Details:    entryDetails,
+	}
+	if err := s.auditRepo.Create(entry); err != nil {
+		logrus.WithError(err).Error("Failed to write audit entry")
+	}
+}
+
+// isDestructiveUpdate reports whether an update enables the job, changes its
+// schedule, run_at, cron format or config, or removes its protection
+func isDestructiveUpdate(job *models.Job, req *models.UpdateJobRequest) bool {
+	if req.Schedule != nil && *req.Schedule != job.Schedule {
+		return true
+	}
+	if req.ScheduleType != nil && *req.ScheduleType != job.ScheduleType {
+		return true
+	}
+	if req.RunAt != nil && (job.RunAt == nil || !req.RunAt.Equal(*job.RunAt)) {
+		return true
+	}
+	if req.CronSeconds != nil && *req.CronSeconds != job.CronSeconds {
+		return true
+	}
+	if req.Config != nil {
+		return true
+	}
+	if state, _ := requestedStateChange(job, req); state != nil && *state == models.JobStateActive {
+		return true
+	}
+	if req.Tags != nil && !req.Tags.Contains(models.ProtectedTag) {
+		return true
+	}
+	return false
+}
+
+// toJobConfig round-trips a value through JSON into a JobConfig
+func toJobConfig(v interface{}) (models.JobConfig, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var config models.JobConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// fromJobConfig round-trips a JobConfig through JSON into v
+func fromJobConfig(config models.JobConfig, v interface{}) error {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
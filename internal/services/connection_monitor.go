@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/integrations"
+	"job-scheduler/internal/models"
+)
+
+// ConnectionProbe checks an external connection answers
+type ConnectionProbe func(ctx context.Context) error
+
+// ConnectionMonitor defines the interface for probing the external connections jobs depend on
+type ConnectionMonitor interface {
+	// AddProbe probes a connection the integrations manager doesn't own, such as the S3 artifact bucket
+	AddProbe(name string, probe ConnectionProbe)
+	ProbeConnections(ctx context.Context)
+	GetConnectionHealth() []models.ConnectionHealth
+	// Unavailable reports whether the named connection is known to be down, and why
+	Unavailable(name string) (string, bool)
+}
+
+// connectionMonitor implements ConnectionMonitor interface
+type connectionMonitor struct {
+	integrations        *integrations.Manager
+	databaseConnections DatabaseConnectionService
+	cfg                 config.ConnectionHealthConfig
+	mu                  sync.Mutex
+	probes              map[string]ConnectionProbe
+	health              map[string]*models.ConnectionHealth
+}
+
+// NewConnectionMonitor creates a connection monitor probing every integration the manager owns
+// and every registered database; databaseConnections may be nil when no databases are registered
+func NewConnectionMonitor(manager *integrations.Manager, databaseConnections DatabaseConnectionService, cfg config.ConnectionHealthConfig) ConnectionMonitor {
+	return &connectionMonitor{
+		integrations:        manager,
+		databaseConnections: databaseConnections,
+		cfg:                 cfg,
+		probes:              make(map[string]ConnectionProbe),
+		health:              make(map[string]*models.ConnectionHealth),
+	}
+}
+
+// JobDependencies returns the names of the connections a job's runs use
+func JobDependencies(job *models.Job) []string {
+	var dependencies []string
+	if name := JobConnection(job); name != "" {
+		dependencies = append(dependencies, integrations.DatabaseIntegration(name))
+	}
+	if job.JobType == models.JobTypeEmailNotification {
+		dependencies = append(dependencies, integrations.SMTPIntegration)
+	}
+	return dependencies
+}
+
+// AddProbe adds a connection to probe, replacing any probe added under the same name
+func (m *connectionMonitor) AddProbe(name string, probe ConnectionProbe) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.probes[name] = probe
+}
+
+// ProbeConnections probes every connection concurrently, each bounded by the probe timeout
+// Connections that are no longer registered stop being reported
+func (m *connectionMonitor) ProbeConnections(ctx context.Context) {
+	probes, complete := m.connectionProbes()
+
+	var wg sync.WaitGroup
+	for name, probe := range probes {
+		wg.Add(1)
+		go func(name string, probe ConnectionProbe) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, m.cfg.Timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := probe(probeCtx)
+			if ctx.Err() != nil {
+				// Shutting down, not a failure of the connection
+				return
+			}
+			m.record(name, time.Since(start), err)
+		}(name, probe)
+	}
+	wg.Wait()
+
+	if !complete {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for name := range m.health {
+		if _, ok := probes[name]; !ok {
+			delete(m.health, name)
+		}
+	}
+}
+
+// connectionProbes returns the probe of every known connection
+// complete is false if the registered databases couldn't be listed
+func (m *connectionMonitor) connectionProbes() (map[string]ConnectionProbe, bool) {
+	probes := make(map[string]ConnectionProbe)
+	m.mu.Lock()
+	for name, probe := range m.probes {
+		probes[name] = probe
+	}
+	m.mu.Unlock()
+
+	if m.integrations != nil {
+		for _, name := range m.integrations.Names() {
+			// Registered databases are probed through the registry, which knows which are still registered
+			if integrations.IsDatabaseIntegration(name) {
+				continue
+			}
+			name := name
+			probes[name] = func(ctx context.Context) error {
+				return m.integrations.Probe(ctx, name)
+			}
+		}
+	}
+
+	if m.databaseConnections == nil {
+		return probes, true
+	}
+	connections, err := m.databaseConnections.GetDatabaseConnections()
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to list database connections to probe")
+		return probes, false
+	}
+	for _, connection := range connections {
+		name := connection.Name
+		probes[integrations.DatabaseIntegration(name)] = func(ctx context.Context) error {
+			db, err := m.databaseConnections.Database(ctx, name)
+			if err != nil {
+				return err
+			}
+			return db.PingContext(ctx)
+		}
+	}
+	return probes, true
+}
+
+// record updates a connection's health with the outcome of a probe
+// A connection goes down after FailureThreshold failed probes in a row and up on its first success
+func (m *connectionMonitor) record(name string, latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	health, ok := m.health[name]
+	if !ok {
+		health = &models.ConnectionHealth{Name: name, Status: models.ConnectionStatusUnknown}
+		m.health[name] = health
+	}
+
+	now := time.Now().UTC()
+	health.Probes++
+	health.LastCheckedAt = &now
+	health.LastLatencyMs = latency.Milliseconds()
+
+	if err == nil {
+		if health.Status == models.ConnectionStatusDown {
+			logrus.WithField("connection", name).Info("Connection recovered")
+		}
+		if health.Status != models.ConnectionStatusUp {
+			health.Status = models.ConnectionStatusUp
+			health.ChangedAt = &now
+		}
+		health.ConsecutiveFailures = 0
+		health.LastError = ""
+		return
+	}
+
+	health.Failures++
+	health.ConsecutiveFailures++
+	health.LastError = err.Error()
+	if health.Status != models.ConnectionStatusDown && health.ConsecutiveFailures >= m.cfg.FailureThreshold {
+		health.Status = models.ConnectionStatusDown
+		health.ChangedAt = &now
+		logrus.WithFields(logrus.Fields{
+			"connection": name,
+			"failures":   health.ConsecutiveFailures,
+			"error":      err,
+		}).Warn("Connection is down - runs of jobs using it will fail fast")
+	}
+}
+
+// GetConnectionHealth returns the health of every probed connection, sorted by name
+func (m *connectionMonitor) GetConnectionHealth() []models.ConnectionHealth {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	list := make([]models.ConnectionHealth, 0, len(m.health))
+	for _, health := range m.health {
+		list = append(list, *health)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// Unavailable reports whether the named connection is known to be down, with its last probe error
+// Connections that were never probed are assumed to be available
+func (m *connectionMonitor) Unavailable(name string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	health, ok := m.health[name]
+	if !ok || health.Status != models.ConnectionStatusDown {
+		return "", false
+	}
+	return health.LastError, true
+}
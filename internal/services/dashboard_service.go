@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+// DashboardService defines the interface for the on-call dashboard
+type DashboardService interface {
+	GetDashboard(failureLimit int) (*models.Dashboard, error)
+	GetFailingJobs() ([]models.FailingJob, error)
+	SetAcknowledgments(acknowledgments AcknowledgmentLookup)
+}
+
+// dashboardService implements DashboardService interface
+type dashboardService struct {
+	jobRepo       repositories.JobRepository
+	executionRepo repositories.JobExecutionRepository
+	artifacts     ArtifactService
+	// acknowledgments annotates failures with operators' notes; nil without acknowledgments
+	acknowledgments AcknowledgmentLookup
+}
+
+const (
+	// dashboardTopStorageJobs is how many of the largest artifact consumers the dashboard lists
+	dashboardTopStorageJobs = 10
+	// dashboardUnhealthiestJobs is how many of the lowest scoring jobs the dashboard lists
+	dashboardUnhealthiestJobs = 10
+)
+
+// NewDashboardService creates a new dashboard service
+// artifacts may be nil, in which case the dashboard has no storage usage
+func NewDashboardService(
+	jobRepo repositories.JobRepository,
+	executionRepo repositories.JobExecutionRepository,
+	artifacts ArtifactService,
+) DashboardService {
+	return &dashboardService{
+		jobRepo:       jobRepo,
+		executionRepo: executionRepo,
+		artifacts:     artifacts,
+	}
+}
+
+// SetAcknowledgments shows operators' acknowledgments of failing jobs alongside their failures
+func (s *dashboardService) SetAcknowledgments(acknowledgments AcknowledgmentLookup) {
+	s.acknowledgments = acknowledgments
+}
+
+// activeAcknowledgments returns the acknowledgment suppressing alerts in effect for each acknowledged job
+func (s *dashboardService) activeAcknowledgments() (map[uuid.UUID]models.JobAcknowledgment, error) {
+	if s.acknowledgments == nil {
+		return nil, nil
+	}
+	acknowledged, err := s.acknowledgments.GetActiveAcknowledgments(models.AcknowledgmentScopeAlerts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job acknowledgments: %w", err)
+	}
+	return acknowledged, nil
+}
+
+// GetDashboard summarizes jobs and runs, listing recent failures with their runbooks
+func (s *dashboardService) GetDashboard(failureLimit int) (*models.Dashboard, error) {
+	if failureLimit < 1 || failureLimit > 100 {
+		failureLimit = 20 // Default limit
+	}
+
+	leastHealthy, totalJobs, err := s.jobRepo.GetAll(context.Background(), 1, dashboardUnhealthiestJobs, models.JobSortHealth, models.JobFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs: %w", err)
+	}
+
+	activeJobs, err := s.jobRepo.GetActiveJobs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active jobs: %w", err)
+	}
+
+	running, err := s.executionRepo.GetRunningExecutions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running executions: %w", err)
+	}
+
+	awaiting, err := s.executionRepo.GetAwaitingApproval()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get runs awaiting approval: %w", err)
+	}
+
+	failures, err := s.executionRepo.GetRecentFailures(failureLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent failures: %w", err)
+	}
+
+	acknowledged, err := s.activeAcknowledgments()
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard := &models.Dashboard{
+		TotalJobs:         totalJobs,
+		ActiveJobs:        len(activeJobs),
+		RunningExecutions: len(running),
+		AwaitingApproval:  len(awaiting),
+		RecentFailures:    make([]models.DashboardFailure, 0, len(failures)),
+		UnhealthiestJobs:  make([]models.DashboardJobHealth, 0, len(leastHealthy)),
+		Acknowledgments:   make([]models.JobAcknowledgment, 0, len(acknowledged)),
+	}
+
+	// Jobs that haven't been scored yet sort last
+	for _, job := range leastHealthy {
+		if job.HealthScore == nil {
+			break
+		}
+		dashboard.UnhealthiestJobs = append(dashboard.UnhealthiestJobs, models.DashboardJobHealth{
+			JobID:       job.ID,
+			JobName:     job.Name,
+			Severity:    job.Severity,
+			Team:        job.Team,
+			HealthScore: *job.HealthScore,
+			ScoredAt:    job.HealthScoredAt,
+		})
+	}
+
+	for _, execution := range failures {
+		failure := models.DashboardFailure{
+			ExecutionID:  execution.ID,
+			JobID:        execution.JobID,
+			JobName:      execution.Job.Name,
+			Severity:     execution.Job.Severity,
+			RunbookURL:   execution.Job.RunbookURL,
+			Docs:         execution.Job.Docs,
+			ErrorMessage: execution.ErrorMessage,
+			FailedAt:     execution.CompletedAt,
+		}
+		if acknowledgment, ok := acknowledged[execution.JobID]; ok {
+			failure.Acknowledgment = &acknowledgment
+		}
+		dashboard.RecentFailures = append(dashboard.RecentFailures, failure)
+	}
+
+	// Acknowledgments expiring soonest first, as they are the next to start alerting again
+	for _, acknowledgment := range acknowledged {
+		dashboard.Acknowledgments = append(dashboard.Acknowledgments, acknowledgment)
+	}
+	sort.Slice(dashboard.Acknowledgments, func(i, j int) bool {
+		return dashboard.Acknowledgments[i].ExpiresBefore(&dashboard.Acknowledgments[j])
+	})
+
+	if s.artifacts != nil {
+		storage, err := s.artifacts.GetStorageUsage(dashboardTopStorageJobs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get storage usage: %w", err)
+		}
+		dashboard.Storage = storage
+	}
+
+	return dashboard, nil
+}
+
+// GetFailingJobs lists the active jobs in a failing streak, failing longest first,
+// with the acknowledgment of those an operator has acknowledged
+func (s *dashboardService) GetFailingJobs() ([]models.FailingJob, error) {
+	failing, err := s.executionRepo.GetFailingStreaks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get failing jobs: %w", err)
+	}
+
+	acknowledged, err := s.activeAcknowledgments()
+	if err != nil {
+		return nil, err
+	}
+	for i := range failing {
+		if acknowledgment, ok := acknowledged[failing[i].JobID]; ok {
+			failing[i].Acknowledgment = &acknowledgment
+		}
+	}
+	return failing, nil
+}
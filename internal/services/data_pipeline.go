@@ -0,0 +1,779 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/expressions"
+	"job-scheduler/internal/models"
+)
+
+// Sources, steps and sinks of data_processing pipelines
+const (
+	PipelineSourceSQL  = "sql"
+	PipelineSourceHTTP = "http"
+	PipelineSourceFile = "file"
+
+	PipelineStepMap    = "map"
+	PipelineStepFilter = "filter"
+	PipelineStepSelect = "select"
+
+	PipelineSinkTable    = "table"
+	PipelineSinkFile     = "file"
+	PipelineSinkArtifact = "artifact"
+)
+
+// Formats of the files pipelines read and write
+const (
+	PipelineFormatCSV  = "csv"
+	PipelineFormatJSON = "json"
+)
+
+// errPipelineConnectionRequired is returned for sql sources without a "connection", which would
+// otherwise read the scheduler's own database
+var errPipelineConnectionRequired = errors.New(`sql source needs a "connection" naming a registered database`)
+
+// maxPipelineRows caps how many rows a pipeline's source may return
+const maxPipelineRows = 100000
+
+// columnNamePattern is what the columns of rows written to a table look like
+// Names are quoted when used, so anything else is refused rather than escaped
+var columnNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// pipelineRow is one row flowing through a pipeline, keyed by column name
+type pipelineRow = map[string]interface{}
+
+// PipelineStageStats reports the rows into and out of one stage of a pipeline and how long it took
+type PipelineStageStats struct {
+	Stage      string `json:"stage"`
+	Type       string `json:"type"`
+	RowsIn     int    `json:"rows_in"`
+	RowsOut    int    `json:"rows_out"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// pipelineFuncs are available in map and filter templates
+// num converts a value to a number, e.g. {{gt (num .amount) 100.0}}
+var pipelineFuncs = template.FuncMap{
+	"num":   toNumber,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+}
+
+// pipelineStep transforms the rows of a pipeline
+type pipelineStep struct {
+	stepType string
+	apply    func(ctx context.Context, rows []pipelineRow) ([]pipelineRow, error)
+}
+
+// DataProcessingExecutor handles data_processing jobs, running the pipeline in the job's config:
+// rows are read from a source, transformed by steps and written to a sink
+// Jobs without a "source" simulate processing instead
+type DataProcessingExecutor struct {
+	dataDir        string
+	internalTables map[string]bool
+	db             *sql.DB
+	databases      DatabaseRegistry
+	httpClient     *http.Client
+}
+
+// NewDataProcessingExecutor creates a data processing executor reading and writing files in dataDir
+// Table sinks without a "connection" may only write to internalTables in the scheduler's own database
+func NewDataProcessingExecutor(dataDir string, internalTables []string) *DataProcessingExecutor {
+	allowed := make(map[string]bool, len(internalTables))
+	for _, table := range internalTables {
+		allowed[table] = true
+	}
+	return &DataProcessingExecutor{
+		dataDir:        dataDir,
+		internalTables: allowed,
+		httpClient:     &http.Client{},
+	}
+}
+
+// SetDatabases sets the scheduler's own database, used only by table sinks writing to an allowed internal
+// table, and the registry of external databases pipelines reference by name
+func (d *DataProcessingExecutor) SetDatabases(db *sql.DB, databases DatabaseRegistry) {
+	d.db = db
+	d.databases = databases
+}
+
+// SetHTTPClient sets the client fetching pipelines' HTTP sources; the run's timeout bounds each request
+func (d *DataProcessingExecutor) SetHTTPClient(client *http.Client) {
+	d.httpClient = client
+}
+
+// Execute runs the job's pipeline
+func (d *DataProcessingExecutor) Execute(ctx context.Context, job *models.Job) error {
+	_, err := d.ExecuteWithArtifacts(ctx, job)
+	return err
+}
+
+// ExecuteWithArtifacts runs the job's pipeline, returning the file written by an artifact sink
+func (d *DataProcessingExecutor) ExecuteWithArtifacts(ctx context.Context, job *models.Job) ([]ArtifactFile, error) {
+	RunLogger(ctx).WithFields(logrus.Fields{
+		"job_id":   job.ID,
+		"job_name": job.Name,
+		"job_type": job.JobType,
+	}).Info("Starting data processing job")
+
+	if job.Config == nil || job.Config["source"] == nil {
+		return nil, d.simulate(ctx, job)
+	}
+	return d.runPipeline(ctx, job)
+}
+
+// GetJobType returns the job type
+func (d *DataProcessingExecutor) GetJobType() models.JobType {
+	return models.JobTypeDataProcessing
+}
+
+// runPipeline reads the rows of config["source"], applies config["steps"] in order and writes the
+// result to config["sink"]. A pipeline without a sink only reports its row counts
+func (d *DataProcessingExecutor) runPipeline(ctx context.Context, job *models.Job) ([]ArtifactFile, error) {
+	// Compile every step before reading anything, so a broken pipeline fails fast
+	source, steps, sink, err := compilePipeline(job.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []PipelineStageStats
+	start := time.Now()
+	sourceType, _ := source["type"].(string)
+	rows, err := d.readSource(ctx, job, source)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > maxPipelineRows {
+		return nil, fmt.Errorf("pipeline source returned %d rows, at most %d are allowed", len(rows), maxPipelineRows)
+	}
+	rowsRead := len(rows)
+	stats = append(stats, PipelineStageStats{Stage: "source", Type: sourceType, RowsOut: rowsRead, DurationMs: time.Since(start).Milliseconds()})
+
+	for i, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		start = time.Now()
+		rowsIn := len(rows)
+		if rows, err = step.apply(ctx, rows); err != nil {
+			return nil, fmt.Errorf("pipeline step %d (%s) failed: %w", i+1, step.stepType, err)
+		}
+		stats = append(stats, PipelineStageStats{Stage: "step", Type: step.stepType, RowsIn: rowsIn, RowsOut: len(rows), DurationMs: time.Since(start).Milliseconds()})
+	}
+
+	// Don't write anything for a cancelled run
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var files []ArtifactFile
+	rowsWritten := 0
+	if sink != nil {
+		start = time.Now()
+		sinkType, _ := sink["type"].(string)
+		if files, err = d.writeSink(ctx, job, sink, rows); err != nil {
+			return nil, err
+		}
+		rowsWritten = len(rows)
+		stats = append(stats, PipelineStageStats{Stage: "sink", Type: sinkType, RowsIn: len(rows), RowsOut: rowsWritten, DurationMs: time.Since(start).Milliseconds()})
+	}
+	RecordOutput(ctx, "rows_read", rowsRead)
+	RecordOutput(ctx, "rows_written", rowsWritten)
+	RecordOutput(ctx, "stages", stats)
+
+	RunLogger(ctx).WithFields(logrus.Fields{
+		"job_id":       job.ID,
+		"source":       sourceType,
+		"steps":        len(steps),
+		"rows_read":    rowsRead,
+		"rows_written": rowsWritten,
+	}).Info("Data pipeline completed successfully")
+
+	return files, nil
+}
+
+// readSource reads the rows of the pipeline's source
+func (d *DataProcessingExecutor) readSource(ctx context.Context, job *models.Job, source map[string]interface{}) ([]pipelineRow, error) {
+	sourceType, _ := source["type"].(string)
+	switch sourceType {
+	case PipelineSourceSQL:
+		query, _ := source["query"].(string)
+		if query == "" {
+			return nil, fmt.Errorf("sql source needs a query")
+		}
+		db, err := d.externalDatabase(ctx, job)
+		if err != nil {
+			return nil, err
+		}
+		return queryRows(ctx, db, query)
+	case PipelineSourceHTTP:
+		url, _ := source["url"].(string)
+		if url == "" {
+			return nil, fmt.Errorf("http source needs a url")
+		}
+		field, _ := source["field"].(string)
+		return fetchJSONRows(ctx, d.httpClient, url, field)
+	case PipelineSourceFile:
+		path, err := d.dataPath(source["path"])
+		if err != nil {
+			return nil, err
+		}
+		return readRowsFile(path, fileFormat(source, path))
+	default:
+		return nil, fmt.Errorf("invalid pipeline source type: %q", sourceType)
+	}
+}
+
+// writeSink writes the rows to the pipeline's sink, returning the file an artifact sink wrote
+func (d *DataProcessingExecutor) writeSink(ctx context.Context, job *models.Job, sink map[string]interface{}, rows []pipelineRow) ([]ArtifactFile, error) {
+	sinkType, _ := sink["type"].(string)
+	switch sinkType {
+	case PipelineSinkTable:
+		table, _ := sink["table"].(string)
+		if !tableNamePattern.MatchString(table) {
+			return nil, fmt.Errorf("invalid sink table: %q", table)
+		}
+		replace, _ := sink["replace"].(bool)
+		db, err := d.sinkDatabase(ctx, job, table)
+		if err != nil {
+			return nil, err
+		}
+		return nil, insertRows(ctx, db, table, rows, replace)
+	case PipelineSinkFile:
+		path, err := d.dataPath(sink["path"])
+		if err != nil {
+			return nil, err
+		}
+		return nil, writeRowsFile(path, fileFormat(sink, path), rows)
+	case PipelineSinkArtifact:
+		format := fileFormat(sink, "")
+		name, _ := sink["name"].(string)
+		if name == "" {
+			name = unsafeFilenameChars.ReplaceAllString(job.Name, "_")
+		}
+		filename := fmt.Sprintf("%s_%s.%s", unsafeFilenameChars.ReplaceAllString(name, "_"), time.Now().Format("20060102_150405"), format)
+		path := filepath.Join(d.dataDir, "exports", job.ID.String(), filename)
+		if err := writeRowsFile(path, format, rows); err != nil {
+			return nil, err
+		}
+		contentType := "text/csv"
+		if format == PipelineFormatJSON {
+			contentType = "application/json"
+		}
+		return []ArtifactFile{{Kind: models.ArtifactKindExport, Name: filename, Path: path, ContentType: contentType}}, nil
+	default:
+		return nil, fmt.Errorf("invalid pipeline sink type: %q", sinkType)
+	}
+}
+
+// externalDatabase returns the registered database named by config["connection"]
+// Pipelines never read the scheduler's own database, which holds its secrets, roles and jobs
+func (d *DataProcessingExecutor) externalDatabase(ctx context.Context, job *models.Job) (*sql.DB, error) {
+	name := JobConnection(job)
+	if name == "" {
+		return nil, errPipelineConnectionRequired
+	}
+	if d.databases == nil {
+		return nil, fmt.Errorf("external databases are not configured")
+	}
+	return d.databases.Database(ctx, name)
+}
+
+// sinkDatabase returns the database a table sink writes to: the one named by config["connection"], or
+// the scheduler's own database when the table is one of the allowed internal tables
+func (d *DataProcessingExecutor) sinkDatabase(ctx context.Context, job *models.Job, table string) (*sql.DB, error) {
+	if JobConnection(job) != "" {
+		return d.externalDatabase(ctx, job)
+	}
+	if !d.internalTables[table] {
+		return nil, fmt.Errorf("table sink %q needs a \"connection\", or to be allowed in DATA_PROCESSING_INTERNAL_TABLES", table)
+	}
+	if d.db == nil {
+		return nil, fmt.Errorf("the service database is not configured")
+	}
+	return d.db, nil
+}
+
+// dataPath resolves a pipeline file path within the data directory, which it can't escape
+func (d *DataProcessingExecutor) dataPath(value interface{}) (string, error) {
+	path, _ := value.(string)
+	if strings.TrimSpace(path) == "" {
+		return "", fmt.Errorf("pipeline file needs a path")
+	}
+	return filepath.Join(d.dataDir, filepath.FromSlash(filepath.Clean("/"+path))), nil
+}
+
+// ValidatePipelineConfig checks a data_processing job's pipeline when it is saved, compiling its step
+// templates and CEL expressions so a broken condition or field expression is rejected rather than
+// failing the first run
+// Jobs without a "source" simulate processing, so there is nothing to check
+func ValidatePipelineConfig(config models.JobConfig) error {
+	if config == nil || config["source"] == nil {
+		return nil
+	}
+	source, _, _, err := compilePipeline(config)
+	if err != nil {
+		return err
+	}
+	if source["type"] == PipelineSourceSQL {
+		if JobConnection(&models.Job{Config: config}) == "" {
+			return errPipelineConnectionRequired
+		}
+	}
+	return nil
+}
+
+// compilePipeline returns the pipeline's source and sink configs, nil without a sink, and its compiled steps
+func compilePipeline(config models.JobConfig) (map[string]interface{}, []pipelineStep, map[string]interface{}, error) {
+	source, ok := config["source"].(map[string]interface{})
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("pipeline source must be an object")
+	}
+	sink, _ := config["sink"].(map[string]interface{})
+	if config["sink"] != nil && sink == nil {
+		return nil, nil, nil, fmt.Errorf("pipeline sink must be an object")
+	}
+
+	var steps []pipelineStep
+	if configured, ok := config["steps"].([]interface{}); ok {
+		for i, stepConfig := range configured {
+			stepConfig, ok := stepConfig.(map[string]interface{})
+			if !ok {
+				return nil, nil, nil, fmt.Errorf("pipeline step %d must be an object", i+1)
+			}
+			step, err := compilePipelineStep(stepConfig)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("pipeline step %d: %w", i+1, err)
+			}
+			steps = append(steps, step)
+		}
+	}
+	return source, steps, sink, nil
+}
+
+// compilePipelineStep parses a step's config: map sets fields to rendered templates or evaluated
+// CEL expressions, filter keeps the rows whose condition renders as true or whose CEL expression
+// is true, and select keeps only the listed fields
+func compilePipelineStep(config map[string]interface{}) (pipelineStep, error) {
+	stepType, _ := config["type"].(string)
+	switch stepType {
+	case PipelineStepMap:
+		fields, _ := config["fields"].(map[string]interface{})
+		exprs, _ := config["expressions"].(map[string]interface{})
+		if len(fields) == 0 && len(exprs) == 0 {
+			return pipelineStep{}, fmt.Errorf("map step needs fields or expressions")
+		}
+		templates := make(map[string]*template.Template, len(fields))
+		for name, value := range fields {
+			text, ok := value.(string)
+			if !ok {
+				return pipelineStep{}, fmt.Errorf("field %q must be a template", name)
+			}
+			tmpl, err := parsePipelineTemplate(name, text)
+			if err != nil {
+				return pipelineStep{}, err
+			}
+			templates[name] = tmpl
+		}
+		compiled := make(map[string]*expressions.Expression, len(exprs))
+		for name, value := range exprs {
+			if _, ok := templates[name]; ok {
+				return pipelineStep{}, fmt.Errorf("field %q is set by both a template and an expression", name)
+			}
+			source, ok := value.(string)
+			if !ok {
+				return pipelineStep{}, fmt.Errorf("expression %q must be a CEL expression", name)
+			}
+			expression, err := expressions.PipelineRow.Compile(source)
+			if err != nil {
+				return pipelineStep{}, fmt.Errorf("expression %q: %w", name, err)
+			}
+			compiled[name] = expression
+		}
+		return pipelineStep{stepType: stepType, apply: func(ctx context.Context, rows []pipelineRow) ([]pipelineRow, error) {
+			mapped := make([]pipelineRow, len(rows))
+			for i, row := range rows {
+				out := make(pipelineRow, len(row)+len(templates)+len(compiled))
+				for key, value := range row {
+					out[key] = value
+				}
+				for name, tmpl := range templates {
+					value, err := renderPipelineTemplate(tmpl, row)
+					if err != nil {
+						return nil, fmt.Errorf("row %d: %w", i+1, err)
+					}
+					out[name] = value
+				}
+				for name, expression := range compiled {
+					value, err := expression.Eval(ctx, map[string]interface{}{"row": row})
+					if err != nil {
+						return nil, fmt.Errorf("row %d: expression %q: %w", i+1, name, err)
+					}
+					out[name] = value
+				}
+				mapped[i] = out
+			}
+			return mapped, nil
+		}}, nil
+	case PipelineStepFilter:
+		condition, _ := config["condition"].(string)
+		source, _ := config["expression"].(string)
+		if (condition == "") == (source == "") {
+			return pipelineStep{}, fmt.Errorf("filter step needs either a condition or an expression")
+		}
+		var keep func(ctx context.Context, row pipelineRow) (bool, error)
+		if source != "" {
+			expression, err := expressions.PipelineRow.CompileCondition(source)
+			if err != nil {
+				return pipelineStep{}, err
+			}
+			keep = func(ctx context.Context, row pipelineRow) (bool, error) {
+				return expression.EvalBool(ctx, map[string]interface{}{"row": row})
+			}
+		} else {
+			tmpl, err := parsePipelineTemplate("condition", condition)
+			if err != nil {
+				return pipelineStep{}, err
+			}
+			keep = func(ctx context.Context, row pipelineRow) (bool, error) {
+				result, err := renderPipelineTemplate(tmpl, row)
+				return strings.TrimSpace(result) == "true", err
+			}
+		}
+		return pipelineStep{stepType: stepType, apply: func(ctx context.Context, rows []pipelineRow) ([]pipelineRow, error) {
+			var kept []pipelineRow
+			for i, row := range rows {
+				matched, err := keep(ctx, row)
+				if err != nil {
+					return nil, fmt.Errorf("row %d: %w", i+1, err)
+				}
+				if matched {
+					kept = append(kept, row)
+				}
+			}
+			return kept, nil
+		}}, nil
+	case PipelineStepSelect:
+		listed, _ := config["fields"].([]interface{})
+		var fields []string
+		for _, field := range listed {
+			if field, ok := field.(string); ok && field != "" {
+				fields = append(fields, field)
+			}
+		}
+		if len(fields) == 0 {
+			return pipelineStep{}, fmt.Errorf("select step needs fields")
+		}
+		return pipelineStep{stepType: stepType, apply: func(ctx context.Context, rows []pipelineRow) ([]pipelineRow, error) {
+			selected := make([]pipelineRow, len(rows))
+			for i, row := range rows {
+				out := make(pipelineRow, len(fields))
+				for _, field := range fields {
+					out[field] = row[field]
+				}
+				selected[i] = out
+			}
+			return selected, nil
+		}}, nil
+	default:
+		return pipelineStep{}, fmt.Errorf("invalid step type: %q", stepType)
+	}
+}
+
+// parsePipelineTemplate parses a map or filter template; rows missing a field it uses fail the step
+func parsePipelineTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(pipelineFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template %q: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// renderPipelineTemplate renders a template with a row's fields
+func renderPipelineTemplate(tmpl *template.Template, row pipelineRow) (string, error) {
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, row); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// toNumber converts a field's value to a number for comparisons in templates
+func toNumber(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(v), 64)
+	case []byte:
+		return strconv.ParseFloat(strings.TrimSpace(string(v)), 64)
+	default:
+		return 0, fmt.Errorf("%v is not a number", value)
+	}
+}
+
+// fileFormat returns the format of a pipeline file, from config["format"] or else the path's extension
+func fileFormat(config map[string]interface{}, path string) string {
+	if format, ok := config["format"].(string); ok && format != "" {
+		return format
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return PipelineFormatJSON
+	}
+	return PipelineFormatCSV
+}
+
+// queryRows runs a query in a read-only transaction, returning one row per result row
+func queryRows(ctx context.Context, db *sql.DB, query string) ([]pipelineRow, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start source query: %w", err)
+	}
+	defer tx.Rollback() // Does nothing once committed
+
+	result, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("source query failed: %w", err)
+	}
+	defer result.Close()
+
+	columns, err := result.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("source query failed: %w", err)
+	}
+	var rows []pipelineRow
+	for result.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := result.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("failed to read source row: %w", err)
+		}
+		row := make(pipelineRow, len(columns))
+		for i, column := range columns {
+			if b, ok := values[i].([]byte); ok {
+				row[column] = string(b)
+			} else {
+				row[column] = values[i]
+			}
+		}
+		rows = append(rows, row)
+		if len(rows) > maxPipelineRows {
+			break
+		}
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("source query failed: %w", err)
+	}
+	return rows, nil
+}
+
+// insertRows writes the rows to a table in one transaction, first deleting its rows when replacing
+func insertRows(ctx context.Context, db *sql.DB, table string, rows []pipelineRow, replace bool) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start writing to %s: %w", table, err)
+	}
+	defer tx.Rollback() // Does nothing once committed
+
+	if replace {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+quoteTableName(table)); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", table, err)
+		}
+	}
+
+	columns := rowColumns(rows)
+	if len(columns) > 0 {
+		quoted := make([]string, len(columns))
+		placeholders := make([]string, len(columns))
+		for i, column := range columns {
+			if !columnNamePattern.MatchString(column) {
+				return fmt.Errorf("invalid column name: %q", column)
+			}
+			quoted[i] = `"` + column + `"`
+			placeholders[i] = "$" + strconv.Itoa(i+1)
+		}
+		statement, err := tx.PrepareContext(ctx, fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+			quoteTableName(table), strings.Join(quoted, ", "), strings.Join(placeholders, ", ")))
+		if err != nil {
+			return fmt.Errorf("failed to write to %s: %w", table, err)
+		}
+		defer statement.Close()
+
+		for i, row := range rows {
+			values := make([]interface{}, len(columns))
+			for j, column := range columns {
+				values[j] = columnValue(row[column])
+			}
+			if _, err := statement.ExecContext(ctx, values...); err != nil {
+				return fmt.Errorf("failed to write row %d to %s: %w", i+1, table, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to write to %s: %w", table, err)
+	}
+	return nil
+}
+
+// columnValue converts a row's value to one a database column takes; objects and lists are stored as JSON
+func columnValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		return v.String()
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	default:
+		return v
+	}
+}
+
+// rowColumns returns every field of the rows in alphabetical order
+func rowColumns(rows []pipelineRow) []string {
+	seen := make(map[string]bool)
+	var columns []string
+	for _, row := range rows {
+		for column := range row {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// readRowsFile reads the rows of a CSV file with a header row, or a JSON file holding an array of objects
+func readRowsFile(path, format string) ([]pipelineRow, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open source file: %w", err)
+	}
+	defer file.Close()
+
+	switch format {
+	case PipelineFormatJSON:
+		decoder := json.NewDecoder(file)
+		decoder.UseNumber()
+		var rows []pipelineRow
+		if err := decoder.Decode(&rows); err != nil {
+			return nil, fmt.Errorf("failed to decode source file: %w", err)
+		}
+		return rows, nil
+	case PipelineFormatCSV:
+		reader := csv.NewReader(file)
+		header, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read source file header: %w", err)
+		}
+		var rows []pipelineRow
+		for {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return rows, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read source file: %w", err)
+			}
+			row := make(pipelineRow, len(header))
+			for i, name := range header {
+				if i < len(record) {
+					row[strings.TrimSpace(name)] = record[i]
+				}
+			}
+			rows = append(rows, row)
+			if len(rows) > maxPipelineRows {
+				return rows, nil
+			}
+		}
+	default:
+		return nil, fmt.Errorf("invalid file format: %q", format)
+	}
+}
+
+// writeRowsFile writes the rows as CSV, with a header row of their fields, or as a JSON array
+func writeRowsFile(path, format string, rows []pipelineRow) error {
+	var content []byte
+	switch format {
+	case PipelineFormatJSON:
+		if rows == nil {
+			rows = []pipelineRow{}
+		}
+		data, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode rows: %w", err)
+		}
+		content = data
+	case PipelineFormatCSV:
+		columns := rowColumns(rows)
+		var buf bytes.Buffer
+		writer := csv.NewWriter(&buf)
+		if err := writer.Write(columns); err != nil {
+			return fmt.Errorf("failed to encode rows: %w", err)
+		}
+		for _, row := range rows {
+			record := make([]string, len(columns))
+			for i, column := range columns {
+				if value := row[column]; value != nil {
+					record[i] = fmt.Sprint(columnValue(value))
+				}
+			}
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to encode rows: %w", err)
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return fmt.Errorf("failed to encode rows: %w", err)
+		}
+		content = buf.Bytes()
+	default:
+		return fmt.Errorf("invalid file format: %q", format)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := ioutil.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,295 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/integrations"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+	"job-scheduler/internal/secrets"
+)
+
+var (
+	// ErrDatabaseConnectionExists is returned when registering a second connection under a name
+	ErrDatabaseConnectionExists = errors.New("a database connection with this name already exists")
+	// ErrUnknownDatabaseConnection is returned when a job references a connection that isn't registered
+	ErrUnknownDatabaseConnection = errors.New("database connection not registered")
+)
+
+// connectionNamePattern is what connection names look like, so they read well in job configs
+var connectionNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// DatabaseRegistry opens the registered external databases that jobs reference by name
+// Executors running SQL, exports or backups take it in their constructor
+type DatabaseRegistry interface {
+	Database(ctx context.Context, name string) (*sql.DB, error)
+}
+
+// DatabaseConnectionService defines the interface for managing registered external databases
+type DatabaseConnectionService interface {
+	DatabaseRegistry
+	CreateDatabaseConnection(req *models.CreateDatabaseConnectionRequest) (*models.DatabaseConnection, error)
+	GetDatabaseConnections() ([]models.DatabaseConnection, error)
+	UpdateDatabaseConnection(id uuid.UUID, req *models.UpdateDatabaseConnectionRequest) (*models.DatabaseConnection, error)
+	DeleteDatabaseConnection(id uuid.UUID) error
+}
+
+// databaseConnectionService implements DatabaseConnectionService interface
+type databaseConnectionService struct {
+	connectionRepo repositories.DatabaseConnectionRepository
+	integrations   *integrations.Manager
+	mu             sync.Mutex
+	// opened is when each registered connection was last changed, as of when its pool was registered
+	opened map[string]time.Time
+}
+
+// NewDatabaseConnectionService creates a new database connection service
+// Connection pools are shared through the integrations manager, which closes them on shutdown
+func NewDatabaseConnectionService(connectionRepo repositories.DatabaseConnectionRepository, manager *integrations.Manager) DatabaseConnectionService {
+	return &databaseConnectionService{
+		connectionRepo: connectionRepo,
+		integrations:   manager,
+		opened:         make(map[string]time.Time),
+	}
+}
+
+// JobConnection returns the name of the registered database a job's config references, if any
+func JobConnection(job *models.Job) string {
+	name, _ := job.Config["connection"].(string)
+	return strings.TrimSpace(name)
+}
+
+// CreateDatabaseConnection registers an external database
+func (s *databaseConnectionService) CreateDatabaseConnection(req *models.CreateDatabaseConnectionRequest) (*models.DatabaseConnection, error) {
+	name := strings.TrimSpace(req.Name)
+	if !connectionNamePattern.MatchString(name) || len(name) > 100 {
+		return nil, fmt.Errorf("name must be lowercase letters, digits, - and _, up to 100 characters")
+	}
+	driver := req.Driver
+	if driver == "" {
+		driver = models.DatabaseDriverPostgres
+	}
+	if !models.IsValidDatabaseDriver(string(driver)) {
+		return nil, fmt.Errorf("invalid database driver: %s", driver)
+	}
+
+	connection := &models.DatabaseConnection{
+		ID:                     uuid.New(),
+		Name:                   name,
+		Description:            req.Description,
+		Driver:                 driver,
+		DSN:                    strings.TrimSpace(req.DSN),
+		Username:               req.Username,
+		PasswordSecret:         req.PasswordSecret,
+		MaxOpenConns:           req.MaxOpenConns,
+		MaxIdleConns:           req.MaxIdleConns,
+		ConnMaxLifetimeSeconds: req.ConnMaxLifetimeSeconds,
+	}
+	if connection.MaxOpenConns == 0 {
+		connection.MaxOpenConns = models.DefaultConnectionMaxOpenConns
+	}
+	if connection.MaxIdleConns == 0 {
+		connection.MaxIdleConns = models.DefaultConnectionMaxIdleConns
+		if connection.MaxIdleConns > connection.MaxOpenConns {
+			connection.MaxIdleConns = connection.MaxOpenConns
+		}
+	}
+	if connection.ConnMaxLifetimeSeconds == 0 {
+		connection.ConnMaxLifetimeSeconds = models.DefaultConnectionMaxLifetimeSeconds
+	}
+	if err := validateDatabaseConnection(connection); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.connectionRepo.FindByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check database connection: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrDatabaseConnectionExists
+	}
+
+	if err := s.connectionRepo.Create(connection); err != nil {
+		return nil, fmt.Errorf("failed to create database connection: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"connection_id": connection.ID,
+		"name":          connection.Name,
+		"driver":        connection.Driver,
+	}).Info("Database connection registered successfully")
+
+	return connection, nil
+}
+
+// GetDatabaseConnections lists all registered database connections
+func (s *databaseConnectionService) GetDatabaseConnections() ([]models.DatabaseConnection, error) {
+	connections, err := s.connectionRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connections: %w", err)
+	}
+	return connections, nil
+}
+
+// UpdateDatabaseConnection updates a registered database; its pool is reopened on next use
+func (s *databaseConnectionService) UpdateDatabaseConnection(id uuid.UUID, req *models.UpdateDatabaseConnectionRequest) (*models.DatabaseConnection, error) {
+	connection, err := s.connectionRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection for update: %w", err)
+	}
+
+	if req.Description != nil {
+		connection.Description = *req.Description
+	}
+	if req.DSN != nil {
+		connection.DSN = strings.TrimSpace(*req.DSN)
+	}
+	if req.Username != nil {
+		connection.Username = *req.Username
+	}
+	if req.PasswordSecret != nil {
+		connection.PasswordSecret = *req.PasswordSecret
+	}
+	if req.MaxOpenConns != nil {
+		connection.MaxOpenConns = *req.MaxOpenConns
+	}
+	if req.MaxIdleConns != nil {
+		connection.MaxIdleConns = *req.MaxIdleConns
+	}
+	if req.ConnMaxLifetimeSeconds != nil {
+		connection.ConnMaxLifetimeSeconds = *req.ConnMaxLifetimeSeconds
+	}
+	if err := validateDatabaseConnection(connection); err != nil {
+		return nil, err
+	}
+
+	if err := s.connectionRepo.Update(connection); err != nil {
+		return nil, fmt.Errorf("failed to update database connection: %w", err)
+	}
+	s.closePool(connection.Name)
+
+	logrus.WithFields(logrus.Fields{
+		"connection_id": connection.ID,
+		"name":          connection.Name,
+	}).Info("Database connection updated successfully")
+
+	return connection, nil
+}
+
+// DeleteDatabaseConnection unregisters a database; jobs referencing it fail until it is registered again
+func (s *databaseConnectionService) DeleteDatabaseConnection(id uuid.UUID) error {
+	connection, err := s.connectionRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection for delete: %w", err)
+	}
+	if err := s.connectionRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete database connection: %w", err)
+	}
+	s.closePool(connection.Name)
+	return nil
+}
+
+// Database returns the pool of a registered database, opening it on first use
+// The registration is read on every call, so changes made through any replica reopen the pool here
+func (s *databaseConnectionService) Database(ctx context.Context, name string) (*sql.DB, error) {
+	connection, err := s.connectionRepo.FindByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+	if connection == nil {
+		s.closePool(name)
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDatabaseConnection, name)
+	}
+
+	integration := integrations.DatabaseIntegration(name)
+	s.mu.Lock()
+	if changed, ok := s.opened[name]; !ok || !changed.Equal(connection.UpdatedAt) || !s.integrations.Has(integration) {
+		if ok {
+			if err := s.integrations.Remove(integration); err != nil {
+				logrus.WithError(err).WithField("connection", name).Warn("Failed to close outdated database pool")
+			}
+		}
+		s.integrations.Register(integration, openDatabaseConnection(*connection))
+		s.opened[name] = connection.UpdatedAt
+	}
+	s.mu.Unlock()
+
+	resource, err := s.integrations.Get(ctx, integration)
+	if err != nil {
+		return nil, err
+	}
+	pool, ok := resource.(*integrations.DatabasePool)
+	if !ok {
+		return nil, fmt.Errorf("%s integration is not a database", integration)
+	}
+	return pool.DB(), nil
+}
+
+// closePool closes a connection's pool, if open, so the next use reopens it with its current settings
+func (s *databaseConnectionService) closePool(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.opened[name]; !ok {
+		return
+	}
+	delete(s.opened, name)
+	if err := s.integrations.Remove(integrations.DatabaseIntegration(name)); err != nil {
+		logrus.WithError(err).WithField("connection", name).Warn("Failed to close database pool")
+	}
+}
+
+// openDatabaseConnection opens a registered database, reading its password from its secret
+// The secret is read on every open, so a rotated password is picked up when the pool reopens
+func openDatabaseConnection(connection models.DatabaseConnection) integrations.OpenFunc {
+	return func(ctx context.Context) (integrations.Resource, error) {
+		var password string
+		if connection.PasswordSecret != "" {
+			secret, err := secrets.Resolve(connection.PasswordSecret)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read password of %s: %w", connection.Name, err)
+			}
+			password = secret
+		}
+
+		return integrations.OpenDatabase(integrations.DatabaseConfig{
+			DSN:             connection.DSN,
+			Username:        connection.Username,
+			Password:        password,
+			MaxOpenConns:    connection.MaxOpenConns,
+			MaxIdleConns:    connection.MaxIdleConns,
+			ConnMaxLifetime: time.Duration(connection.ConnMaxLifetimeSeconds) * time.Second,
+		})(ctx)
+	}
+}
+
+// validateDatabaseConnection checks a connection's DSN, secret reference and pool limits
+func validateDatabaseConnection(connection *models.DatabaseConnection) error {
+	if err := integrations.CheckDSN(connection.DSN); err != nil {
+		return err
+	}
+	if connection.PasswordSecret != "" {
+		if err := secrets.Validate(connection.PasswordSecret); err != nil {
+			return fmt.Errorf("invalid password_secret: %w", err)
+		}
+	}
+	if connection.MaxOpenConns < 1 {
+		return fmt.Errorf("max_open_conns must be at least 1")
+	}
+	if connection.MaxIdleConns < 0 || connection.MaxIdleConns > connection.MaxOpenConns {
+		return fmt.Errorf("max_idle_conns must be between 0 and max_open_conns")
+	}
+	if connection.ConnMaxLifetimeSeconds < 0 {
+		return fmt.Errorf("conn_max_lifetime_seconds must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,468 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+)
+
+// Database maintenance operation types
+const (
+	MaintenanceVacuum           = "vacuum"
+	MaintenanceAnalyze          = "analyze"
+	MaintenanceRotatePartitions = "rotate_partitions"
+	MaintenanceSQL              = "sql"
+)
+
+// Partition intervals of rotate_partitions operations
+const (
+	PartitionDaily   = "day"
+	PartitionWeekly  = "week"
+	PartitionMonthly = "month"
+)
+
+// defaultPartitionPremake is how many partitions a rotation makes sure exist, the current one included,
+// when the operation doesn't say
+const defaultPartitionPremake = 2
+
+// tableNamePattern is what table names in db_maintenance configs look like, optionally schema-qualified
+// Names are quoted when used, so anything else is refused rather than escaped
+var tableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// partitionSuffixPattern matches the suffix of partitions named by rotate_partitions
+var partitionSuffixPattern = regexp.MustCompile(`_p([0-9]{6}|[0-9]{8})$`)
+
+// DBMaintenanceExecutor handles db_maintenance jobs, running VACUUM, ANALYZE, partition rotation and
+// named SQL statements against the scheduler's database or a registered external one
+type DBMaintenanceExecutor struct {
+	statements map[string]string
+	db         *sql.DB
+	databases  DatabaseRegistry
+}
+
+// NewDBMaintenanceExecutor creates a database maintenance executor
+// statements are the only SQL statements jobs may run, by name
+func NewDBMaintenanceExecutor(statements map[string]string) *DBMaintenanceExecutor {
+	return &DBMaintenanceExecutor{
+		statements: statements,
+	}
+}
+
+// SetDatabases sets the scheduler's own database, used by jobs without a "connection", and the
+// registry of external databases jobs reference by name
+func (d *DBMaintenanceExecutor) SetDatabases(db *sql.DB, databases DatabaseRegistry) {
+	d.db = db
+	d.databases = databases
+}
+
+// maintenanceOperation is one step of a db_maintenance job
+type maintenanceOperation struct {
+	opType string
+	tables []string
+
+	// vacuum
+	full    bool
+	analyze bool
+
+	// rotate_partitions
+	interval string
+	premake  int
+	retain   int
+
+	// sql
+	statement string
+
+	timeout time.Duration
+}
+
+// MaintenanceResult is the outcome of one operation on one target, recorded in the run's output
+type MaintenanceResult struct {
+	Type         string   `json:"type"`
+	Target       string   `json:"target"`
+	OK           bool     `json:"ok"`
+	DurationMS   int64    `json:"duration_ms"`
+	RowsAffected int64    `json:"rows_affected,omitempty"`
+	Created      []string `json:"partitions_created,omitempty"`
+	Dropped      []string `json:"partitions_dropped,omitempty"`
+	Error        string   `json:"error,omitempty"`
+}
+
+// Execute runs the job's operations in order
+// Every operation runs, and the job fails if any of them does
+func (d *DBMaintenanceExecutor) Execute(ctx context.Context, job *models.Job) error {
+	RunLogger(ctx).WithFields(logrus.Fields{
+		"job_id":   job.ID,
+		"job_name": job.Name,
+		"job_type": job.JobType,
+	}).Info("Starting database maintenance job")
+
+	// Extract configuration
+	operations, err := d.parseOperations(job.Config)
+	if err != nil {
+		return fmt.Errorf("db maintenance failed - %w", err)
+	}
+
+	database := "service"
+	db := d.db
+	if name := JobConnection(job); name != "" {
+		if d.databases == nil {
+			return fmt.Errorf("db maintenance failed - external databases are not configured")
+		}
+		if db, err = d.databases.Database(ctx, name); err != nil {
+			return fmt.Errorf("db maintenance failed - %w", err)
+		}
+		database = name
+	}
+	if db == nil {
+		return fmt.Errorf("db maintenance failed - the service database is not configured")
+	}
+
+	var results []MaintenanceResult
+	var failures []string
+	for _, operation := range operations {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		for _, result := range d.runOperation(ctx, db, operation) {
+			RunLogger(ctx).WithFields(logrus.Fields{
+				"job_id":      job.ID,
+				"operation":   result.Type,
+				"target":      result.Target,
+				"duration_ms": result.DurationMS,
+				"ok":          result.OK,
+			}).Info("Database maintenance operation finished")
+
+			results = append(results, result)
+			if !result.OK {
+				failures = append(failures, fmt.Sprintf("%s %s: %s", result.Type, result.Target, result.Error))
+			}
+		}
+	}
+
+	RecordOutput(ctx, "database", database)
+	RecordOutput(ctx, "operations", results)
+	RecordOutput(ctx, "operations_failed", len(failures))
+
+	if len(failures) > 0 {
+		return fmt.Errorf("db maintenance failed - %d of %d operations failed: %s", len(failures), len(results), strings.Join(failures, "; "))
+	}
+
+	RunLogger(ctx).WithFields(logrus.Fields{
+		"job_id":     job.ID,
+		"database":   database,
+		"operations": len(results),
+	}).Info("Database maintenance completed successfully")
+
+	return nil
+}
+
+// GetJobType returns the job type
+func (d *DBMaintenanceExecutor) GetJobType() models.JobType {
+	return models.JobTypeDBMaintenance
+}
+
+// parseOperations reads a db_maintenance job's operations from config["operations"]
+func (d *DBMaintenanceExecutor) parseOperations(config models.JobConfig) ([]maintenanceOperation, error) {
+	listed, ok := config["operations"].([]interface{})
+	if !ok || len(listed) == 0 {
+		return nil, fmt.Errorf("no operations configured")
+	}
+
+	operations := make([]maintenanceOperation, 0, len(listed))
+	for i, raw := range listed {
+		fields, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("operation %d is not an object", i+1)
+		}
+		operation, err := d.parseOperation(fields)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i+1, err)
+		}
+		operations = append(operations, operation)
+	}
+	return operations, nil
+}
+
+// parseOperation reads one operation, checking its table names and statement
+func (d *DBMaintenanceExecutor) parseOperation(fields map[string]interface{}) (maintenanceOperation, error) {
+	operation := maintenanceOperation{analyze: true, premake: defaultPartitionPremake}
+	operation.opType, _ = fields["type"].(string)
+
+	if table, ok := fields["table"].(string); ok && table != "" {
+		operation.tables = append(operation.tables, table)
+	}
+	if tables, ok := fields["tables"].([]interface{}); ok {
+		for _, table := range tables {
+			name, ok := table.(string)
+			if !ok {
+				return operation, fmt.Errorf("tables must be names")
+			}
+			operation.tables = append(operation.tables, name)
+		}
+	}
+	for _, table := range operation.tables {
+		if !tableNamePattern.MatchString(table) {
+			return operation, fmt.Errorf("invalid table name %q", table)
+		}
+	}
+	if timeout, ok := fields["timeout_seconds"].(float64); ok && timeout > 0 {
+		operation.timeout = time.Duration(timeout * float64(time.Second))
+	}
+
+	switch operation.opType {
+	case MaintenanceVacuum:
+		if full, ok := fields["full"].(bool); ok {
+			operation.full = full
+		}
+		if analyze, ok := fields["analyze"].(bool); ok {
+			operation.analyze = analyze
+		}
+	case MaintenanceAnalyze:
+	case MaintenanceRotatePartitions:
+		if len(operation.tables) != 1 {
+			return operation, fmt.Errorf("rotate_partitions needs exactly one table")
+		}
+		operation.interval, _ = fields["interval"].(string)
+		switch operation.interval {
+		case PartitionDaily, PartitionWeekly, PartitionMonthly:
+		default:
+			return operation, fmt.Errorf("invalid partition interval %q: must be day, week or month", operation.interval)
+		}
+		if premake, ok := fields["premake"].(float64); ok {
+			if premake < 1 {
+				return operation, fmt.Errorf("premake must be at least 1")
+			}
+			operation.premake = int(premake)
+		}
+		if retain, ok := fields["retain"].(float64); ok {
+			if retain < 0 {
+				return operation, fmt.Errorf("retain can't be negative")
+			}
+			operation.retain = int(retain)
+		}
+	case MaintenanceSQL:
+		name, _ := fields["statement"].(string)
+		statement, ok := d.statements[name]
+		if !ok {
+			return operation, fmt.Errorf("statement %q is not allowed - add it to DB_MAINTENANCE_STATEMENTS", name)
+		}
+		operation.tables = []string{name}
+		operation.statement = statement
+	default:
+		return operation, fmt.Errorf("unknown operation type %q", operation.opType)
+	}
+	return operation, nil
+}
+
+// runOperation runs an operation, bounded by its own timeout if it has one, returning a result per
+// table. VACUUM and ANALYZE without tables cover the whole database
+func (d *DBMaintenanceExecutor) runOperation(ctx context.Context, db *sql.DB, operation maintenanceOperation) []MaintenanceResult {
+	if operation.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, operation.timeout)
+		defer cancel()
+	}
+
+	targets := operation.tables
+	if len(targets) == 0 {
+		targets = []string{""}
+	}
+
+	results := make([]MaintenanceResult, 0, len(targets))
+	for _, target := range targets {
+		result := MaintenanceResult{Type: operation.opType, Target: target}
+		if target == "" {
+			result.Target = "database"
+		}
+
+		start := time.Now()
+		var err error
+		switch operation.opType {
+		case MaintenanceVacuum:
+			_, err = db.ExecContext(ctx, vacuumStatement(operation, target))
+		case MaintenanceAnalyze:
+			_, err = db.ExecContext(ctx, strings.TrimSpace("ANALYZE "+quoteTableName(target)))
+		case MaintenanceRotatePartitions:
+			err = rotatePartitions(ctx, db, operation, target, time.Now().UTC(), &result)
+		case MaintenanceSQL:
+			var res sql.Result
+			if res, err = db.ExecContext(ctx, operation.statement); err == nil {
+				result.RowsAffected, _ = res.RowsAffected()
+			}
+		}
+		result.DurationMS = time.Since(start).Milliseconds()
+
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.OK = true
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// vacuumStatement builds the VACUUM statement for a table, or the whole database if table is empty
+// VACUUM can't run inside a transaction, so it is run on its own
+func vacuumStatement(operation maintenanceOperation, table string) string {
+	var options []string
+	if operation.full {
+		options = append(options, "FULL")
+	}
+	if operation.analyze {
+		options = append(options, "ANALYZE")
+	}
+
+	statement := "VACUUM"
+	if len(options) > 0 {
+		statement += " (" + strings.Join(options, ", ") + ")"
+	}
+	if table != "" {
+		statement += " " + quoteTableName(table)
+	}
+	return statement
+}
+
+// rotatePartitions creates the parent table's partitions from the current period on, premake in all,
+// and drops those that ended more than retain periods before the current one
+// Only partitions named <table>_p<period start> are dropped, so ones made by hand are left alone
+func rotatePartitions(ctx context.Context, db *sql.DB, operation maintenanceOperation, parent string, now time.Time, result *MaintenanceResult) error {
+	existing, err := listPartitions(ctx, db, parent)
+	if err != nil {
+		return err
+	}
+
+	schema, base := "", parent
+	if dot := strings.Index(parent, "."); dot >= 0 {
+		schema, base = parent[:dot+1], parent[dot+1:]
+	}
+
+	current := partitionPeriodStart(now, operation.interval)
+	for i := 0; i < operation.premake; i++ {
+		start := addPartitionPeriods(current, operation.interval, i)
+		name := base + partitionSuffix(start, operation.interval)
+		if existing[name] {
+			continue
+		}
+		statement := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s')",
+			quoteTableName(schema+name), quoteTableName(parent),
+			start.Format("2006-01-02"), addPartitionPeriods(start, operation.interval, 1).Format("2006-01-02"))
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to create partition %s: %w", name, err)
+		}
+		result.Created = append(result.Created, name)
+	}
+
+	if operation.retain == 0 {
+		return nil
+	}
+	cutoff := addPartitionPeriods(current, operation.interval, -operation.retain)
+	names := make([]string, 0, len(existing))
+	for name := range existing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		start, ok := parsePartitionStart(name, base, operation.interval)
+		if !ok || !start.Before(cutoff) {
+			continue
+		}
+		if _, err := db.ExecContext(ctx, "DROP TABLE IF EXISTS "+quoteTableName(schema+name)); err != nil {
+			return fmt.Errorf("failed to drop partition %s: %w", name, err)
+		}
+		result.Dropped = append(result.Dropped, name)
+	}
+	return nil
+}
+
+// listPartitions returns the names of a partitioned table's partitions
+func listPartitions(ctx context.Context, db *sql.DB, parent string) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx,
+		"SELECT c.relname FROM pg_inherits i JOIN pg_class c ON c.oid = i.inhrelid WHERE i.inhparent = $1::regclass",
+		quoteTableName(parent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitions of %s: %w", parent, err)
+	}
+	defer rows.Close()
+
+	partitions := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to list partitions of %s: %w", parent, err)
+		}
+		partitions[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list partitions of %s: %w", parent, err)
+	}
+	return partitions, nil
+}
+
+// partitionPeriodStart returns the start of the period containing t: its day, its week from Monday,
+// or its month
+func partitionPeriodStart(t time.Time, interval string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch interval {
+	case PartitionWeekly:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case PartitionMonthly:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// addPartitionPeriods moves a period start n periods on, or back if n is negative
+func addPartitionPeriods(start time.Time, interval string, n int) time.Time {
+	switch interval {
+	case PartitionWeekly:
+		return start.AddDate(0, 0, 7*n)
+	case PartitionMonthly:
+		return start.AddDate(0, n, 0)
+	default:
+		return start.AddDate(0, 0, n)
+	}
+}
+
+// partitionSuffix names the partition starting at start: _pYYYYMM for months, _pYYYYMMDD otherwise
+func partitionSuffix(start time.Time, interval string) string {
+	if interval == PartitionMonthly {
+		return "_p" + start.Format("200601")
+	}
+	return "_p" + start.Format("20060102")
+}
+
+// parsePartitionStart reads the period start from the name of a partition made by rotatePartitions
+func parsePartitionStart(name, base, interval string) (time.Time, bool) {
+	match := partitionSuffixPattern.FindStringSubmatch(name)
+	if match == nil || strings.TrimSuffix(name, match[0]) != base {
+		return time.Time{}, false
+	}
+	layout := "20060102"
+	if interval == PartitionMonthly {
+		layout = "200601"
+	}
+	start, err := time.ParseInLocation(layout, match[1], time.UTC)
+	return start, err == nil
+}
+
+// quoteTableName quotes each part of a validated, optionally schema-qualified table name
+func quoteTableName(table string) string {
+	if table == "" {
+		return ""
+	}
+	parts := strings.Split(table, ".")
+	for i, part := range parts {
+		parts[i] = `"` + part + `"`
+	}
+	return strings.Join(parts, ".")
+}
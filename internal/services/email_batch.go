@@ -0,0 +1,367 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/integrations"
+	"job-scheduler/internal/models"
+)
+
+const (
+	// maxEmailRecipients caps how many recipients a batch email run sends to
+	maxEmailRecipients = 10000
+	// maxEmailSendConcurrency caps how many emails of a batch are sent at once
+	maxEmailSendConcurrency = 20
+	// maxReportedRecipientResults caps how many per-recipient results are kept in the run's output
+	maxReportedRecipientResults = 1000
+	// maxRecipientArtifactBytes caps how much of a recipients CSV artifact is read
+	maxRecipientArtifactBytes = 32 << 20
+	// simulatedEmailSendDelay is how long sending each email of a batch takes without SMTP
+	simulatedEmailSendDelay = 100 * time.Millisecond
+)
+
+// Statuses of a batch email's recipients
+const (
+	RecipientStatusSent       = "sent"
+	RecipientStatusSuppressed = "suppressed"
+	RecipientStatusFailed     = "failed"
+	RecipientStatusNotSent    = "not_sent"
+)
+
+// ArtifactReader opens stored artifacts, such as CSV files listing an email job's recipients
+type ArtifactReader interface {
+	OpenArtifact(ctx context.Context, id uuid.UUID) (*models.Artifact, io.ReadCloser, error)
+}
+
+// EmailRecipientResult reports what happened to one recipient of a batch email run
+type EmailRecipientResult struct {
+	Email     string `json:"email"`
+	Status    string `json:"status"`
+	MessageID string `json:"message_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// errRecipientsConnectionRequired is returned for recipient queries without a "connection", which would
+// otherwise read the scheduler's own database and could mail its secrets anywhere
+var errRecipientsConnectionRequired = errors.New(`recipients_query needs a "connection" naming a registered database`)
+
+// ValidateEmailConfig checks that a batch email job reading its recipients from a query names the
+// registered database to run it on
+func ValidateEmailConfig(config models.JobConfig) error {
+	if query, _ := config["recipients_query"].(string); query != "" && JobConnection(&models.Job{Config: config}) == "" {
+		return errRecipientsConnectionRequired
+	}
+	return nil
+}
+
+// emailRecipient is one recipient of a batch email run, with the fields merged into its templates
+type emailRecipient struct {
+	email  string
+	fields map[string]interface{}
+}
+
+// isBatchEmail reports whether the job's config lists recipients instead of a single recipient
+func isBatchEmail(config models.JobConfig) bool {
+	if config == nil {
+		return false
+	}
+	_, inline := config["recipients"]
+	query, _ := config["recipients_query"].(string)
+	artifact, _ := config["recipients_artifact"].(string)
+	return inline || query != "" || artifact != ""
+}
+
+// executeBatch sends a mail-merged email to every recipient of the job's recipient source:
+// config["recipients"] lists addresses or objects, config["recipients_query"] runs a SQL query on the
+// registered database named by config["connection"] and
+// config["recipients_artifact"] reads a CSV artifact with a header row. config["subject"] and
+// config["body"] are templates rendered with each recipient's fields, e.g. "Hi {{.name}}"
+// A retry sends only to the recipients an earlier attempt didn't send to
+func (e *EmailNotificationExecutor) executeBatch(ctx context.Context, job *models.Job) error {
+	subjectTemplate := "Scheduled Notification"
+	bodyTemplate := "This is a scheduled email notification."
+	addressField := "email"
+	concurrency := 1
+	ratePerSecond := 0.0
+
+	if s, ok := job.Config["subject"].(string); ok {
+		subjectTemplate = s
+	}
+	if b, ok := job.Config["body"].(string); ok {
+		bodyTemplate = b
+	}
+	if f, ok := job.Config["recipient_field"].(string); ok && f != "" {
+		addressField = f
+	}
+	if c, ok := job.Config["send_concurrency"].(float64); ok && c >= 1 {
+		concurrency = int(c)
+		if concurrency > maxEmailSendConcurrency {
+			concurrency = maxEmailSendConcurrency
+		}
+	}
+	if r, ok := job.Config["send_rate_per_second"].(float64); ok && r > 0 {
+		ratePerSecond = r
+	}
+
+	subject, err := template.New("subject").Option("missingkey=error").Parse(subjectTemplate)
+	if err != nil {
+		return fmt.Errorf("invalid email subject template: %w", err)
+	}
+	body, err := template.New("body").Option("missingkey=error").Parse(bodyTemplate)
+	if err != nil {
+		return fmt.Errorf("invalid email body template: %w", err)
+	}
+
+	recipients, err := e.loadRecipients(ctx, job.Config, addressField)
+	if err != nil {
+		return err
+	}
+	if len(recipients) > maxEmailRecipients {
+		return fmt.Errorf("too many recipients: %d, at most %d are allowed", len(recipients), maxEmailRecipients)
+	}
+
+	results := make([]EmailRecipientResult, len(recipients))
+	for i, recipient := range recipients {
+		results[i] = EmailRecipientResult{Email: recipient.email, Status: RecipientStatusNotSent}
+	}
+
+	// Don't send to recipients who bounced, complained or unsubscribed
+	suppressed := map[string]bool{}
+	if e.suppressions != nil {
+		addresses := make([]string, len(recipients))
+		for i, recipient := range recipients {
+			addresses[i] = recipient.email
+		}
+		if suppressed, err = e.suppressions.SuppressedRecipients(addresses); err != nil {
+			return fmt.Errorf("failed to check email suppression list: %w", err)
+		}
+	}
+
+	var pace <-chan time.Time
+	if interval := time.Duration(float64(time.Second) / ratePerSecond); ratePerSecond > 0 && interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		pace = ticker.C
+	}
+
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				results[i] = e.sendToRecipient(ctx, recipients[i], subject, body)
+			}
+		}()
+	}
+
+feed:
+	for i, recipient := range recipients {
+		if suppressed[recipient.email] {
+			results[i].Status = RecipientStatusSuppressed
+			continue
+		}
+		if pace != nil {
+			select {
+			case <-pace:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+		select {
+		case queue <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	counts := make(map[string]int)
+	for _, result := range results {
+		counts[result.Status]++
+	}
+	reported := results
+	if len(reported) > maxReportedRecipientResults {
+		reported = reported[:maxReportedRecipientResults]
+	}
+	RecordOutput(ctx, "recipients_total", len(recipients))
+	RecordOutput(ctx, "sent", counts[RecipientStatusSent])
+	RecordOutput(ctx, "failed", counts[RecipientStatusFailed])
+	RecordOutput(ctx, "suppressed_recipients", counts[RecipientStatusSuppressed])
+	RecordOutput(ctx, "recipient_results", reported)
+
+	RunLogger(ctx).WithFields(logrus.Fields{
+		"job_id":     job.ID,
+		"recipients": len(recipients),
+		"sent":       counts[RecipientStatusSent],
+		"failed":     counts[RecipientStatusFailed],
+		"suppressed": counts[RecipientStatusSuppressed],
+	}).Info("Batch email finished")
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if failed := counts[RecipientStatusFailed]; failed > 0 {
+		return fmt.Errorf("failed to send email to %d of %d recipients", failed, len(recipients))
+	}
+	return nil
+}
+
+// sendToRecipient renders the templates with the recipient's fields and sends the email, once per
+// run even across retries
+func (e *EmailNotificationExecutor) sendToRecipient(ctx context.Context, recipient emailRecipient, subject, body *template.Template) EmailRecipientResult {
+	result := EmailRecipientResult{Email: recipient.email}
+	fail := func(err error) EmailRecipientResult {
+		result.Status = RecipientStatusFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	if _, ok := models.NormalizeEmail(recipient.email); !ok {
+		return fail(fmt.Errorf("invalid email address"))
+	}
+	var renderedSubject, renderedBody bytes.Buffer
+	if err := subject.Execute(&renderedSubject, recipient.fields); err != nil {
+		return fail(fmt.Errorf("failed to render subject: %w", err))
+	}
+	if err := body.Execute(&renderedBody, recipient.fields); err != nil {
+		return fail(fmt.Errorf("failed to render body: %w", err))
+	}
+
+	if e.integrations != nil && e.integrations.Has(integrations.SMTPIntegration) {
+		messageID, err := PerformSideEffectOnce(ctx, "email:"+recipient.email, func() (string, error) {
+			return e.send(ctx, recipient.email, renderedSubject.String(), renderedBody.String())
+		})
+		if err != nil {
+			return fail(err)
+		}
+		result.MessageID = messageID
+	} else if err := sleepContext(ctx, simulatedEmailSendDelay); err != nil { // Simulate email sending delay
+		return fail(err)
+	}
+
+	result.Status = RecipientStatusSent
+	return result
+}
+
+// loadRecipients reads the recipients of the job's recipient source, dropping repeated addresses
+// addressField names the field holding each recipient's address
+func (e *EmailNotificationExecutor) loadRecipients(ctx context.Context, config models.JobConfig, addressField string) ([]emailRecipient, error) {
+	var rows []map[string]interface{}
+	query, _ := config["recipients_query"].(string)
+	artifact, _ := config["recipients_artifact"].(string)
+
+	switch {
+	case config["recipients"] != nil:
+		listed, ok := config["recipients"].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("recipients must be a list")
+		}
+		for i, item := range listed {
+			switch item := item.(type) {
+			case string:
+				rows = append(rows, map[string]interface{}{addressField: item})
+			case map[string]interface{}:
+				rows = append(rows, item)
+			default:
+				return nil, fmt.Errorf("recipient %d is neither an address nor an object", i)
+			}
+		}
+	case query != "":
+		connection := JobConnection(&models.Job{Config: config})
+		if connection == "" {
+			return nil, errRecipientsConnectionRequired
+		}
+		if e.data == nil {
+			return nil, fmt.Errorf("recipient queries are not configured")
+		}
+		result, err := e.data.RunQuery(ctx, connection, query)
+		if err != nil {
+			return nil, fmt.Errorf("recipients query failed: %w", err)
+		}
+		rows = result
+	case artifact != "":
+		result, err := e.readRecipientArtifact(ctx, artifact)
+		if err != nil {
+			return nil, err
+		}
+		rows = result
+	}
+
+	seen := make(map[string]bool, len(rows))
+	recipients := make([]emailRecipient, 0, len(rows))
+	for i, row := range rows {
+		address, _ := row[addressField].(string)
+		address = strings.TrimSpace(address)
+		if address == "" {
+			return nil, fmt.Errorf("recipient %d has no %q field", i, addressField)
+		}
+		key := strings.ToLower(address)
+		if normalized, ok := models.NormalizeEmail(address); ok {
+			key = normalized
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		recipients = append(recipients, emailRecipient{email: address, fields: row})
+	}
+	return recipients, nil
+}
+
+// readRecipientArtifact reads the rows of a CSV artifact, keyed by the names in its header row
+func (e *EmailNotificationExecutor) readRecipientArtifact(ctx context.Context, id string) ([]map[string]interface{}, error) {
+	if e.artifacts == nil {
+		return nil, fmt.Errorf("recipient artifacts are not configured")
+	}
+	artifactID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipients artifact ID: %s", id)
+	}
+	_, file, err := e.artifacts.OpenArtifact(ctx, artifactID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recipients artifact: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(io.LimitReader(file, maxRecipientArtifactBytes))
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recipients artifact header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+
+	var rows []map[string]interface{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read recipients artifact: %w", err)
+		}
+		row := make(map[string]interface{}, len(header))
+		for i, name := range header {
+			if i < len(record) {
+				row[name] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+// ErrEmailNotSuppressed is returned when lifting the suppression of an address that isn't suppressed
+var ErrEmailNotSuppressed = errors.New("email address is not suppressed")
+
+// emailProviderActor is recorded as having suppressed the addresses of the email provider's events
+const emailProviderActor = "email-provider"
+
+// SuppressionLookup finds suppressed addresses, so email jobs can skip them
+type SuppressionLookup interface {
+	// SuppressedRecipients returns which of the recipients, as given, are suppressed
+	SuppressedRecipients(recipients []string) (map[string]bool, error)
+}
+
+// EmailSuppressionService defines the interface for maintaining the addresses email jobs don't send to
+type EmailSuppressionService interface {
+	SuppressionLookup
+	Suppress(req *models.CreateEmailSuppressionRequest, actor string) (*models.EmailSuppression, error)
+	Unsuppress(email, actor string) error
+	ListSuppressions(page, limit int) (*models.EmailSuppressionListResponse, error)
+	HandleEmailEvents(events []models.EmailEvent) (int, error)
+}
+
+// emailSuppressionService implements EmailSuppressionService interface
+type emailSuppressionService struct {
+	repo repositories.EmailSuppressionRepository
+}
+
+// NewEmailSuppressionService creates a new email suppression service
+func NewEmailSuppressionService(repo repositories.EmailSuppressionRepository) EmailSuppressionService {
+	return &emailSuppressionService{
+		repo: repo,
+	}
+}
+
+// Suppress stops email jobs from sending to an address
+func (s *emailSuppressionService) Suppress(req *models.CreateEmailSuppressionRequest, actor string) (*models.EmailSuppression, error) {
+	if actor == "" {
+		return nil, fmt.Errorf("the suppressing user is required")
+	}
+	email, ok := models.NormalizeEmail(req.Email)
+	if !ok {
+		return nil, fmt.Errorf("invalid email address: %s", req.Email)
+	}
+	reason := req.Reason
+	if reason == "" {
+		reason = models.SuppressionManual
+	}
+	if !models.IsValidSuppressionReason(string(reason)) {
+		return nil, fmt.Errorf("invalid suppression reason: %s", reason)
+	}
+
+	suppression := &models.EmailSuppression{
+		Email:        email,
+		Reason:       reason,
+		Details:      strings.TrimSpace(req.Details),
+		SuppressedBy: actor,
+	}
+	if err := s.repo.Upsert(suppression); err != nil {
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"email":         email,
+		"reason":        reason,
+		"suppressed_by": actor,
+	}).Info("Email address suppressed")
+	return suppression, nil
+}
+
+// Unsuppress lets email jobs send to an address again
+func (s *emailSuppressionService) Unsuppress(email, actor string) error {
+	normalized, ok := models.NormalizeEmail(email)
+	if !ok {
+		return fmt.Errorf("invalid email address: %s", email)
+	}
+	deleted, err := s.repo.Delete(normalized)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrEmailNotSuppressed
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"email":           normalized,
+		"unsuppressed_by": actor,
+	}).Info("Email address suppression lifted")
+	return nil
+}
+
+// ListSuppressions retrieves a page of suppressed addresses, most recently suppressed first
+func (s *emailSuppressionService) ListSuppressions(page, limit int) (*models.EmailSuppressionListResponse, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	suppressions, totalCount, err := s.repo.List(page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.EmailSuppressionListResponse{
+		Suppressions: suppressions,
+		TotalCount:   totalCount,
+		Page:         page,
+		Limit:        limit,
+		TotalPages:   int(math.Ceil(float64(totalCount) / float64(limit))),
+	}, nil
+}
+
+// HandleEmailEvents suppresses the addresses of the email provider's bounce, complaint and unsubscribe
+// events, returning how many addresses were suppressed. Other events and invalid addresses are skipped
+func (s *emailSuppressionService) HandleEmailEvents(events []models.EmailEvent) (int, error) {
+	suppressed := 0
+	for _, event := range events {
+		reason, ok := event.SuppressionReason()
+		if !ok {
+			continue
+		}
+		email, ok := models.NormalizeEmail(event.Email)
+		if !ok {
+			logrus.WithField("email", event.Email).Warn("Skipping email event with an invalid address")
+			continue
+		}
+
+		details := event.Event
+		if event.Reason != "" {
+			details += ": " + event.Reason
+		}
+		if err := s.repo.Upsert(&models.EmailSuppression{
+			Email:        email,
+			Reason:       reason,
+			Details:      details,
+			SuppressedBy: emailProviderActor,
+		}); err != nil {
+			return suppressed, err
+		}
+		suppressed++
+	}
+
+	if suppressed > 0 {
+		logrus.WithField("suppressed", suppressed).Info("Email addresses suppressed from provider events")
+	}
+	return suppressed, nil
+}
+
+// SuppressedRecipients returns which of the recipients, as given, are suppressed
+// Recipients that aren't valid addresses are never suppressed
+func (s *emailSuppressionService) SuppressedRecipients(recipients []string) (map[string]bool, error) {
+	byAddress := make(map[string][]string, len(recipients))
+	addresses := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		address, ok := models.NormalizeEmail(recipient)
+		if !ok {
+			continue
+		}
+		if _, seen := byAddress[address]; !seen {
+			addresses = append(addresses, address)
+		}
+		byAddress[address] = append(byAddress[address], recipient)
+	}
+
+	found, err := s.repo.FindSuppressed(addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	suppressed := make(map[string]bool, len(found))
+	for _, address := range found {
+		for _, recipient := range byAddress[address] {
+			suppressed[recipient] = true
+		}
+	}
+	return suppressed, nil
+}
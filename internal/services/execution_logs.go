@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+// runLoggerKey is the context key under which a run's logger is found
+type runLoggerKey struct{}
+
+// RunLogger returns the logger an executor logs a run's lines to
+// Lines go to the application log as usual and, when the scheduler captures the run's logs, are kept
+// with the run. Without a run in the context it returns the standard logger
+func RunLogger(ctx context.Context) *logrus.Entry {
+	if entry, ok := ctx.Value(runLoggerKey{}).(*logrus.Entry); ok {
+		return entry
+	}
+	return logrus.NewEntry(logrus.StandardLogger())
+}
+
+// RunLog captures the lines logged to a run's logger, writing them in batches as the run goes
+type RunLog struct {
+	repo        repositories.JobExecutionLogRepository
+	executionID uuid.UUID
+	maxLines    int
+	mu          sync.Mutex
+	pending     []models.JobExecutionLog
+	sequence    int
+	truncated   bool
+	stop        chan struct{}
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+// StartRunLog starts capturing a run's lines and returns a context carrying its logger
+// The logger's lines carry the given fields; Close must be called once the executor returns
+func StartRunLog(ctx context.Context, repo repositories.JobExecutionLogRepository, executionID uuid.UUID, fields logrus.Fields, cfg config.ExecutionLogsConfig) (context.Context, *RunLog) {
+	runLog := &RunLog{
+		repo:        repo,
+		executionID: executionID,
+		maxLines:    cfg.MaxLines,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+
+	// The run's logger only feeds the hook, which passes each line on to the standard logger
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	logger.SetLevel(logrus.StandardLogger().GetLevel())
+	logger.AddHook(runLogHook{runLog: runLog})
+
+	go runLog.flushPeriodically(cfg.FlushInterval)
+	return context.WithValue(ctx, runLoggerKey{}, logger.WithFields(fields)), runLog
+}
+
+// flushPeriodically writes captured lines every interval until the log is closed
+func (l *RunLog) flushPeriodically(interval time.Duration) {
+	defer close(l.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			l.flush()
+		case <-l.stop:
+			l.flush()
+			return
+		}
+	}
+}
+
+// Close writes the remaining lines; lines logged afterwards are only sent to the application log
+func (l *RunLog) Close() {
+	l.closeOnce.Do(func() {
+		close(l.stop)
+		<-l.done
+	})
+}
+
+// capture queues a line for writing, dropping lines beyond the run's limit
+func (l *RunLog) capture(entry *logrus.Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	select {
+	case <-l.stop:
+		return
+	default:
+	}
+	if l.truncated {
+		return
+	}
+	if l.sequence >= l.maxLines {
+		l.truncated = true
+		l.sequence++
+		l.pending = append(l.pending, models.JobExecutionLog{
+			ExecutionID: l.executionID,
+			Sequence:    l.sequence,
+			Level:       logrus.WarnLevel.String(),
+			Message:     fmt.Sprintf("Log truncated after %d lines", l.maxLines),
+			LoggedAt:    time.Now().UTC(),
+		})
+		return
+	}
+
+	fields := make(models.JobConfig, len(entry.Data))
+	for key, value := range entry.Data {
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		fields[key] = value
+	}
+	l.sequence++
+	l.pending = append(l.pending, models.JobExecutionLog{
+		ExecutionID: l.executionID,
+		Sequence:    l.sequence,
+		Level:       entry.Level.String(),
+		Message:     entry.Message,
+		Fields:      fields,
+		LoggedAt:    entry.Time.UTC(),
+	})
+}
+
+// flush writes the queued lines, dropping them if they can't be written
+func (l *RunLog) flush() {
+	l.mu.Lock()
+	lines := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	if err := l.repo.CreateBatch(lines); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"execution_id": l.executionID,
+			"lines":        len(lines),
+			"error":        err,
+		}).Warn("Failed to write run log lines")
+	}
+}
+
+// runLogHook captures a run logger's lines and passes them on to the standard logger
+type runLogHook struct {
+	runLog *RunLog
+}
+
+// Levels returns every level; the run logger's own level filters what reaches the hook
+func (h runLogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire captures the line and logs it to the application log
+func (h runLogHook) Fire(entry *logrus.Entry) error {
+	h.runLog.capture(entry)
+	logrus.StandardLogger().WithFields(entry.Data).WithTime(entry.Time).Log(entry.Level, entry.Message)
+	return nil
+}
+
+// ExecutionLogService defines the interface for reading the lines runs logged
+type ExecutionLogService interface {
+	GetExecutionLogs(executionID uuid.UUID, after, limit int) (*models.ExecutionLogPage, error)
+}
+
+// executionLogService implements ExecutionLogService interface
+type executionLogService struct {
+	logRepo       repositories.JobExecutionLogRepository
+	executionRepo repositories.JobExecutionRepository
+}
+
+// NewExecutionLogService creates a new execution log service
+func NewExecutionLogService(logRepo repositories.JobExecutionLogRepository, executionRepo repositories.JobExecutionRepository) ExecutionLogService {
+	return &executionLogService{
+		logRepo:       logRepo,
+		executionRepo: executionRepo,
+	}
+}
+
+// GetExecutionLogs returns up to limit of a run's lines logged after the given sequence
+// The run's status is read before its lines, so a finished page holds every line up to its end
+func (s *executionLogService) GetExecutionLogs(executionID uuid.UUID, after, limit int) (*models.ExecutionLogPage, error) {
+	execution, err := s.executionRepo.GetByID(executionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get execution: %w", err)
+	}
+
+	lines, err := s.logRepo.GetByExecutionID(executionID, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get execution logs: %w", err)
+	}
+
+	page := &models.ExecutionLogPage{
+		Lines:     lines,
+		NextAfter: after,
+		Finished:  execution.IsCompleted(),
+	}
+	if len(lines) > 0 {
+		page.NextAfter = lines[len(lines)-1].Sequence
+	}
+	return page, nil
+}
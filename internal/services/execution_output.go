@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"job-scheduler/internal/models"
+)
+
+// outputRecorderKey is the context key under which a run's OutputRecorder is found
+type outputRecorderKey struct{}
+
+// OutputRecorder collects the structured output of a run
+// The scheduler gives every run one through its context; executors record to it with RecordOutput
+type OutputRecorder struct {
+	mu      sync.Mutex
+	output  models.ExecutionOutput
+	dropped int
+}
+
+// NewOutputRecorder creates an empty output recorder
+func NewOutputRecorder() *OutputRecorder {
+	return &OutputRecorder{output: make(models.ExecutionOutput)}
+}
+
+// WithOutputRecorder returns a context carrying the recorder for executors to record to
+func WithOutputRecorder(ctx context.Context, recorder *OutputRecorder) context.Context {
+	return context.WithValue(ctx, outputRecorderKey{}, recorder)
+}
+
+// RecordOutput records a value of the run's output under key, replacing any value recorded under it
+// It does nothing when the context carries no recorder, such as when an executor is called directly
+func RecordOutput(ctx context.Context, key string, value interface{}) {
+	if recorder, ok := ctx.Value(outputRecorderKey{}).(*OutputRecorder); ok {
+		recorder.Set(key, value)
+	}
+}
+
+// Set records a value under key
+// Long strings are truncated, values that can't be stored as JSON are stored as text, and keys
+// beyond MaxExecutionOutputKeys are dropped
+func (r *OutputRecorder) Set(key string, value interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.output[key]; !exists && len(r.output) >= models.MaxExecutionOutputKeys {
+		r.dropped++
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		value = truncateOutput(v)
+	case []byte:
+		value = truncateOutput(string(v))
+	default:
+		if _, err := json.Marshal(v); err != nil {
+			value = truncateOutput(fmt.Sprint(v))
+		}
+	}
+	r.output[key] = value
+}
+
+// Output returns a copy of the recorded output, or nil if nothing was recorded
+func (r *OutputRecorder) Output() models.ExecutionOutput {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.output) == 0 {
+		return nil
+	}
+	output := make(models.ExecutionOutput, len(r.output)+1)
+	for key, value := range r.output {
+		output[key] = value
+	}
+	if r.dropped > 0 {
+		output["_dropped_keys"] = r.dropped
+	}
+	return output
+}
+
+// truncateOutput shortens a string value to MaxExecutionOutputValueLength bytes
+func truncateOutput(value string) string {
+	if len(value) <= models.MaxExecutionOutputValueLength {
+		return value
+	}
+	return value[:models.MaxExecutionOutputValueLength] + "...(truncated)"
+}
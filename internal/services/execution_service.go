@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+var (
+	// ErrExecutionFinished is returned when cancelling a run that has already finished
+	ErrExecutionFinished = errors.New("execution has already finished")
+	// ErrExecutionNotRunning is returned when cancelling or extending a run that isn't executing on this instance
+	ErrExecutionNotRunning = errors.New("execution is not running on this instance")
+	// ErrInvalidExtension is returned when extending a run's deadline by a duration that isn't positive
+	ErrInvalidExtension = errors.New("extension must be a positive duration")
+	// ErrExtensionLimit is returned when extending a run already at its maximum execution time
+	ErrExtensionLimit = errors.New("execution is already at its maximum execution time")
+	// ErrInvalidDeadline is returned when moving a run's deadline to a time that has already passed
+	ErrInvalidDeadline = errors.New("deadline must be in the future")
+)
+
+// minEstimatedExecutionCount is the smallest estimate of a job's runs a page reports as is; smaller
+// estimates are replaced by an exact count, which is cheap at that size and may differ widely
+const minEstimatedExecutionCount = 10000
+
+// RunController stops runs and moves their deadlines that are executing in the background
+// It is implemented by the scheduler
+type RunController interface {
+	CancelRun(executionID uuid.UUID, force bool) bool
+	ExtendRun(executionID uuid.UUID, by time.Duration) (time.Time, error)
+	SetRunDeadline(executionID uuid.UUID, deadline time.Time) (time.Time, error)
+}
+
+// ExecutionService defines the interface for run history and managing individual runs
+type ExecutionService interface {
+	GetExecution(executionID uuid.UUID) (*models.JobExecution, error)
+	GetJobExecutions(ctx context.Context, jobID uuid.UUID, page, limit int, exact bool) (*models.JobExecutionListResponse, error)
+	CountJobExecutions(ctx context.Context, jobID uuid.UUID) (int64, error)
+	GetRecentExecutions(ctx context.Context, limit int) ([]models.JobExecution, error)
+	CancelExecution(executionID uuid.UUID, force bool) (*models.JobExecution, error)
+	ExtendExecution(executionID uuid.UUID, by time.Duration) (*models.JobExecution, time.Time, error)
+	SetExecutionDeadline(executionID uuid.UUID, deadline time.Time) (*models.JobExecution, time.Time, error)
+}
+
+// executionService implements ExecutionService interface
+type executionService struct {
+	jobRepo       repositories.JobRepository
+	executionRepo repositories.JobExecutionRepository
+	runs          RunController
+}
+
+// NewExecutionService creates a new execution service
+func NewExecutionService(
+	jobRepo repositories.JobRepository,
+	executionRepo repositories.JobExecutionRepository,
+	runs RunController,
+) ExecutionService {
+	return &executionService{
+		jobRepo:       jobRepo,
+		executionRepo: executionRepo,
+		runs:          runs,
+	}
+}
+
+// GetExecution retrieves a run with its job
+func (s *executionService) GetExecution(executionID uuid.UUID) (*models.JobExecution, error) {
+	execution, err := s.executionRepo.GetByID(executionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get execution: %w", err)
+	}
+	return execution, nil
+}
+
+// GetJobExecutions retrieves a page of a job's runs, newest first
+// Unless exact is set the total is estimated, unless the job has few runs or the page is the last one,
+// since counting every run of a busy job takes longer than fetching the page
+func (s *executionService) GetJobExecutions(ctx context.Context, jobID uuid.UUID, page, limit int, exact bool) (*models.JobExecutionListResponse, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	if _, err := s.jobRepo.GetByID(jobID); err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	var executions []models.JobExecution
+	var totalCount int64
+	var estimated bool
+	var err error
+	if exact {
+		executions, totalCount, err = s.executionRepo.GetByJobID(ctx, jobID, page, limit)
+	} else {
+		executions, totalCount, estimated, err = s.estimatedJobExecutions(ctx, jobID, page, limit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job executions: %w", err)
+	}
+
+	return &models.JobExecutionListResponse{
+		Executions:          executions,
+		TotalCount:          totalCount,
+		Page:                page,
+		Limit:               limit,
+		TotalPages:          int(math.Ceil(float64(totalCount) / float64(limit))),
+		TotalCountEstimated: estimated,
+	}, nil
+}
+
+// estimatedJobExecutions retrieves a page of a job's runs with an estimate of how many it has
+// A short page ends the list, so its runs and those before it are the exact total
+func (s *executionService) estimatedJobExecutions(ctx context.Context, jobID uuid.UUID, page, limit int) ([]models.JobExecution, int64, bool, error) {
+	executions, err := s.executionRepo.GetPageByJobID(ctx, jobID, page, limit)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	seen := int64((page-1)*limit + len(executions))
+	if len(executions) > 0 && len(executions) < limit {
+		return executions, seen, false, nil
+	}
+
+	estimate, err := s.executionRepo.EstimateCountByJobID(ctx, jobID)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	if estimate < minEstimatedExecutionCount {
+		count, err := s.executionRepo.CountByJobID(ctx, jobID)
+		return executions, count, false, err
+	}
+	if estimate < seen {
+		estimate = seen
+	}
+	return executions, estimate, true, nil
+}
+
+// CountJobExecutions counts a job's runs exactly
+func (s *executionService) CountJobExecutions(ctx context.Context, jobID uuid.UUID) (int64, error) {
+	if _, err := s.jobRepo.GetByID(jobID); err != nil {
+		return 0, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	count, err := s.executionRepo.CountByJobID(ctx, jobID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count job executions: %w", err)
+	}
+	return count, nil
+}
+
+// GetRecentExecutions retrieves the most recent runs across all jobs
+func (s *executionService) GetRecentExecutions(ctx context.Context, limit int) ([]models.JobExecution, error) {
+	if limit < 1 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	executions, err := s.executionRepo.GetRecentExecutions(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent executions: %w", err)
+	}
+	return executions, nil
+}
+
+// CancelExecution stops a running execution
+// The executor is cancelled in the background; the run is recorded as cancelled once it stops
+// A forced cancel records the run as killed without waiting for its executor to stop
+// Stalled and interrupted runs have no executor left, so they are recorded as cancelled straight away;
+// cancelling an interrupted run keeps it from being requeued
+func (s *executionService) CancelExecution(executionID uuid.UUID, force bool) (*models.JobExecution, error) {
+	execution, err := s.executionRepo.GetByID(executionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get execution: %w", err)
+	}
+
+	if execution.IsCompleted() {
+		return nil, ErrExecutionFinished
+	}
+	if status := execution.Status; status == models.ExecutionStatusStalled || status == models.ExecutionStatusInterrupted {
+		if err := execution.MarkAsCancelled(); err != nil {
+			return nil, err
+		}
+		if err := s.executionRepo.Update(execution); err != nil {
+			return nil, fmt.Errorf("failed to cancel %s execution: %w", status, err)
+		}
+		return execution, nil
+	}
+	if !s.runs.CancelRun(executionID, force) {
+		return nil, ErrExecutionNotRunning
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":       execution.JobID,
+		"execution_id": execution.ID,
+	}).Info("Execution cancellation requested")
+
+	return execution, nil
+}
+
+// ExtendExecution moves a running execution's deadline later, so a legitimately long run isn't timed out
+// It returns the run and its new deadline, which never exceeds the run's maximum execution time
+func (s *executionService) ExtendExecution(executionID uuid.UUID, by time.Duration) (*models.JobExecution, time.Time, error) {
+	if by <= 0 {
+		return nil, time.Time{}, ErrInvalidExtension
+	}
+
+	execution, err := s.executionRepo.GetByID(executionID)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to get execution: %w", err)
+	}
+
+	if execution.IsCompleted() {
+		return nil, time.Time{}, ErrExecutionFinished
+	}
+	deadline, err := s.runs.ExtendRun(executionID, by)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":       execution.JobID,
+		"execution_id": execution.ID,
+		"deadline":     deadline,
+	}).Info("Execution deadline extended")
+
+	return execution, deadline, nil
+}
+
+// SetExecutionDeadline moves a running execution's deadline to the given time, either to give a long run
+// more time or to stop a run sooner. It returns the run and its new deadline, which never exceeds
+// the run's maximum execution time
+func (s *executionService) SetExecutionDeadline(executionID uuid.UUID, deadline time.Time) (*models.JobExecution, time.Time, error) {
+	if !deadline.After(time.Now()) {
+		return nil, time.Time{}, ErrInvalidDeadline
+	}
+
+	execution, err := s.executionRepo.GetByID(executionID)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to get execution: %w", err)
+	}
+
+	if execution.IsCompleted() {
+		return nil, time.Time{}, ErrExecutionFinished
+	}
+	deadline, err = s.runs.SetRunDeadline(executionID, deadline)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":       execution.JobID,
+		"execution_id": execution.ID,
+		"deadline":     deadline,
+	}).Info("Execution deadline changed")
+
+	return execution, deadline, nil
+}
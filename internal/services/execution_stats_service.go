@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+// ExecutionStatsService defines the interface for run statistics and resource usage
+type ExecutionStatsService interface {
+	GetJobStats(jobID uuid.UUID) (*models.JobExecutionStats, error)
+	GetOverallStats() (*models.OverallExecutionStats, error)
+	GetStatsByJobType(window time.Duration) ([]models.JobTypeExecutionStats, error)
+	GetThroughput(from, to time.Time, interval time.Duration) ([]models.ThroughputBucket, error)
+	GetResourceUsage(jobID uuid.UUID, limit int) ([]models.ExecutionUsagePoint, error)
+}
+
+// executionStatsService implements ExecutionStatsService interface
+type executionStatsService struct {
+	jobRepo       repositories.JobRepository
+	executionRepo repositories.JobExecutionRepository
+}
+
+// NewExecutionStatsService creates a new execution stats service
+func NewExecutionStatsService(jobRepo repositories.JobRepository, executionRepo repositories.JobExecutionRepository) ExecutionStatsService {
+	return &executionStatsService{
+		jobRepo:       jobRepo,
+		executionRepo: executionRepo,
+	}
+}
+
+// GetJobStats summarizes a job's runs, including their average resource usage
+func (s *executionStatsService) GetJobStats(jobID uuid.UUID) (*models.JobExecutionStats, error) {
+	if _, err := s.jobRepo.GetByID(jobID); err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	stats, err := s.executionRepo.GetExecutionStats(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get execution stats: %w", err)
+	}
+	return stats, nil
+}
+
+// GetOverallStats summarizes the runs of all jobs, including failures in the last 24 hours
+func (s *executionStatsService) GetOverallStats() (*models.OverallExecutionStats, error) {
+	_, totalJobs, err := s.jobRepo.GetAll(context.Background(), 1, 1, models.JobSortNewest, models.JobFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count jobs: %w", err)
+	}
+
+	stats, err := s.executionRepo.GetOverallStats(time.Now().UTC().Add(-24 * time.Hour))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get execution stats: %w", err)
+	}
+	stats.TotalJobs = totalJobs
+	return stats, nil
+}
+
+// GetStatsByJobType summarizes the runs of each job type started within the window
+func (s *executionStatsService) GetStatsByJobType(window time.Duration) ([]models.JobTypeExecutionStats, error) {
+	stats, err := s.executionRepo.GetStatsByJobType(time.Now().UTC().Add(-window))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get execution stats: %w", err)
+	}
+	return stats, nil
+}
+
+// GetThroughput counts the runs started, completed and failed in each interval from from until to,
+// returning every bucket, those without runs included, so the series can be charted as is
+func (s *executionStatsService) GetThroughput(from, to time.Time, interval time.Duration) ([]models.ThroughputBucket, error) {
+	counted, err := s.executionRepo.GetThroughput(from, to, interval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get execution throughput: %w", err)
+	}
+
+	buckets := make([]models.ThroughputBucket, 0, int(to.Sub(from)/interval)+1)
+	next := 0
+	for start := from; start.Before(to); start = start.Add(interval) {
+		bucket := models.ThroughputBucket{Start: start}
+		if next < len(counted) && counted[next].Start.Equal(start) {
+			bucket = counted[next]
+			next++
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets, nil
+}
+
+// GetResourceUsage returns the resource usage of a job's most recent runs, oldest first for graphing
+func (s *executionStatsService) GetResourceUsage(jobID uuid.UUID, limit int) ([]models.ExecutionUsagePoint, error) {
+	if limit < 1 || limit > 500 {
+		limit = 100 // Default limit
+	}
+
+	if _, err := s.jobRepo.GetByID(jobID); err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	executions, err := s.executionRepo.GetWithResourceUsage(jobID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource usage: %w", err)
+	}
+
+	points := make([]models.ExecutionUsagePoint, 0, len(executions))
+	for i := len(executions) - 1; i >= 0; i-- {
+		execution := executions[i]
+		points = append(points, models.ExecutionUsagePoint{
+			ExecutionID: execution.ID,
+			StartedAt:   execution.StartedAt,
+			Status:      execution.Status,
+			DurationMs:  execution.ExecutionDuration,
+			Usage:       execution.ResourceUsage,
+		})
+	}
+	return points, nil
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"job-scheduler/internal/models"
+)
+
+// ErrJobTypeRegistered is returned when registering an executor for a job type that already has one
+var ErrJobTypeRegistered = errors.New("job type already has an executor")
+
+// registry holds executors for custom job types registered by embedders
+var registry = struct {
+	mu        sync.RWMutex
+	executors map[models.JobType]JobExecutor
+}{executors: make(map[models.JobType]JobExecutor)}
+
+// RegisterExecutor adds an executor for a custom job type
+// Register executors before creating the scheduler; jobs of the type are accepted once it is registered
+func RegisterExecutor(jobType models.JobType, executor JobExecutor) error {
+	if jobType == "" {
+		return errors.New("job type is required")
+	}
+	if executor == nil {
+		return fmt.Errorf("executor for %s is nil", jobType)
+	}
+	if executor.GetJobType() != jobType {
+		return fmt.Errorf("executor runs %s jobs, not %s", executor.GetJobType(), jobType)
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if _, exists := registry.executors[jobType]; exists || models.IsValidJobType(string(jobType)) {
+		return fmt.Errorf("%w: %s", ErrJobTypeRegistered, jobType)
+	}
+	registry.executors[jobType] = executor
+	return nil
+}
+
+// RegisteredExecutors returns the executors registered for custom job types
+func RegisteredExecutors() map[models.JobType]JobExecutor {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	executors := make(map[models.JobType]JobExecutor, len(registry.executors))
+	for jobType, executor := range registry.executors {
+		executors[jobType] = executor
+	}
+	return executors
+}
+
+// IsSupportedJobType reports whether jobs of the type can run, either built in or registered
+func IsSupportedJobType(jobType models.JobType) bool {
+	if models.IsValidJobType(string(jobType)) {
+		return true
+	}
+
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	_, exists := registry.executors[jobType]
+	return exists
+}
@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"job-scheduler/internal/models"
+)
+
+// Health check probe types
+const (
+	HealthProbeHTTP = "http"
+	HealthProbeTCP  = "tcp"
+	HealthProbeDNS  = "dns"
+)
+
+// defaultHealthCheckURL is checked when a health check job configures no probes
+const defaultHealthCheckURL = "https://httpbin.org/status/200"
+
+// maxProbeBodyOutput caps the response body kept in each failed HTTP probe's result when a job runs
+// several probes
+const maxProbeBodyOutput = 512
+
+// healthProbe is one check of a health check job: an HTTP request, a TCP connect or a DNS lookup
+type healthProbe struct {
+	name      string
+	probeType string
+	target    string
+
+	method         string
+	headers        map[string]string
+	body           string
+	expectedStatus int
+	bodyPattern    *regexp.Regexp
+	insecureTLS    bool
+	serverName     string
+
+	expectedAddress string
+
+	maxLatency time.Duration
+	timeout    time.Duration
+}
+
+// HealthProbeResult is the outcome of one probe, recorded in the run's output
+type HealthProbeResult struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Target     string   `json:"target"`
+	OK         bool     `json:"ok"`
+	LatencyMS  int64    `json:"latency_ms"`
+	StatusCode int      `json:"status_code,omitempty"`
+	Addresses  []string `json:"addresses,omitempty"`
+	Body       string   `json:"response_body,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// parseHealthProbes reads a health check job's probes from its config
+// config["probes"] lists probes, config["urls"] lists URLs to GET and config["url"] is a single URL;
+// request options, assertions and thresholds set at the top level apply to every probe that doesn't
+// set its own
+func parseHealthProbes(config models.JobConfig) ([]healthProbe, error) {
+	defaults, err := parseHealthProbe(config, healthProbe{
+		probeType:      HealthProbeHTTP,
+		method:         http.MethodGet,
+		expectedStatus: http.StatusOK,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var probes []healthProbe
+	if listed, ok := config["probes"].([]interface{}); ok {
+		for i, raw := range listed {
+			fields, ok := raw.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("probe %d is not an object", i+1)
+			}
+			probe, err := parseHealthProbe(fields, defaults)
+			if err != nil {
+				return nil, fmt.Errorf("probe %d: %w", i+1, err)
+			}
+			probes = append(probes, probe)
+		}
+	}
+	if urls, ok := config["urls"].([]interface{}); ok {
+		for i, raw := range urls {
+			url, ok := raw.(string)
+			if !ok || url == "" {
+				return nil, fmt.Errorf("urls[%d] is not a URL", i)
+			}
+			probe := defaults
+			probe.probeType, probe.target = HealthProbeHTTP, url
+			probes = append(probes, probe)
+		}
+	}
+	if len(probes) == 0 {
+		probe := defaults
+		if probe.target == "" && probe.probeType == HealthProbeHTTP {
+			probe.target = defaultHealthCheckURL
+		}
+		probes = append(probes, probe)
+	}
+
+	for i := range probes {
+		if probes[i].target == "" {
+			return nil, fmt.Errorf("%s probe %d has no target", probes[i].probeType, i+1)
+		}
+		if probes[i].name == "" {
+			probes[i].name = probes[i].target
+		}
+	}
+	return probes, nil
+}
+
+// parseHealthProbe reads a probe's settings, starting from defaults
+func parseHealthProbe(fields map[string]interface{}, defaults healthProbe) (healthProbe, error) {
+	probe := defaults
+	probe.name, probe.target = "", ""
+
+	if name, ok := fields["name"].(string); ok {
+		probe.name = name
+	}
+	if probeType, ok := fields["type"].(string); ok && probeType != "" {
+		probe.probeType = strings.ToLower(probeType)
+	}
+	switch probe.probeType {
+	case HealthProbeHTTP:
+		probe.target, _ = fields["url"].(string)
+	case HealthProbeTCP:
+		probe.target, _ = fields["address"].(string)
+	case HealthProbeDNS:
+		probe.target, _ = fields["host"].(string)
+	default:
+		return probe, fmt.Errorf("unknown probe type %q", probe.probeType)
+	}
+
+	if method, ok := fields["method"].(string); ok && method != "" {
+		probe.method = strings.ToUpper(method)
+	}
+	if headers, ok := fields["headers"].(map[string]interface{}); ok {
+		probe.headers = make(map[string]string, len(headers))
+		for name, value := range headers {
+			probe.headers[name] = fmt.Sprint(value)
+		}
+	}
+	if body, ok := fields["body"].(string); ok {
+		probe.body = body
+	}
+	if status, ok := fields["expected_status"].(float64); ok {
+		probe.expectedStatus = int(status)
+	}
+	if pattern, ok := fields["body_regex"].(string); ok && pattern != "" {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return probe, fmt.Errorf("invalid body_regex: %w", err)
+		}
+		probe.bodyPattern = compiled
+	}
+	if insecure, ok := fields["insecure_skip_verify"].(bool); ok {
+		probe.insecureTLS = insecure
+	}
+	if serverName, ok := fields["tls_server_name"].(string); ok {
+		probe.serverName = serverName
+	}
+	if address, ok := fields["expected_address"].(string); ok {
+		probe.expectedAddress = address
+	}
+	if latency, ok := fields["max_latency_ms"].(float64); ok && latency > 0 {
+		probe.maxLatency = time.Duration(latency * float64(time.Millisecond))
+	}
+	if timeout, ok := fields["timeout_seconds"].(float64); ok && timeout > 0 {
+		probe.timeout = time.Duration(timeout * float64(time.Second))
+	}
+	return probe, nil
+}
+
+// runProbe runs one probe, bounded by its own timeout if it has one, and checks its latency threshold
+// HTTP requests count against the job's call budget, if it declares one
+func (h *HealthCheckExecutor) runProbe(ctx context.Context, budget *CallBudget, probe healthProbe) HealthProbeResult {
+	if probe.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, probe.timeout)
+		defer cancel()
+	}
+
+	result := HealthProbeResult{Name: probe.name, Type: probe.probeType, Target: probe.target}
+	start := time.Now()
+	var err error
+	switch probe.probeType {
+	case HealthProbeTCP:
+		err = probeTCP(ctx, probe)
+	case HealthProbeDNS:
+		result.Addresses, err = probeDNS(ctx, probe)
+	default:
+		client := h.httpClient
+		if probe.insecureTLS || probe.serverName != "" {
+			client = tlsProbeClient(client, probe)
+			defer client.CloseIdleConnections()
+		}
+		if budget != nil {
+			client = budget.Client(client)
+		}
+		err = probeHTTP(ctx, client, probe, &result)
+	}
+	latency := time.Since(start)
+	result.LatencyMS = latency.Milliseconds()
+
+	if err == nil && probe.maxLatency > 0 && latency > probe.maxLatency {
+		err = fmt.Errorf("took %dms, over the %dms threshold", latency.Milliseconds(), probe.maxLatency.Milliseconds())
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.OK = true
+	return result
+}
+
+// probeHTTP sends the probe's request and checks the status code and body
+func probeHTTP(ctx context.Context, client *http.Client, probe healthProbe, result *HealthProbeResult) error {
+	var body io.Reader
+	if probe.body != "" {
+		body = strings.NewReader(probe.body)
+	}
+	req, err := http.NewRequestWithContext(ctx, probe.method, probe.target, body)
+	if err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+	for name, value := range probe.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Keep the start of the response body for the run's output, successful or not
+	content, _ := ioutil.ReadAll(io.LimitReader(resp.Body, models.MaxExecutionOutputValueLength))
+	result.StatusCode = resp.StatusCode
+	result.Body = string(content)
+
+	if resp.StatusCode != probe.expectedStatus {
+		return fmt.Errorf("expected status %d, got %d", probe.expectedStatus, resp.StatusCode)
+	}
+	if probe.bodyPattern != nil && !probe.bodyPattern.Match(content) {
+		return fmt.Errorf("response body doesn't match %q", probe.bodyPattern.String())
+	}
+	return nil
+}
+
+// tlsProbeClient returns a client for a probe with its own TLS settings, keeping the shared client's
+// timeout but connecting through a dedicated transport
+func tlsProbeClient(client *http.Client, probe healthProbe) *http.Client {
+	return &http.Client{
+		Timeout: client.Timeout,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: probe.insecureTLS,
+				ServerName:         probe.serverName,
+			},
+		},
+	}
+}
+
+// probeTCP checks that the probe's address accepts TCP connections
+func probeTCP(ctx context.Context, probe healthProbe) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", probe.target)
+	if err != nil {
+		return fmt.Errorf("connect failed: %w", err)
+	}
+	return conn.Close()
+}
+
+// probeDNS resolves the probe's host, checking it resolves to the expected address if one is set
+func probeDNS(ctx context.Context, probe healthProbe) ([]string, error) {
+	addresses, err := net.DefaultResolver.LookupHost(ctx, probe.target)
+	if err != nil {
+		return nil, fmt.Errorf("lookup failed: %w", err)
+	}
+	if probe.expectedAddress == "" {
+		return addresses, nil
+	}
+	for _, address := range addresses {
+		if address == probe.expectedAddress {
+			return addresses, nil
+		}
+	}
+	return addresses, fmt.Errorf("resolved to %s, not %s", strings.Join(addresses, ", "), probe.expectedAddress)
+}
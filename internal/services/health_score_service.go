@@ -0,0 +1,190 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+// Health score weights of each signal; they add up to 100
+const (
+	healthSuccessWeight  = 40
+	healthLatenessWeight = 20
+	healthDurationWeight = 20
+	healthAlertWeight    = 20
+)
+
+const (
+	// healthScoreRuns is how many of a job's most recent finished runs its health score is based on
+	healthScoreRuns = 50
+	// healthLatenessLimit is the average start delay of scheduled runs at which lateness scores nothing
+	healthLatenessLimit = 5 * time.Minute
+	// healthDurationLimit is how many times slower recent runs may get than earlier ones before the trend scores nothing
+	healthDurationLimit = 2.0
+	// healthAlertWindow is how far back failed runs, each of which alerts on-call, are counted
+	healthAlertWindow = 24 * time.Hour
+	// healthAlertLimit is the number of failed runs within the window at which alert volume scores nothing
+	healthAlertLimit = 10
+)
+
+// HealthScoreService defines the interface for job health scoring
+type HealthScoreService interface {
+	RecalculateHealthScores() (int, error)
+}
+
+// healthScoreService implements HealthScoreService interface
+type healthScoreService struct {
+	jobRepo       repositories.JobRepository
+	executionRepo repositories.JobExecutionRepository
+}
+
+// NewHealthScoreService creates a new health score service
+func NewHealthScoreService(jobRepo repositories.JobRepository, executionRepo repositories.JobExecutionRepository) HealthScoreService {
+	return &healthScoreService{
+		jobRepo:       jobRepo,
+		executionRepo: executionRepo,
+	}
+}
+
+// RecalculateHealthScores scores every active job from its recent runs and returns how many were scored
+// Jobs without finished runs aren't scored
+func (s *healthScoreService) RecalculateHealthScores() (int, error) {
+	jobs, err := s.jobRepo.GetActiveJobs()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get active jobs: %w", err)
+	}
+
+	now := time.Now().UTC()
+	scored := 0
+	for _, job := range jobs {
+		runs, err := s.executionRepo.GetRecentFinished(job.ID, healthScoreRuns)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"job_id": job.ID,
+				"error":  err,
+			}).Error("Failed to get runs for health score")
+			continue
+		}
+
+		score, ok := scoreJobHealth(runs, now)
+		if !ok {
+			continue
+		}
+		if err := s.jobRepo.UpdateHealthScore(job.ID, score, now); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"job_id": job.ID,
+				"error":  err,
+			}).Error("Failed to record health score")
+			continue
+		}
+		scored++
+	}
+	return scored, nil
+}
+
+// scoreJobHealth combines the success rate, lateness, duration trend and alert volume of a job's
+// finished runs, newest first, into a score from 0 (unhealthy) to 100 (healthy)
+// It returns false if there are no runs to score
+func scoreJobHealth(runs []models.JobExecution, now time.Time) (int, bool) {
+	if len(runs) == 0 {
+		return 0, false
+	}
+
+	score := healthSuccessWeight*successRate(runs) +
+		healthLatenessWeight*latenessScore(runs) +
+		healthDurationWeight*durationTrendScore(runs) +
+		healthAlertWeight*alertScore(runs, now)
+	return int(math.Round(score)), true
+}
+
+// successRate is the share of runs that completed
+func successRate(runs []models.JobExecution) float64 {
+	completed := 0
+	for _, run := range runs {
+		if run.Status == models.ExecutionStatusCompleted {
+			completed++
+		}
+	}
+	return float64(completed) / float64(len(runs))
+}
+
+// latenessScore falls from 1 to 0 as the average start delay of scheduled first attempts reaches the limit
+func latenessScore(runs []models.JobExecution) float64 {
+	average, ok := averageStartDelay(runs)
+	if !ok {
+		return 1
+	}
+	return clampUnit(1 - float64(average)/float64(healthLatenessLimit))
+}
+
+// averageStartDelay returns how late the scheduled first attempts among the runs started on average
+// Retries start late on purpose, so they don't count. It returns false if no run was scheduled
+func averageStartDelay(runs []models.JobExecution) (time.Duration, bool) {
+	var total time.Duration
+	scheduled := 0
+	for _, run := range runs {
+		if run.ScheduledFor == nil || run.Attempt > 1 {
+			continue
+		}
+		if delay := run.StartedAt.Sub(*run.ScheduledFor); delay > 0 {
+			total += delay
+		}
+		scheduled++
+	}
+	if scheduled == 0 {
+		return 0, false
+	}
+	return total / time.Duration(scheduled), true
+}
+
+// durationTrendScore compares the average duration of the newer half of the runs with the older half
+// It is 1 while runs aren't getting slower and falls to 0 as they reach the limit
+func durationTrendScore(runs []models.JobExecution) float64 {
+	var durations []int64
+	for _, run := range runs {
+		if run.ExecutionDuration != nil {
+			durations = append(durations, *run.ExecutionDuration)
+		}
+	}
+	if len(durations) < 4 {
+		return 1
+	}
+
+	half := len(durations) / 2
+	recent, earlier := averageOf(durations[:half]), averageOf(durations[half:])
+	if earlier <= 0 {
+		return 1
+	}
+	return clampUnit((healthDurationLimit - recent/earlier) / (healthDurationLimit - 1))
+}
+
+// alertScore falls from 1 to 0 as the number of runs that failed within the alert window reaches the limit
+func alertScore(runs []models.JobExecution, now time.Time) float64 {
+	since := now.Add(-healthAlertWindow)
+	alerts := 0
+	for _, run := range runs {
+		if run.Status != models.ExecutionStatusCompleted && run.StartedAt.After(since) {
+			alerts++
+		}
+	}
+	return clampUnit(1 - float64(alerts)/healthAlertLimit)
+}
+
+// averageOf returns the mean of the values
+func averageOf(values []int64) float64 {
+	var sum int64
+	for _, value := range values {
+		sum += value
+	}
+	return float64(sum) / float64(len(values))
+}
+
+// clampUnit limits a value to between 0 and 1
+func clampUnit(value float64) float64 {
+	return math.Max(0, math.Min(1, value))
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+// historyJobPageSize is how many jobs are loaded at a time while pruning
+const historyJobPageSize = 100
+
+// HistoryService defines the interface for pruning old run history
+type HistoryService interface {
+	PruneExecutions() (int64, error)
+	GetCleanupStats() models.HistoryCleanupStats
+}
+
+// historyService implements HistoryService interface
+type historyService struct {
+	jobRepo       repositories.JobRepository
+	executionRepo repositories.JobExecutionRepository
+	cfg           config.HistoryConfig
+	mu            sync.Mutex
+	stats         models.HistoryCleanupStats
+}
+
+// NewHistoryService creates a new history service
+func NewHistoryService(jobRepo repositories.JobRepository, executionRepo repositories.JobExecutionRepository, cfg config.HistoryConfig) HistoryService {
+	return &historyService{
+		jobRepo:       jobRepo,
+		executionRepo: executionRepo,
+		cfg:           cfg,
+	}
+}
+
+// PruneExecutions deletes every job's finished runs older than its retention, in batches, and returns
+// how many were deleted
+func (s *historyService) PruneExecutions() (int64, error) {
+	start := time.Now().UTC()
+	pruned, err := s.pruneAll(start)
+
+	s.mu.Lock()
+	s.stats.Runs++
+	s.stats.LastRunAt = &start
+	s.stats.LastDurationMs = time.Since(start).Milliseconds()
+	s.stats.LastPruned = pruned
+	s.stats.TotalPruned += pruned
+	s.stats.LastError = ""
+	if err != nil {
+		s.stats.LastError = err.Error()
+	}
+	s.mu.Unlock()
+
+	return pruned, err
+}
+
+// GetCleanupStats returns how much run history has been pruned since startup
+func (s *historyService) GetCleanupStats() models.HistoryCleanupStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// pruneAll walks every job, including inactive ones, pruning each job's runs
+func (s *historyService) pruneAll(now time.Time) (int64, error) {
+	var pruned int64
+	var after *models.Cursor
+	for {
+		jobs, err := s.jobRepo.GetPage(context.Background(), after, historyJobPageSize, models.JobFilter{})
+		if err != nil {
+			return pruned, fmt.Errorf("failed to get jobs: %w", err)
+		}
+
+		for i := range jobs {
+			job := &jobs[i]
+			retention := s.retention(job)
+			if retention <= 0 {
+				continue
+			}
+
+			deleted, err := s.pruneJob(job, now.Add(-retention))
+			pruned += deleted
+			if err != nil {
+				return pruned, err
+			}
+		}
+
+		if len(jobs) < historyJobPageSize {
+			return pruned, nil
+		}
+		last := jobs[len(jobs)-1]
+		after = &models.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// pruneJob deletes a job's runs that started before the cutoff, one batch per statement so no
+// delete holds its locks for long
+func (s *historyService) pruneJob(job *models.Job, before time.Time) (int64, error) {
+	var pruned int64
+	for {
+		deleted, err := s.executionRepo.DeleteFinishedBefore(job.ID, before, s.cfg.DeleteBatchSize)
+		pruned += deleted
+		if err != nil {
+			return pruned, err
+		}
+		if deleted < int64(s.cfg.DeleteBatchSize) {
+			break
+		}
+	}
+
+	if pruned > 0 {
+		logrus.WithFields(logrus.Fields{
+			"job_id": job.ID,
+			"pruned": pruned,
+			"before": before,
+		}).Debug("Pruned old job executions")
+	}
+	return pruned, nil
+}
+
+// retention returns how long a job's finished runs are kept
+// config["history_retention_days"] overrides the default retention, with 0 keeping them forever
+func (s *historyService) retention(job *models.Job) time.Duration {
+	if days, ok := job.Config["history_retention_days"].(float64); ok && days >= 0 {
+		return time.Duration(days * float64(24*time.Hour))
+	}
+	return s.cfg.Retention
+}
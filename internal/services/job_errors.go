@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// MarkJobErrored records that the scheduler can't use a job's stored schedule, and why
+// Schedules are validated when jobs are saved through the API, so this happens to jobs written
+// some other way, such as data migrated from another system
+func (s *jobService) MarkJobErrored(id uuid.UUID, reason string) error {
+	if err := s.jobRepo.MarkErrored(id, reason, time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to mark job errored: %w", err)
+	}
+	return nil
+}
+
+// ClearJobError clears a job's error record once the scheduler can schedule it again
+func (s *jobService) ClearJobError(id uuid.UUID) error {
+	if err := s.jobRepo.ClearError(id); err != nil {
+		return fmt.Errorf("failed to clear job error: %w", err)
+	}
+	return nil
+}
+
+// GetErroredJobs retrieves the errored jobs, which the scheduler can't schedule, most recently errored first
+func (s *jobService) GetErroredJobs(ctx context.Context) ([]models.Job, error) {
+	jobs, err := s.jobRepo.GetErroredJobs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get errored jobs: %w", err)
+	}
+	return jobs, nil
+}
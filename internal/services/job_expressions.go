@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"job-scheduler/internal/expressions"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/notifications"
+)
+
+// ValidateJobExpressions compiles the CEL expressions in a job's config, its success criteria and
+// notification filter, so a broken one is rejected when the job is saved rather than when it is used
+func ValidateJobExpressions(config models.JobConfig) error {
+	if _, err := ParseSuccessCriteria(config); err != nil {
+		return err
+	}
+	if _, err := notifications.ParseNotificationFilter(config); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SuccessCriteria decides whether a run that returned without error succeeded, declared in the job's
+// config as a CEL expression over the run's recorded output, duration and attempt:
+//
+//	"success_criteria": "output.rows_written > 0 && duration_ms < 60000"
+//
+// A run not meeting its criteria fails, and is retried like any other failure
+type SuccessCriteria struct {
+	expression *expressions.Expression
+}
+
+// ParseSuccessCriteria compiles the job's success criteria from its config; it returns nil when none
+// are declared
+func ParseSuccessCriteria(config models.JobConfig) (*SuccessCriteria, error) {
+	raw, ok := config["success_criteria"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	source, ok := raw.(string)
+	if !ok {
+		return nil, fmt.Errorf("success_criteria must be a CEL expression")
+	}
+	expression, err := expressions.SuccessCriteria.CompileCondition(source)
+	if err != nil {
+		return nil, err
+	}
+	return &SuccessCriteria{expression: expression}, nil
+}
+
+// Check returns an error unless the finished run meets the criteria
+func (c *SuccessCriteria) Check(ctx context.Context, execution *models.JobExecution) error {
+	output := map[string]interface{}(execution.Output)
+	if output == nil {
+		output = map[string]interface{}{}
+	}
+	var duration time.Duration
+	if !execution.StartedAt.IsZero() {
+		duration = time.Since(execution.StartedAt)
+	}
+
+	met, err := c.expression.EvalBool(ctx, map[string]interface{}{
+		"output":      output,
+		"duration_ms": duration.Milliseconds(),
+		"attempt":     int64(execution.Attempt),
+	})
+	if err != nil {
+		return fmt.Errorf("success criteria could not be checked: %w", err)
+	}
+	if !met {
+		return fmt.Errorf("success criteria not met: %s", c.expression)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"job-scheduler/internal/models"
+)
+
+// exportPageSize is how many jobs are read at a time when exporting jobs
+const exportPageSize = 500
+
+// ExportJobs returns the definitions of every job, or of one team's jobs when team is given, ordered
+// by team and name so exports of unchanged jobs are identical
+func (s *jobService) ExportJobs(ctx context.Context, team *string) (*models.JobManifest, error) {
+	manifest := &models.JobManifest{Version: models.JobManifestVersion, Jobs: []models.JobDefinition{}}
+
+	var after *models.Cursor
+	for {
+		jobs, err := s.jobRepo.GetPage(ctx, after, exportPageSize, models.JobFilter{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get jobs: %w", err)
+		}
+		for i := range jobs {
+			if team == nil || jobs[i].Team == strings.TrimSpace(*team) {
+				manifest.Jobs = append(manifest.Jobs, models.NewJobDefinition(&jobs[i]))
+			}
+		}
+		if len(jobs) < exportPageSize {
+			break
+		}
+		last := jobs[len(jobs)-1]
+		after = &models.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	sort.SliceStable(manifest.Jobs, func(i, j int) bool {
+		if manifest.Jobs[i].Team != manifest.Jobs[j].Team {
+			return manifest.Jobs[i].Team < manifest.Jobs[j].Team
+		}
+		return manifest.Jobs[i].Name < manifest.Jobs[j].Name
+	})
+	return manifest, nil
+}
+
+// PlanJobImport works out what importing the manifest would do to each of its jobs, without changing
+// anything. Jobs are matched to existing jobs by team and name. With diff set, updates list the
+// settings they change
+func (s *jobService) PlanJobImport(manifest *models.JobManifest, diff bool) ([]models.JobImportItem, error) {
+	items := make([]models.JobImportItem, len(manifest.Jobs))
+	seen := make(map[string]bool, len(manifest.Jobs))
+
+	for i := range manifest.Jobs {
+		def := &manifest.Jobs[i]
+		def.Team = strings.TrimSpace(def.Team)
+		item := &items[i]
+		item.Name = def.Name
+		item.Team = def.Team
+
+		key := def.Team + "\x00" + def.Name
+		if err := validateJobDefinition(def); err != nil {
+			failImport(item, err)
+			continue
+		}
+		if seen[key] {
+			failImport(item, fmt.Errorf("job %q is defined more than once", def.Name))
+			continue
+		}
+		seen[key] = true
+
+		desired, err := s.jobFromRequest(def.CreateRequest())
+		if err != nil {
+			failImport(item, err)
+			continue
+		}
+		existing, err := s.GetJobByName(def.Team, def.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if existing == nil {
+			if desired.ScheduleType == models.ScheduleTypeOnce && !desired.RunAt.After(time.Now()) {
+				failImport(item, ErrRunAtPassed)
+				continue
+			}
+			item.Action = models.JobImportCreate
+			continue
+		}
+
+		// Settings left out keep the job's, as they do when replacing a job by name
+		id := existing.ID
+		item.ID = &id
+		if def.Owner == "" {
+			desired.Owner = existing.Owner
+		}
+		if def.State == "" {
+			desired.State = existing.State
+		} else if def.State != existing.State {
+			if _, err := requestedStateChange(existing, &models.UpdateJobRequest{State: &def.State}); err != nil {
+				failImport(item, err)
+				continue
+			}
+		}
+		if def.RunAt == nil {
+			desired.RunAt = existing.RunAt
+		}
+
+		changes, err := jobChanges(existing, desired)
+		if err != nil {
+			return nil, err
+		}
+		if len(changes) == 0 {
+			item.Action = models.JobImportUnchanged
+			continue
+		}
+		item.Action = models.JobImportUpdate
+		if diff {
+			item.Changes = changes
+		}
+	}
+	return items, nil
+}
+
+// validateJobDefinition checks a definition has the settings every job needs
+func validateJobDefinition(def *models.JobDefinition) error {
+	switch {
+	case def.Name == "":
+		return fmt.Errorf("job name is required")
+	case def.Schedule == "" && def.ScheduleType != models.ScheduleTypeOnce:
+		return fmt.Errorf("job schedule is required")
+	case def.JobType == "":
+		return fmt.Errorf("job type is required")
+	}
+	return nil
+}
+
+// failImport marks an import item as failed
+func failImport(item *models.JobImportItem, err error) {
+	item.Action = models.JobImportFailed
+	item.Error = err.Error()
+}
+
+// jobChanges lists the settings of the job's definition that differ between two versions of a job,
+// ordered by setting name
+func jobChanges(from, to *models.Job) ([]models.JobImportChange, error) {
+	before, err := definitionSettings(from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := definitionSettings(to)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]bool, len(before)+len(after))
+	for field := range before {
+		fields[field] = true
+	}
+	for field := range after {
+		fields[field] = true
+	}
+
+	var changes []models.JobImportChange
+	for field := range fields {
+		if !reflect.DeepEqual(before[field], after[field]) {
+			changes = append(changes, models.JobImportChange{Field: field, From: before[field], To: after[field]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes, nil
+}
+
+// definitionSettings returns the settings of a job's definition by name, as they are written in a manifest
+func definitionSettings(job *models.Job) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(models.NewJobDefinition(job))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare jobs: %w", err)
+	}
+	var settings map[string]interface{}
+	if err := json.Unmarshal(encoded, &settings); err != nil {
+		return nil, fmt.Errorf("failed to compare jobs: %w", err)
+	}
+	return settings, nil
+}
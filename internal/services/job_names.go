@@ -0,0 +1,167 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+)
+
+// GetJobByName retrieves a team's job by name, with when it runs next and last ran, returning nil when
+// the team has none
+func (s *jobService) GetJobByName(team, name string) (*models.Job, error) {
+	job, err := s.jobRepo.FindByName(strings.TrimSpace(team), name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if job == nil {
+		return nil, nil
+	}
+
+	jobs := []models.Job{*job}
+	if err := s.applyRunTimes(jobs); err != nil {
+		return nil, err
+	}
+	return &jobs[0], nil
+}
+
+// UpsertJobByName creates the job described by req if its team has no job with its name, or makes the
+// team's job with the name match it, reporting whether the job was created
+// Creating is checked against the name whether or not job names are unique, so repeating a request
+// never makes a second job
+func (s *jobService) UpsertJobByName(req *models.CreateJobRequest) (*models.Job, bool, error) {
+	existing, err := s.GetJobByName(req.Team, req.Name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if existing == nil {
+		job, err := s.createJob(req, true)
+		if !errors.Is(err, ErrJobNameTaken) {
+			return job, err == nil, err
+		}
+
+		// Another request created the job since it was looked up
+		existing, err = s.GetJobByName(req.Team, req.Name)
+		if err != nil {
+			return nil, false, err
+		}
+		if existing == nil {
+			return nil, false, fmt.Errorf("failed to get job: job %q not found", req.Name)
+		}
+	}
+
+	update := s.ReplacementRequest(existing, req)
+	job, err := s.UpdateJob(existing.ID, update)
+	if err != nil {
+		return nil, false, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id": job.ID,
+		"name":   job.Name,
+		"team":   job.Team,
+	}).Info("Job replaced by name")
+
+	return job, false, nil
+}
+
+// ReplacementRequest returns the update that makes a job match a create request, with fields left
+// out of the request taking their defaults as they would when creating the job
+// Only timing, config and tags that differ from the job's are included, so applying the same request
+// again doesn't rearm a one-time job or need a second approver. The job keeps its state unless the
+// request sets state or is_active, and keeps its owner unless the request names one
+func (s *jobService) ReplacementRequest(job *models.Job, req *models.CreateJobRequest) *models.UpdateJobRequest {
+	scheduleType := req.ScheduleType
+	if scheduleType == "" {
+		scheduleType = models.ScheduleTypeCron
+	}
+	cronSeconds := s.defaultCronSeconds()
+	if req.CronSeconds != nil {
+		cronSeconds = *req.CronSeconds
+	}
+	config := req.Config
+	if config == nil {
+		config = models.GetDefaultConfig(req.JobType)
+	}
+	severity := req.Severity
+	if severity == "" {
+		severity = models.JobSeverityMedium
+	}
+	backoff := req.BackoffStrategy
+	if backoff == "" {
+		backoff = models.BackoffExponential
+	}
+	misfire := req.MisfirePolicy
+	if misfire == "" {
+		misfire = models.MisfireIgnore
+	}
+	concurrency := req.ConcurrencyPolicy
+	if concurrency == "" {
+		concurrency = models.ConcurrencyAllow
+	}
+	team := strings.TrimSpace(req.Team)
+	group := strings.TrimSpace(req.ConcurrencyGroup)
+
+	update := &models.UpdateJobRequest{
+		Name:        &req.Name,
+		Description: &req.Description,
+		JobType:     &req.JobType,
+		IsActive:    req.IsActive,
+		State:       req.State,
+
+		RequiresApproval: &req.RequiresApproval,
+
+		Team: &team,
+
+		RunbookURL: &req.RunbookURL,
+		Docs:       &req.Docs,
+		Severity:   &severity,
+
+		RemediationActions: &req.RemediationActions,
+
+		MaxRetries:          &req.MaxRetries,
+		BackoffStrategy:     &backoff,
+		InitialDelaySeconds: &req.InitialDelaySeconds,
+
+		TimeoutSeconds: &req.TimeoutSeconds,
+
+		MisfirePolicy: &misfire,
+
+		ConcurrencyPolicy: &concurrency,
+		ConcurrencyGroup:  &group,
+
+		ChangedBy: req.ChangedBy,
+	}
+	if req.Owner != "" {
+		update.Owner = &req.Owner
+	}
+
+	if req.Schedule != job.Schedule {
+		update.Schedule = &req.Schedule
+	}
+	currentType := job.ScheduleType
+	if currentType == "" {
+		currentType = models.ScheduleTypeCron
+	}
+	if scheduleType != currentType {
+		update.ScheduleType = &scheduleType
+	}
+	if req.RunAt != nil && (job.RunAt == nil || !req.RunAt.Equal(*job.RunAt)) {
+		update.RunAt = req.RunAt
+	}
+	if cronSeconds != job.CronSeconds {
+		update.CronSeconds = &cronSeconds
+	}
+	if !reflect.DeepEqual(config, job.Config) {
+		update.Config = &config
+	}
+	if !reflect.DeepEqual(req.Tags, job.Tags) && (len(req.Tags) > 0 || len(job.Tags) > 0) {
+		update.Tags = &req.Tags
+	}
+	return update
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"job-scheduler/internal/apidocs"
+	"job-scheduler/internal/models"
+)
+
+// PreviewJob works out when a cron schedule would next fire in a time zone, and checks a config against
+// its job type's schema, without saving anything. Problems are reported in the preview rather than as
+// errors, so a form can show all of them at once
+func (s *jobService) PreviewJob(req *models.PreviewJobRequest) (*models.JobPreview, error) {
+	preview := &models.JobPreview{
+		Valid:     true,
+		FireTimes: []time.Time{},
+		Errors:    []models.JobPreviewError{},
+	}
+
+	count := req.Count
+	if count <= 0 {
+		count = models.DefaultPreviewCount
+	}
+	if count > models.MaxPreviewCount {
+		count = models.MaxPreviewCount
+	}
+
+	loc := time.Local
+	if req.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(req.Timezone); err != nil {
+			preview.AddError("timezone", fmt.Sprintf("unknown time zone: %s", req.Timezone))
+			loc = nil
+		}
+	}
+	if loc != nil {
+		preview.Timezone = loc.String()
+	}
+
+	cronSeconds := s.defaultCronSeconds()
+	if req.CronSeconds != nil {
+		cronSeconds = *req.CronSeconds
+	}
+	if strings.TrimSpace(req.Schedule) == "" {
+		preview.AddError("schedule", "schedule is required")
+	} else if schedule, err := ParseSchedule(req.Schedule, cronSeconds); err != nil {
+		preview.AddError("schedule", fmt.Sprintf("invalid cron expression '%s': %v", req.Schedule, err))
+	} else if loc != nil {
+		// Schedules without a CRON_TZ fire in the zone of the time they're asked about
+		next := time.Now().In(loc)
+		for len(preview.FireTimes) < count {
+			if next = schedule.Next(next); next.IsZero() {
+				break
+			}
+			preview.FireTimes = append(preview.FireTimes, next)
+		}
+	}
+
+	if req.JobType != "" && !IsSupportedJobType(req.JobType) {
+		preview.AddError("job_type", fmt.Sprintf("invalid job type: %s", req.JobType))
+		return preview, nil
+	}
+	if req.Config == nil {
+		return preview, nil
+	}
+	if req.JobType == "" {
+		preview.AddError("job_type", "job_type is required to check a config")
+		return preview, nil
+	}
+
+	if _, err := ParseCallBudget(req.Config); err != nil {
+		preview.AddError("config", err.Error())
+	}
+	if err := ValidateJobExpressions(req.Config); err != nil {
+		preview.AddError("config", err.Error())
+	}
+	if req.JobType == models.JobTypeDataProcessing {
+		if err := ValidatePipelineConfig(req.Config); err != nil {
+			preview.AddError("config", fmt.Sprintf("invalid pipeline: %v", err))
+		}
+	}
+	if req.JobType == models.JobTypeReportGeneration {
+		if err := ValidateReportConfig(req.Config); err != nil {
+			preview.AddError("config", err.Error())
+		}
+	}
+	if req.JobType == models.JobTypeEmailNotification {
+		if err := ValidateEmailConfig(req.Config); err != nil {
+			preview.AddError("config", err.Error())
+		}
+	}
+	for _, problem := range apidocs.ValidateJobConfig(req.JobType, req.Config) {
+		preview.AddError("config", problem)
+	}
+
+	return preview, nil
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+// JobRevisionService keeps a numbered revision of each change to a job's name, schedule and config,
+// so a bad change can be seen and rolled back
+type JobRevisionService interface {
+	JobRevisionRecorder
+	GetRevisions(jobID uuid.UUID) ([]models.JobRevision, error)
+	RollbackRequest(jobID uuid.UUID, revision int, actor string) (*models.UpdateJobRequest, error)
+}
+
+// jobRevisionService implements JobRevisionService interface
+type jobRevisionService struct {
+	revisionRepo repositories.JobRevisionRepository
+}
+
+// NewJobRevisionService creates a new job revision service, registering it to record the job service's changes
+func NewJobRevisionService(revisionRepo repositories.JobRevisionRepository, jobService JobService) JobRevisionService {
+	s := &jobRevisionService{
+		revisionRepo: revisionRepo,
+	}
+	jobService.SetRevisionRecorder(s)
+	return s
+}
+
+// RecordRevision records a job's name, schedule and config as its next revision, unless none of them changed
+// Jobs created before revisions were kept get their definition from before the change as revision 1
+func (s *jobRevisionService) RecordRevision(previous, job *models.Job, author string) error {
+	latest, err := s.revisionRepo.GetLatest(job.ID)
+	if err != nil {
+		return err
+	}
+
+	revision := models.NewJobRevision(job, 1, author)
+	if latest == nil && previous != nil {
+		baseline := models.NewJobRevision(previous, 1, "")
+		if len(revision.ChangedFrom(baseline)) == 0 {
+			return nil
+		}
+		if err := s.revisionRepo.Create(baseline); err != nil {
+			return err
+		}
+		latest = baseline
+	}
+	if latest != nil {
+		if len(revision.ChangedFrom(latest)) == 0 {
+			return nil
+		}
+		revision.Revision = latest.Revision + 1
+	}
+	if err := s.revisionRepo.Create(revision); err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":   job.ID,
+		"revision": revision.Revision,
+		"author":   author,
+	}).Info("Job revision recorded")
+
+	return nil
+}
+
+// GetRevisions lists a job's revisions, newest first
+func (s *jobRevisionService) GetRevisions(jobID uuid.UUID) ([]models.JobRevision, error) {
+	revisions, err := s.revisionRepo.GetByJobID(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// RollbackRequest returns the update restoring a job's name, schedule and config to one of its revisions
+// Applying it records a new revision, so rollbacks can themselves be rolled back
+func (s *jobRevisionService) RollbackRequest(jobID uuid.UUID, revision int, actor string) (*models.UpdateJobRequest, error) {
+	found, err := s.revisionRepo.GetByRevision(jobID, revision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job revision: %w", err)
+	}
+
+	config := found.Config
+	req := &models.UpdateJobRequest{
+		Name:      &found.Name,
+		Schedule:  &found.Schedule,
+		Config:    &config,
+		ChangedBy: actor,
+	}
+	if found.ScheduleType != "" {
+		scheduleType := found.ScheduleType
+		req.ScheduleType = &scheduleType
+	}
+	return req, nil
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"job-scheduler/internal/models"
+)
+
+// MinEveryInterval is the shortest interval an @every schedule may use
+const MinEveryInterval = time.Second
+
+var (
+	// scheduleParser parses five-field cron expressions and descriptors such as @hourly, @daily and @every 90s
+	scheduleParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+	// secondsScheduleParser also accepts six-field expressions whose first field is the second
+	secondsScheduleParser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+)
+
+// ParseSchedule parses a cron schedule expression; withSeconds allows a leading seconds field
+// @every intervals must be whole seconds of at least MinEveryInterval, since cron would silently round them.
+// They fall due on multiples of the interval since the Unix epoch, see EverySchedule
+func ParseSchedule(expr string, withSeconds bool) (cron.Schedule, error) {
+	if strings.HasPrefix(expr, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(expr, "@every ")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid @every interval: %w", err)
+		}
+		if interval < MinEveryInterval {
+			return nil, fmt.Errorf("@every interval must be at least %s", MinEveryInterval)
+		}
+		if interval%time.Second != 0 {
+			return nil, errors.New("@every interval must be a whole number of seconds")
+		}
+		return EverySchedule{Interval: interval}, nil
+	}
+	if withSeconds {
+		return secondsScheduleParser.Parse(expr)
+	}
+	if len(strings.Fields(expr)) == 6 {
+		return nil, errors.New("six-field schedules with seconds require cron_seconds")
+	}
+	return scheduleParser.Parse(expr)
+}
+
+// EverySchedule is the schedule of an @every interval. Unlike cron's, which counts from when the job
+// was scheduled, its occurrences are multiples of the interval since the Unix epoch, so replicas
+// started at different times agree on them and claim the same runs
+type EverySchedule struct {
+	Interval time.Duration
+}
+
+// Next returns the first occurrence after t
+func (e EverySchedule) Next(t time.Time) time.Time {
+	return e.Prev(t).Add(e.Interval)
+}
+
+// Prev returns the latest occurrence at or before t
+func (e EverySchedule) Prev(t time.Time) time.Time {
+	seconds := int64(e.Interval / time.Second)
+	unix := t.Unix()
+	unix -= ((unix % seconds) + seconds) % seconds
+	return time.Unix(unix, 0).In(t.Location())
+}
+
+// OnceSchedule is the schedule of a one-time job: it falls due a single time, at At
+type OnceSchedule struct {
+	At time.Time
+}
+
+// Next returns At until it has passed; after that there is no next occurrence
+func (o OnceSchedule) Next(t time.Time) time.Time {
+	if t.Before(o.At) {
+		return o.At
+	}
+	return time.Time{}
+}
+
+// JobSchedule returns when a job's occurrences fall due: once at run_at for one-time jobs,
+// otherwise per its cron schedule or interval
+func JobSchedule(job *models.Job) (cron.Schedule, error) {
+	if job.IsOneTime() {
+		if job.RunAt == nil {
+			return nil, errors.New("one-time job has no run_at")
+		}
+		return OnceSchedule{At: job.RunAt.UTC()}, nil
+	}
+
+	schedule, err := ParseSchedule(job.Schedule, job.CronSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron schedule: %w", err)
+	}
+	return schedule, nil
+}
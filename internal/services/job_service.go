@@ -1,61 +1,260 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/robfig/cron/v3"
 	"github.com/sirupsen/logrus"
 
 	"job-scheduler/internal/models"
 	"job-scheduler/internal/repositories"
 )
 
+var (
+	// ErrInvalidCursor is returned when a pagination cursor can't be decoded
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrInvalidJobSort is returned when listing jobs in an unknown order
+	ErrInvalidJobSort = errors.New("invalid sort order")
+	// ErrInvalidJobFilter is returned when listing jobs with a filter that can't match any job
+	ErrInvalidJobFilter = errors.New("invalid job filter")
+	// ErrJobAlreadyPaused is returned when pausing a job that is paused
+	ErrJobAlreadyPaused = errors.New("job is already paused")
+	// ErrJobNotPaused is returned when resuming a job that isn't paused
+	ErrJobNotPaused = errors.New("job is not paused")
+	// ErrInvalidStateTransition is returned when a job can't change from its state to the one asked for
+	ErrInvalidStateTransition = errors.New("invalid job state transition")
+	// ErrJobStateNotRequestable is returned when creating or updating a job asks for a state only pausing
+	// or the scheduler can put it in
+	ErrJobStateNotRequestable = errors.New("job state can't be requested")
+	// ErrJobNameTaken is returned when job names are unique and the job's team already has a job with its name
+	ErrJobNameTaken = errors.New("the team already has a job with this name")
+	// ErrPauseActorRequired is returned when a job is paused or resumed anonymously
+	ErrPauseActorRequired = errors.New("pausing and resuming jobs require an identified user")
+	// ErrPauseReasonRequired is returned when a job is paused without a reason
+	ErrPauseReasonRequired = errors.New("a reason is required to pause a job")
+	// ErrRunAtPassed is returned when a one-time job would be scheduled for a time that has passed
+	ErrRunAtPassed = errors.New("run_at of a one-time job must be in the future")
+)
+
 // JobService defines the interface for job business logic
 type JobService interface {
 	CreateJob(req *models.CreateJobRequest) (*models.Job, error)
+	UpsertJobByName(req *models.CreateJobRequest) (*models.Job, bool, error)
+	ReplacementRequest(job *models.Job, req *models.CreateJobRequest) *models.UpdateJobRequest
 	GetJobByID(id uuid.UUID) (*models.Job, error)
-	GetAllJobs(page, limit int) (*models.JobListResponse, error)
+	GetJobByName(team, name string) (*models.Job, error)
+	GetAllJobs(ctx context.Context, page, limit int, sort models.JobSort, filter models.JobFilter) (*models.JobListResponse, error)
+	ListJobs(ctx context.Context, cursor string, limit int, filter models.JobFilter) (*models.JobPage, error)
 	UpdateJob(id uuid.UUID, req *models.UpdateJobRequest) (*models.Job, error)
 	DeleteJob(id uuid.UUID) error
+	PauseJob(id uuid.UUID, actor, reason string) (*models.Job, error)
+	ResumeJob(id uuid.UUID, actor string) (*models.Job, error)
 	GetActiveJobs() ([]models.Job, error)
+	GetSchedulableJobs() ([]models.Job, error)
 	ValidateCronSchedule(schedule string) error
+	PreviewJob(req *models.PreviewJobRequest) (*models.JobPreview, error)
+	SetCronSecondsDefault(enabled bool)
+	SetUniqueJobNames(enabled bool)
+	SetChangeListener(listener JobChangeListener)
+	SetRevisionRecorder(recorder JobRevisionRecorder)
+	SetRunTimeSource(source JobRunTimeSource)
+	RecordRunTimes(id uuid.UUID, lastRunAt, nextRunAt time.Time) error
+	CompleteOneTimeJob(id uuid.UUID, ranAt time.Time) error
+	MarkJobErrored(id uuid.UUID, reason string) error
+	ClearJobError(id uuid.UUID) error
+	GetErroredJobs(ctx context.Context) ([]models.Job, error)
+	ExportJobs(ctx context.Context, team *string) (*models.JobManifest, error)
+	PlanJobImport(manifest *models.JobManifest, diff bool) ([]models.JobImportItem, error)
+}
+
+// JobChangeListener is told when jobs are created, saved or deleted, so schedule changes apply straight away
+// It is implemented by the scheduler
+type JobChangeListener interface {
+	JobCreated(job *models.Job)
+	JobSaved(job *models.Job)
+	JobDeleted(jobID uuid.UUID)
+}
+
+// JobRevisionRecorder keeps the history of changes to jobs' names, schedules and configs
+// It is implemented by the job revision service
+type JobRevisionRecorder interface {
+	// RecordRevision records a created or updated job's definition; previous is nil for new jobs
+	RecordRevision(previous, job *models.Job, author string) error
+}
+
+// JobRunTimeSource tells when jobs are next due and when they last ran
+// It is implemented by the scheduler
+type JobRunTimeSource interface {
+	NextRunTimes(jobIDs []uuid.UUID) map[uuid.UUID]time.Time
+	LastRunTimes(jobIDs []uuid.UUID) (map[uuid.UUID]time.Time, error)
 }
 
 // jobService implements JobService interface
 type jobService struct {
-	jobRepo repositories.JobRepository
-	parser  cron.Parser
+	jobRepo  repositories.JobRepository
+	mu       sync.RWMutex
+	listener JobChangeListener
+	runTimes JobRunTimeSource
+	// revisions records changes to jobs' definitions, if set
+	revisions JobRevisionRecorder
+	// cronSeconds is whether new jobs' schedules may have a seconds field unless they say otherwise
+	cronSeconds bool
+	// uniqueNames is whether each team's jobs must have different names
+	uniqueNames bool
 }
 
 // NewJobService creates a new job service
 func NewJobService(jobRepo repositories.JobRepository) JobService {
-	// Create cron parser with standard options
-	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
-
 	return &jobService{
 		jobRepo: jobRepo,
-		parser:  parser,
 	}
 }
 
 // CreateJob creates a new job with validation
+// When job names are unique it returns ErrJobNameTaken if the job's team already has a job with its name
 func (s *jobService) CreateJob(req *models.CreateJobRequest) (*models.Job, error) {
+	return s.createJob(req, s.uniqueJobNames())
+}
+
+// createJob creates a new job with validation, failing with ErrJobNameTaken if uniqueName is set and
+// the job's team already has a job with its name
+func (s *jobService) createJob(req *models.CreateJobRequest, uniqueName bool) (*models.Job, error) {
 	logrus.WithFields(logrus.Fields{
 		"name":     req.Name,
 		"job_type": req.JobType,
 		"schedule": req.Schedule,
 	}).Info("Creating new job")
 
+	job, err := s.jobFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if job.ScheduleType == models.ScheduleTypeOnce && !job.RunAt.After(time.Now()) {
+		return nil, ErrRunAtPassed
+	}
+	s.scheduleNextRun(job)
+
+	// Save to database
+	if uniqueName {
+		holder, err := s.jobRepo.CreateIfNameFree(job)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create job: %w", err)
+		}
+		if holder != nil {
+			return nil, ErrJobNameTaken
+		}
+	} else if err := s.jobRepo.Create(job); err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+	s.jobCreated(job)
+	s.jobSaved(job)
+	s.jobRevised(nil, job, req.ChangedBy)
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":   job.ID,
+		"name":     job.Name,
+		"job_type": job.JobType,
+	}).Info("Job created successfully")
+
+	return job, nil
+}
+
+// jobFromRequest validates a create request and returns the job it describes, with defaults for the
+// settings it leaves out. It doesn't check that a one-time job's run_at is still ahead
+func (s *jobService) jobFromRequest(req *models.CreateJobRequest) (*models.Job, error) {
 	// Validate job type
-	if !models.IsValidJobType(string(req.JobType)) {
+	if !IsSupportedJobType(req.JobType) {
 		return nil, fmt.Errorf("invalid job type: %s", req.JobType)
 	}
 
-	// Validate cron schedule
-	if err := s.ValidateCronSchedule(req.Schedule); err != nil {
-		return nil, fmt.Errorf("invalid cron schedule: %w", err)
+	// Validate schedule
+	scheduleType := req.ScheduleType
+	if scheduleType == "" {
+		scheduleType = models.ScheduleTypeCron
+	}
+	cronSeconds := s.defaultCronSeconds()
+	if req.CronSeconds != nil {
+		cronSeconds = *req.CronSeconds
+	}
+	if err := s.validateSchedule(scheduleType, req.Schedule, req.RunAt, cronSeconds); err != nil {
+		return nil, err
+	}
+
+	// Validate on-call documentation
+	if err := validateRunbookURL(req.RunbookURL); err != nil {
+		return nil, err
+	}
+	severity := req.Severity
+	if severity == "" {
+		severity = models.JobSeverityMedium
+	}
+	if !models.IsValidJobSeverity(string(severity)) {
+		return nil, fmt.Errorf("invalid severity: %s", severity)
+	}
+	if err := s.validateRemediationActions(uuid.Nil, req.RemediationActions); err != nil {
+		return nil, err
+	}
+
+	// Validate retry policy
+	backoff := req.BackoffStrategy
+	if backoff == "" {
+		backoff = models.BackoffExponential
+	}
+	if err := validateRetryPolicy(req.MaxRetries, backoff, req.InitialDelaySeconds); err != nil {
+		return nil, err
+	}
+	if req.TimeoutSeconds < 0 {
+		return nil, fmt.Errorf("timeout_seconds must not be negative")
+	}
+
+	// Validate misfire policy
+	misfire := req.MisfirePolicy
+	if misfire == "" {
+		misfire = models.MisfireIgnore
+	}
+	if !models.IsValidMisfirePolicy(string(misfire)) {
+		return nil, fmt.Errorf("invalid misfire policy: %s", misfire)
+	}
+
+	// Validate concurrency policy
+	concurrency := req.ConcurrencyPolicy
+	if concurrency == "" {
+		concurrency = models.ConcurrencyAllow
+	}
+	if !models.IsValidConcurrencyPolicy(string(concurrency)) {
+		return nil, fmt.Errorf("invalid concurrency policy: %s", concurrency)
+	}
+
+	// Validate call budget
+	if _, err := ParseCallBudget(req.Config); err != nil {
+		return nil, err
+	}
+
+	// Validate the job's success criteria and notification filter, and the pipeline's step expressions
+	if err := ValidateJobExpressions(req.Config); err != nil {
+		return nil, err
+	}
+	if req.JobType == models.JobTypeDataProcessing {
+		if err := ValidatePipelineConfig(req.Config); err != nil {
+			return nil, fmt.Errorf("invalid pipeline: %w", err)
+		}
+	}
+	if req.JobType == models.JobTypeReportGeneration {
+		if err := ValidateReportConfig(req.Config); err != nil {
+			return nil, err
+		}
+	}
+	if req.JobType == models.JobTypeEmailNotification {
+		if err := ValidateEmailConfig(req.Config); err != nil {
+			return nil, err
+		}
 	}
 
 	// Create job model
@@ -66,12 +265,45 @@ func (s *jobService) CreateJob(req *models.CreateJobRequest) (*models.Job, error
 		Schedule:    req.Schedule,
 		JobType:     req.JobType,
 		Config:      req.Config,
-		IsActive:    true, // Default to active
+		State:       models.JobStateActive, // Default to active
+
+		ScheduleType: scheduleType,
+		RunAt:        req.RunAt,
+		CronSeconds:  cronSeconds,
+
+		RequiresApproval: req.RequiresApproval,
+		Tags:             req.Tags,
+
+		Team:  strings.TrimSpace(req.Team),
+		Owner: req.Owner,
+
+		RunbookURL: req.RunbookURL,
+		Docs:       req.Docs,
+		Severity:   severity,
+
+		RemediationActions: req.RemediationActions,
+
+		MaxRetries:          req.MaxRetries,
+		BackoffStrategy:     backoff,
+		InitialDelaySeconds: req.InitialDelaySeconds,
+		TimeoutSeconds:      req.TimeoutSeconds,
+
+		MisfirePolicy: misfire,
+
+		ConcurrencyPolicy: concurrency,
+		ConcurrencyGroup:  strings.TrimSpace(req.ConcurrencyGroup),
 	}
 
-	// Override IsActive if provided
-	if req.IsActive != nil {
-		job.IsActive = *req.IsActive
+	// Override the state if provided
+	state, err := models.RequestedJobState(req.IsActive, req.State)
+	if err != nil {
+		return nil, err
+	}
+	if state != nil {
+		if !state.IsRequestable() {
+			return nil, fmt.Errorf("%w: %s", ErrJobStateNotRequestable, *state)
+		}
+		job.State = *state
 	}
 
 	// Set default config if not provided
@@ -79,31 +311,35 @@ func (s *jobService) CreateJob(req *models.CreateJobRequest) (*models.Job, error
 		job.Config = models.GetDefaultConfig(req.JobType)
 	}
 
-	// Save to database
-	if err := s.jobRepo.Create(job); err != nil {
-		return nil, fmt.Errorf("failed to create job: %w", err)
-	}
-
-	logrus.WithFields(logrus.Fields{
-		"job_id":   job.ID,
-		"name":     job.Name,
-		"job_type": job.JobType,
-	}).Info("Job created successfully")
-
 	return job, nil
 }
 
-// GetJobByID retrieves a job by its ID
+// GetJobByID retrieves a job by its ID, with when it runs next and last ran
 func (s *jobService) GetJobByID(id uuid.UUID) (*models.Job, error) {
 	job, err := s.jobRepo.GetByID(id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get job: %w", err)
 	}
-	return job, nil
+
+	jobs := []models.Job{*job}
+	if err := s.applyRunTimes(jobs); err != nil {
+		return nil, err
+	}
+	return &jobs[0], nil
 }
 
-// GetAllJobs retrieves all jobs with pagination
-func (s *jobService) GetAllJobs(page, limit int) (*models.JobListResponse, error) {
+// GetAllJobs retrieves the jobs matching the filter with pagination, newest first unless another order is given
+func (s *jobService) GetAllJobs(ctx context.Context, page, limit int, sort models.JobSort, filter models.JobFilter) (*models.JobListResponse, error) {
+	if sort == "" {
+		sort = models.JobSortNewest
+	}
+	if !models.IsValidJobSort(string(sort)) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidJobSort, sort)
+	}
+	if err := validateJobFilter(filter); err != nil {
+		return nil, err
+	}
+
 	// Validate pagination parameters
 	if page < 1 {
 		page = 1
@@ -112,10 +348,13 @@ func (s *jobService) GetAllJobs(page, limit int) (*models.JobListResponse, error
 		limit = 10 // Default limit
 	}
 
-	jobs, totalCount, err := s.jobRepo.GetAll(page, limit)
+	jobs, totalCount, err := s.jobRepo.GetAll(ctx, page, limit, sort, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get jobs: %w", err)
 	}
+	if err := s.applyRunTimes(jobs); err != nil {
+		return nil, err
+	}
 
 	// Calculate total pages
 	totalPages := int(math.Ceil(float64(totalCount) / float64(limit)))
@@ -129,6 +368,43 @@ func (s *jobService) GetAllJobs(page, limit int) (*models.JobListResponse, error
 	}, nil
 }
 
+// ListJobs retrieves a page of the jobs matching the filter after the given cursor, newest first
+func (s *jobService) ListJobs(ctx context.Context, cursor string, limit int, filter models.JobFilter) (*models.JobPage, error) {
+	if limit < 1 || limit > 100 {
+		limit = 10 // Default limit
+	}
+	if err := validateJobFilter(filter); err != nil {
+		return nil, err
+	}
+
+	var after *models.Cursor
+	if cursor != "" {
+		decoded, err := models.DecodeCursor(cursor)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+		}
+		after = decoded
+	}
+
+	// Fetch one extra job to find out whether there is a next page
+	jobs, err := s.jobRepo.GetPage(ctx, after, limit+1, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs: %w", err)
+	}
+	if err := s.applyRunTimes(jobs); err != nil {
+		return nil, err
+	}
+
+	page := &models.JobPage{Jobs: jobs, Limit: limit}
+	if len(jobs) > limit {
+		page.Jobs = jobs[:limit]
+		last := page.Jobs[limit-1]
+		page.NextCursor = models.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	return page, nil
+}
+
 // UpdateJob updates an existing job
 func (s *jobService) UpdateJob(id uuid.UUID, req *models.UpdateJobRequest) (*models.Job, error) {
 	logrus.WithFields(logrus.Fields{
@@ -140,6 +416,7 @@ func (s *jobService) UpdateJob(id uuid.UUID, req *models.UpdateJobRequest) (*mod
 	if err != nil {
 		return nil, fmt.Errorf("failed to get job for update: %w", err)
 	}
+	previous := *job
 
 	// Update fields if provided
 	if req.Name != nil {
@@ -148,31 +425,173 @@ func (s *jobService) UpdateJob(id uuid.UUID, req *models.UpdateJobRequest) (*mod
 	if req.Description != nil {
 		job.Description = *req.Description
 	}
-	if req.Schedule != nil {
+	if req.Schedule != nil || req.ScheduleType != nil || req.RunAt != nil || req.CronSeconds != nil {
+		scheduleType, schedule, runAt, cronSeconds := job.ScheduleType, job.Schedule, job.RunAt, job.CronSeconds
+		if scheduleType == "" {
+			scheduleType = models.ScheduleTypeCron
+		}
+		// Switching between cron and one-time drops the previous kind's timing
+		if req.ScheduleType != nil && *req.ScheduleType != scheduleType {
+			scheduleType, schedule, runAt = *req.ScheduleType, "", nil
+		}
+		if req.Schedule != nil {
+			schedule = *req.Schedule
+		}
+		if req.RunAt != nil {
+			runAt = req.RunAt
+		}
+		if req.CronSeconds != nil {
+			cronSeconds = *req.CronSeconds
+		}
+
 		// Validate new schedule
-		if err := s.ValidateCronSchedule(*req.Schedule); err != nil {
-			return nil, fmt.Errorf("invalid cron schedule: %w", err)
+		if err := s.validateSchedule(scheduleType, schedule, runAt, cronSeconds); err != nil {
+			return nil, err
 		}
-		job.Schedule = *req.Schedule
+		job.ScheduleType, job.Schedule, job.RunAt, job.CronSeconds = scheduleType, schedule, runAt, cronSeconds
 	}
 	if req.JobType != nil {
 		// Validate new job type
-		if !models.IsValidJobType(string(*req.JobType)) {
+		if !IsSupportedJobType(*req.JobType) {
 			return nil, fmt.Errorf("invalid job type: %s", *req.JobType)
 		}
 		job.JobType = *req.JobType
 	}
 	if req.Config != nil {
+		if _, err := ParseCallBudget(*req.Config); err != nil {
+			return nil, err
+		}
+		if err := ValidateJobExpressions(*req.Config); err != nil {
+			return nil, err
+		}
 		job.Config = *req.Config
 	}
-	if req.IsActive != nil {
-		job.IsActive = *req.IsActive
+	if job.JobType == models.JobTypeDataProcessing && (req.Config != nil || req.JobType != nil) {
+		if err := ValidatePipelineConfig(job.Config); err != nil {
+			return nil, fmt.Errorf("invalid pipeline: %w", err)
+		}
+	}
+	if job.JobType == models.JobTypeReportGeneration && (req.Config != nil || req.JobType != nil) {
+		if err := ValidateReportConfig(job.Config); err != nil {
+			return nil, err
+		}
+	}
+	if job.JobType == models.JobTypeEmailNotification && (req.Config != nil || req.JobType != nil) {
+		if err := ValidateEmailConfig(job.Config); err != nil {
+			return nil, err
+		}
+	}
+	state, err := requestedStateChange(job, req)
+	if err != nil {
+		return nil, err
+	}
+	if state != nil {
+		// The pause and error records only describe paused and errored jobs
+		if job.State == models.JobStatePaused {
+			clearPause(job)
+		}
+		if job.State == models.JobStateErrored {
+			job.ErroredAt = nil
+			job.ErrorReason = ""
+		}
+		job.State = *state
+	}
+	if req.RequiresApproval != nil {
+		job.RequiresApproval = *req.RequiresApproval
+	}
+	if req.Tags != nil {
+		job.Tags = *req.Tags
+	}
+	if req.Team != nil {
+		job.Team = strings.TrimSpace(*req.Team)
+	}
+	if req.Owner != nil {
+		job.Owner = *req.Owner
+	}
+	if req.RunbookURL != nil {
+		if err := validateRunbookURL(*req.RunbookURL); err != nil {
+			return nil, err
+		}
+		job.RunbookURL = *req.RunbookURL
+	}
+	if req.Docs != nil {
+		job.Docs = *req.Docs
+	}
+	if req.Severity != nil {
+		if !models.IsValidJobSeverity(string(*req.Severity)) {
+			return nil, fmt.Errorf("invalid severity: %s", *req.Severity)
+		}
+		job.Severity = *req.Severity
+	}
+	if req.RemediationActions != nil {
+		if err := s.validateRemediationActions(job.ID, *req.RemediationActions); err != nil {
+			return nil, err
+		}
+		job.RemediationActions = *req.RemediationActions
+	}
+	if req.MaxRetries != nil || req.BackoffStrategy != nil || req.InitialDelaySeconds != nil {
+		if req.MaxRetries != nil {
+			job.MaxRetries = *req.MaxRetries
+		}
+		if req.BackoffStrategy != nil {
+			job.BackoffStrategy = *req.BackoffStrategy
+		}
+		if req.InitialDelaySeconds != nil {
+			job.InitialDelaySeconds = *req.InitialDelaySeconds
+		}
+		if job.BackoffStrategy == "" {
+			job.BackoffStrategy = models.BackoffExponential
+		}
+		if err := validateRetryPolicy(job.MaxRetries, job.BackoffStrategy, job.InitialDelaySeconds); err != nil {
+			return nil, err
+		}
+	}
+	if req.TimeoutSeconds != nil {
+		if *req.TimeoutSeconds < 0 {
+			return nil, fmt.Errorf("timeout_seconds must not be negative")
+		}
+		job.TimeoutSeconds = *req.TimeoutSeconds
+	}
+	if req.MisfirePolicy != nil {
+		if !models.IsValidMisfirePolicy(string(*req.MisfirePolicy)) {
+			return nil, fmt.Errorf("invalid misfire policy: %s", *req.MisfirePolicy)
+		}
+		job.MisfirePolicy = *req.MisfirePolicy
+	}
+	if req.ConcurrencyPolicy != nil {
+		if !models.IsValidConcurrencyPolicy(string(*req.ConcurrencyPolicy)) {
+			return nil, fmt.Errorf("invalid concurrency policy: %s", *req.ConcurrencyPolicy)
+		}
+		job.ConcurrencyPolicy = *req.ConcurrencyPolicy
+	}
+	if req.ConcurrencyGroup != nil {
+		job.ConcurrencyGroup = strings.TrimSpace(*req.ConcurrencyGroup)
+	}
+	// Rescheduling or activating a one-time job arms it to run again, even if it already ran
+	if job.IsOneTime() && job.IsActive() && (req.RunAt != nil || req.ScheduleType != nil || state != nil) {
+		if !job.RunAt.After(time.Now()) {
+			return nil, ErrRunAtPassed
+		}
+		job.CompletedAt = nil
+	}
+	if req.Schedule != nil || req.ScheduleType != nil || req.RunAt != nil || req.CronSeconds != nil || state != nil {
+		s.scheduleNextRun(job)
 	}
 
 	// Save updated job
-	if err := s.jobRepo.Update(job); err != nil {
+	if s.uniqueJobNames() {
+		holder, err := s.jobRepo.UpdateIfNameFree(job)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update job: %w", err)
+		}
+		if holder != nil {
+			return nil, ErrJobNameTaken
+		}
+	} else if err := s.jobRepo.Update(job); err != nil {
 		return nil, fmt.Errorf("failed to update job: %w", err)
 	}
+	s.jobSaved(job)
+	s.jobRevised(&previous, job, req.ChangedBy)
 
 	logrus.WithFields(logrus.Fields{
 		"job_id": job.ID,
@@ -182,6 +601,92 @@ func (s *jobService) UpdateJob(id uuid.UUID, req *models.UpdateJobRequest) (*mod
 	return job, nil
 }
 
+// PauseJob pauses a job, unscheduling it straight away, and records who paused it and why
+func (s *jobService) PauseJob(id uuid.UUID, actor, reason string) (*models.Job, error) {
+	if actor == "" {
+		return nil, ErrPauseActorRequired
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrPauseReasonRequired
+	}
+
+	job, err := s.jobRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if job.State == models.JobStatePaused {
+		return nil, ErrJobAlreadyPaused
+	}
+	if !job.State.CanTransitionTo(models.JobStatePaused) {
+		return nil, fmt.Errorf("%w: %s jobs can't be paused", ErrInvalidStateTransition, job.State)
+	}
+
+	now := time.Now().UTC()
+	job.State = models.JobStatePaused
+	job.PausedAt = &now
+	job.PausedBy = actor
+	job.PauseReason = reason
+	s.scheduleNextRun(job)
+
+	if err := s.jobRepo.Update(job); err != nil {
+		return nil, fmt.Errorf("failed to pause job: %w", err)
+	}
+	s.jobSaved(job)
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":    job.ID,
+		"name":      job.Name,
+		"paused_by": actor,
+		"reason":    reason,
+	}).Info("Job paused")
+
+	return job, nil
+}
+
+// ResumeJob makes a paused job active again, scheduling it straight away
+func (s *jobService) ResumeJob(id uuid.UUID, actor string) (*models.Job, error) {
+	if actor == "" {
+		return nil, ErrPauseActorRequired
+	}
+
+	job, err := s.jobRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if job.State != models.JobStatePaused {
+		return nil, ErrJobNotPaused
+	}
+	if job.IsOneTime() && !job.RunAt.After(time.Now()) {
+		return nil, ErrRunAtPassed
+	}
+
+	job.State = models.JobStateActive
+	job.CompletedAt = nil
+	clearPause(job)
+	s.scheduleNextRun(job)
+
+	if err := s.jobRepo.Update(job); err != nil {
+		return nil, fmt.Errorf("failed to resume job: %w", err)
+	}
+	s.jobSaved(job)
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":     job.ID,
+		"name":       job.Name,
+		"resumed_by": actor,
+	}).Info("Job resumed")
+
+	return job, nil
+}
+
+// clearPause removes the record of who paused the job
+func clearPause(job *models.Job) {
+	job.PausedAt = nil
+	job.PausedBy = ""
+	job.PauseReason = ""
+}
+
 // DeleteJob deletes a job by its ID
 func (s *jobService) DeleteJob(id uuid.UUID) error {
 	logrus.WithFields(logrus.Fields{
@@ -191,6 +696,9 @@ func (s *jobService) DeleteJob(id uuid.UUID) error {
 	if err := s.jobRepo.Delete(id); err != nil {
 		return fmt.Errorf("failed to delete job: %w", err)
 	}
+	if listener := s.changeListener(); listener != nil {
+		listener.JobDeleted(id)
+	}
 
 	logrus.WithFields(logrus.Fields{
 		"job_id": id,
@@ -199,6 +707,123 @@ func (s *jobService) DeleteJob(id uuid.UUID) error {
 	return nil
 }
 
+// SetChangeListener registers the listener told about saved and deleted jobs
+func (s *jobService) SetChangeListener(listener JobChangeListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listener = listener
+}
+
+// changeListener returns the registered change listener, if any
+func (s *jobService) changeListener() JobChangeListener {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.listener
+}
+
+// SetRevisionRecorder registers the recorder told about changes to jobs' definitions
+func (s *jobService) SetRevisionRecorder(recorder JobRevisionRecorder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revisions = recorder
+}
+
+// SetRunTimeSource registers the source of jobs' live next and last run times
+func (s *jobService) SetRunTimeSource(source JobRunTimeSource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.runTimes = source
+}
+
+// SetCronSecondsDefault sets whether new jobs' schedules may have a seconds field when they don't set cron_seconds
+func (s *jobService) SetCronSecondsDefault(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cronSeconds = enabled
+}
+
+// SetUniqueJobNames sets whether each team's jobs must have different names
+func (s *jobService) SetUniqueJobNames(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.uniqueNames = enabled
+}
+
+// uniqueJobNames returns whether each team's jobs must have different names
+func (s *jobService) uniqueJobNames() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.uniqueNames
+}
+
+// defaultCronSeconds returns whether new jobs' schedules may have a seconds field by default
+func (s *jobService) defaultCronSeconds() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cronSeconds
+}
+
+// applyRunTimes sets when the jobs run next, from their cron entries, and when they last ran, from
+// their latest runs. Without a source, or for jobs it doesn't know, the recorded times are kept
+func (s *jobService) applyRunTimes(jobs []models.Job) error {
+	s.mu.RLock()
+	source := s.runTimes
+	s.mu.RUnlock()
+	if source == nil || len(jobs) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(jobs))
+	for i := range jobs {
+		ids[i] = jobs[i].ID
+	}
+	lastRuns, err := source.LastRunTimes(ids)
+	if err != nil {
+		return fmt.Errorf("failed to get last run times: %w", err)
+	}
+	nextRuns := source.NextRunTimes(ids)
+
+	for i := range jobs {
+		if next, ok := nextRuns[jobs[i].ID]; ok {
+			jobs[i].NextRunAt = &next
+		}
+		if last, ok := lastRuns[jobs[i].ID]; ok {
+			jobs[i].LastRunAt = &last
+		}
+	}
+	return nil
+}
+
+// jobCreated tells the change listener about a new job, before it is told the job was saved
+func (s *jobService) jobCreated(job *models.Job) {
+	if listener := s.changeListener(); listener != nil {
+		jobCopy := *job
+		listener.JobCreated(&jobCopy)
+	}
+}
+
+// jobSaved tells the change listener about a created or updated job
+func (s *jobService) jobSaved(job *models.Job) {
+	if listener := s.changeListener(); listener != nil {
+		jobCopy := *job
+		listener.JobSaved(&jobCopy)
+	}
+}
+
+// jobRevised tells the revision recorder about a created or updated job
+// Failing to record a revision is logged rather than failing a change that was already saved
+func (s *jobService) jobRevised(previous, job *models.Job, author string) {
+	s.mu.RLock()
+	recorder := s.revisions
+	s.mu.RUnlock()
+	if recorder == nil {
+		return
+	}
+	if err := recorder.RecordRevision(previous, job, author); err != nil {
+		logrus.WithError(err).WithField("job_id", job.ID).Warn("Failed to record job revision")
+	}
+}
+
 // GetActiveJobs retrieves all active jobs
 func (s *jobService) GetActiveJobs() ([]models.Job, error) {
 	jobs, err := s.jobRepo.GetActiveJobs()
@@ -208,11 +833,164 @@ func (s *jobService) GetActiveJobs() ([]models.Job, error) {
 	return jobs, nil
 }
 
-// ValidateCronSchedule validates a cron schedule expression
+// GetSchedulableJobs retrieves the jobs the scheduler schedules, including errored jobs it tries again
+func (s *jobService) GetSchedulableJobs() ([]models.Job, error) {
+	jobs, err := s.jobRepo.GetSchedulableJobs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedulable jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// scheduleNextRun sets when an active job's next occurrence is due, counting from now
+// Jobs in other states have no next occurrence
+func (s *jobService) scheduleNextRun(job *models.Job) {
+	job.NextRunAt = nil
+	if !job.IsActive() {
+		return
+	}
+	schedule, err := JobSchedule(job)
+	if err != nil {
+		return
+	}
+	next := schedule.Next(time.Now()).UTC()
+	if next.IsZero() {
+		return
+	}
+	job.NextRunAt = &next
+}
+
+// CompleteOneTimeJob expires a one-time job once its run has fired, recording when
+func (s *jobService) CompleteOneTimeJob(id uuid.UUID, ranAt time.Time) error {
+	job, err := s.jobRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+	if !job.IsOneTime() {
+		return fmt.Errorf("job %s is not a one-time job", id)
+	}
+
+	now := time.Now().UTC()
+	ranAt = ranAt.UTC()
+	job.State = models.JobStateExpired
+	job.CompletedAt = &now
+	job.LastRunAt = &ranAt
+	job.NextRunAt = nil
+
+	if err := s.jobRepo.Update(job); err != nil {
+		return fmt.Errorf("failed to complete job: %w", err)
+	}
+	s.jobSaved(job)
+
+	logrus.WithFields(logrus.Fields{
+		"job_id": job.ID,
+		"name":   job.Name,
+		"run_at": ranAt,
+	}).Info("One-time job completed")
+	return nil
+}
+
+// RecordRunTimes records the job's last fired occurrence and the next one due
+func (s *jobService) RecordRunTimes(id uuid.UUID, lastRunAt, nextRunAt time.Time) error {
+	if err := s.jobRepo.UpdateRunTimes(id, lastRunAt, nextRunAt); err != nil {
+		return fmt.Errorf("failed to record job run times: %w", err)
+	}
+	return nil
+}
+
+// ValidateCronSchedule validates a cron schedule expression, descriptor or @every interval
+// A seconds field is only accepted when new jobs may use one by default
 func (s *jobService) ValidateCronSchedule(schedule string) error {
-	_, err := s.parser.Parse(schedule)
+	return validateCronExpression(schedule, s.defaultCronSeconds())
+}
+
+// validateCronExpression validates a cron schedule expression, with or without a seconds field
+func validateCronExpression(schedule string, withSeconds bool) error {
+	_, err := ParseSchedule(schedule, withSeconds)
 	if err != nil {
 		return fmt.Errorf("invalid cron expression '%s': %w", schedule, err)
 	}
 	return nil
 }
+
+// validateSchedule checks a job's timing: cron jobs need a valid cron expression, one-time jobs
+// a run_at and no cron expression
+func (s *jobService) validateSchedule(scheduleType models.ScheduleType, schedule string, runAt *time.Time, cronSeconds bool) error {
+	switch scheduleType {
+	case models.ScheduleTypeCron:
+		if runAt != nil {
+			return errors.New("run_at is only used by one-time jobs")
+		}
+		if err := validateCronExpression(schedule, cronSeconds); err != nil {
+			return fmt.Errorf("invalid cron schedule: %w", err)
+		}
+	case models.ScheduleTypeOnce:
+		if schedule != "" {
+			return errors.New("one-time jobs run at run_at and have no cron schedule")
+		}
+		if runAt == nil {
+			return errors.New("run_at is required for one-time jobs")
+		}
+	default:
+		return fmt.Errorf("invalid schedule type: %s", scheduleType)
+	}
+	return nil
+}
+
+// validateRunbookURL checks that a runbook link, if set, is an absolute http(s) URL
+func validateRunbookURL(runbookURL string) error {
+	if runbookURL == "" {
+		return nil
+	}
+	parsed, err := url.ParseRequestURI(runbookURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid runbook URL: %s", runbookURL)
+	}
+	return nil
+}
+
+// validateRemediationActions checks a job's remediation actions; fallback jobs must exist and
+// can't be the job itself
+func (s *jobService) validateRemediationActions(jobID uuid.UUID, actions models.RemediationActions) error {
+	if len(actions) > models.MaxRemediationActions {
+		return fmt.Errorf("a job may declare at most %d remediation actions", models.MaxRemediationActions)
+	}
+
+	for _, action := range actions {
+		switch action.Type {
+		case models.RemediationRetryNow, models.RemediationDisableJob:
+		case models.RemediationRunFallback:
+			if action.FallbackJobID == nil {
+				return errors.New("run_fallback_job requires fallback_job_id")
+			}
+			if *action.FallbackJobID == jobID {
+				return errors.New("a job can't be its own fallback")
+			}
+			if _, err := s.jobRepo.GetByID(*action.FallbackJobID); err != nil {
+				return fmt.Errorf("invalid fallback job: %w", err)
+			}
+		case models.RemediationCallWebhook:
+			parsed, err := url.ParseRequestURI(action.WebhookURL)
+			if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+				return fmt.Errorf("invalid remediation webhook URL: %s", action.WebhookURL)
+			}
+		default:
+			return fmt.Errorf("invalid remediation action: %s", action.Type)
+		}
+	}
+	return nil
+}
+
+// validateRetryPolicy checks a job's retry count, backoff strategy and initial delay
+func validateRetryPolicy(maxRetries int, backoff models.BackoffStrategy, initialDelaySeconds int) error {
+	if maxRetries < 0 || maxRetries > models.MaxJobRetries {
+		return fmt.Errorf("max_retries must be between 0 and %d", models.MaxJobRetries)
+	}
+	if !models.IsValidBackoffStrategy(string(backoff)) {
+		return fmt.Errorf("invalid backoff strategy: %s", backoff)
+	}
+	if initialDelaySeconds < 0 {
+		return fmt.Errorf("initial_delay_seconds must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"fmt"
+
+	"job-scheduler/internal/models"
+)
+
+// requestedStateChange returns the state an update request moves a job to, or nil when it leaves
+// the job's state as it is
+// is_active false leaves jobs that aren't active as they are, and is_active true leaves errored jobs
+// to the scheduler, so older clients setting is_active don't change states they don't know about
+func requestedStateChange(job *models.Job, req *models.UpdateJobRequest) (*models.JobState, error) {
+	state, err := models.RequestedJobState(req.IsActive, req.State)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil || *state == job.State {
+		return nil, nil
+	}
+	if req.State == nil {
+		if !*req.IsActive && !job.IsActive() {
+			return nil, nil
+		}
+		if *req.IsActive && job.State == models.JobStateErrored {
+			return nil, nil
+		}
+	}
+
+	if !state.IsRequestable() {
+		return nil, fmt.Errorf("%w: %s", ErrJobStateNotRequestable, *state)
+	}
+	if !job.State.CanTransitionTo(*state) {
+		return nil, fmt.Errorf("%w: from %s to %s", ErrInvalidStateTransition, job.State, *state)
+	}
+	return state, nil
+}
+
+// validateJobFilter checks a filter only names job states and types that exist, and that its
+// created range isn't empty
+func validateJobFilter(filter models.JobFilter) error {
+	for _, state := range filter.States {
+		if !models.IsValidJobState(string(state)) {
+			return fmt.Errorf("%w: unknown job state %q", ErrInvalidJobFilter, state)
+		}
+	}
+	if filter.JobType != "" && !models.IsValidJobType(string(filter.JobType)) {
+		return fmt.Errorf("%w: unknown job type %q", ErrInvalidJobFilter, filter.JobType)
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return fmt.Errorf("%w: created_after must be before created_before", ErrInvalidJobFilter)
+	}
+	return nil
+}
@@ -1,35 +1,82 @@
 package services
 
 import (
+	"context"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"os"
-	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
+	"job-scheduler/internal/integrations"
 	"job-scheduler/internal/models"
 )
 
 // JobExecutor defines the interface for executing different types of jobs
+// Executors stop work and return the context's error once ctx is cancelled
 type JobExecutor interface {
-	Execute(job *models.Job) error
+	Execute(ctx context.Context, job *models.Job) error
 	GetJobType() models.JobType
 }
 
+// LifecycleExecutor is implemented by executors that prepare resources before the first run
+// and release them after the last. The scheduler calls Init on start, failing to start if it
+// returns an error, and Close on stop once running jobs have finished
+type LifecycleExecutor interface {
+	Init(ctx context.Context) error
+	Close() error
+}
+
+// UsageReportingExecutor is implemented by executors that measure their own resource usage,
+// such as containerized executors reporting container stats instead of process counters
+type UsageReportingExecutor interface {
+	ExecuteWithUsage(ctx context.Context, job *models.Job) (*models.ResourceUsage, error)
+}
+
 // EmailNotificationExecutor handles email notification jobs
-type EmailNotificationExecutor struct{}
+// Without an SMTP integration, sending is simulated
+type EmailNotificationExecutor struct {
+	integrations *integrations.Manager
+	from         string
+	suppressions SuppressionLookup
+	data         ReportDataSource
+	artifacts    ArtifactReader
+}
+
+// NewEmailNotificationExecutor creates an email executor that sends through the shared SMTP pool
+func NewEmailNotificationExecutor(manager *integrations.Manager, from string) *EmailNotificationExecutor {
+	return &EmailNotificationExecutor{
+		integrations: manager,
+		from:         from,
+	}
+}
+
+// SetSuppressions skips recipients on the suppression list, such as addresses that bounced
+func (e *EmailNotificationExecutor) SetSuppressions(suppressions SuppressionLookup) {
+	e.suppressions = suppressions
+}
+
+// SetRecipientSources enables batch emails whose recipients come from a SQL query or a CSV artifact
+func (e *EmailNotificationExecutor) SetRecipientSources(data ReportDataSource, artifacts ArtifactReader) {
+	e.data = data
+	e.artifacts = artifacts
+}
 
-// Execute simulates sending an email notification
-func (e *EmailNotificationExecutor) Execute(job *models.Job) error {
-	logrus.WithFields(logrus.Fields{
+// Execute sends an email notification, to every recipient of a recipient source for batch emails
+func (e *EmailNotificationExecutor) Execute(ctx context.Context, job *models.Job) error {
+	RunLogger(ctx).WithFields(logrus.Fields{
 		"job_id":   job.ID,
 		"job_name": job.Name,
 		"job_type": job.JobType,
 	}).Info("Starting email notification job")
 
+	if isBatchEmail(job.Config) {
+		return e.executeBatch(ctx, job)
+	}
+
 	// Extract configuration
 	recipient := "user@example.com"
 	subject := "Scheduled Notification"
@@ -47,11 +94,42 @@ func (e *EmailNotificationExecutor) Execute(job *models.Job) error {
 		}
 	}
 
-	// Simulate email sending delay
-	time.Sleep(1 * time.Second)
+	// Don't send to recipients who bounced, complained or unsubscribed
+	if e.suppressions != nil {
+		suppressed, err := e.suppressions.SuppressedRecipients([]string{recipient})
+		if err != nil {
+			return fmt.Errorf("failed to check email suppression list: %w", err)
+		}
+		RecordOutput(ctx, "suppressed_recipients", len(suppressed))
+		if suppressed[recipient] {
+			RecordOutput(ctx, "recipient", recipient)
+			RecordOutput(ctx, "subject", subject)
+			RunLogger(ctx).WithFields(logrus.Fields{
+				"job_id":    job.ID,
+				"recipient": recipient,
+			}).Warn("Recipient is suppressed - email not sent")
+			return nil
+		}
+	}
+
+	if e.integrations != nil && e.integrations.Has(integrations.SMTPIntegration) {
+		// A retry doesn't send the email again once an earlier attempt sent it
+		messageID, err := PerformSideEffectOnce(ctx, "email:"+recipient, func() (string, error) {
+			return e.send(ctx, recipient, subject, body)
+		})
+		if err != nil {
+			return err
+		}
+		RecordOutput(ctx, "message_id", messageID)
+	} else if err := sleepContext(ctx, 1*time.Second); err != nil { // Simulate email sending delay
+		return err
+	}
+
+	RecordOutput(ctx, "recipient", recipient)
+	RecordOutput(ctx, "subject", subject)
 
 	// Log the "email" details
-	logrus.WithFields(logrus.Fields{
+	RunLogger(ctx).WithFields(logrus.Fields{
 		"job_id":    job.ID,
 		"recipient": recipient,
 		"subject":   subject,
@@ -61,22 +139,61 @@ func (e *EmailNotificationExecutor) Execute(job *models.Job) error {
 	return nil
 }
 
+// Init connects to the SMTP server ahead of the first run
+// An unreachable server is logged rather than failing startup; runs reconnect on use
+func (e *EmailNotificationExecutor) Init(ctx context.Context) error {
+	if e.integrations == nil || !e.integrations.Has(integrations.SMTPIntegration) {
+		return nil
+	}
+	if _, err := e.integrations.Get(ctx, integrations.SMTPIntegration); err != nil {
+		logrus.WithError(err).Warn("Failed to pre-connect to SMTP server")
+	}
+	return nil
+}
+
+// Close does nothing; the SMTP pool is closed with the other integrations
+func (e *EmailNotificationExecutor) Close() error {
+	return nil
+}
+
+// send delivers the email on a pooled SMTP connection, returning its message-id
+func (e *EmailNotificationExecutor) send(ctx context.Context, recipient, subject, body string) (string, error) {
+	resource, err := e.integrations.Get(ctx, integrations.SMTPIntegration)
+	if err != nil {
+		return "", err
+	}
+	sender, ok := resource.(integrations.MailSender)
+	if !ok {
+		return "", fmt.Errorf("smtp integration can't send mail")
+	}
+	messageID := fmt.Sprintf("<%s@job-scheduler>", uuid.New())
+
+	// Header values must not contain line breaks
+	header := strings.NewReplacer("\r", " ", "\n", " ")
+	message := strings.Join([]string{
+		"From: " + e.from,
+		"To: " + header.Replace(recipient),
+		"Subject: " + header.Replace(subject),
+		"Message-ID: " + messageID,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	if err := sender.Send(ctx, e.from, []string{recipient}, []byte(message)); err != nil {
+		return "", err
+	}
+	return messageID, nil
+}
+
 // GetJobType returns the job type
 func (e *EmailNotificationExecutor) GetJobType() models.JobType {
 	return models.JobTypeEmailNotification
 }
 
-// DataProcessingExecutor handles data processing jobs
-type DataProcessingExecutor struct{}
-
-// Execute simulates data processing
-func (d *DataProcessingExecutor) Execute(job *models.Job) error {
-	logrus.WithFields(logrus.Fields{
-		"job_id":   job.ID,
-		"job_name": job.Name,
-		"job_type": job.JobType,
-	}).Info("Starting data processing job")
-
+// simulate simulates data processing for jobs without a pipeline
+func (d *DataProcessingExecutor) simulate(ctx context.Context, job *models.Job) error {
 	// Extract configuration
 	processingTime := 5
 	dataSize := "1MB"
@@ -94,7 +211,7 @@ func (d *DataProcessingExecutor) Execute(job *models.Job) error {
 		}
 	}
 
-	logrus.WithFields(logrus.Fields{
+	RunLogger(ctx).WithFields(logrus.Fields{
 		"job_id":           job.ID,
 		"data_size":        dataSize,
 		"operation":        operation,
@@ -102,9 +219,13 @@ func (d *DataProcessingExecutor) Execute(job *models.Job) error {
 	}).Info("Processing data...")
 
 	// Simulate data processing
-	time.Sleep(time.Duration(processingTime) * time.Second)
+	if err := sleepContext(ctx, time.Duration(processingTime)*time.Second); err != nil {
+		return err
+	}
+	RecordOutput(ctx, "operation", operation)
+	RecordOutput(ctx, "data_size", dataSize)
 
-	logrus.WithFields(logrus.Fields{
+	RunLogger(ctx).WithFields(logrus.Fields{
 		"job_id":     job.ID,
 		"data_size":  dataSize,
 		"operation":  operation,
@@ -113,97 +234,67 @@ func (d *DataProcessingExecutor) Execute(job *models.Job) error {
 	return nil
 }
 
-// GetJobType returns the job type
-func (d *DataProcessingExecutor) GetJobType() models.JobType {
-	return models.JobTypeDataProcessing
-}
-
 // ReportGenerationExecutor handles report generation jobs
 type ReportGenerationExecutor struct {
 	reportsDir string
+	templates  ReportTemplateLookup
+	data       ReportDataSource
+	httpClient *http.Client
 }
 
 // NewReportGenerationExecutor creates a new report generation executor
 func NewReportGenerationExecutor(reportsDir string) *ReportGenerationExecutor {
 	return &ReportGenerationExecutor{
 		reportsDir: reportsDir,
+		httpClient: &http.Client{},
 	}
 }
 
-// Execute generates a simple text report
-func (r *ReportGenerationExecutor) Execute(job *models.Job) error {
-	logrus.WithFields(logrus.Fields{
-		"job_id":   job.ID,
-		"job_name": job.Name,
-		"job_type": job.JobType,
-	}).Info("Starting report generation job")
-
-	// Extract configuration
-	reportType := "daily_summary"
-	format := "txt"
-	includeCharts := false
+// SetTemplates enables reports built from stored templates
+func (r *ReportGenerationExecutor) SetTemplates(templates ReportTemplateLookup, data ReportDataSource) {
+	r.templates = templates
+	r.data = data
+}
 
-	if job.Config != nil {
-		if rt, ok := job.Config["report_type"].(string); ok {
-			reportType = rt
-		}
-		if f, ok := job.Config["format"].(string); ok {
-			format = f
-		}
-		if ic, ok := job.Config["include_charts"].(bool); ok {
-			includeCharts = ic
-		}
-	}
+// SetHTTPClient sets the client fetching reports' HTTP sources; the run's timeout bounds each request
+func (r *ReportGenerationExecutor) SetHTTPClient(client *http.Client) {
+	r.httpClient = client
+}
 
-	// Ensure reports directory exists
+// Init checks the reports directory can be created
+func (r *ReportGenerationExecutor) Init(ctx context.Context) error {
 	if err := os.MkdirAll(r.reportsDir, 0755); err != nil {
 		return fmt.Errorf("failed to create reports directory: %w", err)
 	}
+	return nil
+}
 
-	// Generate report filename
-	timestamp := time.Now().Format("20060102_150405")
-	filename := fmt.Sprintf("%s_%s_%s.%s", reportType, job.ID.String()[:8], timestamp, format)
-	filepath := filepath.Join(r.reportsDir, filename)
-
-	// Generate report content
-	content := fmt.Sprintf(`Report: %s
-Generated: %s
-Job ID: %s
-Job Name: %s
-
-Summary:
-- Report Type: %s
-- Format: %s
-- Include Charts: %t
-- Generated at: %s
-
-This is a sample report generated by the job scheduler.
-In a real implementation, this would contain actual data and analysis.
-
-Sample Data:
-- Total Records Processed: 1,234
-- Success Rate: 98.5%%
-- Average Processing Time: 2.3 seconds
-- Errors Encountered: 18
-
-End of Report
-`, reportType, time.Now().Format("2006-01-02 15:04:05"), job.ID, job.Name,
-		reportType, format, includeCharts, time.Now().Format("2006-01-02 15:04:05"))
-
-	// Write report to file
-	if err := ioutil.WriteFile(filepath, []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to write report file: %w", err)
-	}
+// Close does nothing; reports are written per run
+func (r *ReportGenerationExecutor) Close() error {
+	return nil
+}
 
-	logrus.WithFields(logrus.Fields{
-		"job_id":         job.ID,
-		"report_type":    reportType,
-		"format":         format,
-		"include_charts": includeCharts,
-		"file_path":      filepath,
-	}).Info("Report generated successfully")
+// Execute generates a report
+func (r *ReportGenerationExecutor) Execute(ctx context.Context, job *models.Job) error {
+	_, err := r.ExecuteWithArtifacts(ctx, job)
+	return err
+}
 
-	return nil
+// ExecuteWithArtifacts generates a report in txt, csv, json, xlsx or pdf format, from a stored
+// report template when config["report_template"] names one, and returns the report file
+func (r *ReportGenerationExecutor) ExecuteWithArtifacts(ctx context.Context, job *models.Job) ([]ArtifactFile, error) {
+	RunLogger(ctx).WithFields(logrus.Fields{
+		"job_id":   job.ID,
+		"job_name": job.Name,
+		"job_type": job.JobType,
+	}).Info("Starting report generation job")
+
+	if job.Config != nil {
+		if name, ok := job.Config["report_template"].(string); ok && name != "" {
+			return r.executeTemplate(ctx, job, name)
+		}
+	}
+	return r.executeReport(ctx, job)
 }
 
 // GetJobType returns the job type
@@ -216,59 +307,91 @@ type HealthCheckExecutor struct {
 	httpClient *http.Client
 }
 
-// NewHealthCheckExecutor creates a new health check executor
-func NewHealthCheckExecutor(timeout time.Duration) *HealthCheckExecutor {
+// NewHealthCheckExecutor creates a new health check executor using the given client
+func NewHealthCheckExecutor(httpClient *http.Client) *HealthCheckExecutor {
 	return &HealthCheckExecutor{
-		httpClient: &http.Client{
-			Timeout: timeout,
-		},
+		httpClient: httpClient,
 	}
 }
 
-// Execute performs a health check by pinging a URL
-func (h *HealthCheckExecutor) Execute(job *models.Job) error {
-	logrus.WithFields(logrus.Fields{
+// Init does nothing; connections are opened by the first check
+func (h *HealthCheckExecutor) Init(ctx context.Context) error {
+	return nil
+}
+
+// Close drops the executor's idle keep-alive connections
+func (h *HealthCheckExecutor) Close() error {
+	h.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// Execute performs a health check by running its probes: HTTP requests, TCP connects and DNS lookups
+// Every probe runs, and the check fails if any of them does
+func (h *HealthCheckExecutor) Execute(ctx context.Context, job *models.Job) error {
+	RunLogger(ctx).WithFields(logrus.Fields{
 		"job_id":   job.ID,
 		"job_name": job.Name,
 		"job_type": job.JobType,
 	}).Info("Starting health check job")
 
 	// Extract configuration
-	url := "https://httpbin.org/status/200"
-	expectedStatus := 200
+	probes, err := parseHealthProbes(job.Config)
+	if err != nil {
+		return fmt.Errorf("health check failed - %w", err)
+	}
 
-	if job.Config != nil {
-		if u, ok := job.Config["url"].(string); ok {
-			url = u
-		}
-		if es, ok := job.Config["expected_status"].(float64); ok {
-			expectedStatus = int(es)
-		}
+	// Count requests against the job's call budget, if it declares one
+	budget, err := ParseCallBudget(job.Config)
+	if err != nil {
+		return fmt.Errorf("health check failed - %w", err)
 	}
 
-	logrus.WithFields(logrus.Fields{
-		"job_id":          job.ID,
-		"url":             url,
-		"expected_status": expectedStatus,
-	}).Info("Performing health check...")
+	results := make([]HealthProbeResult, 0, len(probes))
+	var failures []string
+	for _, probe := range probes {
+		RunLogger(ctx).WithFields(logrus.Fields{
+			"job_id": job.ID,
+			"probe":  probe.name,
+			"type":   probe.probeType,
+			"target": probe.target,
+		}).Info("Performing health check...")
+
+		result := h.runProbe(ctx, budget, probe)
+		results = append(results, result)
+		if !result.OK {
+			failures = append(failures, fmt.Sprintf("%s: %s", result.Name, result.Error))
+		}
+	}
 
-	// Perform HTTP request
-	resp, err := h.httpClient.Get(url)
-	if err != nil {
-		return fmt.Errorf("health check failed - request error: %w", err)
+	if len(probes) == 1 && probes[0].probeType == HealthProbeHTTP {
+		// A single URL keeps the output of the original health check
+		RecordOutput(ctx, "url", results[0].Target)
+		RecordOutput(ctx, "status_code", results[0].StatusCode)
+		RecordOutput(ctx, "response_body", results[0].Body)
+		RecordOutput(ctx, "latency_ms", results[0].LatencyMS)
+	} else {
+		// Only failed probes keep the start of their response bodies
+		for i := range results {
+			if results[i].OK {
+				results[i].Body = ""
+			} else if len(results[i].Body) > maxProbeBodyOutput {
+				results[i].Body = results[i].Body[:maxProbeBodyOutput]
+			}
+		}
+		RecordOutput(ctx, "probes", results)
+		RecordOutput(ctx, "probes_failed", len(failures))
 	}
-	defer resp.Body.Close()
 
-	// Check status code
-	if resp.StatusCode != expectedStatus {
-		return fmt.Errorf("health check failed - expected status %d, got %d", expectedStatus, resp.StatusCode)
+	if len(failures) == 1 && len(probes) == 1 {
+		return fmt.Errorf("health check failed - %s", results[0].Error)
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("health check failed - %d of %d probes failed: %s", len(failures), len(probes), strings.Join(failures, "; "))
 	}
 
-	logrus.WithFields(logrus.Fields{
-		"job_id":      job.ID,
-		"url":         url,
-		"status_code": resp.StatusCode,
-		"status":      resp.Status,
+	RunLogger(ctx).WithFields(logrus.Fields{
+		"job_id": job.ID,
+		"probes": len(probes),
 	}).Info("Health check completed successfully")
 
 	return nil
@@ -278,3 +401,16 @@ func (h *HealthCheckExecutor) Execute(job *models.Job) error {
 func (h *HealthCheckExecutor) GetJobType() models.JobType {
 	return models.JobTypeHealthCheck
 }
+
+// sleepContext waits for d, returning early with the context's error if ctx is cancelled
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
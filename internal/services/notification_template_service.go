@@ -0,0 +1,148 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/notifications"
+	"job-scheduler/internal/repositories"
+)
+
+// ErrTemplateNameTaken is returned when creating a template with an existing name
+var ErrTemplateNameTaken = errors.New("a template with this name already exists")
+
+// NotificationTemplateService defines the interface for managing notification templates
+type NotificationTemplateService interface {
+	CreateTemplate(req *models.CreateNotificationTemplateRequest) (*models.NotificationTemplate, error)
+	GetTemplates() ([]models.NotificationTemplate, error)
+	GetTemplateByID(id uuid.UUID) (*models.NotificationTemplate, error)
+	UpdateTemplate(id uuid.UUID, req *models.UpdateNotificationTemplateRequest) (*models.NotificationTemplate, error)
+	DeleteTemplate(id uuid.UUID) error
+}
+
+// notificationTemplateService implements NotificationTemplateService interface
+type notificationTemplateService struct {
+	templateRepo repositories.NotificationTemplateRepository
+}
+
+// NewNotificationTemplateService creates a new notification template service
+func NewNotificationTemplateService(templateRepo repositories.NotificationTemplateRepository) NotificationTemplateService {
+	return &notificationTemplateService{
+		templateRepo: templateRepo,
+	}
+}
+
+// CreateTemplate validates and stores a notification template
+func (s *notificationTemplateService) CreateTemplate(req *models.CreateNotificationTemplateRequest) (*models.NotificationTemplate, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("template name is required")
+	}
+	if !models.IsValidTemplateChannel(string(req.Channel)) {
+		return nil, fmt.Errorf("invalid template channel: %s", req.Channel)
+	}
+	if err := notifications.ValidateTemplate(req.Channel, req.Subject, req.Body); err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	existing, err := s.templateRepo.FindByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check template name: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrTemplateNameTaken
+	}
+
+	template := &models.NotificationTemplate{
+		ID:       uuid.New(),
+		Name:     name,
+		Channel:  req.Channel,
+		Event:    strings.TrimSpace(req.Event),
+		Subject:  req.Subject,
+		Body:     req.Body,
+		IsActive: true,
+	}
+	if req.IsActive != nil {
+		template.IsActive = *req.IsActive
+	}
+
+	if err := s.templateRepo.Create(template); err != nil {
+		return nil, fmt.Errorf("failed to create template: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"template_id": template.ID,
+		"name":        template.Name,
+		"channel":     template.Channel,
+		"event":       template.Event,
+	}).Info("Notification template created successfully")
+
+	return template, nil
+}
+
+// GetTemplates lists all notification templates
+func (s *notificationTemplateService) GetTemplates() ([]models.NotificationTemplate, error) {
+	templates, err := s.templateRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get templates: %w", err)
+	}
+	return templates, nil
+}
+
+// GetTemplateByID retrieves a notification template by its ID
+func (s *notificationTemplateService) GetTemplateByID(id uuid.UUID) (*models.NotificationTemplate, error) {
+	template, err := s.templateRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+	return template, nil
+}
+
+// UpdateTemplate updates a notification template, validating the result
+func (s *notificationTemplateService) UpdateTemplate(id uuid.UUID, req *models.UpdateNotificationTemplateRequest) (*models.NotificationTemplate, error) {
+	template, err := s.templateRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template for update: %w", err)
+	}
+
+	if req.Event != nil {
+		template.Event = strings.TrimSpace(*req.Event)
+	}
+	if req.Subject != nil {
+		template.Subject = *req.Subject
+	}
+	if req.Body != nil {
+		template.Body = *req.Body
+	}
+	if req.IsActive != nil {
+		template.IsActive = *req.IsActive
+	}
+
+	if err := notifications.ValidateTemplate(template.Channel, template.Subject, template.Body); err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	if err := s.templateRepo.Update(template); err != nil {
+		return nil, fmt.Errorf("failed to update template: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"template_id": template.ID,
+		"name":        template.Name,
+	}).Info("Notification template updated successfully")
+
+	return template, nil
+}
+
+// DeleteTemplate deletes a notification template - its channel goes back to the built-in format
+func (s *notificationTemplateService) DeleteTemplate(id uuid.UUID) error {
+	if err := s.templateRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+	return nil
+}
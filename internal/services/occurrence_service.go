@@ -0,0 +1,58 @@
+package services
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+// OccurrenceService defines the interface for explaining scheduled occurrences that didn't run
+type OccurrenceService interface {
+	GetMissedOccurrences(jobID uuid.UUID, page, limit int) (*models.MissedOccurrenceListResponse, error)
+}
+
+// occurrenceService implements OccurrenceService interface
+type occurrenceService struct {
+	jobRepo    repositories.JobRepository
+	missedRepo repositories.MissedOccurrenceRepository
+}
+
+// NewOccurrenceService creates a new occurrence service
+func NewOccurrenceService(jobRepo repositories.JobRepository, missedRepo repositories.MissedOccurrenceRepository) OccurrenceService {
+	return &occurrenceService{
+		jobRepo:    jobRepo,
+		missedRepo: missedRepo,
+	}
+}
+
+// GetMissedOccurrences retrieves a page of a job's missed occurrences, newest first
+func (s *occurrenceService) GetMissedOccurrences(jobID uuid.UUID, page, limit int) (*models.MissedOccurrenceListResponse, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	if _, err := s.jobRepo.GetByID(jobID); err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	occurrences, totalCount, err := s.missedRepo.GetByJobID(jobID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get missed occurrences: %w", err)
+	}
+
+	return &models.MissedOccurrenceListResponse{
+		Occurrences: occurrences,
+		TotalCount:  totalCount,
+		Page:        page,
+		Limit:       limit,
+		TotalPages:  int(math.Ceil(float64(totalCount) / float64(limit))),
+	}, nil
+}
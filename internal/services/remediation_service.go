@@ -0,0 +1,223 @@
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+var (
+	// ErrActionLinkInvalid is returned when a signed action link doesn't verify
+	ErrActionLinkInvalid = errors.New("action link is invalid")
+	// ErrActionLinkExpired is returned when a signed action link has expired
+	ErrActionLinkExpired = errors.New("action link has expired")
+)
+
+// remediationActor is recorded as the user when a remediation link pauses a job
+const remediationActor = "remediation-link"
+
+// RemediationService defines the interface for remediation actions offered in failure notifications
+type RemediationService interface {
+	Links(job *models.Job, execution *models.JobExecution) []models.RemediationLink
+	Describe(executionID uuid.UUID, index int, expires, signature string) (*models.RemediationAction, *models.Job, error)
+	Perform(executionID uuid.UUID, index int, expires, signature string) (*models.RemediationResult, error)
+}
+
+// remediationService implements RemediationService interface
+type remediationService struct {
+	jobRepo       repositories.JobRepository
+	executionRepo repositories.JobExecutionRepository
+	jobService    JobService
+	trigger       JobTrigger
+	httpClient    *http.Client
+	baseURL       string
+	secret        []byte
+	expiry        time.Duration
+}
+
+// NewRemediationService creates a new remediation service
+// When the signing secret is empty a random one is generated, so links don't survive restarts
+func NewRemediationService(
+	jobRepo repositories.JobRepository,
+	executionRepo repositories.JobExecutionRepository,
+	jobService JobService,
+	trigger JobTrigger,
+	httpClient *http.Client,
+	cfg config.RemediationConfig,
+) RemediationService {
+	secret := []byte(cfg.SigningSecret)
+	if cfg.SigningSecret == "" {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			panic(fmt.Sprintf("failed to generate signing secret: %v", err))
+		}
+		logrus.Warn("REMEDIATION_SIGNING_SECRET is not set - action links will be invalidated on restart")
+	}
+
+	return &remediationService{
+		jobRepo:       jobRepo,
+		executionRepo: executionRepo,
+		jobService:    jobService,
+		trigger:       trigger,
+		httpClient:    httpClient,
+		baseURL:       cfg.PublicBaseURL,
+		secret:        secret,
+		expiry:        cfg.LinkExpiry,
+	}
+}
+
+// Links returns a signed link for each of the job's remediation actions, applying it to the failed run
+func (s *remediationService) Links(job *models.Job, execution *models.JobExecution) []models.RemediationLink {
+	if len(job.RemediationActions) == 0 {
+		return nil
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(s.expiry).Unix(), 10)
+	links := make([]models.RemediationLink, 0, len(job.RemediationActions))
+	for i, action := range job.RemediationActions {
+		links = append(links, models.RemediationLink{
+			Type:  action.Type,
+			Label: action.DisplayLabel(),
+			URL: fmt.Sprintf("%s/api/v1/actions/%s/%d?expires=%s&signature=%s",
+				s.baseURL, execution.ID, i, expires, s.sign(execution.ID, i, action.Type, expires)),
+		})
+	}
+	return links
+}
+
+// Describe verifies an action link and returns the action it applies and the failed run's job
+func (s *remediationService) Describe(executionID uuid.UUID, index int, expires, signature string) (*models.RemediationAction, *models.Job, error) {
+	execution, err := s.executionRepo.GetByID(executionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get execution: %w", err)
+	}
+	job, err := s.jobRepo.GetByID(execution.JobID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	// Links stop working if the job's actions change under them
+	if index < 0 || index >= len(job.RemediationActions) {
+		return nil, nil, ErrActionLinkInvalid
+	}
+	action := job.RemediationActions[index]
+	if !hmac.Equal([]byte(s.sign(executionID, index, action.Type, expires)), []byte(signature)) {
+		return nil, nil, ErrActionLinkInvalid
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return nil, nil, ErrActionLinkInvalid
+	}
+	if time.Now().Unix() > expiresAt {
+		return nil, nil, ErrActionLinkExpired
+	}
+
+	return &action, job, nil
+}
+
+// Perform verifies an action link and applies its remediation action to the failed run
+func (s *remediationService) Perform(executionID uuid.UUID, index int, expires, signature string) (*models.RemediationResult, error) {
+	action, job, err := s.Describe(executionID, index, expires, signature)
+	if err != nil {
+		return nil, err
+	}
+	execution, err := s.executionRepo.GetByID(executionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get execution: %w", err)
+	}
+
+	result := &models.RemediationResult{
+		Type:        action.Type,
+		Label:       action.DisplayLabel(),
+		JobID:       job.ID,
+		ExecutionID: execution.ID,
+	}
+
+	switch action.Type {
+	case models.RemediationRetryNow:
+		if err := s.trigger.TriggerJob(job, execution.Parameters); err != nil {
+			return nil, fmt.Errorf("failed to retry job: %w", err)
+		}
+		result.Message = fmt.Sprintf("Started a new run of %s", job.Name)
+
+	case models.RemediationRunFallback:
+		fallback, err := s.jobRepo.GetByID(*action.FallbackJobID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get fallback job: %w", err)
+		}
+		if err := s.trigger.TriggerJob(fallback, nil); err != nil {
+			return nil, fmt.Errorf("failed to run fallback job: %w", err)
+		}
+		result.Message = fmt.Sprintf("Started fallback job %s", fallback.Name)
+
+	case models.RemediationDisableJob:
+		reason := fmt.Sprintf("Disabled from the failure notification of run %s", execution.ID)
+		if _, err := s.jobService.PauseJob(job.ID, remediationActor, reason); err != nil && !errors.Is(err, ErrJobAlreadyPaused) {
+			return nil, fmt.Errorf("failed to disable job: %w", err)
+		}
+		result.Message = fmt.Sprintf("Paused %s", job.Name)
+
+	case models.RemediationCallWebhook:
+		if err := s.callWebhook(action.WebhookURL, job, execution); err != nil {
+			return nil, err
+		}
+		result.Message = fmt.Sprintf("Called %s", action.WebhookURL)
+
+	default:
+		return nil, fmt.Errorf("invalid remediation action: %s", action.Type)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":       job.ID,
+		"execution_id": execution.ID,
+		"action":       action.Type,
+	}).Info("Applied remediation action")
+
+	return result, nil
+}
+
+// callWebhook posts the failed run to the action's webhook
+func (s *remediationService) callWebhook(url string, job *models.Job, execution *models.JobExecution) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"event":     "remediation",
+		"job":       dto.FromJob(job),
+		"execution": dto.FromExecution(execution),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	resp, err := s.httpClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to call remediation webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("remediation webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sign returns the hex HMAC-SHA256 of the run, the action and the link's expiry
+func (s *remediationService) sign(executionID uuid.UUID, index int, actionType models.RemediationActionType, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(fmt.Sprintf("%s|%d|%s|%s", executionID, index, actionType, expires)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
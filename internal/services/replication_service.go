@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+// Replication errors
+var (
+	// ErrNotStandby is returned when promoting an instance that isn't a replication standby
+	ErrNotStandby = errors.New("instance is not a replication standby")
+	// ErrAlreadyPromoted is returned when promoting a standby a second time
+	ErrAlreadyPromoted = errors.New("standby was already promoted")
+)
+
+// ReplicationService serves a primary's job changes and applies them on a standby
+type ReplicationService interface {
+	GetChanges(after int64, limit int) (*models.JobChangeFeed, error)
+	GetSnapshot(ctx context.Context) (*models.JobSnapshot, error)
+	LatestSequence() (int64, error)
+	GetState() (*models.ReplicationState, error)
+	ApplyChange(change *models.JobChange) error
+	ApplySnapshot(snapshot *models.JobSnapshot) error
+	Promote(actor string) (*models.ReplicationState, error)
+}
+
+// replicationService implements ReplicationService interface
+type replicationService struct {
+	repo repositories.ReplicationRepository
+}
+
+// NewReplicationService creates a new replication service
+func NewReplicationService(repo repositories.ReplicationRepository) ReplicationService {
+	return &replicationService{
+		repo: repo,
+	}
+}
+
+// GetChanges returns up to limit changes after a sequence, with the latest sequence
+func (s *replicationService) GetChanges(after int64, limit int) (*models.JobChangeFeed, error) {
+	latest, err := s.repo.LatestSequence()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.repo.GetChangesAfter(after, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.JobChangeFeed{Changes: changes, LatestSequence: latest}, nil
+}
+
+// GetSnapshot returns every job, for a standby to start from
+func (s *replicationService) GetSnapshot(ctx context.Context) (*models.JobSnapshot, error) {
+	return s.repo.GetSnapshot(ctx)
+}
+
+// LatestSequence returns the sequence of the latest change recorded
+func (s *replicationService) LatestSequence() (int64, error) {
+	return s.repo.LatestSequence()
+}
+
+// GetState returns how far this standby has followed its primary
+func (s *replicationService) GetState() (*models.ReplicationState, error) {
+	return s.repo.GetState()
+}
+
+// ApplyChange applies one of the primary's changes to this standby's jobs
+func (s *replicationService) ApplyChange(change *models.JobChange) error {
+	var job *models.Job
+	if change.Action == models.JobChangeUpsert {
+		job = &models.Job{}
+		if err := fromJobConfig(change.Payload, job); err != nil {
+			return fmt.Errorf("failed to decode job change %d: %w", change.Sequence, err)
+		}
+		if job.ID != change.JobID {
+			return fmt.Errorf("job change %d is for job %s but holds job %s", change.Sequence, change.JobID, job.ID)
+		}
+	}
+
+	if err := s.repo.ApplyChange(change, job); err != nil {
+		return fmt.Errorf("failed to apply job change %d: %w", change.Sequence, err)
+	}
+	return nil
+}
+
+// ApplySnapshot replaces this standby's jobs with the primary's
+func (s *replicationService) ApplySnapshot(snapshot *models.JobSnapshot) error {
+	if err := s.repo.ApplySnapshot(snapshot); err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"jobs":     len(snapshot.Jobs),
+		"sequence": snapshot.Sequence,
+	}).Info("Job snapshot applied from primary")
+	return nil
+}
+
+// Promote records that this standby was promoted, so it keeps running jobs after a restart
+func (s *replicationService) Promote(actor string) (*models.ReplicationState, error) {
+	state, err := s.repo.GetState()
+	if err != nil {
+		return nil, err
+	}
+	if state.IsPromoted() {
+		return nil, ErrAlreadyPromoted
+	}
+
+	now := time.Now().UTC()
+	state.PromotedAt = &now
+	state.PromotedBy = actor
+	if err := s.repo.SaveState(state); err != nil {
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"promoted_by":   actor,
+		"last_sequence": state.LastSequence,
+	}).Warn("Standby promoted to primary")
+	return state, nil
+}
+
+// jobChangeOutbox records saved and deleted jobs in the outbox a primary serves to standbys, then
+// passes them on to the next listener
+type jobChangeOutbox struct {
+	repo repositories.ReplicationRepository
+	next JobChangeListener
+}
+
+// NewJobChangeOutbox creates a change listener recording every job change for replication
+// Register it in place of the scheduler, which it passes the changes on to
+func NewJobChangeOutbox(repo repositories.ReplicationRepository, next JobChangeListener) JobChangeListener {
+	return &jobChangeOutbox{
+		repo: repo,
+		next: next,
+	}
+}
+
+// JobCreated passes a new job on; it is recorded when it is saved
+func (o *jobChangeOutbox) JobCreated(job *models.Job) {
+	if o.next != nil {
+		o.next.JobCreated(job)
+	}
+}
+
+// JobSaved records the job as it was saved
+func (o *jobChangeOutbox) JobSaved(job *models.Job) {
+	payload, err := toJobConfig(job)
+	if err == nil {
+		o.record(&models.JobChange{JobID: job.ID, Action: models.JobChangeUpsert, Payload: payload})
+	} else {
+		logrus.WithError(err).WithField("job_id", job.ID).Error("Failed to encode job change - standbys won't see it")
+	}
+
+	if o.next != nil {
+		o.next.JobSaved(job)
+	}
+}
+
+// JobDeleted records the deletion
+func (o *jobChangeOutbox) JobDeleted(jobID uuid.UUID) {
+	o.record(&models.JobChange{JobID: jobID, Action: models.JobChangeDelete})
+
+	if o.next != nil {
+		o.next.JobDeleted(jobID)
+	}
+}
+
+// record appends a change to the outbox, logging failures as the change is already saved
+func (o *jobChangeOutbox) record(change *models.JobChange) {
+	if err := o.repo.AppendChange(change); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"job_id": change.JobID,
+			"action": change.Action,
+		}).Error("Failed to record job change - standbys won't see it until they start from a snapshot")
+	}
+}
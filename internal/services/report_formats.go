@@ -0,0 +1,475 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"job-scheduler/internal/models"
+)
+
+// reportDocument is a report ready to be rendered: its framing, columns and the rows of each section
+type reportDocument struct {
+	title          string
+	header         string
+	footer         string
+	jobID          uuid.UUID
+	jobName        string
+	generatedAt    time.Time
+	columns        models.ReportColumns
+	sections       []reportSection
+	includeSummary bool
+}
+
+// totalRows counts the rows of every section
+func (d *reportDocument) totalRows() int {
+	total := 0
+	for _, section := range d.sections {
+		total += len(section.rows)
+	}
+	return total
+}
+
+// headings returns the column headings in order
+func (d *reportDocument) headings() []string {
+	headings := make([]string, len(d.columns))
+	for i, column := range d.columns {
+		headings[i] = column.Heading()
+	}
+	return headings
+}
+
+// renderReport renders a report in one of the supported formats
+func renderReport(format string, doc *reportDocument) ([]byte, error) {
+	switch format {
+	case models.ReportFormatText:
+		return renderTextReport(doc), nil
+	case models.ReportFormatCSV:
+		return renderCSVReport(doc)
+	case models.ReportFormatJSON:
+		return renderJSONReport(doc)
+	case models.ReportFormatXLSX:
+		return renderXLSXReport(doc)
+	case models.ReportFormatPDF:
+		return renderPDFReport(doc), nil
+	default:
+		return nil, fmt.Errorf("invalid report format: %s", format)
+	}
+}
+
+// renderTextReport renders the layout with one table per section
+func renderTextReport(doc *reportDocument) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "Report: %s\n", doc.title)
+	fmt.Fprintf(&buf, "Generated: %s\n", doc.generatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(&buf, "Job ID: %s\n", doc.jobID)
+	fmt.Fprintf(&buf, "Job Name: %s\n", doc.jobName)
+	if doc.header != "" {
+		fmt.Fprintf(&buf, "\n%s\n", doc.header)
+	}
+
+	headings := doc.headings()
+	for _, section := range doc.sections {
+		fmt.Fprintf(&buf, "\n== %s ==\n", section.name)
+		fmt.Fprintln(&buf, strings.Join(headings, " | "))
+		for _, row := range section.rows {
+			fmt.Fprintln(&buf, strings.Join(rowValues(doc.columns, row), " | "))
+		}
+		fmt.Fprintf(&buf, "(%d rows)\n", len(section.rows))
+	}
+
+	if doc.includeSummary {
+		fmt.Fprintf(&buf, "\nSummary:\n- Sections: %d\n- Total Rows: %d\n", len(doc.sections), doc.totalRows())
+	}
+	if doc.footer != "" {
+		fmt.Fprintf(&buf, "\n%s\n", doc.footer)
+	}
+
+	return buf.Bytes()
+}
+
+// renderCSVReport renders all sections in one CSV, prefixed with the section name
+func renderCSVReport(doc *reportDocument) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	header := append([]string{"section"}, doc.headings()...)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, section := range doc.sections {
+		for _, row := range section.rows {
+			record := append([]string{section.name}, rowValues(doc.columns, row)...)
+			if err := writer.Write(record); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// jsonReport is the document written for json reports
+type jsonReport struct {
+	Title       string              `json:"title"`
+	GeneratedAt time.Time           `json:"generated_at"`
+	JobID       uuid.UUID           `json:"job_id"`
+	JobName     string              `json:"job_name"`
+	Columns     []string            `json:"columns"`
+	Sections    []jsonReportSection `json:"sections"`
+	TotalRows   int                 `json:"total_rows"`
+}
+
+// jsonReportSection holds a section's rows, keyed by column key
+type jsonReportSection struct {
+	Name string                   `json:"name"`
+	Rows []map[string]interface{} `json:"rows"`
+}
+
+// renderJSONReport renders the sections as JSON, keeping the queried values' types
+func renderJSONReport(doc *reportDocument) ([]byte, error) {
+	report := jsonReport{
+		Title:       doc.title,
+		GeneratedAt: doc.generatedAt.UTC(),
+		JobID:       doc.jobID,
+		JobName:     doc.jobName,
+		Columns:     make([]string, len(doc.columns)),
+		Sections:    make([]jsonReportSection, len(doc.sections)),
+		TotalRows:   doc.totalRows(),
+	}
+	for i, column := range doc.columns {
+		report.Columns[i] = column.Key
+	}
+
+	for i, section := range doc.sections {
+		rows := make([]map[string]interface{}, len(section.rows))
+		for j, row := range section.rows {
+			rows[j] = make(map[string]interface{}, len(doc.columns))
+			for _, column := range doc.columns {
+				value := row[column.Key]
+				if raw, ok := value.([]byte); ok {
+					value = string(raw)
+				}
+				rows[j][column.Key] = value
+			}
+		}
+		report.Sections[i] = jsonReportSection{Name: section.name, Rows: rows}
+	}
+
+	return json.MarshalIndent(report, "", "  ")
+}
+
+// xlsxSheetNameLength is the longest sheet name Excel accepts
+const xlsxSheetNameLength = 31
+
+// unsafeSheetNameChars matches characters Excel doesn't allow in sheet names
+var unsafeSheetNameChars = strings.NewReplacer("[", "_", "]", "_", ":", "_", "*", "_", "?", "_", "/", "_", "\\", "_")
+
+// renderXLSXReport renders a workbook with one sheet per section, headings in the first row
+// Numbers are written as numeric cells so they can be summed and charted
+func renderXLSXReport(doc *reportDocument) ([]byte, error) {
+	sections := doc.sections
+	if len(sections) == 0 {
+		// A workbook needs at least one sheet
+		sections = []reportSection{{name: "report"}}
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	var sheets, sheetRels, sheetTypes strings.Builder
+	used := make(map[string]bool, len(sections))
+	for i, section := range sections {
+		sheetID := i + 1
+		name := xlsxSheetName(section.name, sheetID, used)
+		fmt.Fprintf(&sheets, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(name), sheetID, sheetID)
+		fmt.Fprintf(&sheetRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, sheetID, sheetID)
+		fmt.Fprintf(&sheetTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, sheetID)
+
+		if err := writeZipFile(archive, fmt.Sprintf("xl/worksheets/sheet%d.xml", sheetID), xlsxSheet(doc, section)); err != nil {
+			return nil, err
+		}
+	}
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			sheetTypes.String() + `</Types>`},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>` +
+			sheets.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			sheetRels.String() + `</Relationships>`},
+	}
+	for _, file := range files {
+		if err := writeZipFile(archive, file.name, file.content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// xlsxSheet renders one section as a worksheet
+func xlsxSheet(doc *reportDocument, section reportSection) string {
+	var sheet strings.Builder
+	sheet.WriteString(xml.Header)
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	writeRow := func(rowNumber int, values []interface{}) {
+		fmt.Fprintf(&sheet, `<row r="%d">`, rowNumber)
+		for i, value := range values {
+			ref := xlsxColumnName(i) + strconv.Itoa(rowNumber)
+			if number, ok := xlsxNumber(value); ok {
+				fmt.Fprintf(&sheet, `<c r="%s"><v>%s</v></c>`, ref, number)
+			} else {
+				fmt.Fprintf(&sheet, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, xmlEscape(formatReportValue(value)))
+			}
+		}
+		sheet.WriteString(`</row>`)
+	}
+
+	headings := doc.headings()
+	headingValues := make([]interface{}, len(headings))
+	for i, heading := range headings {
+		headingValues[i] = heading
+	}
+	writeRow(1, headingValues)
+
+	for i, row := range section.rows {
+		values := make([]interface{}, len(doc.columns))
+		for j, column := range doc.columns {
+			values[j] = row[column.Key]
+		}
+		writeRow(i+2, values)
+	}
+
+	sheet.WriteString(`</sheetData></worksheet>`)
+	return sheet.String()
+}
+
+// xlsxSheetName makes a section name a valid, unique sheet name
+func xlsxSheetName(name string, sheetID int, used map[string]bool) string {
+	name = strings.TrimSpace(unsafeSheetNameChars.Replace(name))
+	if name == "" {
+		name = fmt.Sprintf("Sheet%d", sheetID)
+	}
+	name = truncateRunes(name, xlsxSheetNameLength)
+	for used[strings.ToLower(name)] {
+		suffix := fmt.Sprintf(" (%d)", sheetID)
+		name = truncateRunes(name, xlsxSheetNameLength-len(suffix)) + suffix
+	}
+	used[strings.ToLower(name)] = true
+	return name
+}
+
+// truncateRunes shortens s to at most n characters
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
+
+// xlsxColumnName returns the spreadsheet column letters for a zero-based index: A, B, ..., Z, AA, ...
+func xlsxColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// xlsxNumber formats numeric values for a numeric cell
+func xlsxNumber(v interface{}) (string, bool) {
+	switch value := v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(value), true
+	case float32:
+		return strconv.FormatFloat(float64(value), 'f', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), true
+	case json.Number:
+		return value.String(), true
+	default:
+		return "", false
+	}
+}
+
+// writeZipFile adds a file to a zip archive
+func writeZipFile(archive *zip.Writer, name, content string) error {
+	w, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if _, err := w.Write([]byte(content)); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// xmlEscape escapes text for XML content and attributes
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// PDF page layout: A4 in points, set in 9pt Courier so the text report's tables stay aligned
+const (
+	pdfPageWidth    = 595
+	pdfPageHeight   = 842
+	pdfMargin       = 40
+	pdfFontSize     = 9
+	pdfLineHeight   = 11
+	pdfLineLength   = 95
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLineHeight
+)
+
+// renderPDFReport renders the text report as a PDF, wrapping long lines and breaking pages as needed
+func renderPDFReport(doc *reportDocument) []byte {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(string(renderTextReport(doc)), "\n"), "\n") {
+		for len(line) > pdfLineLength {
+			lines = append(lines, line[:pdfLineLength])
+			line = line[pdfLineLength:]
+		}
+		lines = append(lines, line)
+	}
+
+	var pages [][]string
+	for len(lines) > pdfLinesPerPage {
+		pages = append(pages, lines[:pdfLinesPerPage])
+		lines = lines[pdfLinesPerPage:]
+	}
+	pages = append(pages, lines)
+
+	// Objects 1-3 are the catalog, page tree and font; each page then has a page and a content object
+	objects := make([]string, 3, 3+2*len(pages))
+	kids := make([]string, len(pages))
+	for i, page := range pages {
+		pageObject := 4 + 2*i
+		kids[i] = fmt.Sprintf("%d 0 R", pageObject)
+
+		var content strings.Builder
+		fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", pdfFontSize, pdfLineHeight, pdfMargin, pdfPageHeight-pdfMargin)
+		for _, line := range page {
+			fmt.Fprintf(&content, "(%s) '\n", pdfEscape(line))
+		}
+		content.WriteString("ET")
+
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, pageObject+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()),
+		)
+	}
+	objects[0] = "<< /Type /Catalog /Pages 2 0 R >>"
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+	objects[2] = "<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>"
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xrefOffset)
+
+	return buf.Bytes()
+}
+
+// pdfEscape escapes a line for a PDF string, replacing characters the standard fonts can't show
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r == '\t':
+			b.WriteRune(' ')
+		case r < 32 || r > 126:
+			b.WriteRune('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// reportContentType returns the MIME type of a report format
+func reportContentType(format string) string {
+	switch format {
+	case models.ReportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case models.ReportFormatText:
+		return "text/plain; charset=utf-8"
+	case models.ReportFormatJSON:
+		return "application/json"
+	case models.ReportFormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case models.ReportFormatPDF:
+		return "application/pdf"
+	default:
+		if contentType := mime.TypeByExtension("." + format); contentType != "" {
+			return contentType
+		}
+		return "application/octet-stream"
+	}
+}
+
+// rowValues formats a row's values in column order - missing values are left empty
+func rowValues(columns models.ReportColumns, row map[string]interface{}) []string {
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		values[i] = formatReportValue(row[column.Key])
+	}
+	return values
+}
+
+// formatReportValue formats a single query result value
+func formatReportValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return value.Format(time.RFC3339)
+	case []byte:
+		return string(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+// ErrReportTemplateNameTaken is returned when creating a report template with an existing name
+var ErrReportTemplateNameTaken = errors.New("a report template with this name already exists")
+
+// ReportTemplateService defines the interface for managing report templates
+type ReportTemplateService interface {
+	CreateReportTemplate(req *models.CreateReportTemplateRequest) (*models.ReportTemplate, error)
+	GetReportTemplates() ([]models.ReportTemplate, error)
+	GetReportTemplateByID(id uuid.UUID) (*models.ReportTemplate, error)
+	UpdateReportTemplate(id uuid.UUID, req *models.UpdateReportTemplateRequest) (*models.ReportTemplate, error)
+	DeleteReportTemplate(id uuid.UUID) error
+}
+
+// reportTemplateService implements ReportTemplateService interface
+type reportTemplateService struct {
+	templateRepo repositories.ReportTemplateRepository
+}
+
+// NewReportTemplateService creates a new report template service
+func NewReportTemplateService(templateRepo repositories.ReportTemplateRepository) ReportTemplateService {
+	return &reportTemplateService{
+		templateRepo: templateRepo,
+	}
+}
+
+// CreateReportTemplate validates and stores a report template
+func (s *reportTemplateService) CreateReportTemplate(req *models.CreateReportTemplateRequest) (*models.ReportTemplate, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("report template name is required")
+	}
+
+	template := &models.ReportTemplate{
+		ID:          uuid.New(),
+		Name:        name,
+		Description: req.Description,
+		Format:      req.Format,
+		Layout:      req.Layout,
+		Columns:     req.Columns,
+		Queries:     req.Queries,
+		IsActive:    true,
+	}
+	if template.Format == "" {
+		template.Format = models.ReportFormatText
+	}
+	if req.IsActive != nil {
+		template.IsActive = *req.IsActive
+	}
+	if err := validateReportTemplate(template); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.templateRepo.FindByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check report template name: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrReportTemplateNameTaken
+	}
+
+	if err := s.templateRepo.Create(template); err != nil {
+		return nil, fmt.Errorf("failed to create report template: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"template_id": template.ID,
+		"name":        template.Name,
+		"format":      template.Format,
+	}).Info("Report template created successfully")
+
+	return template, nil
+}
+
+// GetReportTemplates lists all report templates
+func (s *reportTemplateService) GetReportTemplates() ([]models.ReportTemplate, error) {
+	templates, err := s.templateRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report templates: %w", err)
+	}
+	return templates, nil
+}
+
+// GetReportTemplateByID retrieves a report template by its ID
+func (s *reportTemplateService) GetReportTemplateByID(id uuid.UUID) (*models.ReportTemplate, error) {
+	template, err := s.templateRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report template: %w", err)
+	}
+	return template, nil
+}
+
+// UpdateReportTemplate updates a report template - jobs pick up the change on their next run
+func (s *reportTemplateService) UpdateReportTemplate(id uuid.UUID, req *models.UpdateReportTemplateRequest) (*models.ReportTemplate, error) {
+	template, err := s.templateRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report template for update: %w", err)
+	}
+
+	if req.Description != nil {
+		template.Description = *req.Description
+	}
+	if req.Format != nil {
+		template.Format = *req.Format
+	}
+	if req.Layout != nil {
+		template.Layout = *req.Layout
+	}
+	if req.Columns != nil {
+		template.Columns = *req.Columns
+	}
+	if req.Queries != nil {
+		template.Queries = *req.Queries
+	}
+	if req.IsActive != nil {
+		template.IsActive = *req.IsActive
+	}
+	if err := validateReportTemplate(template); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Update(template); err != nil {
+		return nil, fmt.Errorf("failed to update report template: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"template_id": template.ID,
+		"name":        template.Name,
+	}).Info("Report template updated successfully")
+
+	return template, nil
+}
+
+// DeleteReportTemplate deletes a report template
+func (s *reportTemplateService) DeleteReportTemplate(id uuid.UUID) error {
+	if err := s.templateRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete report template: %w", err)
+	}
+	return nil
+}
+
+// validateReportTemplate checks the format, columns and queries of a report template
+func validateReportTemplate(template *models.ReportTemplate) error {
+	if !models.IsValidReportFormat(template.Format) {
+		return fmt.Errorf("invalid report format: %s", template.Format)
+	}
+
+	if len(template.Columns) == 0 {
+		return fmt.Errorf("report template must define at least one column")
+	}
+	keys := make(map[string]bool, len(template.Columns))
+	for _, column := range template.Columns {
+		key := strings.TrimSpace(column.Key)
+		if key == "" {
+			return fmt.Errorf("report column key is required")
+		}
+		if keys[key] {
+			return fmt.Errorf("duplicate report column: %s", key)
+		}
+		keys[key] = true
+	}
+
+	if len(template.Queries) == 0 {
+		return fmt.Errorf("report template must define at least one query")
+	}
+	names := make(map[string]bool, len(template.Queries))
+	for _, query := range template.Queries {
+		name := strings.TrimSpace(query.Name)
+		if name == "" || strings.TrimSpace(query.Query) == "" {
+			return fmt.Errorf("report queries require a name and a query")
+		}
+		if names[name] {
+			return fmt.Errorf("duplicate report query: %s", name)
+		}
+		names[name] = true
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+)
+
+// ReportTemplateLookup finds stored report templates by name
+// It returns nil when no template has the name
+type ReportTemplateLookup interface {
+	FindByName(name string) (*models.ReportTemplate, error)
+}
+
+// ReportDataSource runs report queries against the registered database named by connection, returning
+// one map per row keyed by column name
+type ReportDataSource interface {
+	RunQuery(ctx context.Context, connection, query string) ([]map[string]interface{}, error)
+}
+
+// errQueryConnectionRequired is returned for report queries without a "connection", which would
+// otherwise read the scheduler's own database
+var errQueryConnectionRequired = errors.New(`query needs a "connection" naming a registered database`)
+
+// registeredDatabaseData runs report queries against registered external databases
+type registeredDatabaseData struct {
+	databases DatabaseRegistry
+}
+
+// NewReportDataSource creates a report data source querying the registered databases jobs name in their
+// "connection". Queries never read the scheduler's own database, which holds its secrets, roles and jobs
+func NewReportDataSource(databases DatabaseRegistry) ReportDataSource {
+	return &registeredDatabaseData{databases: databases}
+}
+
+// RunQuery runs the query in a read-only transaction on the named database
+func (d *registeredDatabaseData) RunQuery(ctx context.Context, connection, query string) ([]map[string]interface{}, error) {
+	if connection == "" {
+		return nil, errQueryConnectionRequired
+	}
+	if d.databases == nil {
+		return nil, fmt.Errorf("external databases are not configured")
+	}
+	db, err := d.databases.Database(ctx, connection)
+	if err != nil {
+		return nil, err
+	}
+	return queryRows(ctx, db, query)
+}
+
+// ValidateReportConfig checks that a report_generation job running its own query names the registered
+// database to run it on
+func ValidateReportConfig(config models.JobConfig) error {
+	if query, _ := config["query"].(string); query != "" && JobConnection(&models.Job{Config: config}) == "" {
+		return errQueryConnectionRequired
+	}
+	return nil
+}
+
+// reportSection holds the rows returned by one query of a report template
+type reportSection struct {
+	name string
+	rows []map[string]interface{}
+}
+
+// unsafeFilenameChars matches characters not allowed in report filenames
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// executeTemplate generates a report from a stored report template, running its queries on the
+// registered database named by the job's "connection"
+func (r *ReportGenerationExecutor) executeTemplate(ctx context.Context, job *models.Job, name string) ([]ArtifactFile, error) {
+	if r.templates == nil || r.data == nil {
+		return nil, fmt.Errorf("report templates are not configured")
+	}
+
+	template, err := r.templates.FindByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report template: %w", err)
+	}
+	if template == nil {
+		return nil, fmt.Errorf("report template %q not found", name)
+	}
+	if !template.IsActive {
+		return nil, fmt.Errorf("report template %q is inactive", name)
+	}
+
+	// Jobs may still override the template's format
+	format := template.Format
+	if f, ok := job.Config["format"].(string); ok && f != "" {
+		format = f
+	}
+	if !models.IsValidReportFormat(format) {
+		return nil, fmt.Errorf("invalid report format: %s", format)
+	}
+
+	sections := make([]reportSection, 0, len(template.Queries))
+	rowCount := 0
+	for _, query := range template.Queries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		rows, err := r.data.RunQuery(ctx, JobConnection(job), query.Query)
+		if err != nil {
+			return nil, fmt.Errorf("report query %q failed: %w", query.Name, err)
+		}
+		sections = append(sections, reportSection{name: query.Name, rows: rows})
+		rowCount += len(rows)
+	}
+
+	title := template.Layout.Title
+	if title == "" {
+		title = template.Name
+	}
+	doc := &reportDocument{
+		title:          title,
+		header:         template.Layout.Header,
+		footer:         template.Layout.Footer,
+		jobID:          job.ID,
+		jobName:        job.Name,
+		generatedAt:    time.Now(),
+		columns:        template.Columns,
+		sections:       sections,
+		includeSummary: template.Layout.IncludeSummary,
+	}
+
+	file, err := r.writeReport(job, template.Name, format, doc)
+	if err != nil {
+		return nil, err
+	}
+	RecordOutput(ctx, "report_template", template.Name)
+	RecordOutput(ctx, "format", format)
+	RecordOutput(ctx, "rows_processed", rowCount)
+	RecordOutput(ctx, "file_path", file.Path)
+
+	RunLogger(ctx).WithFields(logrus.Fields{
+		"job_id":          job.ID,
+		"report_template": template.Name,
+		"format":          format,
+		"sections":        len(sections),
+		"file_path":       file.Path,
+	}).Info("Report generated from template successfully")
+
+	return []ArtifactFile{file}, nil
+}
+
+// writeReport renders a report and writes it to the reports directory, named after the report
+// and the job, returning the file to record as the run's artifact
+func (r *ReportGenerationExecutor) writeReport(job *models.Job, name, format string, doc *reportDocument) (ArtifactFile, error) {
+	content, err := renderReport(format, doc)
+	if err != nil {
+		return ArtifactFile{}, fmt.Errorf("failed to render report: %w", err)
+	}
+
+	// Ensure reports directory exists
+	if err := os.MkdirAll(r.reportsDir, 0755); err != nil {
+		return ArtifactFile{}, fmt.Errorf("failed to create reports directory: %w", err)
+	}
+
+	timestamp := time.Now().Format("20060102_150405")
+	filename := fmt.Sprintf("%s_%s_%s.%s", unsafeFilenameChars.ReplaceAllString(name, "_"), job.ID.String()[:8], timestamp, format)
+	path := filepath.Join(r.reportsDir, filename)
+
+	if err := ioutil.WriteFile(path, content, 0644); err != nil {
+		return ArtifactFile{}, fmt.Errorf("failed to write report file: %w", err)
+	}
+	return ArtifactFile{Kind: models.ArtifactKindReport, Name: filename, Path: path, ContentType: reportContentType(format)}, nil
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+// ErrRoleAssignmentExists is returned when assigning a role to a user who already has one
+var ErrRoleAssignmentExists = errors.New("user already has a role assignment")
+
+// RoleService defines the interface for assigning roles and resolving users' roles
+type RoleService interface {
+	CreateRoleAssignment(req *models.CreateRoleAssignmentRequest, actor string) (*models.RoleAssignment, error)
+	GetRoleAssignments() ([]models.RoleAssignment, error)
+	UpdateRoleAssignment(id uuid.UUID, req *models.UpdateRoleAssignmentRequest, actor string) (*models.RoleAssignment, error)
+	DeleteRoleAssignment(id uuid.UUID) error
+	ResolveRole(user string) (models.Role, error)
+}
+
+// roleService implements RoleService interface
+type roleService struct {
+	assignmentRepo repositories.RoleAssignmentRepository
+	cfg            config.RBACConfig
+}
+
+// NewRoleService creates a new role service
+func NewRoleService(assignmentRepo repositories.RoleAssignmentRepository, cfg config.RBACConfig) RoleService {
+	return &roleService{
+		assignmentRepo: assignmentRepo,
+		cfg:            cfg,
+	}
+}
+
+// CreateRoleAssignment grants a user a role
+func (s *roleService) CreateRoleAssignment(req *models.CreateRoleAssignmentRequest, actor string) (*models.RoleAssignment, error) {
+	user := strings.TrimSpace(req.User)
+	if user == "" {
+		return nil, fmt.Errorf("user is required")
+	}
+	if !models.IsValidRole(string(req.Role)) {
+		return nil, fmt.Errorf("invalid role: %s", req.Role)
+	}
+
+	existing, err := s.assignmentRepo.FindByUser(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check role assignment: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrRoleAssignmentExists
+	}
+
+	assignment := &models.RoleAssignment{
+		ID:         uuid.New(),
+		User:       user,
+		Role:       req.Role,
+		AssignedBy: actor,
+	}
+
+	if err := s.assignmentRepo.Create(assignment); err != nil {
+		return nil, fmt.Errorf("failed to create role assignment: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user":        assignment.User,
+		"role":        assignment.Role,
+		"assigned_by": actor,
+	}).Info("Role assigned")
+
+	return assignment, nil
+}
+
+// GetRoleAssignments lists all role assignments
+func (s *roleService) GetRoleAssignments() ([]models.RoleAssignment, error) {
+	assignments, err := s.assignmentRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role assignments: %w", err)
+	}
+	return assignments, nil
+}
+
+// UpdateRoleAssignment changes a user's role
+func (s *roleService) UpdateRoleAssignment(id uuid.UUID, req *models.UpdateRoleAssignmentRequest, actor string) (*models.RoleAssignment, error) {
+	if !models.IsValidRole(string(req.Role)) {
+		return nil, fmt.Errorf("invalid role: %s", req.Role)
+	}
+
+	assignment, err := s.assignmentRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role assignment for update: %w", err)
+	}
+
+	assignment.Role = req.Role
+	assignment.AssignedBy = actor
+	if err := s.assignmentRepo.Update(assignment); err != nil {
+		return nil, fmt.Errorf("failed to update role assignment: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user":        assignment.User,
+		"role":        assignment.Role,
+		"assigned_by": actor,
+	}).Info("Role changed")
+
+	return assignment, nil
+}
+
+// DeleteRoleAssignment deletes a role assignment - the user falls back to the default role
+func (s *roleService) DeleteRoleAssignment(id uuid.UUID) error {
+	if err := s.assignmentRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete role assignment: %w", err)
+	}
+	return nil
+}
+
+// ResolveRole returns a user's role: admin for the configured admins, the assigned role, or
+// the default role for users without an assignment. It returns "" when the user has no role
+func (s *roleService) ResolveRole(user string) (models.Role, error) {
+	for _, admin := range s.cfg.Admins {
+		if admin == user {
+			return models.RoleAdmin, nil
+		}
+	}
+
+	assignment, err := s.assignmentRepo.FindByUser(user)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve role: %w", err)
+	}
+	if assignment != nil {
+		return assignment.Role, nil
+	}
+	return models.Role(s.cfg.DefaultRole), nil
+}
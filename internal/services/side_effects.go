@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"job-scheduler/internal/models"
+)
+
+// sideEffectRecorderKey is the context key under which a run's SideEffectRecorder is found
+type sideEffectRecorderKey struct{}
+
+// SideEffectRecorder collects the tokens of the external side effects a run performs
+// The scheduler gives every run one through its context, holding the tokens of the attempts it retries,
+// and saves the tokens with the run's outcome
+type SideEffectRecorder struct {
+	mu     sync.Mutex
+	tokens models.SideEffectTokens
+}
+
+// NewSideEffectRecorder creates a recorder holding the tokens of earlier attempts
+func NewSideEffectRecorder(previous models.SideEffectTokens) *SideEffectRecorder {
+	tokens := previous.Copy()
+	if tokens == nil {
+		tokens = make(models.SideEffectTokens)
+	}
+	return &SideEffectRecorder{tokens: tokens}
+}
+
+// WithSideEffectRecorder returns a context carrying the recorder for executors to record to
+func WithSideEffectRecorder(ctx context.Context, recorder *SideEffectRecorder) context.Context {
+	return context.WithValue(ctx, sideEffectRecorderKey{}, recorder)
+}
+
+// SideEffectToken returns the token recorded for the side effect under key, by this attempt or an
+// earlier one. It finds nothing when the context carries no recorder
+func SideEffectToken(ctx context.Context, key string) (string, bool) {
+	if recorder, ok := ctx.Value(sideEffectRecorderKey{}).(*SideEffectRecorder); ok {
+		return recorder.Token(key)
+	}
+	return "", false
+}
+
+// RecordSideEffect records the token of a side effect the run performed, such as an email's message-id
+// It does nothing when the context carries no recorder, such as when an executor is called directly
+func RecordSideEffect(ctx context.Context, key, token string) {
+	if recorder, ok := ctx.Value(sideEffectRecorderKey{}).(*SideEffectRecorder); ok {
+		recorder.Record(key, token)
+	}
+}
+
+// PerformSideEffectOnce performs a side effect unless this run or one it retries already did, returning
+// its token. perform returns the token identifying what it did; it is recorded if perform succeeds.
+// Tokens are saved with the attempt's outcome, so a side effect performed by an attempt that never
+// finished, such as on an instance that stopped mid-run, may still be repeated
+func PerformSideEffectOnce(ctx context.Context, key string, perform func() (string, error)) (string, error) {
+	if token, done := SideEffectToken(ctx, key); done {
+		RunLogger(ctx).WithField("side_effect", key).Info("Side effect already performed by an earlier attempt - skipping")
+		return token, nil
+	}
+
+	token, err := perform()
+	if err != nil {
+		return "", err
+	}
+	RecordSideEffect(ctx, key, token)
+	return token, nil
+}
+
+// Token returns the token recorded under key
+func (r *SideEffectRecorder) Token(key string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	token, ok := r.tokens[key]
+	return token, ok
+}
+
+// Record records a token under key, replacing any token recorded under it
+func (r *SideEffectRecorder) Record(key, token string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens[key] = token
+}
+
+// Tokens returns a copy of the recorded tokens, or nil if none were recorded
+func (r *SideEffectRecorder) Tokens() models.SideEffectTokens {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.tokens.Copy()
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+// maintainedTables are the scheduler's tables that every run churns
+var maintainedTables = []string{
+	models.Job{}.TableName(),
+	"job_executions",
+	models.JobRunClaim{}.TableName(),
+}
+
+// TableMaintenanceService defines the interface for maintaining the scheduler's hot tables
+type TableMaintenanceService interface {
+	MaintainTables(ctx context.Context) error
+	GetMaintenanceStats() models.TableMaintenanceStats
+}
+
+// tableMaintenanceService implements TableMaintenanceService interface
+type tableMaintenanceService struct {
+	repo  repositories.TableMaintenanceRepository
+	cfg   config.TableMaintenanceConfig
+	mu    sync.Mutex
+	stats models.TableMaintenanceStats
+}
+
+// NewTableMaintenanceService creates a new table maintenance service
+func NewTableMaintenanceService(repo repositories.TableMaintenanceRepository, cfg config.TableMaintenanceConfig) TableMaintenanceService {
+	return &tableMaintenanceService{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+// MaintainTables analyzes, or vacuums and analyzes, each hot table in turn
+// A failure on one table doesn't stop the others
+func (s *tableMaintenanceService) MaintainTables(ctx context.Context) error {
+	start := time.Now().UTC()
+	operation := "analyze"
+	if s.cfg.Vacuum {
+		operation = "vacuum_analyze"
+	}
+
+	results := make([]models.TableMaintenanceResult, 0, len(maintainedTables))
+	var failures []error
+	for _, table := range maintainedTables {
+		if ctx.Err() != nil {
+			break
+		}
+
+		tableStart := time.Now()
+		var err error
+		if s.cfg.Vacuum {
+			err = s.repo.VacuumAnalyze(ctx, table)
+		} else {
+			err = s.repo.Analyze(ctx, table)
+		}
+
+		result := models.TableMaintenanceResult{
+			Table:      table,
+			Operation:  operation,
+			DurationMs: time.Since(tableStart).Milliseconds(),
+		}
+		if err != nil {
+			result.Error = err.Error()
+			failures = append(failures, err)
+		}
+		results = append(results, result)
+
+		logrus.WithFields(logrus.Fields{
+			"table":       table,
+			"operation":   operation,
+			"duration_ms": result.DurationMs,
+		}).Debug("Maintained table")
+	}
+
+	s.mu.Lock()
+	s.stats.Runs++
+	s.stats.LastRunAt = &start
+	s.stats.LastDurationMs = time.Since(start).Milliseconds()
+	s.stats.LastTables = results
+	s.mu.Unlock()
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("table maintenance interrupted: %w", ctx.Err())
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to maintain %d of %d tables: %w", len(failures), len(maintainedTables), failures[0])
+	}
+	return nil
+}
+
+// GetMaintenanceStats returns the table maintenance runs since startup
+func (s *tableMaintenanceService) GetMaintenanceStats() models.TableMaintenanceStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.stats
+	stats.LastTables = append([]models.TableMaintenanceResult(nil), s.stats.LastTables...)
+	return stats
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+// ErrTeamChannelExists is returned when creating a second channel for a team
+var ErrTeamChannelExists = errors.New("team already has a channel")
+
+// TeamChannelService defines the interface for managing team notification channels
+type TeamChannelService interface {
+	CreateTeamChannel(req *models.CreateTeamChannelRequest) (*models.TeamChannel, error)
+	GetTeamChannels() ([]models.TeamChannel, error)
+	UpdateTeamChannel(id uuid.UUID, req *models.UpdateTeamChannelRequest) (*models.TeamChannel, error)
+	DeleteTeamChannel(id uuid.UUID) error
+}
+
+// teamChannelService implements TeamChannelService interface
+type teamChannelService struct {
+	channelRepo repositories.TeamChannelRepository
+}
+
+// NewTeamChannelService creates a new team channel service
+func NewTeamChannelService(channelRepo repositories.TeamChannelRepository) TeamChannelService {
+	return &teamChannelService{
+		channelRepo: channelRepo,
+	}
+}
+
+// CreateTeamChannel maps a team to the channel its notifications are routed to
+func (s *teamChannelService) CreateTeamChannel(req *models.CreateTeamChannelRequest) (*models.TeamChannel, error) {
+	team := strings.TrimSpace(req.Team)
+	if team == "" {
+		return nil, fmt.Errorf("team is required")
+	}
+	if !models.IsValidChannelKind(string(req.Kind)) {
+		return nil, fmt.Errorf("invalid channel kind: %s", req.Kind)
+	}
+	if err := validateChannelURL(req.URL); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.channelRepo.FindByTeam(team)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team channel: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrTeamChannelExists
+	}
+
+	channel := &models.TeamChannel{
+		ID:       uuid.New(),
+		Team:     team,
+		Kind:     req.Kind,
+		URL:      req.URL,
+		IsActive: true,
+	}
+	if req.IsActive != nil {
+		channel.IsActive = *req.IsActive
+	}
+
+	if err := s.channelRepo.Create(channel); err != nil {
+		return nil, fmt.Errorf("failed to create team channel: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"channel_id": channel.ID,
+		"team":       channel.Team,
+		"kind":       channel.Kind,
+	}).Info("Team channel created successfully")
+
+	return channel, nil
+}
+
+// GetTeamChannels lists all team channels
+func (s *teamChannelService) GetTeamChannels() ([]models.TeamChannel, error) {
+	channels, err := s.channelRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team channels: %w", err)
+	}
+	return channels, nil
+}
+
+// UpdateTeamChannel updates a team's channel
+func (s *teamChannelService) UpdateTeamChannel(id uuid.UUID, req *models.UpdateTeamChannelRequest) (*models.TeamChannel, error) {
+	channel, err := s.channelRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team channel for update: %w", err)
+	}
+
+	if req.Kind != nil {
+		if !models.IsValidChannelKind(string(*req.Kind)) {
+			return nil, fmt.Errorf("invalid channel kind: %s", *req.Kind)
+		}
+		channel.Kind = *req.Kind
+	}
+	if req.URL != nil {
+		if err := validateChannelURL(*req.URL); err != nil {
+			return nil, err
+		}
+		channel.URL = *req.URL
+	}
+	if req.IsActive != nil {
+		channel.IsActive = *req.IsActive
+	}
+
+	if err := s.channelRepo.Update(channel); err != nil {
+		return nil, fmt.Errorf("failed to update team channel: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"channel_id": channel.ID,
+		"team":       channel.Team,
+	}).Info("Team channel updated successfully")
+
+	return channel, nil
+}
+
+// DeleteTeamChannel deletes a team channel - the team falls back to the default channel
+func (s *teamChannelService) DeleteTeamChannel(id uuid.UUID) error {
+	if err := s.channelRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete team channel: %w", err)
+	}
+	return nil
+}
+
+// validateChannelURL checks that a channel URL is an absolute http(s) URL
+func validateChannelURL(channelURL string) error {
+	u, err := url.Parse(channelURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("channel url must be an http(s) URL")
+	}
+	return nil
+}
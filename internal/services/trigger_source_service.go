@@ -0,0 +1,137 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/expressions"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+// Bounds for trigger source settings
+const (
+	minVisibilityTimeoutSeconds = 10
+	maxVisibilityTimeoutSeconds = 43200 // SQS maximum of 12 hours
+	maxTriggerSourceMessages    = 10
+)
+
+// TriggerSourceService defines the interface for trigger source business logic
+type TriggerSourceService interface {
+	CreateTriggerSource(jobID uuid.UUID, req *models.CreateTriggerSourceRequest) (*models.TriggerSource, error)
+	GetTriggerSourcesByJobID(jobID uuid.UUID) ([]models.TriggerSource, error)
+	DeleteTriggerSource(id uuid.UUID) error
+}
+
+// triggerSourceService implements TriggerSourceService interface
+type triggerSourceService struct {
+	sourceRepo repositories.TriggerSourceRepository
+	jobRepo    repositories.JobRepository
+}
+
+// NewTriggerSourceService creates a new trigger source service
+func NewTriggerSourceService(sourceRepo repositories.TriggerSourceRepository, jobRepo repositories.JobRepository) TriggerSourceService {
+	return &triggerSourceService{
+		sourceRepo: sourceRepo,
+		jobRepo:    jobRepo,
+	}
+}
+
+// CreateTriggerSource maps an SQS queue or Pub/Sub subscription to a job
+func (s *triggerSourceService) CreateTriggerSource(jobID uuid.UUID, req *models.CreateTriggerSourceRequest) (*models.TriggerSource, error) {
+	// Make sure the job exists
+	if _, err := s.jobRepo.GetByID(jobID); err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	if !models.IsValidTriggerSourceKind(string(req.Kind)) {
+		return nil, fmt.Errorf("invalid trigger source kind: %s", req.Kind)
+	}
+	if err := validateTriggerTarget(req.Kind, req.Target); err != nil {
+		return nil, err
+	}
+
+	source := &models.TriggerSource{
+		ID:                       uuid.New(),
+		JobID:                    jobID,
+		Kind:                     req.Kind,
+		Target:                   req.Target,
+		VisibilityTimeoutSeconds: 300,
+		MaxMessages:              maxTriggerSourceMessages,
+		IsActive:                 true,
+	}
+
+	if req.VisibilityTimeoutSeconds != nil {
+		v := *req.VisibilityTimeoutSeconds
+		if v < minVisibilityTimeoutSeconds || v > maxVisibilityTimeoutSeconds {
+			return nil, fmt.Errorf("visibility_timeout_seconds must be between %d and %d",
+				minVisibilityTimeoutSeconds, maxVisibilityTimeoutSeconds)
+		}
+		source.VisibilityTimeoutSeconds = v
+	}
+	if req.MaxMessages != nil {
+		if *req.MaxMessages < 1 || *req.MaxMessages > maxTriggerSourceMessages {
+			return nil, fmt.Errorf("max_messages must be between 1 and %d", maxTriggerSourceMessages)
+		}
+		source.MaxMessages = *req.MaxMessages
+	}
+	if condition := strings.TrimSpace(req.Condition); condition != "" {
+		if _, err := expressions.TriggerCondition.CompileCondition(condition); err != nil {
+			return nil, err
+		}
+		source.Condition = condition
+	}
+	if req.IsActive != nil {
+		source.IsActive = *req.IsActive
+	}
+
+	if err := s.sourceRepo.Create(source); err != nil {
+		return nil, fmt.Errorf("failed to create trigger source: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":    jobID,
+		"source_id": source.ID,
+		"kind":      source.Kind,
+	}).Info("Trigger source created successfully")
+
+	return source, nil
+}
+
+// GetTriggerSourcesByJobID lists the trigger sources mapped to a job
+func (s *triggerSourceService) GetTriggerSourcesByJobID(jobID uuid.UUID) ([]models.TriggerSource, error) {
+	sources, err := s.sourceRepo.GetByJobID(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trigger sources: %w", err)
+	}
+	return sources, nil
+}
+
+// DeleteTriggerSource deletes a trigger source by its ID
+func (s *triggerSourceService) DeleteTriggerSource(id uuid.UUID) error {
+	if err := s.sourceRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete trigger source: %w", err)
+	}
+	return nil
+}
+
+// validateTriggerTarget checks the target matches the source kind
+func validateTriggerTarget(kind models.TriggerSourceKind, target string) error {
+	switch kind {
+	case models.TriggerSourceKindSQS:
+		u, err := url.Parse(target)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("sqs target must be a queue URL")
+		}
+	case models.TriggerSourceKindPubSub:
+		parts := strings.Split(target, "/")
+		if len(parts) != 4 || parts[0] != "projects" || parts[2] != "subscriptions" {
+			return fmt.Errorf("pubsub target must be projects/<project>/subscriptions/<name>")
+		}
+	}
+	return nil
+}
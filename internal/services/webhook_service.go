@@ -0,0 +1,315 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+var (
+	// ErrWebhookNotFound is returned when a webhook token doesn't exist or is disabled
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrInvalidSignature is returned when the request signature doesn't match
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrRateLimited is returned when a webhook exceeds its rate limit
+	ErrRateLimited = errors.New("webhook rate limit exceeded")
+	// ErrWebhookJobInactive is returned when a webhook's job is paused, disabled or archived
+	ErrWebhookJobInactive = errors.New("webhook's job is not active")
+)
+
+// stripeSignatureTolerance bounds how old a Stripe-style signature timestamp may be
+const stripeSignatureTolerance = 5 * time.Minute
+
+// JobTrigger starts an out-of-schedule run of a job with the given parameters
+// It is implemented by the scheduler
+type JobTrigger interface {
+	TriggerJob(job *models.Job, params models.JobConfig) error
+}
+
+// WebhookService defines the interface for inbound webhook business logic
+type WebhookService interface {
+	CreateWebhook(jobID uuid.UUID, req *models.CreateJobWebhookRequest) (*models.JobWebhook, string, error)
+	GetWebhooksByJobID(jobID uuid.UUID) ([]models.JobWebhook, error)
+	DeleteWebhook(id uuid.UUID) error
+	HandleInbound(token string, headers http.Header, body []byte) (*models.JobWebhook, *models.JobExecution, error)
+	SetApprovalService(approvals ApprovalService)
+}
+
+// webhookService implements WebhookService interface
+type webhookService struct {
+	webhookRepo repositories.JobWebhookRepository
+	jobRepo     repositories.JobRepository
+	trigger     JobTrigger
+	limiter     *webhookRateLimiter
+	// approvals holds the runs of jobs requiring approval, if set
+	approvals ApprovalService
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(
+	webhookRepo repositories.JobWebhookRepository,
+	jobRepo repositories.JobRepository,
+	trigger JobTrigger,
+) WebhookService {
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		jobRepo:     jobRepo,
+		trigger:     trigger,
+		limiter:     newWebhookRateLimiter(),
+	}
+}
+
+// SetApprovalService sends webhook calls for jobs requiring approval to approvers instead of running them
+// Without it such calls are refused
+func (s *webhookService) SetApprovalService(approvals ApprovalService) {
+	s.approvals = approvals
+}
+
+// CreateWebhook creates a new inbound webhook for a job
+// The generated secret is returned separately as it is never serialized afterwards
+func (s *webhookService) CreateWebhook(jobID uuid.UUID, req *models.CreateJobWebhookRequest) (*models.JobWebhook, string, error) {
+	// Make sure the job exists
+	if _, err := s.jobRepo.GetByID(jobID); err != nil {
+		return nil, "", fmt.Errorf("failed to get job: %w", err)
+	}
+
+	webhook := &models.JobWebhook{
+		ID:                 uuid.New(),
+		JobID:              jobID,
+		SignatureHeader:    models.DefaultWebhookSignatureHeader,
+		SignatureScheme:    models.WebhookSignatureSchemeHex,
+		RateLimitPerMinute: models.DefaultWebhookRateLimitPerMinute,
+		IsActive:           true,
+	}
+
+	if req.SignatureHeader != "" {
+		webhook.SignatureHeader = req.SignatureHeader
+	}
+	if req.SignatureScheme != "" {
+		if !models.IsValidWebhookSignatureScheme(string(req.SignatureScheme)) {
+			return nil, "", fmt.Errorf("invalid signature scheme: %s", req.SignatureScheme)
+		}
+		webhook.SignatureScheme = req.SignatureScheme
+	}
+	if req.RateLimitPerMinute != nil {
+		if *req.RateLimitPerMinute < 1 {
+			return nil, "", fmt.Errorf("rate_limit_per_minute must be at least 1")
+		}
+		webhook.RateLimitPerMinute = *req.RateLimitPerMinute
+	}
+
+	token, err := randomHex(24)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate webhook token: %w", err)
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	webhook.Token = token
+	webhook.Secret = secret
+
+	if err := s.webhookRepo.Create(webhook); err != nil {
+		return nil, "", fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":     jobID,
+		"webhook_id": webhook.ID,
+	}).Info("Job webhook created successfully")
+
+	return webhook, secret, nil
+}
+
+// GetWebhooksByJobID lists the webhooks configured for a job
+func (s *webhookService) GetWebhooksByJobID(jobID uuid.UUID) ([]models.JobWebhook, error) {
+	webhooks, err := s.webhookRepo.GetByJobID(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook deletes a webhook by its ID
+func (s *webhookService) DeleteWebhook(id uuid.UUID) error {
+	if err := s.webhookRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+// HandleInbound validates an inbound webhook call and triggers its job
+// The JSON body, if any, is passed to the run as execution parameters. Only active jobs are triggered, and
+// the runs of jobs requiring approval wait for it; that run is returned, nil when the job was triggered
+func (s *webhookService) HandleInbound(token string, headers http.Header, body []byte) (*models.JobWebhook, *models.JobExecution, error) {
+	webhook, err := s.webhookRepo.GetByToken(token)
+	if err != nil || !webhook.IsActive {
+		return nil, nil, ErrWebhookNotFound
+	}
+
+	signature := headers.Get(webhook.SignatureHeader)
+	if !verifyWebhookSignature(webhook.SignatureScheme, webhook.Secret, signature, body, time.Now()) {
+		logrus.WithField("webhook_id", webhook.ID).Warn("Rejected webhook call with invalid signature")
+		return nil, nil, ErrInvalidSignature
+	}
+
+	if !s.limiter.Allow(webhook.ID, webhook.RateLimitPerMinute, time.Now()) {
+		return nil, nil, ErrRateLimited
+	}
+
+	job, err := s.jobRepo.GetByID(webhook.JobID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if !job.IsActive() {
+		logrus.WithFields(logrus.Fields{
+			"job_id":     job.ID,
+			"webhook_id": webhook.ID,
+			"state":      job.State,
+		}).Warn("Ignored webhook call for inactive job")
+		return nil, nil, ErrWebhookJobInactive
+	}
+
+	params, err := models.JobConfigFromPayload(body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	var awaiting *models.JobExecution
+	if job.RequiresApproval {
+		if s.approvals == nil {
+			return nil, nil, fmt.Errorf("job requires approval, but run approvals are not configured")
+		}
+		if awaiting, err = s.approvals.RequestTriggeredApproval(job, params); err != nil {
+			return nil, nil, fmt.Errorf("failed to request run approval: %w", err)
+		}
+	} else if err := s.trigger.TriggerJob(job, params); err != nil {
+		return nil, nil, fmt.Errorf("failed to trigger job: %w", err)
+	}
+
+	if err := s.webhookRepo.MarkTriggered(webhook.ID, time.Now().UTC()); err != nil {
+		logrus.WithError(err).Warn("Failed to record webhook trigger time")
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":            job.ID,
+		"webhook_id":        webhook.ID,
+		"awaiting_approval": awaiting != nil,
+	}).Info("Job triggered via webhook")
+
+	return webhook, awaiting, nil
+}
+
+// verifyWebhookSignature checks a signature header value against the body
+func verifyWebhookSignature(scheme models.WebhookSignatureScheme, secret, signature string, body []byte, now time.Time) bool {
+	if signature == "" {
+		return false
+	}
+
+	switch scheme {
+	case models.WebhookSignatureSchemeStripe:
+		var timestamp string
+		var candidates []string
+		for _, part := range strings.Split(signature, ",") {
+			kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			switch kv[0] {
+			case "t":
+				timestamp = kv[1]
+			case "v1":
+				candidates = append(candidates, kv[1])
+			}
+		}
+		if timestamp == "" || len(candidates) == 0 {
+			return false
+		}
+
+		var ts int64
+		if _, err := fmt.Sscanf(timestamp, "%d", &ts); err != nil {
+			return false
+		}
+		if age := now.Sub(time.Unix(ts, 0)); age > stripeSignatureTolerance || age < -stripeSignatureTolerance {
+			return false
+		}
+
+		expected := computeHMAC(secret, append([]byte(timestamp+"."), body...))
+		for _, candidate := range candidates {
+			if hmac.Equal([]byte(candidate), []byte(expected)) {
+				return true
+			}
+		}
+		return false
+	default:
+		provided := strings.TrimPrefix(signature, "sha256=")
+		expected := computeHMAC(secret, body)
+		return hmac.Equal([]byte(strings.ToLower(provided)), []byte(expected))
+	}
+}
+
+// computeHMAC returns the hex encoded HMAC-SHA256 of data
+func computeHMAC(secret string, data []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// randomHex returns n random bytes hex encoded
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// webhookRateLimiter is a fixed one-minute window limiter keyed by webhook ID
+type webhookRateLimiter struct {
+	mu      sync.Mutex
+	windows map[uuid.UUID]*rateWindow
+}
+
+// rateWindow tracks calls in the current window
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// newWebhookRateLimiter creates a new webhook rate limiter
+func newWebhookRateLimiter() *webhookRateLimiter {
+	return &webhookRateLimiter{
+		windows: make(map[uuid.UUID]*rateWindow),
+	}
+}
+
+// Allow records a call and reports whether it is within the limit
+func (l *webhookRateLimiter) Allow(id uuid.UUID, limitPerMinute int, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	window, exists := l.windows[id]
+	if !exists || now.Sub(window.start) >= time.Minute {
+		l.windows[id] = &rateWindow{start: now, count: 1}
+		return true
+	}
+
+	if window.count >= limitPerMinute {
+		return false
+	}
+	window.count++
+	return true
+}
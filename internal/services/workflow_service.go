@@ -0,0 +1,501 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+var (
+	// ErrWorkflowExists is returned when defining a second workflow under a name
+	ErrWorkflowExists = errors.New("a workflow with this name already exists")
+	// ErrWorkflowInactive is returned when triggering a workflow that has been deactivated
+	ErrWorkflowInactive = errors.New("workflow is not active")
+)
+
+// WorkflowNodeRunner runs a workflow node's job and waits for its outcome, retries included,
+// returning the output its last attempt recorded. The scheduler implements it
+type WorkflowNodeRunner interface {
+	RunWorkflowNode(job *models.Job, params models.JobConfig) (models.ExecutionOutput, error)
+}
+
+// WorkflowService defines the interface for workflow business logic
+type WorkflowService interface {
+	CreateWorkflow(req *models.CreateWorkflowRequest) (*models.Workflow, error)
+	GetWorkflow(id uuid.UUID) (*models.Workflow, error)
+	GetWorkflows() ([]models.Workflow, error)
+	UpdateWorkflow(id uuid.UUID, req *models.UpdateWorkflowRequest) (*models.Workflow, error)
+	DeleteWorkflow(id uuid.UUID) error
+	TriggerWorkflow(id uuid.UUID, params models.JobConfig, actor string) (*models.WorkflowExecution, error)
+	GetWorkflowExecutions(workflowID uuid.UUID, limit int) ([]models.WorkflowExecution, error)
+	GetWorkflowExecution(id uuid.UUID) (*models.WorkflowExecution, error)
+	GetWorkflowRun(id uuid.UUID) (*models.WorkflowRun, error)
+	GetWorkflowCriticalPath(id uuid.UUID, deadline time.Duration) (*models.WorkflowCriticalPath, error)
+}
+
+// workflowService implements WorkflowService interface
+type workflowService struct {
+	workflowRepo  repositories.WorkflowRepository
+	jobRepo       repositories.JobRepository
+	executionRepo repositories.JobExecutionRepository
+	runner        WorkflowNodeRunner
+}
+
+// NewWorkflowService creates a new workflow service running nodes through the given runner
+func NewWorkflowService(workflowRepo repositories.WorkflowRepository, jobRepo repositories.JobRepository, executionRepo repositories.JobExecutionRepository, runner WorkflowNodeRunner) WorkflowService {
+	return &workflowService{
+		workflowRepo:  workflowRepo,
+		jobRepo:       jobRepo,
+		executionRepo: executionRepo,
+		runner:        runner,
+	}
+}
+
+// CreateWorkflow defines a workflow after checking its graph and that every node's job exists
+func (s *workflowService) CreateWorkflow(req *models.CreateWorkflowRequest) (*models.Workflow, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return nil, fmt.Errorf("name is required, up to 100 characters")
+	}
+	if err := s.validateGraph(req.Graph); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.workflowRepo.FindByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check workflow: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrWorkflowExists
+	}
+
+	workflow := &models.Workflow{
+		ID:          uuid.New(),
+		Name:        name,
+		Description: req.Description,
+		Graph:       req.Graph,
+		IsActive:    true,
+	}
+	if req.IsActive != nil {
+		workflow.IsActive = *req.IsActive
+	}
+
+	if err := s.workflowRepo.Create(workflow); err != nil {
+		return nil, fmt.Errorf("failed to create workflow: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"workflow_id": workflow.ID,
+		"name":        workflow.Name,
+		"nodes":       len(workflow.Graph.Nodes),
+	}).Info("Workflow created successfully")
+
+	return workflow, nil
+}
+
+// GetWorkflow retrieves a workflow by ID
+func (s *workflowService) GetWorkflow(id uuid.UUID) (*models.Workflow, error) {
+	workflow, err := s.workflowRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+	return workflow, nil
+}
+
+// GetWorkflows lists all workflows
+func (s *workflowService) GetWorkflows() ([]models.Workflow, error) {
+	workflows, err := s.workflowRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflows: %w", err)
+	}
+	return workflows, nil
+}
+
+// UpdateWorkflow updates a workflow; executions already running keep the graph they started with
+func (s *workflowService) UpdateWorkflow(id uuid.UUID, req *models.UpdateWorkflowRequest) (*models.Workflow, error) {
+	workflow, err := s.workflowRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow for update: %w", err)
+	}
+
+	if req.Description != nil {
+		workflow.Description = *req.Description
+	}
+	if req.Graph != nil {
+		if err := s.validateGraph(*req.Graph); err != nil {
+			return nil, err
+		}
+		workflow.Graph = *req.Graph
+	}
+	if req.IsActive != nil {
+		workflow.IsActive = *req.IsActive
+	}
+
+	if err := s.workflowRepo.Update(workflow); err != nil {
+		return nil, fmt.Errorf("failed to update workflow: %w", err)
+	}
+
+	logrus.WithField("workflow_id", workflow.ID).Info("Workflow updated successfully")
+	return workflow, nil
+}
+
+// DeleteWorkflow deletes a workflow and its execution history
+func (s *workflowService) DeleteWorkflow(id uuid.UUID) error {
+	if err := s.workflowRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete workflow: %w", err)
+	}
+
+	logrus.WithField("workflow_id", id).Info("Workflow deleted successfully")
+	return nil
+}
+
+// validateGraph checks a graph's shape and that every node's job exists
+func (s *workflowService) validateGraph(graph models.WorkflowGraph) error {
+	if err := graph.Validate(); err != nil {
+		return fmt.Errorf("invalid workflow graph: %w", err)
+	}
+	for _, node := range graph.Nodes {
+		if _, err := s.jobRepo.GetByID(node.JobID); err != nil {
+			return fmt.Errorf("node %q: %w", node.Key, err)
+		}
+	}
+	return nil
+}
+
+// TriggerWorkflow starts a run of a workflow in the background and returns its execution
+// Nodes without upstream nodes start straight away; every other node starts once all its upstream
+// nodes have completed. When a node fails the nodes downstream of it are skipped, while
+// branches that don't depend on it carry on
+func (s *workflowService) TriggerWorkflow(id uuid.UUID, params models.JobConfig, actor string) (*models.WorkflowExecution, error) {
+	workflow, err := s.workflowRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+	if !workflow.IsActive {
+		return nil, ErrWorkflowInactive
+	}
+
+	// Load every node's job up front, so a run doesn't start when one has since been deleted
+	jobs := make(map[string]*models.Job, len(workflow.Graph.Nodes))
+	for _, node := range workflow.Graph.Nodes {
+		job, err := s.jobRepo.GetByID(node.JobID)
+		if err != nil {
+			return nil, fmt.Errorf("node %q: %w", node.Key, err)
+		}
+		jobs[node.Key] = job
+	}
+
+	execution := &models.WorkflowExecution{
+		ID:          uuid.New(),
+		WorkflowID:  workflow.ID,
+		Status:      models.WorkflowExecutionStatusRunning,
+		Graph:       workflow.Graph,
+		Nodes:       make(models.WorkflowNodeStates, len(workflow.Graph.Nodes)),
+		Parameters:  params,
+		TriggeredBy: actor,
+		StartedAt:   time.Now().UTC(),
+	}
+	for _, node := range workflow.Graph.Nodes {
+		execution.Nodes[node.Key] = &models.WorkflowNodeState{
+			Status: models.WorkflowNodeStatusPending,
+			JobID:  node.JobID,
+		}
+	}
+	if err := s.workflowRepo.CreateExecution(execution); err != nil {
+		return nil, fmt.Errorf("failed to create workflow execution: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"workflow_id":           workflow.ID,
+		"workflow_execution_id": execution.ID,
+		"triggered_by":          actor,
+	}).Info("Triggering workflow")
+
+	// The caller gets a snapshot; the run goes on updating its own copy
+	snapshot := *execution
+	snapshot.Nodes = copyNodeStates(execution.Nodes)
+
+	go s.run(workflow.Graph, jobs, execution)
+	return &snapshot, nil
+}
+
+// nodeResult is the outcome of one node's run
+type nodeResult struct {
+	key    string
+	output models.ExecutionOutput
+	err    error
+}
+
+// run drives a workflow execution to completion, recording each node's progress as it changes
+// Only this goroutine touches the execution; node runs report back over a channel
+func (s *workflowService) run(graph models.WorkflowGraph, jobs map[string]*models.Job, execution *models.WorkflowExecution) {
+	results := make(chan nodeResult, len(graph.Nodes))
+	outputs := make(map[string]interface{}, len(graph.Nodes))
+	running := s.startReadyNodes(graph, jobs, execution, outputs, results)
+	s.saveExecution(execution)
+
+	for running > 0 {
+		result := <-results
+		running--
+
+		now := time.Now().UTC()
+		state := execution.Nodes[result.key]
+		state.CompletedAt = &now
+		if result.err == nil {
+			state.Status = models.WorkflowNodeStatusCompleted
+			outputs[result.key] = decodedOutput(result.output)
+		} else {
+			state.Status = models.WorkflowNodeStatusFailed
+			state.Error = result.err.Error()
+			skipDownstream(graph, execution, result.key)
+			logrus.WithFields(logrus.Fields{
+				"workflow_execution_id": execution.ID,
+				"node":                  result.key,
+				"error":                 result.err,
+			}).Warn("Workflow node failed - skipping the nodes that depend on it")
+		}
+
+		running += s.startReadyNodes(graph, jobs, execution, outputs, results)
+		s.saveExecution(execution)
+	}
+
+	now := time.Now().UTC()
+	execution.CompletedAt = &now
+	execution.Status = models.WorkflowExecutionStatusCompleted
+	for _, state := range execution.Nodes {
+		if state.Status != models.WorkflowNodeStatusCompleted {
+			execution.Status = models.WorkflowExecutionStatusFailed
+			break
+		}
+	}
+	s.saveExecution(execution)
+
+	logrus.WithFields(logrus.Fields{
+		"workflow_id":           execution.WorkflowID,
+		"workflow_execution_id": execution.ID,
+		"status":                execution.Status,
+		"duration":              now.Sub(execution.StartedAt).String(),
+	}).Info("Workflow execution finished")
+}
+
+// startReadyNodes starts every pending node whose upstream nodes have all completed, passing it the
+// values its inbound edges map from their outputs. It returns how many nodes it started
+func (s *workflowService) startReadyNodes(graph models.WorkflowGraph, jobs map[string]*models.Job, execution *models.WorkflowExecution, outputs map[string]interface{}, results chan<- nodeResult) int {
+	started := 0
+	for _, key := range graph.TopologicalOrder() {
+		state := execution.Nodes[key]
+		if state.Status != models.WorkflowNodeStatusPending {
+			continue
+		}
+		ready := true
+		for _, upstream := range graph.Upstream(key) {
+			if execution.Nodes[upstream].Status != models.WorkflowNodeStatusCompleted {
+				ready = false
+				break
+			}
+		}
+		if !ready {
+			continue
+		}
+
+		now := time.Now().UTC()
+		state.Status = models.WorkflowNodeStatusRunning
+		state.StartedAt = &now
+		started++
+
+		// A node whose mapped parameters can't be filled fails without running
+		params, err := nodeParams(graph, execution, key, outputs)
+		go func(key string, job *models.Job) {
+			if err != nil {
+				results <- nodeResult{key: key, err: err}
+				return
+			}
+			output, err := s.runner.RunWorkflowNode(job, params)
+			results <- nodeResult{key: key, output: output, err: err}
+		}(key, jobs[key])
+	}
+	return started
+}
+
+// nodeParams returns the parameters of a node's run: the workflow's parameters, overridden by the values
+// its inbound edges map from their upstream nodes' outputs, plus where the run belongs
+func nodeParams(graph models.WorkflowGraph, execution *models.WorkflowExecution, key string, outputs map[string]interface{}) (models.JobConfig, error) {
+	params := make(models.JobConfig, len(execution.Parameters)+2)
+	for k, v := range execution.Parameters {
+		params[k] = v
+	}
+	for _, edge := range graph.Inbound(key) {
+		for param, expr := range edge.Params {
+			path, err := models.ParseJSONPath(expr)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %q: %w", param, err)
+			}
+			value, ok := path.Lookup(outputs[edge.From])
+			if !ok {
+				return nil, fmt.Errorf("parameter %q: the output of node %q has no value at %s", param, edge.From, path)
+			}
+			params[param] = value
+		}
+	}
+	params["workflow_execution_id"] = execution.ID.String()
+	params["workflow_node"] = key
+	return params, nil
+}
+
+// decodedOutput returns a run's output as decoded JSON, as it is stored, so JSONPaths see the same
+// fields whatever Go types the executor recorded
+func decodedOutput(output models.ExecutionOutput) interface{} {
+	encoded, err := json.Marshal(output)
+	if err != nil {
+		return nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return nil
+	}
+	return decoded
+}
+
+// skipDownstream marks the pending nodes that depend, directly or not, on a failed node as skipped
+func skipDownstream(graph models.WorkflowGraph, execution *models.WorkflowExecution, failed string) {
+	now := time.Now().UTC()
+	queue := graph.Downstream(failed)
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+		state := execution.Nodes[key]
+		if state.Status != models.WorkflowNodeStatusPending {
+			continue
+		}
+		state.Status = models.WorkflowNodeStatusSkipped
+		state.CompletedAt = &now
+		state.Error = fmt.Sprintf("upstream node %q failed", failed)
+		queue = append(queue, graph.Downstream(key)...)
+	}
+}
+
+// saveExecution records a workflow execution's progress, logging rather than stopping the run on failure
+func (s *workflowService) saveExecution(execution *models.WorkflowExecution) {
+	if err := s.workflowRepo.UpdateExecution(execution); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"workflow_execution_id": execution.ID,
+			"error":                 err,
+		}).Error("Failed to update workflow execution")
+	}
+}
+
+// copyNodeStates returns a deep copy of a workflow execution's node states
+func copyNodeStates(nodes models.WorkflowNodeStates) models.WorkflowNodeStates {
+	copied := make(models.WorkflowNodeStates, len(nodes))
+	for key, state := range nodes {
+		stateCopy := *state
+		copied[key] = &stateCopy
+	}
+	return copied
+}
+
+// GetWorkflowExecutions lists a workflow's most recent executions, newest first
+func (s *workflowService) GetWorkflowExecutions(workflowID uuid.UUID, limit int) ([]models.WorkflowExecution, error) {
+	if _, err := s.workflowRepo.GetByID(workflowID); err != nil {
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+	executions, err := s.workflowRepo.GetExecutionsByWorkflowID(workflowID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow executions: %w", err)
+	}
+	return executions, nil
+}
+
+// GetWorkflowExecution retrieves a workflow execution with the progress of each node
+func (s *workflowService) GetWorkflowExecution(id uuid.UUID) (*models.WorkflowExecution, error) {
+	execution, err := s.workflowRepo.GetExecutionByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow execution: %w", err)
+	}
+	return execution, nil
+}
+
+// GetWorkflowRun retrieves a workflow execution as a tree of its nodes and their job runs
+func (s *workflowService) GetWorkflowRun(id uuid.UUID) (*models.WorkflowRun, error) {
+	execution, workflow, graph, err := s.getExecutionGraph(id)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := s.executionRepo.GetByWorkflowExecution(execution.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow node runs: %w", err)
+	}
+	return models.NewWorkflowRun(execution, workflow.Name, graph, runs), nil
+}
+
+// GetWorkflowCriticalPath works out a workflow run's critical path and each node's slack, against a
+// deadline for the whole run if one is given
+// Nodes take as long as they took; nodes yet to finish are estimated from their jobs' average runs
+func (s *workflowService) GetWorkflowCriticalPath(id uuid.UUID, deadline time.Duration) (*models.WorkflowCriticalPath, error) {
+	execution, _, graph, err := s.getExecutionGraph(id)
+	if err != nil {
+		return nil, err
+	}
+
+	durations := make(map[string]time.Duration, len(execution.Nodes))
+	estimated := make(map[string]bool)
+	averages := make(map[uuid.UUID]time.Duration)
+	now := time.Now().UTC()
+	for key, state := range execution.Nodes {
+		if state.StartedAt != nil && state.CompletedAt != nil {
+			durations[key] = state.CompletedAt.Sub(*state.StartedAt)
+			continue
+		}
+
+		average, ok := averages[state.JobID]
+		if !ok {
+			stats, err := s.executionRepo.GetExecutionStats(state.JobID)
+			if err != nil {
+				return nil, fmt.Errorf("node %q: failed to get average run time: %w", key, err)
+			}
+			if stats.AverageExecutionTime != nil {
+				average = time.Duration(*stats.AverageExecutionTime) * time.Millisecond
+			}
+			averages[state.JobID] = average
+		}
+		// A running node takes at least as long as it has run so far
+		if state.StartedAt != nil && now.Sub(*state.StartedAt) > average {
+			average = now.Sub(*state.StartedAt)
+		}
+		durations[key] = average
+		estimated[key] = true
+	}
+
+	path := graph.CriticalPath(durations, deadline)
+	for i := range path.Nodes {
+		path.Nodes[i].Estimated = estimated[path.Nodes[i].Key]
+	}
+	return &path, nil
+}
+
+// getExecutionGraph retrieves a workflow execution, its workflow and the graph it runs
+// Executions from before runs kept their graph use the workflow's current graph
+func (s *workflowService) getExecutionGraph(id uuid.UUID) (*models.WorkflowExecution, *models.Workflow, models.WorkflowGraph, error) {
+	execution, err := s.workflowRepo.GetExecutionByID(id)
+	if err != nil {
+		return nil, nil, models.WorkflowGraph{}, fmt.Errorf("failed to get workflow execution: %w", err)
+	}
+	workflow, err := s.workflowRepo.GetByID(execution.WorkflowID)
+	if err != nil {
+		return nil, nil, models.WorkflowGraph{}, fmt.Errorf("failed to get workflow: %w", err)
+	}
+	graph := execution.Graph
+	if len(graph.Nodes) == 0 {
+		graph = workflow.Graph
+	}
+	return execution, workflow, graph, nil
+}
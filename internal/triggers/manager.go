@@ -0,0 +1,308 @@
+package triggers
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/expressions"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+)
+
+// receiveErrorBackoff is how long a poller waits after a failed receive
+const receiveErrorBackoff = 10 * time.Second
+
+// defaultVisibilityTimeout is used when a source has no visibility timeout set
+const defaultVisibilityTimeout = 5 * time.Minute
+
+// JobRunner runs a job synchronously and reports whether it succeeded
+// It is implemented by the scheduler
+type JobRunner interface {
+	RunJob(job *models.Job, params models.JobConfig) error
+}
+
+// Manager runs one poller per active trigger source
+type Manager struct {
+	sourceRepo repositories.TriggerSourceRepository
+	jobRepo    repositories.JobRepository
+	runner     JobRunner
+	config     config.TriggersConfig
+	httpClient *http.Client
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	pollers map[uuid.UUID]*poller
+}
+
+// poller tracks a running source poller
+type poller struct {
+	source models.TriggerSource
+	cancel context.CancelFunc
+}
+
+// NewManager creates a new trigger source manager
+func NewManager(
+	sourceRepo repositories.TriggerSourceRepository,
+	jobRepo repositories.JobRepository,
+	runner JobRunner,
+	cfg *config.Config,
+) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Manager{
+		sourceRepo: sourceRepo,
+		jobRepo:    jobRepo,
+		runner:     runner,
+		config:     cfg.Triggers,
+		httpClient: &http.Client{
+			// Long polls wait up to 20s, leave headroom
+			Timeout: 60 * time.Second,
+		},
+		ctx:     ctx,
+		cancel:  cancel,
+		pollers: make(map[uuid.UUID]*poller),
+	}
+}
+
+// Start loads active trigger sources and keeps pollers in sync with the database
+func (m *Manager) Start() error {
+	if !m.config.Enabled {
+		logrus.Info("Trigger sources disabled")
+		return nil
+	}
+
+	if err := m.Reload(); err != nil {
+		return err
+	}
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		ticker := time.NewTicker(m.config.ReloadInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-m.ctx.Done():
+				return
+			case <-ticker.C:
+				if err := m.Reload(); err != nil {
+					logrus.WithError(err).Error("Failed to reload trigger sources")
+				}
+			}
+		}
+	}()
+
+	logrus.Info("Trigger source manager started")
+	return nil
+}
+
+// Stop stops all pollers and waits for in-flight runs to finish
+func (m *Manager) Stop() {
+	m.cancel()
+	m.wg.Wait()
+	logrus.Info("Trigger source manager stopped")
+}
+
+// Reload starts pollers for new or changed sources and stops removed ones
+func (m *Manager) Reload() error {
+	sources, err := m.sourceRepo.GetActive()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current := make(map[uuid.UUID]models.TriggerSource, len(sources))
+	for _, source := range sources {
+		current[source.ID] = source
+	}
+
+	// Stop pollers whose source was removed, disabled or changed
+	for id, p := range m.pollers {
+		source, exists := current[id]
+		if !exists || !source.UpdatedAt.Equal(p.source.UpdatedAt) {
+			p.cancel()
+			delete(m.pollers, id)
+		}
+	}
+
+	// Start pollers for sources without one
+	for id, source := range current {
+		if _, running := m.pollers[id]; running {
+			continue
+		}
+
+		adapter, err := NewSource(&source, m.config, m.httpClient)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"source_id": id,
+				"error":     err,
+			}).Error("Failed to create trigger source")
+			continue
+		}
+		var condition *expressions.Expression
+		if source.Condition != "" {
+			if condition, err = expressions.TriggerCondition.CompileCondition(source.Condition); err != nil {
+				logrus.WithFields(logrus.Fields{
+					"source_id": id,
+					"error":     err,
+				}).Error("Invalid trigger source condition - not polling it")
+				continue
+			}
+		}
+
+		ctx, cancel := context.WithCancel(m.ctx)
+		m.pollers[id] = &poller{source: source, cancel: cancel}
+
+		m.wg.Add(1)
+		go m.poll(ctx, source, adapter, condition)
+	}
+
+	return nil
+}
+
+// poll receives messages from a source until its context is cancelled
+func (m *Manager) poll(ctx context.Context, source models.TriggerSource, adapter Source, condition *expressions.Expression) {
+	defer m.wg.Done()
+
+	log := logrus.WithFields(logrus.Fields{
+		"source_id": source.ID,
+		"kind":      source.Kind,
+		"job_id":    source.JobID,
+	})
+	log.Info("Trigger source poller started")
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Trigger source poller stopped")
+			return
+		default:
+		}
+
+		messages, err := adapter.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			log.WithError(err).Error("Failed to receive messages")
+			sleepContext(ctx, receiveErrorBackoff)
+			continue
+		}
+
+		var batch sync.WaitGroup
+		for _, msg := range messages {
+			batch.Add(1)
+			go func(msg Message) {
+				defer batch.Done()
+				m.handleMessage(source, adapter, condition, msg)
+			}(msg)
+		}
+		batch.Wait()
+	}
+}
+
+// handleMessage runs the mapped job for one message matching the condition and acks or nacks it
+// While the run is in progress the message visibility is extended at half the timeout
+func (m *Manager) handleMessage(source models.TriggerSource, adapter Source, condition *expressions.Expression, msg Message) {
+	log := logrus.WithFields(logrus.Fields{
+		"source_id":  source.ID,
+		"job_id":     source.JobID,
+		"message_id": msg.ID,
+	})
+
+	// Ack/nack must still reach the queue when shutting down mid-run
+	ackCtx := context.Background()
+
+	params, err := msg.toParams()
+	if err != nil {
+		// A malformed message will never succeed - drop it rather than redeliver forever
+		log.WithError(err).Warn("Discarding message with invalid payload")
+		if ackErr := adapter.Ack(ackCtx, msg); ackErr != nil {
+			log.WithError(ackErr).Error("Failed to ack message")
+		}
+		return
+	}
+
+	// Messages the condition doesn't match, or can't be evaluated for, never run the job either
+	matched, err := msg.matches(ackCtx, condition)
+	if err != nil || !matched {
+		if err != nil {
+			log.WithError(err).Warn("Trigger condition failed for message - discarding it")
+		} else {
+			log.Info("Message doesn't match the trigger condition - acknowledged without running")
+		}
+		if ackErr := adapter.Ack(ackCtx, msg); ackErr != nil {
+			log.WithError(ackErr).Error("Failed to ack message")
+		}
+		return
+	}
+
+	job, err := m.jobRepo.GetByID(source.JobID)
+	if err != nil || !job.IsActive() {
+		log.Warn("Mapped job missing or inactive, releasing message")
+		if nackErr := adapter.Nack(ackCtx, msg); nackErr != nil {
+			log.WithError(nackErr).Error("Failed to nack message")
+		}
+		return
+	}
+
+	// Keep the message invisible while the job runs
+	timeout := time.Duration(source.VisibilityTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultVisibilityTimeout
+	}
+	heartbeatDone := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(timeout / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-heartbeatDone:
+				return
+			case <-ticker.C:
+				if err := adapter.Extend(ackCtx, msg, timeout); err != nil {
+					log.WithError(err).Warn("Failed to extend message visibility")
+				}
+			}
+		}
+	}()
+
+	runErr := m.runner.RunJob(job, params)
+	close(heartbeatDone)
+
+	if runErr != nil {
+		log.WithError(runErr).Warn("Triggered run failed, releasing message for redelivery")
+		if err := adapter.Nack(ackCtx, msg); err != nil {
+			log.WithError(err).Error("Failed to nack message")
+		}
+		return
+	}
+
+	if err := adapter.Ack(ackCtx, msg); err != nil {
+		log.WithError(err).Error("Failed to ack message")
+		return
+	}
+	log.Info("Triggered run completed, message acknowledged")
+}
+
+// sleepContext sleeps for d or until ctx is cancelled
+func sleepContext(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
@@ -0,0 +1,210 @@
+package triggers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+)
+
+const (
+	pubSubEndpoint      = "https://pubsub.googleapis.com/v1/"
+	gceMetadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+)
+
+// PubSubSource reads messages from a GCP Pub/Sub subscription using the REST API
+type PubSubSource struct {
+	subscription string
+	ackDeadline  int
+	maxMessages  int
+	httpClient   *http.Client
+	tokens       *gcpTokenSource
+}
+
+// NewPubSubSource creates a new Pub/Sub trigger source
+func NewPubSubSource(source *models.TriggerSource, cfg config.TriggersConfig, httpClient *http.Client) *PubSubSource {
+	maxMessages := source.MaxMessages
+	if maxMessages < 1 {
+		maxMessages = 10
+	}
+
+	return &PubSubSource{
+		subscription: source.Target,
+		ackDeadline:  source.VisibilityTimeoutSeconds,
+		maxMessages:  maxMessages,
+		httpClient:   httpClient,
+		tokens: &gcpTokenSource{
+			static:     cfg.GCPAccessToken,
+			httpClient: httpClient,
+		},
+	}
+}
+
+// pubSubPullResponse is the subscriptions.pull response shape
+type pubSubPullResponse struct {
+	ReceivedMessages []struct {
+		AckID   string `json:"ackId"`
+		Message struct {
+			MessageID  string            `json:"messageId"`
+			Data       string            `json:"data"`
+			Attributes map[string]string `json:"attributes"`
+		} `json:"message"`
+	} `json:"receivedMessages"`
+}
+
+// Receive pulls a batch of messages and sets their ack deadline
+func (p *PubSubSource) Receive(ctx context.Context) ([]Message, error) {
+	var resp pubSubPullResponse
+	if err := p.call(ctx, "pull", map[string]interface{}{
+		"maxMessages": p.maxMessages,
+	}, &resp); err != nil {
+		return nil, err
+	}
+
+	messages := make([]Message, 0, len(resp.ReceivedMessages))
+	for _, m := range resp.ReceivedMessages {
+		data, err := base64.StdEncoding.DecodeString(m.Message.Data)
+		if err != nil {
+			data = []byte(m.Message.Data)
+		}
+		messages = append(messages, Message{
+			ID:         m.Message.MessageID,
+			Body:       data,
+			Attributes: m.Message.Attributes,
+			receipt:    m.AckID,
+		})
+	}
+
+	// Apply the configured deadline up front, the subscription default may be shorter
+	for _, msg := range messages {
+		if err := p.Extend(ctx, msg, time.Duration(p.ackDeadline)*time.Second); err != nil {
+			return messages, err
+		}
+	}
+
+	return messages, nil
+}
+
+// Ack acknowledges the message so it is not redelivered
+func (p *PubSubSource) Ack(ctx context.Context, msg Message) error {
+	return p.call(ctx, "acknowledge", map[string]interface{}{
+		"ackIds": []string{msg.receipt},
+	}, nil)
+}
+
+// Nack sets the ack deadline to zero so the message is redelivered immediately
+func (p *PubSubSource) Nack(ctx context.Context, msg Message) error {
+	return p.Extend(ctx, msg, 0)
+}
+
+// Extend modifies the message ack deadline
+func (p *PubSubSource) Extend(ctx context.Context, msg Message, timeout time.Duration) error {
+	return p.call(ctx, "modifyAckDeadline", map[string]interface{}{
+		"ackIds":             []string{msg.receipt},
+		"ackDeadlineSeconds": int(timeout.Seconds()),
+	}, nil)
+}
+
+// call performs an authenticated subscriptions.<method> request
+func (p *PubSubSource) call(ctx context.Context, method string, payload interface{}, out interface{}) error {
+	token, err := p.tokens.Token(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get GCP access token: %w", err)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode Pub/Sub %s request: %w", method, err)
+	}
+
+	url := pubSubEndpoint + p.subscription + ":" + method
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build Pub/Sub %s request: %w", method, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Pub/Sub %s request failed: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read Pub/Sub %s response: %w", method, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Pub/Sub %s failed with status %d: %s", method, resp.StatusCode, string(respBody))
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("failed to decode Pub/Sub %s response: %w", method, err)
+		}
+	}
+	return nil
+}
+
+// gcpTokenSource returns a static token or caches tokens from the GCE metadata server
+type gcpTokenSource struct {
+	static     string
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// Token returns a valid access token
+func (t *gcpTokenSource) Token(ctx context.Context) (string, error) {
+	if t.static != "" {
+		return t.static, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && time.Now().Before(t.expires) {
+		return t.token, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gceMetadataTokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("metadata server returned status %d", resp.StatusCode)
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", fmt.Errorf("failed to decode metadata token: %w", err)
+	}
+
+	// Refresh a minute early to avoid using a token that expires mid-request
+	t.token = tokenResp.AccessToken
+	t.expires = time.Now().Add(time.Duration(tokenResp.ExpiresIn)*time.Second - time.Minute)
+
+	return t.token, nil
+}
@@ -0,0 +1,89 @@
+package triggers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/expressions"
+	"job-scheduler/internal/models"
+)
+
+// Message is a single message received from a trigger source
+type Message struct {
+	ID         string
+	Body       []byte
+	Attributes map[string]string
+
+	// receipt is the source-specific handle used to ack/nack (SQS receipt handle, Pub/Sub ack id)
+	receipt string
+}
+
+// Source is a message queue that launches job runs
+// Ack removes a message after a successful run, Nack releases it for redelivery,
+// and Extend keeps it invisible to other consumers while a run is still going
+type Source interface {
+	Receive(ctx context.Context) ([]Message, error)
+	Ack(ctx context.Context, msg Message) error
+	Nack(ctx context.Context, msg Message) error
+	Extend(ctx context.Context, msg Message, timeout time.Duration) error
+}
+
+// NewSource creates the queue adapter for a trigger source
+func NewSource(source *models.TriggerSource, cfg config.TriggersConfig, httpClient *http.Client) (Source, error) {
+	switch source.Kind {
+	case models.TriggerSourceKindSQS:
+		return NewSQSSource(source, cfg, httpClient), nil
+	case models.TriggerSourceKindPubSub:
+		return NewPubSubSource(source, cfg, httpClient), nil
+	default:
+		return nil, fmt.Errorf("unsupported trigger source kind: %s", source.Kind)
+	}
+}
+
+// toParams converts a message into execution parameters
+func (m Message) toParams() (models.JobConfig, error) {
+	params, err := models.JobConfigFromPayload(m.Body)
+	if err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = models.JobConfig{}
+	}
+
+	params["message_id"] = m.ID
+	if len(m.Attributes) > 0 {
+		attributes := make(map[string]interface{}, len(m.Attributes))
+		for k, v := range m.Attributes {
+			attributes[k] = v
+		}
+		params["message_attributes"] = attributes
+	}
+
+	return params, nil
+}
+
+// matches returns true if the message satisfies the trigger source's condition, or there is none
+func (m Message) matches(ctx context.Context, condition *expressions.Expression) (bool, error) {
+	if condition == nil {
+		return true, nil
+	}
+	body, err := models.JobConfigFromPayload(m.Body)
+	if err != nil {
+		return false, err
+	}
+	if body == nil {
+		body = models.JobConfig{}
+	}
+	attributes := m.Attributes
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+	return condition.EvalBool(ctx, map[string]interface{}{
+		"body":       map[string]interface{}(body),
+		"attributes": attributes,
+		"message_id": m.ID,
+	})
+}
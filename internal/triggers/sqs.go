@@ -0,0 +1,164 @@
+package triggers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/sigv4"
+)
+
+// sqsLongPollSeconds is the SQS long polling wait time per receive call
+const sqsLongPollSeconds = 20
+
+// SQSSource reads messages from an AWS SQS queue using the JSON protocol
+type SQSSource struct {
+	queueURL          string
+	endpoint          string
+	visibilityTimeout int
+	maxMessages       int
+	signer            sigv4.Signer
+	httpClient        *http.Client
+}
+
+// NewSQSSource creates a new SQS trigger source
+// The region is taken from the queue URL host when it follows the
+// sqs.<region>.amazonaws.com convention, otherwise from configuration
+func NewSQSSource(source *models.TriggerSource, cfg config.TriggersConfig, httpClient *http.Client) *SQSSource {
+	s := &SQSSource{
+		queueURL:          source.Target,
+		visibilityTimeout: source.VisibilityTimeoutSeconds,
+		maxMessages:       source.MaxMessages,
+		signer: sigv4.Signer{
+			AccessKeyID:  cfg.AWSAccessKeyID,
+			SecretKey:    cfg.AWSSecretKey,
+			SessionToken: cfg.AWSSessionToken,
+			Region:       cfg.AWSRegion,
+			Service:      "sqs",
+		},
+		httpClient: httpClient,
+	}
+
+	if u, err := url.Parse(source.Target); err == nil {
+		s.endpoint = u.Scheme + "://" + u.Host + "/"
+		if parts := strings.Split(u.Host, "."); len(parts) >= 4 && parts[0] == "sqs" {
+			s.signer.Region = parts[1]
+		}
+	}
+
+	if s.maxMessages < 1 || s.maxMessages > 10 {
+		s.maxMessages = 10
+	}
+
+	return s
+}
+
+// sqsReceiveResponse is the ReceiveMessage response shape
+type sqsReceiveResponse struct {
+	Messages []struct {
+		MessageID         string `json:"MessageId"`
+		ReceiptHandle     string `json:"ReceiptHandle"`
+		Body              string `json:"Body"`
+		MessageAttributes map[string]struct {
+			StringValue string `json:"StringValue"`
+		} `json:"MessageAttributes"`
+	} `json:"Messages"`
+}
+
+// Receive long-polls the queue for a batch of messages
+func (s *SQSSource) Receive(ctx context.Context) ([]Message, error) {
+	var resp sqsReceiveResponse
+	err := s.call(ctx, "ReceiveMessage", map[string]interface{}{
+		"QueueUrl":              s.queueURL,
+		"MaxNumberOfMessages":   s.maxMessages,
+		"WaitTimeSeconds":       sqsLongPollSeconds,
+		"VisibilityTimeout":     s.visibilityTimeout,
+		"MessageAttributeNames": []string{"All"},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]Message, 0, len(resp.Messages))
+	for _, m := range resp.Messages {
+		attributes := make(map[string]string, len(m.MessageAttributes))
+		for k, v := range m.MessageAttributes {
+			attributes[k] = v.StringValue
+		}
+		messages = append(messages, Message{
+			ID:         m.MessageID,
+			Body:       []byte(m.Body),
+			Attributes: attributes,
+			receipt:    m.ReceiptHandle,
+		})
+	}
+
+	return messages, nil
+}
+
+// Ack deletes the message from the queue
+func (s *SQSSource) Ack(ctx context.Context, msg Message) error {
+	return s.call(ctx, "DeleteMessage", map[string]interface{}{
+		"QueueUrl":      s.queueURL,
+		"ReceiptHandle": msg.receipt,
+	}, nil)
+}
+
+// Nack makes the message visible again immediately so it is redelivered
+func (s *SQSSource) Nack(ctx context.Context, msg Message) error {
+	return s.Extend(ctx, msg, 0)
+}
+
+// Extend resets the message visibility timeout
+func (s *SQSSource) Extend(ctx context.Context, msg Message, timeout time.Duration) error {
+	return s.call(ctx, "ChangeMessageVisibility", map[string]interface{}{
+		"QueueUrl":          s.queueURL,
+		"ReceiptHandle":     msg.receipt,
+		"VisibilityTimeout": int(timeout.Seconds()),
+	}, nil)
+}
+
+// call performs a signed SQS JSON protocol request
+func (s *SQSSource) call(ctx context.Context, action string, payload interface{}, out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode SQS %s request: %w", action, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build SQS %s request: %w", action, err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+	req.Header.Set("X-Amz-Target", "AmazonSQS."+action)
+	s.signer.Sign(req, sigv4.PayloadHash(body), time.Now().UTC())
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("SQS %s request failed: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read SQS %s response: %w", action, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("SQS %s failed with status %d: %s", action, resp.StatusCode, string(respBody))
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("failed to decode SQS %s response: %w", action, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Package usertokens issues and verifies the bearer tokens identifying users of the API
+// A token names its user and when it expires, signed with HMAC-SHA256 under a secret shared by every
+// instance, so the user can't be changed without the secret
+package usertokens
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidToken is returned for tokens that are malformed or not signed with the secret
+	ErrInvalidToken = errors.New("invalid user token")
+	// ErrExpiredToken is returned for tokens past their expiry
+	ErrExpiredToken = errors.New("user token has expired")
+)
+
+// Signer issues and verifies user tokens under one secret
+type Signer struct {
+	secret []byte
+}
+
+// NewSigner creates a signer; it returns nil for an empty secret, which verifies no token
+func NewSigner(secret string) *Signer {
+	if secret == "" {
+		return nil
+	}
+	return &Signer{secret: []byte(secret)}
+}
+
+// Issue returns a token identifying user until expiresAt
+func (s *Signer) Issue(user string, expiresAt time.Time) (string, error) {
+	user = strings.TrimSpace(user)
+	if user == "" {
+		return "", fmt.Errorf("user is required")
+	}
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(user))
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	return encoded + "." + expires + "." + s.sign(encoded, expires), nil
+}
+
+// Verify returns the user a token identifies, if it is signed with the secret and hasn't expired
+func (s *Signer) Verify(token string, now time.Time) (string, error) {
+	if s == nil {
+		return "", ErrInvalidToken
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", ErrInvalidToken
+	}
+	encoded, expires, signature := parts[0], parts[1], parts[2]
+	if !hmac.Equal([]byte(signature), []byte(s.sign(encoded, expires))) {
+		return "", ErrInvalidToken
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	if !now.Before(time.Unix(unix, 0)) {
+		return "", ErrExpiredToken
+	}
+	user, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(user) == 0 {
+		return "", ErrInvalidToken
+	}
+	return string(user), nil
+}
+
+func (s *Signer) sign(encodedUser, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encodedUser + "." + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,31 @@
+-- Create job_webhooks table
+CREATE TABLE IF NOT EXISTS job_webhooks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL,
+    secret VARCHAR(128) NOT NULL,
+    signature_header VARCHAR(100) NOT NULL DEFAULT 'X-Signature-256',
+    signature_scheme VARCHAR(30) NOT NULL DEFAULT 'hmac_sha256_hex',
+    rate_limit_per_minute INTEGER NOT NULL DEFAULT 60,
+    is_active BOOLEAN DEFAULT true,
+    last_triggered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for better performance
+CREATE UNIQUE INDEX IF NOT EXISTS idx_job_webhooks_token ON job_webhooks(token);
+CREATE INDEX IF NOT EXISTS idx_job_webhooks_job_id ON job_webhooks(job_id);
+
+-- Add check constraint for rate limit (must be positive)
+ALTER TABLE job_webhooks
+ADD CONSTRAINT chk_job_webhooks_rate_limit
+CHECK (rate_limit_per_minute > 0);
+
+CREATE TRIGGER update_job_webhooks_updated_at
+    BEFORE UPDATE ON job_webhooks
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Store trigger-supplied parameters (e.g. webhook payloads) on executions
+ALTER TABLE job_executions ADD COLUMN IF NOT EXISTS parameters JSONB;
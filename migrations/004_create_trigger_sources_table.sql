@@ -0,0 +1,26 @@
+-- Create trigger_sources table
+CREATE TABLE IF NOT EXISTS trigger_sources (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    target VARCHAR(500) NOT NULL,
+    visibility_timeout_seconds INTEGER NOT NULL DEFAULT 300,
+    max_messages INTEGER NOT NULL DEFAULT 10,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_trigger_sources_job_id ON trigger_sources(job_id);
+CREATE INDEX IF NOT EXISTS idx_trigger_sources_is_active ON trigger_sources(is_active);
+
+-- Add check constraint for kind values
+ALTER TABLE trigger_sources
+ADD CONSTRAINT chk_trigger_sources_kind
+CHECK (kind IN ('sqs', 'pubsub'));
+
+CREATE TRIGGER update_trigger_sources_updated_at
+    BEFORE UPDATE ON trigger_sources
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
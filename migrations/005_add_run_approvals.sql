@@ -0,0 +1,17 @@
+-- Jobs flagged requires_approval only run after someone approves each due run
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS requires_approval BOOLEAN DEFAULT false;
+
+-- Approval information on executions
+ALTER TABLE job_executions ADD COLUMN IF NOT EXISTS approved_by VARCHAR(255);
+ALTER TABLE job_executions ADD COLUMN IF NOT EXISTS approved_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE job_executions ADD COLUMN IF NOT EXISTS approval_expires_at TIMESTAMP WITH TIME ZONE;
+
+-- Allow the approval statuses
+ALTER TABLE job_executions DROP CONSTRAINT IF EXISTS chk_job_executions_status;
+ALTER TABLE job_executions
+ADD CONSTRAINT chk_job_executions_status
+CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled', 'awaiting_approval', 'expired'));
+
+-- Index for the approval expiry sweep
+CREATE INDEX IF NOT EXISTS idx_job_executions_awaiting_approval
+ON job_executions(approval_expires_at) WHERE status = 'awaiting_approval';
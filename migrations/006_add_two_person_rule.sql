@@ -0,0 +1,48 @@
+-- Job tags - jobs tagged "protected" are subject to the two-person rule
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS tags JSONB DEFAULT '[]';
+
+-- Create audit_entries table
+CREATE TABLE IF NOT EXISTS audit_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID NOT NULL,
+    action VARCHAR(100) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    details JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_entries_entity ON audit_entries(entity_type, entity_id);
+
+-- Create pending_changes table
+CREATE TABLE IF NOT EXISTS pending_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL,
+    payload JSONB,
+    requested_by VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reviewed_by VARCHAR(255),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_pending_changes_job_id ON pending_changes(job_id);
+CREATE INDEX IF NOT EXISTS idx_pending_changes_status ON pending_changes(status);
+
+-- Add check constraints for action and status values
+ALTER TABLE pending_changes
+ADD CONSTRAINT chk_pending_changes_action
+CHECK (action IN ('update', 'delete'));
+
+ALTER TABLE pending_changes
+ADD CONSTRAINT chk_pending_changes_status
+CHECK (status IN ('pending', 'approved', 'rejected'));
+
+CREATE TRIGGER update_pending_changes_updated_at
+    BEFORE UPDATE ON pending_changes
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,13 @@
+-- On-call documentation for jobs, included in failure notifications and the dashboard
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS runbook_url VARCHAR(500);
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS docs TEXT;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS severity VARCHAR(20) DEFAULT 'medium';
+
+-- Add check constraint for severity values
+ALTER TABLE jobs
+ADD CONSTRAINT chk_jobs_severity
+CHECK (severity IN ('low', 'medium', 'high', 'critical'));
+
+-- Index for the dashboard's recent failures
+CREATE INDEX IF NOT EXISTS idx_job_executions_failed
+ON job_executions(completed_at DESC) WHERE status = 'failed';
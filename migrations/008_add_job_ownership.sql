@@ -0,0 +1,26 @@
+-- Job ownership, used to route notifications to the owning team
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS team VARCHAR(100);
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS owner VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_team ON jobs(team);
+
+-- Create team_channels table
+CREATE TABLE IF NOT EXISTS team_channels (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    team VARCHAR(100) NOT NULL UNIQUE,
+    kind VARCHAR(20) NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add check constraint for kind values
+ALTER TABLE team_channels
+ADD CONSTRAINT chk_team_channels_kind
+CHECK (kind IN ('slack', 'webhook'));
+
+CREATE TRIGGER update_team_channels_updated_at
+    BEFORE UPDATE ON team_channels
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
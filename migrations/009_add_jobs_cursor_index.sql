@@ -0,0 +1,2 @@
+-- Index for cursor pagination of jobs (newest first, ID breaks ties)
+CREATE INDEX IF NOT EXISTS idx_jobs_created_at_id ON jobs(created_at DESC, id DESC);
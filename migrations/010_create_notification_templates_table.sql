@@ -0,0 +1,25 @@
+-- Create notification_templates table
+CREATE TABLE IF NOT EXISTS notification_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    channel VARCHAR(20) NOT NULL,
+    event VARCHAR(50) NOT NULL DEFAULT '',
+    subject VARCHAR(500),
+    body TEXT NOT NULL,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add check constraint for channel values
+ALTER TABLE notification_templates
+ADD CONSTRAINT chk_notification_templates_channel
+CHECK (channel IN ('slack', 'webhook', 'email'));
+
+-- Templates are looked up by channel and event when a notification is sent
+CREATE INDEX IF NOT EXISTS idx_notification_templates_lookup ON notification_templates(channel, event) WHERE is_active = true;
+
+CREATE TRIGGER update_notification_templates_updated_at
+    BEFORE UPDATE ON notification_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,23 @@
+-- Create report_templates table
+CREATE TABLE IF NOT EXISTS report_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT,
+    format VARCHAR(10) NOT NULL DEFAULT 'txt',
+    layout JSONB,
+    columns JSONB,
+    queries JSONB,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add check constraint for format values
+ALTER TABLE report_templates
+ADD CONSTRAINT chk_report_templates_format
+CHECK (format IN ('txt', 'csv'));
+
+CREATE TRIGGER update_report_templates_updated_at
+    BEFORE UPDATE ON report_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,15 @@
+-- Create artifacts table for files produced by job runs, e.g. generated reports
+CREATE TABLE IF NOT EXISTS artifacts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    execution_id UUID NOT NULL REFERENCES job_executions(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    storage_key VARCHAR(500) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Reports are listed per job, newest first
+CREATE INDEX IF NOT EXISTS idx_artifacts_job_id_created_at ON artifacts(job_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_artifacts_execution_id ON artifacts(execution_id);
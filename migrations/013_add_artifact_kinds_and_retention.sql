@@ -0,0 +1,13 @@
+-- Artifacts hold reports, exports and logs, each kept until its retention ends
+ALTER TABLE artifacts ADD COLUMN IF NOT EXISTS kind VARCHAR(20) NOT NULL DEFAULT 'report';
+ALTER TABLE artifacts ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
+
+-- Add check constraint for kind values
+ALTER TABLE artifacts
+ADD CONSTRAINT chk_artifacts_kind
+CHECK (kind IN ('report', 'export', 'log'));
+
+-- Reports are listed per job and kind; the purge looks up expired artifacts
+DROP INDEX IF EXISTS idx_artifacts_job_id_created_at;
+CREATE INDEX IF NOT EXISTS idx_artifacts_job_id_kind_created_at ON artifacts(job_id, kind, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_artifacts_expires_at ON artifacts(expires_at) WHERE expires_at IS NOT NULL;
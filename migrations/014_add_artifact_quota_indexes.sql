@@ -0,0 +1,2 @@
+-- Quota eviction deletes each job's oldest artifacts first and sums their sizes
+CREATE INDEX IF NOT EXISTS idx_artifacts_job_id_created_at ON artifacts(job_id, created_at);
@@ -0,0 +1,15 @@
+-- Retry policy for failed runs
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS max_retries INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS backoff_strategy VARCHAR(20) DEFAULT 'exponential';
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS initial_delay_seconds INTEGER NOT NULL DEFAULT 0;
+
+-- Add check constraint for backoff strategy values
+ALTER TABLE jobs
+ADD CONSTRAINT chk_jobs_backoff_strategy
+CHECK (backoff_strategy IN ('fixed', 'linear', 'exponential'));
+
+-- Each retry is its own execution, linked to the attempt it retries
+ALTER TABLE job_executions ADD COLUMN IF NOT EXISTS attempt INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE job_executions ADD COLUMN IF NOT EXISTS retry_of_id UUID REFERENCES job_executions(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_job_executions_retry_of_id ON job_executions(retry_of_id);
@@ -0,0 +1,6 @@
+-- Resource telemetry captured during each run
+ALTER TABLE job_executions ADD COLUMN IF NOT EXISTS resource_usage JSONB;
+
+-- Usage is graphed per job over its most recent runs
+CREATE INDEX IF NOT EXISTS idx_job_executions_job_id_usage
+ON job_executions(job_id, started_at DESC) WHERE resource_usage IS NOT NULL;
@@ -0,0 +1,12 @@
+-- Create job_run_claims table
+-- Scheduler instances sharing the database claim each scheduled run before executing it
+CREATE TABLE IF NOT EXISTS job_run_claims (
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    instance_id VARCHAR(255) NOT NULL,
+    claimed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (job_id, scheduled_for)
+);
+
+-- Old claims are pruned periodically
+CREATE INDEX IF NOT EXISTS idx_job_run_claims_claimed_at ON job_run_claims(claimed_at);
@@ -0,0 +1,5 @@
+-- Runs left running by an instance that stopped are marked stalled
+ALTER TABLE job_executions DROP CONSTRAINT IF EXISTS chk_job_executions_status;
+ALTER TABLE job_executions
+ADD CONSTRAINT chk_job_executions_status
+CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled', 'awaiting_approval', 'expired', 'stalled'));
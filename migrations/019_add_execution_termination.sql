@@ -0,0 +1,2 @@
+-- Records whether a cancelled or timed out run stopped gracefully or was killed
+ALTER TABLE job_executions ADD COLUMN IF NOT EXISTS termination VARCHAR(20);
@@ -0,0 +1,9 @@
+-- Records the cron occurrence each scheduled run belongs to, separately from when it started
+ALTER TABLE job_executions ADD COLUMN IF NOT EXISTS scheduled_for TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_job_executions_scheduled_for ON job_executions(scheduled_for);
+
+-- Each attempt of an occurrence is recorded at most once, even if two instances fire it
+CREATE UNIQUE INDEX IF NOT EXISTS idx_job_executions_occurrence
+    ON job_executions(job_id, scheduled_for, attempt)
+    WHERE scheduled_for IS NOT NULL;
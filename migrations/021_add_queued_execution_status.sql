@@ -0,0 +1,5 @@
+-- Runs waiting for a free execution slot are recorded as queued
+ALTER TABLE job_executions DROP CONSTRAINT IF EXISTS chk_job_executions_status;
+ALTER TABLE job_executions
+ADD CONSTRAINT chk_job_executions_status
+CHECK (status IN ('pending', 'queued', 'running', 'completed', 'failed', 'cancelled', 'awaiting_approval', 'expired', 'stalled'));
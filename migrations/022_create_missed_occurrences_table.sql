@@ -0,0 +1,14 @@
+-- Create missed_occurrences table
+-- Records scheduled occurrences of jobs that didn't run, with the reason, so gaps in run history are explained
+CREATE TABLE IF NOT EXISTS missed_occurrences (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason VARCHAR(50) NOT NULL,
+    details TEXT,
+    instance_id VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Each occurrence is recorded once, even if several instances notice it
+CREATE UNIQUE INDEX IF NOT EXISTS idx_missed_occurrences_occurrence ON missed_occurrences(job_id, scheduled_for);
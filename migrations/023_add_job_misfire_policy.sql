@@ -0,0 +1,8 @@
+-- Add misfire policy and run times to jobs
+-- The misfire policy decides what happens to occurrences missed while no scheduler instance was running
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS misfire_policy VARCHAR(30) DEFAULT 'ignore'
+    CHECK (misfire_policy IN ('ignore', 'run_once_on_startup', 'run_all_missed'));
+
+-- The last occurrence fired and the next one due; occurrences from next_run_at on are caught up at startup
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS last_run_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS next_run_at TIMESTAMP WITH TIME ZONE;
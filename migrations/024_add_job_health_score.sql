@@ -0,0 +1,7 @@
+-- Add health score to jobs
+-- A 0-100 score from recent success rate, lateness, duration trend and alert volume, recalculated periodically
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS health_score INTEGER CHECK (health_score BETWEEN 0 AND 100);
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS health_scored_at TIMESTAMP WITH TIME ZONE;
+
+-- Jobs are listed by health score to triage the least healthy first
+CREATE INDEX IF NOT EXISTS idx_jobs_health_score ON jobs(health_score);
@@ -0,0 +1,5 @@
+-- Add pause record to jobs
+-- Records who paused a job through POST /jobs/{id}/pause, when and why; cleared when the job is resumed
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS paused_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS paused_by VARCHAR(255);
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS pause_reason TEXT;
@@ -0,0 +1,3 @@
+-- Add remediation actions to jobs
+-- Fixes on-call engineers can apply from a failure notification through signed links
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS remediation_actions JSONB NOT NULL DEFAULT '[]';
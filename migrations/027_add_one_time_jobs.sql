@@ -0,0 +1,6 @@
+-- Add one-time jobs
+-- Jobs with schedule_type 'once' have no cron schedule; they run at run_at and are deactivated
+-- afterwards, recording completed_at
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS schedule_type VARCHAR(20) NOT NULL DEFAULT 'cron';
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS run_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,25 @@
+-- Create alert_rules table
+CREATE TABLE IF NOT EXISTS alert_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT,
+    metric VARCHAR(30) NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    window_runs INTEGER NOT NULL DEFAULT 20,
+    job_id UUID REFERENCES jobs(id) ON DELETE CASCADE,
+    team VARCHAR(100),
+    severity VARCHAR(20) DEFAULT 'warning',
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add check constraint for metric values
+ALTER TABLE alert_rules
+ADD CONSTRAINT chk_alert_rules_metric
+CHECK (metric IN ('failure_rate', 'lateness', 'duration'));
+
+CREATE TRIGGER update_alert_rules_updated_at
+    BEFORE UPDATE ON alert_rules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
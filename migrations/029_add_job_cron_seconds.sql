@@ -0,0 +1,3 @@
+-- Add seconds-granularity cron schedules
+-- Jobs with cron_seconds set may use six-field schedules whose first field is the second
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS cron_seconds BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,19 @@
+-- Create role_assignments table
+CREATE TABLE IF NOT EXISTS role_assignments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    username VARCHAR(255) NOT NULL UNIQUE,
+    role VARCHAR(20) NOT NULL,
+    assigned_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add check constraint for role values
+ALTER TABLE role_assignments
+ADD CONSTRAINT chk_role_assignments_role
+CHECK (role IN ('viewer', 'operator', 'admin'));
+
+CREATE TRIGGER update_role_assignments_updated_at
+    BEFORE UPDATE ON role_assignments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,25 @@
+-- Create database_connections table
+CREATE TABLE IF NOT EXISTS database_connections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT,
+    driver VARCHAR(20) NOT NULL DEFAULT 'postgres',
+    dsn TEXT NOT NULL,
+    username VARCHAR(255),
+    password_secret VARCHAR(255),
+    max_open_conns INTEGER NOT NULL DEFAULT 5,
+    max_idle_conns INTEGER NOT NULL DEFAULT 2,
+    conn_max_lifetime_seconds INTEGER NOT NULL DEFAULT 1800,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add check constraint for driver values
+ALTER TABLE database_connections
+ADD CONSTRAINT chk_database_connections_driver
+CHECK (driver IN ('postgres'));
+
+CREATE TRIGGER update_database_connections_updated_at
+    BEFORE UPDATE ON database_connections
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+-- Runs of jobs whose connection is known to be down are recorded as dependency_unavailable,
+-- which is longer than the status column allowed
+ALTER TABLE job_executions ALTER COLUMN status TYPE VARCHAR(30);
+ALTER TABLE job_executions DROP CONSTRAINT IF EXISTS chk_job_executions_status;
+ALTER TABLE job_executions
+ADD CONSTRAINT chk_job_executions_status
+CHECK (status IN ('pending', 'queued', 'running', 'completed', 'failed', 'cancelled', 'awaiting_approval', 'expired', 'stalled', 'dependency_unavailable'));
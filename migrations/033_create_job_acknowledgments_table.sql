@@ -0,0 +1,16 @@
+-- Create job_acknowledgments table
+CREATE TABLE IF NOT EXISTS job_acknowledgments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    note TEXT NOT NULL,
+    acknowledged_by VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    cleared_by VARCHAR(255),
+    cleared_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_acknowledgments_job_id ON job_acknowledgments(job_id);
+
+-- Active acknowledgments are looked up on every alert evaluation and failed run
+CREATE INDEX IF NOT EXISTS idx_job_acknowledgments_active ON job_acknowledgments(expires_at) WHERE cleared_at IS NULL;
@@ -0,0 +1,2 @@
+-- Structured output recorded by executors during a run
+ALTER TABLE job_executions ADD COLUMN IF NOT EXISTS output JSONB;
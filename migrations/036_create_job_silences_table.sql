@@ -0,0 +1,16 @@
+-- Create job_silences table
+CREATE TABLE IF NOT EXISTS job_silences (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    silenced_by VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    lifted_by VARCHAR(255),
+    lifted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_silences_job_id ON job_silences(job_id);
+
+-- Active silences are looked up before every notification
+CREATE INDEX IF NOT EXISTS idx_job_silences_active ON job_silences(expires_at) WHERE lifted_at IS NULL;
@@ -0,0 +1,13 @@
+-- Create job_execution_logs table
+CREATE TABLE IF NOT EXISTS job_execution_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    execution_id UUID NOT NULL REFERENCES job_executions(id) ON DELETE CASCADE,
+    sequence INTEGER NOT NULL,
+    level VARCHAR(10) NOT NULL,
+    message TEXT NOT NULL,
+    fields JSONB,
+    logged_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Lines are read in order, a page after a given sequence at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_job_execution_logs_sequence ON job_execution_logs(execution_id, sequence);
@@ -0,0 +1,3 @@
+-- Jobs are looked up by team and name when upserting by name and when names must be unique
+-- Uniqueness is optional (SCHEDULER_UNIQUE_JOB_NAMES), so the index doesn't enforce it
+CREATE INDEX IF NOT EXISTS idx_jobs_team_name ON jobs(team, name);
@@ -0,0 +1,2 @@
+-- Throughput stats count runs finished in a time range by completed_at
+CREATE INDEX IF NOT EXISTS idx_job_executions_completed_at ON job_executions(completed_at) WHERE completed_at IS NOT NULL;
@@ -0,0 +1,6 @@
+-- Add error record to jobs
+-- Records when and why the scheduler found a job's stored schedule unusable; cleared once it is scheduled again
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS errored_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS error_reason TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_errored_at ON jobs(errored_at) WHERE errored_at IS NOT NULL;
@@ -0,0 +1,19 @@
+-- Replace jobs.is_active with a lifecycle state
+-- active and errored jobs are scheduled; paused, disabled, expired and archived jobs aren't
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS state VARCHAR(20) NOT NULL DEFAULT 'active';
+
+-- Existing jobs take the state their records describe; the rest of the inactive jobs are disabled
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'jobs' AND column_name = 'is_active') THEN
+        UPDATE jobs SET state = 'errored' WHERE is_active AND errored_at IS NOT NULL;
+        UPDATE jobs SET state = 'expired' WHERE NOT is_active AND completed_at IS NOT NULL;
+        UPDATE jobs SET state = 'paused' WHERE NOT is_active AND completed_at IS NULL AND paused_at IS NOT NULL;
+        UPDATE jobs SET state = 'disabled' WHERE NOT is_active AND completed_at IS NULL AND paused_at IS NULL;
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_state ON jobs(state);
+
+DROP INDEX IF EXISTS idx_jobs_is_active;
+ALTER TABLE jobs DROP COLUMN IF EXISTS is_active;
@@ -0,0 +1,20 @@
+-- Create job_changes table
+-- The outbox of job definition changes a primary serves to standbys, read in sequence order
+CREATE TABLE IF NOT EXISTS job_changes (
+    sequence BIGSERIAL PRIMARY KEY,
+    job_id UUID NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    payload JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create replication_state table
+-- A standby's single row records the last change it applied and whether it was promoted
+CREATE TABLE IF NOT EXISTS replication_state (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    last_sequence BIGINT NOT NULL DEFAULT 0,
+    last_synced_at TIMESTAMP WITH TIME ZONE,
+    promoted_at TIMESTAMP WITH TIME ZONE,
+    promoted_by VARCHAR(255),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,10 @@
+-- Add concurrency policy to jobs
+-- The concurrency policy decides whether a scheduled run may overlap the job's previous run
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS concurrency_policy VARCHAR(20) DEFAULT 'allow'
+    CHECK (concurrency_policy IN ('allow', 'forbid', 'replace'));
+
+-- Runs not started because the job's previous run was still going are recorded as skipped
+ALTER TABLE job_executions DROP CONSTRAINT IF EXISTS chk_job_executions_status;
+ALTER TABLE job_executions
+ADD CONSTRAINT chk_job_executions_status
+CHECK (status IN ('pending', 'queued', 'running', 'completed', 'failed', 'cancelled', 'awaiting_approval', 'expired', 'stalled', 'dependency_unavailable', 'skipped'));
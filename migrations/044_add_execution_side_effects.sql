@@ -0,0 +1,2 @@
+-- External side effects performed by a run, saved with its outcome so retries don't repeat them
+ALTER TABLE job_executions ADD COLUMN IF NOT EXISTS side_effects JSONB;
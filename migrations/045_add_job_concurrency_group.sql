@@ -0,0 +1,3 @@
+-- Jobs in the same concurrency group share a limit on concurrent runs, configured per group
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS concurrency_group VARCHAR(100);
+CREATE INDEX IF NOT EXISTS idx_jobs_concurrency_group ON jobs(concurrency_group);
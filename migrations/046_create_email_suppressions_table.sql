@@ -0,0 +1,21 @@
+-- Create email_suppressions table - addresses email jobs no longer send to, such as ones that
+-- bounced or unsubscribed
+CREATE TABLE IF NOT EXISTS email_suppressions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    email VARCHAR(320) NOT NULL UNIQUE,
+    reason VARCHAR(20) NOT NULL,
+    details TEXT,
+    suppressed_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add check constraint for reason values
+ALTER TABLE email_suppressions
+ADD CONSTRAINT chk_email_suppressions_reason
+CHECK (reason IN ('bounce', 'complaint', 'unsubscribe', 'manual'));
+
+CREATE TRIGGER update_email_suppressions_updated_at
+    BEFORE UPDATE ON email_suppressions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,9 @@
+-- The instance running a run, so a restarted instance can tell which unfinished runs were its own
+ALTER TABLE job_executions ADD COLUMN IF NOT EXISTS instance_id VARCHAR(255);
+CREATE INDEX IF NOT EXISTS idx_job_executions_instance_id ON job_executions(instance_id);
+
+-- Runs stopped by a shutdown or found unfinished after a restart wait as interrupted until requeued or failed
+ALTER TABLE job_executions DROP CONSTRAINT IF EXISTS chk_job_executions_status;
+ALTER TABLE job_executions
+ADD CONSTRAINT chk_job_executions_status
+CHECK (status IN ('pending', 'queued', 'running', 'completed', 'failed', 'cancelled', 'awaiting_approval', 'expired', 'stalled', 'dependency_unavailable', 'skipped', 'interrupted'));
@@ -0,0 +1,15 @@
+-- Create job_revisions table
+-- Each change to a job's name, schedule or config is kept as a numbered revision that can be rolled back to
+CREATE TABLE IF NOT EXISTS job_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    schedule_type VARCHAR(20) NOT NULL DEFAULT 'cron',
+    schedule VARCHAR(100) NOT NULL,
+    config JSONB,
+    author VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_job_revisions_job_revision ON job_revisions(job_id, revision);
@@ -0,0 +1,7 @@
+-- Workflow executions keep the graph they run, so a run can be shown as it was even after the workflow changes
+ALTER TABLE workflow_executions ADD COLUMN IF NOT EXISTS graph JSONB;
+
+-- Workflow node runs are looked up by the workflow execution that started them
+CREATE INDEX IF NOT EXISTS idx_job_executions_workflow_execution
+    ON job_executions ((parameters->>'workflow_execution_id'))
+    WHERE parameters ? 'workflow_execution_id';
@@ -0,0 +1,17 @@
+-- Acknowledgments have a scope: alerts holds back a job's alerts and failure notifications, notifications
+-- mutes all of its notifications but leaves its alerts alone, as silences did
+ALTER TABLE job_acknowledgments ADD COLUMN IF NOT EXISTS scope VARCHAR(20) NOT NULL DEFAULT 'alerts';
+
+-- An acknowledgment without an expiry lasts until it is cleared
+ALTER TABLE job_acknowledgments ALTER COLUMN expires_at DROP NOT NULL;
+
+DROP INDEX IF EXISTS idx_job_acknowledgments_active;
+CREATE INDEX IF NOT EXISTS idx_job_acknowledgments_active ON job_acknowledgments(expires_at NULLS FIRST) WHERE cleared_at IS NULL;
+
+-- Silences become acknowledgments in the notifications scope
+INSERT INTO job_acknowledgments (id, job_id, note, acknowledged_by, scope, expires_at, cleared_by, cleared_at, created_at)
+SELECT id, job_id, reason, silenced_by, 'notifications', expires_at, lifted_by, lifted_at, created_at
+FROM job_silences
+ON CONFLICT (id) DO NOTHING;
+
+DROP TABLE IF EXISTS job_silences;
@@ -0,0 +1,2 @@
+-- Trigger sources may have a CEL condition deciding which messages run their job
+ALTER TABLE trigger_sources ADD COLUMN IF NOT EXISTS condition TEXT;
@@ -0,0 +1,2 @@
+-- Per-job run timeout, in place of the scheduler's; 0 uses the scheduler's
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS timeout_seconds INTEGER NOT NULL DEFAULT 0;
@@ -17,6 +17,8 @@ import (
 type Connection struct {
 	DB     *gorm.DB
 	Config *config.Config
+	// QueryMetrics records the latency of every query
+	QueryMetrics *QueryMetrics
 }
 
 // NewConnection creates a new database connection
@@ -35,11 +37,20 @@ func NewConnection(cfg *config.Config) (*Connection, error) {
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
+		PrepareStmt:            cfg.Database.PrepareStmt,
+		SkipDefaultTransaction: cfg.Database.SkipDefaultTransaction,
+		CreateBatchSize:        cfg.Database.CreateBatchSize,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// Record query latency and log slow queries
+	queryMetrics := NewQueryMetrics(cfg.Database.SlowQueryThreshold)
+	if err := db.Use(queryMetrics); err != nil {
+		return nil, fmt.Errorf("failed to register query metrics: %w", err)
+	}
+
 	// Get underlying SQL DB to configure connection pool
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -54,8 +65,9 @@ func NewConnection(cfg *config.Config) (*Connection, error) {
 	logrus.Info("Successfully connected to database")
 
 	return &Connection{
-		DB:     db,
-		Config: cfg,
+		DB:           db,
+		Config:       cfg,
+		QueryMetrics: queryMetrics,
 	}, nil
 }
 
@@ -67,6 +79,27 @@ func (c *Connection) AutoMigrate() error {
 	err := c.DB.AutoMigrate(
 		&models.Job{},
 		&models.JobExecution{},
+		&models.JobWebhook{},
+		&models.TriggerSource{},
+		&models.AuditEntry{},
+		&models.PendingChange{},
+		&models.TeamChannel{},
+		&models.NotificationTemplate{},
+		&models.ReportTemplate{},
+		&models.Artifact{},
+		&models.JobRunClaim{},
+		&models.MissedOccurrence{},
+		&models.AlertRule{},
+		&models.RoleAssignment{},
+		&models.DatabaseConnection{},
+		&models.JobAcknowledgment{},
+		&models.Workflow{},
+		&models.WorkflowExecution{},
+		&models.JobExecutionLog{},
+		&models.JobRevision{},
+		&models.JobChange{},
+		&models.ReplicationState{},
+		&models.EmailSuppression{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
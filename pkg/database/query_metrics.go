@@ -0,0 +1,296 @@
+package database
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// queryStartKey is where the before callbacks leave the query's start time
+	queryStartKey = "query_metrics:start"
+	// maxTrackedQueries caps how many distinct statements get their own stats; the rest share one entry
+	maxTrackedQueries = 500
+	// otherQueries is the statement the queries beyond maxTrackedQueries are counted under
+	otherQueries = "(other)"
+)
+
+// queryLatencyBuckets are the upper bounds, in milliseconds, of the latency histogram buckets
+var queryLatencyBuckets = []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000}
+
+var (
+	// placeholderPattern matches the bound parameter placeholders of a statement
+	placeholderPattern = regexp.MustCompile(`\$\d+`)
+	// placeholderListPattern matches lists of placeholders, e.g. of an IN clause, which vary in length
+	placeholderListPattern = regexp.MustCompile(`\?(\s*,\s*\?)+`)
+)
+
+// LatencyBucket counts the queries that took at most UpperBoundMs
+// The last bucket holds the queries slower than every bound, up to the slowest one
+type LatencyBucket struct {
+	UpperBoundMs float64 `json:"le"`
+	Count        int64   `json:"count"`
+}
+
+// QueryStats summarizes the executions of one statement
+type QueryStats struct {
+	Query     string          `json:"query"`
+	Operation string          `json:"operation"`
+	Table     string          `json:"table"`
+	Count     int64           `json:"count"`
+	Errors    int64           `json:"errors"`
+	Slow      int64           `json:"slow"`
+	TotalMs   float64         `json:"total_ms"`
+	AverageMs float64         `json:"average_ms"`
+	P95Ms     float64         `json:"p95_ms"`
+	MaxMs     float64         `json:"max_ms"`
+	Histogram []LatencyBucket `json:"histogram"`
+}
+
+// QueryMetricsSummary totals the queries across every statement
+type QueryMetricsSummary struct {
+	Queries   int64   `json:"queries"`
+	Errors    int64   `json:"errors"`
+	Slow      int64   `json:"slow"`
+	AverageMs float64 `json:"average_ms"`
+}
+
+// queryStats is the running stats of one statement
+type queryStats struct {
+	operation string
+	table     string
+	count     int64
+	errors    int64
+	slow      int64
+	total     time.Duration
+	max       time.Duration
+	buckets   []int64
+}
+
+// QueryMetrics is a GORM plugin that records a latency histogram per statement and
+// logs statements slower than a threshold. Statements are recorded with their placeholders,
+// so bound parameters never reach the logs or the stats
+type QueryMetrics struct {
+	threshold time.Duration
+	mu        sync.Mutex
+	queries   map[string]*queryStats
+}
+
+// NewQueryMetrics creates a query metrics plugin logging queries slower than threshold
+func NewQueryMetrics(threshold time.Duration) *QueryMetrics {
+	return &QueryMetrics{
+		threshold: threshold,
+		queries:   make(map[string]*queryStats),
+	}
+}
+
+// Name returns the plugin's name
+func (m *QueryMetrics) Name() string {
+	return "query_metrics"
+}
+
+// Initialize registers callbacks timing every kind of statement
+func (m *QueryMetrics) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	register := []struct {
+		operation string
+		before    func(string, func(*gorm.DB)) error
+		after     func(string, func(*gorm.DB)) error
+	}{
+		{"create", callback.Create().Before("gorm:create").Register, callback.Create().After("gorm:create").Register},
+		{"query", callback.Query().Before("gorm:query").Register, callback.Query().After("gorm:query").Register},
+		{"update", callback.Update().Before("gorm:update").Register, callback.Update().After("gorm:update").Register},
+		{"delete", callback.Delete().Before("gorm:delete").Register, callback.Delete().After("gorm:delete").Register},
+		{"row", callback.Row().Before("gorm:row").Register, callback.Row().After("gorm:row").Register},
+		{"raw", callback.Raw().Before("gorm:raw").Register, callback.Raw().After("gorm:raw").Register},
+	}
+	for _, r := range register {
+		if err := r.before("query_metrics:before_"+r.operation, m.start); err != nil {
+			return err
+		}
+		if err := r.after("query_metrics:after_"+r.operation, m.finish(r.operation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// start records when a statement started
+func (m *QueryMetrics) start(db *gorm.DB) {
+	db.InstanceSet(queryStartKey, time.Now())
+}
+
+// finish records a statement's latency and logs it if it was slow
+func (m *QueryMetrics) finish(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		started, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		duration := time.Since(started)
+
+		sql := db.Statement.SQL.String()
+		if sql == "" {
+			return
+		}
+		query := normalizeQuery(sql)
+		failed := db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound)
+		slow := m.threshold > 0 && duration >= m.threshold
+
+		m.record(query, operation, db.Statement.Table, duration, failed, slow)
+
+		if slow {
+			logrus.WithFields(logrus.Fields{
+				"query":       query,
+				"operation":   operation,
+				"table":       db.Statement.Table,
+				"duration_ms": duration.Milliseconds(),
+				"rows":        db.Statement.RowsAffected,
+				"params":      len(db.Statement.Vars),
+			}).Warn("Slow database query")
+		}
+	}
+}
+
+// record adds one execution to a statement's stats
+func (m *QueryMetrics) record(query, operation, table string, duration time.Duration, failed, slow bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, ok := m.queries[query]
+	if !ok {
+		if len(m.queries) >= maxTrackedQueries {
+			query, operation, table = otherQueries, "", ""
+			stats = m.queries[query]
+		}
+		if stats == nil {
+			stats = &queryStats{
+				operation: operation,
+				table:     table,
+				buckets:   make([]int64, len(queryLatencyBuckets)+1),
+			}
+			m.queries[query] = stats
+		}
+	}
+
+	stats.count++
+	stats.total += duration
+	if duration > stats.max {
+		stats.max = duration
+	}
+	if failed {
+		stats.errors++
+	}
+	if slow {
+		stats.slow++
+	}
+
+	ms := durationMs(duration)
+	bucket := sort.SearchFloat64s(queryLatencyBuckets, ms)
+	stats.buckets[bucket]++
+}
+
+// Top returns the stats of the statements that took the most time in total, worst first
+func (m *QueryMetrics) Top(limit int) []QueryStats {
+	m.mu.Lock()
+	list := make([]QueryStats, 0, len(m.queries))
+	for query, stats := range m.queries {
+		list = append(list, stats.snapshot(query))
+	}
+	m.mu.Unlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].TotalMs > list[j].TotalMs
+	})
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
+
+// Summary totals the queries recorded across every statement
+func (m *QueryMetrics) Summary() QueryMetricsSummary {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var summary QueryMetricsSummary
+	var total time.Duration
+	for _, stats := range m.queries {
+		summary.Queries += stats.count
+		summary.Errors += stats.errors
+		summary.Slow += stats.slow
+		total += stats.total
+	}
+	if summary.Queries > 0 {
+		summary.AverageMs = durationMs(total / time.Duration(summary.Queries))
+	}
+	return summary
+}
+
+// Reset clears the recorded stats
+func (m *QueryMetrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queries = make(map[string]*queryStats)
+}
+
+// snapshot returns a copy of the stats
+func (s *queryStats) snapshot(query string) QueryStats {
+	stats := QueryStats{
+		Query:     query,
+		Operation: s.operation,
+		Table:     s.table,
+		Count:     s.count,
+		Errors:    s.errors,
+		Slow:      s.slow,
+		TotalMs:   durationMs(s.total),
+		MaxMs:     durationMs(s.max),
+		Histogram: make([]LatencyBucket, 0, len(s.buckets)),
+	}
+	if s.count > 0 {
+		stats.AverageMs = durationMs(s.total / time.Duration(s.count))
+	}
+
+	// p95 is reported as the upper bound of the bucket it falls in, capped by the slowest query
+	rank := (s.count*95 + 99) / 100
+	var seen int64
+	for i, count := range s.buckets {
+		bound := stats.MaxMs
+		if i < len(queryLatencyBuckets) {
+			bound = queryLatencyBuckets[i]
+		}
+		stats.Histogram = append(stats.Histogram, LatencyBucket{UpperBoundMs: bound, Count: count})
+
+		seen += count
+		if stats.P95Ms == 0 && s.count > 0 && seen >= rank {
+			stats.P95Ms = bound
+			if bound > stats.MaxMs {
+				stats.P95Ms = stats.MaxMs
+			}
+		}
+	}
+	return stats
+}
+
+// normalizeQuery replaces a statement's placeholders with ?, collapsing lists of them,
+// so statements differing only in the length of an IN list share their stats
+func normalizeQuery(sql string) string {
+	query := placeholderPattern.ReplaceAllString(sql, "?")
+	query = placeholderListPattern.ReplaceAllString(query, "?, ...")
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// durationMs returns a duration in fractional milliseconds
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,259 @@
+package tests
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/handlers"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+	"job-scheduler/internal/usertokens"
+)
+
+// testTokenSecret signs the bearer tokens of users in access control tests
+const testTokenSecret = "test-token-secret"
+
+// bearer returns an Authorization header value authenticating user
+func bearer(user string) string {
+	token, _ := usertokens.NewSigner(testTokenSecret).Issue(user, time.Now().Add(time.Hour))
+	return "Bearer " + token
+}
+
+// MockRoleAssignmentRepository is a mock implementation of RoleAssignmentRepository
+type MockRoleAssignmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleAssignmentRepository) Create(assignment *models.RoleAssignment) error {
+	args := m.Called(assignment)
+	return args.Error(0)
+}
+
+func (m *MockRoleAssignmentRepository) GetByID(id uuid.UUID) (*models.RoleAssignment, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.RoleAssignment), args.Error(1)
+}
+
+func (m *MockRoleAssignmentRepository) FindByUser(user string) (*models.RoleAssignment, error) {
+	args := m.Called(user)
+	return args.Get(0).(*models.RoleAssignment), args.Error(1)
+}
+
+func (m *MockRoleAssignmentRepository) GetAll() ([]models.RoleAssignment, error) {
+	args := m.Called()
+	return args.Get(0).([]models.RoleAssignment), args.Error(1)
+}
+
+func (m *MockRoleAssignmentRepository) Update(assignment *models.RoleAssignment) error {
+	args := m.Called(assignment)
+	return args.Error(0)
+}
+
+func (m *MockRoleAssignmentRepository) Delete(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// newRoleRepo returns a role assignment repository where alice is a viewer and oscar an operator
+func newRoleRepo() *MockRoleAssignmentRepository {
+	repo := new(MockRoleAssignmentRepository)
+	repo.On("FindByUser", "alice").Return(&models.RoleAssignment{User: "alice", Role: models.RoleViewer}, nil)
+	repo.On("FindByUser", "oscar").Return(&models.RoleAssignment{User: "oscar", Role: models.RoleOperator}, nil)
+	repo.On("FindByUser", mock.Anything).Return((*models.RoleAssignment)(nil), nil)
+	return repo
+}
+
+func newAccessControlledRouter(repo *MockRoleAssignmentRepository, cfg config.RBACConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	access := handlers.NewAccessControl(services.NewRoleService(repo, cfg), cfg)
+	ok := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) }
+	for _, version := range []string{"/api/v1", "/api/v2"} {
+		api := router.Group(version, access.Middleware())
+		api.GET("/health", ok)
+		api.GET("/jobs", ok)
+		api.POST("/jobs", ok)
+		api.POST("/jobs/preview", ok)
+		api.DELETE("/jobs/:id", ok)
+		api.POST("/jobs/:id/pause", ok)
+		api.POST("/executions/:id/cancel", ok)
+		api.GET("/role-assignments", ok)
+	}
+	return router
+}
+
+func serve(router *gin.Engine, method, path, user string) int {
+	req := httptest.NewRequest(method, path, nil)
+	if user != "" {
+		req.Header.Set("Authorization", bearer(user))
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestAccessControl_Roles(t *testing.T) {
+	router := newAccessControlledRouter(newRoleRepo(), config.RBACConfig{Enabled: true, DefaultRole: "viewer", Admins: []string{"root"}, TokenSecret: testTokenSecret})
+	jobPath := "/api/v1/jobs/" + uuid.NewString()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		user   string
+		want   int
+	}{
+		{"health is public", http.MethodGet, "/api/v1/health", "", http.StatusOK},
+		{"anonymous", http.MethodGet, "/api/v1/jobs", "", http.StatusUnauthorized},
+		{"viewer lists jobs", http.MethodGet, "/api/v1/jobs", "alice", http.StatusOK},
+		{"viewer can't pause", http.MethodPost, jobPath + "/pause", "alice", http.StatusForbidden},
+		{"unassigned user gets the default role", http.MethodGet, "/api/v2/jobs", "nobody", http.StatusOK},
+		{"operator pauses", http.MethodPost, jobPath + "/pause", "oscar", http.StatusOK},
+		{"operator cancels runs", http.MethodPost, "/api/v2/executions/" + uuid.NewString() + "/cancel", "oscar", http.StatusOK},
+		{"operator can't create", http.MethodPost, "/api/v2/jobs", "oscar", http.StatusForbidden},
+		{"operator previews", http.MethodPost, "/api/v1/jobs/preview", "oscar", http.StatusOK},
+		{"viewer can't preview", http.MethodPost, "/api/v1/jobs/preview", "alice", http.StatusForbidden},
+		{"operator can't delete", http.MethodDelete, jobPath, "oscar", http.StatusForbidden},
+		{"operator can't read roles", http.MethodGet, "/api/v1/role-assignments", "oscar", http.StatusForbidden},
+		{"configured admin creates", http.MethodPost, "/api/v1/jobs", "root", http.StatusOK},
+		{"configured admin deletes", http.MethodDelete, jobPath, "root", http.StatusOK},
+		{"unknown route", http.MethodGet, "/api/v1/nope", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, serve(router, tt.method, tt.path, tt.user))
+		})
+	}
+}
+
+func TestAccessControl_NoDefaultRole(t *testing.T) {
+	router := newAccessControlledRouter(newRoleRepo(), config.RBACConfig{Enabled: true, TokenSecret: testTokenSecret})
+
+	assert.Equal(t, http.StatusForbidden, serve(router, http.MethodGet, "/api/v1/jobs", "nobody"))
+	assert.Equal(t, http.StatusOK, serve(router, http.MethodGet, "/api/v1/jobs", "alice"))
+}
+
+func TestAccessControl_Disabled(t *testing.T) {
+	repo := newRoleRepo()
+	router := newAccessControlledRouter(repo, config.RBACConfig{})
+
+	assert.Equal(t, http.StatusOK, serve(router, http.MethodDelete, "/api/v1/jobs/"+uuid.NewString(), ""))
+	repo.AssertNotCalled(t, "FindByUser", mock.Anything)
+}
+
+func TestAccessControl_OperatorUpdatesOnlyOwnedJobs(t *testing.T) {
+	// Setup - a job owned by someone else
+	gin.SetMode(gin.TestMode)
+	jobRepo := new(MockJobRepository)
+	job := &models.Job{ID: uuid.New(), Name: "nightly", Owner: "olivia"}
+	jobRepo.On("GetByID", job.ID).Return(job, nil)
+
+	jobService := services.NewJobService(jobRepo)
+	changeControl := services.NewChangeControlService(jobService, jobRepo, new(MockPendingChangeRepository), new(MockAuditRepository), false)
+	cfg := config.RBACConfig{Enabled: true, TokenSecret: testTokenSecret}
+
+	router := gin.New()
+	api := router.Group("/api/v1", handlers.NewAccessControl(services.NewRoleService(newRoleRepo(), cfg), cfg).Middleware())
+	handlers.NewJobHandler(jobService, changeControl).RegisterRoutes(api)
+
+	// Execute
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/jobs/"+job.ID.String(), strings.NewReader(`{"description":"x"}`))
+	req.Header.Set("Authorization", bearer("oscar"))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), handlers.ErrNotJobOwner.Error())
+	jobRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestAccessControl_IgnoresUnauthenticatedUsers(t *testing.T) {
+	router := newAccessControlledRouter(newRoleRepo(), config.RBACConfig{Enabled: true, Admins: []string{"root"}, TokenSecret: testTokenSecret})
+	expired, _ := usertokens.NewSigner(testTokenSecret).Issue("root", time.Now().Add(-time.Minute))
+	forged, _ := usertokens.NewSigner("another-secret").Issue("root", time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+	}{
+		{"X-User naming an admin", handlers.ActorHeader, "root"},
+		{"token signed with another secret", "Authorization", "Bearer " + forged},
+		{"expired token", "Authorization", "Bearer " + expired},
+		{"malformed token", "Authorization", "Bearer root"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/jobs", nil)
+			req.Header.Set(tt.header, tt.value)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+	}
+}
+
+func TestAccessControl_AuthenticatesClientCertificates(t *testing.T) {
+	// Setup - no token secret, so only client certificates are accepted
+	router := newAccessControlledRouter(newRoleRepo(), config.RBACConfig{Enabled: true, Admins: []string{"root"}})
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "root"}}
+
+	// Execute
+	verified := httptest.NewRequest(http.MethodPost, "/api/v1/jobs", nil)
+	verified.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	unverified := httptest.NewRequest(http.MethodPost, "/api/v1/jobs", nil)
+	unverified.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+
+	// Assert
+	for req, want := range map[*http.Request]int{verified: http.StatusOK, unverified: http.StatusUnauthorized} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, want, w.Code)
+	}
+}
+
+func TestAccessControl_OperatorPausesOnlyOwnedJobs(t *testing.T) {
+	// Setup - a job owned by someone else
+	gin.SetMode(gin.TestMode)
+	jobRepo := new(MockJobRepository)
+	job := &models.Job{ID: uuid.New(), Name: "nightly", Owner: "olivia"}
+	jobRepo.On("GetByID", job.ID).Return(job, nil)
+
+	jobService := services.NewJobService(jobRepo)
+	changeControl := services.NewChangeControlService(jobService, jobRepo, new(MockPendingChangeRepository), new(MockAuditRepository), false)
+	cfg := config.RBACConfig{Enabled: true, TokenSecret: testTokenSecret}
+
+	router := gin.New()
+	api := router.Group("/api/v1", handlers.NewAccessControl(services.NewRoleService(newRoleRepo(), cfg), cfg).Middleware())
+	handlers.NewJobHandler(jobService, changeControl).RegisterRoutes(api)
+
+	for _, action := range []string{"pause", "resume"} {
+		// Execute
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/jobs/"+job.ID.String()+"/"+action, strings.NewReader(`{"reason":"upstream outage"}`))
+		req.Header.Set("Authorization", bearer("oscar"))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, w.Code, action)
+		assert.Contains(t, w.Body.String(), handlers.ErrNotJobOwner.Error())
+	}
+	jobRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
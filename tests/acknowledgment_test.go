@@ -0,0 +1,387 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/scheduler"
+	"job-scheduler/internal/services"
+)
+
+// MockJobAcknowledgmentRepository is a mock implementation of JobAcknowledgmentRepository
+type MockJobAcknowledgmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockJobAcknowledgmentRepository) Create(acknowledgment *models.JobAcknowledgment) error {
+	args := m.Called(acknowledgment)
+	return args.Error(0)
+}
+
+func (m *MockJobAcknowledgmentRepository) GetByID(id uuid.UUID) (*models.JobAcknowledgment, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.JobAcknowledgment), args.Error(1)
+}
+
+func (m *MockJobAcknowledgmentRepository) GetByJobID(jobID uuid.UUID) ([]models.JobAcknowledgment, error) {
+	args := m.Called(jobID)
+	return args.Get(0).([]models.JobAcknowledgment), args.Error(1)
+}
+
+func (m *MockJobAcknowledgmentRepository) GetActive(now time.Time) ([]models.JobAcknowledgment, error) {
+	args := m.Called(now)
+	return args.Get(0).([]models.JobAcknowledgment), args.Error(1)
+}
+
+func (m *MockJobAcknowledgmentRepository) Update(acknowledgment *models.JobAcknowledgment) error {
+	args := m.Called(acknowledgment)
+	return args.Error(0)
+}
+
+// expiresIn returns the time d from now
+func expiresIn(d time.Duration) *time.Time {
+	at := time.Now().Add(d)
+	return &at
+}
+
+func TestAcknowledgmentService_AcknowledgeJob(t *testing.T) {
+	// Setup
+	job := &models.Job{ID: uuid.New(), Name: "Vendor sync"}
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetByID", job.ID).Return(job, nil)
+	mockRepo := new(MockJobAcknowledgmentRepository)
+	mockRepo.On("Create", mock.AnythingOfType("*models.JobAcknowledgment")).Return(nil)
+	mockAudit := new(MockAuditRepository)
+	mockAudit.On("Create", mock.MatchedBy(func(entry *models.AuditEntry) bool {
+		return entry.EntityID == job.ID && entry.Action == services.AuditActionJobAcknowledged &&
+			entry.Actor == "alice" && entry.Details["scope"] == "alerts"
+	})).Return(nil)
+
+	service := services.NewAcknowledgmentService(mockRepo, mockJobRepo, mockAudit)
+
+	// Execute
+	acknowledgment, err := service.AcknowledgeJob(job.ID, &models.CreateJobAcknowledgmentRequest{
+		Note:     "  Known vendor outage  ",
+		Duration: "4h",
+	}, "alice")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Known vendor outage", acknowledgment.Note)
+	assert.Equal(t, "alice", acknowledgment.AcknowledgedBy)
+	assert.Equal(t, models.AcknowledgmentScopeAlerts, acknowledgment.Scope)
+	require.NotNil(t, acknowledgment.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(4*time.Hour), *acknowledgment.ExpiresAt, time.Minute)
+	assert.True(t, acknowledgment.IsActive(time.Now()))
+	mockAudit.AssertExpectations(t)
+
+	// Without an expiry the acknowledgment lasts until it is cleared
+	acknowledgment, err = service.AcknowledgeJob(job.ID, &models.CreateJobAcknowledgmentRequest{Note: "Decommissioned vendor"}, "alice")
+	require.NoError(t, err)
+	assert.Nil(t, acknowledgment.ExpiresAt)
+	assert.True(t, acknowledgment.IsActive(time.Now().AddDate(1, 0, 0)))
+}
+
+func TestAcknowledgmentService_AcknowledgeJobValidation(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	testCases := []struct {
+		name  string
+		req   models.CreateJobAcknowledgmentRequest
+		actor string
+	}{
+		{"missing user", models.CreateJobAcknowledgmentRequest{Note: "outage", Duration: "1h"}, ""},
+		{"missing note", models.CreateJobAcknowledgmentRequest{Note: " ", Duration: "1h"}, "alice"},
+		{"unknown scope", models.CreateJobAcknowledgmentRequest{Note: "outage", Duration: "1h", Scope: "pages"}, "alice"},
+		{"both expiries", models.CreateJobAcknowledgmentRequest{Note: "outage", Duration: "1h", ExpiresAt: &future}, "alice"},
+		{"invalid duration", models.CreateJobAcknowledgmentRequest{Note: "outage", Duration: "soon"}, "alice"},
+		{"expired", models.CreateJobAcknowledgmentRequest{Note: "outage", ExpiresAt: &past}, "alice"},
+		{"too long", models.CreateJobAcknowledgmentRequest{Note: "outage", Duration: "200h"}, "alice"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := services.NewAcknowledgmentService(new(MockJobAcknowledgmentRepository), new(MockJobRepository), nil)
+			_, err := service.AcknowledgeJob(uuid.New(), &tc.req, tc.actor)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAcknowledgmentService_ClearAcknowledgment(t *testing.T) {
+	// Setup
+	jobID := uuid.New()
+	active := &models.JobAcknowledgment{ID: uuid.New(), JobID: jobID, Note: "outage", Scope: models.AcknowledgmentScopeAlerts, ExpiresAt: expiresIn(time.Hour)}
+	expired := &models.JobAcknowledgment{ID: uuid.New(), JobID: jobID, Note: "outage", Scope: models.AcknowledgmentScopeAlerts, ExpiresAt: expiresIn(-time.Hour)}
+	mockRepo := new(MockJobAcknowledgmentRepository)
+	mockRepo.On("GetByID", active.ID).Return(active, nil)
+	mockRepo.On("GetByID", expired.ID).Return(expired, nil)
+	mockRepo.On("Update", active).Return(nil)
+
+	service := services.NewAcknowledgmentService(mockRepo, new(MockJobRepository), nil)
+
+	// Execute
+	cleared, err := service.ClearAcknowledgment(jobID, active.ID, "bob")
+
+	// Assert - cleared acknowledgments are kept, marked as cleared
+	require.NoError(t, err)
+	assert.Equal(t, "bob", *cleared.ClearedBy)
+	assert.False(t, cleared.IsActive(time.Now()))
+
+	_, err = service.ClearAcknowledgment(jobID, expired.ID, "bob")
+	assert.ErrorIs(t, err, services.ErrAcknowledgmentInactive)
+
+	// An acknowledgment of another job isn't found
+	_, err = service.ClearAcknowledgment(uuid.New(), active.ID, "bob")
+	assert.Error(t, err)
+	mockRepo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestAcknowledgmentService_GetActiveAcknowledgmentsKeepsLatestExpiry(t *testing.T) {
+	// Setup - one job acknowledged twice, another silenced until further notice
+	jobID, silencedID := uuid.New(), uuid.New()
+	later := models.JobAcknowledgment{ID: uuid.New(), JobID: jobID, Note: "extended", Scope: models.AcknowledgmentScopeAlerts, ExpiresAt: expiresIn(3 * time.Hour)}
+	earlier := models.JobAcknowledgment{ID: uuid.New(), JobID: jobID, Note: "first", Scope: models.AcknowledgmentScopeAlerts, ExpiresAt: expiresIn(time.Hour)}
+	silenced := models.JobAcknowledgment{ID: uuid.New(), JobID: silencedID, Note: "migration", Scope: models.AcknowledgmentScopeNotifications}
+	mockRepo := new(MockJobAcknowledgmentRepository)
+	mockRepo.On("GetActive", mock.AnythingOfType("time.Time")).Return([]models.JobAcknowledgment{earlier, silenced, later}, nil)
+
+	service := services.NewAcknowledgmentService(mockRepo, new(MockJobRepository), nil)
+
+	// Execute
+	all, err := service.GetActiveAcknowledgments()
+	require.NoError(t, err)
+	alerts, err := service.GetActiveAcknowledgments(models.AcknowledgmentScopeAlerts)
+	require.NoError(t, err)
+
+	// Assert - each job's acknowledgment expiring last, in the scopes asked for
+	assert.Len(t, all, 2)
+	assert.Equal(t, "extended", all[jobID].Note)
+	assert.Equal(t, "migration", all[silencedID].Note)
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, "extended", alerts[jobID].Note)
+}
+
+func TestAcknowledgmentService_SilenceJob(t *testing.T) {
+	// Setup
+	job := &models.Job{ID: uuid.New(), Name: "Nightly export"}
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetByID", job.ID).Return(job, nil)
+	mockRepo := new(MockJobAcknowledgmentRepository)
+	mockRepo.On("Create", mock.AnythingOfType("*models.JobAcknowledgment")).Return(nil)
+	mockAudit := new(MockAuditRepository)
+	mockAudit.On("Create", mock.MatchedBy(func(entry *models.AuditEntry) bool {
+		return entry.EntityID == job.ID && entry.Action == services.AuditActionJobAcknowledged &&
+			entry.Details["scope"] == "notifications" && entry.Details["note"] == "Database maintenance"
+	})).Return(nil)
+
+	service := services.NewAcknowledgmentService(mockRepo, mockJobRepo, mockAudit)
+
+	// Execute
+	silence, err := service.SilenceJob(job.ID, &models.CreateJobSilenceRequest{Duration: "2h", Reason: " Database maintenance "}, "alice")
+
+	// Assert - a silence is an acknowledgment of the job's notifications for the duration
+	require.NoError(t, err)
+	assert.Equal(t, models.AcknowledgmentScopeNotifications, silence.Scope)
+	assert.Equal(t, "Database maintenance", silence.Note)
+	require.NotNil(t, silence.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), *silence.ExpiresAt, time.Minute)
+	mockAudit.AssertExpectations(t)
+
+	// A silence needs a duration
+	_, err = service.SilenceJob(job.ID, &models.CreateJobSilenceRequest{Reason: "maintenance"}, "alice")
+	assert.Error(t, err)
+}
+
+func TestAcknowledgmentService_ClearScopeLiftsSilences(t *testing.T) {
+	// Setup - a silence in effect, an expired one, and an acknowledgment of the job's alerts
+	jobID := uuid.New()
+	active := models.JobAcknowledgment{ID: uuid.New(), JobID: jobID, Note: "maintenance", Scope: models.AcknowledgmentScopeNotifications, ExpiresAt: expiresIn(time.Hour)}
+	expired := models.JobAcknowledgment{ID: uuid.New(), JobID: jobID, Note: "old", Scope: models.AcknowledgmentScopeNotifications, ExpiresAt: expiresIn(-time.Hour)}
+	alerts := models.JobAcknowledgment{ID: uuid.New(), JobID: jobID, Note: "outage", Scope: models.AcknowledgmentScopeAlerts, ExpiresAt: expiresIn(time.Hour)}
+	mockRepo := new(MockJobAcknowledgmentRepository)
+	mockRepo.On("GetByJobID", jobID).Return([]models.JobAcknowledgment{active, expired, alerts}, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*models.JobAcknowledgment")).Return(nil)
+	mockAudit := new(MockAuditRepository)
+	mockAudit.On("Create", mock.MatchedBy(func(entry *models.AuditEntry) bool {
+		return entry.Action == services.AuditActionJobAcknowledgmentCleared && entry.Actor == "bob"
+	})).Return(nil)
+
+	service := services.NewAcknowledgmentService(mockRepo, new(MockJobRepository), mockAudit)
+
+	// Execute
+	lifted, err := service.ClearScope(jobID, models.AcknowledgmentScopeNotifications, "bob")
+
+	// Assert - only the silence in effect is lifted
+	require.NoError(t, err)
+	if assert.Len(t, lifted, 1) {
+		assert.Equal(t, active.ID, lifted[0].ID)
+		assert.Equal(t, "bob", *lifted[0].ClearedBy)
+		assert.False(t, lifted[0].IsActive(time.Now()))
+	}
+	mockRepo.AssertNumberOfCalls(t, "Update", 1)
+	mockAudit.AssertNumberOfCalls(t, "Create", 1)
+
+	// Lifting again finds nothing to lift
+	mockRepo.ExpectedCalls = nil
+	mockRepo.On("GetByJobID", jobID).Return([]models.JobAcknowledgment{expired, alerts}, nil)
+	_, err = service.ClearScope(jobID, models.AcknowledgmentScopeNotifications, "bob")
+	assert.ErrorIs(t, err, services.ErrJobNotAcknowledged)
+}
+
+func TestAcknowledgmentService_ListActiveAcknowledgmentsSoonestFirst(t *testing.T) {
+	// Setup
+	untilCleared := models.JobAcknowledgment{ID: uuid.New(), JobID: uuid.New(), Scope: models.AcknowledgmentScopeNotifications}
+	later := models.JobAcknowledgment{ID: uuid.New(), JobID: uuid.New(), Scope: models.AcknowledgmentScopeNotifications, ExpiresAt: expiresIn(3 * time.Hour)}
+	sooner := models.JobAcknowledgment{ID: uuid.New(), JobID: uuid.New(), Scope: models.AcknowledgmentScopeNotifications, ExpiresAt: expiresIn(time.Hour)}
+	alerts := models.JobAcknowledgment{ID: uuid.New(), JobID: uuid.New(), Scope: models.AcknowledgmentScopeAlerts, ExpiresAt: expiresIn(time.Minute)}
+	mockRepo := new(MockJobAcknowledgmentRepository)
+	mockRepo.On("GetActive", mock.AnythingOfType("time.Time")).Return([]models.JobAcknowledgment{untilCleared, later, alerts, sooner}, nil)
+
+	service := services.NewAcknowledgmentService(mockRepo, new(MockJobRepository), nil)
+
+	// Execute
+	silences, err := service.ListActiveAcknowledgments(models.AcknowledgmentScopeNotifications)
+
+	// Assert - the silences, those without an expiry last
+	require.NoError(t, err)
+	if assert.Len(t, silences, 3) {
+		assert.Equal(t, sooner.ID, silences[0].ID)
+		assert.Equal(t, later.ID, silences[1].ID)
+		assert.Equal(t, untilCleared.ID, silences[2].ID)
+	}
+}
+
+// stubAcknowledgmentLookup returns fixed active acknowledgments, filtered by scope
+type stubAcknowledgmentLookup []models.JobAcknowledgment
+
+func (s stubAcknowledgmentLookup) GetActiveAcknowledgments(scopes ...models.AcknowledgmentScope) (map[uuid.UUID]models.JobAcknowledgment, error) {
+	active := make(map[uuid.UUID]models.JobAcknowledgment)
+	for _, acknowledgment := range s {
+		for _, scope := range scopes {
+			if acknowledgment.Scope == scope {
+				active[acknowledgment.JobID] = acknowledgment
+			}
+		}
+	}
+	return active, nil
+}
+
+func TestJobExecutor_HoldsBackFailureNotificationsOfAcknowledgedJobs(t *testing.T) {
+	// Setup - jobs of an unknown type fail without running anything
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second}}
+	var failed int
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Run(func(args mock.Arguments) {
+		if args.Get(0).(*models.JobExecution).Status == models.ExecutionStatusFailed {
+			failed++
+		}
+	}).Return(nil)
+	silenced := &models.Job{ID: uuid.New(), Name: "Silenced", JobType: "unknown"}
+	acknowledged := &models.Job{ID: uuid.New(), Name: "Acknowledged", JobType: "unknown"}
+	other := &models.Job{ID: uuid.New(), Name: "Other", JobType: "unknown"}
+
+	notifier := &recordingNotifier{}
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	executor.SetNotifier(notifier)
+	executor.SetAcknowledgments(stubAcknowledgmentLookup{
+		{JobID: silenced.ID, AcknowledgedBy: "alice", Scope: models.AcknowledgmentScopeNotifications, ExpiresAt: expiresIn(time.Hour)},
+		{JobID: acknowledged.ID, AcknowledgedBy: "bob", Scope: models.AcknowledgmentScopeAlerts},
+	})
+
+	// Execute
+	assert.Error(t, executor.ExecuteJob(silenced))
+	assert.Error(t, executor.ExecuteJob(acknowledged))
+	assert.Error(t, executor.ExecuteJob(other))
+
+	// Assert - every failure is recorded, only the other job's is notified
+	assert.Equal(t, 3, failed)
+	if assert.Len(t, notifier.received, 1) {
+		assert.Equal(t, other.ID, notifier.received[0].Job.ID)
+		assert.Contains(t, notifier.received[0].Title, fmt.Sprintf("Job failed: %s", other.Name))
+	}
+}
+
+func TestAlertService_SuppressesAcknowledgedJobs(t *testing.T) {
+	// Setup
+	job := models.Job{ID: uuid.New(), Name: "vendor-sync"}
+	rule := models.AlertRule{ID: uuid.New(), Name: "jobs-failing", Metric: models.AlertMetricFailureRate,
+		Threshold: 50, Window: 2, Severity: "warning", IsActive: true}
+	failed := []models.JobExecution{
+		{JobID: job.ID, Status: models.ExecutionStatusFailed, StartedAt: time.Now()},
+		{JobID: job.ID, Status: models.ExecutionStatusFailed, StartedAt: time.Now()},
+	}
+
+	mockRuleRepo := new(MockAlertRuleRepository)
+	mockRuleRepo.On("GetActive").Return([]models.AlertRule{rule}, nil)
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetActiveJobs").Return([]models.Job{job}, nil)
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("GetRecentFinished", job.ID, models.MaxAlertRuleWindow).Return(failed, nil)
+	acknowledgment := models.JobAcknowledgment{ID: uuid.New(), JobID: job.ID, Note: "vendor outage, ack until 6pm",
+		Scope: models.AcknowledgmentScopeAlerts, ExpiresAt: expiresIn(time.Hour)}
+	mockAckRepo := new(MockJobAcknowledgmentRepository)
+	mockAckRepo.On("GetActive", mock.AnythingOfType("time.Time")).Return([]models.JobAcknowledgment{acknowledgment}, nil).Once()
+	mockAckRepo.On("GetActive", mock.AnythingOfType("time.Time")).Return([]models.JobAcknowledgment{}, nil)
+
+	service := services.NewAlertService(mockRuleRepo, mockJobRepo, mockExecutionRepo, nil, config.AlertsConfig{})
+	service.SetAcknowledgments(services.NewAcknowledgmentService(mockAckRepo, mockJobRepo, nil))
+
+	// Execute - the job is acknowledged
+	firing, err := service.EvaluateAlerts()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, firing)
+	assert.Empty(t, service.GetFiringAlerts())
+
+	// Execute - the acknowledgment has ended
+	firing, err = service.EvaluateAlerts()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, firing)
+	assert.Len(t, service.GetFiringAlerts(), 1)
+}
+
+func TestDashboardService_GetFailingJobsShowsAcknowledgments(t *testing.T) {
+	// Setup
+	acknowledgedJob, otherJob := uuid.New(), uuid.New()
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("GetFailingStreaks").Return([]models.FailingJob{
+		{JobID: acknowledgedJob, JobName: "Vendor sync", StreakLength: 4},
+		{JobID: otherJob, JobName: "Nightly ETL", StreakLength: 2},
+	}, nil)
+	mockAckRepo := new(MockJobAcknowledgmentRepository)
+	mockAckRepo.On("GetActive", mock.AnythingOfType("time.Time")).Return([]models.JobAcknowledgment{
+		{ID: uuid.New(), JobID: acknowledgedJob, Note: "known issue", AcknowledgedBy: "alice", Scope: models.AcknowledgmentScopeAlerts, ExpiresAt: expiresIn(time.Hour)},
+		{ID: uuid.New(), JobID: otherJob, Note: "maintenance", AcknowledgedBy: "bob", Scope: models.AcknowledgmentScopeNotifications, ExpiresAt: expiresIn(time.Hour)},
+	}, nil)
+
+	service := services.NewDashboardService(new(MockJobRepository), mockExecutionRepo, nil)
+	service.SetAcknowledgments(services.NewAcknowledgmentService(mockAckRepo, new(MockJobRepository), nil))
+
+	// Execute
+	jobs, err := service.GetFailingJobs()
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	if assert.NotNil(t, jobs[0].Acknowledgment) {
+		assert.Equal(t, "known issue", jobs[0].Acknowledgment.Note)
+	}
+	// A silence isn't shown as an acknowledgment of the failure
+	assert.Nil(t, jobs[1].Acknowledgment)
+}
@@ -0,0 +1,189 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// MockAlertRuleRepository is a mock implementation of AlertRuleRepository
+type MockAlertRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockAlertRuleRepository) Create(rule *models.AlertRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockAlertRuleRepository) GetByID(id uuid.UUID) (*models.AlertRule, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AlertRule), args.Error(1)
+}
+
+func (m *MockAlertRuleRepository) FindByName(name string) (*models.AlertRule, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AlertRule), args.Error(1)
+}
+
+func (m *MockAlertRuleRepository) GetAll() ([]models.AlertRule, error) {
+	args := m.Called()
+	return args.Get(0).([]models.AlertRule), args.Error(1)
+}
+
+func (m *MockAlertRuleRepository) GetActive() ([]models.AlertRule, error) {
+	args := m.Called()
+	return args.Get(0).([]models.AlertRule), args.Error(1)
+}
+
+func (m *MockAlertRuleRepository) Update(rule *models.AlertRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockAlertRuleRepository) Delete(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestAlertService_CreateAlertRule(t *testing.T) {
+	// Setup
+	mockRuleRepo := new(MockAlertRuleRepository)
+	mockRuleRepo.On("FindByName", "etl-failing").Return(nil, nil)
+	mockRuleRepo.On("Create", mock.AnythingOfType("*models.AlertRule")).Return(nil)
+
+	service := services.NewAlertService(mockRuleRepo, new(MockJobRepository), new(MockJobExecutionRepository),
+		http.DefaultClient, config.AlertsConfig{})
+
+	// Execute
+	rule, err := service.CreateAlertRule(&models.CreateAlertRuleRequest{
+		Name:      "etl-failing",
+		Metric:    models.AlertMetricFailureRate,
+		Threshold: 25,
+		Team:      "data",
+	})
+
+	// Assert - window and severity take their defaults
+	assert.NoError(t, err)
+	assert.Equal(t, models.DefaultAlertRuleWindow, rule.Window)
+	assert.Equal(t, models.DefaultAlertSeverity, rule.Severity)
+	assert.True(t, rule.IsActive)
+	mockRuleRepo.AssertExpectations(t)
+}
+
+func TestAlertService_CreateAlertRule_Invalid(t *testing.T) {
+	service := services.NewAlertService(new(MockAlertRuleRepository), new(MockJobRepository), new(MockJobExecutionRepository),
+		http.DefaultClient, config.AlertsConfig{})
+
+	tests := []struct {
+		name string
+		req  models.CreateAlertRuleRequest
+	}{
+		{"unknown metric", models.CreateAlertRuleRequest{Name: "a", Metric: "memory", Threshold: 1}},
+		{"window too large", models.CreateAlertRuleRequest{Name: "a", Metric: models.AlertMetricDuration, Window: models.MaxAlertRuleWindow + 1}},
+		{"failure rate over 100", models.CreateAlertRuleRequest{Name: "a", Metric: models.AlertMetricFailureRate, Threshold: 100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreateAlertRule(&tt.req)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAlertService_EvaluateAlerts_FiresAndResolves(t *testing.T) {
+	// Setup - an Alertmanager that records each batch of alerts it receives
+	var batches [][]models.Alert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/alerts", r.URL.Path)
+		var alerts []models.Alert
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&alerts))
+		batches = append(batches, alerts)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	job := models.Job{ID: uuid.New(), Name: "nightly-etl", Team: "data", RunbookURL: "https://runbooks/etl"}
+	otherTeam := models.Job{ID: uuid.New(), Name: "billing", Team: "billing"}
+	rule := models.AlertRule{
+		ID:        uuid.New(),
+		Name:      "data-jobs-failing",
+		Metric:    models.AlertMetricFailureRate,
+		Threshold: 50,
+		Window:    4,
+		Team:      "data",
+		Severity:  "critical",
+		IsActive:  true,
+	}
+
+	run := func(status models.ExecutionStatus) models.JobExecution {
+		return models.JobExecution{JobID: job.ID, Status: status, StartedAt: time.Now()}
+	}
+	failing := []models.JobExecution{
+		run(models.ExecutionStatusFailed), run(models.ExecutionStatusFailed), run(models.ExecutionStatusFailed),
+		run(models.ExecutionStatusCompleted),
+		// Outside the rule's window
+		run(models.ExecutionStatusCompleted), run(models.ExecutionStatusCompleted),
+	}
+	healthy := []models.JobExecution{run(models.ExecutionStatusCompleted), run(models.ExecutionStatusCompleted)}
+
+	mockRuleRepo := new(MockAlertRuleRepository)
+	mockRuleRepo.On("GetActive").Return([]models.AlertRule{rule}, nil)
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetActiveJobs").Return([]models.Job{job, otherTeam}, nil)
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("GetRecentFinished", job.ID, models.MaxAlertRuleWindow).Return(failing, nil).Once()
+	mockExecutionRepo.On("GetRecentFinished", job.ID, models.MaxAlertRuleWindow).Return(healthy, nil).Once()
+
+	service := services.NewAlertService(mockRuleRepo, mockJobRepo, mockExecutionRepo,
+		server.Client(), config.AlertsConfig{AlertmanagerURL: server.URL})
+
+	// Execute - the job fails 3 of its last 4 runs
+	firing, err := service.EvaluateAlerts()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, firing)
+	if assert.Len(t, batches, 1) && assert.Len(t, batches[0], 1) {
+		alert := batches[0][0]
+		assert.Equal(t, "data-jobs-failing", alert.Labels["alertname"])
+		assert.Equal(t, job.ID.String(), alert.Labels["job_id"])
+		assert.Equal(t, "critical", alert.Labels["severity"])
+		assert.Equal(t, "data", alert.Labels["team"])
+		assert.Equal(t, "75.00", alert.Annotations["value"])
+		assert.Equal(t, "https://runbooks/etl", alert.Annotations["runbook_url"])
+		assert.Nil(t, alert.EndsAt)
+	}
+	assert.Len(t, service.GetFiringAlerts(), 1)
+
+	// Execute - the job recovers
+	firing, err = service.EvaluateAlerts()
+
+	// Assert - the alert is sent once more as resolved, then forgotten
+	assert.NoError(t, err)
+	assert.Equal(t, 0, firing)
+	if assert.Len(t, batches, 2) && assert.Len(t, batches[1], 1) {
+		assert.NotNil(t, batches[1][0].EndsAt)
+		assert.Equal(t, batches[0][0].StartsAt.Unix(), batches[1][0].StartsAt.Unix())
+	}
+	assert.Empty(t, service.GetFiringAlerts())
+	mockExecutionRepo.AssertExpectations(t)
+	mockExecutionRepo.AssertNotCalled(t, "GetRecentFinished", otherTeam.ID, mock.Anything)
+}
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"job-scheduler/internal/handlers"
+)
+
+// openAPIDocument is the part of an OpenAPI document the tests look at
+type openAPIDocument struct {
+	OpenAPI string `json:"openapi"`
+	Paths   map[string]map[string]struct {
+		OperationID  string                   `json:"operationId"`
+		Parameters   []map[string]interface{} `json:"parameters"`
+		RequestBody  map[string]interface{}   `json:"requestBody"`
+		Responses    map[string]interface{}   `json:"responses"`
+		Security     *[]interface{}           `json:"security"`
+		RequiredRole string                   `json:"x-required-role"`
+	} `json:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Required   []string               `json:"required"`
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+func newDocsRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	v1 := router.Group("/api/v1")
+	handlers.NewJobHandler(nil, nil).RegisterRoutes(v1)
+	handlers.NewJobManifestHandler(nil, nil).RegisterRoutes(v1)
+	handlers.NewJobTriggerHandler(nil, nil).RegisterRoutes(v1)
+	handlers.NewExecutionHandler(nil).RegisterRoutes(v1)
+	handlers.NewDocsHandler(router.Routes).RegisterRoutes(v1)
+	handlers.NewJobHandlerV2(nil, nil).RegisterRoutes(router.Group("/api/v2"))
+	return router
+}
+
+func getOpenAPI(t *testing.T, router *gin.Engine) openAPIDocument {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var doc openAPIDocument
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	return doc
+}
+
+func TestDocsHandler_DescribesEveryRoute(t *testing.T) {
+	router := newDocsRouter()
+
+	doc := getOpenAPI(t, router)
+
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	operationIDs := make(map[string]bool)
+	for _, route := range router.Routes() {
+		path := route.Path
+		for _, param := range []string{":id", ":name"} {
+			path = strings.Replace(path, param, "{"+param[1:]+"}", 1)
+		}
+		operation, ok := doc.Paths[path][strings.ToLower(route.Method)]
+		if assert.True(t, ok, "%s %s is not documented", route.Method, route.Path) {
+			assert.NotContains(t, operationIDs, operation.OperationID)
+			operationIDs[operation.OperationID] = true
+			assert.Contains(t, operation.Responses, "default")
+		}
+	}
+
+	// Path parameters are declared and the main routes describe their bodies
+	getJob := doc.Paths["/api/v1/jobs/{id}"]["get"]
+	if assert.Len(t, getJob.Parameters, 1) {
+		assert.Equal(t, "id", getJob.Parameters[0]["name"])
+		assert.Equal(t, "path", getJob.Parameters[0]["in"])
+	}
+	assert.NotNil(t, doc.Paths["/api/v1/jobs"]["post"].RequestBody)
+	assert.Contains(t, doc.Paths["/api/v1/jobs"]["post"].Responses, "201")
+	assert.Contains(t, doc.Paths["/api/v2/jobs/{id}"]["delete"].Responses, "204")
+
+	// Docs are public while deleting a job needs an admin
+	docs := doc.Paths["/api/v1/openapi.json"]["get"]
+	if assert.NotNil(t, docs.Security) {
+		assert.Empty(t, *docs.Security)
+	}
+	assert.Equal(t, "admin", doc.Paths["/api/v1/jobs/{id}"]["delete"].RequiredRole)
+	assert.Equal(t, "operator", doc.Paths["/api/v1/jobs/{id}/pause"]["post"].RequiredRole)
+}
+
+func TestDocsHandler_DescribesSchemas(t *testing.T) {
+	doc := getOpenAPI(t, newDocsRouter())
+	schemas := doc.Components.Schemas
+
+	assert.ElementsMatch(t, []string{"name", "schedule", "job_type"}, schemas["CreateJobRequest"].Required)
+	assert.Contains(t, schemas["JobResponse"].Properties, "next_run_at")
+	assert.Contains(t, schemas, "ErrorResponse")
+	assert.Contains(t, schemas, "LegacyError")
+
+	// Each built-in job type's config is described
+	for name, setting := range map[string]string{
+		"EmailNotificationConfig": "recipient",
+		"DataProcessingConfig":    "operation",
+		"ReportGenerationConfig":  "report_template",
+		"HealthCheckConfig":       "probes",
+		"DBMaintenanceConfig":     "operations",
+	} {
+		if assert.Contains(t, schemas, name) {
+			assert.Contains(t, schemas[name].Properties, setting)
+		}
+	}
+}
+
+func TestDocsHandler_ServesSwaggerUI(t *testing.T) {
+	w := httptest.NewRecorder()
+	newDocsRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/docs", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, w.Body.String(), `url: "openapi.json"`)
+}
@@ -0,0 +1,342 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/notifications"
+	"job-scheduler/internal/scheduler"
+	"job-scheduler/internal/services"
+)
+
+// memoryApprovalRuns keeps runs in memory, saving status transitions only from the status they were
+// read in, as the database repository does
+type memoryApprovalRuns struct {
+	*MockJobExecutionRepository
+	mu   sync.Mutex
+	runs map[uuid.UUID]models.JobExecution
+}
+
+func newMemoryApprovalRuns() *memoryApprovalRuns {
+	return &memoryApprovalRuns{MockJobExecutionRepository: newStartupExecutionRepo(), runs: map[uuid.UUID]models.JobExecution{}}
+}
+
+func (r *memoryApprovalRuns) Create(execution *models.JobExecution) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	execution.MarkSaved()
+	r.runs[execution.ID] = *execution
+	return nil
+}
+
+func (r *memoryApprovalRuns) GetByID(id uuid.UUID) (*models.JobExecution, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run, ok := r.runs[id]
+	if !ok {
+		return nil, fmt.Errorf("job execution with ID %s not found", id)
+	}
+	return &run, nil
+}
+
+func (r *memoryApprovalRuns) Update(execution *models.JobExecution) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.runs[execution.ID]
+	if !ok {
+		return fmt.Errorf("job execution with ID %s not found", execution.ID)
+	}
+	if from, transitioned := execution.UnsavedTransition(); transitioned && stored.Status != from {
+		return fmt.Errorf("%w: job execution %s is no longer %s", models.ErrIllegalTransition, execution.ID, from)
+	}
+	execution.MarkSaved()
+	r.runs[execution.ID] = *execution
+	return nil
+}
+
+func (r *memoryApprovalRuns) GetAwaitingApproval() ([]models.JobExecution, error) {
+	return r.withStatus(func(run models.JobExecution) bool { return run.IsAwaitingApproval() }), nil
+}
+
+func (r *memoryApprovalRuns) GetExpiredApprovals(now time.Time) ([]models.JobExecution, error) {
+	return r.withStatus(func(run models.JobExecution) bool {
+		return run.IsAwaitingApproval() && run.ApprovalExpiresAt.Before(now)
+	}), nil
+}
+
+func (r *memoryApprovalRuns) withStatus(match func(models.JobExecution) bool) []models.JobExecution {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var runs []models.JobExecution
+	for _, run := range r.runs {
+		if match(run) {
+			runs = append(runs, run)
+		}
+	}
+	return runs
+}
+
+// approvedRuns records the approved runs it is asked to start
+type approvedRuns struct {
+	mu      sync.Mutex
+	started []uuid.UUID
+}
+
+func (r *approvedRuns) RunApproved(job *models.Job, execution *models.JobExecution) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.started = append(r.started, execution.ID)
+	return nil
+}
+
+// lockedNotifier records notifications from any goroutine
+type lockedNotifier struct {
+	mu       sync.Mutex
+	received []notifications.Notification
+}
+
+func (n *lockedNotifier) Notify(ctx context.Context, notification notifications.Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.received = append(n.received, notification)
+	return nil
+}
+
+func (n *lockedNotifier) events() []notifications.Event {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var events []notifications.Event
+	for _, notification := range n.received {
+		events = append(events, notification.Event)
+	}
+	return events
+}
+
+func newApprovalService(runs *memoryApprovalRuns, job *models.Job, runner services.ApprovedRunExecutor, notifier notifications.Notifier, timeout time.Duration) services.ApprovalService {
+	jobRepo := new(MockJobRepository)
+	jobRepo.On("GetByID", job.ID).Return(job, nil).Maybe()
+	return services.NewApprovalService(runs, jobRepo, runner, notifier, timeout)
+}
+
+func TestApprovalService_ConcurrentApprovalsStartRunOnce(t *testing.T) {
+	// Setup
+	job := &models.Job{ID: uuid.New(), Name: "Prod backfill", RequiresApproval: true}
+	runs := newMemoryApprovalRuns()
+	runner := &approvedRuns{}
+	notifier := &lockedNotifier{}
+	service := newApprovalService(runs, job, runner, notifier, time.Hour)
+
+	run, err := service.RequestApproval(job, time.Now().UTC())
+	require.NoError(t, err)
+	assert.Equal(t, []notifications.Event{notifications.EventApprovalRequested}, notifier.events())
+	pending, err := service.GetPendingApprovals()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	// Execute - several approvers approve at once
+	approvers := []string{"alice", "bob", "carol", "dave", "erin"}
+	results := make([]error, len(approvers))
+	var wg sync.WaitGroup
+	for i, approver := range approvers {
+		wg.Add(1)
+		go func(i int, approver string) {
+			defer wg.Done()
+			_, results[i] = service.Approve(run.ID, approver)
+		}(i, approver)
+	}
+	wg.Wait()
+
+	// Assert - exactly one approval wins and starts the run
+	approved := 0
+	for _, err := range results {
+		if err == nil {
+			approved++
+		} else {
+			assert.ErrorIs(t, err, services.ErrNotAwaitingApproval)
+		}
+	}
+	assert.Equal(t, 1, approved)
+	assert.Equal(t, []uuid.UUID{run.ID}, runner.started)
+
+	stored, _ := runs.GetByID(run.ID)
+	assert.Equal(t, models.ExecutionStatusPending, stored.Status)
+	require.NotNil(t, stored.ApprovedBy)
+	assert.Contains(t, approvers, *stored.ApprovedBy)
+}
+
+// stoppedRunner refuses to start approved runs, as a stopped scheduler does
+type stoppedRunner struct{}
+
+func (stoppedRunner) RunApproved(job *models.Job, execution *models.JobExecution) error {
+	return fmt.Errorf("scheduler is not running")
+}
+
+func TestApprovalService_ApprovedRunThatCantStartFails(t *testing.T) {
+	// Setup
+	job := &models.Job{ID: uuid.New(), Name: "Prod backfill", RequiresApproval: true}
+	runs := newMemoryApprovalRuns()
+	service := newApprovalService(runs, job, stoppedRunner{}, &lockedNotifier{}, time.Hour)
+	run, err := service.RequestApproval(job, time.Now().UTC())
+	require.NoError(t, err)
+
+	// Execute
+	_, err = service.Approve(run.ID, "alice")
+
+	// Assert - the run fails instead of staying approved with nothing to run it
+	assert.ErrorContains(t, err, "scheduler is not running")
+	stored, _ := runs.GetByID(run.ID)
+	assert.Equal(t, models.ExecutionStatusFailed, stored.Status)
+	assert.Contains(t, stored.ErrorMessage.String(), "scheduler is not running")
+}
+
+func TestApprovalService_WorksWithoutNotifier(t *testing.T) {
+	// Setup
+	job := &models.Job{ID: uuid.New(), Name: "Prod backfill", RequiresApproval: true}
+	runs := newMemoryApprovalRuns()
+	runner := &approvedRuns{}
+	service := newApprovalService(runs, job, runner, nil, time.Hour)
+
+	// Execute
+	run, err := service.RequestApproval(job, time.Now().UTC())
+	require.NoError(t, err)
+	_, err = service.Approve(run.ID, "alice")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{run.ID}, runner.started)
+}
+
+func TestApprovalService_RejectCancelsRun(t *testing.T) {
+	// Setup
+	job := &models.Job{ID: uuid.New(), Name: "Prod backfill", RequiresApproval: true}
+	runs := newMemoryApprovalRuns()
+	runner := &approvedRuns{}
+	service := newApprovalService(runs, job, runner, &lockedNotifier{}, time.Hour)
+	run, err := service.RequestApproval(job, time.Now().UTC())
+	require.NoError(t, err)
+
+	// Execute
+	rejected, err := service.Reject(run.ID, "alice", "freeze week")
+
+	// Assert - the run is cancelled with why, and can no longer be approved or rejected
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusCancelled, rejected.Status)
+	assert.Equal(t, "Rejected by alice: freeze week", rejected.ErrorMessage.String())
+	_, err = service.Approve(run.ID, "bob")
+	assert.ErrorIs(t, err, services.ErrNotAwaitingApproval)
+	_, err = service.Reject(run.ID, "bob", "")
+	assert.ErrorIs(t, err, services.ErrNotAwaitingApproval)
+	assert.Empty(t, runner.started)
+}
+
+func TestApprovalService_ExpiresUnapprovedRuns(t *testing.T) {
+	// Setup - runs awaiting approval for no time at all
+	job := &models.Job{ID: uuid.New(), Name: "Prod backfill", RequiresApproval: true}
+	runs := newMemoryApprovalRuns()
+	runner := &approvedRuns{}
+	notifier := &lockedNotifier{}
+	service := newApprovalService(runs, job, runner, notifier, -time.Minute)
+	run, err := service.RequestApproval(job, time.Now().UTC())
+	require.NoError(t, err)
+
+	// Execute - an approval after the window, then the expiry sweep
+	_, approveErr := service.Approve(run.ID, "alice")
+	expired, err := service.ExpireStale()
+
+	// Assert
+	assert.ErrorIs(t, approveErr, services.ErrApprovalExpired)
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	stored, _ := runs.GetByID(run.ID)
+	assert.Equal(t, models.ExecutionStatusExpired, stored.Status)
+	assert.NotNil(t, stored.CompletedAt)
+	assert.Equal(t, []notifications.Event{notifications.EventApprovalRequested, notifications.EventApprovalExpired}, notifier.events())
+	assert.Empty(t, runner.started)
+
+	// Expired runs aren't expired again
+	expired, err = service.ExpireStale()
+	require.NoError(t, err)
+	assert.Zero(t, expired)
+}
+
+// gatedRunClaims grants every claim, holding the first until it is released
+type gatedRunClaims struct {
+	claimed chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (c *gatedRunClaims) Claim(claim *models.JobRunClaim) (bool, error) {
+	first := false
+	c.once.Do(func() { first = true })
+	if first {
+		close(c.claimed)
+		<-c.release
+	}
+	return true, nil
+}
+
+func (c *gatedRunClaims) DeleteBefore(before time.Time) (int64, error) { return 0, nil }
+
+func TestScheduler_ApprovalGateStopsScheduledRunAndShutdownDoesNotDeadlock(t *testing.T) {
+	// Setup - a job requiring approval, due every second
+	runner := &countingExecutor{jobType: "test_approval_gate"}
+	require.NoError(t, scheduler.RegisterExecutor(runner.jobType, runner))
+	job := models.Job{ID: uuid.New(), Name: "Prod backfill", Schedule: "@every 1s", JobType: runner.jobType, State: models.JobStateActive, RequiresApproval: true}
+
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetActiveJobs").Return([]models.Job{job}, nil).Maybe()
+	mockJobRepo.On("GetSchedulableJobs").Return([]models.Job{job}, nil)
+	mockJobRepo.On("UpdateRunTimes", job.ID, mock.Anything, mock.Anything).Return(nil).Maybe()
+	runs := newMemoryApprovalRuns()
+	notifier := &lockedNotifier{}
+	claims := &gatedRunClaims{claimed: make(chan struct{}), release: make(chan struct{})}
+
+	cfg := &config.Config{
+		Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second},
+		Reports:   config.ReportsConfig{Directory: t.TempDir()},
+	}
+	s := scheduler.NewScheduler(services.NewJobService(mockJobRepo), runs, cfg)
+	s.SetRunClaims(claims)
+	s.SetApprovalService(newApprovalService(runs, &job, s, notifier, time.Hour))
+	require.NoError(t, s.Start())
+
+	// Execute - the scheduler stops while the occurrence is still being claimed
+	select {
+	case <-claims.claimed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the job never fired")
+	}
+	stopped := make(chan error, 1)
+	go func() { stopped <- s.Stop() }()
+	time.Sleep(100 * time.Millisecond)
+	close(claims.release)
+
+	// Assert - shutdown waits for the firing job instead of deadlocking with it
+	select {
+	case err := <-stopped:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop deadlocked with a firing job")
+	}
+
+	// The occurrence only got a run awaiting approval
+	pending, err := runs.GetAwaitingApproval()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, job.ID, pending[0].JobID)
+	assert.NotNil(t, pending[0].ScheduledFor)
+	assert.Equal(t, []notifications.Event{notifications.EventApprovalRequested}, notifier.events())
+	assert.Zero(t, runner.runs)
+	assert.False(t, s.IsRunning())
+}
@@ -0,0 +1,383 @@
+package tests
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/artifacts"
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/handlers"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// MockArtifactRepository is a mock implementation of ArtifactRepository
+type MockArtifactRepository struct {
+	mock.Mock
+}
+
+func (m *MockArtifactRepository) Create(artifact *models.Artifact) error {
+	args := m.Called(artifact)
+	return args.Error(0)
+}
+
+func (m *MockArtifactRepository) GetByID(id uuid.UUID) (*models.Artifact, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Artifact), args.Error(1)
+}
+
+func (m *MockArtifactRepository) GetByJobID(jobID uuid.UUID, kind models.ArtifactKind, limit int) ([]models.Artifact, error) {
+	args := m.Called(jobID, kind, limit)
+	return args.Get(0).([]models.Artifact), args.Error(1)
+}
+
+func (m *MockArtifactRepository) GetByExecutionID(executionID uuid.UUID) ([]models.Artifact, error) {
+	args := m.Called(executionID)
+	return args.Get(0).([]models.Artifact), args.Error(1)
+}
+
+func (m *MockArtifactRepository) GetExpired(before time.Time, limit int) ([]models.Artifact, error) {
+	args := m.Called(before, limit)
+	return args.Get(0).([]models.Artifact), args.Error(1)
+}
+
+func (m *MockArtifactRepository) GetOldestByJob(jobID uuid.UUID, limit int) ([]models.Artifact, error) {
+	args := m.Called(jobID, limit)
+	return args.Get(0).([]models.Artifact), args.Error(1)
+}
+
+func (m *MockArtifactRepository) GetOldestByTeam(team string, limit int) ([]models.Artifact, error) {
+	args := m.Called(team, limit)
+	return args.Get(0).([]models.Artifact), args.Error(1)
+}
+
+func (m *MockArtifactRepository) SumBytesByJob(jobID uuid.UUID) (int64, error) {
+	args := m.Called(jobID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockArtifactRepository) SumBytesByTeam(team string) (int64, error) {
+	args := m.Called(team)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockArtifactRepository) GetUsageByJob(limit int) ([]models.ArtifactUsage, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]models.ArtifactUsage), args.Error(1)
+}
+
+func (m *MockArtifactRepository) GetUsageByTeam() ([]models.TeamArtifactUsage, error) {
+	args := m.Called()
+	return args.Get(0).([]models.TeamArtifactUsage), args.Error(1)
+}
+
+func (m *MockArtifactRepository) Delete(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestLocalStore_SignedURLVerifies(t *testing.T) {
+	store := artifacts.NewLocalStore(t.TempDir(), "https://scheduler.example.com", "secret")
+	artifact := &models.Artifact{ID: uuid.New()}
+	now := time.Now()
+
+	signedURL, err := store.SignedURL(artifact, now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(signedURL, "https://scheduler.example.com/api/v1/artifacts/"+artifact.ID.String()+"/download?"))
+
+	u, _ := url.Parse(signedURL)
+	expires, signature := u.Query().Get("expires"), u.Query().Get("signature")
+
+	// Valid until it expires
+	assert.NoError(t, store.Verify(artifact.ID, expires, signature, now))
+	assert.ErrorIs(t, store.Verify(artifact.ID, expires, signature, now.Add(2*time.Minute)), artifacts.ErrURLExpired)
+
+	// Signatures don't transfer to other artifacts or expiries
+	assert.ErrorIs(t, store.Verify(uuid.New(), expires, signature, now), artifacts.ErrInvalidSignature)
+	later := strconv.FormatInt(now.Add(time.Hour).Unix(), 10)
+	assert.ErrorIs(t, store.Verify(artifact.ID, later, signature, now), artifacts.ErrInvalidSignature)
+
+	// Other secrets don't verify
+	other := artifacts.NewLocalStore(t.TempDir(), "", "other-secret")
+	assert.ErrorIs(t, other.Verify(artifact.ID, expires, signature, now), artifacts.ErrInvalidSignature)
+}
+
+func TestS3Store_SignedURLIsPresigned(t *testing.T) {
+	store := artifacts.NewS3Store(config.ArtifactsConfig{
+		S3Bucket:   "reports",
+		S3Region:   "eu-west-1",
+		S3Endpoint: "http://minio:9000",
+		S3Prefix:   "scheduler/",
+	}, config.TriggersConfig{AWSAccessKeyID: "AKIDEXAMPLE", AWSSecretKey: "secret"}, nil)
+	artifact := &models.Artifact{ID: uuid.New(), Name: "report.csv", StorageKey: "job/run/report.csv"}
+
+	signedURL, err := store.SignedURL(artifact, time.Now().Add(30*24*time.Hour))
+
+	assert.NoError(t, err)
+	u, _ := url.Parse(signedURL)
+	assert.Equal(t, "minio:9000", u.Host)
+	assert.Equal(t, "/reports/scheduler/job/run/report.csv", u.Path)
+	assert.Equal(t, "AWS4-HMAC-SHA256", u.Query().Get("X-Amz-Algorithm"))
+	assert.Equal(t, "host", u.Query().Get("X-Amz-SignedHeaders"))
+	assert.True(t, strings.HasPrefix(u.Query().Get("X-Amz-Credential"), "AKIDEXAMPLE/"))
+	assert.Len(t, u.Query().Get("X-Amz-Signature"), 64)
+	// Expiry is capped at S3's seven day maximum
+	assert.Equal(t, "604800", u.Query().Get("X-Amz-Expires"))
+}
+
+func TestArtifactService_RecordAndDownload(t *testing.T) {
+	// Setup
+	dir := t.TempDir()
+	reportPath := filepath.Join(dir, "daily.txt")
+	assert.NoError(t, ioutil.WriteFile(reportPath, []byte("report body"), 0644))
+
+	mockJobRepo := new(MockJobRepository)
+	mockArtifactRepo := new(MockArtifactRepository)
+	store := artifacts.NewLocalStore(dir, "", "secret")
+	service := services.NewArtifactService(mockJobRepo, mockArtifactRepo, store, services.ArtifactPolicy{URLExpiry: time.Minute, Retention: 24 * time.Hour})
+
+	job := &models.Job{ID: uuid.New(), Name: "Daily Report"}
+	execution := &models.JobExecution{ID: uuid.New(), JobID: job.ID}
+
+	var recorded *models.Artifact
+	mockArtifactRepo.On("Create", mock.AnythingOfType("*models.Artifact")).
+		Run(func(args mock.Arguments) { recorded = args.Get(0).(*models.Artifact) }).
+		Return(nil)
+
+	// Execute - record the run's report
+	err := service.RecordArtifacts(job, execution, []services.ArtifactFile{
+		{Name: "daily.txt", Path: reportPath, ContentType: "text/plain; charset=utf-8"},
+	})
+
+	// Assert - the report moved under the job and execution
+	assert.NoError(t, err)
+	if !assert.NotNil(t, recorded) {
+		return
+	}
+	assert.Equal(t, int64(len("report body")), recorded.SizeBytes)
+	assert.Equal(t, models.ArtifactKindReport, recorded.Kind)
+	if assert.NotNil(t, recorded.ExpiresAt) {
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), *recorded.ExpiresAt, 5*time.Second)
+	}
+	_, statErr := os.Stat(reportPath)
+	assert.True(t, os.IsNotExist(statErr))
+	assert.FileExists(t, filepath.Join(dir, job.ID.String(), execution.ID.String(), "daily.txt"))
+
+	// Execute - download through a signed URL
+	mockArtifactRepo.On("GetByID", recorded.ID).Return(recorded, nil)
+	signedURL, expiresAt, err := service.GetDownloadURL(recorded.ID)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), expiresAt, 5*time.Second)
+
+	u, _ := url.Parse(signedURL)
+	artifact, file, err := service.OpenSignedDownload(recorded.ID, u.Query().Get("expires"), u.Query().Get("signature"))
+	assert.NoError(t, err)
+	defer file.Close()
+	content, _ := ioutil.ReadAll(file)
+	assert.Equal(t, "report body", string(content))
+	assert.Equal(t, "daily.txt", artifact.Name)
+
+	// Tampered links are rejected
+	_, _, err = service.OpenSignedDownload(recorded.ID, u.Query().Get("expires"), "bogus")
+	assert.ErrorIs(t, err, services.ErrDownloadLinkInvalid)
+}
+
+func TestArtifactService_JobRetentionOverride(t *testing.T) {
+	// Setup
+	dir := t.TempDir()
+	reportPath := filepath.Join(dir, "audit.csv")
+	assert.NoError(t, ioutil.WriteFile(reportPath, []byte("a,b"), 0644))
+
+	mockArtifactRepo := new(MockArtifactRepository)
+	service := services.NewArtifactService(new(MockJobRepository), mockArtifactRepo,
+		artifacts.NewLocalStore(dir, "", "secret"), services.ArtifactPolicy{URLExpiry: time.Minute, Retention: 24 * time.Hour})
+
+	// Audit reports are kept forever
+	job := &models.Job{ID: uuid.New(), Config: models.JobConfig{"artifact_retention_days": float64(0)}}
+	execution := &models.JobExecution{ID: uuid.New(), JobID: job.ID}
+
+	mockArtifactRepo.On("Create", mock.MatchedBy(func(a *models.Artifact) bool {
+		return a.ExpiresAt == nil
+	})).Return(nil)
+
+	// Execute
+	err := service.RecordArtifacts(job, execution, []services.ArtifactFile{
+		{Kind: models.ArtifactKindExport, Name: "audit.csv", Path: reportPath, ContentType: "text/csv"},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	mockArtifactRepo.AssertExpectations(t)
+}
+
+func TestArtifactService_PurgeExpired(t *testing.T) {
+	// Setup
+	dir := t.TempDir()
+	store := artifacts.NewLocalStore(dir, "", "secret")
+	mockArtifactRepo := new(MockArtifactRepository)
+	service := services.NewArtifactService(new(MockJobRepository), mockArtifactRepo, store, services.ArtifactPolicy{URLExpiry: time.Minute, Retention: time.Hour})
+
+	expired := models.Artifact{ID: uuid.New(), StorageKey: "job/run/old.txt"}
+	storedPath := filepath.Join(dir, "job", "run", "old.txt")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(storedPath), 0755))
+	assert.NoError(t, ioutil.WriteFile(storedPath, []byte("old"), 0644))
+
+	mockArtifactRepo.On("GetExpired", mock.AnythingOfType("time.Time"), 100).Return([]models.Artifact{expired}, nil).Once()
+	mockArtifactRepo.On("GetExpired", mock.AnythingOfType("time.Time"), 100).Return([]models.Artifact{}, nil).Once()
+	mockArtifactRepo.On("Delete", expired.ID).Return(nil)
+
+	// Execute
+	purged, err := service.PurgeExpired()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	assert.NoFileExists(t, storedPath)
+	mockArtifactRepo.AssertExpectations(t)
+}
+
+func TestArtifactService_RejectsOverQuota(t *testing.T) {
+	// Setup
+	dir := t.TempDir()
+	reportPath := filepath.Join(dir, "big.csv")
+	assert.NoError(t, ioutil.WriteFile(reportPath, []byte("0123456789"), 0644))
+
+	mockArtifactRepo := new(MockArtifactRepository)
+	service := services.NewArtifactService(new(MockJobRepository), mockArtifactRepo,
+		artifacts.NewLocalStore(dir, "", "secret"), services.ArtifactPolicy{JobQuotaBytes: 100, TeamQuotaBytes: 50})
+
+	job := &models.Job{ID: uuid.New(), Name: "Export", Team: "payments"}
+	execution := &models.JobExecution{ID: uuid.New(), JobID: job.ID}
+
+	mockArtifactRepo.On("SumBytesByJob", job.ID).Return(int64(20), nil)
+	mockArtifactRepo.On("SumBytesByTeam", "payments").Return(int64(45), nil)
+
+	// Execute
+	err := service.RecordArtifacts(job, execution, []services.ArtifactFile{
+		{Name: "big.csv", Path: reportPath, ContentType: "text/csv"},
+	})
+
+	// Assert - the team quota is exceeded and nothing is stored
+	assert.ErrorIs(t, err, services.ErrQuotaExceeded)
+	assert.FileExists(t, reportPath)
+	mockArtifactRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestArtifactService_EvictOverQuota(t *testing.T) {
+	// Setup
+	dir := t.TempDir()
+	store := artifacts.NewLocalStore(dir, "", "secret")
+	mockArtifactRepo := new(MockArtifactRepository)
+	service := services.NewArtifactService(new(MockJobRepository), mockArtifactRepo, store,
+		services.ArtifactPolicy{JobQuotaBytes: 100, EvictionThreshold: 80})
+
+	jobID := uuid.New()
+	oldest := []models.Artifact{
+		{ID: uuid.New(), JobID: jobID, StorageKey: "job/run1/a.txt", SizeBytes: 30},
+		{ID: uuid.New(), JobID: jobID, StorageKey: "job/run2/b.txt", SizeBytes: 30},
+		{ID: uuid.New(), JobID: jobID, StorageKey: "job/run3/c.txt", SizeBytes: 30},
+	}
+	for _, artifact := range oldest {
+		storedPath := filepath.Join(dir, filepath.FromSlash(artifact.StorageKey))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(storedPath), 0755))
+		assert.NoError(t, ioutil.WriteFile(storedPath, []byte("data"), 0644))
+	}
+
+	mockArtifactRepo.On("GetUsageByJob", 0).Return([]models.ArtifactUsage{{JobID: jobID, Count: 3, Bytes: 90}}, nil)
+	mockArtifactRepo.On("GetOldestByJob", jobID, 100).Return(oldest, nil).Once()
+	mockArtifactRepo.On("Delete", oldest[0].ID).Return(nil)
+
+	// Execute
+	evicted, err := service.EvictOverQuota()
+
+	// Assert - 90 bytes is over 80% of the quota, so only the oldest artifact goes
+	assert.NoError(t, err)
+	assert.Equal(t, 1, evicted)
+	assert.NoFileExists(t, filepath.Join(dir, "job", "run1", "a.txt"))
+	assert.FileExists(t, filepath.Join(dir, "job", "run2", "b.txt"))
+	mockArtifactRepo.AssertExpectations(t)
+}
+
+func TestGCSStore_SignedURLIsSigned(t *testing.T) {
+	// Setup - write a throwaway service account key
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	credentials, _ := json.Marshal(map[string]string{
+		"client_email": "scheduler@project.iam.gserviceaccount.com",
+		"private_key":  string(keyPEM),
+	})
+	credentialsFile := filepath.Join(t.TempDir(), "key.json")
+	assert.NoError(t, ioutil.WriteFile(credentialsFile, credentials, 0600))
+
+	store, err := artifacts.NewGCSStore(config.ArtifactsConfig{
+		GCSBucket:          "reports",
+		GCSCredentialsFile: credentialsFile,
+	}, nil)
+	assert.NoError(t, err)
+
+	// Execute
+	signedURL, err := store.SignedURL(&models.Artifact{Name: "report.csv", StorageKey: "job/run/report.csv"}, time.Now().Add(time.Hour))
+
+	// Assert
+	assert.NoError(t, err)
+	u, _ := url.Parse(signedURL)
+	assert.Equal(t, "storage.googleapis.com", u.Host)
+	assert.Equal(t, "/reports/job/run/report.csv", u.Path)
+	assert.Equal(t, "GOOG4-RSA-SHA256", u.Query().Get("X-Goog-Algorithm"))
+	assert.True(t, strings.HasPrefix(u.Query().Get("X-Goog-Credential"), "scheduler@project.iam.gserviceaccount.com/"))
+	assert.Equal(t, "3600", u.Query().Get("X-Goog-Expires"))
+	assert.Len(t, u.Query().Get("X-Goog-Signature"), 512)
+}
+
+func TestArtifactHandler_ExecutionArtifactByName(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockArtifactRepo := new(MockArtifactRepository)
+	service := services.NewArtifactService(new(MockJobRepository), mockArtifactRepo,
+		artifacts.NewLocalStore(t.TempDir(), "", "secret"), services.ArtifactPolicy{URLExpiry: time.Minute})
+	executionID := uuid.New()
+	report := models.Artifact{ID: uuid.New(), ExecutionID: executionID, Name: "revenue.xlsx", StorageKey: "job/run/revenue.xlsx"}
+	mockArtifactRepo.On("GetByExecutionID", executionID).Return([]models.Artifact{report}, nil)
+
+	router := gin.New()
+	api := router.Group("/api/v1")
+	handlers.NewExecutionHandler(nil).RegisterRoutes(api)
+	handlers.NewArtifactHandler(service).RegisterRoutes(api)
+
+	// Execute - a run's file is found by name and redirected to its signed URL
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/executions/"+executionID.String()+"/artifacts/revenue.xlsx?redirect=true", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusFound, w.Code)
+	location, _ := url.Parse(w.Header().Get("Location"))
+	assert.Equal(t, "/api/v1/artifacts/"+report.ID.String()+"/download", location.Path)
+	assert.NotEmpty(t, location.Query().Get("signature"))
+
+	// Execute - files the run didn't produce aren't found
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/executions/"+executionID.String()+"/artifacts/other.csv", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+func TestParseCallBudget(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      models.JobConfig
+		expectedErr string
+	}{
+		{"no budget", models.JobConfig{}, ""},
+		{"run budget", models.JobConfig{"call_budget": map[string]interface{}{"max_calls": float64(1000)}}, ""},
+		{"windowed pause", models.JobConfig{"call_budget": map[string]interface{}{"max_calls": float64(10), "window": "1m", "on_exceeded": "pause"}}, ""},
+		{"not an object", models.JobConfig{"call_budget": float64(10)}, "must be an object"},
+		{"missing max_calls", models.JobConfig{"call_budget": map[string]interface{}{}}, "max_calls"},
+		{"fractional max_calls", models.JobConfig{"call_budget": map[string]interface{}{"max_calls": 1.5}}, "max_calls"},
+		{"invalid window", models.JobConfig{"call_budget": map[string]interface{}{"max_calls": float64(1), "window": "soon"}}, "window"},
+		{"unknown action", models.JobConfig{"call_budget": map[string]interface{}{"max_calls": float64(1), "on_exceeded": "retry"}}, "on_exceeded"},
+		{"pause without window", models.JobConfig{"call_budget": map[string]interface{}{"max_calls": float64(1), "on_exceeded": "pause"}}, "window is required"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := services.ParseCallBudget(tc.config)
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestCallBudget_FailsCallsOverBudget(t *testing.T) {
+	// Setup
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	budget := &services.CallBudget{MaxCalls: 2, OnExceeded: services.BudgetExceededFail}
+	client := budget.Client(&http.Client{Timeout: time.Second})
+
+	// Execute
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+		}
+	}
+	_, err := client.Get(server.URL)
+
+	// Assert - the third call never reaches the server
+	assert.ErrorIs(t, err, services.ErrCallBudgetExceeded)
+	assert.Equal(t, 2, calls)
+}
+
+func TestCallBudget_PausesUntilWindowRefills(t *testing.T) {
+	// Setup
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	window := 200 * time.Millisecond
+	budget := &services.CallBudget{MaxCalls: 1, Window: window, OnExceeded: services.BudgetExceededPause}
+	client := budget.Client(&http.Client{Timeout: time.Second})
+
+	// Execute
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+		}
+	}
+
+	// Assert - the second call waited for the window to refill
+	assert.GreaterOrEqual(t, time.Since(start), window)
+}
+
+func TestHealthCheckExecutor_EnforcesCallBudget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	executor := services.NewHealthCheckExecutor(&http.Client{Timeout: time.Second})
+	job := &models.Job{ID: uuid.New(), JobType: models.JobTypeHealthCheck, Config: models.JobConfig{
+		"url":             server.URL,
+		"expected_status": float64(200),
+		"call_budget":     map[string]interface{}{"max_calls": "many"},
+	}}
+
+	err := executor.Execute(context.Background(), job)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "max_calls")
+}
@@ -0,0 +1,135 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// MockPendingChangeRepository is a mock implementation of PendingChangeRepository
+type MockPendingChangeRepository struct {
+	mock.Mock
+}
+
+func (m *MockPendingChangeRepository) Create(change *models.PendingChange) error {
+	args := m.Called(change)
+	return args.Error(0)
+}
+
+func (m *MockPendingChangeRepository) GetByID(id uuid.UUID) (*models.PendingChange, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.PendingChange), args.Error(1)
+}
+
+func (m *MockPendingChangeRepository) GetPending() ([]models.PendingChange, error) {
+	args := m.Called()
+	return args.Get(0).([]models.PendingChange), args.Error(1)
+}
+
+func (m *MockPendingChangeRepository) Update(change *models.PendingChange) error {
+	args := m.Called(change)
+	return args.Error(0)
+}
+
+// MockAuditRepository is a mock implementation of AuditRepository
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Create(entry *models.AuditEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) GetByEntity(entityType string, entityID uuid.UUID, limit int) ([]models.AuditEntry, error) {
+	args := m.Called(entityType, entityID, limit)
+	return args.Get(0).([]models.AuditEntry), args.Error(1)
+}
+
+func newProtectedJob() *models.Job {
+	return &models.Job{
+		ID:       uuid.New(),
+		Name:     "Protected Job",
+		Schedule: "0 9 * * *",
+		JobType:  models.JobTypeHealthCheck,
+		State:    models.JobStateActive,
+		Tags:     models.JobTags{models.ProtectedTag},
+	}
+}
+
+func TestChangeControlService_ProposeUpdate_QueuesScheduleChange(t *testing.T) {
+	// Setup
+	jobRepo := new(MockJobRepository)
+	changeRepo := new(MockPendingChangeRepository)
+	auditRepo := new(MockAuditRepository)
+	service := services.NewChangeControlService(services.NewJobService(jobRepo), jobRepo, changeRepo, auditRepo, true)
+
+	job := newProtectedJob()
+	schedule := "*/5 * * * *"
+
+	jobRepo.On("GetByID", job.ID).Return(job, nil)
+	changeRepo.On("Create", mock.AnythingOfType("*models.PendingChange")).Return(nil)
+	auditRepo.On("Create", mock.AnythingOfType("*models.AuditEntry")).Return(nil)
+
+	// Execute
+	change, err := service.ProposeUpdate(job.ID, &models.UpdateJobRequest{Schedule: &schedule}, "alice")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, change)
+	assert.Equal(t, models.PendingChangeActionUpdate, change.Action)
+	assert.Equal(t, "alice", change.RequestedBy)
+	assert.Equal(t, schedule, change.Payload["schedule"])
+
+	jobRepo.AssertNotCalled(t, "Update", mock.Anything)
+	changeRepo.AssertExpectations(t)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestChangeControlService_ProposeUpdate_NonDestructive(t *testing.T) {
+	// Setup
+	jobRepo := new(MockJobRepository)
+	service := services.NewChangeControlService(services.NewJobService(jobRepo), jobRepo, new(MockPendingChangeRepository), new(MockAuditRepository), true)
+
+	job := newProtectedJob()
+	description := "Updated description"
+
+	jobRepo.On("GetByID", job.ID).Return(job, nil)
+
+	// Execute
+	change, err := service.ProposeUpdate(job.ID, &models.UpdateJobRequest{Description: &description}, "alice")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, change)
+}
+
+func TestChangeControlService_Approve_SelfApproval(t *testing.T) {
+	// Setup
+	jobRepo := new(MockJobRepository)
+	changeRepo := new(MockPendingChangeRepository)
+	service := services.NewChangeControlService(services.NewJobService(jobRepo), jobRepo, changeRepo, new(MockAuditRepository), true)
+
+	change := &models.PendingChange{
+		ID:          uuid.New(),
+		JobID:       uuid.New(),
+		Action:      models.PendingChangeActionDelete,
+		RequestedBy: "alice",
+		Status:      models.PendingChangeStatusPending,
+	}
+
+	changeRepo.On("GetByID", change.ID).Return(change, nil)
+
+	// Execute
+	_, err := service.Approve(change.ID, "alice")
+
+	// Assert
+	assert.ErrorIs(t, err, services.ErrSelfApproval)
+	jobRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	changeRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
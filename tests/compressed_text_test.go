@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"job-scheduler/internal/models"
+)
+
+func TestCompressedText_SmallValuesStoredPlain(t *testing.T) {
+	text := models.CompressedText("connection refused")
+
+	stored, err := text.Value()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "connection refused", stored)
+}
+
+func TestCompressedText_LargeValuesRoundTrip(t *testing.T) {
+	// A large, repetitive stack trace
+	trace := strings.Repeat("goroutine 1 [running]:\nmain.main()\n\t/app/main.go:42 +0x1d\n", 500)
+	text := models.CompressedText(trace)
+
+	stored, err := text.Value()
+	assert.NoError(t, err)
+
+	storedString, ok := stored.(string)
+	assert.True(t, ok)
+	assert.True(t, strings.HasPrefix(storedString, "gzip:"))
+	assert.Less(t, len(storedString), len(trace)/10)
+
+	// Reading it back yields the original text
+	var scanned models.CompressedText
+	assert.NoError(t, scanned.Scan([]byte(storedString)))
+	assert.Equal(t, trace, scanned.String())
+}
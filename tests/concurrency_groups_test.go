@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/scheduler"
+)
+
+func TestJobExecutor_LimitsRunsPerConcurrencyGroup(t *testing.T) {
+	// Setup - plenty of execution slots, but only one run of a reporting job at a time
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{
+		MaxConcurrentJobs:      5,
+		MaxQueueWait:           100 * time.Millisecond,
+		MaxQueueDepth:          10,
+		ConcurrencyGroupLimits: map[string]int{"reporting": 1},
+	}}
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+
+	newJob := func(group string) *models.Job {
+		return &models.Job{ID: uuid.New(), Name: "Report", JobType: models.JobTypeEmailNotification, ConcurrencyGroup: group}
+	}
+
+	// Take the reporting group's only slot with a run that takes about a second
+	busy := make(chan error, 1)
+	go func() { busy <- executor.ExecuteJob(newJob("reporting")) }()
+	require.Eventually(t, func() bool { return executor.GetRunningJobsCount() == 1 }, time.Second, 10*time.Millisecond)
+
+	// Execute - another reporting job waits for the group, then fails; a job outside it runs
+	other := make(chan error, 1)
+	go func() { other <- executor.ExecuteJob(newJob("")) }()
+	err := executor.ExecuteJob(newJob("reporting"))
+
+	// Assert
+	assert.ErrorIs(t, err, scheduler.ErrConcurrencyGroupFull)
+	assert.Equal(t, scheduler.ConcurrencyGroupStats{Limit: 1, Running: 1}, executor.GetConcurrencyGroups()["reporting"])
+	assert.NoError(t, <-other)
+	assert.NoError(t, <-busy)
+	assert.Equal(t, scheduler.ConcurrencyGroupStats{Limit: 1}, executor.GetConcurrencyGroups()["reporting"])
+
+	var queued *models.JobExecution
+	for _, call := range mockExecutionRepo.Calls {
+		if execution := call.Arguments.Get(0).(*models.JobExecution); execution.Status == models.ExecutionStatusFailed {
+			queued = execution
+		}
+	}
+	require.NotNil(t, queued, "the run that waited is recorded as failed")
+	assert.Contains(t, queued.ErrorMessage.String(), "concurrency group limit reached")
+}
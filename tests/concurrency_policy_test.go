@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/scheduler"
+	"job-scheduler/internal/services"
+)
+
+func newConcurrencyPolicyExecutor() (*scheduler.JobExecutor, *MockJobExecutionRepository) {
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 5, MaxQueueWait: time.Second}}
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	return scheduler.NewJobExecutor(mockExecutionRepo, cfg), mockExecutionRepo
+}
+
+func TestJobExecutor_ForbidSkipsOverlappingRun(t *testing.T) {
+	// Setup - a run of the job that takes about a second is already going
+	executor, mockExecutionRepo := newConcurrencyPolicyExecutor()
+	job := &models.Job{ID: uuid.New(), Name: "Nightly ETL", JobType: models.JobTypeEmailNotification, ConcurrencyPolicy: models.ConcurrencyForbid}
+
+	first := make(chan error, 1)
+	go func() { first <- executor.ExecuteScheduledJob(job, time.Now().UTC()) }()
+	require.Eventually(t, func() bool { return executor.GetRunningJobsCount() == 1 }, time.Second, 10*time.Millisecond)
+
+	// Execute
+	scheduledFor := time.Now().UTC()
+	err := executor.ExecuteScheduledJob(job, scheduledFor)
+
+	// Assert - the overlapping run is recorded as skipped and the previous one finishes
+	assert.ErrorIs(t, err, scheduler.ErrRunSkipped)
+	var skipped *models.JobExecution
+	for _, call := range mockExecutionRepo.Calls {
+		if execution := call.Arguments.Get(0).(*models.JobExecution); execution.Status == models.ExecutionStatusSkipped {
+			skipped = execution
+		}
+	}
+	require.NotNil(t, skipped)
+	assert.Equal(t, job.ID, skipped.JobID)
+	assert.Equal(t, scheduledFor, *skipped.ScheduledFor)
+	assert.True(t, skipped.IsCompleted())
+	assert.NoError(t, <-first)
+
+	// Once the previous run finished, the next occurrence runs
+	assert.NoError(t, executor.ExecuteScheduledJob(job, time.Now().UTC()))
+}
+
+func TestJobExecutor_ReplaceCancelsRunningRun(t *testing.T) {
+	// Setup
+	executor, _ := newConcurrencyPolicyExecutor()
+	job := &models.Job{ID: uuid.New(), Name: "Nightly ETL", JobType: models.JobTypeEmailNotification, ConcurrencyPolicy: models.ConcurrencyReplace}
+
+	first := make(chan error, 1)
+	go func() { first <- executor.ExecuteScheduledJob(job, time.Now().UTC()) }()
+	require.Eventually(t, func() bool { return executor.GetRunningJobsCount() == 1 }, time.Second, 10*time.Millisecond)
+
+	// Execute
+	err := executor.ExecuteScheduledJob(job, time.Now().UTC())
+
+	// Assert - the previous run was cancelled and the new one ran
+	assert.NoError(t, err)
+	assert.ErrorIs(t, <-first, scheduler.ErrExecutionCancelled)
+}
+
+func TestJobService_CreateJob_ValidatesConcurrencyPolicy(t *testing.T) {
+	// Setup
+	mockRepo := new(MockJobRepository)
+	jobService := services.NewJobService(mockRepo)
+
+	// Execute
+	job, err := jobService.CreateJob(&models.CreateJobRequest{
+		Name:              "Nightly ETL",
+		Schedule:          "0 2 * * *",
+		JobType:           models.JobTypeDataProcessing,
+		ConcurrencyPolicy: "sometimes",
+	})
+
+	// Assert
+	assert.EqualError(t, err, "invalid concurrency policy: sometimes")
+	assert.Nil(t, job)
+	mockRepo.AssertNotCalled(t, "Create")
+}
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/integrations"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/scheduler"
+	"job-scheduler/internal/services"
+)
+
+func newConnectionMonitor(manager *integrations.Manager, threshold int) services.ConnectionMonitor {
+	return services.NewConnectionMonitor(manager, nil, config.ConnectionHealthConfig{
+		Enabled:          true,
+		Interval:         time.Minute,
+		Timeout:          time.Second,
+		FailureThreshold: threshold,
+	})
+}
+
+func TestConnectionMonitor_GoesDownAfterThresholdAndRecovers(t *testing.T) {
+	// Setup
+	var probeErr error = errors.New("connection refused")
+	monitor := newConnectionMonitor(nil, 2)
+	monitor.AddProbe("s3", func(ctx context.Context) error { return probeErr })
+
+	// Execute - one failure isn't enough to be down
+	monitor.ProbeConnections(context.Background())
+	_, down := monitor.Unavailable("s3")
+	assert.False(t, down)
+	assert.Equal(t, models.ConnectionStatusUnknown, monitor.GetConnectionHealth()[0].Status)
+
+	// The second failure in a row is
+	monitor.ProbeConnections(context.Background())
+	reason, down := monitor.Unavailable("s3")
+	assert.True(t, down)
+	assert.Equal(t, "connection refused", reason)
+
+	// A single success brings it back up
+	probeErr = nil
+	monitor.ProbeConnections(context.Background())
+	_, down = monitor.Unavailable("s3")
+	assert.False(t, down)
+
+	// Assert
+	health := monitor.GetConnectionHealth()
+	require.Len(t, health, 1)
+	assert.Equal(t, models.ConnectionStatusUp, health[0].Status)
+	assert.Equal(t, int64(3), health[0].Probes)
+	assert.Equal(t, int64(2), health[0].Failures)
+	assert.Equal(t, 0, health[0].ConsecutiveFailures)
+	assert.Empty(t, health[0].LastError)
+}
+
+func TestConnectionMonitor_ProbesIntegrationsAndForgetsRemovedOnes(t *testing.T) {
+	// Setup
+	manager := integrations.NewManager()
+	smtp := &stubResource{pingErr: errors.New("421 service not available")}
+	manager.Register(integrations.SMTPIntegration, func(ctx context.Context) (integrations.Resource, error) {
+		return smtp, nil
+	})
+	monitor := newConnectionMonitor(manager, 1)
+
+	// Execute - the integration is opened to be probed, even though no run used it yet
+	monitor.ProbeConnections(context.Background())
+
+	// Assert - the failed ping closed it so the next use reconnects
+	reason, down := monitor.Unavailable(integrations.SMTPIntegration)
+	assert.True(t, down)
+	assert.Equal(t, "421 service not available", reason)
+	assert.Equal(t, int32(1), smtp.closed)
+
+	// Removed integrations stop being reported
+	require.NoError(t, manager.Remove(integrations.SMTPIntegration))
+	monitor.ProbeConnections(context.Background())
+	assert.Empty(t, monitor.GetConnectionHealth())
+	_, down = monitor.Unavailable(integrations.SMTPIntegration)
+	assert.False(t, down)
+}
+
+func TestJobExecutor_FailsFastWhenDependencyIsDown(t *testing.T) {
+	// Setup
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second}}
+	var created []*models.JobExecution
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Run(func(args mock.Arguments) {
+		created = append(created, args.Get(0).(*models.JobExecution))
+	}).Return(nil)
+
+	monitor := newConnectionMonitor(nil, 1)
+	monitor.AddProbe(integrations.DatabaseIntegration("reports"), func(ctx context.Context) error {
+		return errors.New("dial tcp: connection refused")
+	})
+	monitor.ProbeConnections(context.Background())
+
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	executor.SetConnectionMonitor(monitor)
+	job := &models.Job{ID: uuid.New(), Name: "Nightly export", JobType: models.JobTypeDataProcessing, MaxRetries: 3,
+		Config: models.JobConfig{"connection": "reports", "processing_time_seconds": float64(0)}}
+
+	// Execute
+	err := executor.ExecuteJob(job)
+
+	// Assert - the run is recorded once, finished, and not retried
+	assert.ErrorIs(t, err, scheduler.ErrDependencyUnavailable)
+	require.Len(t, created, 1)
+	assert.Equal(t, models.ExecutionStatusDependencyUnavailable, created[0].Status)
+	assert.NotNil(t, created[0].CompletedAt)
+	assert.Contains(t, created[0].ErrorMessage.String(), "database:reports is down")
+	assert.Zero(t, executor.CancelRetries())
+	mockExecutionRepo.AssertNotCalled(t, "Update", mock.Anything)
+
+	// Jobs that don't use the connection still run
+	other := &models.Job{ID: uuid.New(), Name: "Quick job", JobType: models.JobTypeDataProcessing,
+		Config: models.JobConfig{"processing_time_seconds": float64(0)}}
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	assert.NoError(t, executor.ExecuteJob(other))
+}
@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+func TestDashboardService_GetDashboard_UnhealthiestJobs(t *testing.T) {
+	// Setup - jobs come back least healthy first, then jobs not scored yet
+	score := 35
+	unhealthy := models.Job{ID: uuid.New(), Name: "Flaky ETL", Severity: models.JobSeverityHigh, HealthScore: &score}
+	unscored := models.Job{ID: uuid.New(), Name: "New job"}
+
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetAll", 1, 10, models.JobSortHealth, models.JobFilter{}).Return([]models.Job{unhealthy, unscored}, int64(2), nil)
+	mockJobRepo.On("GetActiveJobs").Return([]models.Job{unhealthy, unscored}, nil)
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("GetRunningExecutions").Return([]models.JobExecution{}, nil)
+	mockExecutionRepo.On("GetAwaitingApproval").Return([]models.JobExecution{}, nil)
+	mockExecutionRepo.On("GetRecentFailures", 20).Return([]models.JobExecution{}, nil)
+
+	service := services.NewDashboardService(mockJobRepo, mockExecutionRepo, nil)
+
+	// Execute
+	dashboard, err := service.GetDashboard(20)
+
+	// Assert - only scored jobs are listed
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), dashboard.TotalJobs)
+	if assert.Len(t, dashboard.UnhealthiestJobs, 1) {
+		assert.Equal(t, unhealthy.ID, dashboard.UnhealthiestJobs[0].JobID)
+		assert.Equal(t, 35, dashboard.UnhealthiestJobs[0].HealthScore)
+	}
+}
+
+func TestDashboardService_GetFailingJobs(t *testing.T) {
+	// Setup
+	failing := []models.FailingJob{{
+		JobID:          uuid.New(),
+		JobName:        "Nightly ETL",
+		Owner:          "data-oncall@example.com",
+		StreakLength:   3,
+		FirstFailureAt: time.Now().UTC().Add(-3 * time.Hour),
+		LastFailureAt:  time.Now().UTC().Add(-time.Hour),
+	}}
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("GetFailingStreaks").Return(failing, nil)
+
+	service := services.NewDashboardService(new(MockJobRepository), mockExecutionRepo, nil)
+
+	// Execute
+	jobs, err := service.GetFailingJobs()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, failing, jobs)
+}
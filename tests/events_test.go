@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/events"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/scheduler"
+)
+
+func TestBus_DeliversMatchingTransitions(t *testing.T) {
+	// Setup
+	bus := events.NewBus()
+	var all, failures []models.ExecutionStatus
+	bus.Subscribe(func(e events.ExecutionTransition) { all = append(all, e.To) })
+	unsubscribe := bus.Subscribe(func(e events.ExecutionTransition) { failures = append(failures, e.To) },
+		models.ExecutionStatusFailed, models.ExecutionStatusStalled)
+
+	// Execute
+	id := uuid.New()
+	bus.Publish(events.ExecutionTransition{ExecutionID: id, From: "", To: models.ExecutionStatusRunning})
+	bus.Publish(events.ExecutionTransition{ExecutionID: id, From: models.ExecutionStatusRunning, To: models.ExecutionStatusFailed})
+	unsubscribe()
+	bus.Publish(events.ExecutionTransition{ExecutionID: id, From: models.ExecutionStatusRunning, To: models.ExecutionStatusStalled})
+
+	// Assert
+	assert.Equal(t, []models.ExecutionStatus{models.ExecutionStatusRunning, models.ExecutionStatusFailed, models.ExecutionStatusStalled}, all)
+	assert.Equal(t, []models.ExecutionStatus{models.ExecutionStatusFailed}, failures)
+}
+
+func TestBus_SurvivesPanickingSubscriber(t *testing.T) {
+	bus := events.NewBus()
+	delivered := false
+	bus.Subscribe(func(events.ExecutionTransition) { panic("subscriber bug") })
+	bus.Subscribe(func(events.ExecutionTransition) { delivered = true })
+
+	assert.NotPanics(t, func() {
+		bus.Publish(events.ExecutionTransition{ExecutionID: uuid.New(), To: models.ExecutionStatusCompleted})
+	})
+	assert.True(t, delivered)
+}
+
+func TestBus_NilBusIgnoresPublish(t *testing.T) {
+	var bus *events.Bus
+	assert.NotPanics(t, func() {
+		bus.Publish(events.ExecutionTransition{ExecutionID: uuid.New(), To: models.ExecutionStatusCompleted})
+	})
+}
+
+func TestJobEventBus_DeliversMatchingEvents(t *testing.T) {
+	// Setup
+	bus := events.NewJobEventBus()
+	var all, failures []events.JobEventType
+	bus.Subscribe(func(e events.JobEvent) { all = append(all, e.Type) })
+	unsubscribe := bus.Subscribe(func(e events.JobEvent) { failures = append(failures, e.Type) }, events.JobEventFailed)
+
+	// Execute
+	jobID := uuid.New()
+	bus.Publish(events.JobEvent{Type: events.JobEventStarted, JobID: jobID})
+	bus.Publish(events.JobEvent{Type: events.JobEventFailed, JobID: jobID})
+	unsubscribe()
+	bus.Publish(events.JobEvent{Type: events.JobEventFailed, JobID: jobID})
+
+	// Assert
+	assert.Equal(t, []events.JobEventType{events.JobEventStarted, events.JobEventFailed, events.JobEventFailed}, all)
+	assert.Equal(t, []events.JobEventType{events.JobEventFailed}, failures)
+}
+
+func TestJobEventBus_StampsEventsAndIgnoresNilBus(t *testing.T) {
+	bus := events.NewJobEventBus()
+	var received events.JobEvent
+	bus.Subscribe(func(e events.JobEvent) { received = e })
+
+	bus.Publish(events.JobEvent{Type: events.JobEventCreated, JobID: uuid.New()})
+	assert.False(t, received.At.IsZero())
+
+	var nilBus *events.JobEventBus
+	assert.NotPanics(t, func() {
+		nilBus.Publish(events.JobEvent{Type: events.JobEventCreated, JobID: uuid.New()})
+	})
+}
+
+func TestJobExecutor_PublishesRunEvents(t *testing.T) {
+	// Setup
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second}}
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+
+	bus := events.NewJobEventBus()
+	var received []events.JobEvent
+	bus.Subscribe(func(e events.JobEvent) { received = append(received, e) })
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	executor.SetJobEvents(bus)
+
+	quick := &models.Job{ID: uuid.New(), Name: "Quick job", JobType: models.JobTypeDataProcessing, Config: models.JobConfig{
+		"processing_time_seconds": float64(0),
+	}}
+	broken := &models.Job{ID: uuid.New(), Name: "Broken job", JobType: "unknown"}
+
+	// Execute
+	assert.NoError(t, executor.ExecuteJob(quick))
+	assert.Error(t, executor.ExecuteJob(broken))
+
+	// Assert
+	if assert.Len(t, received, 4) {
+		assert.Equal(t, events.JobEventStarted, received[0].Type)
+		assert.Equal(t, events.JobEventCompleted, received[1].Type)
+		assert.Equal(t, quick.ID, received[1].JobID)
+		assert.NotNil(t, received[1].ExecutionID)
+		assert.Equal(t, events.JobEventStarted, received[2].Type)
+		assert.Equal(t, events.JobEventFailed, received[3].Type)
+		assert.Contains(t, received[3].Error, "no executor found")
+	}
+}
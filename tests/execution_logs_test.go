@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/scheduler"
+	"job-scheduler/internal/services"
+)
+
+// memoryExecutionLogRepository keeps run log lines in memory
+type memoryExecutionLogRepository struct {
+	mu    sync.Mutex
+	lines []models.JobExecutionLog
+}
+
+func (r *memoryExecutionLogRepository) CreateBatch(lines []models.JobExecutionLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lines = append(r.lines, lines...)
+	return nil
+}
+
+func (r *memoryExecutionLogRepository) GetByExecutionID(executionID uuid.UUID, after, limit int) ([]models.JobExecutionLog, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var lines []models.JobExecutionLog
+	for _, line := range r.lines {
+		if line.ExecutionID == executionID && line.Sequence > after && len(lines) < limit {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+func TestRunLog_CapturesLines(t *testing.T) {
+	repo := &memoryExecutionLogRepository{}
+	executionID := uuid.New()
+	ctx, runLog := services.StartRunLog(context.Background(), repo, executionID, logrus.Fields{"job_name": "Nightly"},
+		config.ExecutionLogsConfig{MaxLines: 10, FlushInterval: time.Hour})
+
+	services.RunLogger(ctx).WithField("rows", 3).Info("Processing rows")
+	services.RunLogger(ctx).Warn("Slow upstream")
+	runLog.Close()
+	services.RunLogger(ctx).Info("Logged after close")
+
+	lines, _ := repo.GetByExecutionID(executionID, 0, 100)
+	if assert.Len(t, lines, 2) {
+		assert.Equal(t, 1, lines[0].Sequence)
+		assert.Equal(t, "info", lines[0].Level)
+		assert.Equal(t, "Processing rows", lines[0].Message)
+		assert.Equal(t, "Nightly", lines[0].Fields["job_name"])
+		assert.Equal(t, 3, lines[0].Fields["rows"])
+		assert.Equal(t, 2, lines[1].Sequence)
+		assert.Equal(t, "warning", lines[1].Level)
+	}
+}
+
+func TestRunLog_TruncatesBeyondMaxLines(t *testing.T) {
+	repo := &memoryExecutionLogRepository{}
+	executionID := uuid.New()
+	ctx, runLog := services.StartRunLog(context.Background(), repo, executionID, nil,
+		config.ExecutionLogsConfig{MaxLines: 3, FlushInterval: time.Hour})
+
+	for i := 0; i < 10; i++ {
+		services.RunLogger(ctx).Info("Line")
+	}
+	runLog.Close()
+
+	lines, _ := repo.GetByExecutionID(executionID, 0, 100)
+	if assert.Len(t, lines, 4) {
+		assert.Equal(t, "Log truncated after 3 lines", lines[3].Message)
+	}
+}
+
+func TestRunLogger_WithoutRunLog(t *testing.T) {
+	assert.NotNil(t, services.RunLogger(context.Background()))
+}
+
+func TestJobExecutor_StoresRunLogs(t *testing.T) {
+	// Setup
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second}}
+	repo := &memoryExecutionLogRepository{}
+	var executionID uuid.UUID
+	linesAtFinish := -1
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Run(func(args mock.Arguments) {
+		execution := args.Get(0).(*models.JobExecution)
+		if execution.IsCompleted() {
+			executionID = execution.ID
+			lines, _ := repo.GetByExecutionID(execution.ID, 0, 100)
+			linesAtFinish = len(lines)
+		}
+	}).Return(nil)
+
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	executor.SetExecutionLogs(repo)
+	job := &models.Job{ID: uuid.New(), Name: "Quick job", JobType: models.JobTypeDataProcessing, Config: models.JobConfig{
+		"processing_time_seconds": float64(0),
+	}}
+
+	// Execute
+	assert.NoError(t, executor.ExecuteJob(job))
+
+	// Assert - the run's lines are stored before it is recorded as finished
+	assert.Greater(t, linesAtFinish, 0)
+	lines, _ := repo.GetByExecutionID(executionID, 0, 100)
+	if assert.NotEmpty(t, lines) {
+		assert.Equal(t, job.ID, lines[0].Fields["job_id"])
+		assert.Equal(t, 1, lines[0].Fields["attempt"])
+	}
+}
+
+func TestExecutionLogService_GetExecutionLogs(t *testing.T) {
+	// Setup
+	repo := &memoryExecutionLogRepository{}
+	execution := &models.JobExecution{ID: uuid.New(), Status: models.ExecutionStatusRunning}
+	for i := 1; i <= 5; i++ {
+		repo.lines = append(repo.lines, models.JobExecutionLog{ExecutionID: execution.ID, Sequence: i, Level: "info", Message: "Line"})
+	}
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("GetByID", execution.ID).Return(execution, nil)
+	service := services.NewExecutionLogService(repo, mockExecutionRepo)
+
+	// Execute
+	first, err := service.GetExecutionLogs(execution.ID, 0, 3)
+	assert.NoError(t, err)
+	execution.Status = models.ExecutionStatusCompleted
+	rest, err := service.GetExecutionLogs(execution.ID, first.NextAfter, 3)
+	assert.NoError(t, err)
+	empty, err := service.GetExecutionLogs(execution.ID, rest.NextAfter, 3)
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Len(t, first.Lines, 3)
+	assert.Equal(t, 3, first.NextAfter)
+	assert.False(t, first.Finished)
+	assert.Len(t, rest.Lines, 2)
+	assert.Equal(t, 5, rest.NextAfter)
+	assert.True(t, rest.Finished)
+	assert.Empty(t, empty.Lines)
+	assert.Equal(t, 5, empty.NextAfter)
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/scheduler"
+	"job-scheduler/internal/services"
+)
+
+func TestOutputRecorder_Set(t *testing.T) {
+	recorder := services.NewOutputRecorder()
+	ctx := services.WithOutputRecorder(context.Background(), recorder)
+
+	services.RecordOutput(ctx, "rows_processed", 42)
+	services.RecordOutput(ctx, "response_body", []byte(strings.Repeat("x", models.MaxExecutionOutputValueLength+10)))
+	services.RecordOutput(ctx, "callback", func() {})
+
+	output := recorder.Output()
+	assert.Equal(t, 42, output["rows_processed"])
+	assert.True(t, strings.HasSuffix(output["response_body"].(string), "...(truncated)"))
+	assert.Len(t, output["response_body"], models.MaxExecutionOutputValueLength+len("...(truncated)"))
+	// A value that can't be stored as JSON is kept as text
+	assert.IsType(t, "", output["callback"])
+}
+
+func TestOutputRecorder_DropsKeysBeyondLimit(t *testing.T) {
+	recorder := services.NewOutputRecorder()
+	for i := 0; i < models.MaxExecutionOutputKeys+3; i++ {
+		recorder.Set(fmt.Sprintf("key_%d", i), i)
+	}
+	// Replacing a recorded key is still allowed
+	recorder.Set("key_0", "replaced")
+
+	output := recorder.Output()
+	assert.Len(t, output, models.MaxExecutionOutputKeys+1)
+	assert.Equal(t, 3, output["_dropped_keys"])
+	assert.Equal(t, "replaced", output["key_0"])
+}
+
+func TestRecordOutput_WithoutRecorder(t *testing.T) {
+	assert.NotPanics(t, func() {
+		services.RecordOutput(context.Background(), "ignored", true)
+	})
+	assert.Nil(t, services.NewOutputRecorder().Output())
+}
+
+func TestJobExecutor_StoresExecutorOutput(t *testing.T) {
+	// Setup
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second}}
+	var finished *models.JobExecution
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Run(func(args mock.Arguments) {
+		finished = args.Get(0).(*models.JobExecution)
+	}).Return(nil)
+
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	job := &models.Job{ID: uuid.New(), Name: "Quick job", JobType: models.JobTypeDataProcessing, Config: models.JobConfig{
+		"processing_time_seconds": float64(0),
+		"operation":               "aggregate",
+	}}
+
+	// Execute
+	assert.NoError(t, executor.ExecuteJob(job))
+
+	// Assert
+	if assert.NotNil(t, finished) {
+		assert.Equal(t, models.ExecutionStatusCompleted, finished.Status)
+		assert.Equal(t, "aggregate", finished.Output["operation"])
+		assert.Equal(t, "1MB", finished.Output["data_size"])
+	}
+}
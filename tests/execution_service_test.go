@@ -0,0 +1,372 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/handlers"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/notifications"
+	"job-scheduler/internal/scheduler"
+	"job-scheduler/internal/services"
+)
+
+// stubRunController is a RunController for runs that are, or aren't, executing here
+type stubRunController bool
+
+func (s stubRunController) CancelRun(executionID uuid.UUID, force bool) bool {
+	return bool(s)
+}
+
+func (s stubRunController) ExtendRun(executionID uuid.UUID, by time.Duration) (time.Time, error) {
+	if !s {
+		return time.Time{}, services.ErrExecutionNotRunning
+	}
+	return time.Now().UTC().Add(by), nil
+}
+
+func (s stubRunController) SetRunDeadline(executionID uuid.UUID, deadline time.Time) (time.Time, error) {
+	if !s {
+		return time.Time{}, services.ErrExecutionNotRunning
+	}
+	return deadline, nil
+}
+
+func TestJobExecutor_CancelExecutionStopsExecutor(t *testing.T) {
+	// Setup
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second}}
+	var recorded []models.JobExecution
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Run(func(args mock.Arguments) {
+		recorded = append(recorded, *args.Get(0).(*models.JobExecution))
+	}).Return(nil)
+
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	job := &models.Job{ID: uuid.New(), Name: "Slow job", JobType: models.JobTypeDataProcessing, Config: models.JobConfig{
+		"processing_time_seconds": float64(60),
+	}}
+
+	done := make(chan error, 1)
+	go func() { done <- executor.ExecuteJob(job) }()
+	assert.Eventually(t, func() bool { return executor.GetRunningJobsCount() == 1 }, time.Second, 10*time.Millisecond)
+
+	// Execute
+	running := executor.GetRunningJobs()[0]
+	assert.True(t, executor.CancelExecution(running.ID, false))
+
+	// Assert - the executor stops well before its 60 seconds of work
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, scheduler.ErrExecutionCancelled)
+	case <-time.After(time.Second):
+		t.Fatal("expected the cancelled run to stop")
+	}
+	last := recorded[len(recorded)-1]
+	assert.Equal(t, models.ExecutionStatusCancelled, last.Status)
+	if assert.NotNil(t, last.Termination) {
+		assert.Equal(t, models.ExecutionTerminationGraceful, *last.Termination)
+	}
+	assert.Equal(t, 0, executor.GetRunningJobsCount())
+	assert.False(t, executor.CancelExecution(running.ID, false))
+}
+
+func TestJobExecutor_ForcedCancelRecordsTermination(t *testing.T) {
+	// Setup
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second}}
+	var recorded []models.JobExecution
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Run(func(args mock.Arguments) {
+		recorded = append(recorded, *args.Get(0).(*models.JobExecution))
+	}).Return(nil)
+
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	job := &models.Job{ID: uuid.New(), Name: "Slow job", JobType: models.JobTypeDataProcessing, Config: models.JobConfig{
+		"processing_time_seconds": float64(60),
+	}}
+
+	done := make(chan error, 1)
+	go func() { done <- executor.ExecuteJob(job) }()
+	assert.Eventually(t, func() bool { return executor.GetRunningJobsCount() == 1 }, time.Second, 10*time.Millisecond)
+
+	// Execute
+	assert.True(t, executor.CancelExecution(executor.GetRunningJobs()[0].ID, true))
+
+	// Assert - the run is cancelled without waiting out the grace period and says how it stopped
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, scheduler.ErrExecutionCancelled)
+	case <-time.After(time.Second):
+		t.Fatal("expected the killed run to stop")
+	}
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, models.ExecutionStatusCancelled, recorded[0].Status)
+	assert.NotNil(t, recorded[0].Termination)
+}
+
+func TestExecutionService_CancelExecution(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      models.ExecutionStatus
+		runningHere bool
+		expectedErr error
+	}{
+		{"running", models.ExecutionStatusRunning, true, nil},
+		{"running on another instance", models.ExecutionStatusRunning, false, services.ErrExecutionNotRunning},
+		{"already finished", models.ExecutionStatusCompleted, true, services.ErrExecutionFinished},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			execution := &models.JobExecution{ID: uuid.New(), JobID: uuid.New(), Status: tc.status}
+			mockExecutionRepo := new(MockJobExecutionRepository)
+			mockExecutionRepo.On("GetByID", execution.ID).Return(execution, nil)
+			service := services.NewExecutionService(new(MockJobRepository), mockExecutionRepo, stubRunController(tc.runningHere))
+
+			// Execute
+			result, err := service.CancelExecution(execution.ID, false)
+
+			// Assert
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, execution.ID, result.ID)
+			} else {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestExecutionService_GetJobExecutionsPaginates(t *testing.T) {
+	// Setup
+	jobID := uuid.New()
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetByID", jobID).Return(&models.Job{ID: jobID}, nil)
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	executions := []models.JobExecution{{ID: uuid.New(), JobID: jobID}, {ID: uuid.New(), JobID: jobID}}
+	mockExecutionRepo.On("GetByJobID", jobID, 2, 2).Return(executions, int64(5), nil)
+	service := services.NewExecutionService(mockJobRepo, mockExecutionRepo, stubRunController(false))
+
+	// Execute
+	list, err := service.GetJobExecutions(context.Background(), jobID, 2, 2, true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, list.Executions, 2)
+	assert.Equal(t, int64(5), list.TotalCount)
+	assert.Equal(t, 3, list.TotalPages)
+}
+
+func TestExecutionService_GetJobExecutionsEstimatesTotal(t *testing.T) {
+	jobID := uuid.New()
+	fullPage := []models.JobExecution{{ID: uuid.New(), JobID: jobID}, {ID: uuid.New(), JobID: jobID}}
+
+	tests := []struct {
+		name          string
+		page          []models.JobExecution
+		estimate      int64
+		exactCount    int64
+		expectedTotal int64
+		estimated     bool
+	}{
+		{"many runs are estimated", fullPage, 250000, 0, 250000, true},
+		{"few runs are counted", fullPage, 900, 870, 870, false},
+		{"last page gives the total", fullPage[:1], 0, 0, 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockJobRepo := new(MockJobRepository)
+			mockJobRepo.On("GetByID", jobID).Return(&models.Job{ID: jobID}, nil)
+			mockExecutionRepo := new(MockJobExecutionRepository)
+			mockExecutionRepo.On("GetPageByJobID", jobID, 2, 2).Return(tt.page, nil)
+			mockExecutionRepo.On("EstimateCountByJobID", jobID).Return(tt.estimate, nil)
+			mockExecutionRepo.On("CountByJobID", jobID).Return(tt.exactCount, nil)
+			service := services.NewExecutionService(mockJobRepo, mockExecutionRepo, stubRunController(false))
+
+			// Execute
+			list, err := service.GetJobExecutions(context.Background(), jobID, 2, 2, false)
+
+			// Assert - the exact count of every run is never taken for a large job
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTotal, list.TotalCount)
+			assert.Equal(t, tt.estimated, list.TotalCountEstimated)
+			mockExecutionRepo.AssertNotCalled(t, "GetByJobID", mock.Anything, mock.Anything, mock.Anything)
+			if tt.estimated {
+				mockExecutionRepo.AssertNotCalled(t, "CountByJobID", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestExecutionHandler_CountJobExecutions(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	jobID := uuid.New()
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetByID", jobID).Return(&models.Job{ID: jobID}, nil)
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("CountByJobID", jobID).Return(int64(123456), nil)
+	router := gin.New()
+	handlers.NewExecutionHandler(services.NewExecutionService(mockJobRepo, mockExecutionRepo, stubRunController(false))).
+		RegisterRoutes(router.Group("/api/v1"))
+
+	// Execute
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodHead, "/api/v1/jobs/"+jobID.String()+"/executions", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "123456", w.Header().Get(handlers.TotalCountHeader))
+	assert.Empty(t, w.Body.String())
+}
+
+func TestExecutionService_GetRecentExecutionsDefaultsLimit(t *testing.T) {
+	// Setup
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("GetRecentExecutions", 20).Return([]models.JobExecution{}, nil)
+	service := services.NewExecutionService(new(MockJobRepository), mockExecutionRepo, stubRunController(false))
+
+	// Execute
+	_, err := service.GetRecentExecutions(context.Background(), 1000)
+
+	// Assert
+	assert.NoError(t, err)
+	mockExecutionRepo.AssertExpectations(t)
+}
+
+func TestJobExecutor_TimeoutWarningAndExtension(t *testing.T) {
+	// Setup - a 2 second run with a 1 second timeout, warned at 50%, that may run for 3 seconds
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{
+		MaxConcurrentJobs:     1,
+		MaxQueueWait:          time.Second,
+		ExecutionTimeout:      time.Second,
+		MaxExecutionTime:      3 * time.Second,
+		TimeoutWarningPercent: 50,
+	}}
+	var recorded []models.ExecutionStatus
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Run(func(args mock.Arguments) {
+		recorded = append(recorded, args.Get(0).(*models.JobExecution).Status)
+	}).Return(nil)
+
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	notifier := make(channelNotifier, 1)
+	executor.SetNotifier(notifier)
+	job := &models.Job{ID: uuid.New(), Name: "Long job", JobType: models.JobTypeDataProcessing, Config: models.JobConfig{
+		"processing_time_seconds": float64(2),
+	}}
+
+	done := make(chan error, 1)
+	go func() { done <- executor.ExecuteJob(job) }()
+
+	// Execute - extend the run once operators are warned
+	var warning notifications.Notification
+	select {
+	case warning = <-notifier:
+	case <-time.After(time.Second):
+		t.Fatal("expected a timeout warning")
+	}
+	assert.Equal(t, notifications.EventTimeoutWarning, warning.Event)
+
+	running := executor.GetRunningJobs()[0]
+	deadline, err := executor.ExtendExecution(running.ID, time.Hour)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, running.StartedAt.Add(3*time.Second), deadline, 100*time.Millisecond)
+
+	// The deadline can't move past the maximum execution time
+	_, err = executor.ExtendExecution(running.ID, time.Minute)
+	assert.ErrorIs(t, err, services.ErrExtensionLimit)
+
+	// Assert - the extended run completes instead of timing out
+	assert.NoError(t, <-done)
+	assert.Equal(t, models.ExecutionStatusCompleted, recorded[len(recorded)-1])
+
+	_, err = executor.ExtendExecution(running.ID, time.Minute)
+	assert.ErrorIs(t, err, services.ErrExecutionNotRunning)
+}
+
+func TestExecutionService_ExtendExecution(t *testing.T) {
+	execution := &models.JobExecution{ID: uuid.New(), Status: models.ExecutionStatusRunning}
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("GetByID", execution.ID).Return(execution, nil)
+
+	service := services.NewExecutionService(new(MockJobRepository), mockExecutionRepo, stubRunController(true))
+
+	_, _, err := service.ExtendExecution(execution.ID, 0)
+	assert.ErrorIs(t, err, services.ErrInvalidExtension)
+
+	result, deadline, err := service.ExtendExecution(execution.ID, 10*time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, execution, result)
+	assert.WithinDuration(t, time.Now().UTC().Add(10*time.Minute), deadline, time.Second)
+}
+
+func TestJobExecutor_SetExecutionDeadlineShortensRun(t *testing.T) {
+	// Setup - a 2 second run with a generous timeout
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{
+		MaxConcurrentJobs: 1,
+		MaxQueueWait:      time.Second,
+		ExecutionTimeout:  time.Minute,
+		MaxExecutionTime:  time.Hour,
+	}}
+	var recorded []models.ExecutionStatus
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Run(func(args mock.Arguments) {
+		recorded = append(recorded, args.Get(0).(*models.JobExecution).Status)
+	}).Return(nil)
+
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	job := &models.Job{ID: uuid.New(), Name: "Long job", JobType: models.JobTypeDataProcessing, Config: models.JobConfig{
+		"processing_time_seconds": float64(2),
+	}}
+
+	done := make(chan error, 1)
+	go func() { done <- executor.ExecuteJob(job) }()
+	assert.Eventually(t, func() bool { return len(executor.GetRunningJobs()) == 1 }, time.Second, 10*time.Millisecond)
+	running := executor.GetRunningJobs()[0]
+
+	// A deadline in the past is rejected
+	_, err := executor.SetExecutionDeadline(running.ID, time.Now().Add(-time.Second))
+	assert.ErrorIs(t, err, services.ErrInvalidDeadline)
+
+	// Execute - pull the deadline in so the run times out early
+	deadline, err := executor.SetExecutionDeadline(running.ID, time.Now().Add(200*time.Millisecond))
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(200*time.Millisecond), deadline, 100*time.Millisecond)
+
+	// Assert - the run stops at the new deadline rather than completing
+	assert.Error(t, <-done)
+	assert.NotEqual(t, models.ExecutionStatusCompleted, recorded[len(recorded)-1])
+}
+
+func TestExecutionService_SetExecutionDeadline(t *testing.T) {
+	execution := &models.JobExecution{ID: uuid.New(), Status: models.ExecutionStatusRunning}
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("GetByID", execution.ID).Return(execution, nil)
+
+	service := services.NewExecutionService(new(MockJobRepository), mockExecutionRepo, stubRunController(true))
+
+	_, _, err := service.SetExecutionDeadline(execution.ID, time.Now().Add(-time.Minute))
+	assert.ErrorIs(t, err, services.ErrInvalidDeadline)
+
+	want := time.Now().UTC().Add(5 * time.Minute)
+	result, deadline, err := service.SetExecutionDeadline(execution.ID, want)
+	assert.NoError(t, err)
+	assert.Equal(t, execution, result)
+	assert.Equal(t, want, deadline)
+}
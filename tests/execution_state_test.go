@@ -0,0 +1,224 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/scheduler"
+)
+
+func TestCanTransition(t *testing.T) {
+	testCases := []struct {
+		from     models.ExecutionStatus
+		to       models.ExecutionStatus
+		expected bool
+	}{
+		{"", models.ExecutionStatusRunning, true},
+		{"", models.ExecutionStatusCompleted, false},
+		{models.ExecutionStatusPending, models.ExecutionStatusRunning, true},
+		{models.ExecutionStatusPending, models.ExecutionStatusCompleted, false},
+		{models.ExecutionStatusRunning, models.ExecutionStatusCompleted, true},
+		{models.ExecutionStatusRunning, models.ExecutionStatusPending, false},
+		{models.ExecutionStatusAwaitingApproval, models.ExecutionStatusPending, true},
+		{models.ExecutionStatusAwaitingApproval, models.ExecutionStatusRunning, false},
+		{models.ExecutionStatusCompleted, models.ExecutionStatusFailed, false},
+		{models.ExecutionStatusCancelled, models.ExecutionStatusRunning, false},
+		{models.ExecutionStatusExpired, models.ExecutionStatusPending, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.from)+"->"+string(tc.to), func(t *testing.T) {
+			assert.Equal(t, tc.expected, models.CanTransition(tc.from, tc.to))
+		})
+	}
+}
+
+func TestJobExecution_MarkAsRejectsIllegalTransitions(t *testing.T) {
+	execution := &models.JobExecution{Status: models.ExecutionStatusPending}
+
+	assert.NoError(t, execution.MarkAsRunning())
+	assert.False(t, execution.StartedAt.IsZero())
+	assert.NoError(t, execution.MarkAsCompleted())
+	assert.NotNil(t, execution.CompletedAt)
+	assert.NotNil(t, execution.ExecutionDuration)
+
+	// A finished run keeps its outcome
+	err := execution.MarkAsFailed("too late")
+	assert.ErrorIs(t, err, models.ErrIllegalTransition)
+	assert.Equal(t, models.ExecutionStatusCompleted, execution.Status)
+	assert.Nil(t, execution.ErrorMessage)
+}
+
+func TestJobExecutor_WritesRunOncePerTransition(t *testing.T) {
+	// Setup
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second}}
+	var created, updated []models.ExecutionStatus
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Run(func(args mock.Arguments) {
+		created = append(created, args.Get(0).(*models.JobExecution).Status)
+	}).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Run(func(args mock.Arguments) {
+		updated = append(updated, args.Get(0).(*models.JobExecution).Status)
+	}).Return(nil)
+
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	job := &models.Job{ID: uuid.New(), Name: "Quick job", JobType: models.JobTypeDataProcessing, Config: models.JobConfig{
+		"processing_time_seconds": float64(0),
+	}}
+
+	// Execute
+	err := executor.ExecuteJob(job)
+
+	// Assert - the run is created already running, then finished with one update
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ExecutionStatus{models.ExecutionStatusRunning}, created)
+	assert.Equal(t, []models.ExecutionStatus{models.ExecutionStatusCompleted}, updated)
+}
+
+func TestJobExecution_UnsavedTransitionKeepsFirstStatus(t *testing.T) {
+	execution := &models.JobExecution{Status: models.ExecutionStatusPending}
+
+	_, transitioned := execution.UnsavedTransition()
+	assert.False(t, transitioned)
+
+	assert.NoError(t, execution.MarkAsRunning())
+	assert.NoError(t, execution.MarkAsFailed("boom"))
+	from, transitioned := execution.UnsavedTransition()
+	assert.True(t, transitioned)
+	assert.Equal(t, models.ExecutionStatusPending, from)
+
+	execution.MarkSaved()
+	_, transitioned = execution.UnsavedTransition()
+	assert.False(t, transitioned)
+}
+
+func TestJobExecutor_MarkStalledRuns(t *testing.T) {
+	// Setup
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second}}
+	old := models.JobExecution{ID: uuid.New(), Status: models.ExecutionStatusRunning, StartedAt: time.Now().UTC().Add(-2 * time.Hour)}
+	recent := models.JobExecution{ID: uuid.New(), Status: models.ExecutionStatusRunning, StartedAt: time.Now().UTC()}
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("GetRunningExecutions").Return([]models.JobExecution{old, recent}, nil)
+	mockExecutionRepo.On("Update", mock.MatchedBy(func(e *models.JobExecution) bool {
+		return e.ID == old.ID && e.Status == models.ExecutionStatusStalled
+	})).Return(nil).Once()
+	var failed *models.JobExecution
+	mockExecutionRepo.On("Update", mock.MatchedBy(func(e *models.JobExecution) bool {
+		return e.ID == old.ID && e.Status == models.ExecutionStatusFailed
+	})).Run(func(args mock.Arguments) {
+		failed = args.Get(0).(*models.JobExecution)
+	}).Return(nil).Once()
+
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+
+	// Execute
+	stalled, err := executor.MarkStalledRuns()
+
+	// Assert - only the run older than any instance would run it is stalled, then failed with the reason
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stalled)
+	mockExecutionRepo.AssertNumberOfCalls(t, "Update", 2)
+	require.NotNil(t, failed)
+	assert.Contains(t, failed.ErrorMessage.String(), "Run stalled - still recorded as running 2h0m0s after it started, past the 1h0m0s maximum execution time")
+}
+
+func TestJobExecutor_MarkStalledRunsByTheirJobsTimeout(t *testing.T) {
+	// Setup - two runs started half an hour ago, well within the maximum execution time; one's job times
+	// its runs out after ten minutes
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second}}
+	startedAt := time.Now().UTC().Add(-30 * time.Minute)
+	timedOut := models.JobExecution{ID: uuid.New(), Job: models.Job{TimeoutSeconds: 600}, Status: models.ExecutionStatusRunning, StartedAt: startedAt}
+	unlimited := models.JobExecution{ID: uuid.New(), Status: models.ExecutionStatusRunning, StartedAt: startedAt}
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("GetRunningExecutions").Return([]models.JobExecution{timedOut, unlimited}, nil)
+	var failed *models.JobExecution
+	mockExecutionRepo.On("Update", mock.MatchedBy(func(e *models.JobExecution) bool {
+		return e.ID == timedOut.ID
+	})).Run(func(args mock.Arguments) {
+		failed = args.Get(0).(*models.JobExecution)
+	}).Return(nil).Twice()
+
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+
+	// Execute
+	stalled, err := executor.MarkStalledRuns()
+
+	// Assert - only the run past its job's own timeout is stalled, then failed naming that timeout
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stalled)
+	mockExecutionRepo.AssertNumberOfCalls(t, "Update", 2)
+	require.NotNil(t, failed)
+	assert.Equal(t, models.ExecutionStatusFailed, failed.Status)
+	assert.Contains(t, failed.ErrorMessage.String(), "after it started, past its job's 10m0s timeout")
+}
+
+func TestJobExecutor_MarkStalledRunsRetriesThem(t *testing.T) {
+	// Setup - the stalled run's job has retries left and stalled runs are retried
+	custom := &blockingExecutor{jobType: "test_stalled_retry", started: make(chan struct{}, 1)}
+	require.NoError(t, scheduler.RegisterExecutor(custom.jobType, custom))
+	job := models.Job{ID: uuid.New(), Name: "Nightly import", JobType: custom.jobType, MaxRetries: 1}
+	old := models.JobExecution{ID: uuid.New(), JobID: job.ID, Job: job, Status: models.ExecutionStatusRunning, Attempt: 1,
+		StartedAt: time.Now().UTC().Add(-2 * time.Hour)}
+
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("GetRunningExecutions").Return([]models.JobExecution{old}, nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	var retry *models.JobExecution
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Run(func(args mock.Arguments) {
+		retry = args.Get(0).(*models.JobExecution)
+	}).Return(nil)
+
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second, RetryStalledRuns: true}}
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+
+	// Execute
+	stalled, err := executor.MarkStalledRuns()
+
+	// Assert - the retry runs as the next attempt
+	require.NoError(t, err)
+	assert.Equal(t, 1, stalled)
+	select {
+	case <-custom.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stalled run wasn't retried")
+	}
+	require.NotNil(t, retry)
+	assert.Equal(t, 2, retry.Attempt)
+	assert.Equal(t, old.ID, *retry.RetryOfID)
+	executor.CancelExecution(retry.ID, true)
+}
+
+func TestJobExecutor_ScheduledRunRecordsOccurrence(t *testing.T) {
+	// Setup
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second}}
+	var created []*models.JobExecution
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Run(func(args mock.Arguments) {
+		created = append(created, args.Get(0).(*models.JobExecution))
+	}).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	job := &models.Job{ID: uuid.New(), Name: "Quick job", JobType: models.JobTypeDataProcessing, Config: models.JobConfig{
+		"processing_time_seconds": float64(0),
+	}}
+	occurrence := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	// Execute - one scheduled run and one triggered outside the schedule
+	assert.NoError(t, executor.ExecuteScheduledJob(job, occurrence))
+	assert.NoError(t, executor.ExecuteJob(job))
+
+	// Assert - only the scheduled run is pinned to its occurrence, and its retries keep it
+	if assert.Len(t, created, 2) {
+		assert.Equal(t, &occurrence, created[0].ScheduledFor)
+		assert.Nil(t, created[1].ScheduledFor)
+		assert.Equal(t, &occurrence, created[0].NextAttempt().ScheduledFor)
+	}
+}
@@ -0,0 +1,313 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/handlers"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// MockJobExecutionRepository is a mock implementation of JobExecutionRepository
+type MockJobExecutionRepository struct {
+	mock.Mock
+}
+
+func (m *MockJobExecutionRepository) Create(execution *models.JobExecution) error {
+	args := m.Called(execution)
+	return args.Error(0)
+}
+
+func (m *MockJobExecutionRepository) GetByID(id uuid.UUID) (*models.JobExecution, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.JobExecution), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) GetByJobID(ctx context.Context, jobID uuid.UUID, page, limit int) ([]models.JobExecution, int64, error) {
+	args := m.Called(jobID, page, limit)
+	return args.Get(0).([]models.JobExecution), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockJobExecutionRepository) Update(execution *models.JobExecution) error {
+	args := m.Called(execution)
+	return args.Error(0)
+}
+
+func (m *MockJobExecutionRepository) Delete(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockJobExecutionRepository) GetRunningExecutions() ([]models.JobExecution, error) {
+	args := m.Called()
+	return args.Get(0).([]models.JobExecution), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) GetExecutionStats(jobID uuid.UUID) (*models.JobExecutionStats, error) {
+	args := m.Called(jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.JobExecutionStats), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) GetRecentExecutions(ctx context.Context, limit int) ([]models.JobExecution, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]models.JobExecution), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) GetInterruptedExecutions() ([]models.JobExecution, error) {
+	args := m.Called()
+	return args.Get(0).([]models.JobExecution), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) GetByWorkflowExecution(workflowExecutionID uuid.UUID) ([]models.JobExecution, error) {
+	args := m.Called(workflowExecutionID)
+	return args.Get(0).([]models.JobExecution), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) GetAwaitingApproval() ([]models.JobExecution, error) {
+	args := m.Called()
+	return args.Get(0).([]models.JobExecution), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) GetExpiredApprovals(now time.Time) ([]models.JobExecution, error) {
+	args := m.Called(now)
+	return args.Get(0).([]models.JobExecution), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) GetRecentFailures(limit int) ([]models.JobExecution, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]models.JobExecution), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) GetOverallStats(failuresSince time.Time) (*models.OverallExecutionStats, error) {
+	args := m.Called(failuresSince)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OverallExecutionStats), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) GetStatsByJobType(since time.Time) ([]models.JobTypeExecutionStats, error) {
+	args := m.Called(since)
+	return args.Get(0).([]models.JobTypeExecutionStats), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) GetPageByJobID(ctx context.Context, jobID uuid.UUID, page, limit int) ([]models.JobExecution, error) {
+	args := m.Called(jobID, page, limit)
+	return args.Get(0).([]models.JobExecution), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) CountByJobID(ctx context.Context, jobID uuid.UUID) (int64, error) {
+	args := m.Called(jobID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) EstimateCountByJobID(ctx context.Context, jobID uuid.UUID) (int64, error) {
+	args := m.Called(jobID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) GetThroughput(from, to time.Time, interval time.Duration) ([]models.ThroughputBucket, error) {
+	args := m.Called(from, to, interval)
+	return args.Get(0).([]models.ThroughputBucket), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) GetWithResourceUsage(jobID uuid.UUID, limit int) ([]models.JobExecution, error) {
+	args := m.Called(jobID, limit)
+	return args.Get(0).([]models.JobExecution), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) GetRecentFinished(jobID uuid.UUID, limit int) ([]models.JobExecution, error) {
+	args := m.Called(jobID, limit)
+	return args.Get(0).([]models.JobExecution), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) GetLatestStartTimes(jobIDs []uuid.UUID) (map[uuid.UUID]time.Time, error) {
+	args := m.Called(jobIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]time.Time), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) GetFailingStreaks() ([]models.FailingJob, error) {
+	args := m.Called()
+	return args.Get(0).([]models.FailingJob), args.Error(1)
+}
+
+func (m *MockJobExecutionRepository) DeleteFinishedBefore(jobID uuid.UUID, before time.Time, limit int) (int64, error) {
+	args := m.Called(jobID, before, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestExecutionStatsService_GetResourceUsage(t *testing.T) {
+	// Setup
+	mockJobRepo := new(MockJobRepository)
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	service := services.NewExecutionStatsService(mockJobRepo, mockExecutionRepo)
+
+	job := &models.Job{ID: uuid.New(), Name: "Nightly ETL"}
+	cpuTime := int64(120)
+	now := time.Now().UTC()
+	newest := models.JobExecution{ID: uuid.New(), JobID: job.ID, StartedAt: now, Status: models.ExecutionStatusCompleted,
+		ResourceUsage: &models.ResourceUsage{Source: models.ResourceUsageSourceProcess, AllocatedBytes: 2048, CPUTimeMs: &cpuTime}}
+	oldest := models.JobExecution{ID: uuid.New(), JobID: job.ID, StartedAt: now.Add(-time.Hour), Status: models.ExecutionStatusFailed,
+		ResourceUsage: &models.ResourceUsage{Source: models.ResourceUsageSourceProcess, AllocatedBytes: 1024}}
+
+	mockJobRepo.On("GetByID", job.ID).Return(job, nil)
+	mockExecutionRepo.On("GetWithResourceUsage", job.ID, 100).Return([]models.JobExecution{newest, oldest}, nil)
+
+	// Execute - an out of range limit falls back to the default
+	points, err := service.GetResourceUsage(job.ID, 0)
+
+	// Assert - points are oldest first for graphing
+	assert.NoError(t, err)
+	if assert.Len(t, points, 2) {
+		assert.Equal(t, oldest.ID, points[0].ExecutionID)
+		assert.Equal(t, newest.ID, points[1].ExecutionID)
+		assert.Equal(t, uint64(2048), points[1].Usage.AllocatedBytes)
+		assert.Equal(t, &cpuTime, points[1].Usage.CPUTimeMs)
+	}
+	mockExecutionRepo.AssertExpectations(t)
+}
+
+func TestExecutionStatsService_GetOverallStats(t *testing.T) {
+	// Setup
+	mockJobRepo := new(MockJobRepository)
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	service := services.NewExecutionStatsService(mockJobRepo, mockExecutionRepo)
+
+	since := time.Now().UTC().Add(-24 * time.Hour)
+	mockJobRepo.On("GetAll", 1, 1, models.JobSortNewest, models.JobFilter{}).Return([]models.Job{}, int64(7), nil)
+	mockExecutionRepo.On("GetOverallStats", mock.MatchedBy(func(t time.Time) bool {
+		return !t.Before(since) && t.Before(since.Add(time.Minute))
+	})).Return(&models.OverallExecutionStats{TotalExecutions: 10, SuccessfulExecutions: 8, FailuresLast24h: 2}, nil)
+
+	// Execute
+	stats, err := service.GetOverallStats()
+
+	// Assert - failures are counted over the last 24 hours
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), stats.TotalJobs)
+	assert.Equal(t, int64(2), stats.FailuresLast24h)
+	mockExecutionRepo.AssertExpectations(t)
+}
+
+func TestResourceUsage_ValueScan(t *testing.T) {
+	cpuTime := int64(42)
+	usage := models.ResourceUsage{Source: models.ResourceUsageSourceContainer, GoroutineDelta: 2, CPUTimeMs: &cpuTime}
+
+	value, err := usage.Value()
+	assert.NoError(t, err)
+
+	var scanned models.ResourceUsage
+	assert.NoError(t, scanned.Scan(value))
+	assert.Equal(t, usage, scanned)
+}
+
+func TestExecutionStatsHandler_GetStatsByJobType(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	service := services.NewExecutionStatsService(new(MockJobRepository), mockExecutionRepo)
+	router := gin.New()
+	handlers.NewExecutionStatsHandler(service).RegisterRoutes(router.Group("/api/v1"))
+
+	avgDuration := int64(1200)
+	since := time.Now().UTC().Add(-7 * 24 * time.Hour)
+	mockExecutionRepo.On("GetStatsByJobType", mock.MatchedBy(func(t time.Time) bool {
+		return !t.Before(since) && t.Before(since.Add(time.Minute))
+	})).Return([]models.JobTypeExecutionStats{
+		{JobType: models.JobTypeEmailNotification, TotalExecutions: 40, SuccessfulExecutions: 30, FailedExecutions: 10, FailureRate: 25, AverageExecutionTime: &avgDuration},
+	}, nil)
+
+	// Execute
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/stats/by-type?window=7d", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"window": "168h0m0s", "stats": [{"job_type": "email_notification", "total_executions": 40,
+		"successful_executions": 30, "failed_executions": 10, "failure_rate": 25, "average_execution_time_ms": 1200}]}`, w.Body.String())
+	mockExecutionRepo.AssertExpectations(t)
+}
+
+func TestExecutionStatsHandler_GetStatsByJobType_InvalidWindow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	router := gin.New()
+	handlers.NewExecutionStatsHandler(services.NewExecutionStatsService(new(MockJobRepository), mockExecutionRepo)).RegisterRoutes(router.Group("/api/v1"))
+
+	for _, window := range []string{"soon", "-1h", "0d", "365d"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/stats/by-type?window="+window, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, window)
+	}
+	mockExecutionRepo.AssertNotCalled(t, "GetStatsByJobType", mock.Anything)
+}
+
+func TestExecutionStatsHandler_GetThroughput(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	service := services.NewExecutionStatsService(new(MockJobRepository), mockExecutionRepo)
+	router := gin.New()
+	handlers.NewExecutionStatsHandler(service).RegisterRoutes(router.Group("/api/v1"))
+
+	// from is rounded down to the hour; buckets without runs aren't returned by the repository
+	from := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
+	mockExecutionRepo.On("GetThroughput", from, to, time.Hour).Return([]models.ThroughputBucket{
+		{Start: from, Started: 12, Completed: 10, Failed: 1},
+		{Start: from.Add(2 * time.Hour), Started: 4, Completed: 5},
+	}, nil)
+
+	// Execute
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+		"/api/v1/stats/throughput?interval=1h&from=2024-01-01T09:20:00Z&to=2024-01-01T12:30:00Z", nil))
+
+	// Assert - every bucket up to to is returned, empty ones included
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"interval": "1h0m0s", "from": "2024-01-01T09:00:00Z", "to": "2024-01-01T12:30:00Z", "buckets": [
+		{"start": "2024-01-01T09:00:00Z", "started": 12, "completed": 10, "failed": 1},
+		{"start": "2024-01-01T10:00:00Z", "started": 0, "completed": 0, "failed": 0},
+		{"start": "2024-01-01T11:00:00Z", "started": 4, "completed": 5, "failed": 0},
+		{"start": "2024-01-01T12:00:00Z", "started": 0, "completed": 0, "failed": 0}]}`, w.Body.String())
+	mockExecutionRepo.AssertExpectations(t)
+}
+
+func TestExecutionStatsHandler_GetThroughput_InvalidRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	router := gin.New()
+	handlers.NewExecutionStatsHandler(services.NewExecutionStatsService(new(MockJobRepository), mockExecutionRepo)).RegisterRoutes(router.Group("/api/v1"))
+
+	for _, query := range []string{
+		"interval=often",
+		"interval=30s",
+		"from=yesterday",
+		"from=2024-01-02T00:00:00Z&to=2024-01-01T00:00:00Z",
+		"from=2023-01-01T00:00:00Z&to=2024-01-01T00:00:00Z&interval=1d",
+		"from=2024-01-01T00:00:00Z&to=2024-01-03T00:00:00Z&interval=1m",
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/stats/throughput?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+	mockExecutionRepo.AssertNotCalled(t, "GetThroughput", mock.Anything, mock.Anything, mock.Anything)
+}
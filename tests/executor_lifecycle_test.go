@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/scheduler"
+)
+
+func TestJobExecutor_InitExecutorsPreparesReportsDirectory(t *testing.T) {
+	// Setup
+	reportsDir := filepath.Join(t.TempDir(), "reports")
+	cfg := &config.Config{
+		Scheduler:   config.SchedulerConfig{MaxConcurrentJobs: 1},
+		HealthCheck: config.HealthCheckConfig{Timeout: time.Second},
+		Reports:     config.ReportsConfig{Directory: reportsDir},
+	}
+	executor := scheduler.NewJobExecutor(new(MockJobExecutionRepository), cfg)
+
+	// Execute
+	err := executor.InitExecutors(context.Background())
+	executor.CloseExecutors()
+
+	// Assert
+	require.NoError(t, err)
+	info, err := os.Stat(reportsDir)
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+}
+
+func TestJobExecutor_InitExecutorsFailsOnInvalidConfig(t *testing.T) {
+	// Setup - the reports directory is blocked by a file
+	blocker := filepath.Join(t.TempDir(), "reports")
+	require.NoError(t, ioutil.WriteFile(blocker, []byte("not a directory"), 0644))
+	cfg := &config.Config{
+		Scheduler:   config.SchedulerConfig{MaxConcurrentJobs: 1},
+		HealthCheck: config.HealthCheckConfig{Timeout: time.Second},
+		Reports:     config.ReportsConfig{Directory: filepath.Join(blocker, "daily")},
+	}
+	executor := scheduler.NewJobExecutor(new(MockJobExecutionRepository), cfg)
+
+	// Execute
+	err := executor.InitExecutors(context.Background())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "report_generation executor")
+}
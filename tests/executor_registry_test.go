@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/scheduler"
+	"job-scheduler/internal/services"
+)
+
+// countingExecutor is a custom executor that counts its runs
+type countingExecutor struct {
+	jobType models.JobType
+	runs    int
+}
+
+func (e *countingExecutor) Execute(ctx context.Context, job *models.Job) error {
+	e.runs++
+	return nil
+}
+
+func (e *countingExecutor) GetJobType() models.JobType {
+	return e.jobType
+}
+
+func TestRegisterExecutor_RunsCustomJobType(t *testing.T) {
+	// Setup
+	custom := &countingExecutor{jobType: "test_registry_custom"}
+	assert.NoError(t, scheduler.RegisterExecutor(custom.jobType, custom))
+
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("Create", mock.AnythingOfType("*models.Job")).Return(nil)
+	jobService := services.NewJobService(mockJobRepo)
+
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second}}
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+
+	// Execute - the job service accepts the custom type and the executor runs it
+	job, err := jobService.CreateJob(&models.CreateJobRequest{
+		Name:     "Custom job",
+		Schedule: "0 9 * * *",
+		JobType:  custom.jobType,
+	})
+	assert.NoError(t, err)
+	job.ID = uuid.New()
+	err = executor.ExecuteJob(job)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, custom.runs)
+}
+
+func TestRegisterExecutor_RejectsInvalidRegistrations(t *testing.T) {
+	custom := &countingExecutor{jobType: "test_registry_duplicate"}
+	assert.NoError(t, services.RegisterExecutor(custom.jobType, custom))
+
+	// The same type can't be registered twice, and built-in types can't be replaced
+	err := services.RegisterExecutor(custom.jobType, custom)
+	assert.ErrorIs(t, err, services.ErrJobTypeRegistered)
+	err = services.RegisterExecutor(models.JobTypeHealthCheck, &countingExecutor{jobType: models.JobTypeHealthCheck})
+	assert.ErrorIs(t, err, services.ErrJobTypeRegistered)
+
+	// The executor must run the type it is registered for
+	assert.Error(t, services.RegisterExecutor("test_registry_mismatch", custom))
+	assert.False(t, services.IsSupportedJobType("test_registry_mismatch"))
+}
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/expressions"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/scheduler"
+	"job-scheduler/internal/services"
+)
+
+func TestExpressions_CompileChecksTypesAndEvaluationIsBounded(t *testing.T) {
+	// Conditions must be true or false, and may only use the environment's variables
+	_, err := expressions.SuccessCriteria.CompileCondition("duration_ms + 1")
+	assert.ErrorContains(t, err, "must be true or false")
+	_, err = expressions.SuccessCriteria.CompileCondition("row.id == 1")
+	assert.ErrorContains(t, err, "undeclared reference to 'row'")
+	_, err = expressions.SuccessCriteria.CompileCondition(strings.Repeat("attempt == 1 || ", 200) + "true")
+	assert.ErrorContains(t, err, "longer than")
+
+	// JSON numbers and recorded structs are read as numbers and objects
+	condition, err := expressions.SuccessCriteria.CompileCondition(`output.rows > 10 && output.stats.ok && attempt == 1`)
+	require.NoError(t, err)
+	met, err := condition.EvalBool(context.Background(), map[string]interface{}{
+		"output": map[string]interface{}{"rows": 12, "stats": struct {
+			OK bool `json:"ok"`
+		}{OK: true}},
+		"duration_ms": int64(5),
+		"attempt":     int64(1),
+	})
+	require.NoError(t, err)
+	assert.True(t, met)
+
+	// An evaluation doing too much work is stopped
+	list := "[" + strings.TrimSuffix(strings.Repeat("1,", 20), ",") + "]"
+	costly, err := expressions.PipelineRow.CompileCondition(list + ".all(a, " + list + ".all(b, " + list + ".all(c, " + list + ".all(d, true))))")
+	require.NoError(t, err)
+	_, err = costly.EvalBool(context.Background(), map[string]interface{}{"row": map[string]interface{}{}})
+	assert.ErrorContains(t, err, "cost limit exceeded")
+}
+
+func TestJobExecutor_FailsRunsNotMeetingSuccessCriteria(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria string
+		status   models.ExecutionStatus
+		message  string
+	}{
+		{"met", "output.operation == 'aggregate' && duration_ms < 60000", models.ExecutionStatusCompleted, ""},
+		{"not met", "output.operation == 'transform'", models.ExecutionStatusFailed, "success criteria not met: output.operation == 'transform'"},
+		{"not evaluable", "output.rows_written > 0", models.ExecutionStatusFailed, "success criteria could not be checked: no such key: rows_written"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup - a simulated data_processing run recording its operation
+			var finished *models.JobExecution
+			mockExecutionRepo := new(MockJobExecutionRepository)
+			mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+			mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Run(func(args mock.Arguments) {
+				finished = args.Get(0).(*models.JobExecution)
+			}).Return(nil)
+			cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second}}
+			executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+			job := &models.Job{ID: uuid.New(), Name: "Rollup", JobType: models.JobTypeDataProcessing, Config: models.JobConfig{
+				"processing_time_seconds": float64(0),
+				"operation":               "aggregate",
+				"success_criteria":        tt.criteria,
+			}}
+
+			// Execute
+			err := executor.ExecuteJob(job)
+
+			// Assert
+			require.NotNil(t, finished)
+			assert.Equal(t, tt.status, finished.Status)
+			if tt.message == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.message)
+			assert.Equal(t, tt.message, finished.ErrorMessage.String())
+		})
+	}
+}
+
+func TestJobService_CreateJobRejectsInvalidExpressions(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  models.JobConfig
+		message string
+	}{
+		{"success criteria", models.JobConfig{"success_criteria": "output.rows >"}, "invalid success criteria"},
+		{"success criteria not a condition", models.JobConfig{"success_criteria": "attempt"}, "must be true or false"},
+		{"notification filter", models.JobConfig{"notification_filter": "event == job_failed"}, "undeclared reference to 'job_failed'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockRepo := new(MockJobRepository)
+			service := services.NewJobService(mockRepo)
+
+			// Execute
+			job, err := service.CreateJob(&models.CreateJobRequest{
+				Name:     "Rollup",
+				Schedule: "0 2 * * *",
+				JobType:  models.JobTypeHealthCheck,
+				Config:   tt.config,
+			})
+
+			// Assert - the broken expression is rejected before the job is saved
+			assert.Nil(t, job)
+			assert.ErrorContains(t, err, tt.message)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/notifications"
+	"job-scheduler/internal/scheduler"
+)
+
+func TestJobExecutor_NotifiesFailuresOnceThresholdReached(t *testing.T) {
+	// Setup - the job overrides the instance's threshold of 1
+	cfg := &config.Config{
+		Scheduler:     config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second},
+		Notifications: config.NotificationsConfig{FailureThreshold: 1},
+	}
+	job := &models.Job{ID: uuid.New(), Name: "Flaky job", JobType: "unknown", Config: models.JobConfig{
+		"alert_after_failures": float64(3),
+	}}
+	failed := models.JobExecution{JobID: job.ID, Status: models.ExecutionStatusFailed, Attempt: 1}
+	completed := models.JobExecution{JobID: job.ID, Status: models.ExecutionStatusCompleted, Attempt: 1}
+
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("GetRecentFinished", job.ID, 3).
+		Return([]models.JobExecution{failed, failed, completed}, nil).Once()
+	mockExecutionRepo.On("GetRecentFinished", job.ID, 3).
+		Return([]models.JobExecution{failed, failed, failed}, nil).Once()
+
+	notifier := &recordingNotifier{}
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	executor.SetNotifier(notifier)
+
+	// Execute - the second failure in a row is held back, the third is notified
+	assert.Error(t, executor.ExecuteJob(job))
+	assert.Empty(t, notifier.received)
+	assert.Error(t, executor.ExecuteJob(job))
+
+	// Assert
+	if assert.Len(t, notifier.received, 1) {
+		assert.Equal(t, notifications.EventJobFailed, notifier.received[0].Event)
+		assert.Contains(t, notifier.received[0].Message, "3 runs failed in a row")
+		assert.Equal(t, 3, notifier.received[0].Fields["consecutive_failures"])
+	}
+}
+
+func TestJobExecutor_NotifiesEveryFailureByDefault(t *testing.T) {
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second}}
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+
+	notifier := &recordingNotifier{}
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	executor.SetNotifier(notifier)
+	job := &models.Job{ID: uuid.New(), Name: "Broken job", JobType: "unknown"}
+
+	assert.Error(t, executor.ExecuteJob(job))
+
+	assert.Len(t, notifier.received, 1)
+	mockExecutionRepo.AssertNotCalled(t, "GetRecentFinished", mock.Anything, mock.Anything)
+}
+
+func TestJobExecutor_NotifiesRunsExceedingDurationThreshold(t *testing.T) {
+	// Setup
+	cfg := &config.Config{
+		Scheduler:     config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second},
+		Notifications: config.NotificationsConfig{DurationThreshold: time.Hour},
+	}
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+
+	notifier := &recordingNotifier{}
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	executor.SetNotifier(notifier)
+	slow := &models.Job{ID: uuid.New(), Name: "Slow job", JobType: models.JobTypeDataProcessing, Config: models.JobConfig{
+		"processing_time_seconds":          float64(1),
+		"alert_duration_threshold_seconds": 0.5,
+	}}
+	quick := &models.Job{ID: uuid.New(), Name: "Quick job", JobType: models.JobTypeDataProcessing, Config: models.JobConfig{
+		"processing_time_seconds": float64(0),
+	}}
+
+	// Execute
+	assert.NoError(t, executor.ExecuteJob(slow))
+	assert.NoError(t, executor.ExecuteJob(quick))
+
+	// Assert - only the run over its job's threshold is notified
+	if assert.Len(t, notifier.received, 1) {
+		assert.Equal(t, notifications.EventDurationExceeded, notifier.received[0].Event)
+		assert.Equal(t, slow.ID, notifier.received[0].Job.ID)
+		assert.Equal(t, "500ms", notifier.received[0].Fields["threshold"])
+	}
+}
@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/scheduler"
+)
+
+func TestJobExecutor_SharesContendedSlotsByTeamWeight(t *testing.T) {
+	// Setup - one slot, with payments weighted twice as heavily as reports
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{
+		MaxConcurrentJobs: 1,
+		OverloadThreshold: 100,
+		MaxQueueWait:      10 * time.Second,
+		ShedBelowSeverity: string(models.JobSeverityLow),
+		TeamWeights:       map[string]int{"payments": 2, "reports": 1},
+	}}
+
+	teams := make(map[uuid.UUID]string)
+	var mu sync.Mutex
+	var order []string
+
+	// Queued runs are recorded before they wait, so the order is taken when they start running
+	recordStart := func(args mock.Arguments) {
+		execution := args.Get(0).(*models.JobExecution)
+		if execution.Status != models.ExecutionStatusRunning {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if team, ok := teams[execution.JobID]; ok {
+			order = append(order, team)
+		}
+	}
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Run(recordStart).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Run(recordStart).Return(nil)
+
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+
+	// Take the only slot with a run that takes about a second
+	busy := &models.Job{ID: uuid.New(), Name: "busy", JobType: models.JobTypeEmailNotification}
+	go func() { _ = executor.ExecuteJob(busy) }()
+	assert.Eventually(t, func() bool { return executor.GetRunningJobsCount() == 1 }, time.Second, 10*time.Millisecond)
+
+	// Queue three instant runs for each team, reports after payments
+	var wg sync.WaitGroup
+	queue := func(team string, count int) {
+		for i := 0; i < count; i++ {
+			job := &models.Job{ID: uuid.New(), Name: team, Team: team, JobType: models.JobTypeDataProcessing,
+				Config: models.JobConfig{"processing_time_seconds": float64(0)}}
+			mu.Lock()
+			teams[job.ID] = team
+			mu.Unlock()
+
+			queued := executor.GetQueuedRuns()[team]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, executor.ExecuteJob(job))
+			}()
+			assert.Eventually(t, func() bool { return executor.GetQueuedRuns()[team] == queued+1 }, time.Second, time.Millisecond)
+		}
+	}
+	queue("payments", 3)
+	queue("reports", 3)
+
+	// Execute - wait for every queued run
+	wg.Wait()
+
+	// Assert - reports get every third slot instead of waiting behind all of payments
+	assert.Equal(t, []string{"payments", "payments", "reports", "payments", "reports", "reports"}, order)
+}
+
+func TestJobExecutor_QueuesRunsUpToMaxQueueDepth(t *testing.T) {
+	// Setup - one slot and room for one waiting run
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{
+		MaxConcurrentJobs: 1,
+		OverloadThreshold: 100,
+		MaxQueueWait:      10 * time.Second,
+		MaxQueueDepth:     1,
+		ShedBelowSeverity: string(models.JobSeverityLow),
+	}}
+	var mu sync.Mutex
+	statuses := make(map[uuid.UUID][]models.ExecutionStatus)
+	record := func(args mock.Arguments) {
+		execution := args.Get(0).(*models.JobExecution)
+		mu.Lock()
+		defer mu.Unlock()
+		statuses[execution.ID] = append(statuses[execution.ID], execution.Status)
+	}
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Run(record).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Run(record).Return(nil)
+
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	newJob := func(seconds float64) *models.Job {
+		return &models.Job{ID: uuid.New(), Name: "job", JobType: models.JobTypeDataProcessing,
+			Config: models.JobConfig{"processing_time_seconds": seconds}}
+	}
+
+	// Take the only slot, then queue one run behind it
+	busy := make(chan error, 1)
+	go func() { busy <- executor.ExecuteJob(newJob(1)) }()
+	assert.Eventually(t, func() bool { return executor.GetRunningJobsCount() == 1 }, time.Second, 10*time.Millisecond)
+	waiting := make(chan error, 1)
+	go func() { waiting <- executor.ExecuteJob(newJob(0)) }()
+	assert.Eventually(t, func() bool { return executor.GetQueuedRuns()[""] == 1 }, time.Second, time.Millisecond)
+
+	// Execute - a run beyond the queue depth is skipped
+	err := executor.ExecuteJob(newJob(0))
+
+	// Assert - the queued run is recorded as queued and runs once the slot frees up
+	assert.ErrorContains(t, err, "run queue is full")
+	assert.NoError(t, <-busy)
+	assert.NoError(t, <-waiting)
+
+	mu.Lock()
+	defer mu.Unlock()
+	var queued []models.ExecutionStatus
+	for _, history := range statuses {
+		if history[0] == models.ExecutionStatusQueued {
+			queued = history
+		}
+	}
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, []models.ExecutionStatus{models.ExecutionStatusQueued, models.ExecutionStatusRunning, models.ExecutionStatusCompleted}, queued)
+}
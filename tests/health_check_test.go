@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+func TestHealthCheckExecutor_RunsEveryProbe(t *testing.T) {
+	// Setup
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/status":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "secret", r.Header.Get("X-Token"))
+			w.Write([]byte(`{"status": "ok"}`))
+		case "/slow":
+			time.Sleep(50 * time.Millisecond)
+			w.Write([]byte("ok"))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("down"))
+		}
+	}))
+	defer server.Close()
+
+	executor := services.NewHealthCheckExecutor(&http.Client{Timeout: time.Second})
+	job := &models.Job{ID: uuid.New(), JobType: models.JobTypeHealthCheck, Config: models.JobConfig{
+		"headers": map[string]interface{}{"X-Token": "secret"},
+		"probes": []interface{}{
+			map[string]interface{}{"name": "api", "url": server.URL + "/status", "method": "post", "body_regex": `"status":\s*"ok"`},
+			map[string]interface{}{"name": "slow", "url": server.URL + "/slow", "max_latency_ms": float64(10)},
+			map[string]interface{}{"name": "port", "type": "tcp", "address": strings.TrimPrefix(server.URL, "http://")},
+			map[string]interface{}{"name": "resolver", "type": "dns", "host": "localhost"},
+		},
+		"urls": []interface{}{server.URL + "/down"},
+	}}
+	recorder := services.NewOutputRecorder()
+	ctx := services.WithOutputRecorder(context.Background(), recorder)
+
+	// Execute
+	err := executor.Execute(ctx, job)
+
+	// Assert - the slow and down probes fail the check, the others pass
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "2 of 5 probes failed")
+		assert.Contains(t, err.Error(), "over the 10ms threshold")
+		assert.Contains(t, err.Error(), "expected status 200, got 503")
+	}
+	output := recorder.Output()
+	assert.Equal(t, 2, output["probes_failed"])
+	results := output["probes"].([]services.HealthProbeResult)
+	if assert.Len(t, results, 5) {
+		assert.True(t, results[0].OK)
+		assert.Empty(t, results[0].Body)
+		assert.False(t, results[1].OK)
+		assert.True(t, results[2].OK)
+		assert.True(t, results[3].OK)
+		assert.NotEmpty(t, results[3].Addresses)
+		assert.False(t, results[4].OK)
+		assert.Equal(t, "down", results[4].Body)
+	}
+}
+
+func TestHealthCheckExecutor_BodyAssertion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status": "degraded"}`))
+	}))
+	defer server.Close()
+
+	executor := services.NewHealthCheckExecutor(&http.Client{Timeout: time.Second})
+	job := &models.Job{ID: uuid.New(), JobType: models.JobTypeHealthCheck, Config: models.JobConfig{
+		"url":        server.URL,
+		"body_regex": `"status":\s*"ok"`,
+	}}
+	recorder := services.NewOutputRecorder()
+
+	err := executor.Execute(services.WithOutputRecorder(context.Background(), recorder), job)
+
+	assert.EqualError(t, err, `health check failed - response body doesn't match "\"status\":\\s*\"ok\""`)
+	// A single URL keeps the original output
+	assert.Equal(t, server.URL, recorder.Output()["url"])
+	assert.Equal(t, 200, recorder.Output()["status_code"])
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// finishedRun builds a finished scheduled run that started delay after it was due
+func finishedRun(status models.ExecutionStatus, scheduledFor time.Time, delay time.Duration, durationMs int64) models.JobExecution {
+	return models.JobExecution{
+		ID:                uuid.New(),
+		Status:            status,
+		ScheduledFor:      &scheduledFor,
+		StartedAt:         scheduledFor.Add(delay),
+		ExecutionDuration: &durationMs,
+		Attempt:           1,
+	}
+}
+
+func TestHealthScoreService_RecalculateHealthScores(t *testing.T) {
+	// Setup
+	now := time.Now().UTC()
+	healthy := models.Job{ID: uuid.New(), Name: "Healthy"}
+	struggling := models.Job{ID: uuid.New(), Name: "Struggling"}
+	unscored := models.Job{ID: uuid.New(), Name: "Never run"}
+
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetActiveJobs").Return([]models.Job{healthy, struggling, unscored}, nil)
+	mockJobRepo.On("UpdateHealthScore", mock.Anything, mock.Anything, mock.AnythingOfType("time.Time")).Return(nil)
+
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("GetRecentFinished", healthy.ID, 50).Return([]models.JobExecution{
+		finishedRun(models.ExecutionStatusCompleted, now.Add(-time.Hour), 0, 1000),
+		finishedRun(models.ExecutionStatusCompleted, now.Add(-2*time.Hour), 0, 1000),
+		finishedRun(models.ExecutionStatusCompleted, now.Add(-3*time.Hour), 0, 1000),
+		finishedRun(models.ExecutionStatusCompleted, now.Add(-4*time.Hour), 0, 1000),
+	}, nil)
+	// Half the runs failed today, every run started 5 minutes late and runs take twice as long as before
+	mockExecutionRepo.On("GetRecentFinished", struggling.ID, 50).Return([]models.JobExecution{
+		finishedRun(models.ExecutionStatusFailed, now.Add(-time.Hour), 5*time.Minute, 2000),
+		finishedRun(models.ExecutionStatusFailed, now.Add(-2*time.Hour), 5*time.Minute, 2000),
+		finishedRun(models.ExecutionStatusCompleted, now.Add(-72*time.Hour), 5*time.Minute, 1000),
+		finishedRun(models.ExecutionStatusCompleted, now.Add(-96*time.Hour), 5*time.Minute, 1000),
+	}, nil)
+	mockExecutionRepo.On("GetRecentFinished", unscored.ID, 50).Return([]models.JobExecution{}, nil)
+
+	service := services.NewHealthScoreService(mockJobRepo, mockExecutionRepo)
+
+	// Execute
+	scored, err := service.RecalculateHealthScores()
+
+	// Assert - success rate 40 * 0.5, no lateness or duration points, alert volume 20 * 0.8
+	assert.NoError(t, err)
+	assert.Equal(t, 2, scored)
+	mockJobRepo.AssertCalled(t, "UpdateHealthScore", healthy.ID, 100, mock.AnythingOfType("time.Time"))
+	mockJobRepo.AssertCalled(t, "UpdateHealthScore", struggling.ID, 36, mock.AnythingOfType("time.Time"))
+	mockJobRepo.AssertNotCalled(t, "UpdateHealthScore", unscored.ID, mock.Anything, mock.Anything)
+}
+
+func TestJobService_GetAllJobs_SortsByHealth(t *testing.T) {
+	// Setup
+	mockRepo := new(MockJobRepository)
+	jobService := services.NewJobService(mockRepo)
+	mockRepo.On("GetAll", 1, 10, models.JobSortHealth, models.JobFilter{}).Return([]models.Job{}, int64(0), nil)
+
+	// Execute
+	_, err := jobService.GetAllJobs(context.Background(), 1, 10, models.JobSortHealth, models.JobFilter{})
+	_, invalidErr := jobService.GetAllJobs(context.Background(), 1, 10, "priority", models.JobFilter{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.ErrorIs(t, invalidErr, services.ErrInvalidJobSort)
+	mockRepo.AssertExpectations(t)
+}
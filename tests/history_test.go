@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+func TestHistoryService_PruneExecutions(t *testing.T) {
+	// Setup - one job on the default retention, one overriding it, one keeping its history forever
+	defaultJob := models.Job{ID: uuid.New(), Config: models.JobConfig{}}
+	shortJob := models.Job{ID: uuid.New(), Config: models.JobConfig{"history_retention_days": float64(7)}}
+	keptJob := models.Job{ID: uuid.New(), Config: models.JobConfig{"history_retention_days": float64(0)}}
+
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetPage", (*models.Cursor)(nil), 100, models.JobFilter{}).Return([]models.Job{defaultJob, shortJob, keptJob}, nil)
+
+	cutoffNear := func(age time.Duration) interface{} {
+		return mock.MatchedBy(func(before time.Time) bool {
+			drift := before.Sub(time.Now().Add(-age))
+			return drift > -time.Minute && drift < time.Minute
+		})
+	}
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	// A full batch is followed by another until a short one
+	mockExecutionRepo.On("DeleteFinishedBefore", defaultJob.ID, cutoffNear(30*24*time.Hour), 2).Return(int64(2), nil).Once()
+	mockExecutionRepo.On("DeleteFinishedBefore", defaultJob.ID, cutoffNear(30*24*time.Hour), 2).Return(int64(1), nil).Once()
+	mockExecutionRepo.On("DeleteFinishedBefore", shortJob.ID, cutoffNear(7*24*time.Hour), 2).Return(int64(0), nil).Once()
+
+	service := services.NewHistoryService(mockJobRepo, mockExecutionRepo, config.HistoryConfig{
+		Retention:       30 * 24 * time.Hour,
+		DeleteBatchSize: 2,
+	})
+
+	// Execute
+	pruned, err := service.PruneExecutions()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), pruned)
+	mockExecutionRepo.AssertExpectations(t)
+	mockExecutionRepo.AssertNotCalled(t, "DeleteFinishedBefore", keptJob.ID, mock.Anything, mock.Anything)
+
+	stats := service.GetCleanupStats()
+	assert.Equal(t, int64(1), stats.Runs)
+	assert.Equal(t, int64(3), stats.LastPruned)
+	assert.Equal(t, int64(3), stats.TotalPruned)
+	assert.NotNil(t, stats.LastRunAt)
+}
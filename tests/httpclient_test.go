@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/httpclient"
+)
+
+func newTestHTTPClients(t *testing.T) *httpclient.Factory {
+	clients, err := httpclient.NewFactory(config.HTTPClientConfig{
+		MaxRetries:          2,
+		RetryBackoff:        time.Millisecond,
+		MaxIdleConns:        10,
+		MaxIdleConnsPerHost: 2,
+		IdleConnTimeout:     time.Minute,
+	})
+	require.NoError(t, err)
+	return clients
+}
+
+func TestHTTPClient_RetriesUnavailableAndReplaysBody(t *testing.T) {
+	// Setup - the first attempt is rejected with 503
+	var attempts int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		assert.NotEmpty(t, r.Header.Get(httpclient.TraceHeader))
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	clients := newTestHTTPClients(t)
+	client := clients.Client("notification_webhook", time.Second)
+
+	// Execute
+	resp, err := client.Post(server.URL, "application/json", bytes.NewReader([]byte(`{"event":"job_failed"}`)))
+
+	// Assert
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{`{"event":"job_failed"}`, `{"event":"job_failed"}`}, bodies)
+
+	stats := clients.Stats()["notification_webhook"]
+	assert.Equal(t, int64(2), stats.Requests)
+	assert.Equal(t, int64(1), stats.Retries)
+	assert.Equal(t, int64(1), stats.ServerErrors)
+}
+
+func TestHTTPClient_DoesNotRetryOtherErrors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := newTestHTTPClients(t).Client("health_check", time.Second)
+
+	resp, err := client.Get(server.URL)
+
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestHTTPClient_GivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := newTestHTTPClients(t).Client("health_check", time.Second)
+
+	resp, err := client.Get(server.URL)
+
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestHTTPClient_RejectsMissingCAFile(t *testing.T) {
+	_, err := httpclient.NewFactory(config.HTTPClientConfig{CAFile: "/nonexistent/ca.pem"})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,226 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/integrations"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// fakeSMTPServer accepts SMTP sessions and records the messages delivered
+type fakeSMTPServer struct {
+	listener    net.Listener
+	connections int32
+
+	mu       sync.Mutex
+	messages []string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &fakeSMTPServer{listener: listener}
+	go server.serve()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (s *fakeSMTPServer) config() integrations.SMTPConfig {
+	addr := s.listener.Addr().(*net.TCPAddr)
+	return integrations.SMTPConfig{Host: "127.0.0.1", Port: addr.Port, PoolSize: 1}
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		atomic.AddInt32(&s.connections, 1)
+		go s.session(conn)
+	}
+}
+
+func (s *fakeSMTPServer) session(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.Fields(line + " x")[0])
+		switch command {
+		case "EHLO", "HELO":
+			reply("250 localhost")
+		case "DATA":
+			reply("354 go ahead")
+			var message strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				message.WriteString(dataLine)
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, message.String())
+			s.mu.Unlock()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) delivered() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.messages...)
+}
+
+// stubResource is a Resource that counts closes and fails pings on demand
+type stubResource struct {
+	pingErr error
+	closed  int32
+}
+
+func (r *stubResource) Ping(ctx context.Context) error { return r.pingErr }
+func (r *stubResource) Close() error {
+	atomic.AddInt32(&r.closed, 1)
+	return nil
+}
+
+func TestIntegrationManager_OpensLazilyOnce(t *testing.T) {
+	// Setup
+	var opens int32
+	resource := &stubResource{}
+	manager := integrations.NewManager()
+	manager.Register("redis", func(ctx context.Context) (integrations.Resource, error) {
+		atomic.AddInt32(&opens, 1)
+		return resource, nil
+	})
+
+	// Nothing is opened until first use
+	assert.Equal(t, integrations.StatusIdle, manager.Check(context.Background())["redis"].Status)
+
+	// Execute - concurrent runs share one open
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := manager.Get(context.Background(), "redis")
+			assert.NoError(t, err)
+			assert.Same(t, resource, got)
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, int32(1), atomic.LoadInt32(&opens))
+	assert.Equal(t, integrations.StatusHealthy, manager.Check(context.Background())["redis"].Status)
+
+	_, err := manager.Get(context.Background(), "kafka")
+	assert.ErrorIs(t, err, integrations.ErrUnknownIntegration)
+}
+
+func TestIntegrationManager_ReconnectsAfterFailedHealthCheckAndClosesOnShutdown(t *testing.T) {
+	// Setup
+	var opened []*stubResource
+	manager := integrations.NewManager()
+	manager.Register("kafka", func(ctx context.Context) (integrations.Resource, error) {
+		resource := &stubResource{}
+		opened = append(opened, resource)
+		return resource, nil
+	})
+
+	_, err := manager.Get(context.Background(), "kafka")
+	require.NoError(t, err)
+
+	// Execute - a failed ping drops the connection
+	opened[0].pingErr = errors.New("broker unreachable")
+	status := manager.Check(context.Background())["kafka"]
+	assert.Equal(t, integrations.StatusUnhealthy, status.Status)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&opened[0].closed))
+
+	// The next run reconnects
+	_, err = manager.Get(context.Background(), "kafka")
+	require.NoError(t, err)
+	assert.Len(t, opened, 2)
+
+	// Shutdown closes it and refuses further use
+	assert.NoError(t, manager.Close())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&opened[1].closed))
+	_, err = manager.Get(context.Background(), "kafka")
+	assert.ErrorIs(t, err, integrations.ErrManagerClosed)
+}
+
+func TestEmailNotificationExecutor_ReusesPooledSMTPConnection(t *testing.T) {
+	// Setup
+	server := newFakeSMTPServer(t)
+	manager := integrations.NewManager()
+	manager.Register(integrations.SMTPIntegration, integrations.OpenSMTPPool(server.config()))
+	defer manager.Close()
+
+	executor := services.NewEmailNotificationExecutor(manager, "scheduler@example.com")
+	job := &models.Job{ID: uuid.New(), Name: "Digest", JobType: models.JobTypeEmailNotification, Config: models.JobConfig{
+		"recipient": "oncall@example.com",
+		"subject":   "Daily digest",
+		"body":      "All jobs succeeded.",
+	}}
+
+	// Execute
+	for i := 0; i < 3; i++ {
+		require.NoError(t, executor.Execute(context.Background(), job))
+	}
+
+	// Assert - every run used the connection opened on first use
+	messages := server.delivered()
+	require.Len(t, messages, 3)
+	assert.Contains(t, messages[0], "Subject: Daily digest")
+	assert.Contains(t, messages[0], "All jobs succeeded.")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&server.connections))
+}
+
+func TestIntegrationManager_RemoveClosesOpenedResource(t *testing.T) {
+	// Setup
+	resource := &stubResource{}
+	manager := integrations.NewManager()
+	manager.Register("database:reports", func(ctx context.Context) (integrations.Resource, error) {
+		return resource, nil
+	})
+	_, err := manager.Get(context.Background(), "database:reports")
+	require.NoError(t, err)
+
+	// Execute
+	require.NoError(t, manager.Remove("database:reports"))
+
+	// Assert - closed once, not again on shutdown
+	assert.False(t, manager.Has("database:reports"))
+	assert.NoError(t, manager.Close())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&resource.closed))
+	assert.NoError(t, manager.Remove("database:unknown"))
+}
@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/scheduler"
+)
+
+// blockingExecutor runs until its context ends, reporting each run on started
+type blockingExecutor struct {
+	jobType models.JobType
+	started chan struct{}
+}
+
+func (e *blockingExecutor) Execute(ctx context.Context, job *models.Job) error {
+	e.started <- struct{}{}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (e *blockingExecutor) GetJobType() models.JobType {
+	return e.jobType
+}
+
+// newStartupExecutionRepo returns an execution repository with no runs left over from an earlier instance
+func newStartupExecutionRepo() *MockJobExecutionRepository {
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("GetRunningExecutions").Return([]models.JobExecution{}, nil).Maybe()
+	mockExecutionRepo.On("GetInterruptedExecutions").Return([]models.JobExecution{}, nil).Maybe()
+	return mockExecutionRepo
+}
+
+func TestJobExecutor_DrainRunsInterruptsRunsAfterGracePeriod(t *testing.T) {
+	// Setup
+	custom := &blockingExecutor{jobType: "test_drain_blocking", started: make(chan struct{}, 1)}
+	require.NoError(t, scheduler.RegisterExecutor(custom.jobType, custom))
+
+	var saved []models.ExecutionStatus
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(0).(*models.JobExecution).Status)
+	}).Return(nil)
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 2, MaxQueueWait: time.Second, InstanceID: "scheduler-1"}}
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	job := &models.Job{ID: uuid.New(), Name: "Long import", JobType: custom.jobType}
+
+	result := make(chan error, 1)
+	go func() { result <- executor.ExecuteJob(job) }()
+	<-custom.started
+
+	// Execute
+	interrupted := executor.DrainRuns(50 * time.Millisecond)
+
+	// Assert - the run is recorded as interrupted and no further run starts
+	assert.Equal(t, 1, interrupted)
+	assert.ErrorIs(t, <-result, scheduler.ErrExecutionInterrupted)
+	assert.Equal(t, []models.ExecutionStatus{models.ExecutionStatusInterrupted}, saved)
+	assert.ErrorIs(t, executor.ExecuteJob(job), scheduler.ErrExecutionInterrupted)
+	assert.Empty(t, custom.started)
+}
+
+func TestJobExecutor_RecoverInterruptedRunsRequeuesThem(t *testing.T) {
+	// Setup - one run of this instance was left running by a crash, and another run was interrupted at shutdown
+	custom := &blockingExecutor{jobType: "test_recover_blocking", started: make(chan struct{}, 1)}
+	require.NoError(t, scheduler.RegisterExecutor(custom.jobType, custom))
+
+	job := models.Job{ID: uuid.New(), Name: "Nightly import", JobType: custom.jobType, MaxRetries: 3}
+	crashed := models.JobExecution{ID: uuid.New(), JobID: job.ID, Status: models.ExecutionStatusRunning, InstanceID: "scheduler-1", Attempt: 1}
+	elsewhere := models.JobExecution{ID: uuid.New(), JobID: job.ID, Status: models.ExecutionStatusRunning, InstanceID: "scheduler-2", Attempt: 1}
+	interrupted := models.JobExecution{ID: uuid.New(), JobID: job.ID, Job: job, Status: models.ExecutionStatusInterrupted, Attempt: 1,
+		Parameters: models.JobConfig{"date": "2024-01-01"}, InstanceID: "scheduler-1"}
+
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("GetRunningExecutions").Return([]models.JobExecution{crashed, elsewhere}, nil)
+	mockExecutionRepo.On("GetInterruptedExecutions").Return([]models.JobExecution{interrupted}, nil)
+	mockExecutionRepo.On("Update", mock.MatchedBy(func(e *models.JobExecution) bool {
+		return e.ID == crashed.ID && e.Status == models.ExecutionStatusInterrupted
+	})).Return(nil).Once()
+	mockExecutionRepo.On("Update", mock.MatchedBy(func(e *models.JobExecution) bool {
+		return e.ID == interrupted.ID && e.Status == models.ExecutionStatusFailed
+	})).Return(nil).Once()
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	var retry *models.JobExecution
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Run(func(args mock.Arguments) {
+		retry = args.Get(0).(*models.JobExecution)
+	}).Return(nil)
+
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 2, MaxQueueWait: time.Second,
+		InstanceID: "scheduler-1", InterruptedRuns: "requeue"}}
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+
+	// Execute
+	requeued, failed, err := executor.RecoverInterruptedRuns()
+
+	// Assert - the interrupted run is re-run as the next attempt with its parameters
+	require.NoError(t, err)
+	assert.Equal(t, 1, requeued)
+	assert.Zero(t, failed)
+	select {
+	case <-custom.started:
+	case <-time.After(time.Second):
+		t.Fatal("requeued run didn't start")
+	}
+	require.NotNil(t, retry)
+	assert.Equal(t, 2, retry.Attempt)
+	assert.Equal(t, interrupted.ID, *retry.RetryOfID)
+	assert.Equal(t, "2024-01-01", retry.Parameters["date"])
+	mockExecutionRepo.AssertExpectations(t)
+	executor.CancelExecution(retry.ID, true)
+}
@@ -0,0 +1,337 @@
+package tests
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/events"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/scheduler"
+	"job-scheduler/internal/services"
+)
+
+func TestScheduler_AppliesJobChangesImmediately(t *testing.T) {
+	// Setup
+	cfg := &config.Config{
+		Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second},
+		Reports:   config.ReportsConfig{Directory: t.TempDir()},
+	}
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetActiveJobs").Return([]models.Job{}, nil).Maybe()
+	mockJobRepo.On("GetSchedulableJobs").Return([]models.Job{}, nil)
+	mockJobRepo.On("Create", mock.AnythingOfType("*models.Job")).Return(nil)
+	mockJobRepo.On("Update", mock.AnythingOfType("*models.Job")).Return(nil)
+	mockJobRepo.On("Delete", mock.Anything).Return(nil)
+
+	jobService := services.NewJobService(mockJobRepo)
+	s := scheduler.NewScheduler(jobService, newStartupExecutionRepo(), cfg)
+	assert.NoError(t, s.Start())
+	defer s.Stop()
+
+	// Execute - a created job is scheduled without waiting for a reload
+	job, err := jobService.CreateJob(&models.CreateJobRequest{
+		Name:     "Nightly ETL",
+		Schedule: "0 2 * * *",
+		JobType:  models.JobTypeDataProcessing,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, s.GetScheduledJobsCount())
+
+	// Deactivating the job unschedules it
+	mockJobRepo.On("GetByID", job.ID).Return(job, nil)
+	inactive := false
+	_, err = jobService.UpdateJob(job.ID, &models.UpdateJobRequest{IsActive: &inactive})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, s.GetScheduledJobsCount())
+
+	// Reactivating and then deleting it unschedules it again
+	active := true
+	_, err = jobService.UpdateJob(job.ID, &models.UpdateJobRequest{IsActive: &active})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, s.GetScheduledJobsCount())
+	assert.NoError(t, jobService.DeleteJob(job.ID))
+	assert.Equal(t, 0, s.GetScheduledJobsCount())
+}
+
+func TestScheduler_PublishesCreatedAndScheduledEvents(t *testing.T) {
+	// Setup
+	cfg := &config.Config{
+		Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second},
+		Reports:   config.ReportsConfig{Directory: t.TempDir()},
+	}
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetActiveJobs").Return([]models.Job{}, nil).Maybe()
+	mockJobRepo.On("GetSchedulableJobs").Return([]models.Job{}, nil)
+	mockJobRepo.On("Create", mock.AnythingOfType("*models.Job")).Return(nil)
+
+	bus := events.NewJobEventBus()
+	var received []events.JobEvent
+	bus.Subscribe(func(e events.JobEvent) { received = append(received, e) })
+	jobService := services.NewJobService(mockJobRepo)
+	s := scheduler.NewScheduler(jobService, newStartupExecutionRepo(), cfg)
+	s.SetJobEvents(bus)
+	assert.NoError(t, s.Start())
+	defer s.Stop()
+
+	// Execute
+	job, err := jobService.CreateJob(&models.CreateJobRequest{
+		Name:     "Nightly ETL",
+		Schedule: "0 2 * * *",
+		JobType:  models.JobTypeDataProcessing,
+	})
+	assert.NoError(t, err)
+
+	// Assert
+	if assert.Len(t, received, 2) {
+		assert.Equal(t, events.JobEventCreated, received[0].Type)
+		assert.Equal(t, job.ID, received[0].JobID)
+		assert.Equal(t, events.JobEventScheduled, received[1].Type)
+		if assert.NotNil(t, received[1].NextRunAt) {
+			assert.True(t, received[1].NextRunAt.After(time.Now()))
+		}
+	}
+}
+
+func TestScheduler_ReloadReportsScheduleChanges(t *testing.T) {
+	// Setup - three jobs are scheduled, then one is edited, one dropped and one imported behind the API's back
+	cfg := &config.Config{
+		Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second},
+		Reports:   config.ReportsConfig{Directory: t.TempDir()},
+	}
+	startedAt := time.Now().UTC().Add(-time.Hour)
+	kept := models.Job{ID: uuid.New(), Name: "Kept", Schedule: "0 2 * * *", JobType: models.JobTypeDataProcessing, State: models.JobStateActive, UpdatedAt: startedAt}
+	edited := models.Job{ID: uuid.New(), Name: "Edited", Schedule: "0 3 * * *", JobType: models.JobTypeDataProcessing, State: models.JobStateActive, UpdatedAt: startedAt}
+	dropped := models.Job{ID: uuid.New(), Name: "Dropped", Schedule: "0 4 * * *", JobType: models.JobTypeDataProcessing, State: models.JobStateActive, UpdatedAt: startedAt}
+	imported := models.Job{ID: uuid.New(), Name: "Imported", Schedule: "0 5 * * *", JobType: models.JobTypeDataProcessing, State: models.JobStateActive, UpdatedAt: startedAt}
+	editedNow := edited
+	editedNow.Schedule = "30 3 * * *"
+	editedNow.UpdatedAt = time.Now().UTC()
+
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetActiveJobs").Return([]models.Job{}, nil).Maybe()
+	mockJobRepo.On("GetSchedulableJobs").Return([]models.Job{}, nil).Once()
+	mockJobRepo.On("GetSchedulableJobs").Return([]models.Job{kept, editedNow, imported}, nil)
+
+	s := scheduler.NewScheduler(services.NewJobService(mockJobRepo), newStartupExecutionRepo(), cfg)
+	assert.NoError(t, s.Start())
+	defer s.Stop()
+	for _, job := range []models.Job{kept, edited, dropped} {
+		job := job
+		assert.NoError(t, s.AddJob(&job))
+	}
+
+	// Execute
+	reload, err := s.Reload()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ReloadedJob{{ID: imported.ID, Name: "Imported"}}, reload.Added)
+	assert.Equal(t, []models.ReloadedJob{{ID: edited.ID, Name: "Edited"}}, reload.Updated)
+	assert.Equal(t, []models.ReloadedJob{{ID: dropped.ID, Name: "Dropped"}}, reload.Removed)
+	assert.Empty(t, reload.Failed)
+	assert.Equal(t, 1, reload.Unchanged)
+	assert.Equal(t, 3, reload.ScheduledJobs)
+}
+
+func TestScheduler_ReloadRequiresRunningScheduler(t *testing.T) {
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second}}
+	s := scheduler.NewScheduler(services.NewJobService(new(MockJobRepository)), newStartupExecutionRepo(), cfg)
+
+	_, err := s.Reload()
+	assert.Error(t, err)
+}
+
+func TestScheduler_StartSchedulesActiveJobs(t *testing.T) {
+	// Setup
+	cfg := &config.Config{
+		Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second},
+		Reports:   config.ReportsConfig{Directory: t.TempDir()},
+	}
+	jobs := make([]models.Job, 100)
+	for i := range jobs {
+		jobs[i] = models.Job{ID: uuid.New(), Name: fmt.Sprintf("Job %d", i), Schedule: "0 2 * * *", JobType: models.JobTypeDataProcessing, State: models.JobStateActive}
+	}
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetActiveJobs").Return([]models.Job{}, nil).Maybe()
+	mockJobRepo.On("GetSchedulableJobs").Return(jobs, nil)
+
+	s := scheduler.NewScheduler(services.NewJobService(mockJobRepo), newStartupExecutionRepo(), cfg)
+
+	// Execute
+	assert.NoError(t, s.Start())
+	defer s.Stop()
+
+	// Assert
+	assert.Equal(t, len(jobs), s.GetScheduledJobsCount())
+	assert.Len(t, s.NextRunTimes([]uuid.UUID{jobs[0].ID, jobs[99].ID}), 2)
+}
+
+func TestScheduler_ScheduleChangesDuringReload(t *testing.T) {
+	// Setup - a reload of many jobs runs while other jobs are added and removed
+	cfg := &config.Config{
+		Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second},
+		Reports:   config.ReportsConfig{Directory: t.TempDir()},
+	}
+	reloaded := make([]models.Job, 1000)
+	for i := range reloaded {
+		reloaded[i] = models.Job{ID: uuid.New(), Name: fmt.Sprintf("Reloaded %d", i), Schedule: "*/5 * * * *", JobType: models.JobTypeDataProcessing, State: models.JobStateActive}
+	}
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetActiveJobs").Return([]models.Job{}, nil).Maybe()
+	mockJobRepo.On("GetSchedulableJobs").Return([]models.Job{}, nil).Once()
+	mockJobRepo.On("GetSchedulableJobs").Return(reloaded, nil)
+
+	s := scheduler.NewScheduler(services.NewJobService(mockJobRepo), newStartupExecutionRepo(), cfg)
+	assert.NoError(t, s.Start())
+	defer s.Stop()
+
+	// Execute
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err := s.Reload()
+		assert.NoError(t, err)
+	}()
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				job := models.Job{ID: uuid.New(), Name: "Transient", Schedule: "0 2 * * *", JobType: models.JobTypeDataProcessing, State: models.JobStateActive}
+				assert.NoError(t, s.AddJob(&job))
+				s.GetScheduledJobsCount()
+				s.RemoveJob(job.ID.String())
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert - only the reloaded jobs are left, each with a single entry
+	assert.Equal(t, len(reloaded), s.GetScheduledJobsCount())
+	ids := make([]uuid.UUID, len(reloaded))
+	for i, job := range reloaded {
+		ids[i] = job.ID
+	}
+	assert.Len(t, s.NextRunTimes(ids), len(reloaded))
+}
+
+func TestScheduler_StartSkipsUnusableSchedules(t *testing.T) {
+	// Setup - jobs are prepared concurrently, and one of them has a schedule cron can't use
+	cfg := &config.Config{
+		Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second},
+		Reports:   config.ReportsConfig{Directory: t.TempDir()},
+	}
+	jobs := make([]models.Job, 500)
+	for i := range jobs {
+		jobs[i] = models.Job{ID: uuid.New(), Name: fmt.Sprintf("Job %d", i), Schedule: fmt.Sprintf("%d * * * *", i%60), JobType: models.JobTypeDataProcessing, State: models.JobStateActive}
+	}
+	jobs[250].Schedule = "not a schedule"
+	jobs[499].State = models.JobStateDisabled
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetActiveJobs").Return([]models.Job{}, nil).Maybe()
+	mockJobRepo.On("GetSchedulableJobs").Return(jobs, nil)
+	mockJobRepo.On("MarkErrored", jobs[250].ID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil)
+
+	s := scheduler.NewScheduler(services.NewJobService(mockJobRepo), newStartupExecutionRepo(), cfg)
+
+	// Execute
+	assert.NoError(t, s.Start())
+	defer s.Stop()
+
+	// Assert - each job got its own schedule, and the unusable one is marked errored
+	assert.Equal(t, len(jobs)-2, s.GetScheduledJobsCount())
+	mockJobRepo.AssertNumberOfCalls(t, "MarkErrored", 1)
+	nextRuns := s.NextRunTimes([]uuid.UUID{jobs[0].ID, jobs[17].ID, jobs[250].ID, jobs[499].ID})
+	assert.Len(t, nextRuns, 2)
+	assert.Equal(t, 0, nextRuns[jobs[0].ID].Minute())
+	assert.Equal(t, 17, nextRuns[jobs[17].ID].Minute())
+}
+
+func TestScheduler_ReloadKeepsEntriesOfUnchangedSettings(t *testing.T) {
+	// Setup - one job was saved without changing, another had its config changed
+	cfg := &config.Config{
+		Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second},
+		Reports:   config.ReportsConfig{Directory: t.TempDir()},
+	}
+	savedAt := time.Now().UTC().Add(-time.Hour)
+	resaved := models.Job{ID: uuid.New(), Name: "Resaved", Schedule: "0 2 * * *", JobType: models.JobTypeDataProcessing, State: models.JobStateActive, Config: models.JobConfig{"operation": "transform"}, UpdatedAt: savedAt}
+	reconfigured := models.Job{ID: uuid.New(), Name: "Reconfigured", Schedule: "0 3 * * *", JobType: models.JobTypeDataProcessing, State: models.JobStateActive, Config: models.JobConfig{"operation": "transform"}, UpdatedAt: savedAt}
+
+	resavedNow := resaved
+	resavedNow.UpdatedAt = time.Now().UTC()
+	scoredAt := time.Now().UTC()
+	resavedNow.HealthScoredAt = &scoredAt
+	reconfiguredNow := reconfigured
+	reconfiguredNow.Config = models.JobConfig{"operation": "aggregate"}
+
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetActiveJobs").Return([]models.Job{}, nil).Maybe()
+	mockJobRepo.On("GetSchedulableJobs").Return([]models.Job{resaved, reconfigured}, nil).Once()
+	mockJobRepo.On("GetSchedulableJobs").Return([]models.Job{resavedNow, reconfiguredNow}, nil)
+
+	s := scheduler.NewScheduler(services.NewJobService(mockJobRepo), newStartupExecutionRepo(), cfg)
+	assert.NoError(t, s.Start())
+	defer s.Stop()
+
+	// Execute
+	reload, err := s.Reload()
+
+	// Assert - only the job whose settings changed is rescheduled
+	assert.NoError(t, err)
+	assert.Equal(t, 1, reload.Unchanged)
+	assert.Equal(t, []models.ReloadedJob{{ID: reconfigured.ID, Name: "Reconfigured"}}, reload.Updated)
+	assert.Empty(t, reload.Added)
+
+	// Reloading again changes nothing
+	reload, err = s.Reload()
+	assert.NoError(t, err)
+	assert.False(t, reload.HasChanges())
+	assert.Equal(t, 2, reload.Unchanged)
+}
+
+func TestScheduler_ReloadMarksUnusableSchedulesErroredOnce(t *testing.T) {
+	// Setup - a job migrated with a schedule cron can't use, and a job errored before whose schedule was fixed
+	cfg := &config.Config{
+		Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second},
+		Reports:   config.ReportsConfig{Directory: t.TempDir()},
+	}
+	erroredAt := time.Now().UTC().Add(-time.Hour)
+	broken := models.Job{ID: uuid.New(), Name: "Migrated", Schedule: "every other tuesday", JobType: models.JobTypeDataProcessing, State: models.JobStateActive}
+	fixed := models.Job{ID: uuid.New(), Name: "Fixed", Schedule: "0 2 * * *", JobType: models.JobTypeDataProcessing, State: models.JobStateErrored, ErroredAt: &erroredAt, ErrorReason: "invalid schedule"}
+	_, scheduleErr := services.JobSchedule(&broken)
+
+	brokenErrored := broken
+	brokenErrored.State = models.JobStateErrored
+	brokenErrored.ErroredAt = &erroredAt
+	brokenErrored.ErrorReason = scheduleErr.Error()
+	fixedCleared := fixed
+	fixedCleared.State, fixedCleared.ErroredAt, fixedCleared.ErrorReason = models.JobStateActive, nil, ""
+
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetActiveJobs").Return([]models.Job{}, nil).Maybe()
+	mockJobRepo.On("GetSchedulableJobs").Return([]models.Job{broken, fixed}, nil).Once()
+	mockJobRepo.On("GetSchedulableJobs").Return([]models.Job{brokenErrored, fixedCleared}, nil)
+	mockJobRepo.On("MarkErrored", broken.ID, scheduleErr.Error(), mock.AnythingOfType("time.Time")).Return(nil).Once()
+	mockJobRepo.On("ClearError", fixed.ID).Return(nil).Once()
+
+	s := scheduler.NewScheduler(services.NewJobService(mockJobRepo), newStartupExecutionRepo(), cfg)
+	assert.NoError(t, s.Start())
+	defer s.Stop()
+
+	// Execute
+	reload, err := s.Reload()
+
+	// Assert - the broken job is still reported as failed, but isn't marked errored again
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ReloadedJob{{ID: broken.ID, Name: "Migrated", Error: scheduleErr.Error()}}, reload.Failed)
+	assert.Equal(t, 1, s.GetScheduledJobsCount())
+	mockJobRepo.AssertExpectations(t)
+}
@@ -0,0 +1,228 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/handlers"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+func newJobManifestRouter(jobService services.JobService, jobRepo *MockJobRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	changeControl := services.NewChangeControlService(jobService, jobRepo, new(MockPendingChangeRepository), new(MockAuditRepository), false)
+
+	router := gin.New()
+	v1 := router.Group("/api/v1")
+	handlers.NewJobHandler(jobService, changeControl).RegisterRoutes(v1)
+	handlers.NewJobManifestHandler(jobService, changeControl).RegisterRoutes(v1)
+	return router
+}
+
+func importJobs(router *gin.Engine, query, body string) (*httptest.ResponseRecorder, models.JobImportResult) {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/jobs/import"+query, strings.NewReader(body)))
+
+	var response struct {
+		Import models.JobImportResult `json:"import"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	return w, response.Import
+}
+
+func TestJobManifest_EncodeAndDecode(t *testing.T) {
+	// Setup
+	manifest := &models.JobManifest{Version: models.JobManifestVersion, Jobs: []models.JobDefinition{{
+		Name:     "nightly-etl",
+		Team:     "data",
+		JobType:  models.JobTypeDataProcessing,
+		Schedule: "0 2 * * *",
+		State:    models.JobStateActive,
+		Config:   models.JobConfig{"operation": "transform"},
+		Tags:     models.JobTags{"protected"},
+	}}}
+
+	for _, format := range []string{models.JobManifestFormatYAML, models.JobManifestFormatJSON} {
+		// Execute
+		data, err := models.EncodeJobManifest(manifest, format)
+		assert.NoError(t, err)
+		decoded, err := models.DecodeJobManifest(data)
+
+		// Assert - settings left at their defaults aren't written out
+		assert.NoError(t, err)
+		assert.Equal(t, manifest, decoded)
+		assert.NotContains(t, string(data), "runbook_url")
+	}
+
+	data, _ := models.EncodeJobManifest(manifest, models.JobManifestFormatYAML)
+	assert.Contains(t, string(data), "  - name: nightly-etl\n")
+	assert.Contains(t, string(data), "schedule: 0 2 * * *\n")
+}
+
+func TestJobManifest_DecodeRejectsUnknownSettings(t *testing.T) {
+	_, err := models.DecodeJobManifest([]byte("jobs:\n  - name: nightly-etl\n    schedul: 0 2 * * *\n"))
+	assert.Error(t, err)
+
+	_, err = models.DecodeJobManifest([]byte("version: 2\njobs: []\n"))
+	assert.Error(t, err)
+}
+
+func TestJobManifestHandler_ExportJobs(t *testing.T) {
+	// Setup
+	mockRepo := new(MockJobRepository)
+	jobs := []models.Job{
+		{ID: uuid.New(), Name: "weekly-report", Team: "data", Schedule: "0 9 * * 1", ScheduleType: models.ScheduleTypeCron, JobType: models.JobTypeReportGeneration, State: models.JobStateActive, Severity: models.JobSeverityMedium},
+		{ID: uuid.New(), Name: "nightly-etl", Team: "data", Schedule: "0 2 * * *", ScheduleType: models.ScheduleTypeCron, JobType: models.JobTypeDataProcessing, State: models.JobStateDisabled, Severity: models.JobSeverityHigh},
+		{ID: uuid.New(), Name: "ping", Team: "web", Schedule: "*/5 * * * *", ScheduleType: models.ScheduleTypeCron, JobType: models.JobTypeHealthCheck, State: models.JobStateActive},
+	}
+	mockRepo.On("GetPage", (*models.Cursor)(nil), 500, models.JobFilter{}).Return(jobs, nil)
+	router := newJobManifestRouter(services.NewJobService(mockRepo), mockRepo)
+
+	// Execute
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/export?team=data", nil))
+
+	// Assert - the team's jobs, by name
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+	manifest, err := models.DecodeJobManifest(w.Body.Bytes())
+	assert.NoError(t, err)
+	if assert.Len(t, manifest.Jobs, 2) {
+		assert.Equal(t, "nightly-etl", manifest.Jobs[0].Name)
+		assert.Equal(t, models.JobStateDisabled, manifest.Jobs[0].State)
+		assert.Equal(t, models.JobSeverityHigh, manifest.Jobs[0].Severity)
+		assert.Equal(t, "weekly-report", manifest.Jobs[1].Name)
+	}
+
+	// JSON is available too
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/export?format=json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var exported models.JobManifest
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &exported))
+	assert.Len(t, exported.Jobs, 3)
+}
+
+func TestJobManifestHandler_ImportDryRunWithDiff(t *testing.T) {
+	// Setup - one job exists with an older schedule, another doesn't exist yet
+	mockRepo := new(MockJobRepository)
+	existing := &models.Job{
+		ID:                uuid.New(),
+		Name:              "nightly-etl",
+		Team:              "data",
+		Owner:             "olivia",
+		Schedule:          "0 2 * * *",
+		ScheduleType:      models.ScheduleTypeCron,
+		JobType:           models.JobTypeDataProcessing,
+		Config:            models.JobConfig{"operation": "transform"},
+		State:             models.JobStateActive,
+		Severity:          models.JobSeverityMedium,
+		BackoffStrategy:   models.BackoffExponential,
+		MisfirePolicy:     models.MisfireIgnore,
+		ConcurrencyPolicy: models.ConcurrencyAllow,
+	}
+	mockRepo.On("FindByName", "data", "nightly-etl").Return(existing, nil)
+	mockRepo.On("FindByName", "data", "weekly-report").Return(nil, nil)
+	router := newJobManifestRouter(services.NewJobService(mockRepo), mockRepo)
+
+	// Execute
+	w, result := importJobs(router, "?dry_run=true&diff=true", `
+jobs:
+  - name: nightly-etl
+    team: data
+    job_type: data_processing
+    schedule: 0 3 * * *
+    config:
+      operation: transform
+  - name: weekly-report
+    team: data
+    job_type: report_generation
+    schedule: 0 9 * * 1
+`)
+
+	// Assert - the plan is reported and nothing is saved
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, 1, result.Created)
+	if assert.Len(t, result.Jobs, 2) {
+		assert.Equal(t, models.JobImportUpdate, result.Jobs[0].Action)
+		assert.Equal(t, []models.JobImportChange{{Field: "schedule", From: "0 2 * * *", To: "0 3 * * *"}}, result.Jobs[0].Changes)
+		assert.Equal(t, models.JobImportCreate, result.Jobs[1].Action)
+	}
+	mockRepo.AssertNotCalled(t, "CreateIfNameFree", mock.Anything)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestJobManifestHandler_ImportRejectsInvalidManifest(t *testing.T) {
+	// Setup
+	mockRepo := new(MockJobRepository)
+	mockRepo.On("FindByName", "", "nightly-etl").Return(nil, nil)
+	router := newJobManifestRouter(services.NewJobService(mockRepo), mockRepo)
+
+	// Execute - the second job is invalid and the third repeats the first
+	w, result := importJobs(router, "", `{"jobs": [
+		{"name": "nightly-etl", "job_type": "data_processing", "schedule": "0 2 * * *"},
+		{"name": "broken", "job_type": "data_processing", "schedule": "not a schedule"},
+		{"name": "nightly-etl", "job_type": "data_processing", "schedule": "0 4 * * *"}
+	]}`)
+
+	// Assert - nothing is imported
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, 2, result.Failed)
+	assert.Equal(t, models.JobImportFailed, result.Jobs[2].Action)
+	mockRepo.AssertNotCalled(t, "CreateIfNameFree", mock.Anything)
+}
+
+func TestJobManifestHandler_ImportAppliesChanges(t *testing.T) {
+	// Setup - one job is already as the manifest describes it
+	mockRepo := new(MockJobRepository)
+	unchanged := &models.Job{
+		ID:                uuid.New(),
+		Name:              "ping",
+		Schedule:          "*/5 * * * *",
+		ScheduleType:      models.ScheduleTypeCron,
+		JobType:           models.JobTypeHealthCheck,
+		Config:            models.GetDefaultConfig(models.JobTypeHealthCheck),
+		State:             models.JobStateActive,
+		Severity:          models.JobSeverityMedium,
+		BackoffStrategy:   models.BackoffExponential,
+		MisfirePolicy:     models.MisfireIgnore,
+		ConcurrencyPolicy: models.ConcurrencyAllow,
+		UpdatedAt:         time.Now().UTC(),
+	}
+	mockRepo.On("FindByName", "", "ping").Return(unchanged, nil)
+	mockRepo.On("FindByName", "", "nightly-etl").Return(nil, nil)
+	mockRepo.On("CreateIfNameFree", mock.AnythingOfType("*models.Job")).Return(nil, nil)
+	router := newJobManifestRouter(services.NewJobService(mockRepo), mockRepo)
+
+	// Execute
+	w, result := importJobs(router, "", `
+jobs:
+  - name: ping
+    job_type: health_check
+    schedule: "*/5 * * * *"
+  - name: nightly-etl
+    job_type: data_processing
+    schedule: 0 2 * * *
+`)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, result.DryRun)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.NotNil(t, result.Jobs[1].ID)
+	mockRepo.AssertNumberOfCalls(t, "CreateIfNameFree", 1)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
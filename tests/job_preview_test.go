@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/handlers"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+func previewJob(t *testing.T, body string) models.JobPreview {
+	gin.SetMode(gin.TestMode)
+	// No expectations are set, so anything touching the repository fails the test
+	mockRepo := new(MockJobRepository)
+	jobService := services.NewJobService(mockRepo)
+	router := gin.New()
+	handlers.NewJobHandler(jobService, nil).RegisterRoutes(router.Group("/api/v1"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/jobs/preview", strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var preview models.JobPreview
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &preview))
+	mockRepo.AssertExpectations(t)
+	return preview
+}
+
+func TestPreviewJob_ListsFireTimesInTimezone(t *testing.T) {
+	// Execute
+	preview := previewJob(t, `{"schedule":"30 9 * * 1-5","timezone":"America/New_York","count":7,
+		"job_type":"report_generation","config":{"format":"csv","include_charts":true,"history_retention_days":7}}`)
+
+	// Assert - weekday mornings in New York, in order
+	assert.True(t, preview.Valid)
+	assert.Empty(t, preview.Errors)
+	assert.Equal(t, "America/New_York", preview.Timezone)
+	require.Len(t, preview.FireTimes, 7)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	for i, fireTime := range preview.FireTimes {
+		local := fireTime.In(newYork)
+		assert.Equal(t, 9, local.Hour())
+		assert.Equal(t, 30, local.Minute())
+		assert.NotEqual(t, time.Saturday, local.Weekday())
+		assert.NotEqual(t, time.Sunday, local.Weekday())
+		assert.True(t, fireTime.After(time.Now()))
+		if i > 0 {
+			assert.True(t, fireTime.After(preview.FireTimes[i-1]))
+		}
+	}
+}
+
+func TestPreviewJob_ReportsEveryProblem(t *testing.T) {
+	// Execute
+	preview := previewJob(t, `{"schedule":"0 2 * * 8","timezone":"Mars/Olympus_Mons",
+		"job_type":"report_generation","config":{"format":"docx","include_charts":"yes","history_retention_days":"7"}}`)
+
+	// Assert
+	assert.False(t, preview.Valid)
+	assert.Empty(t, preview.FireTimes)
+	fields := map[string][]string{}
+	for _, problem := range preview.Errors {
+		fields[problem.Field] = append(fields[problem.Field], problem.Message)
+	}
+	assert.Len(t, fields["timezone"], 1)
+	assert.Len(t, fields["schedule"], 1)
+	assert.Equal(t, []string{
+		"config.format must be one of txt, csv, json, xlsx, pdf",
+		"config.include_charts must be true or false",
+		"config.history_retention_days must be a number",
+	}, fields["config"])
+
+	// A config can only be checked against its job type's schema
+	preview = previewJob(t, `{"schedule":"@hourly","config":{"format":"csv"}}`)
+	assert.False(t, preview.Valid)
+	require.Len(t, preview.Errors, 1)
+	assert.Equal(t, "job_type", preview.Errors[0].Field)
+	assert.Len(t, preview.FireTimes, models.DefaultPreviewCount)
+}
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+func TestJob_RetryDelay(t *testing.T) {
+	testCases := []struct {
+		name     string
+		strategy models.BackoffStrategy
+		retry    int
+		expected time.Duration
+	}{
+		{"fixed", models.BackoffFixed, 3, 10 * time.Second},
+		{"linear", models.BackoffLinear, 3, 30 * time.Second},
+		{"exponential first retry", models.BackoffExponential, 1, 10 * time.Second},
+		{"exponential third retry", models.BackoffExponential, 3, 40 * time.Second},
+		{"exponential is capped", models.BackoffExponential, 10, models.MaxRetryDelay},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &models.Job{BackoffStrategy: tc.strategy, InitialDelaySeconds: 10}
+			assert.Equal(t, tc.expected, job.RetryDelay(tc.retry))
+		})
+	}
+}
+
+func TestJobExecution_NextAttempt(t *testing.T) {
+	failed := &models.JobExecution{
+		ID:         uuid.New(),
+		JobID:      uuid.New(),
+		Status:     models.ExecutionStatusFailed,
+		Parameters: models.JobConfig{"region": "eu"},
+		Attempt:    2,
+	}
+
+	retry := failed.NextAttempt()
+
+	assert.NotEqual(t, failed.ID, retry.ID)
+	assert.Equal(t, failed.JobID, retry.JobID)
+	assert.Equal(t, models.ExecutionStatusPending, retry.Status)
+	assert.Equal(t, 3, retry.Attempt)
+	if assert.NotNil(t, retry.RetryOfID) {
+		assert.Equal(t, failed.ID, *retry.RetryOfID)
+	}
+	assert.Equal(t, failed.Parameters, retry.Parameters)
+}
+
+func TestJobService_CreateJob_RetryPolicy(t *testing.T) {
+	// Setup
+	mockRepo := new(MockJobRepository)
+	jobService := services.NewJobService(mockRepo)
+	mockRepo.On("Create", mock.AnythingOfType("*models.Job")).Return(nil)
+
+	// Execute
+	job, err := jobService.CreateJob(&models.CreateJobRequest{
+		Name:                "Flaky Job",
+		Schedule:            "0 9 * * *",
+		JobType:             models.JobTypeHealthCheck,
+		MaxRetries:          3,
+		InitialDelaySeconds: 30,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, job.MaxRetries)
+	assert.Equal(t, models.BackoffExponential, job.BackoffStrategy) // Default strategy
+	assert.Equal(t, 30, job.InitialDelaySeconds)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestJobService_CreateJob_InvalidRetryPolicy(t *testing.T) {
+	testCases := []struct {
+		name        string
+		maxRetries  int
+		strategy    models.BackoffStrategy
+		delay       int
+		expectedErr string
+	}{
+		{"negative retries", -1, "", 0, "max_retries must be between"},
+		{"too many retries", models.MaxJobRetries + 1, "", 0, "max_retries must be between"},
+		{"unknown strategy", 1, "random", 0, "invalid backoff strategy"},
+		{"negative delay", 1, models.BackoffFixed, -5, "initial_delay_seconds"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockJobRepository)
+			jobService := services.NewJobService(mockRepo)
+
+			job, err := jobService.CreateJob(&models.CreateJobRequest{
+				Name:                "Test Job",
+				Schedule:            "0 9 * * *",
+				JobType:             models.JobTypeHealthCheck,
+				MaxRetries:          tc.maxRetries,
+				BackoffStrategy:     tc.strategy,
+				InitialDelaySeconds: tc.delay,
+			})
+
+			assert.Error(t, err)
+			assert.Nil(t, job)
+			assert.Contains(t, err.Error(), tc.expectedErr)
+			mockRepo.AssertNotCalled(t, "Create")
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/handlers"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// memoryJobRevisionRepository keeps job revisions in memory
+type memoryJobRevisionRepository struct {
+	mu        sync.Mutex
+	revisions []models.JobRevision
+}
+
+func (r *memoryJobRevisionRepository) Create(revision *models.JobRevision) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.revisions {
+		if existing.JobID == revision.JobID && existing.Revision == revision.Revision {
+			return fmt.Errorf("revision %d of job %s already exists", revision.Revision, revision.JobID)
+		}
+	}
+	revision.ID = uuid.New()
+	r.revisions = append(r.revisions, *revision)
+	return nil
+}
+
+func (r *memoryJobRevisionRepository) GetByJobID(jobID uuid.UUID) ([]models.JobRevision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var revisions []models.JobRevision
+	for _, revision := range r.revisions {
+		if revision.JobID == jobID {
+			revisions = append(revisions, revision)
+		}
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Revision > revisions[j].Revision })
+	return revisions, nil
+}
+
+func (r *memoryJobRevisionRepository) GetByRevision(jobID uuid.UUID, revision int) (*models.JobRevision, error) {
+	revisions, _ := r.GetByJobID(jobID)
+	for i := range revisions {
+		if revisions[i].Revision == revision {
+			return &revisions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("job %s has no revision %d", jobID, revision)
+}
+
+func (r *memoryJobRevisionRepository) GetLatest(jobID uuid.UUID) (*models.JobRevision, error) {
+	revisions, _ := r.GetByJobID(jobID)
+	if len(revisions) == 0 {
+		return nil, nil
+	}
+	return &revisions[0], nil
+}
+
+func newJobRevisionRouter(jobService services.JobService, jobRepo *MockJobRepository, revisionService services.JobRevisionService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	changeControl := services.NewChangeControlService(jobService, jobRepo, new(MockPendingChangeRepository), new(MockAuditRepository), false)
+
+	router := gin.New()
+	api := router.Group("/api/v1")
+	handlers.NewJobHandler(jobService, changeControl).RegisterRoutes(api)
+	handlers.NewJobRevisionHandler(revisionService, jobService, changeControl).RegisterRoutes(api)
+	return router
+}
+
+// jobRevisionsResponse is the body of GET /api/v1/jobs/{id}/revisions
+type jobRevisionsResponse struct {
+	Revisions []struct {
+		Revision int              `json:"revision"`
+		Schedule string           `json:"schedule"`
+		Config   models.JobConfig `json:"config"`
+		Author   string           `json:"author"`
+		Changes  []string         `json:"changes"`
+	} `json:"revisions"`
+}
+
+func getJobRevisions(t *testing.T, router *gin.Engine, jobID uuid.UUID) jobRevisionsResponse {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/"+jobID.String()+"/revisions", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var body jobRevisionsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return body
+}
+
+func sendAs(router *gin.Engine, method, path, actor, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(handlers.ActorHeader, actor)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestJobRevisions_RecordsChangesWithAuthor(t *testing.T) {
+	// Setup
+	mockRepo := new(MockJobRepository)
+	jobService := services.NewJobService(mockRepo)
+	revisionService := services.NewJobRevisionService(&memoryJobRevisionRepository{}, jobService)
+	router := newJobRevisionRouter(jobService, mockRepo, revisionService)
+
+	var created *models.Job
+	mockRepo.On("Create", mock.AnythingOfType("*models.Job")).Run(func(args mock.Arguments) {
+		created = args.Get(0).(*models.Job)
+	}).Return(nil)
+	w := sendAs(router, http.MethodPost, "/api/v1/jobs", "alice",
+		`{"name":"nightly-etl","schedule":"0 2 * * *","job_type":"data_processing","config":{"batch_size":100}}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	stored := *created
+	mockRepo.On("GetByID", created.ID).Return(&stored, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*models.Job")).Return(nil)
+
+	// Execute - the schedule changes, then only the description does
+	w = sendAs(router, http.MethodPut, "/api/v1/jobs/"+created.ID.String(), "bob", `{"schedule":"0 3 * * *"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = sendAs(router, http.MethodPut, "/api/v1/jobs/"+created.ID.String(), "bob", `{"description":"Load the warehouse"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// Assert - one revision per change to the definition, newest first, saying who changed what
+	body := getJobRevisions(t, router, created.ID)
+	require.Len(t, body.Revisions, 2)
+	assert.Equal(t, 2, body.Revisions[0].Revision)
+	assert.Equal(t, "0 3 * * *", body.Revisions[0].Schedule)
+	assert.Equal(t, "bob", body.Revisions[0].Author)
+	assert.Equal(t, []string{"schedule"}, body.Revisions[0].Changes)
+	assert.Equal(t, 1, body.Revisions[1].Revision)
+	assert.Equal(t, "alice", body.Revisions[1].Author)
+	assert.Equal(t, models.JobConfig{"batch_size": float64(100)}, body.Revisions[1].Config)
+	assert.Empty(t, body.Revisions[1].Changes)
+}
+
+func TestJobRevisions_RollbackRestoresRevision(t *testing.T) {
+	// Setup - a job from before revisions were kept
+	mockRepo := new(MockJobRepository)
+	jobService := services.NewJobService(mockRepo)
+	revisionService := services.NewJobRevisionService(&memoryJobRevisionRepository{}, jobService)
+	router := newJobRevisionRouter(jobService, mockRepo, revisionService)
+
+	stored := models.Job{
+		ID:           uuid.New(),
+		Name:         "nightly-etl",
+		Schedule:     "0 2 * * *",
+		ScheduleType: models.ScheduleTypeCron,
+		JobType:      models.JobTypeDataProcessing,
+		Config:       models.JobConfig{"batch_size": float64(100)},
+		State:        models.JobStateActive,
+	}
+	mockRepo.On("GetByID", stored.ID).Return(&stored, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*models.Job")).Return(nil)
+	path := "/api/v1/jobs/" + stored.ID.String()
+
+	// Execute - a broken change, then a rollback to how the job was before it
+	w := sendAs(router, http.MethodPut, path, "bob", `{"schedule":"0 2 * * 8","config":{"batch_size":5}}`)
+	require.Equal(t, http.StatusBadRequest, w.Code, "an invalid schedule is refused")
+	w = sendAs(router, http.MethodPut, path, "bob", `{"schedule":"*/1 * * * *","config":{"batch_size":5}}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = sendAs(router, http.MethodPost, path+"/rollback/1", "carol", "")
+
+	// Assert - the job is back as it was, and the rollback is itself a revision
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "0 2 * * *", stored.Schedule)
+	assert.Equal(t, models.JobConfig{"batch_size": float64(100)}, stored.Config)
+
+	body := getJobRevisions(t, router, stored.ID)
+	require.Len(t, body.Revisions, 3)
+	assert.Equal(t, 3, body.Revisions[0].Revision)
+	assert.Equal(t, "carol", body.Revisions[0].Author)
+	assert.Equal(t, []string{"schedule", "config"}, body.Revisions[0].Changes)
+	assert.Equal(t, "bob", body.Revisions[1].Author)
+	assert.Empty(t, body.Revisions[2].Author, "the job's definition before revisions were kept")
+
+	// A revision the job doesn't have can't be rolled back to
+	w = sendAs(router, http.MethodPost, path+"/rollback/7", "carol", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = sendAs(router, http.MethodPost, path+"/rollback/latest", "carol", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
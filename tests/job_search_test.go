@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+func TestJobHandler_GetJobs_SearchesAndSorts(t *testing.T) {
+	// Setup
+	mockRepo := new(MockJobRepository)
+	router := newJobNameRouter(services.NewJobService(mockRepo), mockRepo)
+	inactive := false
+	createdAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdBefore := time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC)
+	filter := models.JobFilter{
+		Name:          "etl",
+		JobType:       models.JobTypeDataProcessing,
+		IsActive:      &inactive,
+		Tag:           "protected",
+		CreatedAfter:  &createdAfter,
+		CreatedBefore: &createdBefore,
+	}
+	mockRepo.On("GetAll", 2, 20, models.JobSortNextRun, filter).Return([]models.Job{}, int64(0), nil)
+
+	// Execute
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs?page=2&limit=20&sort=next_run&name=%20etl%20"+
+		"&job_type=data_processing&is_active=false&tag=protected&created_after=2024-01-01&created_before=2024-02-01T12:00:00Z", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestJobHandler_GetJobs_RejectsInvalidFilters(t *testing.T) {
+	// Setup
+	mockRepo := new(MockJobRepository)
+	router := newJobNameRouter(services.NewJobService(mockRepo), mockRepo)
+
+	for _, query := range []string{
+		"is_active=maybe",
+		"job_type=cooking",
+		"created_after=yesterday",
+		"created_after=2024-02-01&created_before=2024-01-01",
+	} {
+		// Execute
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs?"+query, nil))
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		assert.Contains(t, w.Body.String(), "Invalid job filter", query)
+	}
+	mockRepo.AssertNotCalled(t, "GetAll")
+}
@@ -1,7 +1,9 @@
 package tests
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -21,16 +23,45 @@ func (m *MockJobRepository) Create(job *models.Job) error {
 	return args.Error(0)
 }
 
+func (m *MockJobRepository) CreateIfNameFree(job *models.Job) (*models.Job, error) {
+	args := m.Called(job)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Job), args.Error(1)
+}
+
+func (m *MockJobRepository) FindByName(team, name string) (*models.Job, error) {
+	args := m.Called(team, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Job), args.Error(1)
+}
+
+func (m *MockJobRepository) UpdateIfNameFree(job *models.Job) (*models.Job, error) {
+	args := m.Called(job)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Job), args.Error(1)
+}
+
 func (m *MockJobRepository) GetByID(id uuid.UUID) (*models.Job, error) {
 	args := m.Called(id)
 	return args.Get(0).(*models.Job), args.Error(1)
 }
 
-func (m *MockJobRepository) GetAll(page, limit int) ([]models.Job, int64, error) {
-	args := m.Called(page, limit)
+func (m *MockJobRepository) GetAll(ctx context.Context, page, limit int, sort models.JobSort, filter models.JobFilter) ([]models.Job, int64, error) {
+	args := m.Called(page, limit, sort, filter)
 	return args.Get(0).([]models.Job), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockJobRepository) GetPage(ctx context.Context, after *models.Cursor, limit int, filter models.JobFilter) ([]models.Job, error) {
+	args := m.Called(after, limit, filter)
+	return args.Get(0).([]models.Job), args.Error(1)
+}
+
 func (m *MockJobRepository) Update(job *models.Job) error {
 	args := m.Called(job)
 	return args.Error(0)
@@ -46,11 +77,41 @@ func (m *MockJobRepository) GetActiveJobs() ([]models.Job, error) {
 	return args.Get(0).([]models.Job), args.Error(1)
 }
 
+func (m *MockJobRepository) GetSchedulableJobs() ([]models.Job, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Job), args.Error(1)
+}
+
 func (m *MockJobRepository) GetByJobType(jobType models.JobType) ([]models.Job, error) {
 	args := m.Called(jobType)
 	return args.Get(0).([]models.Job), args.Error(1)
 }
 
+func (m *MockJobRepository) UpdateRunTimes(id uuid.UUID, lastRunAt, nextRunAt time.Time) error {
+	args := m.Called(id, lastRunAt, nextRunAt)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) UpdateHealthScore(id uuid.UUID, score int, scoredAt time.Time) error {
+	args := m.Called(id, score, scoredAt)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) MarkErrored(id uuid.UUID, reason string, erroredAt time.Time) error {
+	args := m.Called(id, reason, erroredAt)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) ClearError(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) GetErroredJobs(ctx context.Context) ([]models.Job, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Job), args.Error(1)
+}
+
 func TestJobService_CreateJob(t *testing.T) {
 	// Setup
 	mockRepo := new(MockJobRepository)
@@ -80,7 +141,7 @@ func TestJobService_CreateJob(t *testing.T) {
 	assert.Equal(t, req.Description, job.Description)
 	assert.Equal(t, req.Schedule, job.Schedule)
 	assert.Equal(t, req.JobType, job.JobType)
-	assert.True(t, job.IsActive)
+	assert.Equal(t, models.JobStateActive, job.State)
 	assert.NotEqual(t, uuid.Nil, job.ID)
 
 	// Verify mock expectations
@@ -137,6 +198,70 @@ func TestJobService_CreateJob_InvalidJobType(t *testing.T) {
 	mockRepo.AssertNotCalled(t, "Create")
 }
 
+func TestJobService_CreateJob_Documentation(t *testing.T) {
+	// Setup
+	mockRepo := new(MockJobRepository)
+	jobService := services.NewJobService(mockRepo)
+
+	// Test data with on-call documentation
+	req := &models.CreateJobRequest{
+		Name:       "Test Job",
+		Schedule:   "0 9 * * *",
+		JobType:    models.JobTypeHealthCheck,
+		RunbookURL: "https://wiki.example.com/runbooks/health-check",
+		Docs:       "## Recovery\nRestart the upstream service.",
+	}
+
+	// Mock expectations
+	mockRepo.On("Create", mock.AnythingOfType("*models.Job")).Return(nil)
+
+	// Execute
+	job, err := jobService.CreateJob(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, req.RunbookURL, job.RunbookURL)
+	assert.Equal(t, req.Docs, job.Docs)
+	assert.Equal(t, models.JobSeverityMedium, job.Severity) // Default severity
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestJobService_CreateJob_InvalidDocumentation(t *testing.T) {
+	testCases := []struct {
+		name        string
+		runbookURL  string
+		severity    models.JobSeverity
+		expectedErr string
+	}{
+		{"relative runbook URL", "/runbooks/job", "", "invalid runbook URL"},
+		{"non-http runbook URL", "ftp://example.com/runbook", "", "invalid runbook URL"},
+		{"unknown severity", "", "urgent", "invalid severity"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockJobRepository)
+			jobService := services.NewJobService(mockRepo)
+
+			req := &models.CreateJobRequest{
+				Name:       "Test Job",
+				Schedule:   "0 9 * * *",
+				JobType:    models.JobTypeHealthCheck,
+				RunbookURL: tc.runbookURL,
+				Severity:   tc.severity,
+			}
+
+			job, err := jobService.CreateJob(req)
+
+			assert.Error(t, err)
+			assert.Nil(t, job)
+			assert.Contains(t, err.Error(), tc.expectedErr)
+			mockRepo.AssertNotCalled(t, "Create")
+		})
+	}
+}
+
 func TestJobService_ValidateCronSchedule(t *testing.T) {
 	// Setup
 	mockRepo := new(MockJobRepository)
@@ -152,6 +277,10 @@ func TestJobService_ValidateCronSchedule(t *testing.T) {
 		{"Valid - Every day at 9 AM", "0 9 * * *", true},
 		{"Valid - Every Monday at midnight", "0 0 * * 1", true},
 		{"Valid - Every 5 minutes", "*/5 * * * *", true},
+		{"Valid - Hourly descriptor", "@hourly", true},
+		{"Valid - Daily descriptor", "@daily", true},
+		{"Valid - Every 90 seconds", "@every 90s", true},
+		{"Valid - Every 1m30s", "@every 1m30s", true},
 		{"Invalid - Too many fields", "* * * * * *", false},
 		{"Invalid - Too few fields", "* * *", false},
 		{"Invalid - Invalid minute", "60 * * * *", false},
@@ -160,6 +289,12 @@ func TestJobService_ValidateCronSchedule(t *testing.T) {
 		{"Invalid - Invalid month", "0 0 1 13 *", false},
 		{"Invalid - Invalid day of week", "0 0 * * 8", false},
 		{"Invalid - Random text", "not a cron", false},
+		{"Invalid - Unknown descriptor", "@fortnightly", false},
+		{"Invalid - Every without interval", "@every", false},
+		{"Invalid - Every bad duration", "@every soon", false},
+		{"Invalid - Every under a second", "@every 500ms", false},
+		{"Invalid - Every fractional seconds", "@every 1500ms", false},
+		{"Invalid - Every negative", "@every -5s", false},
 	}
 
 	for _, tc := range testCases {
@@ -185,22 +320,22 @@ func TestJobService_GetAllJobs(t *testing.T) {
 			ID:       uuid.New(),
 			Name:     "Job 1",
 			JobType:  models.JobTypeEmailNotification,
-			IsActive: true,
+			State: models.JobStateActive,
 		},
 		{
 			ID:       uuid.New(),
 			Name:     "Job 2",
 			JobType:  models.JobTypeDataProcessing,
-			IsActive: true,
+			State: models.JobStateActive,
 		},
 	}
 	expectedCount := int64(2)
 
 	// Mock expectations
-	mockRepo.On("GetAll", 1, 10).Return(expectedJobs, expectedCount, nil)
+	mockRepo.On("GetAll", 1, 10, models.JobSortNewest, models.JobFilter{}).Return(expectedJobs, expectedCount, nil)
 
 	// Execute
-	response, err := jobService.GetAllJobs(1, 10)
+	response, err := jobService.GetAllJobs(context.Background(), 1, 10, "", models.JobFilter{})
 
 	// Assert
 	assert.NoError(t, err)
@@ -221,10 +356,10 @@ func TestJobService_GetAllJobs_PaginationDefaults(t *testing.T) {
 	jobService := services.NewJobService(mockRepo)
 
 	// Mock expectations with default pagination
-	mockRepo.On("GetAll", 1, 10).Return([]models.Job{}, int64(0), nil)
+	mockRepo.On("GetAll", 1, 10, models.JobSortNewest, models.JobFilter{}).Return([]models.Job{}, int64(0), nil)
 
 	// Execute with invalid pagination parameters
-	response, err := jobService.GetAllJobs(0, -5) // Invalid page and limit
+	response, err := jobService.GetAllJobs(context.Background(), 0, -5, "", models.JobFilter{}) // Invalid page and limit
 
 	// Assert
 	assert.NoError(t, err)
@@ -235,3 +370,206 @@ func TestJobService_GetAllJobs_PaginationDefaults(t *testing.T) {
 	// Verify mock expectations
 	mockRepo.AssertExpectations(t)
 }
+
+func TestJobService_ListJobs_CursorPagination(t *testing.T) {
+	// Setup
+	mockRepo := new(MockJobRepository)
+	jobService := services.NewJobService(mockRepo)
+
+	now := time.Now().UTC()
+	jobs := []models.Job{
+		{ID: uuid.New(), Name: "Job 1", CreatedAt: now},
+		{ID: uuid.New(), Name: "Job 2", CreatedAt: now.Add(-time.Minute)},
+		{ID: uuid.New(), Name: "Job 3", CreatedAt: now.Add(-2 * time.Minute)},
+	}
+
+	// One more job than the limit means there is a next page
+	mockRepo.On("GetPage", (*models.Cursor)(nil), 3, models.JobFilter{}).Return(jobs, nil)
+
+	// Execute
+	page, err := jobService.ListJobs(context.Background(), "", 2, models.JobFilter{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, page.Jobs, 2)
+	assert.NotEmpty(t, page.NextCursor)
+
+	cursor, err := models.DecodeCursor(page.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs[1].ID, cursor.ID)
+	assert.True(t, jobs[1].CreatedAt.Equal(cursor.CreatedAt))
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestJobService_ListJobs_InvalidCursor(t *testing.T) {
+	mockRepo := new(MockJobRepository)
+	jobService := services.NewJobService(mockRepo)
+
+	_, err := jobService.ListJobs(context.Background(), "not-a-cursor", 10, models.JobFilter{})
+
+	assert.ErrorIs(t, err, services.ErrInvalidCursor)
+	mockRepo.AssertNotCalled(t, "GetPage")
+}
+
+// stubRunTimeSource reports fixed next and last run times
+type stubRunTimeSource struct {
+	next map[uuid.UUID]time.Time
+	last map[uuid.UUID]time.Time
+}
+
+func (s *stubRunTimeSource) NextRunTimes(jobIDs []uuid.UUID) map[uuid.UUID]time.Time {
+	return s.next
+}
+
+func (s *stubRunTimeSource) LastRunTimes(jobIDs []uuid.UUID) (map[uuid.UUID]time.Time, error) {
+	return s.last, nil
+}
+
+func TestJobService_GetJobByID_RunTimes(t *testing.T) {
+	// Setup - the scheduled job's recorded next run is stale; the other job isn't scheduled here
+	stale := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	next := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	last := time.Date(2024, 1, 1, 9, 0, 5, 0, time.UTC)
+	scheduled := &models.Job{ID: uuid.New(), Name: "Scheduled", NextRunAt: &stale}
+	other := &models.Job{ID: uuid.New(), Name: "Other", NextRunAt: &stale}
+
+	mockRepo := new(MockJobRepository)
+	mockRepo.On("GetByID", scheduled.ID).Return(scheduled, nil)
+	mockRepo.On("GetByID", other.ID).Return(other, nil)
+	jobService := services.NewJobService(mockRepo)
+	jobService.SetRunTimeSource(&stubRunTimeSource{
+		next: map[uuid.UUID]time.Time{scheduled.ID: next},
+		last: map[uuid.UUID]time.Time{scheduled.ID: last},
+	})
+
+	// Execute
+	job, err := jobService.GetJobByID(scheduled.ID)
+	otherJob, otherErr := jobService.GetJobByID(other.ID)
+
+	// Assert - live times win, and recorded ones are kept for jobs the source doesn't know
+	assert.NoError(t, err)
+	assert.Equal(t, next, *job.NextRunAt)
+	assert.Equal(t, last, *job.LastRunAt)
+	assert.NoError(t, otherErr)
+	assert.Equal(t, stale, *otherJob.NextRunAt)
+	assert.Nil(t, otherJob.LastRunAt)
+}
+
+func TestJobService_PauseAndResumeJob(t *testing.T) {
+	// Setup
+	job := &models.Job{ID: uuid.New(), Name: "Nightly ETL", Schedule: "0 2 * * *", State: models.JobStateActive}
+	mockRepo := new(MockJobRepository)
+	mockRepo.On("GetByID", job.ID).Return(job, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*models.Job")).Return(nil)
+	jobService := services.NewJobService(mockRepo)
+
+	// Pausing needs an identified user and records who paused the job and why
+	_, err := jobService.PauseJob(job.ID, "", "Upstream outage")
+	assert.ErrorIs(t, err, services.ErrPauseActorRequired)
+
+	paused, err := jobService.PauseJob(job.ID, "alice", "Upstream outage")
+	assert.NoError(t, err)
+	assert.Equal(t, models.JobStatePaused, paused.State)
+	assert.NotNil(t, paused.PausedAt)
+	assert.Equal(t, "alice", paused.PausedBy)
+	assert.Equal(t, "Upstream outage", paused.PauseReason)
+	assert.Nil(t, paused.NextRunAt)
+
+	_, err = jobService.PauseJob(job.ID, "alice", "Again")
+	assert.ErrorIs(t, err, services.ErrJobAlreadyPaused)
+
+	// Resuming clears the pause record
+	resumed, err := jobService.ResumeJob(job.ID, "bob")
+	assert.NoError(t, err)
+	assert.Equal(t, models.JobStateActive, resumed.State)
+	assert.Nil(t, resumed.PausedAt)
+	assert.Empty(t, resumed.PausedBy)
+	assert.Empty(t, resumed.PauseReason)
+	assert.NotNil(t, resumed.NextRunAt)
+
+	_, err = jobService.ResumeJob(job.ID, "bob")
+	assert.ErrorIs(t, err, services.ErrJobNotPaused)
+}
+
+func TestJobService_CreateJob_InvalidRemediationActions(t *testing.T) {
+	jobService := services.NewJobService(new(MockJobRepository))
+
+	for _, actions := range []models.RemediationActions{
+		{{Type: models.RemediationCallWebhook, WebhookURL: "ftp://example.com/hook"}},
+		{{Type: models.RemediationRunFallback}},
+		{{Type: "reboot_server"}},
+		make(models.RemediationActions, models.MaxRemediationActions+1),
+	} {
+		_, err := jobService.CreateJob(&models.CreateJobRequest{
+			Name:               "Test Job",
+			Schedule:           "0 * * * *",
+			JobType:            models.JobTypeDataProcessing,
+			RemediationActions: actions,
+		})
+		assert.Error(t, err)
+	}
+}
+
+func TestJobService_CreateJob_OneTime(t *testing.T) {
+	// Setup
+	mockRepo := new(MockJobRepository)
+	mockRepo.On("Create", mock.AnythingOfType("*models.Job")).Return(nil)
+	jobService := services.NewJobService(mockRepo)
+	runAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	// Execute
+	job, err := jobService.CreateJob(&models.CreateJobRequest{
+		Name:         "Send launch email",
+		ScheduleType: models.ScheduleTypeOnce,
+		RunAt:        &runAt,
+		JobType:      models.JobTypeDataProcessing,
+	})
+
+	// Assert - the job is next due at run_at
+	assert.NoError(t, err)
+	assert.True(t, job.IsOneTime())
+	if assert.NotNil(t, job.NextRunAt) {
+		assert.Equal(t, runAt, *job.NextRunAt)
+	}
+
+	// One-time jobs need a future run_at and no cron schedule
+	past := time.Now().Add(-time.Minute)
+	_, err = jobService.CreateJob(&models.CreateJobRequest{Name: "Late", ScheduleType: models.ScheduleTypeOnce, RunAt: &past, JobType: models.JobTypeDataProcessing})
+	assert.ErrorIs(t, err, services.ErrRunAtPassed)
+	_, err = jobService.CreateJob(&models.CreateJobRequest{Name: "No run_at", ScheduleType: models.ScheduleTypeOnce, JobType: models.JobTypeDataProcessing})
+	assert.Error(t, err)
+	_, err = jobService.CreateJob(&models.CreateJobRequest{Name: "Both", ScheduleType: models.ScheduleTypeOnce, Schedule: "0 * * * *", RunAt: &runAt, JobType: models.JobTypeDataProcessing})
+	assert.Error(t, err)
+}
+
+func TestJobService_CreateJob_CronSeconds(t *testing.T) {
+	// Setup
+	mockRepo := new(MockJobRepository)
+	mockRepo.On("Create", mock.AnythingOfType("*models.Job")).Return(nil)
+	jobService := services.NewJobService(mockRepo)
+	enabled := true
+
+	// Six fields need cron_seconds, per job or by default
+	_, err := jobService.CreateJob(&models.CreateJobRequest{Name: "Poll", Schedule: "*/15 * * * * *", JobType: models.JobTypeDataProcessing})
+	assert.Error(t, err)
+
+	job, err := jobService.CreateJob(&models.CreateJobRequest{Name: "Poll", Schedule: "*/15 * * * * *", CronSeconds: &enabled, JobType: models.JobTypeDataProcessing})
+	assert.NoError(t, err)
+	assert.True(t, job.CronSeconds)
+	if assert.NotNil(t, job.NextRunAt) {
+		assert.Zero(t, job.NextRunAt.Second()%15)
+		assert.True(t, job.NextRunAt.Sub(time.Now()) <= 15*time.Second)
+	}
+
+	jobService.SetCronSecondsDefault(true)
+	job, err = jobService.CreateJob(&models.CreateJobRequest{Name: "Poll", Schedule: "30 * * * * *", JobType: models.JobTypeDataProcessing})
+	assert.NoError(t, err)
+	assert.True(t, job.CronSeconds)
+
+	// Five fields keep their meaning with seconds enabled
+	schedule, err := services.ParseSchedule("0 9 * * *", true)
+	assert.NoError(t, err)
+	from := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), schedule.Next(from))
+}
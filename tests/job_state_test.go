@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+func TestJobService_UpdateJob_ChangesState(t *testing.T) {
+	// Setup
+	job := &models.Job{ID: uuid.New(), Name: "Nightly ETL", Schedule: "0 2 * * *", State: models.JobStateDisabled}
+	mockRepo := new(MockJobRepository)
+	mockRepo.On("GetByID", job.ID).Return(job, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*models.Job")).Return(nil)
+	jobService := services.NewJobService(mockRepo)
+	state := func(s models.JobState) *models.JobState { return &s }
+
+	// Execute - disabled jobs may be archived, but archived jobs can't go straight back to active
+	archived, err := jobService.UpdateJob(job.ID, &models.UpdateJobRequest{State: state(models.JobStateArchived)})
+	assert.NoError(t, err)
+	assert.Equal(t, models.JobStateArchived, archived.State)
+
+	_, err = jobService.UpdateJob(job.ID, &models.UpdateJobRequest{State: state(models.JobStateActive)})
+	assert.ErrorIs(t, err, services.ErrInvalidStateTransition)
+
+	// Paused is entered by pausing, not by updating; unknown and conflicting states are refused
+	_, err = jobService.UpdateJob(job.ID, &models.UpdateJobRequest{State: state(models.JobStatePaused)})
+	assert.ErrorIs(t, err, services.ErrJobStateNotRequestable)
+	_, err = jobService.UpdateJob(job.ID, &models.UpdateJobRequest{State: state("sleeping")})
+	assert.Error(t, err)
+	active := true
+	_, err = jobService.UpdateJob(job.ID, &models.UpdateJobRequest{IsActive: &active, State: state(models.JobStateDisabled)})
+	assert.Error(t, err)
+
+	// Assert - is_active false leaves a job that isn't active in its state
+	inactive := false
+	unchanged, err := jobService.UpdateJob(job.ID, &models.UpdateJobRequest{IsActive: &inactive})
+	assert.NoError(t, err)
+	assert.Equal(t, models.JobStateArchived, unchanged.State)
+	assert.False(t, unchanged.IsActive())
+}
+
+func TestJobHandler_GetJobs_FiltersByState(t *testing.T) {
+	// Setup
+	mockRepo := new(MockJobRepository)
+	jobService := services.NewJobService(mockRepo)
+	router := newJobNameRouter(jobService, mockRepo)
+	errored := models.Job{ID: uuid.New(), Name: "Migrated", Schedule: "every other tuesday", State: models.JobStateErrored}
+	filter := models.JobFilter{States: []models.JobState{models.JobStateActive, models.JobStateErrored}}
+	mockRepo.On("GetAll", 1, 10, models.JobSortNewest, filter).Return([]models.Job{errored}, int64(1), nil)
+
+	// Execute
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs?state=active,%20errored", nil))
+	invalid := httptest.NewRecorder()
+	router.ServeHTTP(invalid, httptest.NewRequest(http.MethodGet, "/api/v1/jobs?state=sleeping", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"state":"errored"`)
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+	mockRepo.AssertExpectations(t)
+}
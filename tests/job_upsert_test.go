@@ -0,0 +1,192 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/handlers"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+func newJobNameRouter(jobService services.JobService, jobRepo *MockJobRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	changeControl := services.NewChangeControlService(jobService, jobRepo, new(MockPendingChangeRepository), new(MockAuditRepository), false)
+
+	router := gin.New()
+	handlers.NewJobHandler(jobService, changeControl).RegisterRoutes(router.Group("/api/v1"))
+	return router
+}
+
+func putJob(router *gin.Engine, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, path, strings.NewReader(body)))
+	return w
+}
+
+func TestJobService_CreateJob_NameTaken(t *testing.T) {
+	// Setup
+	mockRepo := new(MockJobRepository)
+	jobService := services.NewJobService(mockRepo)
+	jobService.SetUniqueJobNames(true)
+	holder := &models.Job{ID: uuid.New(), Name: "nightly-etl", Team: "data"}
+	mockRepo.On("CreateIfNameFree", mock.AnythingOfType("*models.Job")).Return(holder, nil)
+
+	// Execute
+	job, err := jobService.CreateJob(&models.CreateJobRequest{
+		Name:     "nightly-etl",
+		Schedule: "0 2 * * *",
+		JobType:  models.JobTypeDataProcessing,
+		Team:     " data ",
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, services.ErrJobNameTaken)
+	assert.Nil(t, job)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestJobHandler_CreateJob_ConflictWhenNameTaken(t *testing.T) {
+	mockRepo := new(MockJobRepository)
+	jobService := services.NewJobService(mockRepo)
+	jobService.SetUniqueJobNames(true)
+	mockRepo.On("CreateIfNameFree", mock.AnythingOfType("*models.Job")).Return(&models.Job{ID: uuid.New()}, nil)
+
+	w := httptest.NewRecorder()
+	newJobNameRouter(jobService, mockRepo).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/jobs",
+		strings.NewReader(`{"name":"nightly-etl","schedule":"0 2 * * *","job_type":"data_processing"}`)))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), services.ErrJobNameTaken.Error())
+}
+
+func TestJobHandler_UpsertJobByName_Creates(t *testing.T) {
+	// Setup - the team has no job with the name
+	mockRepo := new(MockJobRepository)
+	jobService := services.NewJobService(mockRepo)
+	mockRepo.On("FindByName", "data", "nightly-etl").Return(nil, nil)
+	mockRepo.On("CreateIfNameFree", mock.AnythingOfType("*models.Job")).Return(nil, nil)
+
+	// Execute
+	w := putJob(newJobNameRouter(jobService, mockRepo), "/api/v1/jobs/by-name/nightly-etl",
+		`{"schedule":"0 2 * * *","job_type":"data_processing","team":"data"}`)
+
+	// Assert - created under the name from the URL, even though names aren't required to be unique
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var body struct {
+		Job struct {
+			Name string `json:"name"`
+			Team string `json:"team"`
+		} `json:"job"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "nightly-etl", body.Job.Name)
+	assert.Equal(t, "data", body.Job.Team)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestJobHandler_UpsertJobByName_UpdatesExisting(t *testing.T) {
+	// Setup - a paused job with the name already exists
+	mockRepo := new(MockJobRepository)
+	jobService := services.NewJobService(mockRepo)
+	existing := &models.Job{
+		ID:           uuid.New(),
+		Name:         "nightly-etl",
+		Team:         "data",
+		Owner:        "olivia",
+		Schedule:     "0 2 * * *",
+		ScheduleType: models.ScheduleTypeCron,
+		JobType:      models.JobTypeDataProcessing,
+		Config:       models.JobConfig{"batch_size": float64(100)},
+		State:        models.JobStateDisabled,
+	}
+	stored := *existing
+	mockRepo.On("FindByName", "data", "nightly-etl").Return(&stored, nil)
+	mockRepo.On("GetByID", existing.ID).Return(&stored, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*models.Job")).Return(nil)
+
+	// Execute
+	w := putJob(newJobNameRouter(jobService, mockRepo), "/api/v1/jobs/by-name/nightly-etl",
+		`{"schedule":"0 3 * * *","job_type":"data_processing","team":"data","description":"Load the warehouse","config":{"batch_size":100}}`)
+
+	// Assert - updated in place, still disabled and owned by its owner
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockRepo.AssertNotCalled(t, "CreateIfNameFree", mock.Anything)
+	mockRepo.AssertCalled(t, "Update", mock.MatchedBy(func(job *models.Job) bool {
+		return job.ID == existing.ID && job.Schedule == "0 3 * * *" && job.Description == "Load the warehouse" &&
+			job.State == models.JobStateDisabled && job.Owner == "olivia" && job.Severity == models.JobSeverityMedium
+	}))
+}
+
+func TestJobService_ReplacementRequest_OnlyChangedTiming(t *testing.T) {
+	jobService := services.NewJobService(new(MockJobRepository))
+	job := &models.Job{
+		Name:         "nightly-etl",
+		Schedule:     "0 2 * * *",
+		ScheduleType: models.ScheduleTypeCron,
+		JobType:      models.JobTypeDataProcessing,
+		Config:       models.JobConfig{"batch_size": float64(100)},
+		Tags:         models.JobTags{"protected"},
+	}
+
+	// Applying the job's own settings again leaves its timing, config and tags alone
+	update := jobService.ReplacementRequest(job, &models.CreateJobRequest{
+		Name:     "nightly-etl",
+		Schedule: "0 2 * * *",
+		JobType:  models.JobTypeDataProcessing,
+		Config:   models.JobConfig{"batch_size": float64(100)},
+		Tags:     models.JobTags{"protected"},
+	})
+
+	assert.Nil(t, update.Schedule)
+	assert.Nil(t, update.ScheduleType)
+	assert.Nil(t, update.CronSeconds)
+	assert.Nil(t, update.Config)
+	assert.Nil(t, update.Tags)
+	assert.Nil(t, update.IsActive)
+	assert.Nil(t, update.Owner)
+}
+
+func TestJobHandler_UpsertJobByName_NameMismatch(t *testing.T) {
+	mockRepo := new(MockJobRepository)
+
+	w := putJob(newJobNameRouter(services.NewJobService(mockRepo), mockRepo), "/api/v1/jobs/by-name/nightly-etl",
+		`{"name":"other","schedule":"0 2 * * *","job_type":"data_processing"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockRepo.AssertNotCalled(t, "FindByName", mock.Anything, mock.Anything)
+}
+
+func TestJobHandler_GetAndDeleteJobByName(t *testing.T) {
+	// Setup
+	mockRepo := new(MockJobRepository)
+	job := &models.Job{ID: uuid.New(), Name: "nightly-etl", Team: "data", JobType: models.JobTypeDataProcessing}
+	mockRepo.On("FindByName", "data", "nightly-etl").Return(job, nil)
+	mockRepo.On("FindByName", "", "nightly-etl").Return(nil, nil)
+	mockRepo.On("Delete", job.ID).Return(nil)
+	router := newJobNameRouter(services.NewJobService(mockRepo), mockRepo)
+
+	// Execute & Assert - found within its team
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/by-name/nightly-etl?team=data", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), job.ID.String())
+
+	// Jobs without a team are another scope
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/by-name/nightly-etl", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/v1/jobs/by-name/nightly-etl?team=data", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockRepo.AssertCalled(t, "Delete", job.ID)
+}
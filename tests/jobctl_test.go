@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/apiclient"
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/handlers"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+func newJobTriggerServer(jobService services.JobService, trigger services.JobTrigger) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	v1 := router.Group("/api/v1")
+	handlers.NewJobHandler(jobService, nil).RegisterRoutes(v1)
+	handlers.NewJobTriggerHandler(jobService, trigger).RegisterRoutes(v1)
+	return httptest.NewServer(router)
+}
+
+func TestJobTriggerHandler_TriggersJobWithParams(t *testing.T) {
+	// Setup
+	job := &models.Job{ID: uuid.New(), Name: "nightly-etl", JobType: models.JobTypeDataProcessing, State: models.JobStateActive}
+	mockRepo := new(MockJobRepository)
+	mockRepo.On("GetByID", job.ID).Return(job, nil)
+	mockTrigger := new(MockJobTrigger)
+	mockTrigger.On("TriggerJob", job, models.JobConfig{"date": "2024-01-01"}).Return(nil)
+	server := newJobTriggerServer(services.NewJobService(mockRepo), mockTrigger)
+	defer server.Close()
+
+	// Execute
+	err := apiclient.New(server.URL, "alice").TriggerJob(context.Background(), job.ID, models.JobConfig{"date": "2024-01-01"})
+
+	// Assert
+	assert.NoError(t, err)
+	mockTrigger.AssertExpectations(t)
+}
+
+func TestJobTriggerHandler_ReportsUnknownJobsAndStoppedScheduler(t *testing.T) {
+	// Setup
+	job := &models.Job{ID: uuid.New(), Name: "nightly-etl", JobType: models.JobTypeDataProcessing}
+	missing := uuid.New()
+	mockRepo := new(MockJobRepository)
+	mockRepo.On("GetByID", job.ID).Return(job, nil)
+	mockRepo.On("GetByID", missing).Return((*models.Job)(nil), errors.New("record not found"))
+	mockTrigger := new(MockJobTrigger)
+	mockTrigger.On("TriggerJob", job, mock.Anything).Return(errors.New("scheduler is not running"))
+	server := newJobTriggerServer(services.NewJobService(mockRepo), mockTrigger)
+	defer server.Close()
+	client := apiclient.New(server.URL, "alice")
+
+	// Execute
+	missingErr := client.TriggerJob(context.Background(), missing, nil)
+	stoppedErr := client.TriggerJob(context.Background(), job.ID, nil)
+
+	// Assert
+	var apiErr *apiclient.Error
+	if assert.True(t, errors.As(missingErr, &apiErr)) {
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	}
+	if assert.True(t, errors.As(stoppedErr, &apiErr)) {
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+		assert.Equal(t, "Failed to trigger job", apiErr.Message)
+	}
+}
+
+func TestAPIClient_GetJobSendsUser(t *testing.T) {
+	// Setup
+	job := &models.Job{ID: uuid.New(), Name: "nightly-etl", JobType: models.JobTypeDataProcessing, Schedule: "0 2 * * *"}
+	var user, authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user = r.Header.Get(handlers.ActorHeader)
+		authorization = r.Header.Get("Authorization")
+		assert.Equal(t, "/api/v1/jobs/"+job.ID.String(), r.URL.Path)
+		json.NewEncoder(w).Encode(map[string]interface{}{"job": dto.FromJob(job)})
+	}))
+	defer server.Close()
+
+	// Execute
+	client := apiclient.New(server.URL+"/", "alice")
+	client.SetToken("alice-token")
+	got, err := client.GetJob(context.Background(), job.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", user)
+	assert.Equal(t, "Bearer alice-token", authorization)
+	assert.Equal(t, job.ID, got.ID)
+	assert.Equal(t, "0 2 * * *", got.Schedule)
+}
+
+func TestAPIClient_FollowExecutionLogsUntilRunFinishes(t *testing.T) {
+	// Setup - the run logs a line, then another and finishes
+	executionID := uuid.New()
+	pages := []apiclient.ExecutionLogPage{
+		{Lines: []dto.ExecutionLogLineResponse{{Sequence: 1, Message: "started"}}, NextAfter: 1},
+		{Lines: []dto.ExecutionLogLineResponse{}, NextAfter: 1},
+		{Lines: []dto.ExecutionLogLineResponse{{Sequence: 2, Message: "done"}}, NextAfter: 2, Finished: true},
+	}
+	var afters []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		afters = append(afters, r.URL.Query().Get("after"))
+		page := pages[0]
+		pages = pages[1:]
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	// Execute
+	var messages []string
+	err := apiclient.New(server.URL, "").FollowExecutionLogs(context.Background(), executionID, true, time.Millisecond,
+		func(line dto.ExecutionLogLineResponse) { messages = append(messages, line.Message) })
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"started", "done"}, messages)
+	assert.Equal(t, []string{"0", "1", "1"}, afters)
+}
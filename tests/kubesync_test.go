@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/kubesync"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// fakeKubernetesAPI serves a list of ScheduledJobs and records the patches sent to them
+type fakeKubernetesAPI struct {
+	mu      sync.Mutex
+	items   []kubesync.ScheduledJob
+	patches map[string]map[string]interface{}
+}
+
+func (f *fakeKubernetesAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		list := kubesync.ScheduledJobList{Items: f.items}
+		list.Metadata.ResourceVersion = "42"
+		_ = json.NewEncoder(w).Encode(list)
+	case http.MethodPatch:
+		body, _ := io.ReadAll(r.Body)
+		var patch map[string]interface{}
+		_ = json.Unmarshal(body, &patch)
+		f.patches[r.URL.Path] = patch
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newKubeSyncController(t *testing.T, jobRepo *MockJobRepository, items ...kubesync.ScheduledJob) (*kubesync.Controller, *fakeKubernetesAPI) {
+	api := &fakeKubernetesAPI{items: items, patches: map[string]map[string]interface{}{}}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{KubernetesSync: config.KubernetesSyncConfig{
+		Enabled:           true,
+		APIServer:         server.URL,
+		ServiceAccountDir: t.TempDir(),
+		ResyncInterval:    time.Minute,
+		Actor:             "kubernetes",
+	}}
+	client, err := kubesync.NewClient(cfg.KubernetesSync)
+	require.NoError(t, err)
+
+	jobService := services.NewJobService(jobRepo)
+	changeControl := services.NewChangeControlService(jobService, jobRepo, new(MockPendingChangeRepository), new(MockAuditRepository), false)
+	return kubesync.NewController(client, jobService, changeControl, cfg), api
+}
+
+func TestKubeSync_SyncAll_CreatesJobAndReportsStatus(t *testing.T) {
+	// Setup - name and team come from the resource's name and namespace
+	mockRepo := new(MockJobRepository)
+	mockRepo.On("FindByName", "data", "nightly-etl").Return(nil, nil)
+	mockRepo.On("CreateIfNameFree", mock.AnythingOfType("*models.Job")).Return(nil, nil)
+	sj := kubesync.ScheduledJob{
+		Metadata: kubesync.ObjectMeta{Name: "nightly-etl", Namespace: "data", ResourceVersion: "7", Generation: 3},
+		Spec:     models.JobDefinition{JobType: models.JobTypeDataProcessing, Schedule: "0 2 * * *"},
+	}
+	controller, api := newKubeSyncController(t, mockRepo, sj)
+
+	// Execute
+	resourceVersion, err := controller.SyncAll(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "42", resourceVersion)
+
+	created := mockRepo.Calls[len(mockRepo.Calls)-1].Arguments.Get(0).(*models.Job)
+	assert.Equal(t, "data", created.Team)
+	assert.Equal(t, "nightly-etl", created.Name)
+	assert.Equal(t, "kubernetes", created.Owner)
+
+	object := "/apis/job-scheduler.io/v1alpha1/namespaces/data/scheduledjobs/nightly-etl"
+	metadata := api.patches[object]["metadata"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"job-scheduler.io/delete-job"}, metadata["finalizers"])
+	assert.Equal(t, "7", metadata["resourceVersion"])
+
+	status := api.patches[object+"/status"]["status"].(map[string]interface{})
+	assert.Equal(t, true, status["synced"])
+	assert.Equal(t, float64(3), status["observedGeneration"])
+	assert.Equal(t, created.ID.String(), status["jobId"])
+	assert.Equal(t, "active", status["state"])
+}
+
+func TestKubeSync_SyncAll_ReportsInvalidSpec(t *testing.T) {
+	// Setup
+	mockRepo := new(MockJobRepository)
+	mockRepo.On("FindByName", "data", "broken").Return(nil, nil)
+	sj := kubesync.ScheduledJob{
+		Metadata: kubesync.ObjectMeta{Name: "broken", Namespace: "data", Finalizers: []string{"job-scheduler.io/delete-job"}},
+		Spec:     models.JobDefinition{JobType: "cooking", Schedule: "0 2 * * *"},
+	}
+	controller, api := newKubeSyncController(t, mockRepo, sj)
+
+	// Execute
+	_, err := controller.SyncAll(context.Background())
+
+	// Assert - the finalizer is already there, so only the status is patched
+	require.NoError(t, err)
+	assert.Len(t, api.patches, 1)
+	status := api.patches["/apis/job-scheduler.io/v1alpha1/namespaces/data/scheduledjobs/broken/status"]["status"].(map[string]interface{})
+	assert.Equal(t, false, status["synced"])
+	assert.NotEmpty(t, status["message"])
+	mockRepo.AssertNotCalled(t, "CreateIfNameFree", mock.Anything)
+}
+
+func TestKubeSync_SyncAll_DeletesJobOfDeletedResource(t *testing.T) {
+	// Setup
+	job := &models.Job{ID: uuid.New(), Name: "nightly-etl", Team: "data", Schedule: "0 2 * * *", State: models.JobStateActive}
+	mockRepo := new(MockJobRepository)
+	mockRepo.On("FindByName", "data", "nightly-etl").Return(job, nil)
+	mockRepo.On("Delete", job.ID).Return(nil)
+	deletedAt := time.Now().UTC()
+	sj := kubesync.ScheduledJob{
+		Metadata: kubesync.ObjectMeta{
+			Name:              "nightly-etl",
+			Namespace:         "data",
+			DeletionTimestamp: &deletedAt,
+			Finalizers:        []string{"example.com/other", "job-scheduler.io/delete-job"},
+		},
+		Spec: models.JobDefinition{JobType: models.JobTypeDataProcessing, Schedule: "0 2 * * *"},
+	}
+	controller, api := newKubeSyncController(t, mockRepo, sj)
+
+	// Execute
+	_, err := controller.SyncAll(context.Background())
+
+	// Assert - other finalizers are kept
+	require.NoError(t, err)
+	mockRepo.AssertCalled(t, "Delete", job.ID)
+	metadata := api.patches["/apis/job-scheduler.io/v1alpha1/namespaces/data/scheduledjobs/nightly-etl"]["metadata"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"example.com/other"}, metadata["finalizers"])
+}
@@ -0,0 +1,230 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/logship"
+)
+
+// blockingSink holds every send until released, to fill the hook's buffer
+type blockingSink struct {
+	mu      sync.Mutex
+	release chan struct{}
+	sent    int
+}
+
+func (s *blockingSink) Send(ctx context.Context, entries []logship.Entry) error {
+	<-s.release
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent += len(entries)
+	return nil
+}
+
+func (s *blockingSink) Close() error {
+	return nil
+}
+
+func newShippingLogger(hook logrus.Hook) *logrus.Logger {
+	logger := logrus.New()
+	logger.Out = &strings.Builder{}
+	logger.AddHook(hook)
+	return logger
+}
+
+func TestLogShipping_Loki(t *testing.T) {
+	// Setup - a Loki that records the pushes it receives
+	var mu sync.Mutex
+	var pushes []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/loki/api/v1/push", r.URL.Path)
+		var push map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&push))
+		mu.Lock()
+		pushes = append(pushes, push)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	hook, err := logship.NewHookFromConfig(config.LogShippingConfig{
+		Backend:       "loki",
+		URL:           server.URL,
+		Service:       "job-scheduler",
+		Level:         "info",
+		BatchSize:     10,
+		FlushInterval: time.Hour,
+		BufferSize:    100,
+		Timeout:       time.Second,
+	})
+	require.NoError(t, err)
+	logger := newShippingLogger(hook)
+
+	// Execute - the debug entry is below the shipping level; Close sends the partial batch
+	logger.WithField("job_id", "abc").Info("Job started")
+	logger.WithError(errors.New("boom")).Error("Job failed")
+	logger.Debug("Noise")
+	require.NoError(t, hook.Close())
+
+	// Assert - one push, one stream per level, fields kept in the line
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, pushes, 1)
+	streams := pushes[0]["streams"].([]interface{})
+	require.Len(t, streams, 2)
+
+	info := streams[0].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"service": "job-scheduler", "level": "info"}, info["stream"])
+	line := info["values"].([]interface{})[0].([]interface{})[1].(string)
+	assert.Contains(t, line, `"job_id":"abc"`)
+	assert.Contains(t, line, `"msg":"Job started"`)
+
+	errorLine := streams[1].(map[string]interface{})["values"].([]interface{})[0].([]interface{})[1].(string)
+	assert.Contains(t, errorLine, `"error":"boom"`)
+	assert.Equal(t, logship.Stats{Shipped: 2}, hook.Stats())
+}
+
+func TestLogShipping_Elasticsearch(t *testing.T) {
+	// Setup - an Elasticsearch that records the bulk lines it receives
+	var lines []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_bulk", r.URL.Path)
+		assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		w.Write([]byte(`{"errors":false,"items":[]}`))
+	}))
+	defer server.Close()
+
+	hook, err := logship.NewHookFromConfig(config.LogShippingConfig{
+		Backend:       "elasticsearch",
+		URL:           server.URL,
+		Index:         "scheduler-logs",
+		Service:       "job-scheduler",
+		Level:         "info",
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+		BufferSize:    10,
+		Timeout:       time.Second,
+	})
+	require.NoError(t, err)
+	logger := newShippingLogger(hook)
+
+	// Execute - a full batch is sent without waiting for the flush interval
+	logger.Info("one")
+	logger.Warn("two")
+	require.Eventually(t, func() bool { return hook.Stats().Shipped == 2 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, hook.Close())
+
+	// Assert - an index action before each document
+	require.Len(t, lines, 4)
+	assert.JSONEq(t, `{"index":{"_index":"scheduler-logs"}}`, lines[0])
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[3]), &doc))
+	assert.Equal(t, "two", doc["msg"])
+	assert.Equal(t, "warning", doc["level"])
+	assert.Equal(t, "job-scheduler", doc["service"])
+	assert.NotEmpty(t, doc["@timestamp"])
+}
+
+func TestLogShipping_Syslog(t *testing.T) {
+	// Setup
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	hook, err := logship.NewHookFromConfig(config.LogShippingConfig{
+		Backend:       "syslog",
+		URL:           "udp://" + listener.LocalAddr().String(),
+		Service:       "job-scheduler",
+		Level:         "info",
+		BatchSize:     1,
+		FlushInterval: time.Hour,
+		BufferSize:    10,
+		Timeout:       time.Second,
+	})
+	require.NoError(t, err)
+	logger := newShippingLogger(hook)
+
+	// Execute
+	logger.Error("Job failed")
+
+	// Assert - local0.err is priority 131
+	buf := make([]byte, 2048)
+	listener.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := listener.ReadFrom(buf)
+	require.NoError(t, err)
+	message := string(buf[:n])
+	assert.True(t, strings.HasPrefix(message, "<131>1 "), message)
+	assert.Contains(t, message, " job-scheduler ")
+	assert.Contains(t, message, `"msg":"Job failed"`)
+	require.NoError(t, hook.Close())
+}
+
+func TestLogShipping_DropsWhenBufferFull(t *testing.T) {
+	// Setup - a backend that doesn't answer until released
+	sink := &blockingSink{release: make(chan struct{})}
+	hook := logship.NewHook(sink, logship.HookOptions{
+		Level:         logrus.InfoLevel,
+		BatchSize:     1,
+		FlushInterval: time.Hour,
+		BufferSize:    2,
+		Timeout:       time.Second,
+	})
+	logger := newShippingLogger(hook)
+
+	// Execute - logging never blocks: one entry is being sent, two wait, the rest are dropped
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			logger.Info("entry")
+			time.Sleep(5 * time.Millisecond)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("logging blocked on a slow backend")
+	}
+	close(sink.release)
+	require.NoError(t, hook.Close())
+
+	// Assert
+	stats := hook.Stats()
+	assert.Equal(t, uint64(10), stats.Shipped+stats.Dropped)
+	assert.Equal(t, uint64(3), stats.Shipped)
+	assert.Equal(t, uint64(7), stats.Dropped)
+}
+
+func TestLogShipping_Config(t *testing.T) {
+	hook, err := logship.NewHookFromConfig(config.LogShippingConfig{})
+	assert.NoError(t, err)
+	assert.Nil(t, hook)
+
+	_, err = logship.NewHookFromConfig(config.LogShippingConfig{Backend: "splunk", URL: "http://splunk", Level: "info"})
+	assert.Error(t, err)
+
+	_, err = logship.NewHookFromConfig(config.LogShippingConfig{Backend: "loki", Level: "info"})
+	assert.Error(t, err)
+
+	_, err = logship.NewHookFromConfig(config.LogShippingConfig{Backend: "syslog", URL: "http://syslog", Level: "info"})
+	assert.Error(t, err)
+}
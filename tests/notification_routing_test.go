@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/notifications"
+)
+
+// stubChannelLookup returns channels from a map keyed by team
+type stubChannelLookup map[string]*models.TeamChannel
+
+func (s stubChannelLookup) FindByTeam(team string) (*models.TeamChannel, error) {
+	return s[team], nil
+}
+
+// recordingNotifier records the notifications it receives
+type recordingNotifier struct {
+	received []notifications.Notification
+}
+
+func (r *recordingNotifier) Notify(ctx context.Context, n notifications.Notification) error {
+	r.received = append(r.received, n)
+	return nil
+}
+
+func TestRoutingNotifier_RoutesToTeamChannel(t *testing.T) {
+	// Setup a Slack-style endpoint for the team
+	var payload map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	fallback := &recordingNotifier{}
+	channels := stubChannelLookup{
+		"payments": {Team: "payments", Kind: models.ChannelKindSlack, URL: server.URL, IsActive: true},
+	}
+	notifier := notifications.NewRoutingNotifier(channels, fallback, &http.Client{Timeout: 5 * time.Second}, nil)
+
+	job := &models.Job{ID: uuid.New(), Name: "Settlement", Team: "payments", RunbookURL: "https://wiki.example.com/settlement"}
+
+	// Execute
+	err := notifier.Notify(context.Background(), notifications.Notification{
+		Event: notifications.EventJobFailed,
+		Title: "Job failed: Settlement",
+		Job:   job,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, fallback.received)
+	assert.Contains(t, payload["text"], "Job failed: Settlement")
+	assert.Contains(t, payload["text"], job.RunbookURL)
+}
+
+func TestRoutingNotifier_FallsBackWithoutTeamChannel(t *testing.T) {
+	fallback := &recordingNotifier{}
+	channels := stubChannelLookup{
+		"payments": {Team: "payments", Kind: models.ChannelKindSlack, URL: "http://127.0.0.1:0", IsActive: false},
+	}
+	notifier := notifications.NewRoutingNotifier(channels, fallback, &http.Client{Timeout: 5 * time.Second}, nil)
+
+	for _, team := range []string{"", "search", "payments"} {
+		err := notifier.Notify(context.Background(), notifications.Notification{
+			Event: notifications.EventJobFailed,
+			Job:   &models.Job{ID: uuid.New(), Team: team},
+		})
+		assert.NoError(t, err)
+	}
+
+	// No team, unknown team and inactive channel all use the default channel
+	assert.Len(t, fallback.received, 3)
+}
+
+func TestFilteringNotifier_DeliversWhatTheJobsFilterMatches(t *testing.T) {
+	// Setup - the job only wants failure notifications once retries are exhausted
+	delivered := &recordingNotifier{}
+	notifier := notifications.NewFilteringNotifier(delivered)
+	job := &models.Job{ID: uuid.New(), Name: "Settlement", Team: "payments", Config: models.JobConfig{
+		"notification_filter": "event == 'job_failed' && execution.attempt >= 2 && job.team == 'payments'",
+	}}
+	unfiltered := &models.Job{ID: uuid.New(), Name: "Cleanup"}
+
+	// Execute
+	for _, n := range []notifications.Notification{
+		{Event: notifications.EventJobFailed, Job: job, Execution: &models.JobExecution{ID: uuid.New(), Attempt: 1}},
+		{Event: notifications.EventJobFailed, Job: job, Execution: &models.JobExecution{ID: uuid.New(), Attempt: 2}},
+		{Event: notifications.EventTimeoutWarning, Job: job, Execution: &models.JobExecution{ID: uuid.New(), Attempt: 2}},
+		{Event: notifications.EventTimeoutWarning, Job: unfiltered},
+		{Event: notifications.EventOverloaded},
+	} {
+		assert.NoError(t, notifier.Notify(context.Background(), n))
+	}
+
+	// Assert - the filter drops the first attempt and the warning, and other notifications pass
+	require.Len(t, delivered.received, 3)
+	assert.Equal(t, 2, delivered.received[0].Execution.Attempt)
+	assert.Equal(t, unfiltered, delivered.received[1].Job)
+	assert.Equal(t, notifications.EventOverloaded, delivered.received[2].Event)
+}
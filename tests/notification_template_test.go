@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/notifications"
+)
+
+// stubTemplateLookup returns templates keyed by channel
+type stubTemplateLookup map[models.TemplateChannel]*models.NotificationTemplate
+
+func (s stubTemplateLookup) FindTemplate(channel models.TemplateChannel, event string) (*models.NotificationTemplate, error) {
+	tmpl := s[channel]
+	if tmpl == nil || (tmpl.Event != "" && tmpl.Event != event) {
+		return nil, nil
+	}
+	return tmpl, nil
+}
+
+func TestValidateTemplate_AcceptsValidJSONTemplate(t *testing.T) {
+	body := `{"text": {{json .Job.Name}}, "error": {{json .Error}}, "took": {{json .Duration}}}`
+
+	err := notifications.ValidateTemplate(models.TemplateChannelSlack, "", body)
+
+	assert.NoError(t, err)
+}
+
+func TestValidateTemplate_RejectsInvalidJSON(t *testing.T) {
+	// Unquoted string values don't produce valid JSON
+	body := `{"text": {{.Job.Name}}}`
+
+	err := notifications.ValidateTemplate(models.TemplateChannelWebhook, "", body)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "valid JSON")
+}
+
+func TestValidateTemplate_RejectsUnknownField(t *testing.T) {
+	err := notifications.ValidateTemplate(models.TemplateChannelEmail, "{{.Job.Name}}", "<p>{{.Job.Nope}}</p>")
+
+	assert.Error(t, err)
+}
+
+func TestValidateTemplate_RejectsSyntaxError(t *testing.T) {
+	err := notifications.ValidateTemplate(models.TemplateChannelEmail, "", "<p>{{.Job.Name</p>")
+
+	assert.Error(t, err)
+}
+
+func TestWebhookNotifier_UsesStoredTemplate(t *testing.T) {
+	// Setup
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	renderer := notifications.NewRenderer(stubTemplateLookup{
+		models.TemplateChannelWebhook: {
+			Name:    "failures",
+			Channel: models.TemplateChannelWebhook,
+			Event:   string(notifications.EventJobFailed),
+			Body:    `{"job": {{json .Job.Name}}, "error": {{json .Error}}}`,
+		},
+	})
+	notifier := notifications.NewWebhookNotifier(server.URL, &http.Client{Timeout: 5 * time.Second}, renderer)
+
+	job := &models.Job{ID: uuid.New(), Name: "Nightly Export"}
+	execution := &models.JobExecution{
+		ID:           uuid.New(),
+		JobID:        job.ID,
+		Status:       models.ExecutionStatusFailed,
+		ErrorMessage: models.NewCompressedText("disk full"),
+	}
+
+	// Execute
+	err := notifier.Notify(context.Background(), notifications.Notification{
+		Event:     notifications.EventJobFailed,
+		Title:     "Job failed: Nightly Export",
+		Job:       job,
+		Execution: execution,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Nightly Export", payload["job"])
+	assert.Equal(t, "disk full", payload["error"])
+}
+
+func TestWebhookNotifier_FallsBackWithoutMatchingTemplate(t *testing.T) {
+	// Setup
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The template only applies to failures
+	renderer := notifications.NewRenderer(stubTemplateLookup{
+		models.TemplateChannelWebhook: {
+			Name:    "failures",
+			Channel: models.TemplateChannelWebhook,
+			Event:   string(notifications.EventJobFailed),
+			Body:    `{"custom": true}`,
+		},
+	})
+	notifier := notifications.NewWebhookNotifier(server.URL, &http.Client{Timeout: 5 * time.Second}, renderer)
+
+	// Execute
+	err := notifier.Notify(context.Background(), notifications.Notification{
+		Event: notifications.EventApprovalRequested,
+		Title: "Approval requested",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Approval requested", payload["title"])
+	assert.Nil(t, payload["custom"])
+}
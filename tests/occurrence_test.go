@@ -0,0 +1,239 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/scheduler"
+	"job-scheduler/internal/services"
+)
+
+// MockMissedOccurrenceRepository is a mock implementation of MissedOccurrenceRepository
+type MockMissedOccurrenceRepository struct {
+	mock.Mock
+}
+
+func (m *MockMissedOccurrenceRepository) Record(occurrence *models.MissedOccurrence) error {
+	args := m.Called(occurrence)
+	return args.Error(0)
+}
+
+func (m *MockMissedOccurrenceRepository) RecordAll(occurrences []models.MissedOccurrence) (int64, error) {
+	args := m.Called(occurrences)
+	if recorded, ok := args.Get(0).(func([]models.MissedOccurrence) int64); ok {
+		return recorded(occurrences), args.Error(1)
+	}
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMissedOccurrenceRepository) GetByJobID(jobID uuid.UUID, page, limit int) ([]models.MissedOccurrence, int64, error) {
+	args := m.Called(jobID, page, limit)
+	return args.Get(0).([]models.MissedOccurrence), args.Get(1).(int64), args.Error(2)
+}
+
+func TestScheduler_CatchUpMissedRuns(t *testing.T) {
+	// Setup - hourly jobs due since 07:00, started at 09:30, and a job that has never been scheduled
+	now := time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC)
+	due := time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC)
+	runner := &countingExecutor{jobType: "test_misfire_run_once"}
+	assert.NoError(t, scheduler.RegisterExecutor(runner.jobType, runner))
+
+	ignored := models.Job{ID: uuid.New(), Name: "Ignored", Schedule: "0 * * * *", JobType: runner.jobType, State: models.JobStateActive, MisfirePolicy: models.MisfireIgnore, NextRunAt: &due}
+	runOnce := models.Job{ID: uuid.New(), Name: "Run once", Schedule: "0 * * * *", JobType: runner.jobType, State: models.JobStateActive, MisfirePolicy: models.MisfireRunOnce, NextRunAt: &due}
+	fresh := models.Job{ID: uuid.New(), Name: "Fresh", Schedule: "* * * * *", JobType: runner.jobType, State: models.JobStateActive, MisfirePolicy: models.MisfireRunAll}
+
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetActiveJobs").Return([]models.Job{ignored, runOnce, fresh}, nil)
+	mockJobRepo.On("UpdateRunTimes", mock.Anything, time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)).Return(nil)
+	ledger := new(MockMissedOccurrenceRepository)
+	recorded := make(map[uuid.UUID][]time.Time)
+	ledger.On("RecordAll", mock.AnythingOfType("[]models.MissedOccurrence")).Run(func(args mock.Arguments) {
+		for _, occurrence := range args.Get(0).([]models.MissedOccurrence) {
+			assert.Equal(t, models.MissedOccurrenceSchedulerDown, occurrence.Reason)
+			recorded[occurrence.JobID] = append(recorded[occurrence.JobID], occurrence.ScheduledFor)
+		}
+	}).Return(func(occurrences []models.MissedOccurrence) int64 { return int64(len(occurrences)) }, nil)
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	var scheduledFor *time.Time
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Run(func(args mock.Arguments) {
+		scheduledFor = args.Get(0).(*models.JobExecution).ScheduledFor
+	}).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1}}
+	s := scheduler.NewScheduler(services.NewJobService(mockJobRepo), mockExecutionRepo, cfg)
+	s.SetMissedOccurrences(ledger)
+
+	// Execute
+	ran, missed, err := s.CatchUpMissedRuns(now)
+
+	// Assert - the ignoring job records every occurrence, the other runs the latest and records the rest
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ran)
+	assert.Equal(t, 5, missed)
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+	}, recorded[ignored.ID])
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
+	}, recorded[runOnce.ID])
+	assert.Equal(t, 1, runner.runs)
+	if assert.NotNil(t, scheduledFor) {
+		assert.Equal(t, time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), *scheduledFor)
+	}
+	mockJobRepo.AssertNumberOfCalls(t, "UpdateRunTimes", 2)
+}
+
+func TestOccurrenceService_GetMissedOccurrences(t *testing.T) {
+	// Setup
+	job := &models.Job{ID: uuid.New(), Name: "Nightly ETL"}
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetByID", job.ID).Return(job, nil)
+	ledger := new(MockMissedOccurrenceRepository)
+	missed := []models.MissedOccurrence{{ID: uuid.New(), JobID: job.ID, Reason: models.MissedOccurrenceOverloadShed}}
+	ledger.On("GetByJobID", job.ID, 1, 20).Return(missed, int64(21), nil)
+
+	service := services.NewOccurrenceService(mockJobRepo, ledger)
+
+	// Execute - an out of range limit falls back to the default
+	list, err := service.GetMissedOccurrences(job.ID, 0, 500)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, missed, list.Occurrences)
+	assert.Equal(t, 2, list.TotalPages)
+	ledger.AssertExpectations(t)
+}
+
+func TestScheduler_CatchUpMissedRuns_OneTimeJob(t *testing.T) {
+	// Setup - a one-time job due at 08:15, missed while no scheduler was running
+	now := time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC)
+	runAt := time.Date(2024, 1, 1, 8, 15, 0, 0, time.UTC)
+	runner := &countingExecutor{jobType: "test_misfire_one_time"}
+	assert.NoError(t, scheduler.RegisterExecutor(runner.jobType, runner))
+
+	job := models.Job{ID: uuid.New(), Name: "Launch email", ScheduleType: models.ScheduleTypeOnce, RunAt: &runAt, JobType: runner.jobType, State: models.JobStateActive, MisfirePolicy: models.MisfireRunOnce, NextRunAt: &runAt}
+
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetActiveJobs").Return([]models.Job{job}, nil)
+	mockJobRepo.On("GetByID", job.ID).Return(&job, nil)
+	var completed *models.Job
+	mockJobRepo.On("Update", mock.AnythingOfType("*models.Job")).Run(func(args mock.Arguments) {
+		completed = args.Get(0).(*models.Job)
+	}).Return(nil)
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1}}
+	s := scheduler.NewScheduler(services.NewJobService(mockJobRepo), mockExecutionRepo, cfg)
+
+	// Execute
+	ran, missed, err := s.CatchUpMissedRuns(now)
+
+	// Assert - it runs once and is completed, with no next occurrence
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ran)
+	assert.Equal(t, 0, missed)
+	assert.Equal(t, 1, runner.runs)
+	if assert.NotNil(t, completed) {
+		assert.Equal(t, models.JobStateExpired, completed.State)
+		assert.NotNil(t, completed.CompletedAt)
+		assert.Nil(t, completed.NextRunAt)
+		assert.Equal(t, runAt, *completed.LastRunAt)
+	}
+	mockJobRepo.AssertNotCalled(t, "UpdateRunTimes", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// flakyExecutor fails its first failures runs, counting every run
+type flakyExecutor struct {
+	jobType  models.JobType
+	failures int
+	mu       sync.Mutex
+	runs     int
+}
+
+func (e *flakyExecutor) Execute(ctx context.Context, job *models.Job) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.runs++
+	if e.runs <= e.failures {
+		return errors.New("upstream unavailable")
+	}
+	return nil
+}
+
+func (e *flakyExecutor) GetJobType() models.JobType {
+	return e.jobType
+}
+
+func (e *flakyExecutor) runCount() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.runs
+}
+
+func TestScheduler_CompletesOneTimeJobOnceItsRunIsOver(t *testing.T) {
+	for name, tc := range map[string]struct {
+		failures   int
+		maxRetries int
+		runs       int
+	}{
+		"after retries succeed":      {failures: 2, maxRetries: 2, runs: 3},
+		"after retries are used up":  {failures: 5, maxRetries: 1, runs: 2},
+		"after a first run succeeds": {failures: 0, maxRetries: 3, runs: 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// Setup - a one-time job missed while down, whose first runs fail
+			now := time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC)
+			runAt := time.Date(2024, 1, 1, 8, 15, 0, 0, time.UTC)
+			runner := &flakyExecutor{jobType: models.JobType("test_one_time_retries_" + uuid.NewString()), failures: tc.failures}
+			require.NoError(t, scheduler.RegisterExecutor(runner.jobType, runner))
+			job := models.Job{ID: uuid.New(), Name: "Launch email", ScheduleType: models.ScheduleTypeOnce, RunAt: &runAt, JobType: runner.jobType,
+				State: models.JobStateActive, MisfirePolicy: models.MisfireRunOnce, NextRunAt: &runAt, MaxRetries: tc.maxRetries, BackoffStrategy: models.BackoffFixed}
+
+			mockJobRepo := new(MockJobRepository)
+			mockJobRepo.On("GetActiveJobs").Return([]models.Job{job}, nil)
+			mockJobRepo.On("GetByID", job.ID).Return(&job, nil)
+			completions := make(chan int, 1)
+			mockJobRepo.On("Update", mock.AnythingOfType("*models.Job")).Run(func(args mock.Arguments) {
+				assert.Equal(t, models.JobStateExpired, args.Get(0).(*models.Job).State)
+				completions <- runner.runCount()
+			}).Return(nil)
+			mockExecutionRepo := new(MockJobExecutionRepository)
+			mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+			mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+
+			cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1}}
+			s := scheduler.NewScheduler(services.NewJobService(mockJobRepo), mockExecutionRepo, cfg)
+
+			// Execute
+			_, _, err := s.CatchUpMissedRuns(now)
+			require.NoError(t, err)
+
+			// Assert - the job is completed once, only after its last attempt
+			select {
+			case runs := <-completions:
+				assert.Equal(t, tc.runs, runs)
+			case <-time.After(5 * time.Second):
+				t.Fatal("the one-time job was never completed")
+			}
+			time.Sleep(50 * time.Millisecond)
+			assert.Equal(t, tc.runs, runner.runCount())
+			mockJobRepo.AssertNumberOfCalls(t, "Update", 1)
+		})
+	}
+}
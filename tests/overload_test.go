@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/notifications"
+	"job-scheduler/internal/scheduler"
+)
+
+// channelNotifier forwards notifications to a channel, for notifications sent in the background
+type channelNotifier chan notifications.Notification
+
+func (c channelNotifier) Notify(ctx context.Context, n notifications.Notification) error {
+	c <- n
+	return nil
+}
+
+func TestJobSeverity_Rank(t *testing.T) {
+	assert.Less(t, models.JobSeverityLow.Rank(), models.JobSeverityMedium.Rank())
+	assert.Less(t, models.JobSeverityMedium.Rank(), models.JobSeverityHigh.Rank())
+	assert.Less(t, models.JobSeverityHigh.Rank(), models.JobSeverityCritical.Rank())
+	assert.Equal(t, models.JobSeverityMedium.Rank(), models.JobSeverity("").Rank())
+}
+
+func TestJobExecutor_ShedsLowSeverityRunsWhenOverloaded(t *testing.T) {
+	// Setup - a single slot, overloaded as soon as it's taken
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{
+		MaxConcurrentJobs: 1,
+		OverloadThreshold: 100,
+		MaxQueueWait:      5 * time.Second,
+		ShedBelowSeverity: string(models.JobSeverityHigh),
+	}}
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	notified := make(channelNotifier, 1)
+	executor.SetNotifier(notified)
+
+	newJob := func(severity models.JobSeverity) *models.Job {
+		return &models.Job{ID: uuid.New(), Name: string(severity) + " job", JobType: models.JobTypeEmailNotification, Severity: severity}
+	}
+
+	// Take the only slot with a run that takes about a second
+	busy := make(chan error, 1)
+	go func() { busy <- executor.ExecuteJob(newJob(models.JobSeverityCritical)) }()
+	assert.Eventually(t, func() bool { return executor.GetRunningJobsCount() == 1 }, time.Second, 10*time.Millisecond)
+
+	// Execute - low severity runs are deferred
+	err := executor.ExecuteScheduledJob(newJob(models.JobSeverityLow), time.Now().UTC())
+	assert.ErrorIs(t, err, scheduler.ErrRunDeferred)
+	assert.True(t, executor.IsOverloaded())
+
+	select {
+	case n := <-notified:
+		assert.Equal(t, notifications.EventOverloaded, n.Event)
+	case <-time.After(time.Second):
+		t.Fatal("expected an overloaded notification")
+	}
+
+	// Execute - critical runs wait for the slot instead of being dropped
+	err = executor.ExecuteScheduledJob(newJob(models.JobSeverityCritical), time.Now().UTC())
+	assert.NoError(t, err)
+	assert.NoError(t, <-busy)
+}
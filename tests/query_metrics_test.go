@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+	"job-scheduler/pkg/database"
+)
+
+// newDryRunDB opens a GORM connection that builds statements without a database
+func newDryRunDB(t *testing.T, metrics *database.QueryMetrics) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=test"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		CreateBatchSize:        2,
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(metrics))
+	return db
+}
+
+func TestQueryMetrics_RecordsPerStatement(t *testing.T) {
+	// Setup
+	metrics := database.NewQueryMetrics(time.Hour)
+	db := newDryRunDB(t, metrics)
+
+	// Execute - IN lists of different lengths are the same statement
+	var jobs []models.Job
+	db.Where("id IN ?", []uuid.UUID{uuid.New(), uuid.New()}).Find(&jobs)
+	db.Where("id IN ?", []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}).Find(&jobs)
+	db.Create(&models.JobExecution{JobID: uuid.New(), Status: models.ExecutionStatusRunning})
+
+	// Assert
+	top := metrics.Top(10)
+	require.Len(t, top, 2)
+	counts := map[string]int64{}
+	for _, stats := range top {
+		counts[stats.Operation] = stats.Count
+		assert.NotContains(t, stats.Query, "$1")
+		assert.Len(t, stats.Histogram, 12)
+	}
+	assert.Equal(t, int64(2), counts["query"])
+	assert.Equal(t, int64(1), counts["create"])
+
+	summary := metrics.Summary()
+	assert.Equal(t, int64(3), summary.Queries)
+	assert.Zero(t, summary.Slow)
+
+	metrics.Reset()
+	assert.Empty(t, metrics.Top(10))
+}
+
+func TestQueryMetrics_LogsSlowQueriesWithoutParameters(t *testing.T) {
+	// Setup - every query counts as slow
+	metrics := database.NewQueryMetrics(time.Nanosecond)
+	db := newDryRunDB(t, metrics)
+	hook := logtest.NewGlobal()
+	defer hook.Reset()
+
+	// Execute
+	var job models.Job
+	db.Where("name = ?", "secret-customer-name").First(&job)
+
+	// Assert
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, logrus.WarnLevel, entry.Level)
+	assert.Equal(t, "Slow database query", entry.Message)
+	assert.Contains(t, entry.Data["query"], "name = ?")
+	assert.NotContains(t, entry.Data["query"], "secret-customer-name")
+	assert.Equal(t, 1, entry.Data["params"])
+	assert.Equal(t, int64(1), metrics.Summary().Slow)
+}
+
+func TestMissedOccurrenceRepository_RecordAllInBatches(t *testing.T) {
+	// Setup
+	metrics := database.NewQueryMetrics(time.Hour)
+	ledger := repositories.NewMissedOccurrenceRepository(newDryRunDB(t, metrics))
+	jobID := uuid.New()
+	occurrences := make([]models.MissedOccurrence, 0, 5)
+	for i := 0; i < 5; i++ {
+		occurrences = append(occurrences, models.MissedOccurrence{
+			JobID:        jobID,
+			ScheduledFor: time.Date(2024, 1, 1, i, 0, 0, 0, time.UTC),
+			Reason:       models.MissedOccurrenceSchedulerDown,
+		})
+	}
+
+	// Execute
+	_, err := ledger.RecordAll(occurrences)
+
+	// Assert - five rows take three inserts of at most two rows
+	assert.NoError(t, err)
+	top := metrics.Top(10)
+	require.Len(t, top, 2)
+	assert.Equal(t, int64(3), top[0].Count+top[1].Count)
+}
@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"job-scheduler/internal/dto"
+	"job-scheduler/internal/handlers"
+)
+
+func newRecoveryRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(handlers.RequestID(), handlers.Recovery())
+	router.GET("/panic", func(c *gin.Context) { panic("nil map") })
+	router.GET("/ok", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })
+	return router
+}
+
+func TestRecovery_RespondsWithErrorEnvelope(t *testing.T) {
+	// Setup
+	router := newRecoveryRouter()
+	before := handlers.RecoveredPanics()
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set(handlers.RequestIDHeader, "req-123")
+
+	// Execute
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "req-123", w.Header().Get(handlers.RequestIDHeader))
+	var response dto.ErrorResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dto.ErrorCodeInternal, response.Error.Code)
+	assert.Equal(t, "req-123", response.Error.RequestID)
+	assert.Equal(t, before+1, handlers.RecoveredPanics())
+}
+
+func TestRequestID_GeneratedWhenMissing(t *testing.T) {
+	router := newRecoveryRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ok", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, w.Header().Get(handlers.RequestIDHeader), 36)
+}
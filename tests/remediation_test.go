@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+func newRemediationFixture(expiry time.Duration) (*models.Job, *models.JobExecution, *MockJobTrigger, services.RemediationService) {
+	job := &models.Job{
+		ID:                 uuid.New(),
+		Name:               "Nightly ETL",
+		RemediationActions: models.RemediationActions{{Type: models.RemediationRetryNow}},
+	}
+	execution := &models.JobExecution{
+		ID:         uuid.New(),
+		JobID:      job.ID,
+		Status:     models.ExecutionStatusFailed,
+		Parameters: models.JobConfig{"date": "2024-01-01"},
+	}
+
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetByID", job.ID).Return(job, nil)
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("GetByID", execution.ID).Return(execution, nil)
+	mockTrigger := new(MockJobTrigger)
+
+	service := services.NewRemediationService(mockJobRepo, mockExecutionRepo, nil, mockTrigger, http.DefaultClient, config.RemediationConfig{
+		SigningSecret: "test-secret",
+		PublicBaseURL: "https://scheduler.example.com",
+		LinkExpiry:    expiry,
+	})
+	return job, execution, mockTrigger, service
+}
+
+func TestRemediationService_PerformRetryNow(t *testing.T) {
+	// Setup
+	job, execution, mockTrigger, service := newRemediationFixture(time.Hour)
+	mockTrigger.On("TriggerJob", job, execution.Parameters).Return(nil)
+
+	links := service.Links(job, execution)
+	assert.Len(t, links, 1)
+	assert.Equal(t, "Retry now", links[0].Label)
+	link, err := url.Parse(links[0].URL)
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/v1/actions/"+execution.ID.String()+"/0", link.Path)
+
+	// Execute
+	result, err := service.Perform(execution.ID, 0, link.Query().Get("expires"), link.Query().Get("signature"))
+
+	// Assert - the failed run's parameters are reused
+	assert.NoError(t, err)
+	assert.Equal(t, models.RemediationRetryNow, result.Type)
+	assert.Equal(t, job.ID, result.JobID)
+	mockTrigger.AssertExpectations(t)
+}
+
+func TestRemediationService_RejectsBadLinks(t *testing.T) {
+	job, execution, mockTrigger, service := newRemediationFixture(time.Hour)
+	link, _ := url.Parse(service.Links(job, execution)[0].URL)
+	expires := link.Query().Get("expires")
+	signature := link.Query().Get("signature")
+
+	// Tampered expiry
+	later := strconv.FormatInt(time.Now().Add(48*time.Hour).Unix(), 10)
+	_, err := service.Perform(execution.ID, 0, later, signature)
+	assert.ErrorIs(t, err, services.ErrActionLinkInvalid)
+
+	// Another action index
+	_, err = service.Perform(execution.ID, 1, expires, signature)
+	assert.ErrorIs(t, err, services.ErrActionLinkInvalid)
+
+	// Expired link
+	job, execution, mockTrigger, service = newRemediationFixture(-time.Minute)
+	link, _ = url.Parse(service.Links(job, execution)[0].URL)
+	_, err = service.Perform(execution.ID, 0, link.Query().Get("expires"), link.Query().Get("signature"))
+	assert.ErrorIs(t, err, services.ErrActionLinkExpired)
+
+	mockTrigger.AssertNotCalled(t, "TriggerJob", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,237 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/handlers"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/replication"
+	"job-scheduler/internal/services"
+)
+
+// MockReplicationRepository is a mock implementation of ReplicationRepository
+type MockReplicationRepository struct {
+	mock.Mock
+}
+
+func (m *MockReplicationRepository) AppendChange(change *models.JobChange) error {
+	args := m.Called(change)
+	return args.Error(0)
+}
+
+func (m *MockReplicationRepository) GetChangesAfter(after int64, limit int) ([]models.JobChange, error) {
+	args := m.Called(after, limit)
+	return args.Get(0).([]models.JobChange), args.Error(1)
+}
+
+func (m *MockReplicationRepository) LatestSequence() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockReplicationRepository) GetSnapshot(ctx context.Context) (*models.JobSnapshot, error) {
+	args := m.Called()
+	return args.Get(0).(*models.JobSnapshot), args.Error(1)
+}
+
+func (m *MockReplicationRepository) GetState() (*models.ReplicationState, error) {
+	args := m.Called()
+	return args.Get(0).(*models.ReplicationState), args.Error(1)
+}
+
+func (m *MockReplicationRepository) SaveState(state *models.ReplicationState) error {
+	args := m.Called(state)
+	return args.Error(0)
+}
+
+func (m *MockReplicationRepository) ApplyChange(change *models.JobChange, job *models.Job) error {
+	args := m.Called(change, job)
+	return args.Error(0)
+}
+
+func (m *MockReplicationRepository) ApplySnapshot(snapshot *models.JobSnapshot) error {
+	args := m.Called(snapshot)
+	return args.Error(0)
+}
+
+// recordingChangeListener records the jobs it is told were saved and deleted
+type recordingChangeListener struct {
+	saved   []uuid.UUID
+	deleted []uuid.UUID
+}
+
+func (l *recordingChangeListener) JobCreated(job *models.Job) {}
+
+func (l *recordingChangeListener) JobSaved(job *models.Job) { l.saved = append(l.saved, job.ID) }
+
+func (l *recordingChangeListener) JobDeleted(jobID uuid.UUID) { l.deleted = append(l.deleted, jobID) }
+
+// stubScheduler counts how often it was started
+type stubScheduler struct {
+	starts int
+}
+
+func (s *stubScheduler) Start() error {
+	s.starts++
+	return nil
+}
+
+const testReplicationToken = "replication-secret"
+
+// newPrimaryServer serves the replication API of a primary with the given repository
+func newPrimaryServer(t *testing.T, repo *MockReplicationRepository) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	cfg := config.ReplicationConfig{Role: config.ReplicationRolePrimary, Token: testReplicationToken}
+	handlers.NewReplicationHandler(services.NewReplicationService(repo), nil, cfg).RegisterRoutes(router.Group("/api/v1"))
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newStandby(repo *MockReplicationRepository, primaryURL string, scheduler replication.Scheduler) *replication.Standby {
+	cfg := &config.Config{Replication: config.ReplicationConfig{
+		Role:         config.ReplicationRoleStandby,
+		PrimaryURL:   primaryURL,
+		Token:        testReplicationToken,
+		PollInterval: time.Second,
+	}}
+	return replication.NewStandby(replication.NewClient(cfg.Replication), services.NewReplicationService(repo), scheduler, cfg)
+}
+
+func TestJobChangeOutbox_RecordsSavesAndDeletes(t *testing.T) {
+	// Setup
+	repo := new(MockReplicationRepository)
+	repo.On("AppendChange", mock.AnythingOfType("*models.JobChange")).Return(nil)
+	next := &recordingChangeListener{}
+	outbox := services.NewJobChangeOutbox(repo, next)
+	job := &models.Job{ID: uuid.New(), Name: "Nightly ETL", Schedule: "0 2 * * *", State: models.JobStatePaused}
+
+	// Execute
+	outbox.JobCreated(job)
+	outbox.JobSaved(job)
+	outbox.JobDeleted(job.ID)
+
+	// Assert - a created job is recorded once, when it is saved, and the scheduler still hears of both
+	repo.AssertNumberOfCalls(t, "AppendChange", 2)
+	upsert := repo.Calls[0].Arguments.Get(0).(*models.JobChange)
+	assert.Equal(t, models.JobChangeUpsert, upsert.Action)
+	assert.Equal(t, job.ID, upsert.JobID)
+	assert.Equal(t, "paused", upsert.Payload["state"])
+	deletion := repo.Calls[1].Arguments.Get(0).(*models.JobChange)
+	assert.Equal(t, models.JobChangeDelete, deletion.Action)
+	assert.Nil(t, deletion.Payload)
+	assert.Equal(t, []uuid.UUID{job.ID}, next.saved)
+	assert.Equal(t, []uuid.UUID{job.ID}, next.deleted)
+}
+
+func TestStandby_Sync_StartsFromSnapshotThenFollowsChanges(t *testing.T) {
+	// Setup - the primary's snapshot is as of change 7, after which a job was updated and another deleted
+	kept := models.Job{ID: uuid.New(), Name: "Nightly ETL", Schedule: "0 2 * * *", State: models.JobStateActive}
+	updated := kept
+	updated.Schedule = "0 3 * * *"
+	deleted := uuid.New()
+
+	primaryRepo := new(MockReplicationRepository)
+	primaryRepo.On("GetSnapshot").Return(&models.JobSnapshot{Sequence: 7, Jobs: []models.Job{kept}}, nil)
+	primaryRepo.On("LatestSequence").Return(int64(9), nil)
+	primaryRepo.On("GetChangesAfter", int64(7), 500).Return([]models.JobChange{
+		{Sequence: 8, JobID: kept.ID, Action: models.JobChangeUpsert, Payload: models.JobConfig{
+			"id": kept.ID.String(), "name": updated.Name, "schedule": updated.Schedule, "state": "active",
+		}},
+		{Sequence: 9, JobID: deleted, Action: models.JobChangeDelete},
+	}, nil)
+	server := newPrimaryServer(t, primaryRepo)
+
+	standbyRepo := new(MockReplicationRepository)
+	standbyRepo.On("GetState").Return(&models.ReplicationState{}, nil)
+	standbyRepo.On("ApplySnapshot", mock.AnythingOfType("*models.JobSnapshot")).Return(nil)
+	standbyRepo.On("ApplyChange", mock.AnythingOfType("*models.JobChange"), mock.Anything).Return(nil)
+	scheduler := &stubScheduler{}
+	standby := newStandby(standbyRepo, server.URL, scheduler)
+
+	// Execute
+	applied, err := standby.Sync(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, applied)
+	snapshot := standbyRepo.Calls[1].Arguments.Get(0).(*models.JobSnapshot)
+	assert.Equal(t, int64(7), snapshot.Sequence)
+	assert.Equal(t, kept.ID, snapshot.Jobs[0].ID)
+
+	upsert := standbyRepo.Calls[2].Arguments.Get(1).(*models.Job)
+	assert.Equal(t, kept.ID, upsert.ID)
+	assert.Equal(t, "0 3 * * *", upsert.Schedule)
+	deletion := standbyRepo.Calls[3].Arguments.Get(0).(*models.JobChange)
+	assert.Equal(t, deleted, deletion.JobID)
+	assert.Nil(t, standbyRepo.Calls[3].Arguments.Get(1))
+	assert.Zero(t, scheduler.starts)
+}
+
+func TestStandby_Promote_StartsSchedulerOnce(t *testing.T) {
+	// Setup
+	lastSynced := time.Now().UTC()
+	state := &models.ReplicationState{LastSequence: 12, LastSyncedAt: &lastSynced}
+	repo := new(MockReplicationRepository)
+	repo.On("GetState").Return(state, nil)
+	repo.On("SaveState", state).Return(nil)
+	scheduler := &stubScheduler{}
+	standby := newStandby(repo, "http://primary.invalid", scheduler)
+
+	// Execute
+	status, err := standby.Promote("alice")
+	_, again := standby.Promote("bob")
+
+	// Assert - the promotion is saved so a restart keeps running jobs
+	require.NoError(t, err)
+	assert.Equal(t, "alice", status.PromotedBy)
+	assert.NotNil(t, status.PromotedAt)
+	assert.Equal(t, int64(12), status.LastSequence)
+	assert.Equal(t, 1, scheduler.starts)
+	assert.ErrorIs(t, again, services.ErrAlreadyPromoted)
+	repo.AssertNumberOfCalls(t, "SaveState", 1)
+
+	_, err = standby.Sync(context.Background())
+	assert.ErrorIs(t, err, services.ErrAlreadyPromoted)
+}
+
+func TestReplicationHandler_GetChanges_RequiresPrimaryAndToken(t *testing.T) {
+	// Setup
+	repo := new(MockReplicationRepository)
+	server := newPrimaryServer(t, repo)
+
+	gin.SetMode(gin.TestMode)
+	standbyRouter := gin.New()
+	standbyCfg := config.ReplicationConfig{Role: config.ReplicationRoleStandby, Token: testReplicationToken}
+	handlers.NewReplicationHandler(services.NewReplicationService(repo), nil, standbyCfg).RegisterRoutes(standbyRouter.Group("/api/v1"))
+
+	// Execute
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/v1/replication/changes", nil)
+	req.Header.Set("Authorization", "Bearer wrong")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	w := httptest.NewRecorder()
+	standbyReq := httptest.NewRequest(http.MethodGet, "/api/v1/replication/changes", nil)
+	standbyReq.Header.Set("Authorization", "Bearer "+testReplicationToken)
+	standbyRouter.ServeHTTP(w, standbyReq)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	repo.AssertNotCalled(t, "GetChangesAfter", mock.Anything, mock.Anything)
+}
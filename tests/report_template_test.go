@@ -0,0 +1,351 @@
+package tests
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// MockReportTemplateRepository is a mock implementation of ReportTemplateRepository
+type MockReportTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportTemplateRepository) Create(template *models.ReportTemplate) error {
+	args := m.Called(template)
+	return args.Error(0)
+}
+
+func (m *MockReportTemplateRepository) GetByID(id uuid.UUID) (*models.ReportTemplate, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReportTemplate), args.Error(1)
+}
+
+func (m *MockReportTemplateRepository) FindByName(name string) (*models.ReportTemplate, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReportTemplate), args.Error(1)
+}
+
+func (m *MockReportTemplateRepository) GetAll() ([]models.ReportTemplate, error) {
+	args := m.Called()
+	return args.Get(0).([]models.ReportTemplate), args.Error(1)
+}
+
+func (m *MockReportTemplateRepository) Update(template *models.ReportTemplate) error {
+	args := m.Called(template)
+	return args.Error(0)
+}
+
+func (m *MockReportTemplateRepository) Delete(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// stubReportData returns canned rows keyed by query, for queries on the warehouse database
+type stubReportData map[string][]map[string]interface{}
+
+func (s stubReportData) RunQuery(ctx context.Context, connection, query string) ([]map[string]interface{}, error) {
+	if connection != "warehouse" {
+		return nil, fmt.Errorf("unexpected connection %q", connection)
+	}
+	return s[query], nil
+}
+
+func newTestReportTemplate() *models.ReportTemplate {
+	return &models.ReportTemplate{
+		ID:       uuid.New(),
+		Name:     "job-health",
+		Format:   models.ReportFormatCSV,
+		Layout:   models.ReportLayout{Title: "Job Health"},
+		Columns:  models.ReportColumns{{Key: "status", Label: "Status"}, {Key: "total", Label: "Runs"}},
+		Queries:  models.ReportQueries{{Name: "last_24h", Query: "SELECT status, COUNT(*) AS total FROM job_executions GROUP BY status"}},
+		IsActive: true,
+	}
+}
+
+func TestReportTemplateService_CreateReportTemplate_Success(t *testing.T) {
+	// Setup
+	mockRepo := new(MockReportTemplateRepository)
+	service := services.NewReportTemplateService(mockRepo)
+	tmpl := newTestReportTemplate()
+
+	mockRepo.On("FindByName", "job-health").Return(nil, nil)
+	mockRepo.On("Create", mock.AnythingOfType("*models.ReportTemplate")).Return(nil)
+
+	// Execute
+	result, err := service.CreateReportTemplate(&models.CreateReportTemplateRequest{
+		Name:    " job-health ",
+		Columns: tmpl.Columns,
+		Queries: tmpl.Queries,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "job-health", result.Name)
+	assert.Equal(t, models.ReportFormatText, result.Format)
+	assert.True(t, result.IsActive)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReportTemplateService_CreateReportTemplate_NameTaken(t *testing.T) {
+	// Setup
+	mockRepo := new(MockReportTemplateRepository)
+	service := services.NewReportTemplateService(mockRepo)
+	tmpl := newTestReportTemplate()
+
+	mockRepo.On("FindByName", "job-health").Return(tmpl, nil)
+
+	// Execute
+	result, err := service.CreateReportTemplate(&models.CreateReportTemplateRequest{
+		Name:    "job-health",
+		Columns: tmpl.Columns,
+		Queries: tmpl.Queries,
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, services.ErrReportTemplateNameTaken)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestReportTemplateService_CreateReportTemplate_Invalid(t *testing.T) {
+	tmpl := newTestReportTemplate()
+	tests := []struct {
+		name string
+		req  models.CreateReportTemplateRequest
+	}{
+		{"unsupported format", models.CreateReportTemplateRequest{Name: "r", Format: "docx", Columns: tmpl.Columns, Queries: tmpl.Queries}},
+		{"no columns", models.CreateReportTemplateRequest{Name: "r", Queries: tmpl.Queries}},
+		{"duplicate column", models.CreateReportTemplateRequest{Name: "r", Columns: models.ReportColumns{{Key: "a"}, {Key: "a"}}, Queries: tmpl.Queries}},
+		{"no queries", models.CreateReportTemplateRequest{Name: "r", Columns: tmpl.Columns}},
+		{"empty query", models.CreateReportTemplateRequest{Name: "r", Columns: tmpl.Columns, Queries: models.ReportQueries{{Name: "q"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockReportTemplateRepository)
+			service := services.NewReportTemplateService(mockRepo)
+
+			result, err := service.CreateReportTemplate(&tt.req)
+
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestReportGenerationExecutor_UsesStoredTemplate(t *testing.T) {
+	// Setup
+	dir := t.TempDir()
+	tmpl := newTestReportTemplate()
+	mockRepo := new(MockReportTemplateRepository)
+	mockRepo.On("FindByName", "job-health").Return(tmpl, nil)
+
+	executor := services.NewReportGenerationExecutor(dir)
+	executor.SetTemplates(mockRepo, stubReportData{
+		tmpl.Queries[0].Query: {
+			{"status": "completed", "total": int64(42)},
+			{"status": "failed", "total": int64(3)},
+		},
+	})
+
+	job := &models.Job{
+		ID:      uuid.New(),
+		Name:    "Daily Health Report",
+		JobType: models.JobTypeReportGeneration,
+		Config:  models.JobConfig{"report_template": "job-health", "connection": "warehouse"},
+	}
+
+	// Execute
+	err := executor.Execute(context.Background(), job)
+
+	// Assert
+	assert.NoError(t, err)
+	files, _ := filepath.Glob(filepath.Join(dir, "job-health_*.csv"))
+	if assert.Len(t, files, 1) {
+		content, _ := ioutil.ReadFile(files[0])
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		assert.Equal(t, []string{"section,Status,Runs", "last_24h,completed,42", "last_24h,failed,3"}, lines)
+	}
+}
+
+func TestReportGenerationExecutor_UnknownTemplate(t *testing.T) {
+	// Setup
+	mockRepo := new(MockReportTemplateRepository)
+	mockRepo.On("FindByName", "missing").Return(nil, nil)
+
+	executor := services.NewReportGenerationExecutor(t.TempDir())
+	executor.SetTemplates(mockRepo, stubReportData{})
+
+	job := &models.Job{
+		ID:     uuid.New(),
+		Config: models.JobConfig{"report_template": "missing"},
+	}
+
+	// Execute
+	err := executor.Execute(context.Background(), job)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestReportGenerationExecutor_QueryToJSON(t *testing.T) {
+	// Setup
+	dir := t.TempDir()
+	executor := services.NewReportGenerationExecutor(dir)
+	executor.SetTemplates(new(MockReportTemplateRepository), stubReportData{
+		"SELECT name, runs FROM job_summary": {{"name": "ETL", "runs": int64(12)}},
+	})
+	job := &models.Job{ID: uuid.New(), Name: "Summary", Config: models.JobConfig{
+		"report_type": "job_summary",
+		"format":      "json",
+		"query":       "SELECT name, runs FROM job_summary",
+		"connection":  "warehouse",
+	}}
+
+	// Execute
+	files, err := executor.ExecuteWithArtifacts(context.Background(), job)
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, files, 1) {
+		assert.Equal(t, "application/json", files[0].ContentType)
+		content, _ := ioutil.ReadFile(files[0].Path)
+		var report struct {
+			Columns  []string `json:"columns"`
+			Sections []struct {
+				Name string                   `json:"name"`
+				Rows []map[string]interface{} `json:"rows"`
+			} `json:"sections"`
+		}
+		assert.NoError(t, json.Unmarshal(content, &report))
+		assert.Equal(t, []string{"name", "runs"}, report.Columns)
+		if assert.Len(t, report.Sections, 1) {
+			assert.Equal(t, "job_summary", report.Sections[0].Name)
+			assert.Equal(t, []map[string]interface{}{{"name": "ETL", "runs": float64(12)}}, report.Sections[0].Rows)
+		}
+	}
+}
+
+func TestReportGenerationExecutor_QueriesOnlyRegisteredDatabases(t *testing.T) {
+	// Setup - no database is registered
+	executor := services.NewReportGenerationExecutor(t.TempDir())
+	executor.SetTemplates(new(MockReportTemplateRepository), services.NewReportDataSource(stubDatabaseRegistry{}))
+	query := "SELECT name, dsn FROM database_connections"
+
+	tests := []struct {
+		name   string
+		config models.JobConfig
+		err    string
+	}{
+		{"without a connection", models.JobConfig{"query": query}, `query needs a "connection" naming a registered database`},
+		{"unregistered connection", models.JobConfig{"query": query, "connection": "warehouse"}, services.ErrUnknownDatabaseConnection.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute
+			err := executor.Execute(context.Background(), &models.Job{ID: uuid.New(), Name: "Leak", Config: tt.config})
+
+			// Assert - the query never reaches the scheduler's own database
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+
+	// Queries without a connection are rejected when the job is saved
+	assert.Error(t, services.ValidateReportConfig(tests[0].config))
+	assert.NoError(t, services.ValidateReportConfig(tests[1].config))
+}
+
+func TestReportGenerationExecutor_HTTPSourceToXLSX(t *testing.T) {
+	// Setup
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [{"region": "EU & UK", "revenue": 1250.5}, {"region": "US", "revenue": 980}]}`))
+	}))
+	defer server.Close()
+
+	executor := services.NewReportGenerationExecutor(t.TempDir())
+	job := &models.Job{ID: uuid.New(), Name: "Revenue", Config: models.JobConfig{
+		"report_type":  "revenue",
+		"format":       "xlsx",
+		"source_url":   server.URL,
+		"source_field": "data",
+		"columns":      []interface{}{"region", "revenue"},
+	}}
+
+	// Execute
+	files, err := executor.ExecuteWithArtifacts(context.Background(), job)
+
+	// Assert - one sheet named after the report, headings first and revenue as numeric cells
+	assert.NoError(t, err)
+	if assert.Len(t, files, 1) {
+		assert.True(t, strings.HasSuffix(files[0].Name, ".xlsx"))
+		archive, err := zip.OpenReader(files[0].Path)
+		if assert.NoError(t, err) {
+			defer archive.Close()
+			contents := make(map[string]string)
+			for _, file := range archive.File {
+				rc, _ := file.Open()
+				data, _ := ioutil.ReadAll(rc)
+				rc.Close()
+				contents[file.Name] = string(data)
+			}
+			assert.Contains(t, contents["xl/workbook.xml"], `<sheet name="revenue"`)
+			sheet := contents["xl/worksheets/sheet1.xml"]
+			assert.Contains(t, sheet, `<c r="A1" t="inlineStr"><is><t>region</t></is></c>`)
+			assert.Contains(t, sheet, `<c r="A2" t="inlineStr"><is><t>EU &amp; UK</t></is></c>`)
+			assert.Contains(t, sheet, `<c r="B2"><v>1250.5</v></c>`)
+			assert.Contains(t, sheet, `<c r="B3"><v>980</v></c>`)
+		}
+	}
+}
+
+func TestReportGenerationExecutor_SampleReportAsPDF(t *testing.T) {
+	executor := services.NewReportGenerationExecutor(t.TempDir())
+	job := &models.Job{ID: uuid.New(), Name: "Sample", Config: models.JobConfig{"format": "pdf"}}
+
+	files, err := executor.ExecuteWithArtifacts(context.Background(), job)
+
+	assert.NoError(t, err)
+	if assert.Len(t, files, 1) {
+		assert.Equal(t, "application/pdf", files[0].ContentType)
+		content, _ := ioutil.ReadFile(files[0].Path)
+		assert.True(t, strings.HasPrefix(string(content), "%PDF-1.4"))
+		assert.Contains(t, string(content), "(Report: daily_summary) '")
+		assert.True(t, strings.HasSuffix(string(content), "%%EOF\n"))
+	}
+}
+
+func TestReportGenerationExecutor_RejectsUnknownFormat(t *testing.T) {
+	executor := services.NewReportGenerationExecutor(t.TempDir())
+	job := &models.Job{ID: uuid.New(), Config: models.JobConfig{"format": "docx"}}
+
+	_, err := executor.ExecuteWithArtifacts(context.Background(), job)
+
+	assert.EqualError(t, err, "invalid report format: docx")
+}
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"job-scheduler/internal/handlers"
+)
+
+func newTimeoutRouter(timeout time.Duration) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	api := router.Group("/api/v1", handlers.RequestTimeout(timeout))
+	// slow stands in for a long list query, giving up once the request's context is done
+	slow := func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+		case <-time.After(time.Second):
+			c.JSON(http.StatusOK, gin.H{})
+		}
+	}
+	api.GET("/jobs", slow)
+	api.GET("/events", func(c *gin.Context) {
+		_, hasDeadline := c.Request.Context().Deadline()
+		c.JSON(http.StatusOK, gin.H{"deadline": hasDeadline})
+	})
+
+	return router
+}
+
+func TestRequestTimeout_RespondsGatewayTimeout(t *testing.T) {
+	router := newTimeoutRouter(50 * time.Millisecond)
+
+	start := time.Now()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs", nil))
+
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"timeout"`)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRequestTimeout_ExemptsStreamsAndCanBeDisabled(t *testing.T) {
+	// Event streams get no deadline
+	w := httptest.NewRecorder()
+	newTimeoutRouter(50*time.Millisecond).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/events", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"deadline": false}`, w.Body.String())
+
+	// A timeout of 0 leaves requests unbounded
+	w = httptest.NewRecorder()
+	newTimeoutRouter(0).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
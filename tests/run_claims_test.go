@@ -0,0 +1,179 @@
+package tests
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/repositories"
+	"job-scheduler/internal/scheduler"
+	"job-scheduler/internal/services"
+)
+
+// memoryRunClaims grants the first claim of each occurrence of a job, as the unique key of job_run_claims does
+type memoryRunClaims struct {
+	mu       sync.Mutex
+	claims   []models.JobRunClaim
+	claimers map[time.Time][]string
+}
+
+func (c *memoryRunClaims) Claim(claim *models.JobRunClaim) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.claimers == nil {
+		c.claimers = map[time.Time][]string{}
+	}
+	occurrence := claim.ScheduledFor.UTC()
+	c.claimers[occurrence] = append(c.claimers[occurrence], claim.InstanceID)
+	for _, existing := range c.claims {
+		if existing.JobID == claim.JobID && existing.ScheduledFor.Equal(claim.ScheduledFor) {
+			return false, nil
+		}
+	}
+	c.claims = append(c.claims, *claim)
+	return true, nil
+}
+
+func (c *memoryRunClaims) DeleteBefore(before time.Time) (int64, error) { return 0, nil }
+
+// snapshot returns the claims won, and the instances that tried to claim each occurrence
+func (c *memoryRunClaims) snapshot() ([]models.JobRunClaim, map[time.Time][]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	claimers := map[time.Time][]string{}
+	for occurrence, instances := range c.claimers {
+		claimers[occurrence] = append([]string(nil), instances...)
+	}
+	return append([]models.JobRunClaim(nil), c.claims...), claimers
+}
+
+// claimingReplica starts a scheduler with the given instance ID sharing claims and runs with other replicas
+func claimingReplica(t *testing.T, instanceID string, job models.Job, claims repositories.JobRunClaimRepository, runs *memoryApprovalRuns) *scheduler.Scheduler {
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetActiveJobs").Return([]models.Job{job}, nil).Maybe()
+	mockJobRepo.On("GetSchedulableJobs").Return([]models.Job{job}, nil)
+	mockJobRepo.On("UpdateRunTimes", job.ID, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	cfg := &config.Config{
+		Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second, InstanceID: instanceID},
+		Reports:   config.ReportsConfig{Directory: t.TempDir()},
+	}
+	s := scheduler.NewScheduler(services.NewJobService(mockJobRepo), runs, cfg)
+	s.SetRunClaims(claims)
+	require.NoError(t, s.Start())
+	return s
+}
+
+func TestEverySchedule_OccurrencesDoNotDependOnStartTime(t *testing.T) {
+	// Setup
+	schedule, err := services.ParseSchedule("@every 90s", false)
+	require.NoError(t, err)
+
+	// Execute - replicas started at different times
+	first := schedule.Next(time.Date(2024, 1, 1, 9, 0, 7, 0, time.UTC))
+	second := schedule.Next(time.Date(2024, 1, 1, 9, 0, 41, 500, time.UTC))
+
+	// Assert - both fall due on the same multiple of the interval since the epoch
+	assert.Equal(t, time.Date(2024, 1, 1, 9, 1, 30, 0, time.UTC), first)
+	assert.Equal(t, first, second)
+	assert.Equal(t, first.Add(90*time.Second), schedule.Next(first))
+	assert.Equal(t, first, schedule.(services.EverySchedule).Prev(first.Add(89*time.Second)))
+}
+
+func TestScheduler_ReplicasRunEachEveryOccurrenceOnce(t *testing.T) {
+	// Setup - two replicas of an @every job started a second apart, which cron alone would
+	// schedule on odd and even seconds
+	runner := &countingExecutor{jobType: "test_claims_replicas"}
+	require.NoError(t, scheduler.RegisterExecutor(runner.jobType, runner))
+	job := models.Job{ID: uuid.New(), Name: "Sync", Schedule: "@every 2s", JobType: runner.jobType, State: models.JobStateActive}
+	claims := &memoryRunClaims{}
+	runs := newMemoryApprovalRuns()
+
+	first := claimingReplica(t, "replica-a", job, claims, runs)
+	time.Sleep(time.Second)
+	second := claimingReplica(t, "replica-b", job, claims, runs)
+	bothRunning := time.Now().UTC()
+
+	// Execute
+	time.Sleep(4500 * time.Millisecond)
+	require.NoError(t, first.Stop())
+	require.NoError(t, second.Stop())
+
+	// Assert - once both replicas run, they claim the same occurrences, and each runs once
+	won, claimers := claims.snapshot()
+	shared := 0
+	for occurrence, instances := range claimers {
+		assert.Zero(t, occurrence.Unix()%2, "occurrence %s", occurrence)
+		if occurrence.After(bothRunning) {
+			assert.ElementsMatch(t, []string{"replica-a", "replica-b"}, instances, "occurrence %s", occurrence)
+			shared++
+		}
+	}
+	assert.GreaterOrEqual(t, shared, 2)
+
+	recorded := runs.withStatus(func(models.JobExecution) bool { return true })
+	assert.Len(t, recorded, len(won))
+	occurrences := map[time.Time]int{}
+	for _, run := range recorded {
+		require.NotNil(t, run.ScheduledFor)
+		occurrences[run.ScheduledFor.UTC()]++
+	}
+	for _, claim := range won {
+		assert.Equal(t, 1, occurrences[claim.ScheduledFor.UTC()], "occurrence %s", claim.ScheduledFor)
+	}
+}
+
+// losingRunClaims loses every claim to another instance, or fails to record it when err is set
+type losingRunClaims struct {
+	mu       sync.Mutex
+	attempts int
+	err      error
+}
+
+func (c *losingRunClaims) Claim(claim *models.JobRunClaim) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.attempts++
+	return false, c.err
+}
+
+func (c *losingRunClaims) DeleteBefore(before time.Time) (int64, error) { return 0, nil }
+
+func (c *losingRunClaims) attempted() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.attempts
+}
+
+func TestScheduler_SkipsRunsItCannotClaim(t *testing.T) {
+	for name, claimErr := range map[string]error{
+		"claimed by another instance": nil,
+		"claim not recorded":          errors.New("connection refused"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			// Setup
+			runner := &countingExecutor{jobType: models.JobType("test_claims_lost_" + uuid.NewString())}
+			require.NoError(t, scheduler.RegisterExecutor(runner.jobType, runner))
+			job := models.Job{ID: uuid.New(), Name: "Sync", Schedule: "@every 1s", JobType: runner.jobType, State: models.JobStateActive}
+			claims := &losingRunClaims{err: claimErr}
+			runs := newMemoryApprovalRuns()
+
+			// Execute
+			s := claimingReplica(t, "replica-b", job, claims, runs)
+			assert.Eventually(t, func() bool { return claims.attempted() >= 2 }, 5*time.Second, 10*time.Millisecond)
+			require.NoError(t, s.Stop())
+
+			// Assert - the occurrences were never recorded or run here
+			assert.Empty(t, runs.withStatus(func(models.JobExecution) bool { return true }))
+			assert.Zero(t, runner.runs)
+		})
+	}
+}
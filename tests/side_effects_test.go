@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/scheduler"
+	"job-scheduler/internal/services"
+)
+
+// sendingExecutor sends one message per run through PerformSideEffectOnce, failing its first attempt
+// after the message is sent
+type sendingExecutor struct {
+	jobType  models.JobType
+	attempts int
+	sent     int
+}
+
+func (e *sendingExecutor) Execute(ctx context.Context, job *models.Job) error {
+	e.attempts++
+	_, err := services.PerformSideEffectOnce(ctx, "message", func() (string, error) {
+		e.sent++
+		return "msg-1", nil
+	})
+	if err != nil {
+		return err
+	}
+	if e.attempts == 1 {
+		return errors.New("failed after sending")
+	}
+	return nil
+}
+
+func (e *sendingExecutor) GetJobType() models.JobType {
+	return e.jobType
+}
+
+func TestJobExecutor_RetryDoesNotRepeatRecordedSideEffect(t *testing.T) {
+	// Setup
+	sender := &sendingExecutor{jobType: "test_side_effect_once"}
+	require.NoError(t, scheduler.RegisterExecutor(sender.jobType, sender))
+
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxConcurrentJobs: 1, MaxQueueWait: time.Second}}
+	executor := scheduler.NewJobExecutor(mockExecutionRepo, cfg)
+	job := &models.Job{ID: uuid.New(), Name: "Invoice mailer", JobType: sender.jobType, MaxRetries: 1, BackoffStrategy: models.BackoffFixed}
+
+	// Execute
+	err := executor.ExecuteJobWaitingForRetries(context.Background(), job, nil)
+
+	// Assert - the retry ran but didn't send again, and both attempts were saved with the token
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sender.attempts)
+	assert.Equal(t, 1, sender.sent)
+
+	var saved []*models.JobExecution
+	for _, call := range mockExecutionRepo.Calls {
+		execution := call.Arguments.Get(0).(*models.JobExecution)
+		if len(saved) == 0 || saved[len(saved)-1] != execution {
+			saved = append(saved, execution)
+		}
+	}
+	require.Len(t, saved, 2)
+	assert.Equal(t, models.ExecutionStatusFailed, saved[0].Status)
+	assert.Equal(t, models.SideEffectTokens{"message": "msg-1"}, saved[0].SideEffects)
+	assert.Equal(t, models.ExecutionStatusCompleted, saved[1].Status)
+	assert.Equal(t, models.SideEffectTokens{"message": "msg-1"}, saved[1].SideEffects)
+}
+
+func TestPerformSideEffectOnce_WithoutRecorderAlwaysPerforms(t *testing.T) {
+	// Setup
+	performed := 0
+	perform := func() (string, error) {
+		performed++
+		return "token", nil
+	}
+
+	// Execute - an executor called directly has no recorder to remember the side effect
+	_, err := services.PerformSideEffectOnce(context.Background(), "message", perform)
+	require.NoError(t, err)
+	token, err := services.PerformSideEffectOnce(context.Background(), "message", perform)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+	assert.Equal(t, 2, performed)
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/scheduler"
+)
+
+func newRateLimitedExecutor(schedulerConfig config.SchedulerConfig) *scheduler.JobExecutor {
+	mockExecutionRepo := new(MockJobExecutionRepository)
+	mockExecutionRepo.On("Create", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	mockExecutionRepo.On("Update", mock.AnythingOfType("*models.JobExecution")).Return(nil)
+	schedulerConfig.MaxConcurrentJobs = 5
+	return scheduler.NewJobExecutor(mockExecutionRepo, &config.Config{Scheduler: schedulerConfig})
+}
+
+func TestJobExecutor_RejectsRunsOverJobTypeRateLimit(t *testing.T) {
+	// Setup - one run of the limited job type may start per minute
+	limited := &countingExecutor{jobType: "test_rate_limited"}
+	unlimited := &countingExecutor{jobType: "test_rate_unlimited"}
+	require.NoError(t, scheduler.RegisterExecutor(limited.jobType, limited))
+	require.NoError(t, scheduler.RegisterExecutor(unlimited.jobType, unlimited))
+
+	executor := newRateLimitedExecutor(config.SchedulerConfig{
+		MaxQueueWait: 100 * time.Millisecond,
+		JobTypeStartRateLimits: map[string]config.RateLimit{
+			string(limited.jobType): {Limit: 1, Window: time.Minute},
+		},
+	})
+	job := &models.Job{ID: uuid.New(), Name: "Digest", JobType: limited.jobType}
+
+	// Execute
+	require.NoError(t, executor.ExecuteJob(job))
+	err := executor.ExecuteJob(job)
+
+	// Assert - the second run is rejected while other job types still start
+	assert.ErrorIs(t, err, scheduler.ErrRateLimited)
+	assert.Equal(t, 1, limited.runs)
+	assert.NoError(t, executor.ExecuteJob(&models.Job{ID: uuid.New(), Name: "Other", JobType: unlimited.jobType}))
+
+	stats := executor.GetRateLimits()
+	require.Len(t, stats, 1)
+	assert.Equal(t, scheduler.RateLimitStats{Limit: 1, Window: "1m0s", InWindow: 1, Started: 1, Rejected: 1}, stats[string(limited.jobType)])
+}
+
+func TestJobExecutor_DefersRunsUntilRateLimitWindowAllows(t *testing.T) {
+	// Setup - one run may start per 200ms across all jobs
+	custom := &countingExecutor{jobType: "test_rate_deferred"}
+	require.NoError(t, scheduler.RegisterExecutor(custom.jobType, custom))
+
+	executor := newRateLimitedExecutor(config.SchedulerConfig{
+		MaxQueueWait:   time.Second,
+		StartRateLimit: config.RateLimit{Limit: 1, Window: 200 * time.Millisecond},
+	})
+	job := &models.Job{ID: uuid.New(), Name: "Digest", JobType: custom.jobType}
+
+	// Execute
+	start := time.Now()
+	require.NoError(t, executor.ExecuteJob(job))
+	err := executor.ExecuteJob(job)
+
+	// Assert - the second run waited for the window and then ran
+	assert.NoError(t, err)
+	assert.Equal(t, 2, custom.runs)
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+	stats := executor.GetRateLimits()["*"]
+	assert.Equal(t, int64(2), stats.Started)
+	assert.Equal(t, int64(1), stats.Deferred)
+	assert.Zero(t, stats.Rejected)
+}
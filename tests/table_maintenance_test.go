@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/repositories"
+	"job-scheduler/internal/services"
+	"job-scheduler/pkg/database"
+)
+
+// maintenanceStatements returns the statements the query metrics recorded, keyed by statement
+func maintenanceStatements(metrics *database.QueryMetrics) map[string]int64 {
+	statements := map[string]int64{}
+	for _, stats := range metrics.Top(0) {
+		statements[stats.Query] = stats.Count
+	}
+	return statements
+}
+
+func TestTableMaintenanceService_Analyze(t *testing.T) {
+	// Setup
+	metrics := database.NewQueryMetrics(time.Hour)
+	repo := repositories.NewTableMaintenanceRepository(newDryRunDB(t, metrics))
+	service := services.NewTableMaintenanceService(repo, config.TableMaintenanceConfig{Enabled: true, Interval: time.Hour})
+
+	// Execute
+	err := service.MaintainTables(context.Background())
+
+	// Assert - each hot table is analyzed once, quoted
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{
+		`ANALYZE "jobs"`:           1,
+		`ANALYZE "job_executions"`: 1,
+		`ANALYZE "job_run_claims"`: 1,
+	}, maintenanceStatements(metrics))
+
+	stats := service.GetMaintenanceStats()
+	assert.Equal(t, int64(1), stats.Runs)
+	assert.NotNil(t, stats.LastRunAt)
+	require.Len(t, stats.LastTables, 3)
+	assert.Equal(t, "analyze", stats.LastTables[0].Operation)
+	assert.Empty(t, stats.LastTables[0].Error)
+}
+
+func TestTableMaintenanceService_Vacuum(t *testing.T) {
+	// Setup
+	metrics := database.NewQueryMetrics(time.Hour)
+	repo := repositories.NewTableMaintenanceRepository(newDryRunDB(t, metrics))
+	service := services.NewTableMaintenanceService(repo, config.TableMaintenanceConfig{Enabled: true, Interval: time.Hour, Vacuum: true})
+
+	// Execute
+	err := service.MaintainTables(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), maintenanceStatements(metrics)[`VACUUM (ANALYZE) "job_executions"`])
+	assert.Equal(t, "vacuum_analyze", service.GetMaintenanceStats().LastTables[1].Operation)
+}
+
+func TestTableMaintenanceService_StopsWhenCancelled(t *testing.T) {
+	// Setup
+	metrics := database.NewQueryMetrics(time.Hour)
+	repo := repositories.NewTableMaintenanceRepository(newDryRunDB(t, metrics))
+	service := services.NewTableMaintenanceService(repo, config.TableMaintenanceConfig{Enabled: true, Interval: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Execute
+	err := service.MaintainTables(ctx)
+
+	// Assert - shutdown doesn't wait for the remaining tables
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, maintenanceStatements(metrics))
+	assert.Empty(t, service.GetMaintenanceStats().LastTables)
+}
@@ -0,0 +1,338 @@
+package tests
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/config"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/triggers"
+)
+
+// queueCall is one request a fake queue received
+type queueCall struct {
+	Action        string
+	Body          map[string]interface{}
+	Authorization string
+}
+
+// fakeQueue records the calls made to it and answers them with respond
+type fakeQueue struct {
+	mu      sync.Mutex
+	calls   []queueCall
+	respond func(action string) string
+}
+
+func (q *fakeQueue) handler(action func(r *http.Request) string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		var body map[string]interface{}
+		_ = json.Unmarshal(raw, &body)
+		call := queueCall{Action: action(r), Body: body, Authorization: r.Header.Get("Authorization")}
+
+		q.mu.Lock()
+		q.calls = append(q.calls, call)
+		respond := q.respond
+		q.mu.Unlock()
+
+		response := "{}"
+		if respond != nil {
+			response = respond(call.Action)
+		}
+		fmt.Fprint(w, response)
+	}
+}
+
+func (q *fakeQueue) callsTo(action string) []queueCall {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var calls []queueCall
+	for _, call := range q.calls {
+		if call.Action == action {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// newFakeSQS starts a fake SQS JSON protocol endpoint
+func newFakeSQS(t *testing.T, queue *fakeQueue) *httptest.Server {
+	server := httptest.NewServer(queue.handler(func(r *http.Request) string {
+		return strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "AmazonSQS.")
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// rewriteTransport sends every request to a test server instead of its own host
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (rt rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rt.target.Scheme
+	req.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+const sqsTwoMessages = `{"Messages":[
+	{"MessageId":"m-1","ReceiptHandle":"r-1","Body":"{\"order\":1}","MessageAttributes":{"source":{"StringValue":"shop"}}},
+	{"MessageId":"m-2","ReceiptHandle":"r-2","Body":"{\"order\":2,\"fail\":true}"}]}`
+
+func TestSQSSource_ReceiveAckNackExtend(t *testing.T) {
+	// Setup
+	queue := &fakeQueue{respond: func(action string) string {
+		if action == "ReceiveMessage" {
+			return sqsTwoMessages
+		}
+		return "{}"
+	}}
+	server := newFakeSQS(t, queue)
+	source := triggers.NewSQSSource(&models.TriggerSource{
+		Kind:                     models.TriggerSourceKindSQS,
+		Target:                   server.URL + "/123456789012/orders",
+		VisibilityTimeoutSeconds: 120,
+		MaxMessages:              5,
+	}, config.TriggersConfig{AWSRegion: "eu-west-1", AWSAccessKeyID: "AKIDEXAMPLE", AWSSecretKey: "secret", AWSSessionToken: "token"}, server.Client())
+	ctx := context.Background()
+
+	// Execute
+	messages, err := source.Receive(ctx)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	require.NoError(t, source.Ack(ctx, messages[0]))
+	require.NoError(t, source.Extend(ctx, messages[1], 90*time.Second))
+	require.NoError(t, source.Nack(ctx, messages[1]))
+
+	// Assert - messages carry their attributes
+	assert.Equal(t, "m-1", messages[0].ID)
+	assert.JSONEq(t, `{"order":1}`, string(messages[0].Body))
+	assert.Equal(t, map[string]string{"source": "shop"}, messages[0].Attributes)
+
+	receive := queue.callsTo("ReceiveMessage")
+	require.Len(t, receive, 1)
+	assert.Equal(t, server.URL+"/123456789012/orders", receive[0].Body["QueueUrl"])
+	assert.Equal(t, float64(5), receive[0].Body["MaxNumberOfMessages"])
+	assert.Equal(t, float64(120), receive[0].Body["VisibilityTimeout"])
+	assert.Contains(t, receive[0].Authorization, "Credential=AKIDEXAMPLE/")
+	assert.Contains(t, receive[0].Authorization, "/eu-west-1/sqs/aws4_request")
+	assert.Contains(t, receive[0].Authorization, "SignedHeaders=content-type;host;x-amz-date;x-amz-security-token;x-amz-target")
+
+	// Acking deletes the message by its receipt handle
+	deletes := queue.callsTo("DeleteMessage")
+	require.Len(t, deletes, 1)
+	assert.Equal(t, "r-1", deletes[0].Body["ReceiptHandle"])
+
+	// Extending and nacking change the visibility timeout, nacking to zero for redelivery
+	visibility := queue.callsTo("ChangeMessageVisibility")
+	require.Len(t, visibility, 2)
+	assert.Equal(t, "r-2", visibility[0].Body["ReceiptHandle"])
+	assert.Equal(t, float64(90), visibility[0].Body["VisibilityTimeout"])
+	assert.Equal(t, float64(0), visibility[1].Body["VisibilityTimeout"])
+}
+
+func TestPubSubSource_PullAckNack(t *testing.T) {
+	// Setup
+	queue := &fakeQueue{respond: func(action string) string {
+		if action == "pull" {
+			return fmt.Sprintf(`{"receivedMessages":[{"ackId":"a-1","message":{"messageId":"p-1","data":%q,"attributes":{"region":"eu"}}}]}`,
+				base64.StdEncoding.EncodeToString([]byte(`{"order":7}`)))
+		}
+		return "{}"
+	}}
+	var paths []string
+	server := httptest.NewServer(queue.handler(func(r *http.Request) string {
+		paths = append(paths, r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer gcp-token" {
+			return "unauthenticated"
+		}
+		return r.URL.Path[strings.LastIndex(r.URL.Path, ":")+1:]
+	}))
+	defer server.Close()
+	target, _ := url.Parse(server.URL)
+	source := triggers.NewPubSubSource(&models.TriggerSource{
+		Kind:                     models.TriggerSourceKindPubSub,
+		Target:                   "projects/acme/subscriptions/orders",
+		VisibilityTimeoutSeconds: 60,
+	}, config.TriggersConfig{GCPAccessToken: "gcp-token"}, &http.Client{Transport: rewriteTransport{target: target}})
+	ctx := context.Background()
+
+	// Execute
+	messages, err := source.Receive(ctx)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.NoError(t, source.Ack(ctx, messages[0]))
+	require.NoError(t, source.Nack(ctx, messages[0]))
+
+	// Assert - the data is decoded, and every call is on the subscription
+	assert.Equal(t, "p-1", messages[0].ID)
+	assert.JSONEq(t, `{"order":7}`, string(messages[0].Body))
+	assert.Equal(t, map[string]string{"region": "eu"}, messages[0].Attributes)
+	assert.Equal(t, "/v1/projects/acme/subscriptions/orders:pull", paths[0])
+	assert.Empty(t, queue.callsTo("unauthenticated"))
+
+	// Received messages get the source's ack deadline, and a nack sets it to zero
+	deadlines := queue.callsTo("modifyAckDeadline")
+	require.Len(t, deadlines, 2)
+	assert.Equal(t, []interface{}{"a-1"}, deadlines[0].Body["ackIds"])
+	assert.Equal(t, float64(60), deadlines[0].Body["ackDeadlineSeconds"])
+	assert.Equal(t, float64(0), deadlines[1].Body["ackDeadlineSeconds"])
+
+	acks := queue.callsTo("acknowledge")
+	require.Len(t, acks, 1)
+	assert.Equal(t, []interface{}{"a-1"}, acks[0].Body["ackIds"])
+}
+
+// staticTriggerSources serves a fixed list of trigger sources
+type staticTriggerSources struct {
+	sources []models.TriggerSource
+}
+
+func (s *staticTriggerSources) Create(source *models.TriggerSource) error { return nil }
+func (s *staticTriggerSources) GetByID(id uuid.UUID) (*models.TriggerSource, error) {
+	return nil, errors.New("not found")
+}
+func (s *staticTriggerSources) GetByJobID(jobID uuid.UUID) ([]models.TriggerSource, error) {
+	return s.sources, nil
+}
+func (s *staticTriggerSources) GetActive() ([]models.TriggerSource, error) { return s.sources, nil }
+func (s *staticTriggerSources) Delete(id uuid.UUID) error                  { return nil }
+
+// paramRunner fails the runs whose parameters ask it to
+type paramRunner struct {
+	mu   sync.Mutex
+	runs []models.JobConfig
+}
+
+func (r *paramRunner) RunJob(job *models.Job, params models.JobConfig) error {
+	r.mu.Lock()
+	r.runs = append(r.runs, params)
+	r.mu.Unlock()
+	if params["fail"] == true {
+		return errors.New("run failed")
+	}
+	return nil
+}
+
+func TestTriggerManager_AcksSucceededRunsAndReleasesFailedOnes(t *testing.T) {
+	// Setup - the queue has two messages, then none
+	var received sync.Once
+	queue := &fakeQueue{}
+	queue.respond = func(action string) string {
+		if action != "ReceiveMessage" {
+			return "{}"
+		}
+		response := `{"Messages":[]}`
+		received.Do(func() { response = sqsTwoMessages })
+		if response != sqsTwoMessages {
+			time.Sleep(10 * time.Millisecond)
+		}
+		return response
+	}
+	server := newFakeSQS(t, queue)
+
+	job := &models.Job{ID: uuid.New(), Name: "import-orders", State: models.JobStateActive}
+	jobRepo := new(MockJobRepository)
+	jobRepo.On("GetByID", job.ID).Return(job, nil)
+	runner := &paramRunner{}
+	sources := &staticTriggerSources{sources: []models.TriggerSource{{
+		ID:                       uuid.New(),
+		JobID:                    job.ID,
+		Kind:                     models.TriggerSourceKindSQS,
+		Target:                   server.URL + "/123456789012/orders",
+		VisibilityTimeoutSeconds: 60,
+		IsActive:                 true,
+	}}}
+	manager := triggers.NewManager(sources, jobRepo, runner, &config.Config{Triggers: config.TriggersConfig{
+		Enabled:        true,
+		ReloadInterval: time.Hour,
+		AWSRegion:      "us-east-1",
+	}})
+
+	// Execute
+	require.NoError(t, manager.Start())
+	assert.Eventually(t, func() bool {
+		return len(queue.callsTo("DeleteMessage")) == 1 && len(queue.callsTo("ChangeMessageVisibility")) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	manager.Stop()
+
+	// Assert - the succeeded run's message is deleted, the failed run's is released for redelivery
+	assert.Equal(t, "r-1", queue.callsTo("DeleteMessage")[0].Body["ReceiptHandle"])
+	release := queue.callsTo("ChangeMessageVisibility")[0]
+	assert.Equal(t, "r-2", release.Body["ReceiptHandle"])
+	assert.Equal(t, float64(0), release.Body["VisibilityTimeout"])
+
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+	require.Len(t, runner.runs, 2)
+	for _, params := range runner.runs {
+		assert.Contains(t, []interface{}{"m-1", "m-2"}, params["message_id"])
+	}
+}
+
+func TestTriggerManager_AcksMessagesNotMatchingConditionWithoutRunning(t *testing.T) {
+	// Setup - the queue has two messages, then none; only messages without "fail" run the job
+	var received sync.Once
+	queue := &fakeQueue{}
+	queue.respond = func(action string) string {
+		if action != "ReceiveMessage" {
+			return "{}"
+		}
+		response := `{"Messages":[]}`
+		received.Do(func() { response = sqsTwoMessages })
+		if response != sqsTwoMessages {
+			time.Sleep(10 * time.Millisecond)
+		}
+		return response
+	}
+	server := newFakeSQS(t, queue)
+
+	job := &models.Job{ID: uuid.New(), Name: "import-orders", State: models.JobStateActive}
+	jobRepo := new(MockJobRepository)
+	jobRepo.On("GetByID", job.ID).Return(job, nil)
+	runner := &paramRunner{}
+	sources := &staticTriggerSources{sources: []models.TriggerSource{{
+		ID:                       uuid.New(),
+		JobID:                    job.ID,
+		Kind:                     models.TriggerSourceKindSQS,
+		Target:                   server.URL + "/123456789012/orders",
+		VisibilityTimeoutSeconds: 60,
+		Condition:                `!has(body.fail) && body.order > 0`,
+		IsActive:                 true,
+	}}}
+	manager := triggers.NewManager(sources, jobRepo, runner, &config.Config{Triggers: config.TriggersConfig{
+		Enabled:        true,
+		ReloadInterval: time.Hour,
+		AWSRegion:      "us-east-1",
+	}})
+
+	// Execute
+	require.NoError(t, manager.Start())
+	assert.Eventually(t, func() bool {
+		return len(queue.callsTo("DeleteMessage")) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	manager.Stop()
+
+	// Assert - both messages are deleted, but only the matching one ran the job
+	assert.Empty(t, queue.callsTo("ChangeMessageVisibility"))
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+	require.Len(t, runner.runs, 1)
+	assert.Equal(t, "m-1", runner.runs[0]["message_id"])
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"job-scheduler/internal/handlers"
+)
+
+func newVersionedRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	sunset := time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC)
+	v1 := router.Group("/api/v1", handlers.APIVersion(1, &handlers.VersionDeprecation{Sunset: sunset, Successor: "/api/v2"}))
+	v1.GET("/ping", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })
+
+	v2 := router.Group("/api/v2", handlers.APIVersion(2, nil))
+	v2.GET("/ping", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })
+
+	return router
+}
+
+func TestAPIVersion_DeprecatedVersionHeaders(t *testing.T) {
+	router := newVersionedRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/ping", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get("API-Version"))
+	assert.Equal(t, "true", w.Header().Get("Deprecation"))
+	assert.Equal(t, "Sun, 31 Jan 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+	assert.Equal(t, `</api/v2>; rel="successor-version"`, w.Header().Get("Link"))
+}
+
+func TestAPIVersion_ContentNegotiation(t *testing.T) {
+	router := newVersionedRouter()
+
+	// Matching vendor media type is served with that media type
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/ping", nil)
+	req.Header.Set("Accept", "application/vnd.job-scheduler.v2+json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("API-Version"))
+	assert.Empty(t, w.Header().Get("Deprecation"))
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/vnd.job-scheduler.v2+json")
+
+	// Asking v1 routes for v2 is not acceptable
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/ping", nil)
+	req.Header.Set("Accept", "application/vnd.job-scheduler.v2+json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotAcceptable, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"not_acceptable"`)
+}
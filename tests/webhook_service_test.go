@@ -0,0 +1,234 @@
+package tests
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/notifications"
+	"job-scheduler/internal/services"
+)
+
+// MockJobWebhookRepository is a mock implementation of JobWebhookRepository
+type MockJobWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockJobWebhookRepository) Create(webhook *models.JobWebhook) error {
+	args := m.Called(webhook)
+	return args.Error(0)
+}
+
+func (m *MockJobWebhookRepository) GetByID(id uuid.UUID) (*models.JobWebhook, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.JobWebhook), args.Error(1)
+}
+
+func (m *MockJobWebhookRepository) GetByToken(token string) (*models.JobWebhook, error) {
+	args := m.Called(token)
+	return args.Get(0).(*models.JobWebhook), args.Error(1)
+}
+
+func (m *MockJobWebhookRepository) GetByJobID(jobID uuid.UUID) ([]models.JobWebhook, error) {
+	args := m.Called(jobID)
+	return args.Get(0).([]models.JobWebhook), args.Error(1)
+}
+
+func (m *MockJobWebhookRepository) Delete(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockJobWebhookRepository) MarkTriggered(id uuid.UUID, at time.Time) error {
+	args := m.Called(id, at)
+	return args.Error(0)
+}
+
+// MockJobTrigger is a mock implementation of JobTrigger
+type MockJobTrigger struct {
+	mock.Mock
+}
+
+func (m *MockJobTrigger) TriggerJob(job *models.Job, params models.JobConfig) error {
+	args := m.Called(job, params)
+	return args.Error(0)
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newTestWebhook(limit int) (*models.JobWebhook, *models.Job) {
+	job := &models.Job{
+		ID:      uuid.New(),
+		Name:    "Webhook Job",
+		JobType: models.JobTypeDataProcessing,
+		State:   models.JobStateActive,
+	}
+	webhook := &models.JobWebhook{
+		ID:                 uuid.New(),
+		JobID:              job.ID,
+		Token:              "token123",
+		Secret:             "s3cret",
+		SignatureHeader:    "X-Hub-Signature-256",
+		SignatureScheme:    models.WebhookSignatureSchemeHex,
+		RateLimitPerMinute: limit,
+		IsActive:           true,
+	}
+	return webhook, job
+}
+
+func TestWebhookService_HandleInbound(t *testing.T) {
+	// Setup
+	mockWebhookRepo := new(MockJobWebhookRepository)
+	mockJobRepo := new(MockJobRepository)
+	mockTrigger := new(MockJobTrigger)
+	webhookService := services.NewWebhookService(mockWebhookRepo, mockJobRepo, mockTrigger)
+
+	webhook, job := newTestWebhook(10)
+	body := []byte(`{"ref": "refs/heads/main"}`)
+
+	headers := http.Header{}
+	headers.Set("X-Hub-Signature-256", sign(webhook.Secret, body))
+
+	// Mock expectations
+	mockWebhookRepo.On("GetByToken", "token123").Return(webhook, nil)
+	mockWebhookRepo.On("MarkTriggered", webhook.ID, mock.Anything).Return(nil)
+	mockJobRepo.On("GetByID", job.ID).Return(job, nil)
+	mockTrigger.On("TriggerJob", job, models.JobConfig{"ref": "refs/heads/main"}).Return(nil)
+
+	// Execute
+	result, awaiting, err := webhookService.HandleInbound("token123", headers, body)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, webhook.ID, result.ID)
+	assert.Nil(t, awaiting)
+	mockTrigger.AssertExpectations(t)
+}
+
+func TestWebhookService_HandleInbound_InactiveJob(t *testing.T) {
+	for _, state := range []models.JobState{models.JobStatePaused, models.JobStateDisabled, models.JobStateArchived} {
+		t.Run(string(state), func(t *testing.T) {
+			// Setup
+			mockWebhookRepo := new(MockJobWebhookRepository)
+			mockJobRepo := new(MockJobRepository)
+			mockTrigger := new(MockJobTrigger)
+			webhookService := services.NewWebhookService(mockWebhookRepo, mockJobRepo, mockTrigger)
+
+			webhook, job := newTestWebhook(10)
+			job.State = state
+			body := []byte(`{}`)
+			headers := http.Header{}
+			headers.Set("X-Hub-Signature-256", sign(webhook.Secret, body))
+
+			mockWebhookRepo.On("GetByToken", "token123").Return(webhook, nil)
+			mockJobRepo.On("GetByID", job.ID).Return(job, nil)
+
+			// Execute
+			_, _, err := webhookService.HandleInbound("token123", headers, body)
+
+			// Assert
+			assert.ErrorIs(t, err, services.ErrWebhookJobInactive)
+			mockTrigger.AssertNotCalled(t, "TriggerJob", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestWebhookService_HandleInbound_RequiresApproval(t *testing.T) {
+	// Setup
+	mockWebhookRepo := new(MockJobWebhookRepository)
+	mockJobRepo := new(MockJobRepository)
+	mockTrigger := new(MockJobTrigger)
+	webhookService := services.NewWebhookService(mockWebhookRepo, mockJobRepo, mockTrigger)
+
+	webhook, job := newTestWebhook(10)
+	job.RequiresApproval = true
+	body := []byte(`{"ref": "refs/heads/main"}`)
+	headers := http.Header{}
+	headers.Set("X-Hub-Signature-256", sign(webhook.Secret, body))
+
+	mockWebhookRepo.On("GetByToken", "token123").Return(webhook, nil)
+	mockWebhookRepo.On("MarkTriggered", webhook.ID, mock.Anything).Return(nil)
+	mockJobRepo.On("GetByID", job.ID).Return(job, nil)
+
+	// Execute - without approvals the call is refused, with them the run waits for approval
+	_, _, refusedErr := webhookService.HandleInbound("token123", headers, body)
+	runs := newMemoryApprovalRuns()
+	notifier := &lockedNotifier{}
+	webhookService.SetApprovalService(newApprovalService(runs, job, &approvedRuns{}, notifier, time.Hour))
+	_, awaiting, err := webhookService.HandleInbound("token123", headers, body)
+
+	// Assert
+	assert.Error(t, refusedErr)
+	require.NoError(t, err)
+	require.NotNil(t, awaiting)
+	stored, err := runs.GetByID(awaiting.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusAwaitingApproval, stored.Status)
+	assert.Equal(t, models.JobConfig{"ref": "refs/heads/main"}, stored.Parameters)
+	assert.Equal(t, []notifications.Event{notifications.EventApprovalRequested}, notifier.events())
+	mockTrigger.AssertNotCalled(t, "TriggerJob", mock.Anything, mock.Anything)
+}
+
+func TestWebhookService_HandleInbound_InvalidSignature(t *testing.T) {
+	// Setup
+	mockWebhookRepo := new(MockJobWebhookRepository)
+	mockJobRepo := new(MockJobRepository)
+	mockTrigger := new(MockJobTrigger)
+	webhookService := services.NewWebhookService(mockWebhookRepo, mockJobRepo, mockTrigger)
+
+	webhook, _ := newTestWebhook(10)
+	body := []byte(`{}`)
+
+	headers := http.Header{}
+	headers.Set("X-Hub-Signature-256", sign("wrong-secret", body))
+
+	mockWebhookRepo.On("GetByToken", "token123").Return(webhook, nil)
+
+	// Execute
+	_, _, err := webhookService.HandleInbound("token123", headers, body)
+
+	// Assert
+	assert.ErrorIs(t, err, services.ErrInvalidSignature)
+	mockTrigger.AssertNotCalled(t, "TriggerJob")
+}
+
+func TestWebhookService_HandleInbound_RateLimited(t *testing.T) {
+	// Setup
+	mockWebhookRepo := new(MockJobWebhookRepository)
+	mockJobRepo := new(MockJobRepository)
+	mockTrigger := new(MockJobTrigger)
+	webhookService := services.NewWebhookService(mockWebhookRepo, mockJobRepo, mockTrigger)
+
+	webhook, job := newTestWebhook(1)
+	body := []byte(`{}`)
+
+	headers := http.Header{}
+	headers.Set("X-Hub-Signature-256", sign(webhook.Secret, body))
+
+	mockWebhookRepo.On("GetByToken", "token123").Return(webhook, nil)
+	mockWebhookRepo.On("MarkTriggered", webhook.ID, mock.Anything).Return(nil)
+	mockJobRepo.On("GetByID", job.ID).Return(job, nil)
+	mockTrigger.On("TriggerJob", job, models.JobConfig{}).Return(nil)
+
+	// Execute - second call within the same minute is rejected
+	_, _, err := webhookService.HandleInbound("token123", headers, body)
+	assert.NoError(t, err)
+
+	_, _, err = webhookService.HandleInbound("token123", headers, body)
+	assert.ErrorIs(t, err, services.ErrRateLimited)
+
+	mockTrigger.AssertNumberOfCalls(t, "TriggerJob", 1)
+}
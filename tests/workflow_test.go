@@ -0,0 +1,680 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"job-scheduler/internal/handlers"
+	"job-scheduler/internal/models"
+	"job-scheduler/internal/services"
+)
+
+// memoryWorkflowRepository keeps workflows and copies of their executions in memory
+type memoryWorkflowRepository struct {
+	mu         sync.Mutex
+	workflows  map[uuid.UUID]*models.Workflow
+	executions map[uuid.UUID]models.WorkflowExecution
+}
+
+func newMemoryWorkflowRepository() *memoryWorkflowRepository {
+	return &memoryWorkflowRepository{
+		workflows:  make(map[uuid.UUID]*models.Workflow),
+		executions: make(map[uuid.UUID]models.WorkflowExecution),
+	}
+}
+
+func (r *memoryWorkflowRepository) Create(workflow *models.Workflow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.workflows[workflow.ID] = workflow
+	return nil
+}
+
+func (r *memoryWorkflowRepository) GetByID(id uuid.UUID) (*models.Workflow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	workflow, ok := r.workflows[id]
+	if !ok {
+		return nil, fmt.Errorf("workflow with ID %s not found", id)
+	}
+	return workflow, nil
+}
+
+func (r *memoryWorkflowRepository) FindByName(name string) (*models.Workflow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, workflow := range r.workflows {
+		if workflow.Name == name {
+			return workflow, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryWorkflowRepository) GetAll() ([]models.Workflow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var workflows []models.Workflow
+	for _, workflow := range r.workflows {
+		workflows = append(workflows, *workflow)
+	}
+	return workflows, nil
+}
+
+func (r *memoryWorkflowRepository) Update(workflow *models.Workflow) error {
+	return r.Create(workflow)
+}
+
+func (r *memoryWorkflowRepository) Delete(id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.workflows, id)
+	return nil
+}
+
+func (r *memoryWorkflowRepository) CreateExecution(execution *models.WorkflowExecution) error {
+	return r.UpdateExecution(execution)
+}
+
+func (r *memoryWorkflowRepository) GetExecutionByID(id uuid.UUID) (*models.WorkflowExecution, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	execution, ok := r.executions[id]
+	if !ok {
+		return nil, fmt.Errorf("workflow execution with ID %s not found", id)
+	}
+	return &execution, nil
+}
+
+func (r *memoryWorkflowRepository) GetExecutionsByWorkflowID(workflowID uuid.UUID, limit int) ([]models.WorkflowExecution, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var executions []models.WorkflowExecution
+	for _, execution := range r.executions {
+		if execution.WorkflowID == workflowID {
+			executions = append(executions, execution)
+		}
+	}
+	return executions, nil
+}
+
+func (r *memoryWorkflowRepository) UpdateExecution(execution *models.WorkflowExecution) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *execution
+	stored.Nodes = make(models.WorkflowNodeStates, len(execution.Nodes))
+	for key, state := range execution.Nodes {
+		stateCopy := *state
+		stored.Nodes[key] = &stateCopy
+	}
+	r.executions[execution.ID] = stored
+	return nil
+}
+
+// stubNodeRunner records the nodes it runs and their parameters, failing the jobs it's told to and
+// returning the outputs it's given
+type stubNodeRunner struct {
+	mu      sync.Mutex
+	fail    map[uuid.UUID]bool
+	outputs map[uuid.UUID]models.ExecutionOutput
+	order   []string
+	params  map[string]models.JobConfig
+}
+
+func (r *stubNodeRunner) RunWorkflowNode(job *models.Job, params models.JobConfig) (models.ExecutionOutput, error) {
+	r.mu.Lock()
+	key := params["workflow_node"].(string)
+	r.order = append(r.order, key)
+	if r.params == nil {
+		r.params = make(map[string]models.JobConfig)
+	}
+	r.params[key] = params
+	r.mu.Unlock()
+	if r.fail[job.ID] {
+		return nil, fmt.Errorf("job %s failed", job.Name)
+	}
+	return r.outputs[job.ID], nil
+}
+
+func (r *stubNodeRunner) ran() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.order...)
+}
+
+// diamondGraph fans out from extract to two transforms that fan back in to load, with an
+// unrelated report branch
+func diamondGraph(jobs map[string]*models.Job) models.WorkflowGraph {
+	graph := models.WorkflowGraph{
+		Edges: []models.WorkflowEdge{
+			{From: "extract", To: "transform-a"},
+			{From: "extract", To: "transform-b"},
+			{From: "transform-a", To: "load"},
+			{From: "transform-b", To: "load"},
+		},
+	}
+	for _, key := range []string{"extract", "transform-a", "transform-b", "load", "report"} {
+		graph.Nodes = append(graph.Nodes, models.WorkflowNode{Key: key, JobID: jobs[key].ID})
+	}
+	return graph
+}
+
+func TestWorkflowGraph_Validate(t *testing.T) {
+	a := models.WorkflowNode{Key: "a", JobID: uuid.New()}
+	b := models.WorkflowNode{Key: "b", JobID: uuid.New()}
+	c := models.WorkflowNode{Key: "c", JobID: uuid.New()}
+
+	testCases := []struct {
+		name    string
+		graph   models.WorkflowGraph
+		wantErr string
+	}{
+		{
+			name:  "valid chain",
+			graph: models.WorkflowGraph{Nodes: []models.WorkflowNode{a, b, c}, Edges: []models.WorkflowEdge{{From: "a", To: "b"}, {From: "b", To: "c"}}},
+		},
+		{name: "no nodes", graph: models.WorkflowGraph{}, wantErr: "at least one node"},
+		{name: "duplicate key", graph: models.WorkflowGraph{Nodes: []models.WorkflowNode{a, a}}, wantErr: "duplicate node key"},
+		{name: "invalid key", graph: models.WorkflowGraph{Nodes: []models.WorkflowNode{{Key: "Load Data", JobID: uuid.New()}}}, wantErr: "invalid node key"},
+		{name: "missing job", graph: models.WorkflowGraph{Nodes: []models.WorkflowNode{{Key: "a"}}}, wantErr: "no job_id"},
+		{
+			name:    "unknown node",
+			graph:   models.WorkflowGraph{Nodes: []models.WorkflowNode{a}, Edges: []models.WorkflowEdge{{From: "a", To: "z"}}},
+			wantErr: "unknown node",
+		},
+		{
+			name:    "self edge",
+			graph:   models.WorkflowGraph{Nodes: []models.WorkflowNode{a}, Edges: []models.WorkflowEdge{{From: "a", To: "a"}}},
+			wantErr: "depend on itself",
+		},
+		{
+			name:    "reserved param",
+			graph:   models.WorkflowGraph{Nodes: []models.WorkflowNode{a, b}, Edges: []models.WorkflowEdge{{From: "a", To: "b", Params: map[string]string{"workflow_node": "$.key"}}}},
+			wantErr: "invalid parameter name",
+		},
+		{
+			name:    "invalid param path",
+			graph:   models.WorkflowGraph{Nodes: []models.WorkflowNode{a, b}, Edges: []models.WorkflowEdge{{From: "a", To: "b", Params: map[string]string{"file": "path"}}}},
+			wantErr: "must start with $",
+		},
+		{
+			name: "param mapped twice",
+			graph: models.WorkflowGraph{Nodes: []models.WorkflowNode{a, b, c}, Edges: []models.WorkflowEdge{
+				{From: "a", To: "c", Params: map[string]string{"file": "$.path"}},
+				{From: "b", To: "c", Params: map[string]string{"file": "$.path"}},
+			}},
+			wantErr: "mapped from both",
+		},
+		{
+			name:    "cycle",
+			graph:   models.WorkflowGraph{Nodes: []models.WorkflowNode{a, b, c}, Edges: []models.WorkflowEdge{{From: "a", To: "b"}, {From: "b", To: "c"}, {From: "c", To: "a"}}},
+			wantErr: "cycle",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.graph.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
+func TestWorkflowGraph_TopologicalOrder(t *testing.T) {
+	jobs := make(map[string]*models.Job)
+	for _, key := range []string{"extract", "transform-a", "transform-b", "load", "report"} {
+		jobs[key] = &models.Job{ID: uuid.New(), Name: key}
+	}
+
+	order := diamondGraph(jobs).TopologicalOrder()
+
+	assert.Equal(t, []string{"extract", "report", "transform-a", "transform-b", "load"}, order)
+}
+
+// runWorkflow triggers a workflow over the diamond graph and waits for it to finish
+func runWorkflow(t *testing.T, failing ...string) (*models.WorkflowExecution, []string) {
+	jobs := make(map[string]*models.Job)
+	mockJobRepo := new(MockJobRepository)
+	for _, key := range []string{"extract", "transform-a", "transform-b", "load", "report"} {
+		jobs[key] = &models.Job{ID: uuid.New(), Name: key}
+		mockJobRepo.On("GetByID", jobs[key].ID).Return(jobs[key], nil)
+	}
+	runner := &stubNodeRunner{fail: make(map[uuid.UUID]bool)}
+	for _, key := range failing {
+		runner.fail[jobs[key].ID] = true
+	}
+
+	repo := newMemoryWorkflowRepository()
+	service := services.NewWorkflowService(repo, mockJobRepo, new(MockJobExecutionRepository), runner)
+	workflow, err := service.CreateWorkflow(&models.CreateWorkflowRequest{Name: "nightly-etl", Graph: diamondGraph(jobs)})
+	require.NoError(t, err)
+
+	started, err := service.TriggerWorkflow(workflow.ID, models.JobConfig{"date": "2026-10-16"}, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, models.WorkflowExecutionStatusRunning, started.Status)
+	assert.Equal(t, "alice", started.TriggeredBy)
+
+	var execution *models.WorkflowExecution
+	require.Eventually(t, func() bool {
+		execution, err = service.GetWorkflowExecution(started.ID)
+		return err == nil && execution.CompletedAt != nil
+	}, 2*time.Second, 10*time.Millisecond)
+	return execution, runner.ran()
+}
+
+// indexOf returns where a node appears in the run order, or -1
+func indexOf(order []string, key string) int {
+	for i, k := range order {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestWorkflowService_TriggerWorkflowRunsFanOutAndFanIn(t *testing.T) {
+	execution, order := runWorkflow(t)
+
+	assert.Equal(t, models.WorkflowExecutionStatusCompleted, execution.Status)
+	assert.Len(t, order, 5)
+	for key, state := range execution.Nodes {
+		assert.Equal(t, models.WorkflowNodeStatusCompleted, state.Status, key)
+	}
+	// load fans in: it runs only after both transforms
+	assert.Greater(t, indexOf(order, "load"), indexOf(order, "transform-a"))
+	assert.Greater(t, indexOf(order, "load"), indexOf(order, "transform-b"))
+	assert.Less(t, indexOf(order, "extract"), indexOf(order, "transform-a"))
+}
+
+func TestWorkflowService_TriggerWorkflowSkipsDownstreamOfFailure(t *testing.T) {
+	execution, order := runWorkflow(t, "transform-a")
+
+	assert.Equal(t, models.WorkflowExecutionStatusFailed, execution.Status)
+	assert.Equal(t, models.WorkflowNodeStatusFailed, execution.Nodes["transform-a"].Status)
+	assert.Contains(t, execution.Nodes["transform-a"].Error, "failed")
+	// The sibling branch and the unrelated branch still run
+	assert.Equal(t, models.WorkflowNodeStatusCompleted, execution.Nodes["transform-b"].Status)
+	assert.Equal(t, models.WorkflowNodeStatusCompleted, execution.Nodes["report"].Status)
+	// The node fanning in from the failure never runs
+	assert.Equal(t, models.WorkflowNodeStatusSkipped, execution.Nodes["load"].Status)
+	assert.Equal(t, `upstream node "transform-a" failed`, execution.Nodes["load"].Error)
+	assert.Equal(t, -1, indexOf(order, "load"))
+}
+
+func TestWorkflowService_TriggerInactiveWorkflow(t *testing.T) {
+	job := &models.Job{ID: uuid.New(), Name: "only"}
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetByID", job.ID).Return(job, nil)
+	inactive := false
+
+	service := services.NewWorkflowService(newMemoryWorkflowRepository(), mockJobRepo, new(MockJobExecutionRepository), &stubNodeRunner{})
+	workflow, err := service.CreateWorkflow(&models.CreateWorkflowRequest{
+		Name:     "paused",
+		Graph:    models.WorkflowGraph{Nodes: []models.WorkflowNode{{Key: "only", JobID: job.ID}}},
+		IsActive: &inactive,
+	})
+	require.NoError(t, err)
+
+	_, err = service.TriggerWorkflow(workflow.ID, nil, "alice")
+
+	assert.ErrorIs(t, err, services.ErrWorkflowInactive)
+}
+
+func TestWorkflowService_CreateWorkflowRejectsDuplicateName(t *testing.T) {
+	job := &models.Job{ID: uuid.New(), Name: "only"}
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetByID", job.ID).Return(job, nil)
+	req := &models.CreateWorkflowRequest{
+		Name:  "nightly",
+		Graph: models.WorkflowGraph{Nodes: []models.WorkflowNode{{Key: "only", JobID: job.ID}}},
+	}
+
+	service := services.NewWorkflowService(newMemoryWorkflowRepository(), mockJobRepo, new(MockJobExecutionRepository), &stubNodeRunner{})
+	_, err := service.CreateWorkflow(req)
+	require.NoError(t, err)
+
+	_, err = service.CreateWorkflow(req)
+
+	assert.ErrorIs(t, err, services.ErrWorkflowExists)
+}
+
+func TestJSONPath_Lookup(t *testing.T) {
+	document := map[string]interface{}{
+		"path":      "/reports/daily.csv",
+		"files":     []interface{}{map[string]interface{}{"name": "a.csv"}, map[string]interface{}{"name": "b.csv"}},
+		"row count": float64(12),
+	}
+
+	testCases := []struct {
+		expr    string
+		want    interface{}
+		found   bool
+		wantErr string
+	}{
+		{expr: "$", want: document, found: true},
+		{expr: "$.path", want: "/reports/daily.csv", found: true},
+		{expr: "$.files[1].name", want: "b.csv", found: true},
+		{expr: "$['row count']", want: float64(12), found: true},
+		{expr: "$.files[2].name"},
+		{expr: "$.missing"},
+		{expr: "path", wantErr: "must start with $"},
+		{expr: "$.files[-1]", wantErr: "not an array index"},
+		{expr: "$..path", wantErr: "empty field name"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			path, err := models.ParseJSONPath(tc.expr)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			value, found := path.Lookup(document)
+			assert.Equal(t, tc.found, found)
+			assert.Equal(t, tc.want, value)
+		})
+	}
+}
+
+func TestWorkflowService_TriggerWorkflowMapsOutputToDownstreamParams(t *testing.T) {
+	// Setup - extract's output feeds load's parameters
+	extract := &models.Job{ID: uuid.New(), Name: "extract"}
+	load := &models.Job{ID: uuid.New(), Name: "load"}
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetByID", extract.ID).Return(extract, nil)
+	mockJobRepo.On("GetByID", load.ID).Return(load, nil)
+	runner := &stubNodeRunner{outputs: map[uuid.UUID]models.ExecutionOutput{
+		extract.ID: {"rows_written": 42, "stages": []services.PipelineStageStats{{Stage: "sink", Type: "file"}}},
+	}}
+
+	service := services.NewWorkflowService(newMemoryWorkflowRepository(), mockJobRepo, new(MockJobExecutionRepository), runner)
+	workflow, err := service.CreateWorkflow(&models.CreateWorkflowRequest{
+		Name: "handoff",
+		Graph: models.WorkflowGraph{
+			Nodes: []models.WorkflowNode{{Key: "extract", JobID: extract.ID}, {Key: "load", JobID: load.ID}},
+			Edges: []models.WorkflowEdge{{From: "extract", To: "load", Params: map[string]string{
+				"expected_rows": "$.rows_written",
+				"sink_type":     "$.stages[0].type",
+			}}},
+		},
+	})
+	require.NoError(t, err)
+
+	// Execute
+	started, err := service.TriggerWorkflow(workflow.ID, models.JobConfig{"date": "2026-10-16"}, "alice")
+	require.NoError(t, err)
+
+	// Assert - the values are decoded from JSON, as a stored run's output would be
+	var execution *models.WorkflowExecution
+	require.Eventually(t, func() bool {
+		execution, err = service.GetWorkflowExecution(started.ID)
+		return err == nil && execution.CompletedAt != nil
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, models.WorkflowExecutionStatusCompleted, execution.Status)
+	params := runner.params["load"]
+	assert.Equal(t, float64(42), params["expected_rows"])
+	assert.Equal(t, "file", params["sink_type"])
+	assert.Equal(t, "2026-10-16", params["date"])
+}
+
+func TestWorkflowService_TriggerWorkflowFailsNodeWithUnmappableParam(t *testing.T) {
+	// Setup - extract records no output
+	extract := &models.Job{ID: uuid.New(), Name: "extract"}
+	load := &models.Job{ID: uuid.New(), Name: "load"}
+	mockJobRepo := new(MockJobRepository)
+	mockJobRepo.On("GetByID", extract.ID).Return(extract, nil)
+	mockJobRepo.On("GetByID", load.ID).Return(load, nil)
+	runner := &stubNodeRunner{}
+
+	service := services.NewWorkflowService(newMemoryWorkflowRepository(), mockJobRepo, new(MockJobExecutionRepository), runner)
+	workflow, err := service.CreateWorkflow(&models.CreateWorkflowRequest{
+		Name: "handoff",
+		Graph: models.WorkflowGraph{
+			Nodes: []models.WorkflowNode{{Key: "extract", JobID: extract.ID}, {Key: "load", JobID: load.ID}},
+			Edges: []models.WorkflowEdge{{From: "extract", To: "load", Params: map[string]string{"file": "$.path"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	// Execute
+	started, err := service.TriggerWorkflow(workflow.ID, nil, "alice")
+	require.NoError(t, err)
+
+	// Assert - load fails without running
+	var execution *models.WorkflowExecution
+	require.Eventually(t, func() bool {
+		execution, err = service.GetWorkflowExecution(started.ID)
+		return err == nil && execution.CompletedAt != nil
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, models.WorkflowNodeStatusFailed, execution.Nodes["load"].Status)
+	assert.Equal(t, `parameter "file": the output of node "extract" has no value at $.path`, execution.Nodes["load"].Error)
+	assert.Equal(t, []string{"extract"}, runner.ran())
+}
+
+func TestWorkflowHandler_GetWorkflowRunShowsWhereItStalled(t *testing.T) {
+	// Setup - a run whose transform-a node is on its second attempt, so load is still waiting
+	jobs := make(map[string]*models.Job)
+	mockJobRepo := new(MockJobRepository)
+	for _, key := range []string{"extract", "transform-a", "transform-b", "load", "report"} {
+		jobs[key] = &models.Job{ID: uuid.New(), Name: key}
+		mockJobRepo.On("GetByID", jobs[key].ID).Return(jobs[key], nil)
+	}
+	repo := newMemoryWorkflowRepository()
+	executionRepo := new(MockJobExecutionRepository)
+	service := services.NewWorkflowService(repo, mockJobRepo, executionRepo, &stubNodeRunner{})
+	workflow, err := service.CreateWorkflow(&models.CreateWorkflowRequest{Name: "nightly-etl", Graph: diamondGraph(jobs)})
+	require.NoError(t, err)
+
+	startedAt := time.Now().UTC().Add(-time.Hour)
+	finishedAt := startedAt.Add(10 * time.Minute)
+	node := func(key string, status models.WorkflowNodeStatus) *models.WorkflowNodeState {
+		state := &models.WorkflowNodeState{Status: status, JobID: jobs[key].ID}
+		if status != models.WorkflowNodeStatusPending {
+			state.StartedAt = &startedAt
+		}
+		if status.IsFinished() {
+			state.CompletedAt = &finishedAt
+		}
+		return state
+	}
+	// The execution predates runs keeping their graph, so the workflow's graph is used
+	execution := &models.WorkflowExecution{
+		ID:         uuid.New(),
+		WorkflowID: workflow.ID,
+		Status:     models.WorkflowExecutionStatusRunning,
+		Nodes: models.WorkflowNodeStates{
+			"extract":     node("extract", models.WorkflowNodeStatusCompleted),
+			"transform-a": node("transform-a", models.WorkflowNodeStatusRunning),
+			"transform-b": node("transform-b", models.WorkflowNodeStatusCompleted),
+			"load":        node("load", models.WorkflowNodeStatusPending),
+			"report":      node("report", models.WorkflowNodeStatusCompleted),
+		},
+		StartedAt: startedAt,
+	}
+	require.NoError(t, repo.CreateExecution(execution))
+
+	params := models.JobConfig{"workflow_execution_id": execution.ID.String(), "workflow_node": "transform-a"}
+	firstAttempt := models.JobExecution{ID: uuid.New(), JobID: jobs["transform-a"].ID, Status: models.ExecutionStatusFailed, Parameters: params, Attempt: 1, StartedAt: startedAt}
+	secondAttempt := models.JobExecution{ID: uuid.New(), JobID: jobs["transform-a"].ID, Status: models.ExecutionStatusRunning, Parameters: params, Attempt: 2, StartedAt: finishedAt}
+	executionRepo.On("GetByWorkflowExecution", execution.ID).Return([]models.JobExecution{firstAttempt, secondAttempt}, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handlers.NewWorkflowHandler(service).RegisterRoutes(router.Group("/api/v1"))
+
+	// Execute
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/workflows/runs/"+execution.ID.String(), nil))
+
+	// Assert - nodes in the order they run, with the stalled node's attempts and what load waits on
+	require.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		WorkflowRun struct {
+			WorkflowName string `json:"workflow_name"`
+			DurationMs   int64  `json:"duration_ms"`
+			Nodes        []struct {
+				Key        string   `json:"key"`
+				Status     string   `json:"status"`
+				DurationMs *int64   `json:"duration_ms"`
+				DependsOn  []string `json:"depends_on"`
+				WaitingOn  []string `json:"waiting_on"`
+				Runs       []struct {
+					ID      uuid.UUID         `json:"id"`
+					Attempt int               `json:"attempt"`
+					Status  string            `json:"status"`
+					Links   map[string]string `json:"links"`
+				} `json:"runs"`
+			} `json:"nodes"`
+		} `json:"workflow_run"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	run := body.WorkflowRun
+	assert.Equal(t, "nightly-etl", run.WorkflowName)
+	assert.GreaterOrEqual(t, run.DurationMs, time.Hour.Milliseconds())
+
+	keys := make([]string, 0, len(run.Nodes))
+	for _, node := range run.Nodes {
+		keys = append(keys, node.Key)
+	}
+	require.Equal(t, []string{"extract", "report", "transform-a", "transform-b", "load"}, keys)
+
+	stalled := run.Nodes[2]
+	assert.Equal(t, "running", stalled.Status)
+	assert.Equal(t, []string{"extract"}, stalled.DependsOn)
+	if assert.Len(t, stalled.Runs, 2) {
+		assert.Equal(t, 2, stalled.Runs[1].Attempt)
+		assert.Equal(t, "running", stalled.Runs[1].Status)
+		assert.Equal(t, "/api/v1/executions/"+secondAttempt.ID.String()+"/logs", stalled.Runs[1].Links["logs"])
+	}
+	assert.Equal(t, []string{"transform-a"}, run.Nodes[4].WaitingOn)
+	assert.Nil(t, run.Nodes[4].DurationMs, "load hasn't started")
+	assert.Empty(t, run.Nodes[0].Runs)
+
+	// A workflow run's ID isn't a workflow's
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/workflows/"+execution.ID.String(), nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestWorkflowGraph_CriticalPath(t *testing.T) {
+	jobs := make(map[string]*models.Job)
+	for _, key := range []string{"extract", "transform-a", "transform-b", "load", "report"} {
+		jobs[key] = &models.Job{ID: uuid.New(), Name: key}
+	}
+	durations := map[string]time.Duration{
+		"extract":     10 * time.Minute,
+		"transform-a": 30 * time.Minute,
+		"transform-b": 20 * time.Minute,
+		"load":        5 * time.Minute,
+		"report":      15 * time.Minute,
+	}
+	slack := func(path models.WorkflowCriticalPath) map[string]time.Duration {
+		slacks := make(map[string]time.Duration)
+		for _, node := range path.Nodes {
+			slacks[node.Key] = node.Slack
+		}
+		return slacks
+	}
+
+	// Without a deadline, slack is measured against the critical path
+	path := diamondGraph(jobs).CriticalPath(durations, 0)
+
+	assert.Equal(t, []string{"extract", "transform-a", "load"}, path.Path)
+	assert.Equal(t, 45*time.Minute, path.Duration)
+	assert.Zero(t, path.Overrun)
+	assert.Equal(t, map[string]time.Duration{
+		"extract":     0,
+		"transform-a": 0,
+		"transform-b": 10 * time.Minute,
+		"load":        0,
+		"report":      30 * time.Minute,
+	}, slack(path))
+	load := path.Nodes[4]
+	assert.Equal(t, "load", load.Key)
+	assert.Equal(t, 40*time.Minute, load.EarliestStart)
+	assert.True(t, load.Critical)
+
+	// With a deadline the critical path misses, its nodes have negative slack
+	path = diamondGraph(jobs).CriticalPath(durations, 40*time.Minute)
+
+	assert.Equal(t, 5*time.Minute, path.Overrun)
+	assert.Equal(t, -5*time.Minute, slack(path)["transform-a"])
+	assert.Equal(t, 5*time.Minute, slack(path)["transform-b"])
+	assert.True(t, path.Nodes[2].Critical)
+	assert.False(t, path.Nodes[3].Critical)
+}
+
+func TestWorkflowService_GetWorkflowCriticalPathEstimatesUnfinishedNodes(t *testing.T) {
+	// Setup - extract finished in 10 minutes, a single transform has run for 20 so far, load hasn't started
+	mockJobRepo := new(MockJobRepository)
+	jobs := make(map[string]*models.Job)
+	for _, key := range []string{"extract", "transform", "load"} {
+		jobs[key] = &models.Job{ID: uuid.New(), Name: key}
+		mockJobRepo.On("GetByID", jobs[key].ID).Return(jobs[key], nil)
+	}
+	graph := models.WorkflowGraph{
+		Nodes: []models.WorkflowNode{
+			{Key: "extract", JobID: jobs["extract"].ID},
+			{Key: "transform", JobID: jobs["transform"].ID},
+			{Key: "load", JobID: jobs["load"].ID},
+		},
+		Edges: []models.WorkflowEdge{{From: "extract", To: "transform"}, {From: "transform", To: "load"}},
+	}
+	repo := newMemoryWorkflowRepository()
+	executionRepo := new(MockJobExecutionRepository)
+	service := services.NewWorkflowService(repo, mockJobRepo, executionRepo, &stubNodeRunner{})
+	workflow, err := service.CreateWorkflow(&models.CreateWorkflowRequest{Name: "etl", Graph: graph})
+	require.NoError(t, err)
+
+	startedAt := time.Now().UTC().Add(-30 * time.Minute)
+	extractDone := startedAt.Add(10 * time.Minute)
+	execution := &models.WorkflowExecution{
+		ID:         uuid.New(),
+		WorkflowID: workflow.ID,
+		Status:     models.WorkflowExecutionStatusRunning,
+		Graph:      graph,
+		Nodes: models.WorkflowNodeStates{
+			"extract":   {Status: models.WorkflowNodeStatusCompleted, JobID: jobs["extract"].ID, StartedAt: &startedAt, CompletedAt: &extractDone},
+			"transform": {Status: models.WorkflowNodeStatusRunning, JobID: jobs["transform"].ID, StartedAt: &extractDone},
+			"load":      {Status: models.WorkflowNodeStatusPending, JobID: jobs["load"].ID},
+		},
+		StartedAt: startedAt,
+	}
+	require.NoError(t, repo.CreateExecution(execution))
+	fiveMinutes, tenMinutes := (5 * time.Minute).Milliseconds(), (10 * time.Minute).Milliseconds()
+	executionRepo.On("GetExecutionStats", jobs["transform"].ID).Return(&models.JobExecutionStats{AverageExecutionTime: &tenMinutes}, nil)
+	executionRepo.On("GetExecutionStats", jobs["load"].ID).Return(&models.JobExecutionStats{AverageExecutionTime: &fiveMinutes}, nil)
+
+	// Execute
+	path, err := service.GetWorkflowCriticalPath(execution.ID, time.Hour)
+
+	// Assert - the transform counts as the 20 minutes it has run, more than its average
+	require.NoError(t, err)
+	assert.Equal(t, []string{"extract", "transform", "load"}, path.Path)
+	require.Len(t, path.Nodes, 3)
+	assert.False(t, path.Nodes[0].Estimated)
+	assert.Equal(t, 10*time.Minute, path.Nodes[0].Duration)
+	assert.True(t, path.Nodes[1].Estimated)
+	assert.InDelta(t, float64(20*time.Minute), float64(path.Nodes[1].Duration), float64(time.Second))
+	assert.True(t, path.Nodes[2].Estimated)
+	assert.Equal(t, 5*time.Minute, path.Nodes[2].Duration)
+	assert.InDelta(t, float64(25*time.Minute), float64(path.Nodes[2].Slack), float64(time.Second))
+	assert.Zero(t, path.Overrun)
+}